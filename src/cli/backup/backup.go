@@ -38,6 +38,7 @@ var subCommandFuncs = []func() *cobra.Command{
 	listCmd,
 	detailsCmd,
 	deleteCmd,
+	searchCmd,
 }
 
 var serviceCommands = []func(cmd *cobra.Command) *cobra.Command{
@@ -59,10 +60,48 @@ func AddCommands(cmd *cobra.Command) {
 
 		for _, addBackupTo := range serviceCommands {
 			sc := addBackupTo(subCommand)
+			if sc == nil {
+				// not all services support every subcommand.
+				continue
+			}
+
 			flags.AddAllProviderFlags(sc)
 			flags.AddAllStorageFlags(sc)
 		}
 	}
+
+	// mounting works on any backup, regardless of service.
+	mountC, _ := utils.AddCommand(backupC, mountCmd())
+
+	flags.AddBackupIDFlag(mountC, true)
+	flags.AddMountFlags(mountC)
+	flags.AddAllProviderFlags(mountC)
+	flags.AddAllStorageFlags(mountC)
+
+	// labels apply to any backup, regardless of service.
+	labelC, _ := utils.AddCommand(backupC, labelCmd())
+
+	flags.AddBackupIDFlag(labelC, true)
+	flags.AddLabelFlag(labelC)
+	flags.AddRemoveLabelFlag(labelC)
+	flags.AddAllProviderFlags(labelC)
+	flags.AddAllStorageFlags(labelC)
+
+	// legal holds apply to any backup, regardless of service.
+	for _, c := range []struct {
+		cmd   *cobra.Command
+		place bool
+	}{
+		{holdCmd(), true},
+		{releaseCmd(), false},
+	} {
+		hc, _ := utils.AddCommand(backupC, c.cmd)
+
+		flags.AddMultipleBackupIDsFlag(hc, false)
+		flags.AddLegalHoldFlags(hc, c.place)
+		flags.AddAllProviderFlags(hc)
+		flags.AddAllStorageFlags(hc)
+	}
 }
 
 // ---------------------------------------------------------------------------
@@ -182,6 +221,10 @@ func genericCreateCommand(
 		errs = []error{}
 	)
 
+	if _, err := backup.ParseLabels(flags.LabelFV); err != nil {
+		return Only(ctx, clues.Wrap(err, "invalid --"+flags.LabelFN))
+	}
+
 	for _, discSel := range selectorSet {
 		discSel.Configure(defaultSelectorConfig)
 
@@ -295,6 +338,12 @@ func genericDeleteCommand(
 	defer utils.CloseRepo(ctx, r)
 
 	if err := r.DeleteBackups(ctx, true, bID...); err != nil {
+		if errors.Is(err, repository.ErrorBackupLegalHold) {
+			return Only(ctx, clues.New(fmt.Sprintf(
+				"Backup %v is under legal hold, and can't be deleted until the hold is released",
+				bID)))
+		}
+
 		return Only(ctx, clues.Wrap(err, fmt.Sprintf("Deleting backup %v", bID)))
 	}
 
@@ -317,6 +366,11 @@ func genericListCommand(
 		return nil
 	}
 
+	filters, err := utils.MakeBackupFilters(utils.MakeBackupFilterOpts())
+	if err != nil {
+		return Only(ctx, err)
+	}
+
 	r, _, err := utils.GetAccountAndConnect(ctx, cmd, service)
 	if err != nil {
 		return Only(ctx, err)
@@ -335,6 +389,7 @@ func genericListCommand(
 		}
 
 		b.Print(ctx)
+		printLegalHolds(ctx, b)
 		fe.PrintItems(
 			ctx,
 			!ifShow(flags.ListAlertsFV),
@@ -345,7 +400,7 @@ func genericListCommand(
 		return nil
 	}
 
-	bs, err := r.BackupsByTag(ctx, store.Service(service))
+	bs, err := r.BackupsByTag(ctx, append(filters, store.Service(service))...)
 	if err != nil {
 		return Only(ctx, clues.Wrap(err, "Failed to list backups in the repository"))
 	}
@@ -1,20 +1,30 @@
 package backup
 
 import (
+	"context"
 	"testing"
 
 	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/alcionai/corso/src/cli/flags"
+	flagsTD "github.com/alcionai/corso/src/cli/flags/testdata"
+	cliTD "github.com/alcionai/corso/src/cli/testdata"
+	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/cli/utils/testdata"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	dtd "github.com/alcionai/corso/src/pkg/backup/details/testdata"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/search"
 	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
 )
 
 type BackupUnitSuite struct {
@@ -95,3 +105,206 @@ func (suite *BackupUnitSuite) TestGenericDetailsCore_empty() {
 	require.Error(t, err, "has error")
 	assert.ErrorIs(t, err, ErrEmptyBackup, clues.ToCore(err))
 }
+
+type searchBackupGetter struct {
+	*testdata.MockBackupGetter
+	bups []*backup.Backup
+}
+
+func (bg searchBackupGetter) Backups(
+	context.Context,
+	[]string,
+) ([]*backup.Backup, *fault.Bus) {
+	return bg.bups, fault.New(true)
+}
+
+func (bg searchBackupGetter) BackupsByTag(
+	context.Context,
+	...store.FilterOption,
+) ([]*backup.Backup, error) {
+	return bg.bups, nil
+}
+
+func (suite *BackupUnitSuite) TestBackupsToSearch() {
+	odSel := selectors.NewOneDriveBackup([]string{"user-id"})
+	exSel := selectors.NewExchangeBackup([]string{"user-id"})
+
+	odBup := &backup.Backup{Selector: odSel.Selector}
+	odBup.ID = "od"
+
+	exBup := &backup.Backup{Selector: exSel.Selector}
+	exBup.ID = "ex"
+
+	table := []struct {
+		name      string
+		bups      []*backup.Backup
+		ids       []string
+		expect    []*backup.Backup
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "all backups",
+			bups:      []*backup.Backup{odBup},
+			expect:    []*backup.Backup{odBup},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "by id",
+			bups:      []*backup.Backup{odBup},
+			ids:       []string{"od"},
+			expect:    []*backup.Backup{odBup},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "other service",
+			bups:      []*backup.Backup{odBup, exBup},
+			ids:       []string{"od", "ex"},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			bups, err := backupsToSearch(
+				ctx,
+				searchBackupGetter{bups: test.bups},
+				path.OneDriveService,
+				test.ids)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, bups)
+		})
+	}
+}
+
+func (suite *BackupUnitSuite) TestSearchResult() {
+	t := suite.T()
+
+	sr := searchResult{
+		BackupID: "bid",
+		Item: search.Item{
+			ShortRef:    "sr",
+			LocationRef: "Inbox",
+			Name:        "subject",
+		},
+	}
+
+	assert.Equal(t, []string{"ID", "Backup ID", "Location", "Name"}, sr.Headers(false))
+	assert.Equal(t, []string{"sr", "bid", "Inbox", "subject"}, sr.Values(false))
+	assert.Equal(t, []string{"Backup ID", "Location", "Name"}, sr.Headers(true))
+	assert.Equal(t, []string{"bid", "Inbox", "subject"}, sr.Values(true))
+}
+
+func (suite *BackupUnitSuite) TestMountFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: "backup"},
+		func(c *cobra.Command) *cobra.Command {
+			mc, _ := utils.AddCommand(c, mountCmd())
+
+			flags.AddBackupIDFlag(mc, true)
+			flags.AddMountFlags(mc)
+
+			return mc
+		},
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			mountCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.BackupFN, flagsTD.BackupInput,
+				"--" + flags.WebDAVFN, ":8080",
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.Equal(t, flagsTD.BackupInput, flags.BackupIDFV)
+	assert.Equal(t, ":8080", flags.WebDAVFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
+func (suite *BackupUnitSuite) TestLabelFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: "backup"},
+		func(c *cobra.Command) *cobra.Command {
+			lc, _ := utils.AddCommand(c, labelCmd())
+
+			flags.AddBackupIDFlag(lc, true)
+			flags.AddLabelFlag(lc)
+			flags.AddRemoveLabelFlag(lc)
+
+			return lc
+		},
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			labelCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.BackupFN, flagsTD.BackupInput,
+				"--" + flags.LabelFN, flagsTD.FlgInputs(flagsTD.LabelInput),
+				"--" + flags.RemoveLabelFN, "old",
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.Equal(t, flagsTD.BackupInput, flags.BackupIDFV)
+	assert.ElementsMatch(t, flagsTD.LabelInput, flags.LabelFV)
+	assert.Equal(t, []string{"old"}, flags.RemoveLabelFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
+func (suite *BackupUnitSuite) TestLegalHoldFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: "backup"},
+		func(c *cobra.Command) *cobra.Command {
+			hc, _ := utils.AddCommand(c, holdCmd())
+
+			flags.AddMultipleBackupIDsFlag(hc, false)
+			flags.AddLegalHoldFlags(hc, true)
+
+			return hc
+		},
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			holdCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.BackupIDsFN, flagsTD.FlgInputs(flagsTD.BackupIDsInput),
+				"--" + flags.ResourceFN, flagsTD.ResourceInput,
+				"--" + flags.HoldNameFN, flagsTD.HoldNameInput,
+				"--" + flags.HoldReasonFN, flagsTD.HoldReasonInput,
+				"--" + flags.HoldCreatedByFN, flagsTD.HoldCreatedByInput,
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.ElementsMatch(t, flagsTD.BackupIDsInput, flags.BackupIDsFV)
+	assert.Equal(t, flagsTD.ResourceInput, flags.ResourceFV)
+	assert.Equal(t, flagsTD.HoldNameInput, flags.HoldNameFV)
+	assert.Equal(t, flagsTD.HoldReasonInput, flags.HoldReasonFV)
+	assert.Equal(t, flagsTD.HoldCreatedByInput, flags.HoldCreatedByFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
@@ -20,6 +20,7 @@ const (
 	dataContacts = "contacts"
 	dataEmail    = "email"
 	dataEvents   = "events"
+	dataSettings = "settings"
 )
 
 const (
@@ -36,6 +37,12 @@ corso backup create exchange --mailbox alice@example.com
 # Backup only Exchange contacts for Alice and Bob
 corso backup create exchange --mailbox alice@example.com,bob@example.com --data contacts
 
+# Backup only the mailbox settings (inbox rules, categories, automatic replies) for Alice
+corso backup create exchange --mailbox alice@example.com --data settings
+
+# Backup all Exchange data for Alice, labeled as the pre-migration backup
+corso backup create exchange --mailbox alice@example.com --label stage=pre-migration
+
 # Backup all Exchange data for all M365 users 
 corso backup create exchange --mailbox '*'`
 
@@ -56,7 +63,17 @@ corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
 
 # Explore contacts named Andy
 corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
-    --contact-name Andy`
+    --contact-name Andy
+
+# Explore only the mailbox settings in the backup
+corso backup details exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --settings-only`
+
+	exchangeServiceCommandSearchExamples = `# Search Alice's Exchange backups for emails mentioning Project X
+corso backup search exchange --query "project x"
+
+# Search a single backup (1234abcd...) for items mentioning the budget
+corso backup search exchange --backups 1234abcd-12ab-cd34-56de-1234abcd --query "budget*"`
 )
 
 // called by backup.go to map subcommands to provider-specific handling.
@@ -73,7 +90,7 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		// Flags addition ordering should follow the order we want them to appear in help and docs:
 		// More generic (ex: --user) and more frequently used flags take precedence.
 		flags.AddMailBoxFlag(c)
-		flags.AddDataFlag(c, []string{dataEmail, dataContacts, dataEvents}, false)
+		flags.AddDataFlag(c, []string{dataEmail, dataContacts, dataEvents, dataSettings}, false)
 		flags.AddFetchParallelismFlag(c)
 		flags.AddDisableDeltaFlag(c)
 		flags.AddEnableImmutableIDFlag(c)
@@ -86,6 +103,7 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddBackupIDFlag(c, false)
 		flags.AddAllBackupListFlags(c)
+		flags.AddBackupListFilterFlags(c)
 
 	case detailsCommand:
 		c, _ = utils.AddCommand(cmd, exchangeDetailsCmd())
@@ -108,6 +126,15 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddMultipleBackupIDsFlag(c, false)
 		flags.AddBackupIDFlag(c, false)
+
+	case searchCommand:
+		c, _ = utils.AddCommand(cmd, exchangeSearchCmd())
+
+		c.Use = c.Use + " " + searchServiceCommandUseSuffix
+		c.Example = exchangeServiceCommandSearchExamples
+
+		flags.AddSearchFlags(c)
+		flags.AddMultipleBackupIDsFlag(c, false)
 	}
 
 	return c
@@ -186,6 +213,7 @@ func exchangeBackupCreateSelectors(userIDs, cats []string) *selectors.ExchangeBa
 		sel.Include(sel.ContactFolders(selectors.Any()))
 		sel.Include(sel.MailFolders(selectors.Any()))
 		sel.Include(sel.EventCalendars(selectors.Any()))
+		sel.Include(sel.MailboxSettings(selectors.Any()))
 	}
 
 	for _, d := range cats {
@@ -196,6 +224,8 @@ func exchangeBackupCreateSelectors(userIDs, cats []string) *selectors.ExchangeBa
 			sel.Include(sel.MailFolders(selectors.Any()))
 		case dataEvents:
 			sel.Include(sel.EventCalendars(selectors.Any()))
+		case dataSettings:
+			sel.Include(sel.MailboxSettings(selectors.Any()))
 		}
 	}
 
@@ -208,9 +238,10 @@ func validateExchangeBackupCreateFlags(userIDs, cats []string) error {
 	}
 
 	for _, d := range cats {
-		if d != dataContacts && d != dataEmail && d != dataEvents {
+		if d != dataContacts && d != dataEmail && d != dataEvents && d != dataSettings {
 			return clues.New(
-				d + " is an unrecognized data type; must be one of " + dataContacts + ", " + dataEmail + ", or " + dataEvents)
+				d + " is an unrecognized data type; must be one of " +
+					dataContacts + ", " + dataEmail + ", " + dataEvents + ", or " + dataSettings)
 		}
 	}
 
@@ -271,6 +302,7 @@ func runDetailsExchangeCmd(cmd *cobra.Command) error {
 	sel := utils.IncludeExchangeRestoreDataSelectors(opts)
 	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
 	utils.FilterExchangeRestoreInfoSelectors(sel, opts)
+	utils.IncludeExchangeDetailsSettingsSelectors(sel, opts)
 
 	ds, err := genericDetailsCommand(cmd, flags.BackupIDFV, sel.Selector)
 	if err != nil {
@@ -314,3 +346,35 @@ func deleteExchangeCmd(cmd *cobra.Command, args []string) error {
 
 	return genericDeleteCommand(cmd, path.ExchangeService, "Exchange", backupIDValue, args)
 }
+
+// ------------------------------------------------------------------------------------------------
+// backup search
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup search exchange [<flag>...]`
+func exchangeSearchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     exchangeServiceCommand,
+		Short:   "Search the contents of M365 Exchange service backups",
+		RunE:    searchExchangeCmd,
+		Args:    cobra.NoArgs,
+		Example: exchangeServiceCommandSearchExamples,
+	}
+}
+
+// searches the contents of Exchange backups.
+func searchExchangeCmd(cmd *cobra.Command, args []string) error {
+	return genericSearchCommand(
+		cmd,
+		path.ExchangeService,
+		exchangeSearchSelector,
+		flags.BackupIDsFV,
+		flags.QueryFV)
+}
+
+func exchangeSearchSelector(protectedResource string) selectors.Selector {
+	sel := selectors.NewExchangeRestore([]string{protectedResource})
+	sel.Include(sel.AllData())
+
+	return sel.Selector
+}
@@ -64,6 +64,13 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 			expectShort: exchangeDeleteCmd().Short,
 			expectRunE:  deleteExchangeCmd,
 		},
+		{
+			name:        "search exchange",
+			use:         searchCommand,
+			expectUse:   expectUse + " " + searchServiceCommandUseSuffix,
+			expectShort: exchangeSearchCmd().Short,
+			expectRunE:  searchExchangeCmd,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -138,6 +145,7 @@ func (suite *ExchangeUnitSuite) TestBackupCreateFlags() {
 	assert.True(t, co.ToggleFeatures.DisableDelta)
 	assert.True(t, co.ToggleFeatures.ExchangeImmutableIDs)
 	assert.True(t, co.ToggleFeatures.DisableSlidingWindowLimiter)
+	assert.Equal(t, map[string]string{"stage": "pre-migration", "dept": "finance"}, co.Labels)
 
 	assert.ElementsMatch(t, flagsTD.MailboxInput, opts.Users)
 	flagsTD.AssertGenericBackupFlags(t, cmd)
@@ -226,6 +234,35 @@ func (suite *ExchangeUnitSuite) TestBackupDeleteFlags() {
 	flagsTD.AssertStorageFlags(t, cmd)
 }
 
+func (suite *ExchangeUnitSuite) TestBackupSearchFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: searchCommand},
+		addExchangeCommands,
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			exchangeServiceCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.BackupIDsFN, flagsTD.FlgInputs(flagsTD.BackupIDsInput),
+				"--" + flags.QueryFN, flagsTD.QueryInput,
+				"--" + flags.IndexDirFN, flagsTD.IndexDirInput,
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.ElementsMatch(t, flagsTD.BackupIDsInput, flags.BackupIDsFV)
+	assert.Equal(t, flagsTD.QueryInput, flags.QueryFV)
+	assert.Equal(t, flagsTD.IndexDirInput, flags.IndexDirFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
 func (suite *ExchangeUnitSuite) TestValidateBackupCreateFlags() {
 	table := []struct {
 		name       string
@@ -252,6 +289,12 @@ func (suite *ExchangeUnitSuite) TestValidateBackupCreateFlags() {
 			user:   []string{"fnord"},
 			expect: assert.NoError,
 		},
+		{
+			name:   "users and settings",
+			user:   []string{"fnord"},
+			data:   []string{dataSettings},
+			expect: assert.NoError,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -271,17 +314,23 @@ func (suite *ExchangeUnitSuite) TestExchangeBackupCreateSelectors() {
 	}{
 		{
 			name:             "default: one of each category, all None() matchers",
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name:             "any users, no data",
 			user:             []string{flags.Wildcard},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name:             "single user, no data",
 			user:             []string{"u1"},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
+		},
+		{
+			name:             "single user, settings",
+			user:             []string{"u1"},
+			data:             []string{dataSettings},
+			expectIncludeLen: 1,
 		},
 		{
 			name:             "any users, contacts",
@@ -41,8 +41,15 @@ corso backup create groups --group Marketing --data messages
 # Backup only group mailbox posts
 corso backup create groups --group Marketing --data conversations
 
+# Backup only the Planner plans, buckets and tasks in Marketing
+corso backup create groups --group Marketing --data planner
+
 # Backup all Groups and Teams data for all groups
-corso backup create groups --group '*'`
+corso backup create groups --group '*'
+
+# Backup Marketing, skipping disk images and any files larger than 1GB
+corso backup create groups --group Marketing \
+    --exclude-extension iso,img --max-file-size 1GB`
 
 	groupsServiceCommandDeleteExamples = `# Delete Groups backup with ID 1234abcd-12ab-cd34-56de-1234abcd \
 and 1234abcd-12ab-cd34-56de-1234abce
@@ -56,7 +63,17 @@ corso backup details groups --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --last-message-reply-after 2022-01-01T00:00:00
 
 # Explore group mailbox posts with conversation subject "hello world"
-corso backup details groups --backup 1234abcd-12ab-cd34-56de-1234abcd --conversation "hello world"`
+corso backup details groups --backup 1234abcd-12ab-cd34-56de-1234abcd --conversation "hello world"
+
+# Explore Planner tasks in the plan "Launch" that are due before the end of 2024
+corso backup details groups --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --plan Launch --task-due-before 2025-01-01T00:00:00`
+
+	groupsServiceCommandSearchExamples = `# Search all Groups backups for messages and files mentioning Project X
+corso backup search groups --query "project x"
+
+# Search a single backup (1234abcd...) for items mentioning the budget
+corso backup search groups --backups 1234abcd-12ab-cd34-56de-1234abcd --query "budget*"`
 )
 
 // called by backup.go to map subcommands to provider-specific handling.
@@ -72,17 +89,22 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 
 		// Flags addition ordering should follow the order we want them to appear in help and docs:
 		flags.AddGroupFlag(c)
-		flags.AddDataFlag(c, []string{flags.DataLibraries, flags.DataMessages, flags.DataConversations}, false)
+		flags.AddDataFlag(
+			c,
+			[]string{flags.DataLibraries, flags.DataMessages, flags.DataConversations, flags.DataPlanner},
+			false)
 		flags.AddFetchParallelismFlag(c)
 		flags.AddDisableDeltaFlag(c)
 		flags.AddGenericBackupFlags(c)
 		flags.AddDisableLazyItemReader(c)
+		flags.AddDriveExclusionFlags(c)
 
 	case listCommand:
 		c, _ = utils.AddCommand(cmd, groupsListCmd(), utils.MarkPreviewCommand())
 
 		flags.AddBackupIDFlag(c, false)
 		flags.AddAllBackupListFlags(c)
+		flags.AddBackupListFilterFlags(c)
 
 	case detailsCommand:
 		c, _ = utils.AddCommand(cmd, groupsDetailsCmd(), utils.MarkPreviewCommand())
@@ -106,6 +128,15 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddMultipleBackupIDsFlag(c, false)
 		flags.AddBackupIDFlag(c, false)
+
+	case searchCommand:
+		c, _ = utils.AddCommand(cmd, groupsSearchCmd(), utils.MarkPreviewCommand())
+
+		c.Use = c.Use + " " + searchServiceCommandUseSuffix
+		c.Example = groupsServiceCommandSearchExamples
+
+		flags.AddSearchFlags(c)
+		flags.AddMultipleBackupIDsFlag(c, false)
 	}
 
 	return c
@@ -142,6 +173,15 @@ func createGroupsCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	exclusions, err := utils.MakeDriveExclusions(
+		flags.ExcludeExtensionFV,
+		flags.ExcludeNameFV,
+		flags.ExcludePathFV,
+		flags.MaxFileSizeFV)
+	if err != nil {
+		return err
+	}
+
 	r, acct, err := utils.AccountConnectAndWriteRepoConfig(
 		ctx,
 		cmd,
@@ -166,6 +206,8 @@ func createGroupsCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	sel := groupsBackupCreateSelectors(ctx, ins, flags.GroupFV, flags.CategoryDataFV)
+	sel.SetDriveExclusions(exclusions)
+
 	selectorSet := []selectors.Selector{}
 
 	for _, discSel := range sel.SplitByResourceOwner(ins.IDs()) {
@@ -292,8 +334,8 @@ func validateGroupsBackupCreateFlags(groups, cats []string) error {
 	// TODO(keepers): release conversations support
 
 	msg := fmt.Sprintf(
-		" is an unrecognized data type; only %s and %s are supported",
-		flags.DataLibraries, flags.DataMessages)
+		" is an unrecognized data type; only %s, %s and %s are supported",
+		flags.DataLibraries, flags.DataMessages, flags.DataPlanner)
 
 	// msg := fmt.Sprintf(
 	// 	" is an unrecognized data type; only %s, %s and %s are supported",
@@ -327,3 +369,35 @@ func groupsBackupCreateSelectors(
 func includeAllGroupsWithCategories(ins idname.Cacher, categories []string) *selectors.GroupsBackup {
 	return utils.AddGroupsCategories(selectors.NewGroupsBackup(ins.IDs()), categories)
 }
+
+// ------------------------------------------------------------------------------------------------
+// backup search
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup search groups [<flag>...]`
+func groupsSearchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     groupsServiceCommand,
+		Short:   "Search the contents of M365 Groups service backups",
+		RunE:    searchGroupsCmd,
+		Args:    cobra.NoArgs,
+		Example: groupsServiceCommandSearchExamples,
+	}
+}
+
+// searches the contents of Groups backups.
+func searchGroupsCmd(cmd *cobra.Command, args []string) error {
+	return genericSearchCommand(
+		cmd,
+		path.GroupsService,
+		groupsSearchSelector,
+		flags.BackupIDsFV,
+		flags.QueryFV)
+}
+
+func groupsSearchSelector(protectedResource string) selectors.Selector {
+	sel := selectors.NewGroupsRestore([]string{protectedResource})
+	sel.Include(sel.AllData())
+
+	return sel.Selector
+}
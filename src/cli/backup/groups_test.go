@@ -64,6 +64,13 @@ func (suite *GroupsUnitSuite) TestAddGroupsCommands() {
 			expectShort: groupsDeleteCmd().Short,
 			expectRunE:  deleteGroupsCmd,
 		},
+		{
+			name:        "search groups",
+			use:         searchCommand,
+			expectUse:   expectUse + " " + searchServiceCommandUseSuffix,
+			expectShort: groupsSearchCmd().Short,
+			expectRunE:  searchGroupsCmd,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -111,12 +118,18 @@ func (suite *GroupsUnitSuite) TestValidateGroupsBackupCreateFlags() {
 			cats:   []string{flags.DataConversations},
 			expect: assert.NoError,
 		},
+		{
+			name:   "planner",
+			cats:   []string{flags.DataPlanner},
+			expect: assert.NoError,
+		},
 		{
 			name: "all allowed",
 			cats: []string{
 				flags.DataLibraries,
 				flags.DataMessages,
 				flags.DataConversations,
+				flags.DataPlanner,
 			},
 			expect: assert.NoError,
 		},
@@ -156,6 +169,7 @@ func (suite *GroupsUnitSuite) TestBackupCreateFlags() {
 				"--" + flags.DisableLazyItemReaderFN,
 			},
 			flagsTD.PreparedGenericBackupFlags(),
+			flagsTD.PreparedDriveExclusionFlags(),
 			flagsTD.PreparedProviderFlags(),
 			flagsTD.PreparedStorageFlags()))
 
@@ -181,6 +195,7 @@ func (suite *GroupsUnitSuite) TestBackupCreateFlags() {
 
 	assert.ElementsMatch(t, flagsTD.GroupsInput, opts.Groups)
 	flagsTD.AssertGenericBackupFlags(t, cmd)
+	flagsTD.AssertDriveExclusionFlags(t, cmd)
 	flagsTD.AssertProviderFlags(t, cmd)
 	flagsTD.AssertStorageFlags(t, cmd)
 }
@@ -232,6 +247,7 @@ func (suite *GroupsUnitSuite) TestBackupDetailsFlags() {
 			},
 			flagsTD.PreparedChannelFlags(),
 			flagsTD.PreparedConversationFlags(),
+			flagsTD.PreparedPlannerFlags(),
 			flagsTD.PreparedProviderFlags(),
 			flagsTD.PreparedStorageFlags(),
 			flagsTD.PreparedLibraryFlags()))
@@ -244,6 +260,7 @@ func (suite *GroupsUnitSuite) TestBackupDetailsFlags() {
 	flagsTD.AssertStorageFlags(t, cmd)
 	flagsTD.AssertChannelFlags(t, cmd)
 	flagsTD.AssertConversationFlags(t, cmd)
+	flagsTD.AssertPlannerFlags(t, cmd)
 	flagsTD.AssertLibraryFlags(t, cmd)
 }
 
@@ -271,3 +288,32 @@ func (suite *GroupsUnitSuite) TestBackupDeleteFlags() {
 	flagsTD.AssertProviderFlags(t, cmd)
 	flagsTD.AssertStorageFlags(t, cmd)
 }
+
+func (suite *GroupsUnitSuite) TestBackupSearchFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: searchCommand},
+		addGroupsCommands,
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			groupsServiceCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.BackupIDsFN, flagsTD.FlgInputs(flagsTD.BackupIDsInput),
+				"--" + flags.QueryFN, flagsTD.QueryInput,
+				"--" + flags.IndexDirFN, flagsTD.IndexDirInput,
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.ElementsMatch(t, flagsTD.BackupIDsInput, flags.BackupIDsFV)
+	assert.Equal(t, flagsTD.QueryInput, flags.QueryFV)
+	assert.Equal(t, flagsTD.IndexDirInput, flags.IndexDirFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
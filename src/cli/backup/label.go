@@ -0,0 +1,92 @@
+package backup
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/repository"
+)
+
+// The backup label subcommand.
+// `corso backup label --backup <id> [<flag>...]`
+var labelCommand = "label"
+
+//nolint:lll
+const labelExamples = `# Mark backup 1234abcd-12ab-cd34-56de-1234abcd as the pre-migration backup
+corso backup label --backup 1234abcd-12ab-cd34-56de-1234abcd --label stage=pre-migration
+
+# Drop the stage label from the backup
+corso backup label --backup 1234abcd-12ab-cd34-56de-1234abcd --remove-label stage`
+
+func labelCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   labelCommand,
+		Short: "Edit the labels of a backup",
+		Long: `Add, replace, or remove the user-defined labels attached to a backup.  Labels are
+key=value pairs, and can be used to filter the output of backup list.`,
+		RunE:    labelBackupCmd,
+		Args:    cobra.NoArgs,
+		Example: labelExamples,
+	}
+}
+
+// processes `corso backup label`.
+func labelBackupCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	set, err := backup.ParseLabels(flags.LabelFV)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "invalid --"+flags.LabelFN))
+	}
+
+	if len(set) == 0 && len(flags.RemoveLabelFV) == 0 {
+		return Only(ctx, clues.New("provide at least one --"+flags.LabelFN+" or --"+flags.RemoveLabelFN))
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.ExchangeService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	bup, err := r.UpdateBackupLabels(ctx, flags.BackupIDFV, set, flags.RemoveLabelFV)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) || errors.Is(err, repository.ErrorBackupNotFound) {
+			return Only(ctx, clues.New("No backup exists with the id "+flags.BackupIDFV))
+		}
+
+		return Only(ctx, clues.Wrap(err, "Failed to update the labels of backup "+flags.BackupIDFV))
+	}
+
+	labels := bup.Labels()
+	if len(labels) == 0 {
+		Infof(ctx, "Backup %s has no labels", flags.BackupIDFV)
+		return nil
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+
+	sort.Strings(pairs)
+
+	Infof(ctx, "Backup %s labels: %s", flags.BackupIDFV, strings.Join(pairs, ", "))
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package backup
+
+import (
+	"context"
+	"os/user"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// The backup hold and release subcommands.
+// `corso backup hold --name <name> [<flag>...]`
+// `corso backup release --name <name> [<flag>...]`
+var (
+	holdCommand    = "hold"
+	releaseCommand = "release"
+)
+
+//nolint:lll
+const holdExamples = `# Hold backup 1234abcd-12ab-cd34-56de-1234abcd for case 1234
+corso backup hold --name case-1234 --reason "Finance audit" --backups 1234abcd-12ab-cd34-56de-1234abcd
+
+# Hold every backup of Alice's data for case 1234
+corso backup hold --name case-1234 --resource alice@example.com`
+
+//nolint:lll
+const releaseExamples = `# Release the case 1234 hold from every backup of Alice's data
+corso backup release --name case-1234 --resource alice@example.com`
+
+func holdCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   holdCommand,
+		Short: "Place backups under legal hold",
+		Long: `Place a named legal hold on backups.  Held backups can't be deleted, and are never
+removed by repository cleanup, until every hold on them is released.  Holds placed by
+resource apply to the backups that exist when the hold is placed.`,
+		RunE:    holdBackupCmd,
+		Args:    cobra.NoArgs,
+		Example: holdExamples,
+	}
+}
+
+func releaseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     releaseCommand,
+		Short:   "Release backups from legal hold",
+		Long:    `Release a named legal hold from backups.  Other holds on the backups are kept.`,
+		RunE:    releaseBackupCmd,
+		Args:    cobra.NoArgs,
+		Example: releaseExamples,
+	}
+}
+
+// processes `corso backup hold`.
+func holdBackupCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	hold := backup.LegalHold{
+		Name:      flags.HoldNameFV,
+		Reason:    flags.HoldReasonFV,
+		CreatedBy: flags.HoldCreatedByFV,
+	}
+
+	if len(hold.CreatedBy) == 0 {
+		if u, err := user.Current(); err == nil {
+			hold.CreatedBy = u.Username
+		}
+	}
+
+	return genericLegalHoldCommand(
+		cmd,
+		func(ctx context.Context, r repository.Repositoryer, ids []string) (int, error) {
+			return r.PlaceLegalHold(ctx, hold, ids...)
+		},
+		"Placed legal hold %s on %d of %d backups")
+}
+
+// processes `corso backup release`.
+func releaseBackupCmd(cmd *cobra.Command, args []string) error {
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	return genericLegalHoldCommand(
+		cmd,
+		func(ctx context.Context, r repository.Repositoryer, ids []string) (int, error) {
+			return r.ReleaseLegalHold(ctx, flags.HoldNameFV, ids...)
+		},
+		"Released legal hold %s from %d of %d backups")
+}
+
+// genericLegalHoldCommand applies the change to the backups picked by
+// the flags, and reports how many backups changed.
+func genericLegalHoldCommand(
+	cmd *cobra.Command,
+	change func(context.Context, repository.Repositoryer, []string) (int, error),
+	report string,
+) error {
+	ctx := clues.Add(cmd.Context(), "legal_hold", flags.HoldNameFV)
+
+	if len(flags.BackupIDsFV) == 0 && len(flags.ResourceFV) == 0 {
+		return Only(ctx, clues.New("provide --"+flags.BackupIDsFN+" or --"+flags.ResourceFN))
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.ExchangeService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	ids, err := legalHoldTargets(ctx, r, flags.BackupIDsFV, flags.ResourceFV)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	changed, err := change(ctx, r, ids)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to update legal hold "+flags.HoldNameFV))
+	}
+
+	Infof(ctx, report, flags.HoldNameFV, changed, len(ids))
+
+	return nil
+}
+
+// legalHoldTargets produces the IDs of the listed backups, plus the IDs
+// of every backup of the protected resource.
+func legalHoldTargets(
+	ctx context.Context,
+	bg repository.BackupGetter,
+	ids []string,
+	resource string,
+) ([]string, error) {
+	if len(resource) == 0 {
+		return ids, nil
+	}
+
+	bups, err := bg.BackupsByTag(ctx, store.ProtectedResource(resource))
+	if err != nil {
+		return nil, clues.Wrap(err, "Failed to list the backups of "+resource)
+	}
+
+	if len(bups) == 0 && len(ids) == 0 {
+		return nil, clues.New("No backups exist for " + resource)
+	}
+
+	seen := map[string]struct{}{}
+	res := []string{}
+
+	for _, id := range ids {
+		seen[id] = struct{}{}
+		res = append(res, id)
+	}
+
+	for _, b := range bups {
+		if _, ok := seen[string(b.ID)]; ok {
+			continue
+		}
+
+		seen[string(b.ID)] = struct{}{}
+		res = append(res, string(b.ID))
+	}
+
+	return res, nil
+}
+
+// printLegalHolds describes each of the backup's legal holds.
+func printLegalHolds(ctx context.Context, b *backup.Backup) {
+	if !b.UnderLegalHold() {
+		return
+	}
+
+	Infof(ctx, "\nLegal holds")
+
+	for _, lh := range b.LegalHolds {
+		msg := lh.Name + ": placed " + dttm.FormatToTabularDisplay(lh.CreatedAt)
+
+		if len(lh.CreatedBy) > 0 {
+			msg += " by " + lh.CreatedBy
+		}
+
+		if len(lh.Reason) > 0 {
+			msg += " (" + lh.Reason + ")"
+		}
+
+		Info(ctx, msg)
+	}
+}
@@ -0,0 +1,120 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/webdav"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/davfs"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/repository"
+)
+
+// The backup mount subcommand.
+// `corso backup mount --backup <id> [<flag>...]`
+var mountCommand = "mount"
+
+//nolint:lll
+const mountExamples = `# Browse backup 1234abcd-12ab-cd34-56de-1234abcd with a WebDAV client
+corso backup mount --backup 1234abcd-12ab-cd34-56de-1234abcd
+
+# Serve the backup on port 8080 of every interface
+corso backup mount --backup 1234abcd-12ab-cd34-56de-1234abcd --webdav :8080`
+
+func mountCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   mountCommand,
+		Short: "Browse a backup over WebDAV",
+		Long: `Serve the contents of a backup as a read-only WebDAV share, so that it can be browsed
+with any WebDAV client, including the file managers of most operating systems.  Folders are
+shown by their display names.  Items are read from the repository as they're opened, and
+Exchange items are converted to eml, ics, and vcf files.
+
+The share has no authentication.  It's served on localhost unless another --webdav address
+is provided.`,
+		RunE:    mountBackupCmd,
+		Args:    cobra.NoArgs,
+		Example: mountExamples,
+	}
+}
+
+// processes `corso backup mount`.
+func mountBackupCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.ExchangeService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	bup, err := r.Backup(ctx, flags.BackupIDFV)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) || errors.Is(err, repository.ErrorBackupNotFound) {
+			return Only(ctx, clues.New("No backup exists with the id "+flags.BackupIDFV))
+		}
+
+		return Only(ctx, clues.Wrap(err, "Failed to find backup "+flags.BackupIDFV))
+	}
+
+	bo, err := r.NewBrowse(ctx, flags.BackupIDFV, control.DefaultExportConfig())
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to initialize backup browsing"))
+	}
+
+	deets, err := bo.Run(ctx)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to load backup details"))
+	}
+
+	dfs, err := davfs.New(deets, &bo, bup.CreationTime)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to lay out backup contents"))
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr: flags.WebDAVFV,
+		Handler: &webdav.Handler{
+			FileSystem: dfs,
+			LockSystem: webdav.NewMemLS(),
+			Logger: func(r *http.Request, err error) {
+				if err != nil {
+					logger.CtxErr(r.Context(), err).Infow("serving webdav request", "method", r.Method)
+				}
+			},
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(_ net.Listener) context.Context { return ctx },
+	}
+
+	Infof(ctx, "Serving backup %s over WebDAV on %s; press Ctrl+C to stop", flags.BackupIDFV, flags.WebDAVFV)
+
+	if err := utils.Serve(ctx, srv, srv.ListenAndServe); err != nil {
+		return Only(ctx, clues.Wrap(err, "serving webdav"))
+	}
+
+	return nil
+}
@@ -32,7 +32,11 @@ corso backup create onedrive --user alice@example.com
 corso backup create onedrive --user alice@example.com,bob@example.com
 
 # Backup all OneDrive data for all M365 users 
-corso backup create onedrive --user '*'`
+corso backup create onedrive --user '*'
+
+# Backup OneDrive for Alice, skipping temp files and the "Archive" folder
+corso backup create onedrive --user alice@example.com \
+    --exclude-name "~$*,*.tmp" --exclude-path Archive`
 
 	oneDriveServiceCommandDeleteExamples = `# Delete OneDrive backup with ID 1234abcd-12ab-cd34-56de-1234abcd \
 and 1234abcd-12ab-cd34-56de-1234abce
@@ -48,6 +52,12 @@ corso backup details onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd \
 # Explore files created before the end of 2015
 corso backup details onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --file-created-before 2015-01-01T00:00:00`
+
+	oneDriveServiceCommandSearchExamples = `# Search all OneDrive backups for files mentioning Project X
+corso backup search onedrive --query "project x"
+
+# Search a single backup (1234abcd...) for files mentioning the budget
+corso backup search onedrive --backups 1234abcd-12ab-cd34-56de-1234abcd --query "budget*"`
 )
 
 // called by backup.go to map subcommands to provider-specific handling.
@@ -66,6 +76,7 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddUserFlag(c)
 		flags.AddGenericBackupFlags(c)
+		flags.AddDriveExclusionFlags(c)
 		fs.BoolVar(
 			&flags.UseOldDeltaProcessFV,
 			flags.UseOldDeltaProcessFN,
@@ -78,6 +89,7 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddBackupIDFlag(c, false)
 		flags.AddAllBackupListFlags(c)
+		flags.AddBackupListFilterFlags(c)
 
 	case detailsCommand:
 		c, _ = utils.AddCommand(cmd, oneDriveDetailsCmd())
@@ -97,6 +109,15 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddMultipleBackupIDsFlag(c, false)
 		flags.AddBackupIDFlag(c, false)
+
+	case searchCommand:
+		c, _ = utils.AddCommand(cmd, oneDriveSearchCmd())
+
+		c.Use = c.Use + " " + searchServiceCommandUseSuffix
+		c.Example = oneDriveServiceCommandSearchExamples
+
+		flags.AddSearchFlags(c)
+		flags.AddMultipleBackupIDsFlag(c, false)
 	}
 
 	return c
@@ -133,6 +154,15 @@ func createOneDriveCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	exclusions, err := utils.MakeDriveExclusions(
+		flags.ExcludeExtensionFV,
+		flags.ExcludeNameFV,
+		flags.ExcludePathFV,
+		flags.MaxFileSizeFV)
+	if err != nil {
+		return err
+	}
+
 	r, acct, err := utils.AccountConnectAndWriteRepoConfig(
 		ctx,
 		cmd,
@@ -144,6 +174,7 @@ func createOneDriveCmd(cmd *cobra.Command, args []string) error {
 	defer utils.CloseRepo(ctx, r)
 
 	sel := oneDriveBackupCreateSelectors(flags.UserFV)
+	sel.SetDriveExclusions(exclusions)
 
 	ins, err := utils.UsersMap(
 		ctx,
@@ -278,3 +309,35 @@ func deleteOneDriveCmd(cmd *cobra.Command, args []string) error {
 
 	return genericDeleteCommand(cmd, path.OneDriveService, "OneDrive", backupIDValue, args)
 }
+
+// ------------------------------------------------------------------------------------------------
+// backup search
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup search onedrive [<flag>...]`
+func oneDriveSearchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     oneDriveServiceCommand,
+		Short:   "Search the contents of M365 OneDrive service backups",
+		RunE:    searchOneDriveCmd,
+		Args:    cobra.NoArgs,
+		Example: oneDriveServiceCommandSearchExamples,
+	}
+}
+
+// searches the contents of OneDrive backups.
+func searchOneDriveCmd(cmd *cobra.Command, args []string) error {
+	return genericSearchCommand(
+		cmd,
+		path.OneDriveService,
+		oneDriveSearchSelector,
+		flags.BackupIDsFV,
+		flags.QueryFV)
+}
+
+func oneDriveSearchSelector(protectedResource string) selectors.Selector {
+	sel := selectors.NewOneDriveRestore([]string{protectedResource})
+	sel.Include(sel.AllData())
+
+	return sel.Selector
+}
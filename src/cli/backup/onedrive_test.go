@@ -63,6 +63,13 @@ func (suite *OneDriveUnitSuite) TestAddOneDriveCommands() {
 			expectShort: oneDriveDeleteCmd().Short,
 			expectRunE:  deleteOneDriveCmd,
 		},
+		{
+			name:        "search onedrive",
+			use:         searchCommand,
+			expectUse:   expectUse + " " + searchServiceCommandUseSuffix,
+			expectShort: oneDriveSearchCmd().Short,
+			expectRunE:  searchOneDriveCmd,
+		},
 	}
 
 	for _, test := range table {
@@ -103,6 +110,7 @@ func (suite *OneDriveUnitSuite) TestBackupCreateFlags() {
 				"--" + flags.UserFN, flagsTD.FlgInputs(flagsTD.UsersInput),
 			},
 			flagsTD.PreparedGenericBackupFlags(),
+			flagsTD.PreparedDriveExclusionFlags(),
 			flagsTD.PreparedProviderFlags(),
 			flagsTD.PreparedStorageFlags()))
 
@@ -123,6 +131,7 @@ func (suite *OneDriveUnitSuite) TestBackupCreateFlags() {
 
 	assert.ElementsMatch(t, flagsTD.UsersInput, opts.Users)
 	flagsTD.AssertGenericBackupFlags(t, cmd)
+	flagsTD.AssertDriveExclusionFlags(t, cmd)
 	flagsTD.AssertProviderFlags(t, cmd)
 	flagsTD.AssertStorageFlags(t, cmd)
 }
@@ -208,6 +217,35 @@ func (suite *OneDriveUnitSuite) TestBackupDeleteFlags() {
 	flagsTD.AssertStorageFlags(t, cmd)
 }
 
+func (suite *OneDriveUnitSuite) TestBackupSearchFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: searchCommand},
+		addOneDriveCommands,
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			oneDriveServiceCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.BackupIDsFN, flagsTD.FlgInputs(flagsTD.BackupIDsInput),
+				"--" + flags.QueryFN, flagsTD.QueryInput,
+				"--" + flags.IndexDirFN, flagsTD.IndexDirInput,
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.ElementsMatch(t, flagsTD.BackupIDsInput, flags.BackupIDsFV)
+	assert.Equal(t, flagsTD.QueryInput, flags.QueryFV)
+	assert.Equal(t, flagsTD.IndexDirInput, flags.IndexDirFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
 func (suite *OneDriveUnitSuite) TestValidateOneDriveBackupCreateFlags() {
 	table := []struct {
 		name   string
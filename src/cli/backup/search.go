@@ -0,0 +1,258 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/search"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// The backup search subcommand.
+// `corso backup search <service> [<flag>...]`
+var searchCommand = "search"
+
+func searchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   searchCommand,
+		Short: "Search the contents of backups",
+		Long: `Search the text of items stored in backups.  Each backup is indexed the
+first time it is searched, and the index is kept locally for later searches.`,
+		RunE: handleSearchCmd,
+		Args: cobra.NoArgs,
+	}
+}
+
+// Handler for calls to `corso backup search`.
+// Produces the same output as `corso backup search --help`.
+func handleSearchCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+const searchServiceCommandUseSuffix = "--query <terms>"
+
+// searchSelectorFn produces a selector for all searchable data owned by
+// the protected resource.
+type searchSelectorFn func(protectedResource string) selectors.Selector
+
+// genericSearchCommand is a helper function that all services can use
+// to search the contents of their backups.
+func genericSearchCommand(
+	cmd *cobra.Command,
+	service path.ServiceType,
+	makeSel searchSelectorFn,
+	backupIDs []string,
+	query string,
+) error {
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	ctx := cmd.Context()
+
+	if len(search.ParseQuery(query)) == 0 {
+		return Only(ctx, clues.New("--query must contain at least one search term"))
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, service)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	indexDir := flags.IndexDirFV
+	if len(indexDir) == 0 {
+		indexDir, err = search.DefaultDir(r.GetID())
+		if err != nil {
+			return Only(ctx, err)
+		}
+	}
+
+	st, err := search.NewLocalStore(indexDir)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	bups, err := backupsToSearch(ctx, r, service, backupIDs)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	results := []Printable{}
+
+	for _, bup := range bups {
+		idx, err := loadOrBuildIndex(ctx, r, st, service, makeSel, bup)
+		if err != nil {
+			return Only(ctx, err)
+		}
+
+		for _, item := range idx.Search(query) {
+			results = append(results, searchResult{
+				BackupID: string(bup.ID),
+				Item:     item,
+			})
+		}
+	}
+
+	if len(results) == 0 {
+		Info(ctx, "No items matched the query")
+		return nil
+	}
+
+	All(ctx, results...)
+
+	return nil
+}
+
+// backupsToSearch retrieves the requested backups, or every backup of
+// the service if no IDs are provided.
+func backupsToSearch(
+	ctx context.Context,
+	r repository.BackupGetter,
+	service path.ServiceType,
+	backupIDs []string,
+) ([]*backup.Backup, error) {
+	if len(backupIDs) == 0 {
+		bups, err := r.BackupsByTag(ctx, store.Service(service))
+		if err != nil {
+			return nil, clues.Wrap(err, "Failed to list backups in the repository")
+		}
+
+		return bups, nil
+	}
+
+	bups, errs := r.Backups(ctx, backupIDs)
+	if errs.Failure() != nil {
+		if errors.Is(errs.Failure(), data.ErrNotFound) {
+			return nil, clues.New(fmt.Sprintf("No backup exists with the ids %v", backupIDs))
+		}
+
+		return nil, clues.Wrap(errs.Failure(), "Failed to retrieve backups")
+	}
+
+	for _, bup := range bups {
+		if bup.Selector.PathService() != service {
+			return nil, clues.New(fmt.Sprintf(
+				"Backup %s is not a %s backup",
+				bup.ID,
+				service.HumanString()))
+		}
+	}
+
+	return bups, nil
+}
+
+// loadOrBuildIndex returns the stored index for the backup.  If the
+// backup has not been indexed yet, the index gets built from the backup
+// contents and stored for later searches.
+func loadOrBuildIndex(
+	ctx context.Context,
+	r repository.Repositoryer,
+	st search.Store,
+	service path.ServiceType,
+	makeSel searchSelectorFn,
+	bup *backup.Backup,
+) (*search.Index, error) {
+	bID := string(bup.ID)
+	ctx = clues.Add(ctx, "backup_id", bID)
+
+	idx, err := st.Load(ctx, bID)
+	if err == nil {
+		return idx, nil
+	}
+
+	if !errors.Is(err, core.ErrNotFound) {
+		logger.CtxErr(ctx, err).Info("discarding unreadable search index")
+	}
+
+	progressMessage := observe.MessageWithCompletion(ctx, observe.DefaultCfg(), "Indexing backup "+bID)
+	defer close(progressMessage)
+
+	deets, _, errs := r.GetBackupDetails(ctx, bID)
+	if errs.Failure() != nil {
+		return nil, clues.Wrap(errs.Failure(), "Failed to get details of backup "+bID)
+	}
+
+	eo, err := r.NewExport(
+		ctx,
+		bID,
+		makeSel(bup.Selector.DiscreteOwner),
+		control.DefaultExportConfig())
+	if err != nil {
+		return nil, clues.Wrap(err, "Failed to initialize indexing of backup "+bID)
+	}
+
+	colls, err := eo.Run(ctx)
+	if err != nil {
+		return nil, clues.Wrap(err, "Failed to read contents of backup "+bID)
+	}
+
+	idx, err = search.BuildIndex(ctx, bID, service, deets, colls, eo.Errors)
+	if err != nil {
+		return nil, clues.Wrap(err, "Failed to index backup "+bID)
+	}
+
+	// A partial index is still searched, but it isn't kept, so that the
+	// missing items get another chance at the next search.
+	if n := len(eo.Errors.Recovered()); n > 0 {
+		Errf(ctx, "%d items in backup %s could not be indexed", n, bID)
+		return idx, nil
+	}
+
+	if err := st.Save(ctx, idx); err != nil {
+		return nil, clues.Wrap(err, "Failed to save index of backup "+bID)
+	}
+
+	return idx, nil
+}
+
+// searchResult is a single item that matched a search.
+type searchResult struct {
+	BackupID string `json:"backupID"`
+	search.Item
+}
+
+func (sr searchResult) MinimumPrintable() any {
+	return sr
+}
+
+func (sr searchResult) Headers(skipID bool) []string {
+	hs := []string{"ID", "Backup ID", "Location", "Name"}
+
+	if skipID {
+		hs = hs[1:]
+	}
+
+	return hs
+}
+
+func (sr searchResult) Values(skipID bool) []string {
+	vs := []string{sr.ShortRef, sr.BackupID, sr.LocationRef, sr.Name}
+
+	if skipID {
+		vs = vs[1:]
+	}
+
+	return vs
+}
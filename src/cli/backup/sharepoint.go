@@ -41,6 +41,9 @@ corso backup create sharepoint --site '*'
 
 # Backup all SharePoint list data for a Site
 corso backup create sharepoint --site https://example.com/hr --data lists
+
+# Backup the HR site, skipping any files larger than 500MB
+corso backup create sharepoint --site https://example.com/hr --max-file-size 500MB
 `
 
 	sharePointServiceCommandDeleteExamples = `# Delete SharePoint backup with ID 1234abcd-12ab-cd34-56de-1234abcd \
@@ -81,6 +84,12 @@ corso backup details sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd \
 # Explore lists modified after a given time
 corso backup details sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --list-modified-after 2024-01-01T12:23:34`
+
+	sharePointServiceCommandSearchExamples = `# Search all SharePoint backups for files and lists mentioning Project X
+corso backup search sharepoint --query "project x"
+
+# Search a single backup (1234abcd...) for items mentioning the budget
+corso backup search sharepoint --backups 1234abcd-12ab-cd34-56de-1234abcd --query "budget*"`
 )
 
 // called by backup.go to map subcommands to provider-specific handling.
@@ -100,12 +109,14 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		// when explicit invoke is not required anymore
 		flags.AddDataFlag(c, []string{flags.DataLibraries}, true)
 		flags.AddGenericBackupFlags(c)
+		flags.AddDriveExclusionFlags(c)
 
 	case listCommand:
 		c, _ = utils.AddCommand(cmd, sharePointListCmd())
 
 		flags.AddBackupIDFlag(c, false)
 		flags.AddAllBackupListFlags(c)
+		flags.AddBackupListFilterFlags(c)
 
 	case detailsCommand:
 		c, _ = utils.AddCommand(cmd, sharePointDetailsCmd())
@@ -125,6 +136,15 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddMultipleBackupIDsFlag(c, false)
 		flags.AddBackupIDFlag(c, false)
+
+	case searchCommand:
+		c, _ = utils.AddCommand(cmd, sharePointSearchCmd())
+
+		c.Use = c.Use + " " + searchServiceCommandUseSuffix
+		c.Example = sharePointServiceCommandSearchExamples
+
+		flags.AddSearchFlags(c)
+		flags.AddMultipleBackupIDsFlag(c, false)
 	}
 
 	return c
@@ -161,6 +181,15 @@ func createSharePointCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	exclusions, err := utils.MakeDriveExclusions(
+		flags.ExcludeExtensionFV,
+		flags.ExcludeNameFV,
+		flags.ExcludePathFV,
+		flags.MaxFileSizeFV)
+	if err != nil {
+		return err
+	}
+
 	r, acct, err := utils.AccountConnectAndWriteRepoConfig(
 		ctx,
 		cmd,
@@ -189,6 +218,8 @@ func createSharePointCmd(cmd *cobra.Command, args []string) error {
 		return Only(ctx, clues.Wrap(err, "Retrieving up sharepoint sites by ID and URL"))
 	}
 
+	sel.SetDriveExclusions(exclusions)
+
 	selectorSet := []selectors.Selector{}
 
 	for _, discSel := range sel.SplitByResourceOwner(ins.IDs()) {
@@ -348,3 +379,37 @@ func runDetailsSharePointCmd(cmd *cobra.Command) error {
 
 	return nil
 }
+
+// ------------------------------------------------------------------------------------------------
+// backup search
+// ------------------------------------------------------------------------------------------------
+
+// `corso backup search sharepoint [<flag>...]`
+func sharePointSearchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     sharePointServiceCommand,
+		Short:   "Search the contents of M365 SharePoint service backups",
+		RunE:    searchSharePointCmd,
+		Args:    cobra.NoArgs,
+		Example: sharePointServiceCommandSearchExamples,
+	}
+}
+
+// searches the contents of SharePoint backups.
+func searchSharePointCmd(cmd *cobra.Command, args []string) error {
+	return genericSearchCommand(
+		cmd,
+		path.SharePointService,
+		sharePointSearchSelector,
+		flags.BackupIDsFV,
+		flags.QueryFV)
+}
+
+func sharePointSearchSelector(protectedResource string) selectors.Selector {
+	sel := selectors.NewSharePointRestore([]string{protectedResource})
+	sel.Include(
+		sel.LibraryFolders(selectors.Any()),
+		sel.Lists(selectors.Any()))
+
+	return sel.Selector
+}
@@ -66,6 +66,13 @@ func (suite *SharePointUnitSuite) TestAddSharePointCommands() {
 			expectShort: sharePointDeleteCmd().Short,
 			expectRunE:  deleteSharePointCmd,
 		},
+		{
+			name:        "search sharepoint",
+			use:         searchCommand,
+			expectUse:   expectUse + " " + searchServiceCommandUseSuffix,
+			expectShort: sharePointSearchCmd().Short,
+			expectRunE:  searchSharePointCmd,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -107,6 +114,7 @@ func (suite *SharePointUnitSuite) TestBackupCreateFlags() {
 				"--" + flags.CategoryDataFN, flagsTD.FlgInputs(flagsTD.SharepointCategoryDataInput),
 			},
 			flagsTD.PreparedGenericBackupFlags(),
+			flagsTD.PreparedDriveExclusionFlags(),
 			flagsTD.PreparedProviderFlags(),
 			flagsTD.PreparedStorageFlags()))
 
@@ -128,6 +136,7 @@ func (suite *SharePointUnitSuite) TestBackupCreateFlags() {
 	assert.ElementsMatch(t, []string{strings.Join(flagsTD.SiteIDInput, ",")}, opts.SiteID)
 	assert.ElementsMatch(t, flagsTD.WebURLInput, opts.WebURL)
 	flagsTD.AssertGenericBackupFlags(t, cmd)
+	flagsTD.AssertDriveExclusionFlags(t, cmd)
 	flagsTD.AssertProviderFlags(t, cmd)
 	flagsTD.AssertStorageFlags(t, cmd)
 }
@@ -213,6 +222,35 @@ func (suite *SharePointUnitSuite) TestBackupDeleteFlags() {
 	flagsTD.AssertStorageFlags(t, cmd)
 }
 
+func (suite *SharePointUnitSuite) TestBackupSearchFlags() {
+	t := suite.T()
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		&cobra.Command{Use: searchCommand},
+		addSharePointCommands,
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			sharePointServiceCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.BackupIDsFN, flagsTD.FlgInputs(flagsTD.BackupIDsInput),
+				"--" + flags.QueryFN, flagsTD.QueryInput,
+				"--" + flags.IndexDirFN, flagsTD.IndexDirInput,
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	assert.ElementsMatch(t, flagsTD.BackupIDsInput, flags.BackupIDsFV)
+	assert.Equal(t, flagsTD.QueryInput, flags.QueryFV)
+	assert.Equal(t, flagsTD.IndexDirInput, flags.IndexDirFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
 func (suite *SharePointUnitSuite) TestValidateSharePointBackupCreateFlags() {
 	table := []struct {
 		name   string
@@ -66,6 +66,7 @@ func addTeamsChatsCommands(cmd *cobra.Command) *cobra.Command {
 
 		flags.AddBackupIDFlag(c, false)
 		flags.AddAllBackupListFlags(c)
+		flags.AddBackupListFilterFlags(c)
 
 	case detailsCommand:
 		c, _ = utils.AddCommand(cmd, teamschatsDetailsCmd(), utils.MarkPreReleaseCommand())
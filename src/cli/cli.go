@@ -10,13 +10,17 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/alcionai/corso/src/cli/backup"
+	cliConfig "github.com/alcionai/corso/src/cli/config"
 	"github.com/alcionai/corso/src/cli/debug"
 	"github.com/alcionai/corso/src/cli/export"
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/cli/help"
+	"github.com/alcionai/corso/src/cli/imports"
 	"github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/repo"
 	"github.com/alcionai/corso/src/cli/restore"
+	"github.com/alcionai/corso/src/cli/schedule"
+	"github.com/alcionai/corso/src/cli/server"
 	"github.com/alcionai/corso/src/internal/observe"
 	"github.com/alcionai/corso/src/internal/version"
 	"github.com/alcionai/corso/src/pkg/config"
@@ -123,9 +127,13 @@ func BuildCommandTree(cmd *cobra.Command) {
 	cmd.CompletionOptions.DisableDefaultCmd = true
 
 	repo.AddCommands(cmd)
+	cliConfig.AddCommands(cmd)
 	backup.AddCommands(cmd)
 	restore.AddCommands(cmd)
 	export.AddCommands(cmd)
+	imports.AddCommands(cmd)
+	schedule.AddCommands(cmd)
+	server.AddCommands(cmd)
 	debug.AddCommands(cmd)
 	help.AddCommands(cmd)
 }
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	corsoConfig "github.com/alcionai/corso/src/pkg/config"
+)
+
+const (
+	configCommand = "config"
+	ListCommand   = "list"
+	ShowCommand   = "show"
+	UseCommand    = "use"
+)
+
+const configExamples = `# An example config file with two profiles
+current_profile = 'prod-eu'
+
+[profiles.prod-eu]
+provider = 'S3'
+bucket = 'corso-eu'
+account_provider = 'M365'
+azure_tenantid = '<tenant-id>'
+
+[profiles.dev]
+provider = 'filesystem'
+path = '/var/lib/corso/dev'
+account_provider = 'M365'
+azure_tenantid = '<tenant-id>'
+
+# Back up using a profile other than the current one
+corso backup create exchange --mailbox '*' --profile dev`
+
+const listExamples = `# List the profiles in the config file
+corso config list`
+
+const showExamples = `# Show the settings of the profile in use
+corso config show
+
+# Show the settings of the dev profile
+corso config show dev`
+
+const useExamples = `# Use the prod-eu profile whenever --profile and CORSO_PROFILE aren't provided
+corso config use prod-eu`
+
+// AddCommands attaches all `corso config * *` commands to the parent.
+func AddCommands(cmd *cobra.Command) {
+	configCmd := configCmd()
+
+	cmd.AddCommand(configCmd)
+	configCmd.AddCommand(listCmd())
+	configCmd.AddCommand(showCmd())
+	configCmd.AddCommand(useCmd())
+}
+
+// The config category of commands.
+// `corso config [<subcommand>] [<flag>...]`
+func configCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   configCommand,
+		Short: "Manage config file profiles",
+		Long: `Manage the named profiles in the config file.  Each profile holds the storage, account,
+and repo settings for one repository.  Select a profile with --profile or the CORSO_PROFILE env
+var, or set the current profile with 'corso config use'.`,
+		RunE:    handleConfigCmd,
+		Args:    cobra.NoArgs,
+		Example: configExamples,
+	}
+}
+
+// Handler for flat calls to `corso config`.
+// Produces the same output as `corso config --help`.
+func handleConfigCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// The config list subcommand.
+// `corso config list [<flag>...]`
+func listCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     ListCommand,
+		Short:   "List the profiles in the config file",
+		RunE:    handleListCmd,
+		Args:    cobra.NoArgs,
+		Example: listExamples,
+	}
+}
+
+// Handler for calls to `corso config list`.
+func handleListCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	ps, err := corsoConfig.Profiles(ctx)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	if len(ps) == 0 {
+		Info(ctx, "No profiles found in the config file")
+		return nil
+	}
+
+	printables := make([]Printable, 0, len(ps))
+
+	for _, p := range ps {
+		printables = append(printables, profile{p})
+	}
+
+	All(ctx, printables...)
+
+	return nil
+}
+
+// The config show subcommand.
+// `corso config show [<name>] [<flag>...]`
+func showCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     ShowCommand + " [<name>]",
+		Short:   "Show the settings of a profile",
+		Long:    `Show the settings of the named profile, or of the profile in use.  Secrets are never shown.`,
+		RunE:    handleShowCmd,
+		Args:    cobra.MaximumNArgs(1),
+		Example: showExamples,
+	}
+}
+
+// Handler for calls to `corso config show`.
+func handleShowCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	var name string
+
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	p, err := corsoConfig.GetProfile(ctx, name)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	Item(ctx, profile{p})
+
+	if DisplayJSONFormat() {
+		return nil
+	}
+
+	keys := make([]string, 0, len(p.Settings))
+
+	for k := range p.Settings {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	Out(ctx, "")
+
+	for _, k := range keys {
+		Outf(ctx, "%s = %s", k, p.Settings[k])
+	}
+
+	return nil
+}
+
+// The config use subcommand.
+// `corso config use <name> [<flag>...]`
+func useCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   UseCommand + " <name>",
+		Short: "Set the current profile",
+		Long: `Set the profile used whenever --profile and the CORSO_PROFILE env var aren't provided.
+The profile must already exist in the config file.`,
+		RunE:    handleUseCmd,
+		Args:    cobra.ExactArgs(1),
+		Example: useExamples,
+	}
+}
+
+// Handler for calls to `corso config use`.
+func handleUseCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	if err := corsoConfig.UseProfile(ctx, args[0]); err != nil {
+		return Only(ctx, err)
+	}
+
+	Infof(ctx, "Using profile %s", strings.ToLower(args[0]))
+
+	return nil
+}
+
+// profile prints a config file profile.
+type profile struct {
+	corsoConfig.Profile
+}
+
+// MinimumPrintable reduces the profile to its printable fields.
+func (p profile) MinimumPrintable() any {
+	return p.Profile
+}
+
+// Headers returns the column names used when printing the profile.
+func (p profile) Headers(bool) []string {
+	return []string{"Name", "Active", "Provider", "Tenant ID", "Repo ID"}
+}
+
+// Values returns the column values used when printing the profile.
+func (p profile) Values(bool) []string {
+	var active string
+
+	if p.Active {
+		active = "*"
+	}
+
+	return []string{p.Name, active, p.Provider(), p.TenantID(), p.RepoID()}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	corsoConfig "github.com/alcionai/corso/src/pkg/config"
+)
+
+type ConfigUnitSuite struct {
+	tester.Suite
+}
+
+func TestConfigUnitSuite(t *testing.T) {
+	suite.Run(t, &ConfigUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ConfigUnitSuite) TestAddConfigCommands() {
+	t := suite.T()
+	cmd := &cobra.Command{}
+
+	AddCommands(cmd)
+
+	cmds := cmd.Commands()
+	require.Len(t, cmds, 1)
+	assert.Equal(t, configCommand, cmds[0].Use)
+
+	found := map[string]bool{}
+
+	for _, c := range cmds[0].Commands() {
+		found[c.Name()] = true
+	}
+
+	for _, name := range []string{ListCommand, ShowCommand, UseCommand} {
+		assert.True(t, found[name], "looking for %s command", name)
+	}
+}
+
+func (suite *ConfigUnitSuite) TestProfileValues() {
+	table := []struct {
+		name    string
+		profile corsoConfig.Profile
+		expect  []string
+	}{
+		{
+			name: "active",
+			profile: corsoConfig.Profile{
+				Name:   "prod-eu",
+				Active: true,
+				Settings: map[string]string{
+					"provider":       "S3",
+					"azure_tenantid": "tid",
+					"repo_id":        "rid",
+				},
+			},
+			expect: []string{"prod-eu", "*", "S3", "tid", "rid"},
+		},
+		{
+			name: "inactive",
+			profile: corsoConfig.Profile{
+				Name:     "dev",
+				Settings: map[string]string{"provider": "filesystem"},
+			},
+			expect: []string{"dev", "", "filesystem", "", ""},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+			p := profile{test.profile}
+
+			assert.Equal(t, test.expect, p.Values(false))
+			assert.Len(t, p.Headers(false), len(test.expect))
+		})
+	}
+}
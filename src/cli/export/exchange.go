@@ -1,11 +1,15 @@
 package export
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/control"
 )
 
 // called by export.go to map subcommands to provider-specific handling.
@@ -21,6 +25,8 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddExchangeDetailsAndRestoreFlags(c, true)
 		flags.AddExportConfigFlags(c)
+		flags.AddDryRunFlag(c)
+		flags.AddExportAggregateFlag(c)
 		flags.AddFailFastFlag(c)
 	}
 
@@ -31,24 +37,25 @@ const (
 	exchangeServiceCommand          = "exchange"
 	exchangeServiceCommandUseSuffix = "<destination> --backup <backupId>"
 
-	// TODO(meain): remove message about only supporting email exports once others are added
 	//nolint:lll
-	exchangeServiceCommandExportExamples = `> Only email exports are supported as of now.
-
-# Export emails with ID 98765abcdef and 12345abcdef from Alice's last backup (1234abcd...) to my-folder
+	exchangeServiceCommandExportExamples = `# Export emails with ID 98765abcdef and 12345abcdef from Alice's last backup (1234abcd...) to my-folder
 corso export exchange my-folder --backup 1234abcd-12ab-cd34-56de-1234abcd --email 98765abcdef,12345abcdef
 
 # Export emails with subject containing "Hello world" in the "Inbox" to my-folder
 corso export exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
-    --email-subject "Hello world" --email-folder Inbox my-folder`
+    --email-subject "Hello world" --email-folder Inbox my-folder
+
+# Export emails with attachments that are smaller than 1MB to my-folder
+corso export exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --email-has-attachments true --email-size-less-than 1MB my-folder
 
-// TODO(meain): Uncomment once support for these are added
-// 		`# Export an entire calendar to my-folder
-// corso export exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
-//     --event-calendar Calendar my-folder
+# Export an entire calendar to my-folder as a single .ics file
+corso export exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --event-calendar Calendar --aggregate my-folder
 
-// # Export the contact with ID abdef0101 to my-folder
-// corso export exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --contact abdef0101 my-folder`
+# Export all contacts to my-folder as a csv file per contacts folder, for importing into Outlook
+corso export exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --contact-folder '*' --format csv my-folder`
 )
 
 // `corso export exchange [<flag>...] <destination>`
@@ -58,7 +65,7 @@ func exchangeExportCmd() *cobra.Command {
 		Short: "Export M365 Exchange service data",
 		RunE:  exportExchangeCmd,
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) != 1 {
+			if len(args) != 1 && len(flags.OutputFV) == 0 {
 				return errors.New("missing export destination")
 			}
 
@@ -86,6 +93,10 @@ func exportExchangeCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if strings.EqualFold(opts.ExportCfg.Format, string(control.CSVFormat)) && !onlyContacts(opts) {
+		Info(ctx, "--format csv only applies to contacts.  Emails and events are exported as .eml and .ics files.")
+	}
+
 	sel := utils.IncludeExchangeRestoreDataSelectors(opts)
 	utils.FilterExchangeRestoreInfoSelectors(sel, opts)
 
@@ -96,6 +107,14 @@ func exportExchangeCmd(cmd *cobra.Command, args []string) error {
 		opts.ExportCfg,
 		sel.Selector,
 		flags.BackupIDFV,
-		"Exchange",
-		defaultAcceptedFormatTypes)
+		"Exchange")
+}
+
+// onlyContacts returns true if the export is limited to contacts.
+func onlyContacts(opts utils.ExchangeOpts) bool {
+	lc, lcf := len(opts.Contact), len(opts.ContactFolder)
+	le, lef := len(opts.Email), len(opts.EmailFolder)
+	lev, lec := len(opts.Event), len(opts.EventCalendar)
+
+	return lc+lcf > 0 && le+lef+lev+lec == 0
 }
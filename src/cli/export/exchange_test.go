@@ -55,6 +55,18 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 						"--" + flags.BackupFN, flagsTD.BackupInput,
 						"--" + flags.FormatFN, flagsTD.FormatType,
 						"--" + flags.ArchiveFN,
+						"--" + flags.AggregateFN,
+						"--" + flags.CollisionsFN, flagsTD.ExportCollisions,
+						"--" + flags.WriteParallelismFN, flagsTD.WriteParallelismInput,
+						"--" + flags.ExportEndpointFN, flagsTD.ExportEndpoint,
+						"--" + flags.ArchiveFormatFN, flagsTD.ArchiveFormat,
+						"--" + flags.ArchiveSplitSizeFN, flagsTD.ArchiveSplitSize,
+						"--" + flags.ArchivePasswordFileFN, flagsTD.ArchivePasswordFile,
+						"--" + flags.ArchiveRecipientFN, flagsTD.FlgInputs(flagsTD.ArchiveRecipients),
+						"--" + flags.ManifestFileFN, flagsTD.ManifestFile,
+						"--" + flags.ManifestSigningKeyFN, flagsTD.ManifestSigningKey,
+						"--" + flags.ExportDoNotUseTLSFN,
+						"--" + flags.ExportDoNotVerifyTLSFN,
 					},
 					flagsTD.PreparedProviderFlags(),
 					flagsTD.PreparedStorageFlags()))
@@ -71,7 +83,19 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 
 			assert.Equal(t, flagsTD.BackupInput, flags.BackupIDFV)
 			assert.Equal(t, flagsTD.Archive, opts.ExportCfg.Archive)
+			assert.True(t, opts.ExportCfg.Aggregate)
 			assert.Equal(t, flagsTD.FormatType, opts.ExportCfg.Format)
+			assert.Equal(t, flagsTD.ExportCollisions, opts.ExportCfg.Collisions)
+			assert.Equal(t, flagsTD.WriteParallelism, opts.ExportCfg.WriteParallelism)
+			assert.Equal(t, flagsTD.ExportEndpoint, opts.ExportCfg.Endpoint)
+			assert.Equal(t, flagsTD.ArchiveFormat, opts.ExportCfg.ArchiveFormat)
+			assert.Equal(t, flagsTD.ArchiveSplitSize, opts.ExportCfg.ArchiveSplitSize)
+			assert.Equal(t, flagsTD.ArchivePasswordFile, opts.ExportCfg.ArchivePasswordFile)
+			assert.ElementsMatch(t, flagsTD.ArchiveRecipients, opts.ExportCfg.ArchiveRecipients)
+			assert.Equal(t, flagsTD.ManifestFile, opts.ExportCfg.ManifestFile)
+			assert.Equal(t, flagsTD.ManifestSigningKey, opts.ExportCfg.ManifestSigningKey)
+			assert.True(t, opts.ExportCfg.DoNotUseTLS)
+			assert.True(t, opts.ExportCfg.DoNotVerifyTLS)
 			flagsTD.AssertStorageFlags(t, cmd)
 		})
 	}
@@ -2,6 +2,7 @@ package export
 
 import (
 	"context"
+	"crypto/ed25519"
 	"errors"
 
 	"github.com/alcionai/clues"
@@ -15,6 +16,7 @@ import (
 	"github.com/alcionai/corso/src/internal/observe"
 	"github.com/alcionai/corso/src/internal/operations"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/export"
 	"github.com/alcionai/corso/src/pkg/selectors"
@@ -27,8 +29,6 @@ var exportCommands = []func(cmd *cobra.Command) *cobra.Command{
 	addExchangeCommands,
 }
 
-var defaultAcceptedFormatTypes = []string{string(control.DefaultFormat)}
-
 // AddCommands attaches all `corso export * *` commands to the parent.
 func AddCommands(cmd *cobra.Command) {
 	subCommand := exportCmd()
@@ -38,6 +38,9 @@ func AddCommands(cmd *cobra.Command) {
 		sc := addExportTo(subCommand)
 		flags.AddAllStorageFlags(sc)
 	}
+
+	// verification only reads local files, and doesn't need a repository.
+	subCommand.AddCommand(verifyCmd())
 }
 
 const exportCommand = "export"
@@ -67,9 +70,13 @@ func runExport(
 	ueco utils.ExportCfgOpts,
 	sel selectors.Selector,
 	backupID, serviceName string,
-	acceptedFormatTypes []string,
 ) error {
-	if err := utils.ValidateExportConfigFlags(&ueco, acceptedFormatTypes); err != nil {
+	if err := utils.ValidateExportConfigFlags(&ueco, utils.ExportFormats(sel.PathService())); err != nil {
+		return Only(ctx, err)
+	}
+
+	signingKey, err := utils.LoadManifestSigningKey(ueco.ManifestSigningKey)
+	if err != nil {
 		return Only(ctx, err)
 	}
 
@@ -80,23 +87,48 @@ func runExport(
 
 	defer utils.CloseRepo(ctx, r)
 
-	exportLocation := args[0]
+	exportLocation, err := exportLocationFrom(args, ueco)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
 	if len(exportLocation) == 0 {
 		// This should not be possible, but adding it just in case.
 		exportLocation = control.DefaultRestoreLocation + dttm.FormatNow(dttm.HumanReadableDriveItem)
 	}
 
-	Infof(ctx, "Exporting to folder %s", exportLocation)
+	var dest export.Destination
+
+	if exportLocation == flags.StdoutOutput {
+		dest = export.NewWriterDestination(cmd.OutOrStdout(), "stdout")
+	} else {
+		dest, err = utils.MakeExportDestination(exportLocation, ueco)
+		if err != nil {
+			return Only(ctx, clues.Wrap(err, "Failed to configure export destination"))
+		}
+	}
+
+	exportCfg := utils.MakeExportConfig(ctx, ueco)
 
 	eo, err := r.NewExport(
 		ctx,
 		backupID,
 		sel,
-		utils.MakeExportConfig(ctx, ueco))
+		exportCfg)
 	if err != nil {
 		return Only(ctx, clues.Wrap(err, "Failed to initialize "+serviceName+" export"))
 	}
 
+	if flags.IsDryRun() {
+		return planExport(ctx, eo, dest, backupID, serviceName)
+	}
+
+	if utils.IsS3ExportLocation(exportLocation) || exportLocation == flags.StdoutOutput {
+		Infof(ctx, "Exporting to %s", dest)
+	} else {
+		Infof(ctx, "Exporting to folder %s", exportLocation)
+	}
+
 	collections, err := eo.Run(ctx)
 	if err != nil {
 		if errors.Is(err, data.ErrNotFound) {
@@ -106,10 +138,18 @@ func runExport(
 		return Only(ctx, clues.Wrap(err, "Failed to run "+serviceName+" export"))
 	}
 
-	if err = showExportProgress(ctx, eo, collections, exportLocation); err != nil {
+	ctr := count.New()
+
+	if err = showExportProgress(ctx, eo, exportCfg, collections, dest, ctr); err != nil {
 		return err
 	}
 
+	// the manifest is written even if some items failed, since it records
+	// exactly what was exported.
+	if err := writeManifest(ctx, eo.Manifest(), signingKey, dest, exportLocation, ueco); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to write export manifest"))
+	}
+
 	if len(eo.Errors.Recovered()) > 0 {
 		Infof(ctx, "\nExport failures")
 
@@ -129,6 +169,94 @@ func runExport(
 		Infof(ctx, "%s: %d items (%s)", k.HumanString(), s.ResourceCount, humanize.Bytes(uint64(s.BytesRead)))
 	}
 
+	if skipped := ctr.Get(count.CollisionSkip); skipped > 0 {
+		Infof(ctx, "Skipped %d items that already exist in %s", skipped, dest)
+	}
+
+	if renamed := ctr.Get(count.CollisionRename); renamed > 0 {
+		Infof(ctx, "Renamed %d items that collided with existing files", renamed)
+	}
+
+	if replaced := ctr.Get(count.CollisionReplace); replaced > 0 {
+		Infof(ctx, "Overwrote %d existing files", replaced)
+	}
+
+	return nil
+}
+
+// planExport prints the changes that the export would make, without
+// reading or writing any item data.
+func planExport(
+	ctx context.Context,
+	eo operations.ExportOperation,
+	dest export.Destination,
+	backupID, serviceName string,
+) error {
+	p, err := eo.Plan(ctx, dest)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return Only(ctx, clues.New("Backup or backup details missing for id "+backupID))
+		}
+
+		return Only(ctx, clues.Wrap(err, "Failed to plan "+serviceName+" export"))
+	}
+
+	if len(eo.Errors.Recovered()) > 0 {
+		Infof(ctx, "\nExport failures")
+
+		for _, i := range eo.Errors.Recovered() {
+			Err(ctx, i.Error())
+		}
+
+		return Only(ctx, clues.New("Incomplete plan of "+serviceName+" export"))
+	}
+
+	utils.PrintPlan(ctx, p)
+
+	return nil
+}
+
+// exportLocationFrom picks the export location out of either the
+// positional argument or the --output flag.
+func exportLocationFrom(args []string, ueco utils.ExportCfgOpts) (string, error) {
+	if len(ueco.Output) == 0 {
+		return args[0], nil
+	}
+
+	if len(args) > 0 {
+		return "", clues.New("export destination provided as both an argument and --" + flags.OutputFN)
+	}
+
+	return ueco.Output, nil
+}
+
+// writeManifest signs the manifest, if a key was provided, and writes it
+// to the manifest file, or else to the root of the export destination.
+// Exports streamed to stdout only produce a manifest when given a
+// manifest file.
+func writeManifest(
+	ctx context.Context,
+	manifest export.Manifest,
+	signingKey ed25519.PrivateKey,
+	dest export.Destination,
+	exportLocation string,
+	ueco utils.ExportCfgOpts,
+) error {
+	if len(ueco.ManifestFile) == 0 && exportLocation == flags.StdoutOutput {
+		return nil
+	}
+
+	err := utils.WriteExportManifest(ctx, manifest, signingKey, dest, ueco.ManifestFile)
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	if len(ueco.ManifestFile) > 0 {
+		Infof(ctx, "Wrote export manifest to %s", ueco.ManifestFile)
+	} else {
+		Infof(ctx, "Wrote export manifest %s", export.ManifestFileName)
+	}
+
 	return nil
 }
 
@@ -136,15 +264,23 @@ func runExport(
 func showExportProgress(
 	ctx context.Context,
 	op operations.ExportOperation,
+	exportCfg control.ExportConfig,
 	collections []export.Collectioner,
-	exportLocation string,
+	dest export.Destination,
+	ctr *count.Bus,
 ) error {
 	// It would be better to give a progressbar than a spinner, but we
 	// have any way of knowing how many files are available as of now.
-	progressMessage := observe.MessageWithCompletion(ctx, observe.DefaultCfg(), "Writing data to disk")
+	progressMessage := observe.MessageWithCompletion(ctx, observe.DefaultCfg(), "Writing data to "+dest.String())
 	defer close(progressMessage)
 
-	err := export.ConsumeExportCollections(ctx, exportLocation, collections, op.Errors)
+	err := export.ConsumeExportCollectionsTo(
+		ctx,
+		dest,
+		exportCfg,
+		collections,
+		ctr,
+		op.Errors)
 	if err != nil {
 		return Only(ctx, err)
 	}
@@ -6,7 +6,6 @@ import (
 
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/cli/utils"
-	"github.com/alcionai/corso/src/pkg/control"
 )
 
 // called by export.go to map subcommands to provider-specific handling.
@@ -25,6 +24,7 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddSharePointDetailsAndRestoreFlags(c)
 		flags.AddGroupDetailsAndRestoreFlags(c)
 		flags.AddExportConfigFlags(c)
+		flags.AddDryRunFlag(c)
 		flags.AddFailFastFlag(c)
 	}
 
@@ -56,7 +56,10 @@ corso export groups my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd \
 corso export groups my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd --conversation "hello world"
 
 # Export post with ID 98765abcdef from a conversation from group mailbox's last backup to /my-exports
-corso export groups my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd --conversation "hello world" --post 98765abcdef`
+corso export groups my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd --conversation "hello world" --post 98765abcdef
+
+# Export the tasks in the Planner plan "Launch" as a csv file to /my-exports
+corso export groups my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd --plan Launch --format csv`
 )
 
 // `corso export groups [<flag>...] <destination>`
@@ -67,7 +70,7 @@ func groupsExportCmd() *cobra.Command {
 		Short:   "Export M365 Groups service data",
 		RunE:    exportGroupsCmd,
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) != 1 {
+			if len(args) != 1 && len(flags.OutputFV) == 0 {
 				return errors.New("missing export destination")
 			}
 
@@ -98,11 +101,6 @@ func exportGroupsCmd(cmd *cobra.Command, args []string) error {
 	sel := utils.IncludeGroupsRestoreDataSelectors(ctx, opts)
 	utils.FilterGroupsRestoreInfoSelectors(sel, opts)
 
-	acceptedGroupsFormatTypes := []string{
-		string(control.DefaultFormat),
-		string(control.JSONFormat),
-	}
-
 	return runExport(
 		ctx,
 		cmd,
@@ -110,6 +108,5 @@ func exportGroupsCmd(cmd *cobra.Command, args []string) error {
 		opts.ExportCfg,
 		sel.Selector,
 		flags.BackupIDFV,
-		"Groups",
-		acceptedGroupsFormatTypes)
+		"Groups")
 }
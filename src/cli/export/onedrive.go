@@ -21,6 +21,7 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddOneDriveDetailsAndRestoreFlags(c)
 		flags.AddExportConfigFlags(c)
+		flags.AddDryRunFlag(c)
 		flags.AddFailFastFlag(c)
 	}
 
@@ -41,7 +42,10 @@ corso export onedrive . --backup 1234abcd-12ab-cd34-56de-1234abcd \
 
 # Export all files and folders in folder "Documents/Finance Reports" that were created before 2020 to /my-exports
 corso export onedrive my-exports --backup 1234abcd-12ab-cd34-56de-1234abcd \
-    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00`
+    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00
+
+# Export all of Bob's files directly into the "exports" prefix of an S3 bucket
+corso export onedrive s3://my-bucket/exports --backup 1234abcd-12ab-cd34-56de-1234abcd`
 )
 
 // `corso export onedrive [<flag>...] <destination>`
@@ -51,7 +55,7 @@ func oneDriveExportCmd() *cobra.Command {
 		Short: "Export M365 OneDrive service data",
 		RunE:  exportOneDriveCmd,
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) != 1 {
+			if len(args) != 1 && len(flags.OutputFV) == 0 {
 				return errors.New("missing export destination")
 			}
 
@@ -89,6 +93,5 @@ func exportOneDriveCmd(cmd *cobra.Command, args []string) error {
 		opts.ExportCfg,
 		sel.Selector,
 		flags.BackupIDFV,
-		"OneDrive",
-		defaultAcceptedFormatTypes)
+		"OneDrive")
 }
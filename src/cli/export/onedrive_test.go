@@ -64,6 +64,9 @@ func (suite *OneDriveUnitSuite) TestAddOneDriveCommands() {
 
 						// bool flags
 						"--" + flags.ArchiveFN,
+						"--" + flags.DryRunFN,
+						"--" + flags.CollisionsFN, flagsTD.ExportCollisions,
+						"--" + flags.WriteParallelismFN, flagsTD.WriteParallelismInput,
 					},
 					flagsTD.PreparedProviderFlags(),
 					flagsTD.PreparedStorageFlags()))
@@ -85,6 +88,7 @@ func (suite *OneDriveUnitSuite) TestAddOneDriveCommands() {
 			assert.Equal(t, flagsTD.FileCreatedBeforeInput, opts.FileCreatedBefore)
 			assert.Equal(t, flagsTD.FileModifiedAfterInput, opts.FileModifiedAfter)
 			assert.Equal(t, flagsTD.FileModifiedBeforeInput, opts.FileModifiedBefore)
+			assert.True(t, flags.DryRunFV)
 			assert.Equal(t, flagsTD.CorsoPassphrase, flags.PassphraseFV)
 			flagsTD.AssertStorageFlags(t, cmd)
 		})
@@ -21,6 +21,7 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddSharePointDetailsAndRestoreFlags(c)
 		flags.AddExportConfigFlags(c)
+		flags.AddDryRunFlag(c)
 		flags.AddFailFastFlag(c)
 	}
 
@@ -75,7 +76,7 @@ func sharePointExportCmd() *cobra.Command {
 		Short: "Export M365 SharePoint service data",
 		RunE:  exportSharePointCmd,
 		Args: func(cmd *cobra.Command, args []string) error {
-			if len(args) != 1 {
+			if len(args) != 1 && len(flags.OutputFV) == 0 {
 				return errors.New("missing export destination")
 			}
 
@@ -113,6 +114,5 @@ func exportSharePointCmd(cmd *cobra.Command, args []string) error {
 		opts.ExportCfg,
 		sel.Selector,
 		flags.BackupIDFV,
-		"SharePoint",
-		defaultAcceptedFormatTypes)
+		"SharePoint")
 }
@@ -0,0 +1,135 @@
+package export
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/export"
+)
+
+const (
+	verifyCommand = "verify"
+
+	verifyCommandExamples = `# Verify the files in /my-exports against the manifest written by the export
+corso export verify /my-exports
+
+# Verify an export against a manifest kept elsewhere, and check that it was signed by the expected key
+corso export verify /my-exports --manifest case-1234-manifest.json --public-key signer.pub.pem`
+)
+
+// `corso export verify <dir> [--manifest <file>] [--public-key <file>]`
+func verifyCmd() *cobra.Command {
+	c := &cobra.Command{
+		Use:     verifyCommand + " <directory>",
+		Short:   "Verify exported files against the export manifest",
+		Long:    `Re-hash the exported files and compare them to the hashes recorded in the export manifest.`,
+		RunE:    verifyExportCmd,
+		Args:    cobra.ExactArgs(1),
+		Example: verifyCommandExamples,
+	}
+
+	flags.AddExportVerifyFlags(c)
+
+	return c
+}
+
+func verifyExportCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	return runVerify(ctx, args[0], flags.ManifestFV, flags.PublicKeyFV)
+}
+
+func runVerify(
+	ctx context.Context,
+	dir, manifestFile, publicKeyFile string,
+) error {
+	if len(manifestFile) == 0 {
+		manifestFile = filepath.Join(dir, export.ManifestFileName)
+	}
+
+	bs, err := os.ReadFile(manifestFile)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to read export manifest"))
+	}
+
+	var manifest export.Manifest
+
+	if err := json.Unmarshal(bs, &manifest); err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to parse export manifest"))
+	}
+
+	if err := verifyManifestSignature(ctx, manifest, publicKeyFile); err != nil {
+		return Only(ctx, err)
+	}
+
+	mismatches, err := manifest.VerifyFiles(dir)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to verify exported files"))
+	}
+
+	if len(mismatches) > 0 {
+		Infof(ctx, "\nVerification failures")
+
+		for _, mm := range mismatches {
+			Errf(ctx, "%s: %s", mm.Path, mm.Reason)
+		}
+
+		return Only(ctx, clues.New("Exported files do not match the manifest"))
+	}
+
+	verified := len(manifest.Files)
+	if len(manifest.Archives) > 0 {
+		verified = len(manifest.Archives)
+	}
+
+	Infof(ctx, "Verified %d files against the manifest for backup %s", verified, manifest.BackupID)
+
+	return nil
+}
+
+// verifyManifestSignature checks the manifest signature, if there is one.
+// Providing a public key requires the manifest to be signed by that key.
+func verifyManifestSignature(
+	ctx context.Context,
+	manifest export.Manifest,
+	publicKeyFile string,
+) error {
+	var key ed25519.PublicKey
+
+	if len(publicKeyFile) > 0 {
+		bs, err := os.ReadFile(publicKeyFile)
+		if err != nil {
+			return clues.Wrap(err, "reading --"+flags.PublicKeyFN)
+		}
+
+		key, err = export.ParseVerifyingKey(bs)
+		if err != nil {
+			return clues.Stack(err)
+		}
+	}
+
+	if manifest.Signature == nil && key == nil {
+		Info(ctx, "Manifest is not signed")
+		return nil
+	}
+
+	if err := manifest.VerifySignature(key); err != nil {
+		return clues.Wrap(err, "verifying manifest signature")
+	}
+
+	Info(ctx, "Manifest signature is valid")
+
+	return nil
+}
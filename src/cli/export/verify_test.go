@@ -0,0 +1,141 @@
+package export
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/export"
+)
+
+type VerifyUnitSuite struct {
+	tester.Suite
+}
+
+func TestVerifyUnitSuite(t *testing.T) {
+	suite.Run(t, &VerifyUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *VerifyUnitSuite) TestRunVerify() {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	var (
+		sum       = sha256.Sum256([]byte("alpha"))
+		keyDir    = suite.T().TempDir()
+		pubFile   = filepath.Join(keyDir, "signer.pub.pem")
+		otherFile = filepath.Join(keyDir, "other.pub.pem")
+	)
+
+	writePublicKey(suite.T(), pubFile, pub)
+	writePublicKey(suite.T(), otherFile, otherPub)
+
+	table := []struct {
+		name          string
+		fileBody      string
+		sign          bool
+		publicKeyFile string
+		expectErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "unsigned match",
+			fileBody:  "alpha",
+			expectErr: assert.NoError,
+		},
+		{
+			name:          "signed match",
+			fileBody:      "alpha",
+			sign:          true,
+			publicKeyFile: pubFile,
+			expectErr:     assert.NoError,
+		},
+		{
+			name:      "modified file",
+			fileBody:  "alphz",
+			sign:      true,
+			expectErr: assert.Error,
+		},
+		{
+			name:          "signed by another key",
+			fileBody:      "alpha",
+			sign:          true,
+			publicKeyFile: otherFile,
+			expectErr:     assert.Error,
+		},
+		{
+			name:          "unsigned with public key",
+			fileBody:      "alpha",
+			publicKeyFile: pubFile,
+			expectErr:     assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			cmd := &cobra.Command{}
+			cmd.SetOut(&strings.Builder{})
+			cmd.SetErr(&strings.Builder{})
+
+			ctx = print.SetRootCmd(ctx, cmd)
+
+			dir := t.TempDir()
+
+			err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte(test.fileBody), 0o644)
+			require.NoError(t, err, clues.ToCore(err))
+
+			m := export.Manifest{
+				BackupID: "bid",
+				Files: []export.ManifestEntry{{
+					Path:   "a.txt",
+					Size:   5,
+					SHA256: hex.EncodeToString(sum[:]),
+				}},
+			}
+
+			if test.sign {
+				require.NoError(t, m.Sign(priv))
+			}
+
+			bs, err := json.Marshal(m)
+			require.NoError(t, err, clues.ToCore(err))
+
+			err = os.WriteFile(filepath.Join(dir, export.ManifestFileName), bs, 0o644)
+			require.NoError(t, err, clues.ToCore(err))
+
+			err = runVerify(ctx, dir, "", test.publicKeyFile)
+			test.expectErr(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func writePublicKey(t *testing.T, file string, pub ed25519.PublicKey) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	require.NoError(t, os.WriteFile(file, bs, 0o644))
+}
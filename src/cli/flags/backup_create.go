@@ -4,8 +4,45 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	ExcludeExtensionFN = "exclude-extension"
+	ExcludeNameFN      = "exclude-name"
+	ExcludePathFN      = "exclude-path"
+	MaxFileSizeFN      = "max-file-size"
+)
+
+var (
+	ExcludeExtensionFV []string
+	ExcludeNameFV      []string
+	ExcludePathFV      []string
+	MaxFileSizeFV      string
+)
+
 func AddGenericBackupFlags(cmd *cobra.Command) {
 	AddFailFastFlag(cmd)
 	AddDisableIncrementalsFlag(cmd)
 	AddForceItemDataDownloadFlag(cmd)
+	AddLabelFlag(cmd)
+}
+
+// AddDriveExclusionFlags adds the flags that leave drive files out of a backup.
+func AddDriveExclusionFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringSliceVar(
+		&ExcludeExtensionFV,
+		ExcludeExtensionFN, nil,
+		"Skip files with these extensions; accepts comma-separated values (ex: iso,pst)")
+	fs.StringSliceVar(
+		&ExcludeNameFV,
+		ExcludeNameFN, nil,
+		"Skip files whose names match these glob patterns; accepts comma-separated values")
+	fs.StringSliceVar(
+		&ExcludePathFV,
+		ExcludePathFN, nil,
+		"Skip files within folders matching these glob patterns, relative to the drive root")
+	fs.StringVar(
+		&MaxFileSizeFV,
+		MaxFileSizeFN, "",
+		"Skip files larger than this size (ex: 500MB, 2GiB)")
 }
@@ -36,3 +36,56 @@ func AddAlertsFN(cmd *cobra.Command) {
 		&ListAlertsFV, AlertsFN, Show,
 		"Toggles showing or hiding the list of alerts produced during the operation.")
 }
+
+const (
+	LabelFN       = "label"
+	RemoveLabelFN = "remove-label"
+	ResourceFN    = "resource"
+	SinceFN       = "since"
+	StatusFN      = "status"
+	UntilFN       = "until"
+)
+
+var (
+	LabelFV       []string
+	RemoveLabelFV []string
+	ResourceFV    string
+	SinceFV       string
+	StatusFV      string
+	UntilFV       string
+)
+
+// AddBackupListFilterFlags adds the flags that narrow down the listed backups.
+func AddBackupListFilterFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringVar(
+		&ResourceFV, ResourceFN, "",
+		"Only list backups of the protected resource with this ID or name.")
+	fs.StringVar(
+		&SinceFV, SinceFN, "",
+		"Only list backups created at or after this time.")
+	fs.StringVar(
+		&UntilFV, UntilFN, "",
+		"Only list backups created at or before this time.")
+	fs.StringVar(
+		&StatusFV, StatusFN, "",
+		"Only list backups with this status (ex: Completed).")
+	fs.StringSliceVar(
+		&LabelFV, LabelFN, nil,
+		"Only list backups with this label; accepts key=value pairs and may be repeated.")
+}
+
+// AddLabelFlag adds the flag that attaches labels to new backups.
+func AddLabelFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(
+		&LabelFV, LabelFN, nil,
+		"Attach a label to the backup; accepts key=value pairs and may be repeated.")
+}
+
+// AddRemoveLabelFlag adds the flag that drops labels from a backup.
+func AddRemoveLabelFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(
+		&RemoveLabelFV, RemoveLabelFN, nil,
+		"Remove the label with this key from the backup; may be repeated.")
+}
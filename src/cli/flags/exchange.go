@@ -9,12 +9,17 @@ const (
 	ContactFolderFN = "contact-folder"
 	ContactNameFN   = "contact-name"
 
-	EmailFN               = "email"
-	EmailFolderFN         = "email-folder"
-	EmailReceivedAfterFN  = "email-received-after"
-	EmailReceivedBeforeFN = "email-received-before"
-	EmailSenderFN         = "email-sender"
-	EmailSubjectFN        = "email-subject"
+	EmailFN                = "email"
+	EmailFolderFN          = "email-folder"
+	EmailReceivedAfterFN   = "email-received-after"
+	EmailReceivedBeforeFN  = "email-received-before"
+	EmailSenderFN          = "email-sender"
+	EmailSubjectFN         = "email-subject"
+	EmailRecipientFN       = "email-recipient"
+	EmailSizeGreaterThanFN = "email-size-greater-than"
+	EmailSizeLessThanFN    = "email-size-less-than"
+	EmailHasAttachmentsFN  = "email-has-attachments"
+	EmailAttachmentNameFN  = "email-attachment-name"
 
 	EventFN             = "event"
 	EventCalendarFN     = "event-calendar"
@@ -23,6 +28,8 @@ const (
 	EventStartsAfterFN  = "event-starts-after"
 	EventStartsBeforeFN = "event-starts-before"
 	EventSubjectFN      = "event-subject"
+
+	SettingsOnlyFN = "settings-only"
 )
 
 // flag values (ie: FV)
@@ -31,12 +38,17 @@ var (
 	ContactFolderFV []string
 	ContactNameFV   string
 
-	EmailFV               []string
-	EmailFolderFV         []string
-	EmailReceivedAfterFV  string
-	EmailReceivedBeforeFV string
-	EmailSenderFV         string
-	EmailSubjectFV        string
+	EmailFV                []string
+	EmailFolderFV          []string
+	EmailReceivedAfterFV   string
+	EmailReceivedBeforeFV  string
+	EmailSenderFV          string
+	EmailSubjectFV         string
+	EmailRecipientFV       string
+	EmailSizeGreaterThanFV string
+	EmailSizeLessThanFV    string
+	EmailHasAttachmentsFV  string
+	EmailAttachmentNameFV  string
 
 	EventFV             []string
 	EventCalendarFV     []string
@@ -45,6 +57,8 @@ var (
 	EventStartsAfterFV  string
 	EventStartsBeforeFV string
 	EventSubjectFV      string
+
+	SettingsOnlyFV bool
 )
 
 // AddExchangeDetailsAndRestoreFlags adds flags that are common to both the
@@ -77,6 +91,26 @@ func AddExchangeDetailsAndRestoreFlags(cmd *cobra.Command, emailOnly bool) {
 		&EmailReceivedBeforeFV,
 		EmailReceivedBeforeFN, "",
 		"Select emails received before this datetime.")
+	fs.StringVar(
+		&EmailRecipientFV,
+		EmailRecipientFN, "",
+		"Select emails sent to a specific recipient.")
+	fs.StringVar(
+		&EmailSizeGreaterThanFV,
+		EmailSizeGreaterThanFN, "",
+		"Select emails larger than this size, including attachments.  Accepts bytes or units (ex: 10MB).")
+	fs.StringVar(
+		&EmailSizeLessThanFV,
+		EmailSizeLessThanFN, "",
+		"Select emails smaller than this size, including attachments.  Accepts bytes or units (ex: 10MB).")
+	fs.StringVar(
+		&EmailHasAttachmentsFV,
+		EmailHasAttachmentsFN, "",
+		"Select emails with attachments. Use `--email-has-attachments false` to select emails without attachments.")
+	fs.StringVar(
+		&EmailAttachmentNameFV,
+		EmailAttachmentNameFN, "",
+		"Select emails with an attachment whose name contains this value.")
 
 	// NOTE: Only temporary until we add support for exporting the
 	// others as well in exchange.
@@ -127,4 +161,11 @@ func AddExchangeDetailsAndRestoreFlags(cmd *cobra.Command, emailOnly bool) {
 		&ContactNameFV,
 		ContactNameFN, "",
 		"Select contacts whose contact name contains this value.")
+
+	// mailbox settings flags
+	fs.BoolVar(
+		&SettingsOnlyFV,
+		SettingsOnlyFN, false,
+		"Select only the mailbox settings (inbox rules, master categories, automatic replies, "+
+			"and calendar permissions), and none of the mail, event, or contact items.")
 }
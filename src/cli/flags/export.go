@@ -2,22 +2,133 @@ package flags
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/pkg/control"
 )
 
 const (
-	ArchiveFN = "archive"
-	FormatFN  = "format"
+	AggregateFN             = "aggregate"
+	ArchiveFN               = "archive"
+	ArchiveFormatFN         = "archive-format"
+	ArchivePasswordFN       = "archive-password"
+	ArchivePasswordFileFN   = "archive-password-file"
+	ArchiveRecipientFN      = "archive-recipient"
+	ArchiveRecipientsFileFN = "archive-recipients-file"
+	ArchiveSplitSizeFN      = "archive-split-size"
+	FormatFN                = "format"
+	ManifestFileFN          = "manifest-file"
+	ManifestSigningKeyFN    = "manifest-signing-key"
+	OutputFN                = "output"
+	WriteParallelismFN      = "write-parallelism"
+
+	// flags used by `corso export verify`.
+	ManifestFN  = "manifest"
+	PublicKeyFN = "public-key"
+
+	// StdoutOutput, when passed as the output, streams the export
+	// archive to stdout.
+	StdoutOutput = "-"
+
+	// flags used when the export destination is an s3://bucket/prefix url.
+	ExportEndpointFN       = "export-endpoint"
+	ExportDoNotUseTLSFN    = "export-disable-tls"
+	ExportDoNotVerifyTLSFN = "export-disable-tls-verification"
 )
 
 var (
-	ArchiveFV bool
-	FormatFV  string
+	AggregateFV             bool
+	ArchiveFV               bool
+	ArchiveFormatFV         string
+	ArchivePasswordFV       string
+	ArchivePasswordFileFV   string
+	ArchiveRecipientFV      []string
+	ArchiveRecipientsFileFV string
+	ArchiveSplitSizeFV      string
+	FormatFV                string
+	ManifestFileFV          string
+	ManifestSigningKeyFV    string
+	OutputFV                string
+	ExportCollisionsFV      string
+	WriteParallelismFV      int
+
+	ExportEndpointFV       string
+	ExportDoNotUseTLSFV    bool
+	ExportDoNotVerifyTLSFV bool
+
+	ManifestFV  string
+	PublicKeyFV string
 )
 
 // AddExportConfigFlags adds the restore config flag set.
 func AddExportConfigFlags(cmd *cobra.Command) {
 	fs := cmd.Flags()
 	fs.BoolVar(&ArchiveFV, ArchiveFN, false, "Export data as an archive instead of individual files")
+	fs.StringVar(
+		&ArchiveFormatFV, ArchiveFormatFN, string(control.ZipArchive),
+		//nolint:lll
+		"Archive type to produce with --"+ArchiveFN+": "+string(control.ZipArchive)+", "+string(control.TarArchive)+", or "+string(control.TarZstArchive))
+	fs.StringVar(
+		&ArchiveSplitSizeFV, ArchiveSplitSizeFN, "",
+		"Split the archive into numbered volumes no larger than this size, eg: 4GB")
+	fs.StringVar(
+		&ArchivePasswordFV, ArchivePasswordFN, "",
+		"Encrypt the zip archive with AES-256 using this password.  "+
+			"Use --"+ArchivePasswordFileFN+" or CORSO_ARCHIVE_PASSWORD on shared machines")
+	fs.StringVar(
+		&ArchivePasswordFileFV, ArchivePasswordFileFN, "",
+		"Path to a file containing the archive password")
+	fs.StringSliceVar(
+		&ArchiveRecipientFV, ArchiveRecipientFN, nil,
+		"Encrypt the archive to this age public key (age1...).  Can be repeated for multiple recipients")
+	fs.StringVar(
+		&ArchiveRecipientsFileFV, ArchiveRecipientsFileFN, "",
+		"Path to an age recipients file, with one public key per line")
+	fs.StringVar(
+		&OutputFV, OutputFN, "",
+		"Export destination, in place of the positional argument.  Use '"+StdoutOutput+"' to stream the archive to stdout")
+	fs.StringVar(
+		&ManifestFileFV, ManifestFileFN, "",
+		"Write the export manifest to this file instead of the root of the export destination")
+	fs.StringVar(
+		&ManifestSigningKeyFV, ManifestSigningKeyFN, "",
+		"Path to a PEM encoded ed25519 private key used to sign the export manifest")
 	fs.StringVar(&FormatFV, FormatFN, "", "Specify the export file format")
 	cobra.CheckErr(fs.MarkHidden(FormatFN))
+	fs.StringVar(
+		&ExportCollisionsFV, CollisionsFN, string(control.DefaultExportCollisionPolicy),
+		//nolint:lll
+		"Sets the behavior for files that already exist in the export location: "+string(control.ExportSkip)+", "+string(control.ExportRename)+", or "+string(control.ExportOverwrite))
+	fs.IntVar(
+		&WriteParallelismFV, WriteParallelismFN, control.DefaultExportWriteParallelism,
+		"Maximum number of files written to disk at the same time")
+	fs.StringVar(
+		&ExportEndpointFV, ExportEndpointFN, "",
+		"S3 service endpoint used when exporting to an s3://bucket/prefix destination.")
+	fs.BoolVar(
+		&ExportDoNotUseTLSFV, ExportDoNotUseTLSFN, false,
+		"Disable TLS (HTTPS) when exporting to an s3:// destination.")
+	fs.BoolVar(
+		&ExportDoNotVerifyTLSFV, ExportDoNotVerifyTLSFN, false,
+		"Disable TLS (HTTPS) certificate verification when exporting to an s3:// destination.")
+}
+
+// AddExportAggregateFlag adds the flag for exporting each calendar or
+// contacts folder as a single file.
+func AddExportAggregateFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.BoolVar(
+		&AggregateFV, AggregateFN, false,
+		"Export each calendar as a single .ics file, and each contacts folder as a single .vcf file")
+}
+
+// AddExportVerifyFlags adds the flags for verifying an export against
+// its manifest.
+func AddExportVerifyFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(
+		&ManifestFV, ManifestFN, "",
+		"Path to the export manifest.  Defaults to the manifest in the root of the export directory")
+	fs.StringVar(
+		&PublicKeyFV, PublicKeyFN, "",
+		"Path to the PEM encoded ed25519 public key expected to have signed the manifest")
 }
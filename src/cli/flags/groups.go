@@ -7,6 +7,7 @@ import (
 const (
 	DataMessages      = "messages"
 	DataConversations = "conversations"
+	DataPlanner       = "planner"
 )
 
 const (
@@ -14,12 +15,18 @@ const (
 	ConversationFN = "conversation"
 	GroupFN        = "group"
 	MessageFN      = "message"
+	PlanFN         = "plan"
 	PostFN         = "post"
+	TaskFN         = "task"
 
 	MessageCreatedAfterFN    = "message-created-after"
 	MessageCreatedBeforeFN   = "message-created-before"
 	MessageLastReplyAfterFN  = "message-last-reply-after"
 	MessageLastReplyBeforeFN = "message-last-reply-before"
+
+	TaskAssigneeFN  = "task-assignee"
+	TaskDueAfterFN  = "task-due-after"
+	TaskDueBeforeFN = "task-due-before"
 )
 
 var (
@@ -27,12 +34,18 @@ var (
 	ConversationFV []string
 	GroupFV        []string
 	MessageFV      []string
+	PlanFV         []string
 	PostFV         []string
+	TaskFV         []string
 
 	MessageCreatedAfterFV    string
 	MessageCreatedBeforeFV   string
 	MessageLastReplyAfterFV  string
 	MessageLastReplyBeforeFV string
+
+	TaskAssigneeFV  string
+	TaskDueAfterFV  string
+	TaskDueBeforeFV string
 )
 
 func AddGroupDetailsAndRestoreFlags(cmd *cobra.Command) {
@@ -77,6 +90,40 @@ func AddGroupDetailsAndRestoreFlags(cmd *cobra.Command) {
 		&PostFV,
 		PostFN, nil,
 		"Select Conversation Posts by reference.")
+
+	AddGroupsPlannerFlags(cmd)
+}
+
+// AddGroupsPlannerFlags adds the planner plan and task selection flags.
+// Planner data is restorable, unlike the rest of the group details flags,
+// so restore commands add these flags on their own.
+func AddGroupsPlannerFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringSliceVar(
+		&PlanFV,
+		PlanFN, nil,
+		"Select Planner plans by title.")
+
+	fs.StringSliceVar(
+		&TaskFV,
+		TaskFN, nil,
+		"Select Planner tasks by title or reference.")
+
+	fs.StringVar(
+		&TaskAssigneeFV,
+		TaskAssigneeFN, "",
+		"Select Planner tasks assigned to this user ID.")
+
+	fs.StringVar(
+		&TaskDueAfterFV,
+		TaskDueAfterFN, "",
+		"Select Planner tasks due after this datetime.")
+
+	fs.StringVar(
+		&TaskDueBeforeFV,
+		TaskDueBeforeFN, "",
+		"Select Planner tasks due before this datetime.")
 }
 
 // AddGroupFlag adds the --group flag, which accepts either the id,
@@ -0,0 +1,28 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const SourceFN = "source"
+
+var SourceFV string
+
+// AddImportMailBoxFlag adds the --mailbox flag, the single mailbox that
+// receives imported data.
+func AddImportMailBoxFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(
+		&UserFV,
+		MailBoxFN, nil,
+		"Import into a specific mailbox.")
+	cobra.CheckErr(cmd.MarkFlagRequired(MailBoxFN))
+}
+
+// AddImportSourceFlag adds the --source flag.
+func AddImportSourceFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&SourceFV,
+		SourceFN, "",
+		"Local directory containing the .eml, .ics, and .vcf files to import.")
+	cobra.CheckErr(cmd.MarkFlagRequired(SourceFN))
+}
@@ -0,0 +1,39 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	HoldCreatedByFN = "created-by"
+	HoldNameFN      = "name"
+	HoldReasonFN    = "reason"
+)
+
+var (
+	HoldCreatedByFV string
+	HoldNameFV      string
+	HoldReasonFV    string
+)
+
+// AddLegalHoldFlags adds the flags that pick the backups a legal hold
+// applies to.  Placing a hold also records its reason and creator.
+func AddLegalHoldFlags(cmd *cobra.Command, place bool) {
+	fs := cmd.Flags()
+
+	fs.StringVar(&HoldNameFV, HoldNameFN, "", "Name of the legal hold.")
+	cobra.CheckErr(cmd.MarkFlagRequired(HoldNameFN))
+
+	fs.StringVar(
+		&ResourceFV, ResourceFN, "",
+		"Apply to every backup of the protected resource with this ID or name.")
+
+	if !place {
+		return
+	}
+
+	fs.StringVar(&HoldReasonFV, HoldReasonFN, "", "Why the backups are held.")
+	fs.StringVar(
+		&HoldCreatedByFV, HoldCreatedByFN, "",
+		"Who placed the hold.  Defaults to the current user.")
+}
@@ -0,0 +1,17 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const WebDAVFN = "webdav"
+
+var WebDAVFV string
+
+// AddMountFlags adds the flags used by `corso backup mount`.
+func AddMountFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&WebDAVFV,
+		WebDAVFN, "127.0.0.1:8080",
+		"Address on which to serve the backup as a read-only WebDAV share.")
+}
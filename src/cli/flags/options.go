@@ -12,6 +12,7 @@ const (
 	DisableIncrementalsFN         = "disable-incrementals"
 	DisableLazyItemReaderFN       = "disable-lazy-item-reader"
 	DisableSlidingWindowLimiterFN = "disable-sliding-window-limiter"
+	DryRunFN                      = "dry-run"
 	ForceItemDataDownloadFN       = "force-item-data-download"
 	EnableImmutableIDFN           = "enable-immutable-id"
 	FailFastFN                    = "fail-fast"
@@ -19,6 +20,8 @@ const (
 	FetchParallelismFN            = "fetch-parallelism"
 	NoPermissionsFN               = "no-permissions"
 	NoStatsFN                     = "no-stats"
+	NoTimestampsFN                = "no-timestamps"
+	ProfileFN                     = "profile"
 	RecoveredErrorsFN             = "recovered-errors"
 	RunModeFN                     = "run-mode"
 	SkippedItemsFN                = "skipped-items"
@@ -32,6 +35,7 @@ var (
 	DisableIncrementalsFV         bool
 	DisableLazyItemReaderFV       bool
 	DisableSlidingWindowLimiterFV bool
+	DryRunFV                      bool
 	ForceItemDataDownloadFV       bool
 	EnableImmutableIDFV           bool
 	FailFastFV                    bool
@@ -42,6 +46,8 @@ var (
 	ListRecoveredErrorsFV         string
 	NoPermissionsFV               bool
 	NoStatsFV                     bool
+	NoTimestampsFV                bool
+	ProfileFV                     string
 	// RunMode describes the type of run, such as:
 	// flagtest, dry, run.  Should default to 'run'.
 	RunModeFV    string
@@ -50,6 +56,7 @@ var (
 
 // well-known flag values
 const (
+	RunModeDry      = "dry"
 	RunModeFlagTest = "flag-test"
 	RunModeRun      = "run"
 )
@@ -68,12 +75,35 @@ func AddFailFastFlag(cmd *cobra.Command) {
 	cobra.CheckErr(fs.MarkHidden(FailFastFN))
 }
 
+// AddDryRunFlag adds the flag that plans the command's changes
+// instead of making them.
+func AddDryRunFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.BoolVar(
+		&DryRunFV, DryRunFN, false,
+		"Print the changes that would be made, without writing any data")
+}
+
+// IsDryRun is true if either --dry-run or the dry run mode was requested.
+func IsDryRun() bool {
+	return DryRunFV || RunModeFV == RunModeDry
+}
+
 // AddNoPermissionsFlag adds OneDrive flag for skipping restoring permissions
 func AddNoPermissionsFlag(cmd *cobra.Command) {
 	fs := cmd.Flags()
 	fs.BoolVar(&NoPermissionsFV, NoPermissionsFN, false, "don't restore file and folder permissions")
 }
 
+// AddNoTimestampsFlag adds the flag for skipping restoring the created and
+// modified times of files.
+func AddNoTimestampsFlag(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.BoolVar(
+		&NoTimestampsFV, NoTimestampsFN, false,
+		"don't restore the original created and modified times of files; they get the time of the restore instead")
+}
+
 // AddSkipReduceFlag adds a hidden flag that allows callers to skip the selector
 // reduction step.  Currently only intended for details commands, not restore.
 func AddSkipReduceFlag(cmd *cobra.Command) {
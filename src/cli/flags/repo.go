@@ -12,8 +12,9 @@ const (
 	AWSSessionTokenFN    = "aws-session-token"
 
 	// Corso Flags
-	PassphraseFN    = "passphrase"
-	NewPassphraseFN = "new-passphrase"
+	PassphraseFN     = "passphrase"
+	PassphraseFileFN = "passphrase-file"
+	NewPassphraseFN  = "new-passphrase"
 )
 
 var (
@@ -23,6 +24,7 @@ var (
 	AWSSecretAccessKeyFV string
 	AWSSessionTokenFV    string
 	PassphraseFV         string
+	PassphraseFileFV     string
 	NewPhasephraseFV     string
 )
 
@@ -71,7 +73,13 @@ func AddCorsoPassphaseFlags(cmd *cobra.Command) {
 		&PassphraseFV,
 		PassphraseFN,
 		"",
-		"Passphrase to protect encrypted repository contents")
+		"Passphrase to protect encrypted repository contents.  Visible to other users in the process list; "+
+			"--"+PassphraseFileFN+", CORSO_PASSPHRASE, or a credential_process in the config file keep it hidden")
+	fs.StringVar(
+		&PassphraseFileFV,
+		PassphraseFileFN,
+		"",
+		"Path to a file containing the passphrase")
 }
 
 // M365 flags
@@ -10,12 +10,24 @@ const (
 	CollisionsFN  = "collisions"
 	DestinationFN = "destination"
 	ToResourceFN  = "to-resource"
+
+	MappingFileFN        = "mapping-file"
+	PermissionMapFN      = "permission-map"
+	TargetTenantIDFN     = "target-tenant-id"
+	TargetClientIDFN     = "target-client-id"
+	TargetClientSecretFN = "target-client-secret"
 )
 
 var (
 	CollisionsFV  string
 	DestinationFV string
 	ToResourceFV  string
+
+	MappingFileFV        string
+	PermissionMapFV      string
+	TargetTenantIDFV     string
+	TargetClientIDFV     string
+	TargetClientSecretFV string
 )
 
 // AddRestoreConfigFlags adds the restore config flag set.
@@ -35,3 +47,32 @@ func AddRestoreConfigFlags(cmd *cobra.Command, canRestoreToAlternate bool) {
 			"Overrides the protected resource (mailbox, site, user, etc) where data gets restored")
 	}
 }
+
+// AddRestoreTargetFlags adds the flags used to restore into another tenant,
+// along with the mapping of backed up users and groups onto their
+// counterparts in the restore target.
+func AddRestoreTargetFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringVar(
+		&MappingFileFV, MappingFileFN, "",
+		"Path to a csv of 'source,target' rows that maps backed up users and groups to the users and groups "+
+			"that receive the restored data")
+	fs.StringVar(
+		&TargetTenantIDFV, TargetTenantIDFN, "",
+		"Restores into this Azure tenant instead of the tenant that was backed up")
+	fs.StringVar(
+		&TargetClientIDFV, TargetClientIDFN, "",
+		"Azure app client ID used to write into the target tenant; defaults to the backed up tenant's client ID")
+	fs.StringVar(
+		&TargetClientSecretFV, TargetClientSecretFN, "",
+		"Azure app client secret used to write into the target tenant; prefer the TARGET_AZURE_CLIENT_SECRET env var")
+}
+
+// AddPermissionMapFlag adds the flag that maps the users and groups
+// of restored drive item permissions and link shares.
+func AddPermissionMapFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&PermissionMapFV, PermissionMapFN, "",
+		"Path to a csv of 'source,target' rows that maps the users and groups of restored permissions and "+
+			"sharing links; a '*' source grants the permissions of missing users and groups to the target instead")
+}
@@ -0,0 +1,19 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const ScheduleConfigFN = "config"
+
+var ScheduleConfigFV string
+
+// AddScheduleConfigFlag adds the --config flag, the yaml file that
+// describes the scheduled jobs.
+func AddScheduleConfigFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&ScheduleConfigFV,
+		ScheduleConfigFN, "",
+		"Path to the yaml file that describes the scheduled backups and maintenance.")
+	cobra.CheckErr(cmd.MarkFlagRequired(ScheduleConfigFN))
+}
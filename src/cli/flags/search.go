@@ -0,0 +1,31 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	IndexDirFN = "index-dir"
+	QueryFN    = "query"
+)
+
+var (
+	IndexDirFV string
+	QueryFV    string
+)
+
+// AddSearchFlags adds the flags used by `corso backup search`.
+func AddSearchFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringVar(
+		&QueryFV,
+		QueryFN, "",
+		"Terms to search for; items must contain every term.  End a term with * to match by prefix.")
+	cobra.CheckErr(cmd.MarkFlagRequired(QueryFN))
+
+	fs.StringVar(
+		&IndexDirFV,
+		IndexDirFN, "",
+		"Directory for storing search indexes; defaults to the user's cache directory")
+}
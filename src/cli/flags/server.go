@@ -0,0 +1,52 @@
+package flags
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const (
+	ServerAddressFN    = "address"
+	ServerTokenFileFN  = "token-file"
+	ServerTLSCertFN    = "tls-cert"
+	ServerTLSKeyFN     = "tls-key"
+	ServerExportRootFN = "export-root"
+)
+
+var (
+	ServerAddressFV    string
+	ServerTokenFileFV  string
+	ServerTLSCertFV    string
+	ServerTLSKeyFV     string
+	ServerExportRootFV string
+)
+
+// AddServerFlags adds the flags that configure the rest api server.
+func AddServerFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+
+	fs.StringVar(
+		&ServerAddressFV,
+		ServerAddressFN, "127.0.0.1:8080",
+		"Address the server listens on.")
+	fs.StringVar(
+		&ServerTokenFileFV,
+		ServerTokenFileFN, "",
+		"File containing the bearer token that clients must present.  Overrides the CORSO_SERVER_TOKEN env var.")
+	fs.StringVar(
+		&ServerTLSCertFV,
+		ServerTLSCertFN, "",
+		"TLS certificate file.  The server only serves HTTPS when both a certificate and key are provided.")
+	fs.StringVar(
+		&ServerTLSKeyFV,
+		ServerTLSKeyFN, "",
+		"TLS private key file.")
+	fs.StringVar(
+		&ServerExportRootFV,
+		ServerExportRootFN, "",
+		"Directory that exports started through the api get written beneath.  Export destinations are "+
+			"resolved relative to it, and may not leave it.  Exports are refused when no root is provided.")
+	fs.StringVar(
+		&ManifestSigningKeyFV,
+		ManifestSigningKeyFN, "",
+		"Path to a PEM encoded ed25519 private key used to sign the manifests of exports started through the api.")
+}
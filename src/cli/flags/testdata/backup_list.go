@@ -15,6 +15,11 @@ func PreparedBackupListFlags() []string {
 		"--" + flags.FailedItemsFN, flags.Show,
 		"--" + flags.SkippedItemsFN, flags.Show,
 		"--" + flags.RecoveredErrorsFN, flags.Show,
+		"--" + flags.ResourceFN, ResourceInput,
+		"--" + flags.SinceFN, SinceInput,
+		"--" + flags.UntilFN, UntilInput,
+		"--" + flags.StatusFN, StatusInput,
+		"--" + flags.LabelFN, FlgInputs(LabelInput),
 	}
 }
 
@@ -23,4 +28,9 @@ func AssertBackupListFlags(t *testing.T, cmd *cobra.Command) {
 	assert.Equal(t, flags.Show, flags.FailedItemsFV)
 	assert.Equal(t, flags.Show, flags.ListSkippedItemsFV)
 	assert.Equal(t, flags.Show, flags.ListRecoveredErrorsFV)
+	assert.Equal(t, ResourceInput, flags.ResourceFV)
+	assert.Equal(t, SinceInput, flags.SinceFV)
+	assert.Equal(t, UntilInput, flags.UntilFV)
+	assert.Equal(t, StatusInput, flags.StatusFV)
+	assert.DeepEqual(t, LabelInput, flags.LabelFV)
 }
@@ -12,6 +12,10 @@ var (
 	BackupInput = "backup-id"
 	SiteInput   = "site-id"
 
+	BackupIDsInput = []string{"backup-id1", "backup-id2"}
+	QueryInput     = "project falcon*"
+	IndexDirInput  = "index-dir"
+
 	GroupsInput  = []string{"team1", "group2"}
 	MailboxInput = []string{"mailbox1", "mailbox2"}
 	UsersInput   = []string{"users1", "users2"}
@@ -37,12 +41,23 @@ var (
 	ConversationInput = []string{"conversation1", "conversation2"}
 	PostInput         = []string{"post1", "post2"}
 
+	PlanInput          = []string{"plan1", "plan2"}
+	TaskInput          = []string{"task1", "task2"}
+	TaskAssigneeInput  = "taskAssignee"
+	TaskDueAfterInput  = "taskDueAfter"
+	TaskDueBeforeInput = "taskDueBefore"
+
 	EmailInput               = []string{"mail1", "mail2"}
 	EmailFldInput            = []string{"mailFld1", "mailFld2"}
 	EmailReceivedAfterInput  = "mailReceivedAfter"
 	EmailReceivedBeforeInput = "mailReceivedBefore"
 	EmailSenderInput         = "mailSender"
 	EmailSubjectInput        = "mailSubject"
+	EmailRecipientInput      = "mailRecipient"
+	EmailSizeGreaterInput    = "mailSizeGreater"
+	EmailSizeLessInput       = "mailSizeLess"
+	EmailHasAttachmentsInput = "mailHasAttachments"
+	EmailAttachmentNameInput = "mailAttachmentName"
 
 	EventInput             = []string{"event1", "event2"}
 	EventCalInput          = []string{"eventCal1", "eventCal2"}
@@ -60,6 +75,11 @@ var (
 	FileModifiedAfterInput  = "fileModifiedAfter"
 	FileModifiedBeforeInput = "fileModifiedBefore"
 
+	ExcludeExtensionInput = []string{"iso", "pst"}
+	ExcludeNameInput      = []string{"~$*", "*.tmp"}
+	ExcludePathInput      = []string{"Archive", "*/node_modules"}
+	MaxFileSizeInput      = "500MB"
+
 	ListsInput              = []string{"listName1", "listName2"}
 	ListCreatedAfterInput   = "listCreatedAfter"
 	ListCreatedBeforeInput  = "listCreatedBefore"
@@ -74,11 +94,28 @@ var (
 	ToResource      = "toResource"
 	SkipPermissions = false
 
+	MappingFile        = "/tmp/corso-mapping.csv"
+	PermissionMap      = "/tmp/corso-permission-map.csv"
+	TargetTenantID     = "testTargetTenantId"
+	TargetClientID     = "testTargetClientId"
+	TargetClientSecret = "testTargetClientSecret"
+
 	DeltaPageSize = "7"
 
 	Archive    = true
 	FormatType = "json"
 
+	ExportCollisions      = "rename"
+	WriteParallelism      = 3
+	WriteParallelismInput = "3"
+	ExportEndpoint        = "minio.example.com:9000"
+	ArchiveFormat         = "tar.zst"
+	ArchiveSplitSize      = "4GB"
+	ArchivePasswordFile   = "/run/secrets/corso-archive-password"
+	ArchiveRecipients     = []string{"age1recipient1", "age1recipient2"}
+	ManifestFile          = "/evidence/case-1234-manifest.json"
+	ManifestSigningKey    = "/run/secrets/corso-manifest-key.pem"
+
 	AzureClientID     = "testAzureClientId"
 	AzureTenantID     = "testAzureTenantId"
 	AzureClientSecret = "testAzureClientSecret"
@@ -87,10 +124,25 @@ var (
 	AWSSecretAccessKey = "testAWSSecretAccessKey"
 	AWSSessionToken    = "testAWSSessionToken"
 
-	CorsoPassphrase = "testCorsoPassphrase"
+	CorsoPassphrase     = "testCorsoPassphrase"
+	CorsoPassphraseFile = "/tmp/corso-passphrase"
 
 	RestoreDestination = "test-restore-destination"
 
+	ImportSource = "/tmp/corso-import"
+
+	ScheduleConfig = "/tmp/corso-schedule.yaml"
+
+	ResourceInput = "finance@example.com"
+	SinceInput    = "2024-01-01"
+	UntilInput    = "2024-02-01T00:00:00Z"
+	StatusInput   = "Completed"
+	LabelInput    = []string{"stage=pre-migration", "dept=finance"}
+
+	HoldNameInput      = "case-1234"
+	HoldReasonInput    = "Finance audit"
+	HoldCreatedByInput = "legal@example.com"
+
 	FetchParallelism = "3"
 
 	FailFast              = true
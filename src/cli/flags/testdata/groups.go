@@ -40,3 +40,21 @@ func AssertConversationFlags(t *testing.T, cmd *cobra.Command) {
 	assert.Equal(t, ConversationInput, flags.ConversationFV)
 	assert.Equal(t, PostInput, flags.PostFV)
 }
+
+func PreparedPlannerFlags() []string {
+	return []string{
+		"--" + flags.PlanFN, FlgInputs(PlanInput),
+		"--" + flags.TaskFN, FlgInputs(TaskInput),
+		"--" + flags.TaskAssigneeFN, TaskAssigneeInput,
+		"--" + flags.TaskDueAfterFN, TaskDueAfterInput,
+		"--" + flags.TaskDueBeforeFN, TaskDueBeforeInput,
+	}
+}
+
+func AssertPlannerFlags(t *testing.T, cmd *cobra.Command) {
+	assert.ElementsMatch(t, PlanInput, flags.PlanFV)
+	assert.ElementsMatch(t, TaskInput, flags.TaskFV)
+	assert.Equal(t, TaskAssigneeInput, flags.TaskAssigneeFV)
+	assert.Equal(t, TaskDueAfterInput, flags.TaskDueAfterFV)
+	assert.Equal(t, TaskDueBeforeInput, flags.TaskDueBeforeFV)
+}
@@ -16,6 +16,7 @@ func PreparedStorageFlags() []string {
 		"--" + flags.AWSSessionTokenFN, AWSSessionToken,
 
 		"--" + flags.PassphraseFN, CorsoPassphrase,
+		"--" + flags.PassphraseFileFN, CorsoPassphraseFile,
 	}
 }
 
@@ -25,6 +26,7 @@ func AssertStorageFlags(t *testing.T, cmd *cobra.Command) {
 	assert.Equal(t, AWSSessionToken, flags.AWSSessionTokenFV)
 
 	assert.Equal(t, CorsoPassphrase, flags.PassphraseFV)
+	assert.Equal(t, CorsoPassphraseFile, flags.PassphraseFileFV)
 }
 
 func PreparedProviderFlags() []string {
@@ -46,6 +48,7 @@ func PreparedGenericBackupFlags() []string {
 		"--" + flags.FailFastFN,
 		"--" + flags.DisableIncrementalsFN,
 		"--" + flags.ForceItemDataDownloadFN,
+		"--" + flags.LabelFN, FlgInputs(LabelInput),
 	}
 }
 
@@ -53,4 +56,21 @@ func AssertGenericBackupFlags(t *testing.T, cmd *cobra.Command) {
 	assert.True(t, flags.FailFastFV, "fail fast flag")
 	assert.True(t, flags.DisableIncrementalsFV, "disable incrementals flag")
 	assert.True(t, flags.ForceItemDataDownloadFV, "force item data download flag")
+	assert.ElementsMatch(t, LabelInput, flags.LabelFV, "label flag")
+}
+
+func PreparedDriveExclusionFlags() []string {
+	return []string{
+		"--" + flags.ExcludeExtensionFN, FlgInputs(ExcludeExtensionInput),
+		"--" + flags.ExcludeNameFN, FlgInputs(ExcludeNameInput),
+		"--" + flags.ExcludePathFN, FlgInputs(ExcludePathInput),
+		"--" + flags.MaxFileSizeFN, MaxFileSizeInput,
+	}
+}
+
+func AssertDriveExclusionFlags(t *testing.T, cmd *cobra.Command) {
+	assert.ElementsMatch(t, ExcludeExtensionInput, flags.ExcludeExtensionFV)
+	assert.ElementsMatch(t, ExcludeNameInput, flags.ExcludeNameFV)
+	assert.ElementsMatch(t, ExcludePathInput, flags.ExcludePathFV)
+	assert.Equal(t, MaxFileSizeInput, flags.MaxFileSizeFV)
 }
@@ -66,11 +66,21 @@ var (
 	corsoEVs = []envVar{
 		{corso, "CORSO_PASSPHRASE", "Passphrase to protect encrypted repository contents. " +
 			"It is impossible to use the repository or recover any backups without this key."},
+		{corso, "CORSO_ARCHIVE_PASSWORD", "Password used to encrypt zip archives produced by exports. " +
+			"Used when neither --archive-password nor --archive-password-file is provided."},
+		{corso, "CORSO_PROFILE", "Named profile in the config file to use. " +
+			"Used when --profile isn't provided, in place of the config file's current profile."},
 	}
 	azureEVs = []envVar{
 		{azure, "AZURE_CLIENT_ID", "Client ID for your Azure AD application used to access your M365 tenant."},
 		{azure, "AZURE_TENANT_ID", "ID for the M365 tenant where the Azure AD application is registered."},
 		{azure, "AZURE_CLIENT_SECRET", "Azure secret for your Azure AD application used to access your M365 tenant."},
+		{azure, "TARGET_AZURE_TENANT_ID", "ID of the M365 tenant that receives restored data. " +
+			"Used when --target-tenant-id isn't provided."},
+		{azure, "TARGET_AZURE_CLIENT_ID", "Client ID for the Azure AD application used to restore into the target tenant. " +
+			"Defaults to AZURE_CLIENT_ID."},
+		{azure, "TARGET_AZURE_CLIENT_SECRET", "Azure secret for the application used to restore into the target tenant. " +
+			"Defaults to AZURE_CLIENT_SECRET."},
 	}
 	awsEVs = []envVar{
 		{aws, "AWS_ACCESS_KEY_ID", "Access key for an IAM user or role for accessing an S3 bucket."},
@@ -0,0 +1,69 @@
+package imports
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// called by imports.go to map subcommands to provider-specific handling.
+func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case importCommand:
+		c, _ = utils.AddCommand(cmd, exchangeImportCmd())
+
+		c.Use = c.Use + " " + exchangeServiceCommandUseSuffix
+
+		flags.AddImportMailBoxFlag(c)
+		flags.AddImportSourceFlag(c)
+		flags.AddRestoreConfigFlags(c, false)
+		flags.AddFailFastFlag(c)
+	}
+
+	return c
+}
+
+const (
+	exchangeServiceCommand          = "exchange"
+	exchangeServiceCommandUseSuffix = "--mailbox <email> --source <directory>"
+
+	//nolint:lll
+	exchangeServiceCommandImportExamples = `# Import the .eml, .ics, and .vcf files in /exports/alice into Alice's mailbox
+corso import exchange --mailbox alice@example.com --source /exports/alice
+
+# Re-import an export into its original folders, replacing any existing copies
+corso import exchange --mailbox alice@example.com --source /exports/alice \
+    --destination '/' --collisions replace`
+)
+
+// `corso import exchange [<flag>...]`
+func exchangeImportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     exchangeServiceCommand,
+		Short:   "Import local email, calendar, and contact files into an M365 Exchange mailbox",
+		RunE:    importExchangeCmd,
+		Args:    cobra.NoArgs,
+		Example: exchangeServiceCommandImportExamples,
+	}
+}
+
+// processes an exchange service import.
+func importExchangeCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	opts := utils.MakeImportOpts(cmd)
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	return runImport(ctx, cmd, opts, path.ExchangeService, "Exchange")
+}
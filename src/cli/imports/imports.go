@@ -0,0 +1,101 @@
+package imports
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+var importCommands = []func(cmd *cobra.Command) *cobra.Command{
+	addExchangeCommands,
+}
+
+// AddCommands attaches all `corso import * *` commands to the parent.
+func AddCommands(cmd *cobra.Command) {
+	subCommand := importCmd()
+	cmd.AddCommand(subCommand)
+
+	for _, addImportTo := range importCommands {
+		sc := addImportTo(subCommand)
+		flags.AddAllProviderFlags(sc)
+		flags.AddAllStorageFlags(sc)
+	}
+}
+
+const importCommand = "import"
+
+// The import category of commands.
+// `corso import [<subcommand>] [<flag>...]`
+func importCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   importCommand,
+		Short: "Import local files into your service data",
+		Long:  `Upload local files, such as those produced by an export, into one of your M365 services.`,
+		RunE:  handleImportCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// Handler for flat calls to `corso import`.
+// Produces the same output as `corso import --help`.
+func handleImportCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// ---------------------------------------------------------------------------
+// common handlers
+// ---------------------------------------------------------------------------
+
+func runImport(
+	ctx context.Context,
+	cmd *cobra.Command,
+	opts utils.ImportOpts,
+	service path.ServiceType,
+	serviceName string,
+) error {
+	if err := utils.ValidateImportFlags(opts); err != nil {
+		return Only(ctx, err)
+	}
+
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, service)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	iop, err := r.NewImport(
+		ctx,
+		opts.Mailbox[0],
+		opts.Source,
+		utils.MakeRestoreConfig(ctx, opts.RestoreCfg))
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to initialize "+serviceName+" import"))
+	}
+
+	ds, err := iop.Run(ctx)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to run "+serviceName+" import"))
+	}
+
+	Info(ctx, "Import Complete")
+
+	skipped := iop.Counter.Get(count.CollisionSkip)
+	if skipped > 0 {
+		Infof(ctx, "Skipped %d items due to collision", skipped)
+	}
+
+	dis := ds.Items()
+
+	Outf(ctx, "Imported %d items", len(dis))
+	dis.MaybePrintEntries(ctx)
+
+	return nil
+}
@@ -48,6 +48,8 @@ func AddCommands(cmd *cobra.Command) {
 		connectCmd          = connectCmd()
 		maintenanceCmd      = maintenanceCmd()
 		updatePassphraseCmd = updatePassphraseCmd()
+		statsCmd            = statsCmd()
+		statusCmd           = statusCmd()
 	)
 
 	cmd.AddCommand(repoCmd)
@@ -55,6 +57,8 @@ func AddCommands(cmd *cobra.Command) {
 	repoCmd.AddCommand(connectCmd)
 	repoCmd.AddCommand(maintenanceCmd)
 	repoCmd.AddCommand(updatePassphraseCmd)
+	repoCmd.AddCommand(statsCmd)
+	repoCmd.AddCommand(statusCmd)
 
 	flags.AddMaintenanceModeFlag(maintenanceCmd)
 	flags.AddForceMaintenanceFlag(maintenanceCmd)
@@ -63,6 +67,8 @@ func AddCommands(cmd *cobra.Command) {
 
 	flags.AddUpdatePassphraseFlags(updatePassphraseCmd, true)
 
+	flags.AddMultipleBackupIDsFlag(statsCmd, false)
+
 	for _, addRepoTo := range repoCommands {
 		addRepoTo(initCmd)
 		addRepoTo(connectCmd)
@@ -34,19 +34,26 @@ func (suite *RepoUnitSuite) TestAddRepoCommands() {
 
 	repo.AddCommands(cmd)
 
-	var found bool
+	var found, foundStats, foundStatus bool
 
 	// This is the repo command.
 	repoCmds := cmd.Commands()
 	require.Len(t, repoCmds, 1)
 
 	for _, c := range repoCmds[0].Commands() {
-		if c.Use == repo.MaintenanceCommand {
+		switch c.Use {
+		case repo.MaintenanceCommand:
 			found = true
+		case repo.StatsCommand:
+			foundStats = true
+		case repo.StatusCommand:
+			foundStatus = true
 		}
 	}
 
 	assert.True(t, found, "looking for maintenance command")
+	assert.True(t, foundStats, "looking for stats command")
+	assert.True(t, foundStatus, "looking for status command")
 }
 
 type RepoE2ESuite struct {
@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"context"
+	"slices"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/usage"
+)
+
+const StatsCommand = "stats"
+
+const statsExamples = `# Report the storage used by every backup in the repository
+corso repo stats
+
+# Report how much space deleting backup 1234abcd-12ab-cd34-56de-1234abcd would free
+corso repo stats --backups 1234abcd-12ab-cd34-56de-1234abcd
+
+# Report the storage usage as JSON
+corso repo stats --json`
+
+// The repo stats subcommand.
+// `corso repo stats [<flag>...]`
+func statsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   StatsCommand,
+		Short: "Report the storage used by the repository",
+		Long: `Report the total size of the repository, and the logical, stored, and unique size of
+each protected resource, service, and backup.  Unique size is the space that only those
+backups use, and is freed by deleting them once maintenance runs.  Producing the report
+reads every backup in the repository, so it may take a while.`,
+		RunE:    handleStatsCmd,
+		Args:    cobra.NoArgs,
+		Example: statsExamples,
+	}
+}
+
+// Handler for calls to `corso repo stats`.
+func handleStatsCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	// Need to give it a valid service so it won't error out on us even though
+	// we don't need the graph client.
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	report, err := r.StorageUsage(ctx)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to report repository storage usage"))
+	}
+
+	report.Backups = filterBackupUsage(report.Backups, flags.BackupIDsFV)
+
+	Item(ctx, report)
+
+	if DisplayJSONFormat() {
+		return nil
+	}
+
+	printUsageGroups(ctx, "Protected resources", report.Resources)
+	printUsageGroups(ctx, "Services", report.Services)
+
+	if len(report.Backups) > 0 {
+		ps := make([]Printable, 0, len(report.Backups))
+		for _, bu := range report.Backups {
+			ps = append(ps, bu)
+		}
+
+		Info(ctx, "\nBackups")
+		All(ctx, ps...)
+	}
+
+	return nil
+}
+
+// filterBackupUsage keeps only the usage of the listed backups.  All usage
+// is kept if no backups are listed.
+func filterBackupUsage(bus []usage.BackupUsage, ids []string) []usage.BackupUsage {
+	if len(ids) == 0 {
+		return bus
+	}
+
+	return slices.DeleteFunc(bus, func(bu usage.BackupUsage) bool {
+		return !slices.Contains(ids, bu.ID)
+	})
+}
+
+// printUsageGroups prints the usage of each group under the title.
+func printUsageGroups(ctx context.Context, title string, gus []usage.GroupUsage) {
+	if len(gus) == 0 {
+		return
+	}
+
+	ps := make([]Printable, 0, len(gus))
+	for _, gu := range gus {
+		ps = append(ps, gu)
+	}
+
+	Info(ctx, "\n"+title)
+	All(ctx, ps...)
+}
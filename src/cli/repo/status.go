@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+const StatusCommand = "status"
+
+const statusExamples = `# Show the state of the connected repository
+corso repo status
+
+# Show the state of the connected repository as JSON
+corso repo status --json`
+
+// The repo status subcommand.
+// `corso repo status [<flag>...]`
+func statusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   StatusCommand,
+		Short: "Show the state of the repository",
+		Long: `Show the repository's ID and version, where it's stored, its compression, object lock,
+and persistent configuration, when maintenance last ran, the number of backups of each
+service, and the incomplete backups awaiting cleanup.  Anything that needs attention,
+such as overdue maintenance, is listed as a warning.`,
+		RunE:    handleStatusCmd,
+		Args:    cobra.NoArgs,
+		Example: statusExamples,
+	}
+}
+
+// Handler for calls to `corso repo status`.
+func handleStatusCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	// Need to give it a valid service so it won't error out on us even though
+	// we don't need the graph client.
+	r, _, err := utils.GetAccountAndConnect(ctx, cmd, path.OneDriveService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	s, err := r.Status(ctx)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "Failed to get the repository status"))
+	}
+
+	Item(ctx, s)
+
+	if DisplayJSONFormat() {
+		return nil
+	}
+
+	retention := s.Retention.Mode
+	if s.Retention.Period > 0 {
+		retention += " for " + s.Retention.Period.String()
+	}
+
+	Outf(ctx, "\nVersion: %s (format %d)", s.Version, s.FormatVersion)
+	Outf(ctx, "Compression: %s", s.Compressor)
+	Outf(ctx, "Object lock: %s (extend locks: %t)", retention, s.Retention.ExtendObjectLocks)
+	Outf(ctx, "Minimum epoch duration: %s", s.MinEpochDuration)
+
+	Outf(ctx, "\nMaintenance owner: %s", s.Maintenance.Owner)
+	Outf(ctx, "Last metadata maintenance: %s", displayTime(s.Maintenance.LastMetadata))
+	Outf(ctx, "Last complete maintenance: %s", displayTime(s.Maintenance.LastComplete))
+
+	services := make([]string, 0, len(s.BackupsByService))
+	for svc, n := range s.BackupsByService {
+		services = append(services, svc+": "+strconv.Itoa(n))
+	}
+
+	sort.Strings(services)
+
+	Outf(ctx, "\nBackups by service: %s", strings.Join(services, ", "))
+	Outf(ctx, "Items awaiting cleanup: %d", s.OrphanedItems)
+
+	if len(s.Warnings) > 0 {
+		Out(ctx, "\nWarnings")
+
+		for _, w := range s.Warnings {
+			Out(ctx, "  - "+w)
+		}
+	}
+
+	return nil
+}
+
+func displayTime(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+
+	return dttm.FormatToTabularDisplay(*t)
+}
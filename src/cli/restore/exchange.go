@@ -20,6 +20,8 @@ func addExchangeCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddExchangeDetailsAndRestoreFlags(c, false)
 		flags.AddRestoreConfigFlags(c, true)
+		flags.AddRestoreTargetFlags(c)
+		flags.AddDryRunFlag(c)
 		flags.AddFailFastFlag(c)
 	}
 
@@ -38,12 +40,19 @@ corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --email 98765ab
 corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --email-subject "Hello world" --email-folder Inbox
 
+# Restore emails sent to bob@example.com which are larger than 10MB and have a pdf attached
+corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --email-recipient bob@example.com --email-size-greater-than 10MB --email-attachment-name .pdf
+
 # Restore an entire calendar
 corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --event-calendar Calendar
 
 # Restore the contact with ID abdef0101
-corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --contact abdef0101`
+corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --contact abdef0101
+
+# Restore only the mailbox settings: inbox rules are recreated and master categories merged
+corso restore exchange --backup 1234abcd-12ab-cd34-56de-1234abcd --settings-only`
 )
 
 // `corso restore exchange [<flag>...]`
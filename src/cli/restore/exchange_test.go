@@ -61,6 +61,11 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 						"--" + flags.EmailReceivedBeforeFN, flagsTD.EmailReceivedBeforeInput,
 						"--" + flags.EmailSenderFN, flagsTD.EmailSenderInput,
 						"--" + flags.EmailSubjectFN, flagsTD.EmailSubjectInput,
+						"--" + flags.EmailRecipientFN, flagsTD.EmailRecipientInput,
+						"--" + flags.EmailSizeGreaterThanFN, flagsTD.EmailSizeGreaterInput,
+						"--" + flags.EmailSizeLessThanFN, flagsTD.EmailSizeLessInput,
+						"--" + flags.EmailHasAttachmentsFN, flagsTD.EmailHasAttachmentsInput,
+						"--" + flags.EmailAttachmentNameFN, flagsTD.EmailAttachmentNameInput,
 						"--" + flags.EventFN, flagsTD.FlgInputs(flagsTD.EventInput),
 						"--" + flags.EventCalendarFN, flagsTD.FlgInputs(flagsTD.EventCalInput),
 						"--" + flags.EventOrganizerFN, flagsTD.EventOrganizerInput,
@@ -68,9 +73,15 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 						"--" + flags.EventStartsAfterFN, flagsTD.EventStartsAfterInput,
 						"--" + flags.EventStartsBeforeFN, flagsTD.EventStartsBeforeInput,
 						"--" + flags.EventSubjectFN, flagsTD.EventSubjectInput,
+						"--" + flags.SettingsOnlyFN,
 						"--" + flags.CollisionsFN, flagsTD.Collisions,
 						"--" + flags.DestinationFN, flagsTD.Destination,
+						"--" + flags.MappingFileFN, flagsTD.MappingFile,
+						"--" + flags.TargetTenantIDFN, flagsTD.TargetTenantID,
+						"--" + flags.TargetClientIDFN, flagsTD.TargetClientID,
+						"--" + flags.TargetClientSecretFN, flagsTD.TargetClientSecret,
 						"--" + flags.ToResourceFN, flagsTD.ToResource,
+						"--" + flags.DryRunFN,
 					},
 					flagsTD.PreparedProviderFlags(),
 					flagsTD.PreparedStorageFlags()))
@@ -95,6 +106,11 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 			assert.Equal(t, flagsTD.EmailReceivedBeforeInput, opts.EmailReceivedBefore)
 			assert.Equal(t, flagsTD.EmailSenderInput, opts.EmailSender)
 			assert.Equal(t, flagsTD.EmailSubjectInput, opts.EmailSubject)
+			assert.Equal(t, flagsTD.EmailRecipientInput, opts.EmailRecipient)
+			assert.Equal(t, flagsTD.EmailSizeGreaterInput, opts.EmailSizeGreaterThan)
+			assert.Equal(t, flagsTD.EmailSizeLessInput, opts.EmailSizeLessThan)
+			assert.Equal(t, flagsTD.EmailHasAttachmentsInput, opts.EmailHasAttachments)
+			assert.Equal(t, flagsTD.EmailAttachmentNameInput, opts.EmailAttachmentName)
 			assert.ElementsMatch(t, flagsTD.EventInput, opts.Event)
 			assert.ElementsMatch(t, flagsTD.EventCalInput, opts.EventCalendar)
 			assert.Equal(t, flagsTD.EventOrganizerInput, opts.EventOrganizer)
@@ -102,9 +118,15 @@ func (suite *ExchangeUnitSuite) TestAddExchangeCommands() {
 			assert.Equal(t, flagsTD.EventStartsAfterInput, opts.EventStartsAfter)
 			assert.Equal(t, flagsTD.EventStartsBeforeInput, opts.EventStartsBefore)
 			assert.Equal(t, flagsTD.EventSubjectInput, opts.EventSubject)
+			assert.True(t, opts.SettingsOnly)
 			assert.Equal(t, flagsTD.Collisions, opts.RestoreCfg.Collisions)
 			assert.Equal(t, flagsTD.Destination, opts.RestoreCfg.Destination)
+			assert.Equal(t, flagsTD.MappingFile, opts.RestoreCfg.MappingFile)
+			assert.Equal(t, flagsTD.TargetTenantID, flags.TargetTenantIDFV)
+			assert.Equal(t, flagsTD.TargetClientID, flags.TargetClientIDFV)
+			assert.Equal(t, flagsTD.TargetClientSecret, flags.TargetClientSecretFV)
 			assert.Equal(t, flagsTD.ToResource, opts.RestoreCfg.ProtectedResource)
+			assert.True(t, flags.DryRunFV)
 			flagsTD.AssertProviderFlags(t, cmd)
 			flagsTD.AssertStorageFlags(t, cmd)
 		})
@@ -23,8 +23,13 @@ func addGroupsCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddSiteFlag(c, false)
 		flags.AddSiteIDFlag(c, false)
 		flags.AddNoPermissionsFlag(c)
+		flags.AddNoTimestampsFlag(c)
 		flags.AddSharePointDetailsAndRestoreFlags(c)
+		flags.AddGroupsPlannerFlags(c)
 		flags.AddRestoreConfigFlags(c, false)
+		flags.AddRestoreTargetFlags(c)
+		flags.AddPermissionMapFlag(c)
+		flags.AddDryRunFlag(c)
 		flags.AddFailFastFlag(c)
 	}
 
@@ -47,7 +52,14 @@ corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd --file "FY2021 Pl
 
 # Restore all files and folders in folder "Documents/Finance Reports" that were created before 2020
 corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd \
-    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00`
+    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00
+
+# Restore the Planner plan "Launch" into Marketing
+corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd --plan Launch
+
+# Restore the Planner tasks assigned to a user that are due after the start of 2024
+corso restore groups --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --task-assignee 4f6c8e2a-1b3d-4e5f-9a7b-0c1d2e3f4a5b --task-due-after 2024-01-01T00:00:00`
 )
 
 // `corso restore groups [<flag>...]`
@@ -21,7 +21,11 @@ func addOneDriveCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddOneDriveDetailsAndRestoreFlags(c)
 		flags.AddNoPermissionsFlag(c)
+		flags.AddNoTimestampsFlag(c)
 		flags.AddRestoreConfigFlags(c, true)
+		flags.AddRestoreTargetFlags(c)
+		flags.AddPermissionMapFlag(c)
+		flags.AddDryRunFlag(c)
 		flags.AddFailFastFlag(c)
 	}
 
@@ -44,7 +48,12 @@ corso restore onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd \
 
 # Restore all files and folders in folder "Documents/Finance Reports" that were created before 2020
 corso restore onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd \
-    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00`
+    --folder "Documents/Finance Reports" --file-created-before 2020-01-01T00:00:00
+
+# Restore Bob's files into another tenant, remapping users and groups listed in mapping.csv
+corso restore onedrive --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --target-tenant-id 4d603060-18d3-4764-9be4-1ec5f9ba4a2f \
+    --to-resource bob@target.example.com --mapping-file mapping.csv`
 )
 
 // `corso restore onedrive [<flag>...]`
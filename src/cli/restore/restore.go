@@ -11,6 +11,7 @@ import (
 	. "github.com/alcionai/corso/src/cli/print"
 	"github.com/alcionai/corso/src/cli/utils"
 	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/operations"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
@@ -100,18 +101,36 @@ func runRestore(
 		return Only(ctx, err)
 	}
 
-	r, _, err := utils.GetAccountAndConnect(ctx, cmd, sel.PathService())
+	principals, err := utils.ReadPrincipalMap(urco)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	permissionPrincipals, err := utils.ReadPermissionMap(urco)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	r, _, err := utils.GetAccountAndConnectToRestoreTarget(ctx, cmd, sel.PathService(), urco)
 	if err != nil {
 		return Only(ctx, err)
 	}
 
 	defer utils.CloseRepo(ctx, r)
 
-	ro, err := r.NewRestore(ctx, backupID, sel, utils.MakeRestoreConfig(ctx, urco))
+	restoreCfg := utils.MakeRestoreConfig(ctx, urco)
+	restoreCfg.PrincipalMap = principals
+	restoreCfg.PermissionMap = permissionPrincipals
+
+	ro, err := r.NewRestore(ctx, backupID, sel, restoreCfg)
 	if err != nil {
 		return Only(ctx, clues.Wrap(err, "Failed to initialize "+serviceName+" restore"))
 	}
 
+	if flags.IsDryRun() {
+		return planRestore(ctx, ro, serviceName)
+	}
+
 	ds, err := ro.Run(ctx)
 	if err != nil {
 		if errors.Is(err, data.ErrNotFound) {
@@ -128,6 +147,9 @@ func runRestore(
 		Infof(ctx, "Skipped %d items due to collision", skipped)
 	}
 
+	printPermissionReport(ctx, ro.Counter)
+	printTimestampReport(ctx, ro.Counter)
+
 	dis := ds.Items()
 
 	Outf(ctx, "Restored %d items", len(dis))
@@ -135,3 +157,51 @@ func runRestore(
 
 	return nil
 }
+
+// planRestore prints the changes that the restore would make, without
+// restoring anything.
+func planRestore(
+	ctx context.Context,
+	ro operations.RestoreOperation,
+	serviceName string,
+) error {
+	p, err := ro.Plan(ctx)
+	if err != nil {
+		if errors.Is(err, data.ErrNotFound) {
+			return Only(ctx, clues.New("Backup or backup details missing for id "+flags.BackupIDFV))
+		}
+
+		return Only(ctx, clues.Wrap(err, "Failed to plan "+serviceName+" restore"))
+	}
+
+	utils.PrintPlan(ctx, p)
+
+	return nil
+}
+
+// printPermissionReport summarizes the permission grants that were
+// given to other users and groups, or not restored at all.
+func printPermissionReport(ctx context.Context, ctr *count.Bus) {
+	remapped := ctr.Get(count.PermissionRemapped)
+	if remapped > 0 {
+		Infof(
+			ctx,
+			"Remapped %d permission grants, %d of them to the fallback user or group",
+			remapped,
+			ctr.Get(count.PermissionFallback))
+	}
+
+	dropped := ctr.Get(count.PermissionDropped)
+	if dropped > 0 {
+		Infof(ctx, "Dropped %d permission grants for users and groups missing from the restore target", dropped)
+	}
+}
+
+// printTimestampReport reports the restored files that kept the time of
+// the restore instead of the timestamps of the backed up file.
+func printTimestampReport(ctx context.Context, ctr *count.Bus) {
+	failed := ctr.Get(count.TimestampsNotRestored)
+	if failed > 0 {
+		Infof(ctx, "Unable to restore the created and modified times of %d files", failed)
+	}
+}
@@ -21,7 +21,11 @@ func addSharePointCommands(cmd *cobra.Command) *cobra.Command {
 		flags.AddBackupIDFlag(c, true)
 		flags.AddSharePointDetailsAndRestoreFlags(c)
 		flags.AddNoPermissionsFlag(c)
+		flags.AddNoTimestampsFlag(c)
 		flags.AddRestoreConfigFlags(c, true)
+		flags.AddRestoreTargetFlags(c)
+		flags.AddPermissionMapFlag(c)
+		flags.AddDryRunFlag(c)
 		flags.AddFailFastFlag(c)
 	}
 
@@ -40,6 +44,11 @@ corso restore sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd --file 98765a
 corso restore sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --file 98765abcdef --no-permissions
 
+# Restore the file with ID 98765abcdef, granting its permissions to the users
+# and groups listed in permission-map.csv
+corso restore sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd \
+    --file 98765abcdef --permission-map permission-map.csv
+
 # Restore files named "ServerRenderTemplate.xsl" in the folder "Display Templates/Style Sheets".
 corso restore sharepoint --backup 1234abcd-12ab-cd34-56de-1234abcd \
     --file "ServerRenderTemplate.xsl" --folder "Display Templates/Style Sheets"
@@ -68,8 +68,14 @@ func (suite *SharePointUnitSuite) TestAddSharePointCommands() {
 						"--" + flags.PageFolderFN, flagsTD.FlgInputs(flagsTD.PageFolderInput),
 						"--" + flags.CollisionsFN, flagsTD.Collisions,
 						"--" + flags.DestinationFN, flagsTD.Destination,
+						"--" + flags.MappingFileFN, flagsTD.MappingFile,
+						"--" + flags.PermissionMapFN, flagsTD.PermissionMap,
+						"--" + flags.TargetTenantIDFN, flagsTD.TargetTenantID,
+						"--" + flags.TargetClientIDFN, flagsTD.TargetClientID,
+						"--" + flags.TargetClientSecretFN, flagsTD.TargetClientSecret,
 						"--" + flags.ToResourceFN, flagsTD.ToResource,
 						"--" + flags.NoPermissionsFN,
+						"--" + flags.NoTimestampsFN,
 					},
 					flagsTD.PreparedProviderFlags(),
 					flagsTD.PreparedStorageFlags()))
@@ -101,8 +107,14 @@ func (suite *SharePointUnitSuite) TestAddSharePointCommands() {
 			assert.ElementsMatch(t, flagsTD.PageFolderInput, opts.PageFolder)
 			assert.Equal(t, flagsTD.Collisions, opts.RestoreCfg.Collisions)
 			assert.Equal(t, flagsTD.Destination, opts.RestoreCfg.Destination)
+			assert.Equal(t, flagsTD.MappingFile, opts.RestoreCfg.MappingFile)
+			assert.Equal(t, flagsTD.PermissionMap, opts.RestoreCfg.PermissionMapFile)
+			assert.Equal(t, flagsTD.TargetTenantID, flags.TargetTenantIDFV)
+			assert.Equal(t, flagsTD.TargetClientID, flags.TargetClientIDFV)
+			assert.Equal(t, flagsTD.TargetClientSecret, flags.TargetClientSecretFV)
 			assert.Equal(t, flagsTD.ToResource, opts.RestoreCfg.ProtectedResource)
 			assert.True(t, flags.NoPermissionsFV)
+			assert.True(t, flags.NoTimestampsFV)
 			flagsTD.AssertProviderFlags(t, cmd)
 			flagsTD.AssertStorageFlags(t, cmd)
 		})
@@ -0,0 +1,122 @@
+package schedule
+
+import (
+	"path/filepath"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/viper"
+
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/control/repository"
+)
+
+const defaultStateFile = "corso-schedule-state.json"
+
+// Config describes the jobs run by `corso schedule run`.
+type Config struct {
+	// StateFile is where the last run status of each job gets persisted.
+	// Relative paths are relative to the config file.  Defaults to
+	// corso-schedule-state.json alongside the config file.
+	StateFile   string             `mapstructure:"stateFile"`
+	Backups     []BackupConfig     `mapstructure:"backups"`
+	Maintenance *MaintenanceConfig `mapstructure:"maintenance"`
+}
+
+// BackupConfig describes a scheduled backup of one service.
+type BackupConfig struct {
+	Name    string `mapstructure:"name"`
+	Service string `mapstructure:"service"`
+	// Resources are the ids or names (eg: email addresses, site urls) of
+	// the protected resources to back up, or '*' for all resources.
+	Resources []string `mapstructure:"resources"`
+	// Categories restricts the backup to the given data types, using the
+	// same values as the --data flag of `corso backup create`.
+	Categories []string `mapstructure:"categories"`
+	Cron       string   `mapstructure:"cron"`
+}
+
+// MaintenanceConfig describes scheduled repository maintenance.
+type MaintenanceConfig struct {
+	Cron  string `mapstructure:"cron"`
+	Mode  string `mapstructure:"mode"`
+	Force bool   `mapstructure:"force"`
+}
+
+// readConfig parses and validates the schedule config file.
+func readConfig(fp string) (Config, error) {
+	var cfg Config
+
+	vpr := viper.New()
+	vpr.SetConfigFile(fp)
+
+	if err := vpr.ReadInConfig(); err != nil {
+		return cfg, clues.Wrap(err, "reading schedule config").With("config_file", fp)
+	}
+
+	if err := vpr.Unmarshal(&cfg); err != nil {
+		return cfg, clues.Wrap(err, "parsing schedule config").With("config_file", fp)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return cfg, clues.Stack(err).With("config_file", fp)
+	}
+
+	if len(cfg.StateFile) == 0 {
+		cfg.StateFile = defaultStateFile
+	}
+
+	if !filepath.IsAbs(cfg.StateFile) {
+		cfg.StateFile = filepath.Join(filepath.Dir(fp), cfg.StateFile)
+	}
+
+	if cfg.Maintenance != nil && len(cfg.Maintenance.Mode) == 0 {
+		cfg.Maintenance.Mode = repository.CompleteMaintenance.String()
+	}
+
+	return cfg, nil
+}
+
+func (cfg Config) validate() error {
+	if len(cfg.Backups) == 0 && cfg.Maintenance == nil {
+		return clues.New("schedule config contains no backups or maintenance")
+	}
+
+	for i, bc := range cfg.Backups {
+		if err := bc.validate(); err != nil {
+			return clues.Stack(err).With("backup_index", i, "backup_name", bc.Name)
+		}
+	}
+
+	if cfg.Maintenance != nil {
+		if len(cfg.Maintenance.Cron) == 0 {
+			return clues.New("maintenance is missing a cron schedule")
+		}
+
+		mode := cfg.Maintenance.Mode
+		if _, ok := repository.StringToMaintenanceType[mode]; len(mode) > 0 && !ok {
+			return clues.New(mode + " is an unrecognized maintenance mode")
+		}
+	}
+
+	return nil
+}
+
+func (bc BackupConfig) validate() error {
+	if len(bc.Name) == 0 {
+		return clues.New("backup is missing a name")
+	}
+
+	if bc.Name == maintenanceJobName {
+		return clues.New("backup name '" + maintenanceJobName + "' is reserved")
+	}
+
+	if len(bc.Resources) == 0 {
+		return clues.New("backup is missing resources")
+	}
+
+	if len(bc.Cron) == 0 {
+		return clues.New("backup is missing a cron schedule")
+	}
+
+	return utils.ValidateBackupService(bc.Service, bc.Categories)
+}
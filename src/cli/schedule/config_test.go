@@ -0,0 +1,155 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type ConfigUnitSuite struct {
+	tester.Suite
+}
+
+func TestConfigUnitSuite(t *testing.T) {
+	suite.Run(t, &ConfigUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ConfigUnitSuite) TestReadConfig() {
+	table := []struct {
+		name      string
+		config    string
+		expectErr assert.ErrorAssertionFunc
+		expect    func(t *testing.T, dir string, cfg Config)
+	}{
+		{
+			name: "backups and maintenance",
+			config: `
+backups:
+  - name: mail
+    service: exchange
+    resources: ["*"]
+    categories: [email, events]
+    cron: "0 1 * * *"
+  - name: sites
+    service: SharePoint
+    resources: [site1]
+    cron: "0 * * * *"
+maintenance:
+  cron: "0 4 * * 0"
+`,
+			expectErr: assert.NoError,
+			expect: func(t *testing.T, dir string, cfg Config) {
+				assert.Equal(t, filepath.Join(dir, defaultStateFile), cfg.StateFile)
+				require.Len(t, cfg.Backups, 2)
+				assert.Equal(t, "mail", cfg.Backups[0].Name)
+				assert.Equal(t, []string{"*"}, cfg.Backups[0].Resources)
+				assert.Equal(t, []string{"email", "events"}, cfg.Backups[0].Categories)
+				assert.Equal(t, "0 1 * * *", cfg.Backups[0].Cron)
+				require.NotNil(t, cfg.Maintenance)
+				assert.Equal(t, "complete", cfg.Maintenance.Mode)
+			},
+		},
+		{
+			name: "relative state file",
+			config: `
+stateFile: state/last.json
+maintenance:
+  cron: "0 4 * * 0"
+  mode: metadata
+  force: true
+`,
+			expectErr: assert.NoError,
+			expect: func(t *testing.T, dir string, cfg Config) {
+				assert.Equal(t, filepath.Join(dir, "state", "last.json"), cfg.StateFile)
+				assert.Empty(t, cfg.Backups)
+				assert.Equal(t, "metadata", cfg.Maintenance.Mode)
+				assert.True(t, cfg.Maintenance.Force)
+			},
+		},
+		{
+			name:      "empty",
+			config:    `stateFile: state.json`,
+			expectErr: assert.Error,
+		},
+		{
+			name: "unsupported service",
+			config: `
+backups:
+  - name: chats
+    service: teams
+    resources: ["*"]
+    cron: "0 1 * * *"
+`,
+			expectErr: assert.Error,
+		},
+		{
+			name: "unsupported category",
+			config: `
+backups:
+  - name: drives
+    service: onedrive
+    resources: ["*"]
+    categories: [email]
+    cron: "0 1 * * *"
+`,
+			expectErr: assert.Error,
+		},
+		{
+			name: "missing resources",
+			config: `
+backups:
+  - name: mail
+    service: exchange
+    cron: "0 1 * * *"
+`,
+			expectErr: assert.Error,
+		},
+		{
+			name: "reserved name",
+			config: `
+backups:
+  - name: maintenance
+    service: exchange
+    resources: ["*"]
+    cron: "0 1 * * *"
+`,
+			expectErr: assert.Error,
+		},
+		{
+			name: "bad maintenance mode",
+			config: `
+maintenance:
+  cron: "0 4 * * 0"
+  mode: partial
+`,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			dir := t.TempDir()
+			fp := filepath.Join(dir, "schedule.yaml")
+
+			err := os.WriteFile(fp, []byte(test.config), 0o600)
+			require.NoError(t, err, clues.ToCore(err))
+
+			cfg, err := readConfig(fp)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			test.expect(t, dir, cfg)
+		})
+	}
+}
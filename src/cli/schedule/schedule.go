@@ -0,0 +1,313 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/internal/scheduler"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	repo "github.com/alcionai/corso/src/pkg/repository"
+)
+
+const (
+	scheduleCommand = "schedule"
+	runCommand      = "run"
+	runUseSuffix    = "--config <file>"
+
+	maintenanceJobName = "maintenance"
+	// maintenance runs against the whole repository, rather than any
+	// single protected resource.
+	maintenanceResource = "repository"
+)
+
+//nolint:lll
+const runExamples = `# Run the backups and maintenance described in schedule.yaml until interrupted
+corso schedule run --config schedule.yaml
+
+# An example schedule.yaml
+stateFile: /var/lib/corso/schedule-state.json
+backups:
+  - name: nightly-mail
+    service: exchange
+    resources: ["*"]
+    categories: [email, events]
+    cron: "0 1 * * *"
+  - name: hourly-sites
+    service: sharepoint
+    resources: ["https://example.sharepoint.com/sites/finance"]
+    cron: "0 * * * *"
+maintenance:
+  cron: "0 4 * * 0"
+  mode: complete`
+
+var scheduleCommands = []func(cmd *cobra.Command) *cobra.Command{
+	addRunCommands,
+}
+
+// AddCommands attaches all `corso schedule * *` commands to the parent.
+func AddCommands(cmd *cobra.Command) {
+	subCommand := scheduleCmd()
+	cmd.AddCommand(subCommand)
+
+	for _, addScheduleTo := range scheduleCommands {
+		sc := addScheduleTo(subCommand)
+		flags.AddAllProviderFlags(sc)
+		flags.AddAllStorageFlags(sc)
+	}
+}
+
+// The schedule category of commands.
+// `corso schedule [<subcommand>] [<flag>...]`
+func scheduleCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   scheduleCommand,
+		Short: "Run backups and maintenance on a schedule",
+		Long:  `Run backups and repository maintenance on cron schedules from a long-running process.`,
+		RunE:  handleScheduleCmd,
+		Args:  cobra.NoArgs,
+	}
+}
+
+// Handler for flat calls to `corso schedule`.
+// Produces the same output as `corso schedule --help`.
+func handleScheduleCmd(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+// called by AddCommands to attach the run subcommand.
+func addRunCommands(cmd *cobra.Command) *cobra.Command {
+	var c *cobra.Command
+
+	switch cmd.Use {
+	case scheduleCommand:
+		c, _ = utils.AddCommand(cmd, scheduleRunCmd())
+
+		c.Use = c.Use + " " + runUseSuffix
+
+		flags.AddScheduleConfigFlag(c)
+		flags.AddFailFastFlag(c)
+	}
+
+	return c
+}
+
+// `corso schedule run [<flag>...]`
+func scheduleRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   runCommand,
+		Short: "Run scheduled backups and maintenance until interrupted",
+		Long: `Run the backups and maintenance described in the config file according to their
+cron schedules.  A resource is never backed up by more than one job at a time; runs that would
+overlap are skipped.  The status of the last run of each job is written to the state file.`,
+		RunE:    runScheduleCmd,
+		Args:    cobra.NoArgs,
+		Example: runExamples,
+	}
+}
+
+// processes `corso schedule run`.
+func runScheduleCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if utils.HasNoFlagsAndShownHelp(cmd) {
+		return nil
+	}
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	cfgFP, err := utils.MakeAbsoluteFilePath(flags.ScheduleConfigFV)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "resolving schedule config path"))
+	}
+
+	cfg, err := readConfig(cfgFP)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	state, err := scheduler.LoadState(cfg.StateFile)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	r, rdao, err := utils.GetAccountAndConnect(ctx, cmd, path.ExchangeService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	bus, err := events.NewBus(ctx, rdao.Repo.Storage, rdao.Repo.Account.ID(), rdao.Opts)
+	if err != nil {
+		return Only(ctx, clues.Wrap(err, "constructing event bus"))
+	}
+
+	bus.SetRepoID(r.GetID())
+
+	defer bus.Close()
+
+	jobs, err := makeJobs(cfg, r, rdao.Repo.Account)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	s, err := scheduler.New(jobs, state, bus)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	Infof(ctx, "Running %d scheduled jobs; status is written to %s", len(jobs), cfg.StateFile)
+
+	if err := s.Run(ctx); err != nil {
+		return Only(ctx, err)
+	}
+
+	Info(ctx, "Scheduler stopped")
+
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// jobs
+// ---------------------------------------------------------------------------
+
+func makeJobs(
+	cfg Config,
+	r repo.Repositoryer,
+	acct account.Account,
+) ([]scheduler.Job, error) {
+	var (
+		jobs = make([]scheduler.Job, 0, len(cfg.Backups)+1)
+		// the repository can't host concurrent operations, so runs are
+		// serialized, even across jobs.
+		repoMu = &sync.Mutex{}
+	)
+
+	for _, bc := range cfg.Backups {
+		jobs = append(jobs, backupJob(bc, r, acct, repoMu))
+	}
+
+	if cfg.Maintenance != nil {
+		job, err := maintenanceJob(*cfg.Maintenance, r, repoMu)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+func backupJob(
+	bc BackupConfig,
+	r repo.Repositoryer,
+	acct account.Account,
+	repoMu *sync.Mutex,
+) scheduler.Job {
+	var (
+		service = strings.ToLower(bc.Service)
+		// the latest lookup of resource ids and names, re-used by
+		// each backup to avoid looking up resources one at a time.
+		insMu sync.Mutex
+		ins   idname.Cacher
+	)
+
+	return scheduler.Job{
+		Name:     bc.Name,
+		Schedule: bc.Cron,
+		Service:  service,
+		Resources: func(ctx context.Context) ([]string, error) {
+			found, err := utils.ResourcesMap(ctx, acct, service, r.Counter())
+			if err != nil {
+				return nil, err
+			}
+
+			insMu.Lock()
+			ins = found
+			insMu.Unlock()
+
+			return utils.ResolveResources(found, bc.Resources)
+		},
+		Lock: repoMu,
+		Run: func(ctx context.Context, resource string) (string, error) {
+			insMu.Lock()
+			cache := ins
+			insMu.Unlock()
+
+			sel := utils.BackupSelector(service, resource, bc.Categories)
+
+			bo, err := r.NewBackupWithLookup(ctx, sel, cache)
+			if err != nil {
+				return "", clues.Wrap(err, "initializing backup")
+			}
+
+			if err := bo.Run(ctx); err != nil {
+				if errors.Is(err, core.ErrServiceNotEnabled) {
+					logger.Ctx(ctx).Infow("service not enabled", "service", service)
+					return "", nil
+				}
+
+				return string(bo.Results.BackupID), clues.Wrap(err, "running backup")
+			}
+
+			return string(bo.Results.BackupID), nil
+		},
+	}
+}
+
+func maintenanceJob(
+	mc MaintenanceConfig,
+	r repo.Repositoryer,
+	repoMu *sync.Mutex,
+) (scheduler.Job, error) {
+	mt, ok := repository.StringToMaintenanceType[mc.Mode]
+	if !ok {
+		return scheduler.Job{}, clues.New(mc.Mode + " is an unrecognized maintenance mode")
+	}
+
+	return scheduler.Job{
+		Name:     maintenanceJobName,
+		Schedule: mc.Cron,
+		Resources: func(context.Context) ([]string, error) {
+			return []string{maintenanceResource}, nil
+		},
+		Lock: repoMu,
+		Run: func(ctx context.Context, _ string) (string, error) {
+			m, err := r.NewMaintenance(
+				ctx,
+				repository.Maintenance{
+					Type:   mt,
+					Safety: repository.FullMaintenanceSafety,
+					Force:  mc.Force,
+				})
+			if err != nil {
+				return "", clues.Wrap(err, "initializing maintenance")
+			}
+
+			return "", clues.Wrap(m.Run(ctx), "running maintenance").OrNil()
+		},
+	}, nil
+}
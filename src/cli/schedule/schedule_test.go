@@ -0,0 +1,56 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	flagsTD "github.com/alcionai/corso/src/cli/flags/testdata"
+	cliTD "github.com/alcionai/corso/src/cli/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type ScheduleUnitSuite struct {
+	tester.Suite
+}
+
+func TestScheduleUnitSuite(t *testing.T) {
+	suite.Run(t, &ScheduleUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ScheduleUnitSuite) TestAddRunCommands() {
+	t := suite.T()
+	parent := &cobra.Command{Use: scheduleCommand}
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		parent,
+		addRunCommands,
+		[]cliTD.UseCobraCommandFn{
+			flags.AddAllProviderFlags,
+			flags.AddAllStorageFlags,
+		},
+		flagsTD.WithFlags(
+			runCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.ScheduleConfigFN, flagsTD.ScheduleConfig,
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	cliTD.CheckCmdChild(
+		t,
+		parent,
+		3,
+		runCommand+" "+runUseSuffix,
+		scheduleRunCmd().Short,
+		runScheduleCmd)
+
+	assert.Equal(t, flagsTD.ScheduleConfig, flags.ScheduleConfigFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
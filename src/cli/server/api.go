@@ -0,0 +1,830 @@
+package server
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/control"
+	ctrlRepo "github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// apiPrefix versions every route.  Breaking changes to the api get a
+// new prefix.
+const apiPrefix = "/v1/"
+
+// maxRequestBodySize bounds the size of request bodies.
+const maxRequestBodySize = 1 << 20
+
+const (
+	jobKindBackup      = "backup"
+	jobKindRestore     = "restore"
+	jobKindExport      = "export"
+	jobKindMaintenance = "maintenance"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// api serves the rest api over a repository.
+type api struct {
+	r     repository.Repositoryer
+	token string
+	jobs  *jobs
+	// signingKey signs export manifests.  Nil if manifests aren't signed.
+	signingKey ed25519.PrivateKey
+	// exportRoot is the absolute, symlink free directory that exports are
+	// written beneath.  Empty if exports are disabled.
+	exportRoot string
+	// resources looks up the ids and names of a service's protected
+	// resources.
+	resources func(ctx context.Context, service string) (idname.Cacher, error)
+}
+
+func newAPI(
+	ctx context.Context,
+	r repository.Repositoryer,
+	acct account.Account,
+	token string,
+	signingKey ed25519.PrivateKey,
+	exportRoot string,
+) *api {
+	return &api{
+		r:          r,
+		token:      token,
+		jobs:       newJobs(ctx),
+		signingKey: signingKey,
+		exportRoot: exportRoot,
+		resources: func(ctx context.Context, service string) (idname.Cacher, error) {
+			return utils.ResourcesMap(ctx, acct, service, r.Counter())
+		},
+	}
+}
+
+// handler produces the http handler for all api routes.
+func (a *api) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(apiPrefix+"openapi.yaml", a.serveOpenAPI)
+	mux.Handle(apiPrefix, a.authenticate(http.HandlerFunc(a.route)))
+
+	return mux
+}
+
+// authenticate rejects requests that lack the bearer token.
+func (a *api) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="corso"`)
+			writeError(w, http.StatusUnauthorized, clues.New("missing or invalid bearer token"))
+
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+func (a *api) serveOpenAPI(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, clues.New("method not allowed"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(openAPISpec)
+}
+
+// route dispatches the request by its path segments, eg:
+// /v1/backups/{id}/details => ["backups", "{id}", "details"].
+func (a *api) route(w http.ResponseWriter, req *http.Request) {
+	var (
+		ctx      = clues.Add(req.Context(), "http_method", req.Method, "http_path", req.URL.Path)
+		segments = strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, apiPrefix), "/"), "/")
+		method   = req.Method
+	)
+
+	req = req.WithContext(ctx)
+	req.Body = http.MaxBytesReader(w, req.Body, maxRequestBodySize)
+
+	switch {
+	case match(segments, "backups"):
+		switch method {
+		case http.MethodGet:
+			a.listBackups(w, req)
+		case http.MethodPost:
+			a.createBackup(w, req)
+		default:
+			methodNotAllowed(w)
+		}
+
+	case match(segments, "backups", "*"):
+		onlyGet(w, req, func() { a.getBackup(w, req, segments[1]) })
+
+	case match(segments, "backups", "*", "details"):
+		onlyGet(w, req, func() { a.getBackupDetails(w, req, segments[1]) })
+
+	case match(segments, "backups", "*", "errors"):
+		onlyGet(w, req, func() { a.getBackupErrors(w, req, segments[1]) })
+
+	case match(segments, "restores"):
+		onlyPost(w, req, func() { a.createRestore(w, req) })
+
+	case match(segments, "exports"):
+		onlyPost(w, req, func() { a.createExport(w, req) })
+
+	case match(segments, "maintenance"):
+		onlyPost(w, req, func() { a.createMaintenance(w, req) })
+
+	case match(segments, "jobs"):
+		onlyGet(w, req, func() { writeJSON(w, http.StatusOK, a.jobs.list()) })
+
+	case match(segments, "jobs", "*"):
+		switch method {
+		case http.MethodGet:
+			a.getJob(w, segments[1])
+		case http.MethodDelete:
+			a.cancelJob(w, segments[1])
+		default:
+			methodNotAllowed(w)
+		}
+
+	default:
+		writeError(w, http.StatusNotFound, clues.New("no such route"))
+	}
+}
+
+// match compares the path segments to the pattern, where "*" matches
+// any single non-empty segment.
+func match(segments []string, pattern ...string) bool {
+	if len(segments) != len(pattern) {
+		return false
+	}
+
+	for i, p := range pattern {
+		if len(segments[i]) == 0 || (p != "*" && p != segments[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func onlyGet(w http.ResponseWriter, req *http.Request, fn func()) {
+	if req.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+
+	fn()
+}
+
+func onlyPost(w http.ResponseWriter, req *http.Request, fn func()) {
+	if req.Method != http.MethodPost {
+		methodNotAllowed(w)
+		return
+	}
+
+	fn()
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	writeError(w, http.StatusMethodNotAllowed, clues.New("method not allowed"))
+}
+
+// ---------------------------------------------------------------------------
+// responses
+// ---------------------------------------------------------------------------
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	// the status is already sent, so there's no recovering from a
+	// failed write.
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// writeRepoError responds with not found for missing backups, and an
+// internal error for everything else.
+func writeRepoError(w http.ResponseWriter, req *http.Request, err error) {
+	if errors.Is(err, data.ErrNotFound) || errors.Is(err, repository.ErrorBackupNotFound) {
+		writeError(w, http.StatusNotFound, clues.New("backup not found"))
+		return
+	}
+
+	logger.CtxErr(req.Context(), err).Error("serving api request")
+	writeError(w, http.StatusInternalServerError, err)
+}
+
+// decodeBody decodes the json request body into the body.  An empty
+// request body leaves the body unchanged.
+func decodeBody(req *http.Request, body any) error {
+	dec := json.NewDecoder(req.Body)
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(body)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return clues.Wrap(err, "decoding request body").OrNil()
+}
+
+// writeBodyError reports a request body that couldn't be decoded.
+func writeBodyError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+
+	var mbe *http.MaxBytesError
+	if errors.As(err, &mbe) {
+		status = http.StatusRequestEntityTooLarge
+	}
+
+	writeError(w, status, err)
+}
+
+// ---------------------------------------------------------------------------
+// backups
+// ---------------------------------------------------------------------------
+
+func (a *api) listBackups(w http.ResponseWriter, req *http.Request) {
+	q := req.URL.Query()
+
+	filters, err := utils.MakeBackupFilters(utils.BackupFilterOpts{
+		Resource: q.Get("resource"),
+		Since:    q.Get("since"),
+		Until:    q.Get("until"),
+		Status:   q.Get("status"),
+		Labels:   q["label"],
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if svc := q.Get("service"); len(svc) > 0 {
+		pst := path.ToServiceType(svc)
+		if pst == path.UnknownService {
+			writeError(w, http.StatusBadRequest, clues.New("unknown service: "+svc))
+			return
+		}
+
+		filters = append(filters, store.Service(pst))
+	}
+
+	bups, err := a.r.BackupsByTag(req.Context(), filters...)
+	if err != nil {
+		writeRepoError(w, req, err)
+		return
+	}
+
+	if bups == nil {
+		bups = []*backup.Backup{}
+	}
+
+	writeJSON(w, http.StatusOK, bups)
+}
+
+func (a *api) getBackup(w http.ResponseWriter, req *http.Request, id string) {
+	bup, err := a.r.Backup(req.Context(), id)
+	if err != nil {
+		writeRepoError(w, req, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, bup)
+}
+
+func (a *api) getBackupDetails(w http.ResponseWriter, req *http.Request, id string) {
+	deets, _, errs := a.r.GetBackupDetails(req.Context(), id)
+	if errs.Failure() != nil {
+		writeRepoError(w, req, errs.Failure())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deets)
+}
+
+func (a *api) getBackupErrors(w http.ResponseWriter, req *http.Request, id string) {
+	fe, _, errs := a.r.GetBackupErrors(req.Context(), id)
+	if errs.Failure() != nil {
+		writeRepoError(w, req, errs.Failure())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, fe)
+}
+
+type createBackupRequest struct {
+	Service string `json:"service"`
+	// Resources are ids or names of the protected resources, or '*'.
+	Resources  []string `json:"resources"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+type backupResourceError struct {
+	Resource string `json:"resource"`
+	Error    string `json:"error"`
+}
+
+type createBackupResult struct {
+	BackupIDs []string              `json:"backupIDs"`
+	Failures  []backupResourceError `json:"failures,omitempty"`
+}
+
+func (a *api) createBackup(w http.ResponseWriter, req *http.Request) {
+	var body createBackupRequest
+
+	if err := decodeBody(req, &body); err != nil {
+		writeBodyError(w, err)
+		return
+	}
+
+	if err := utils.ValidateBackupService(body.Service, body.Categories); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if len(body.Resources) == 0 {
+		writeError(w, http.StatusBadRequest, clues.New("at least one resource is required"))
+		return
+	}
+
+	job := a.jobs.start(jobKindBackup, func(ctx context.Context) (any, error) {
+		return a.runBackups(ctx, body)
+	})
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// runBackups backs up each resource in turn.  A failure for one resource
+// doesn't prevent backups of the others.
+func (a *api) runBackups(ctx context.Context, body createBackupRequest) (any, error) {
+	ins, err := a.resources(ctx, body.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := utils.ResolveResources(ins, body.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	result := createBackupResult{BackupIDs: []string{}}
+
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		bid, err := a.runBackup(ctx, ins, body.Service, id, body.Categories)
+		if err != nil {
+			result.Failures = append(result.Failures, backupResourceError{
+				Resource: id,
+				Error:    err.Error(),
+			})
+
+			continue
+		}
+
+		if len(bid) > 0 {
+			result.BackupIDs = append(result.BackupIDs, bid)
+		}
+	}
+
+	if len(result.Failures) > 0 {
+		return result, clues.New("one or more backups failed")
+	}
+
+	return result, nil
+}
+
+func (a *api) runBackup(
+	ctx context.Context,
+	ins idname.Cacher,
+	service, resourceID string,
+	cats []string,
+) (string, error) {
+	sel := utils.BackupSelector(service, resourceID, cats)
+
+	bo, err := a.r.NewBackupWithLookup(ctx, sel, ins)
+	if err != nil {
+		return "", clues.Wrap(err, "initializing backup")
+	}
+
+	if err := bo.Run(ctx); err != nil {
+		if errors.Is(err, core.ErrServiceNotEnabled) {
+			logger.Ctx(ctx).Infow("service not enabled", "service", service)
+			return "", nil
+		}
+
+		return "", clues.Wrap(err, "running backup")
+	}
+
+	return string(bo.Results.BackupID), nil
+}
+
+// ---------------------------------------------------------------------------
+// restores and exports
+// ---------------------------------------------------------------------------
+
+// restoreSelector selects all of the data in the backup.
+func restoreSelector(bup *backup.Backup) (selectors.Selector, error) {
+	owners := []string{bup.Selector.DiscreteOwner}
+
+	switch bup.Selector.PathService() {
+	case path.ExchangeService:
+		sel := selectors.NewExchangeRestore(owners)
+		sel.Include(sel.AllData())
+
+		return sel.Selector, nil
+	case path.OneDriveService:
+		sel := selectors.NewOneDriveRestore(owners)
+		sel.Include(sel.AllData())
+
+		return sel.Selector, nil
+	case path.SharePointService:
+		sel := selectors.NewSharePointRestore(owners)
+		sel.Include(sel.AllData())
+
+		return sel.Selector, nil
+	case path.GroupsService:
+		sel := selectors.NewGroupsRestore(owners)
+		sel.Include(sel.AllData())
+
+		return sel.Selector, nil
+	}
+
+	return selectors.Selector{}, clues.New("backup service does not support restores and exports").
+		With("service", bup.Selector.PathService())
+}
+
+// backupSelector looks up the backup and produces a selector for all of
+// its data.  Responds to the request on failure.
+func (a *api) backupSelector(
+	w http.ResponseWriter,
+	req *http.Request,
+	backupID string,
+) (selectors.Selector, bool) {
+	if len(backupID) == 0 {
+		writeError(w, http.StatusBadRequest, clues.New("a backup id is required"))
+		return selectors.Selector{}, false
+	}
+
+	bup, err := a.r.Backup(req.Context(), backupID)
+	if err != nil {
+		writeRepoError(w, req, err)
+		return selectors.Selector{}, false
+	}
+
+	sel, err := restoreSelector(bup)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return selectors.Selector{}, false
+	}
+
+	return sel, true
+}
+
+type createRestoreRequest struct {
+	BackupID string `json:"backupID"`
+	// RestoreConfig overrides the default restore config, which restores
+	// into a new, timestamped folder, skipping colliding items.
+	RestoreConfig *control.RestoreConfig `json:"restoreConfig,omitempty"`
+}
+
+func (a *api) createRestore(w http.ResponseWriter, req *http.Request) {
+	restoreCfg := control.DefaultRestoreConfig(dttm.HumanReadable)
+	restoreCfg.IncludePermissions = true
+
+	body := createRestoreRequest{RestoreConfig: &restoreCfg}
+
+	if err := decodeBody(req, &body); err != nil {
+		writeBodyError(w, err)
+		return
+	}
+
+	if body.RestoreConfig == nil {
+		body.RestoreConfig = &restoreCfg
+	}
+
+	if !control.IsValidCollisionPolicy(body.RestoreConfig.OnCollision) {
+		writeError(w, http.StatusBadRequest, clues.New("invalid collision policy"))
+		return
+	}
+
+	sel, ok := a.backupSelector(w, req, body.BackupID)
+	if !ok {
+		return
+	}
+
+	job := a.jobs.start(jobKindRestore, func(ctx context.Context) (any, error) {
+		ro, err := a.r.NewRestore(ctx, body.BackupID, sel, *body.RestoreConfig)
+		if err != nil {
+			return nil, clues.Wrap(err, "initializing restore")
+		}
+
+		if _, err := ro.Run(ctx); err != nil {
+			return ro.Results, clues.Wrap(err, "running restore")
+		}
+
+		return ro.Results, nil
+	})
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+type createExportRequest struct {
+	BackupID string `json:"backupID"`
+	// Destination is a directory beneath the server's export root.
+	Destination   string `json:"destination"`
+	Archive       bool   `json:"archive,omitempty"`
+	ArchiveFormat string `json:"archiveFormat,omitempty"`
+	Format        string `json:"format,omitempty"`
+	Aggregate     bool   `json:"aggregate,omitempty"`
+	Collisions    string `json:"collisions,omitempty"`
+}
+
+type exportCategoryStats struct {
+	Items int64 `json:"items"`
+	Bytes int64 `json:"bytes"`
+}
+
+type createExportResult struct {
+	Destination string                         `json:"destination"`
+	Stats       map[string]exportCategoryStats `json:"stats"`
+}
+
+func (a *api) createExport(w http.ResponseWriter, req *http.Request) {
+	var body createExportRequest
+
+	if err := decodeBody(req, &body); err != nil {
+		writeBodyError(w, err)
+		return
+	}
+
+	if len(body.Destination) == 0 {
+		writeError(w, http.StatusBadRequest, clues.New("an export destination is required"))
+		return
+	}
+
+	if len(a.exportRoot) == 0 {
+		writeError(w, http.StatusForbidden, clues.New("exports are disabled; start the server with --export-root"))
+		return
+	}
+
+	dest, err := exportDestination(a.exportRoot, body.Destination)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	exportCfg := control.DefaultExportConfig()
+	exportCfg.Archive = body.Archive
+	exportCfg.Aggregate = body.Aggregate
+
+	if len(body.ArchiveFormat) > 0 {
+		exportCfg.ArchiveFormat = control.ArchiveFormat(strings.ToLower(body.ArchiveFormat))
+	}
+
+	if len(body.Collisions) > 0 {
+		exportCfg.OnCollision = control.ExportCollisionPolicy(body.Collisions)
+	}
+
+	if !control.IsValidArchiveFormat(exportCfg.ArchiveFormat) {
+		writeError(w, http.StatusBadRequest, clues.New("invalid archive format"))
+		return
+	}
+
+	if !control.IsValidExportCollisionPolicy(exportCfg.OnCollision) {
+		writeError(w, http.StatusBadRequest, clues.New("invalid collision policy"))
+		return
+	}
+
+	sel, ok := a.backupSelector(w, req, body.BackupID)
+	if !ok {
+		return
+	}
+
+	if !slices.Contains(utils.ExportFormats(sel.PathService()), strings.ToLower(body.Format)) {
+		writeError(w, http.StatusBadRequest, clues.New("unsupported export format: "+body.Format))
+		return
+	}
+
+	exportCfg.Format = control.FormatType(strings.ToLower(body.Format))
+
+	job := a.jobs.start(jobKindExport, func(ctx context.Context) (any, error) {
+		return a.runExport(ctx, body, dest, sel, exportCfg)
+	})
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (a *api) runExport(
+	ctx context.Context,
+	body createExportRequest,
+	destDir string,
+	sel selectors.Selector,
+	exportCfg control.ExportConfig,
+) (any, error) {
+	eo, err := a.r.NewExport(ctx, body.BackupID, sel, exportCfg)
+	if err != nil {
+		return nil, clues.Wrap(err, "initializing export")
+	}
+
+	colls, err := eo.Run(ctx)
+	if err != nil {
+		return nil, clues.Wrap(err, "running export")
+	}
+
+	dest := export.NewLocalDestination(destDir)
+
+	err = export.ConsumeExportCollectionsTo(ctx, dest, exportCfg, colls, count.New(), eo.Errors)
+	if err != nil {
+		return nil, clues.Wrap(err, "writing export")
+	}
+
+	err = utils.WriteExportManifest(ctx, eo.Manifest(), a.signingKey, dest, "")
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	result := createExportResult{
+		Destination: body.Destination,
+		Stats:       map[string]exportCategoryStats{},
+	}
+
+	for cat, ks := range eo.GetStats() {
+		result.Stats[cat.HumanString()] = exportCategoryStats{
+			Items: ks.ResourceCount,
+			Bytes: ks.BytesRead,
+		}
+	}
+
+	if len(eo.Errors.Recovered()) > 0 {
+		return result, clues.New("export completed with item failures").
+			With("failure_count", len(eo.Errors.Recovered()))
+	}
+
+	return result, nil
+}
+
+// exportDestination resolves the destination relative to the export
+// root.  Destinations that leave the root, through the path or by way of
+// a symlink, are rejected.
+func exportDestination(root, dest string) (string, error) {
+	full := filepath.Join(root, filepath.FromSlash(dest))
+	if !within(root, full) {
+		return "", clues.New("export destination is outside the export root")
+	}
+
+	// the destination may not exist yet, so symlinks are resolved in its
+	// deepest existing ancestor.  The root exists, so this ends there at
+	// the latest.
+	existing, rest := full, ""
+
+	for {
+		resolved, err := filepath.EvalSymlinks(existing)
+		if err == nil {
+			full = filepath.Join(resolved, rest)
+			break
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", clues.Wrap(err, "resolving export destination")
+		}
+
+		// a broken symlink could point anywhere once its target appears.
+		if _, err := os.Lstat(existing); err == nil {
+			return "", clues.New("export destination contains a broken symlink")
+		}
+
+		rest = filepath.Join(filepath.Base(existing), rest)
+		existing = filepath.Dir(existing)
+	}
+
+	if !within(root, full) {
+		return "", clues.New("export destination is outside the export root")
+	}
+
+	return full, nil
+}
+
+// within reports whether the path is the root, or beneath it.
+func within(root, p string) bool {
+	rel, err := filepath.Rel(root, p)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// ---------------------------------------------------------------------------
+// maintenance
+// ---------------------------------------------------------------------------
+
+type createMaintenanceRequest struct {
+	// Mode is either 'complete' (default) or 'metadata'.
+	Mode  string `json:"mode,omitempty"`
+	Force bool   `json:"force,omitempty"`
+}
+
+func (a *api) createMaintenance(w http.ResponseWriter, req *http.Request) {
+	var body createMaintenanceRequest
+
+	if err := decodeBody(req, &body); err != nil {
+		writeBodyError(w, err)
+		return
+	}
+
+	if len(body.Mode) == 0 {
+		body.Mode = ctrlRepo.CompleteMaintenance.String()
+	}
+
+	mt, ok := ctrlRepo.StringToMaintenanceType[body.Mode]
+	if !ok {
+		writeError(w, http.StatusBadRequest, clues.New(body.Mode+" is an unrecognized maintenance mode"))
+		return
+	}
+
+	job := a.jobs.start(jobKindMaintenance, func(ctx context.Context) (any, error) {
+		m, err := a.r.NewMaintenance(
+			ctx,
+			ctrlRepo.Maintenance{
+				Type:   mt,
+				Safety: ctrlRepo.FullMaintenanceSafety,
+				Force:  body.Force,
+			})
+		if err != nil {
+			return nil, clues.Wrap(err, "initializing maintenance")
+		}
+
+		return nil, clues.Wrap(m.Run(ctx), "running maintenance").OrNil()
+	})
+
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// ---------------------------------------------------------------------------
+// jobs
+// ---------------------------------------------------------------------------
+
+func (a *api) getJob(w http.ResponseWriter, id string) {
+	job, ok := a.jobs.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, clues.New("job not found"))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+func (a *api) cancelJob(w http.ResponseWriter, id string) {
+	job, ok := a.jobs.get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, clues.New("job not found"))
+		return
+	}
+
+	if job.done() {
+		writeError(w, http.StatusConflict, clues.New("job already "+string(job.Status)))
+		return
+	}
+
+	job, _ = a.jobs.cancel(id)
+
+	writeJSON(w, http.StatusAccepted, job)
+}
@@ -0,0 +1,424 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/operations"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/repository"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+const testToken = "0123456789abcdef"
+
+// mockRepo implements the repository methods used by the api.  Calls
+// to any other method panic.
+type mockRepo struct {
+	repository.Repositoryer
+
+	backups   []*backup.Backup
+	backupErr error
+	newBackup func(selectors.Selector) error
+}
+
+func (mr mockRepo) BackupsByTag(context.Context, ...store.FilterOption) ([]*backup.Backup, error) {
+	return mr.backups, mr.backupErr
+}
+
+func (mr mockRepo) Backup(_ context.Context, id string) (*backup.Backup, error) {
+	for _, b := range mr.backups {
+		if string(b.ID) == id {
+			return b, nil
+		}
+	}
+
+	return nil, clues.Stack(data.ErrNotFound)
+}
+
+func (mr mockRepo) NewBackupWithLookup(
+	_ context.Context,
+	sel selectors.Selector,
+	_ idname.Cacher,
+) (operations.BackupOperation, error) {
+	return operations.BackupOperation{}, mr.newBackup(sel)
+}
+
+type APIUnitSuite struct {
+	tester.Suite
+}
+
+func TestAPIUnitSuite(t *testing.T) {
+	suite.Run(t, &APIUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func newTestAPI(ctx context.Context, mr mockRepo) *api {
+	return &api{
+		r:     mr,
+		token: testToken,
+		jobs:  newJobs(ctx),
+		resources: func(context.Context, string) (idname.Cacher, error) {
+			return idname.NewCache(map[string]string{"uid": "user@example.com"}), nil
+		},
+	}
+}
+
+func serve(a *api, method, target, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	a.handler().ServeHTTP(rec, req)
+
+	return rec
+}
+
+func (suite *APIUnitSuite) TestRoutes() {
+	bup := &backup.Backup{
+		BaseModel: model.BaseModel{ID: model.StableID("bid")},
+		Status:    "Completed",
+		Selector:  selectors.NewOneDriveBackup([]string{"uid"}).Selector,
+	}
+
+	table := []struct {
+		name         string
+		method       string
+		target       string
+		token        string
+		body         string
+		expectStatus int
+		expectBody   string
+	}{
+		{
+			name:         "missing token",
+			method:       http.MethodGet,
+			target:       "/v1/backups",
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name:         "wrong token",
+			method:       http.MethodGet,
+			target:       "/v1/backups",
+			token:        "fedcba9876543210",
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name:         "openapi spec needs no token",
+			method:       http.MethodGet,
+			target:       "/v1/openapi.yaml",
+			expectStatus: http.StatusOK,
+			expectBody:   "openapi: 3.0.3",
+		},
+		{
+			name:         "list backups",
+			method:       http.MethodGet,
+			target:       "/v1/backups?service=exchange",
+			token:        testToken,
+			expectStatus: http.StatusOK,
+			expectBody:   `"ID":"bid"`,
+		},
+		{
+			name:         "list backups of unknown service",
+			method:       http.MethodGet,
+			target:       "/v1/backups?service=fax",
+			token:        testToken,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "list backups with a malformed label",
+			method:       http.MethodGet,
+			target:       "/v1/backups?label=stage",
+			token:        testToken,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "get backup",
+			method:       http.MethodGet,
+			target:       "/v1/backups/bid",
+			token:        testToken,
+			expectStatus: http.StatusOK,
+			expectBody:   `"status":"Completed"`,
+		},
+		{
+			name:         "get missing backup",
+			method:       http.MethodGet,
+			target:       "/v1/backups/nope",
+			token:        testToken,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "unknown route",
+			method:       http.MethodGet,
+			target:       "/v1/widgets",
+			token:        testToken,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "wrong method",
+			method:       http.MethodPut,
+			target:       "/v1/backups/bid",
+			token:        testToken,
+			expectStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "backup of unsupported service",
+			method:       http.MethodPost,
+			target:       "/v1/backups",
+			token:        testToken,
+			body:         `{"service": "fax", "resources": ["*"]}`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "backup without resources",
+			method:       http.MethodPost,
+			target:       "/v1/backups",
+			token:        testToken,
+			body:         `{"service": "exchange"}`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "backup with unknown fields",
+			method:       http.MethodPost,
+			target:       "/v1/backups",
+			token:        testToken,
+			body:         `{"service": "exchange", "resources": ["*"], "mailboxes": ["a"]}`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "restore of missing backup",
+			method:       http.MethodPost,
+			target:       "/v1/restores",
+			token:        testToken,
+			body:         `{"backupID": "nope"}`,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "restore with bad collision policy",
+			method:       http.MethodPost,
+			target:       "/v1/restores",
+			token:        testToken,
+			body:         `{"backupID": "bid", "restoreConfig": {"onCollision": "clobber"}}`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "export without destination",
+			method:       http.MethodPost,
+			target:       "/v1/exports",
+			token:        testToken,
+			body:         `{"backupID": "bid"}`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "export in a format the service doesn't produce",
+			method:       http.MethodPost,
+			target:       "/v1/exports",
+			token:        testToken,
+			body:         `{"backupID": "bid", "destination": "/tmp/out", "format": "csv"}`,
+			expectStatus: http.StatusBadRequest,
+			expectBody:   "unsupported export format",
+		},
+		{
+			name:         "export outside the export root",
+			method:       http.MethodPost,
+			target:       "/v1/exports",
+			token:        testToken,
+			body:         `{"backupID": "bid", "destination": "../out"}`,
+			expectStatus: http.StatusBadRequest,
+			expectBody:   "outside the export root",
+		},
+		{
+			name:         "oversized request body",
+			method:       http.MethodPost,
+			target:       "/v1/exports",
+			token:        testToken,
+			body:         `{"backupID": "` + strings.Repeat("b", maxRequestBodySize) + `"}`,
+			expectStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:         "maintenance with bad mode",
+			method:       http.MethodPost,
+			target:       "/v1/maintenance",
+			token:        testToken,
+			body:         `{"mode": "partial"}`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "missing job",
+			method:       http.MethodGet,
+			target:       "/v1/jobs/nope",
+			token:        testToken,
+			expectStatus: http.StatusNotFound,
+		},
+		{
+			name:         "list jobs",
+			method:       http.MethodGet,
+			target:       "/v1/jobs",
+			token:        testToken,
+			expectStatus: http.StatusOK,
+			expectBody:   "[]",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			a := newTestAPI(ctx, mockRepo{backups: []*backup.Backup{bup}})
+			a.exportRoot = t.TempDir()
+
+			rec := serve(a, test.method, test.target, test.token, test.body)
+			assert.Equal(t, test.expectStatus, rec.Code, rec.Body.String())
+			assert.Contains(t, rec.Body.String(), test.expectBody)
+		})
+	}
+}
+
+func (suite *APIUnitSuite) TestCreateExport_disabled() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	a := newTestAPI(ctx, mockRepo{})
+
+	rec := serve(a, http.MethodPost, "/v1/exports", testToken, `{"backupID": "bid", "destination": "out"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	assert.Contains(t, rec.Body.String(), "--export-root")
+}
+
+func (suite *APIUnitSuite) TestExportDestination() {
+	t := suite.T()
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err, clues.ToCore(err))
+
+	outside := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "in"), 0o755))
+	require.NoError(t, os.Symlink(filepath.Join(root, "in"), filepath.Join(root, "inner")))
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "escape")))
+	require.NoError(t, os.Symlink(filepath.Join(outside, "missing"), filepath.Join(root, "broken")))
+
+	table := []struct {
+		name      string
+		dest      string
+		expect    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "new folder",
+			dest:      "a/b",
+			expect:    filepath.Join(root, "a", "b"),
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "absolute paths are relative to the root",
+			dest:      "/a",
+			expect:    filepath.Join(root, "a"),
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "the root",
+			dest:      ".",
+			expect:    root,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "symlink within the root",
+			dest:      "inner/new",
+			expect:    filepath.Join(root, "in", "new"),
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "parent of the root",
+			dest:      "a/../../out",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "symlink out of the root",
+			dest:      "escape/new",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "broken symlink",
+			dest:      "broken/new",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, err := exportDestination(root, test.dest)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *APIUnitSuite) TestCreateBackup() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var owners []string
+
+	a := newTestAPI(ctx, mockRepo{
+		newBackup: func(sel selectors.Selector) error {
+			owners = append(owners, sel.DiscreteOwner)
+			return clues.New("no provider")
+		},
+	})
+
+	rec := serve(
+		a,
+		http.MethodPost,
+		"/v1/backups",
+		testToken,
+		`{"service": "exchange", "resources": ["user@example.com"], "categories": ["email"]}`)
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+
+	var job Job
+
+	err := json.Unmarshal(rec.Body.Bytes(), &job)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, jobKindBackup, job.Kind)
+
+	a.jobs.wait()
+
+	rec = serve(a, http.MethodGet, "/v1/jobs/"+job.ID, testToken, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	err = json.Unmarshal(rec.Body.Bytes(), &job)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, JobFailed, job.Status)
+	assert.Contains(t, rec.Body.String(), "no provider")
+
+	// names get resolved to ids before backing up.
+	assert.Equal(t, []string{"uid"}, owners)
+
+	rec = serve(a, http.MethodDelete, "/v1/jobs/"+job.ID, testToken, "")
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+}
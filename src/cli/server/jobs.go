@@ -0,0 +1,270 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/google/uuid"
+
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobFailed    JobStatus = "failed"
+	JobCancelled JobStatus = "cancelled"
+)
+
+// Job describes an asynchronous operation started through the api.
+type Job struct {
+	ID          string     `json:"id"`
+	Kind        string     `json:"kind"`
+	Status      JobStatus  `json:"status"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	// Result is the kind-specific outcome of the job, if any.
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (j Job) done() bool {
+	return j.Status == JobCompleted || j.Status == JobFailed || j.Status == JobCancelled
+}
+
+// jobFunc performs the job's work.  The returned result is reported in
+// the job, even when accompanied by an error.
+type jobFunc func(ctx context.Context) (any, error)
+
+// jobRetention is how long finished jobs remain available to clients.
+const jobRetention = 24 * time.Hour
+
+type trackedJob struct {
+	Job
+	ctx    context.Context
+	cancel context.CancelFunc
+	fn     jobFunc
+}
+
+// jobs runs and tracks asynchronous jobs.  Jobs run one at a time, in
+// the order they were started, with pending jobs waiting in a queue.
+// Finished jobs are forgotten once they've been done for longer than the
+// retention.
+type jobs struct {
+	ctx       context.Context
+	now       func() time.Time
+	retention time.Duration
+
+	mu   sync.Mutex
+	byID map[string]*trackedJob
+	// queue holds the pending jobs, in the order they were started.
+	queue   []*trackedJob
+	running bool
+	wg      sync.WaitGroup
+}
+
+// newJobs produces a job tracker.  Cancelling the ctx cancels all jobs.
+func newJobs(ctx context.Context) *jobs {
+	return &jobs{
+		ctx:       ctx,
+		now:       time.Now,
+		retention: jobRetention,
+		byID:      map[string]*trackedJob{},
+	}
+}
+
+// start queues the job and returns its initial state.
+func (js *jobs) start(kind string, fn jobFunc) Job {
+	ctx, cancel := context.WithCancel(js.ctx)
+
+	tj := &trackedJob{
+		Job: Job{
+			ID:        uuid.NewString(),
+			Kind:      kind,
+			Status:    JobPending,
+			CreatedAt: js.now(),
+		},
+		cancel: cancel,
+		fn:     fn,
+	}
+
+	tj.ctx = clues.Add(ctx, "server_job_id", tj.ID, "server_job_kind", kind)
+
+	js.wg.Add(1)
+
+	js.mu.Lock()
+	js.prune()
+	js.byID[tj.ID] = tj
+	js.queue = append(js.queue, tj)
+	job := tj.Job
+	js.next()
+	js.mu.Unlock()
+
+	// cancelled jobs leave the queue right away, instead of waiting
+	// for their turn.
+	context.AfterFunc(ctx, func() { js.dequeue(tj) })
+
+	return job
+}
+
+// next runs the job at the head of the queue, unless a job is already
+// running.  js.mu must be held.
+func (js *jobs) next() {
+	if js.running || len(js.queue) == 0 {
+		return
+	}
+
+	tj := js.queue[0]
+	js.queue = js.queue[1:]
+	js.running = true
+
+	started := js.now()
+	tj.Status = JobRunning
+	tj.StartedAt = &started
+
+	go js.run(tj)
+}
+
+func (js *jobs) run(tj *trackedJob) {
+	defer tj.cancel()
+
+	var (
+		ctx    = tj.ctx
+		result any
+		err    = ctx.Err()
+	)
+
+	// cancellation may have raced with taking the job off the queue.
+	if err == nil {
+		logger.Ctx(ctx).Info("starting server job")
+
+		result, err = tj.fn(ctx)
+		if err != nil && ctx.Err() != nil {
+			err = errors.Join(ctx.Err(), err)
+		}
+	}
+
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	js.finish(tj, result, err)
+	js.running = false
+	js.next()
+}
+
+// dequeue removes the job from the queue, if it's still pending, and
+// finishes it as cancelled.
+func (js *jobs) dequeue(tj *trackedJob) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	i := slices.Index(js.queue, tj)
+	if i < 0 {
+		return
+	}
+
+	js.queue = slices.Delete(js.queue, i, i+1)
+	js.finish(tj, nil, tj.ctx.Err())
+}
+
+// finish records the outcome of the job.  js.mu must be held.
+func (js *jobs) finish(tj *trackedJob, result any, err error) {
+	defer js.wg.Done()
+
+	completed := js.now()
+	tj.CompletedAt = &completed
+	tj.Result = result
+	tj.Status = JobCompleted
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		tj.Status = JobCancelled
+		tj.Error = err.Error()
+	case err != nil:
+		tj.Status = JobFailed
+		tj.Error = err.Error()
+	}
+}
+
+// prune forgets the jobs that finished before the retention window.
+// js.mu must be held.
+func (js *jobs) prune() {
+	cutoff := js.now().Add(-js.retention)
+
+	for id, tj := range js.byID {
+		if tj.done() && tj.CompletedAt.Before(cutoff) {
+			delete(js.byID, id)
+		}
+	}
+}
+
+func (js *jobs) snapshot(tj *trackedJob) Job {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	return tj.Job
+}
+
+// get returns the current state of the job.
+func (js *jobs) get(id string) (Job, bool) {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	tj, ok := js.byID[id]
+	if !ok {
+		return Job{}, false
+	}
+
+	return tj.Job, true
+}
+
+// list returns the current state of all jobs, newest first.
+func (js *jobs) list() []Job {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+
+	js.prune()
+
+	result := make([]Job, 0, len(js.byID))
+
+	for _, tj := range js.byID {
+		result = append(result, tj.Job)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result
+}
+
+// cancel requests cancellation of the job.  Cancellation is
+// asynchronous: running jobs stop at the operation's next
+// cancellation check.
+func (js *jobs) cancel(id string) (Job, bool) {
+	js.mu.Lock()
+	tj, ok := js.byID[id]
+	js.mu.Unlock()
+
+	if !ok {
+		return Job{}, false
+	}
+
+	tj.cancel()
+
+	return js.snapshot(tj), true
+}
+
+// wait blocks until all jobs are done.
+func (js *jobs) wait() {
+	js.wg.Wait()
+}
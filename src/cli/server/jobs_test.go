@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type JobsUnitSuite struct {
+	tester.Suite
+}
+
+func TestJobsUnitSuite(t *testing.T) {
+	suite.Run(t, &JobsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// waitFor polls until the job reaches the status.
+func waitFor(t *testing.T, js *jobs, id string, status JobStatus) Job {
+	var job Job
+
+	assert.Eventually(
+		t,
+		func() bool {
+			job, _ = js.get(id)
+			return job.Status == status
+		},
+		5*time.Second,
+		10*time.Millisecond,
+		"job never reached status %s", status)
+
+	return job
+}
+
+func (suite *JobsUnitSuite) TestJobs_results() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	js := newJobs(ctx)
+
+	ok := js.start("ok", func(context.Context) (any, error) {
+		return "result", nil
+	})
+	assert.Equal(t, JobPending, ok.Status)
+	assert.NotEmpty(t, ok.ID)
+
+	failed := js.start("fail", func(context.Context) (any, error) {
+		return "partial", clues.New("boom")
+	})
+
+	js.wait()
+
+	job := waitFor(t, js, ok.ID, JobCompleted)
+	assert.Equal(t, "result", job.Result)
+	assert.Empty(t, job.Error)
+	assert.NotNil(t, job.StartedAt)
+	assert.NotNil(t, job.CompletedAt)
+
+	job = waitFor(t, js, failed.ID, JobFailed)
+	assert.Equal(t, "partial", job.Result)
+	assert.Contains(t, job.Error, "boom")
+
+	assert.Len(t, js.list(), 2)
+
+	_, found := js.get("missing")
+	assert.False(t, found)
+}
+
+func (suite *JobsUnitSuite) TestJobs_serialAndCancel() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		js      = newJobs(ctx)
+		started = make(chan struct{})
+	)
+
+	running := js.start("running", func(ctx context.Context) (any, error) {
+		close(started)
+		<-ctx.Done()
+
+		return nil, clues.Stack(ctx.Err())
+	})
+
+	<-started
+
+	pending := js.start("pending", func(context.Context) (any, error) {
+		require.Fail(t, "cancelled job should never run")
+		return nil, nil
+	})
+
+	// only one job runs at a time.
+	job, ok := js.get(pending.ID)
+	require.True(t, ok)
+	assert.Equal(t, JobPending, job.Status)
+
+	_, ok = js.cancel(pending.ID)
+	require.True(t, ok)
+
+	waitFor(t, js, pending.ID, JobCancelled)
+
+	_, ok = js.cancel(running.ID)
+	require.True(t, ok)
+
+	js.wait()
+
+	job = waitFor(t, js, running.ID, JobCancelled)
+	assert.NotNil(t, job.StartedAt)
+
+	_, ok = js.cancel("missing")
+	assert.False(t, ok)
+}
+
+func (suite *JobsUnitSuite) TestJobs_runInOrder() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		js      = newJobs(ctx)
+		release = make(chan struct{})
+		ran     []int
+	)
+
+	js.start("blocker", func(context.Context) (any, error) {
+		<-release
+		return nil, nil
+	})
+
+	for i := 0; i < 10; i++ {
+		i := i
+
+		js.start("ordered", func(context.Context) (any, error) {
+			// jobs never overlap, so no lock is needed.
+			ran = append(ran, i)
+			return nil, nil
+		})
+	}
+
+	close(release)
+	js.wait()
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, ran)
+}
+
+func (suite *JobsUnitSuite) TestJobs_prune() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		js  = newJobs(ctx)
+		now = time.Now()
+	)
+
+	js.now = func() time.Time { return now }
+
+	old := js.start("old", func(context.Context) (any, error) {
+		return nil, nil
+	})
+
+	js.wait()
+
+	now = now.Add(jobRetention / 2)
+
+	recent := js.start("recent", func(context.Context) (any, error) {
+		return nil, nil
+	})
+
+	js.wait()
+
+	assert.Len(t, js.list(), 2)
+
+	now = now.Add(jobRetention/2 + time.Minute)
+
+	jobs := js.list()
+	require.Len(t, jobs, 1)
+	assert.Equal(t, recent.ID, jobs[0].ID)
+
+	_, found := js.get(old.ID)
+	assert.False(t, found)
+}
@@ -0,0 +1,194 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+const (
+	serverCommand = "server"
+
+	// minTokenLength guards against trivially guessable tokens.
+	minTokenLength = 16
+)
+
+//nolint:lll
+const serverExamples = `# Serve the api on the default address, using the token in CORSO_SERVER_TOKEN
+corso server
+
+# Serve the api over https on all interfaces
+corso server --address :8443 --token-file /etc/corso/token \
+    --tls-cert /etc/corso/cert.pem --tls-key /etc/corso/key.pem
+
+# Allow exports, written beneath /srv/corso-exports
+corso server --export-root /srv/corso-exports
+
+# Start a backup of every mailbox, then poll the job
+curl -H "Authorization: Bearer $CORSO_SERVER_TOKEN" \
+    -d '{"service": "exchange", "resources": ["*"]}' http://127.0.0.1:8080/v1/backups
+curl -H "Authorization: Bearer $CORSO_SERVER_TOKEN" http://127.0.0.1:8080/v1/jobs/<jobID>`
+
+// AddCommands attaches the `corso server` command to the parent.
+func AddCommands(cmd *cobra.Command) {
+	c, _ := utils.AddCommand(cmd, serverCmd())
+
+	flags.AddServerFlags(c)
+	flags.AddFailFastFlag(c)
+	flags.AddAllProviderFlags(c)
+	flags.AddAllStorageFlags(c)
+}
+
+// `corso server [<flag>...]`
+func serverCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   serverCommand,
+		Short: "Serve a REST API for the repository",
+		Long: `Serve a versioned JSON REST API for listing and inspecting backups, and for starting
+backups, restores, exports, and maintenance as asynchronous jobs.  Every request must present the
+server's bearer token.  The OpenAPI specification is served at /v1/openapi.yaml.`,
+		RunE:    runServerCmd,
+		Args:    cobra.NoArgs,
+		Example: serverExamples,
+	}
+}
+
+// processes `corso server`.
+func runServerCmd(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if flags.RunModeFV == flags.RunModeFlagTest {
+		return nil
+	}
+
+	token, err := loadToken(flags.ServerTokenFileFV)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	signingKey, err := utils.LoadManifestSigningKey(flags.ManifestSigningKeyFV)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	exportRoot, err := loadExportRoot(flags.ServerExportRootFV)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	useTLS := len(flags.ServerTLSCertFV) > 0 || len(flags.ServerTLSKeyFV) > 0
+	if useTLS && (len(flags.ServerTLSCertFV) == 0 || len(flags.ServerTLSKeyFV) == 0) {
+		return Only(ctx, clues.New("both --"+flags.ServerTLSCertFN+" and --"+flags.ServerTLSKeyFN+" are required for https"))
+	}
+
+	r, rdao, err := utils.GetAccountAndConnect(ctx, cmd, path.ExchangeService)
+	if err != nil {
+		return Only(ctx, err)
+	}
+
+	defer utils.CloseRepo(ctx, r)
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	a := newAPI(ctx, r, rdao.Repo.Account, token, signingKey, exportRoot)
+
+	srv := &http.Server{
+		Addr:              flags.ServerAddressFV,
+		Handler:           a.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(_ net.Listener) context.Context { return ctx },
+	}
+
+	listen := srv.ListenAndServe
+	if useTLS {
+		listen = func() error {
+			return srv.ListenAndServeTLS(flags.ServerTLSCertFV, flags.ServerTLSKeyFV)
+		}
+	}
+
+	Infof(ctx, "Serving the Corso API on %s", flags.ServerAddressFV)
+
+	if err := utils.Serve(ctx, srv, listen); err != nil {
+		return Only(ctx, clues.Wrap(err, "serving api"))
+	}
+
+	Info(ctx, "Shutting down; waiting for running jobs to stop")
+
+	// jobs share the signal context, so they're already cancelled.
+	a.jobs.wait()
+
+	return nil
+}
+
+// loadExportRoot resolves the export root to an absolute path, free of
+// symlinks, so that export destinations can be checked against it.  An
+// empty root leaves exports disabled.
+func loadExportRoot(root string) (string, error) {
+	if len(root) == 0 {
+		return "", nil
+	}
+
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return "", clues.Wrap(err, "resolving --"+flags.ServerExportRootFN)
+	}
+
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", clues.Wrap(err, "resolving --"+flags.ServerExportRootFN)
+	}
+
+	fi, err := os.Stat(resolved)
+	if err != nil {
+		return "", clues.Wrap(err, "reading --"+flags.ServerExportRootFN)
+	}
+
+	if !fi.IsDir() {
+		return "", clues.New("--" + flags.ServerExportRootFN + " is not a directory")
+	}
+
+	return resolved, nil
+}
+
+// loadToken reads the bearer token from the file, falling back to the env.
+func loadToken(tokenFile string) (string, error) {
+	token := os.Getenv(credentials.CorsoServerToken)
+
+	if len(tokenFile) > 0 {
+		bs, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", clues.Wrap(err, "reading --"+flags.ServerTokenFileFN)
+		}
+
+		token = string(bs)
+	}
+
+	token = strings.TrimSpace(token)
+
+	if len(token) == 0 {
+		return "", clues.New("a token is required; provide --" + flags.ServerTokenFileFN +
+			" or set " + credentials.CorsoServerToken)
+	}
+
+	if len(token) < minTokenLength {
+		return "", clues.New("the token must be at least 16 characters")
+	}
+
+	return token, nil
+}
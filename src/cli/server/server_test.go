@@ -0,0 +1,126 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	flagsTD "github.com/alcionai/corso/src/cli/flags/testdata"
+	cliTD "github.com/alcionai/corso/src/cli/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type ServerUnitSuite struct {
+	tester.Suite
+}
+
+func TestServerUnitSuite(t *testing.T) {
+	suite.Run(t, &ServerUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ServerUnitSuite) TestAddCommands() {
+	t := suite.T()
+	parent := &cobra.Command{Use: "corso"}
+
+	cmd := cliTD.SetUpCmdHasFlags(
+		t,
+		parent,
+		func(c *cobra.Command) *cobra.Command {
+			AddCommands(c)
+			return c.Commands()[0]
+		},
+		nil,
+		flagsTD.WithFlags(
+			serverCommand,
+			[]string{
+				"--" + flags.RunModeFN, flags.RunModeFlagTest,
+				"--" + flags.ServerAddressFN, ":8443",
+				"--" + flags.ServerTokenFileFN, "/tmp/token",
+				"--" + flags.ServerTLSCertFN, "/tmp/cert.pem",
+				"--" + flags.ServerTLSKeyFN, "/tmp/key.pem",
+			},
+			flagsTD.PreparedProviderFlags(),
+			flagsTD.PreparedStorageFlags()))
+
+	cliTD.CheckCmdChild(
+		t,
+		parent,
+		3,
+		serverCommand,
+		serverCmd().Short,
+		runServerCmd)
+
+	assert.Equal(t, ":8443", flags.ServerAddressFV)
+	assert.Equal(t, "/tmp/token", flags.ServerTokenFileFV)
+	assert.Equal(t, "/tmp/cert.pem", flags.ServerTLSCertFV)
+	assert.Equal(t, "/tmp/key.pem", flags.ServerTLSKeyFV)
+	flagsTD.AssertProviderFlags(t, cmd)
+	flagsTD.AssertStorageFlags(t, cmd)
+}
+
+func (suite *ServerUnitSuite) TestLoadToken() {
+	dir := suite.T().TempDir()
+
+	fileToken := filepath.Join(dir, "token")
+	err := os.WriteFile(fileToken, []byte("  file-token-0123456789\n"), 0o600)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	shortToken := filepath.Join(dir, "short")
+	err = os.WriteFile(shortToken, []byte("short"), 0o600)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name      string
+		env       string
+		file      string
+		expect    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "env",
+			env:       "env-token-0123456789",
+			expect:    "env-token-0123456789",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "file overrides env",
+			env:       "env-token-0123456789",
+			file:      fileToken,
+			expect:    "file-token-0123456789",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "missing",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing file",
+			file:      filepath.Join(dir, "nope"),
+			expectErr: assert.Error,
+		},
+		{
+			name:      "too short",
+			file:      shortToken,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			t.Setenv(credentials.CorsoServerToken, test.env)
+
+			token, err := loadToken(test.file)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, token)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// BackupFilterOpts holds the values that narrow down a list of backups.
+type BackupFilterOpts struct {
+	Resource string
+	Since    string
+	Until    string
+	Status   string
+	// Labels are key=value pairs.
+	Labels []string
+}
+
+// MakeBackupFilterOpts reads the backup list filters out of the flags.
+func MakeBackupFilterOpts() BackupFilterOpts {
+	return BackupFilterOpts{
+		Resource: flags.ResourceFV,
+		Since:    flags.SinceFV,
+		Until:    flags.UntilFV,
+		Status:   flags.StatusFV,
+		Labels:   flags.LabelFV,
+	}
+}
+
+// MakeBackupFilters produces the store filters matching the options.
+// Returns an error if any of the options are malformed.
+func MakeBackupFilters(bfo BackupFilterOpts) ([]store.FilterOption, error) {
+	var fs []store.FilterOption
+
+	if len(bfo.Resource) > 0 {
+		fs = append(fs, store.ProtectedResource(bfo.Resource))
+	}
+
+	if len(bfo.Since) > 0 {
+		t, err := dttm.ParseTime(bfo.Since)
+		if err != nil {
+			return nil, clues.New("invalid time format for " + flags.SinceFN)
+		}
+
+		fs = append(fs, store.Since(t))
+	}
+
+	if len(bfo.Until) > 0 {
+		t, err := dttm.ParseTime(bfo.Until)
+		if err != nil {
+			return nil, clues.New("invalid time format for " + flags.UntilFN)
+		}
+
+		fs = append(fs, store.Until(t))
+	}
+
+	if len(bfo.Status) > 0 {
+		fs = append(fs, store.Status(bfo.Status))
+	}
+
+	labels, err := backup.ParseLabels(bfo.Labels)
+	if err != nil {
+		return nil, clues.Wrap(err, "invalid "+flags.LabelFN)
+	}
+
+	for k, v := range labels {
+		fs = append(fs, store.Label(k, v))
+	}
+
+	return fs, nil
+}
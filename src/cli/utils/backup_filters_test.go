@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type BackupFiltersUnitSuite struct {
+	tester.Suite
+}
+
+func TestBackupFiltersUnitSuite(t *testing.T) {
+	suite.Run(t, &BackupFiltersUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *BackupFiltersUnitSuite) TestMakeBackupFilters() {
+	table := []struct {
+		name        string
+		bfo         BackupFilterOpts
+		expectCount int
+		expectErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "none",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "all",
+			bfo: BackupFilterOpts{
+				Resource: "finance@example.com",
+				Since:    "2024-01-01",
+				Until:    "2024-02-01T00:00:00Z",
+				Status:   "Completed",
+				Labels:   []string{"stage=pre-migration", "dept=finance"},
+			},
+			expectCount: 6,
+			expectErr:   assert.NoError,
+		},
+		{
+			name:      "bad since",
+			bfo:       BackupFilterOpts{Since: "last tuesday"},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "bad until",
+			bfo:       BackupFilterOpts{Until: "tomorrow"},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "bad label",
+			bfo:       BackupFilterOpts{Labels: []string{"stage"}},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			fs, err := MakeBackupFilters(test.bfo)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Len(t, fs, test.expectCount)
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package utils
 
 import (
+	"strings"
+
 	"github.com/alcionai/clues"
 	"github.com/spf13/cobra"
 
 	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/selectors"
 )
 
@@ -15,12 +18,17 @@ type ExchangeOpts struct {
 	ContactFolder []string
 	ContactName   string
 
-	Email               []string
-	EmailFolder         []string
-	EmailReceivedAfter  string
-	EmailReceivedBefore string
-	EmailSender         string
-	EmailSubject        string
+	Email                []string
+	EmailFolder          []string
+	EmailReceivedAfter   string
+	EmailReceivedBefore  string
+	EmailSender          string
+	EmailSubject         string
+	EmailRecipient       string
+	EmailSizeGreaterThan string
+	EmailSizeLessThan    string
+	EmailHasAttachments  string
+	EmailAttachmentName  string
 
 	Event             []string
 	EventCalendar     []string
@@ -30,6 +38,8 @@ type ExchangeOpts struct {
 	EventStartsBefore string
 	EventSubject      string
 
+	SettingsOnly bool
+
 	RestoreCfg RestoreCfgOpts
 	ExportCfg  ExportCfgOpts
 
@@ -45,12 +55,17 @@ func MakeExchangeOpts(cmd *cobra.Command) ExchangeOpts {
 		ContactFolder: flags.ContactFolderFV,
 		ContactName:   flags.ContactNameFV,
 
-		Email:               flags.EmailFV,
-		EmailFolder:         flags.EmailFolderFV,
-		EmailReceivedAfter:  flags.EmailReceivedAfterFV,
-		EmailReceivedBefore: flags.EmailReceivedBeforeFV,
-		EmailSender:         flags.EmailSenderFV,
-		EmailSubject:        flags.EmailSubjectFV,
+		Email:                flags.EmailFV,
+		EmailFolder:          flags.EmailFolderFV,
+		EmailReceivedAfter:   flags.EmailReceivedAfterFV,
+		EmailReceivedBefore:  flags.EmailReceivedBeforeFV,
+		EmailSender:          flags.EmailSenderFV,
+		EmailSubject:         flags.EmailSubjectFV,
+		EmailRecipient:       flags.EmailRecipientFV,
+		EmailSizeGreaterThan: flags.EmailSizeGreaterThanFV,
+		EmailSizeLessThan:    flags.EmailSizeLessThanFV,
+		EmailHasAttachments:  flags.EmailHasAttachmentsFV,
+		EmailAttachmentName:  flags.EmailAttachmentNameFV,
 
 		Event:             flags.EventFV,
 		EventCalendar:     flags.EventCalendarFV,
@@ -60,6 +75,8 @@ func MakeExchangeOpts(cmd *cobra.Command) ExchangeOpts {
 		EventStartsBefore: flags.EventStartsBeforeFV,
 		EventSubject:      flags.EventSubjectFV,
 
+		SettingsOnly: flags.SettingsOnlyFV,
+
 		RestoreCfg: makeRestoreCfgOpts(cmd),
 		ExportCfg:  makeExportCfgOpts(cmd),
 
@@ -129,6 +146,18 @@ func ValidateExchangeRestoreFlags(backupID string, opts ExchangeOpts) error {
 		return clues.New("invalid time format for email-received-before")
 	}
 
+	if _, ok := opts.Populated[flags.EmailSizeGreaterThanFN]; ok && !IsValidSize(opts.EmailSizeGreaterThan) {
+		return clues.New("invalid size format for email-size-greater-than")
+	}
+
+	if _, ok := opts.Populated[flags.EmailSizeLessThanFN]; ok && !IsValidSize(opts.EmailSizeLessThan) {
+		return clues.New("invalid size format for email-size-less-than")
+	}
+
+	if _, ok := opts.Populated[flags.EmailHasAttachmentsFN]; ok && !IsValidBool(opts.EmailHasAttachments) {
+		return clues.New("invalid format for email-has-attachments")
+	}
+
 	if _, ok := opts.Populated[flags.EventStartsAfterFN]; ok && !IsValidTimeFormat(opts.EventStartsAfter) {
 		return clues.New("invalid time format for event-starts-after")
 	}
@@ -141,9 +170,29 @@ func ValidateExchangeRestoreFlags(backupID string, opts ExchangeOpts) error {
 		return clues.New("invalid format for event-recurs")
 	}
 
+	if opts.SettingsOnly && hasExchangeDataSelection(opts) {
+		return clues.New("--settings-only cannot be combined with mail, event, or contact selection flags")
+	}
+
+	// only contacts have a csv export.  Events would quietly export as ics.
+	if strings.EqualFold(opts.ExportCfg.Format, string(control.CSVFormat)) &&
+		len(opts.Event)+len(opts.EventCalendar) > 0 {
+		return clues.New("--format csv only applies to contacts, and cannot be combined with event selection flags")
+	}
+
 	return nil
 }
 
+// hasExchangeDataSelection returns true if any of the item or folder
+// selection flags were provided.
+func hasExchangeDataSelection(opts ExchangeOpts) bool {
+	lc, lcf := len(opts.Contact), len(opts.ContactFolder)
+	le, lef := len(opts.Email), len(opts.EmailFolder)
+	lev, lec := len(opts.Event), len(opts.EventCalendar)
+
+	return lc+lcf+le+lef+lev+lec > 0
+}
+
 // IncludeExchangeRestoreDataSelectors builds the common data-selector
 // inclusions for exchange commands.
 func IncludeExchangeRestoreDataSelectors(opts ExchangeOpts) *selectors.ExchangeRestore {
@@ -154,11 +203,14 @@ func IncludeExchangeRestoreDataSelectors(opts ExchangeOpts) *selectors.ExchangeR
 
 	sel := selectors.NewExchangeRestore(users)
 
-	lc, lcf := len(opts.Contact), len(opts.ContactFolder)
-	le, lef := len(opts.Email), len(opts.EmailFolder)
-	lev, lec := len(opts.Event), len(opts.EventCalendar)
+	// mailbox settings are only ever restored on their own.
+	if opts.SettingsOnly {
+		sel.Include(sel.MailboxSettings(selectors.Any()))
+		return sel
+	}
+
 	// either scope the request to a set of users
-	if lc+lcf+le+lef+lev+lec == 0 {
+	if !hasExchangeDataSelection(opts) {
 		sel.Include(sel.AllData())
 		return sel
 	}
@@ -173,6 +225,21 @@ func IncludeExchangeRestoreDataSelectors(opts ExchangeOpts) *selectors.ExchangeR
 	return sel
 }
 
+// IncludeExchangeDetailsSettingsSelectors adds the mailbox settings to the
+// selector when no specific data was requested, so that backup details
+// lists the settings alongside the items.  Restores and exports don't use
+// this; settings are only restored when --settings-only is requested.
+func IncludeExchangeDetailsSettingsSelectors(
+	sel *selectors.ExchangeRestore,
+	opts ExchangeOpts,
+) {
+	if opts.SettingsOnly || hasExchangeDataSelection(opts) {
+		return
+	}
+
+	sel.Include(sel.MailboxSettings(selectors.Any()))
+}
+
 // FilterExchangeRestoreInfoSelectors builds the common info-selector filters.
 func FilterExchangeRestoreInfoSelectors(
 	sel *selectors.ExchangeRestore,
@@ -183,6 +250,11 @@ func FilterExchangeRestoreInfoSelectors(
 	AddExchangeInfo(sel, opts.EmailReceivedBefore, sel.MailReceivedBefore)
 	AddExchangeInfo(sel, opts.EmailSender, sel.MailSender)
 	AddExchangeInfo(sel, opts.EmailSubject, sel.MailSubject)
+	AddExchangeInfo(sel, opts.EmailRecipient, sel.MailRecipient)
+	AddExchangeInfo(sel, opts.EmailSizeGreaterThan, sel.MailSizeGreater)
+	AddExchangeInfo(sel, opts.EmailSizeLessThan, sel.MailSizeLess)
+	AddExchangeInfo(sel, opts.EmailHasAttachments, sel.MailHasAttachments)
+	AddExchangeInfo(sel, opts.EmailAttachmentName, sel.MailAttachmentName)
 	AddExchangeInfo(sel, opts.EventOrganizer, sel.EventOrganizer)
 	AddExchangeInfo(sel, opts.EventRecurs, sel.EventRecurs)
 	AddExchangeInfo(sel, opts.EventStartsAfter, sel.EventStartsAfter)
@@ -51,6 +51,54 @@ func (suite *ExchangeUtilsSuite) TestValidateRestoreFlags() {
 			opts:   utils.ExchangeOpts{EmailReceivedAfter: "fnords"},
 			expect: assert.Error,
 		},
+		{
+			name:     "valid size",
+			backupID: "bid",
+			opts: utils.ExchangeOpts{
+				EmailSizeGreaterThan: "10MB",
+				Populated:            flags.PopulatedFlags{flags.EmailSizeGreaterThanFN: {}},
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:     "invalid size",
+			backupID: "bid",
+			opts: utils.ExchangeOpts{
+				EmailSizeLessThan: "fnords",
+				Populated:         flags.PopulatedFlags{flags.EmailSizeLessThanFN: {}},
+			},
+			expect: assert.Error,
+		},
+		{
+			name:     "settings only",
+			backupID: "bid",
+			opts:     utils.ExchangeOpts{SettingsOnly: true},
+			expect:   assert.NoError,
+		},
+		{
+			name:     "settings only with email",
+			backupID: "bid",
+			opts:     utils.ExchangeOpts{SettingsOnly: true, Email: []string{"id"}},
+			expect:   assert.Error,
+		},
+		{
+			name:     "csv with contacts",
+			backupID: "bid",
+			opts: utils.ExchangeOpts{
+				ContactFolder: []string{"Friends"},
+				ExportCfg:     utils.ExportCfgOpts{Format: "csv"},
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:     "csv with events",
+			backupID: "bid",
+			opts: utils.ExchangeOpts{
+				EventCalendar: []string{"Calendar"},
+				ExportCfg:     utils.ExportCfgOpts{Format: "CSV"},
+			},
+			expect: assert.Error,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -302,6 +350,14 @@ func (suite *ExchangeUtilsSuite) TestIncludeExchangeRestoreDataSelectors() {
 			},
 			expectIncludeLen: 1,
 		},
+		{
+			name: "settings only",
+			opts: utils.ExchangeOpts{
+				SettingsOnly: true,
+				Users:        a,
+			},
+			expectIncludeLen: 1,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -311,6 +367,40 @@ func (suite *ExchangeUtilsSuite) TestIncludeExchangeRestoreDataSelectors() {
 	}
 }
 
+func (suite *ExchangeUtilsSuite) TestIncludeExchangeDetailsSettingsSelectors() {
+	table := []struct {
+		name             string
+		opts             utils.ExchangeOpts
+		expectIncludeLen int
+	}{
+		{
+			name:             "no selectors",
+			expectIncludeLen: 4,
+		},
+		{
+			name: "email selected",
+			opts: utils.ExchangeOpts{
+				Email: []string{"id"},
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "settings only",
+			opts: utils.ExchangeOpts{
+				SettingsOnly: true,
+			},
+			expectIncludeLen: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			sel := utils.IncludeExchangeRestoreDataSelectors(test.opts)
+			utils.IncludeExchangeDetailsSettingsSelectors(sel, test.opts)
+			assert.Len(suite.T(), sel.Includes, test.expectIncludeLen)
+		})
+	}
+}
+
 func (suite *ExchangeUtilsSuite) TestAddExchangeInclude() {
 	var (
 		empty             = []string{}
@@ -428,6 +518,41 @@ func (suite *ExchangeUtilsSuite) TestFilterExchangeRestoreInfoSelectors() {
 			},
 			expectFilterLen: 1,
 		},
+		{
+			name: "recipient",
+			opts: utils.ExchangeOpts{
+				EmailRecipient: stub,
+			},
+			expectFilterLen: 1,
+		},
+		{
+			name: "sizeGreaterThan",
+			opts: utils.ExchangeOpts{
+				EmailSizeGreaterThan: stub,
+			},
+			expectFilterLen: 1,
+		},
+		{
+			name: "sizeLessThan",
+			opts: utils.ExchangeOpts{
+				EmailSizeLessThan: stub,
+			},
+			expectFilterLen: 1,
+		},
+		{
+			name: "hasAttachments",
+			opts: utils.ExchangeOpts{
+				EmailHasAttachments: stub,
+			},
+			expectFilterLen: 1,
+		},
+		{
+			name: "attachmentName",
+			opts: utils.ExchangeOpts{
+				EmailAttachmentName: stub,
+			},
+			expectFilterLen: 1,
+		},
 		{
 			name: "organizer",
 			opts: utils.ExchangeOpts{
@@ -466,18 +591,23 @@ func (suite *ExchangeUtilsSuite) TestFilterExchangeRestoreInfoSelectors() {
 		{
 			name: "one of each",
 			opts: utils.ExchangeOpts{
-				ContactName:         stub,
-				EmailReceivedAfter:  stub,
-				EmailReceivedBefore: stub,
-				EmailSender:         stub,
-				EmailSubject:        stub,
-				EventOrganizer:      stub,
-				EventRecurs:         stub,
-				EventStartsAfter:    stub,
-				EventStartsBefore:   stub,
-				EventSubject:        stub,
-			},
-			expectFilterLen: 10,
+				ContactName:          stub,
+				EmailReceivedAfter:   stub,
+				EmailReceivedBefore:  stub,
+				EmailSender:          stub,
+				EmailSubject:         stub,
+				EmailRecipient:       stub,
+				EmailSizeGreaterThan: stub,
+				EmailSizeLessThan:    stub,
+				EmailHasAttachments:  stub,
+				EmailAttachmentName:  stub,
+				EventOrganizer:       stub,
+				EventRecurs:          stub,
+				EventStartsAfter:     stub,
+				EventStartsBefore:    stub,
+				EventSubject:         stub,
+			},
+			expectFilterLen: 15,
 		},
 	}
 	for _, test := range table {
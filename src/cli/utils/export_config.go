@@ -1,28 +1,77 @@
 package utils
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alcionai/clues"
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/internal/archive"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/export"
 	"github.com/alcionai/corso/src/pkg/filters"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/storage"
 )
 
+// s3ExportScheme identifies export destinations that write to an s3 bucket.
+const s3ExportScheme = "s3://"
+
 type ExportCfgOpts struct {
-	Archive bool
-	Format  string
+	Aggregate             bool
+	Archive               bool
+	ArchiveFormat         string
+	ArchivePassword       string
+	ArchivePasswordFile   string
+	ArchiveRecipients     []string
+	ArchiveRecipientsFile string
+	ArchiveSplitSize      string
+	Format                string
+	ManifestFile          string
+	ManifestSigningKey    string
+	Output                string
+	Collisions            string
+	WriteParallelism      int
+
+	// only used when exporting to an s3://bucket/prefix destination.
+	Endpoint       string
+	DoNotUseTLS    bool
+	DoNotVerifyTLS bool
 
 	Populated flags.PopulatedFlags
 }
 
 func makeExportCfgOpts(cmd *cobra.Command) ExportCfgOpts {
 	return ExportCfgOpts{
-		Archive: flags.ArchiveFV,
-		Format:  flags.FormatFV,
+		Aggregate:             flags.AggregateFV,
+		Archive:               flags.ArchiveFV,
+		ArchiveFormat:         flags.ArchiveFormatFV,
+		ArchivePassword:       flags.ArchivePasswordFV,
+		ArchivePasswordFile:   flags.ArchivePasswordFileFV,
+		ArchiveRecipients:     flags.ArchiveRecipientFV,
+		ArchiveRecipientsFile: flags.ArchiveRecipientsFileFV,
+		ArchiveSplitSize:      flags.ArchiveSplitSizeFV,
+		Format:                flags.FormatFV,
+		ManifestFile:          flags.ManifestFileFV,
+		ManifestSigningKey:    flags.ManifestSigningKeyFV,
+		Output:                flags.OutputFV,
+		Collisions:            flags.ExportCollisionsFV,
+		WriteParallelism:      flags.WriteParallelismFV,
+		Endpoint:              flags.ExportEndpointFV,
+		DoNotUseTLS:           flags.ExportDoNotUseTLSFV,
+		DoNotVerifyTLS:        flags.ExportDoNotVerifyTLSFV,
 
 		// populated contains the list of flags that appear in the
 		// command, according to pflags.  Use this to differentiate
@@ -37,14 +86,114 @@ func MakeExportConfig(
 ) control.ExportConfig {
 	exportCfg := control.DefaultExportConfig()
 
+	exportCfg.Aggregate = opts.Aggregate
 	exportCfg.Archive = opts.Archive
 	exportCfg.Format = control.FormatType(opts.Format)
 
+	if _, ok := opts.Populated[flags.ArchiveFormatFN]; ok {
+		exportCfg.ArchiveFormat = control.ArchiveFormat(strings.ToLower(opts.ArchiveFormat))
+	}
+
+	if _, ok := opts.Populated[flags.ArchiveSplitSizeFN]; ok {
+		// validated in ValidateExportConfigFlags
+		size, _ := humanize.ParseBytes(opts.ArchiveSplitSize)
+		exportCfg.ArchiveSplitSize = int64(size)
+	}
+
+	// sourced from flags, files, or env in ValidateExportConfigFlags.
+	exportCfg.ArchivePassword = opts.ArchivePassword
+	exportCfg.ArchiveRecipients = opts.ArchiveRecipients
+
+	if _, ok := opts.Populated[flags.CollisionsFN]; ok {
+		exportCfg.OnCollision = control.ExportCollisionPolicy(opts.Collisions)
+	}
+
+	if _, ok := opts.Populated[flags.WriteParallelismFN]; ok {
+		exportCfg.WriteParallelism = opts.WriteParallelism
+	}
+
 	return exportCfg
 }
 
+// IsS3ExportLocation is true if the export location is an s3://bucket/prefix url.
+func IsS3ExportLocation(exportLocation string) bool {
+	return strings.HasPrefix(exportLocation, s3ExportScheme)
+}
+
+// MakeS3ExportConfig builds the s3 config for an s3://bucket/prefix export
+// location.  Credentials are sourced the same way as for the repository:
+// from the aws flags, falling back to the aws env vars.
+func MakeS3ExportConfig(
+	exportLocation string,
+	opts ExportCfgOpts,
+) (storage.S3Config, error) {
+	var (
+		cfg            = storage.S3Config{}
+		bucket, prefix = splitS3ExportLocation(exportLocation)
+		// the aws credential flags are shared with the repository.
+		awsOverrides = flags.PopulateS3Flags(opts.Populated)
+	)
+
+	if len(bucket) == 0 {
+		return cfg, clues.New("missing bucket in export destination: " + exportLocation)
+	}
+
+	overrides := map[string]string{
+		storage.Bucket:         bucket,
+		storage.Prefix:         prefix,
+		storage.Endpoint:       opts.Endpoint,
+		storage.DoNotUseTLS:    strconv.FormatBool(opts.DoNotUseTLS),
+		storage.DoNotVerifyTLS: strconv.FormatBool(opts.DoNotVerifyTLS),
+	}
+
+	for _, k := range []string{
+		credentials.AWSAccessKeyID,
+		credentials.AWSSecretAccessKey,
+		credentials.AWSSessionToken,
+	} {
+		if v, ok := awsOverrides[k]; ok {
+			overrides[k] = v
+		}
+	}
+
+	err := cfg.ApplyConfigOverrides(viper.New(), false, false, overrides)
+
+	return cfg, clues.Wrap(err, "building s3 export config").OrNil()
+}
+
+// MakeExportDestination produces the destination that export items are
+// written to.  s3://bucket/prefix locations stream items into the bucket,
+// all others write to the local filesystem.
+func MakeExportDestination(
+	exportLocation string,
+	opts ExportCfgOpts,
+) (export.Destination, error) {
+	if !IsS3ExportLocation(exportLocation) {
+		return export.NewLocalDestination(exportLocation), nil
+	}
+
+	cfg, err := MakeS3ExportConfig(exportLocation, opts)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	dest, err := export.NewS3Destination(cfg)
+
+	return dest, clues.Stack(err).OrNil()
+}
+
+// splitS3ExportLocation breaks an s3://bucket/prefix url into its bucket
+// and prefix.
+func splitS3ExportLocation(exportLocation string) (string, string) {
+	loc := strings.TrimPrefix(exportLocation, s3ExportScheme)
+	bucket, prefix, _ := strings.Cut(loc, "/")
+
+	return bucket, strings.Trim(prefix, "/")
+}
+
 // ValidateExportConfigFlags ensures all export config flags that utilize
-// enumerated values match a well-known value.
+// enumerated values match a well-known value.  Archive secrets held in
+// files or env vars are loaded into the opts.
 func ValidateExportConfigFlags(opts *ExportCfgOpts, acceptedFormatTypes []string) error {
 	if _, populated := opts.Populated[flags.FormatFN]; !populated {
 		opts.Format = string(control.DefaultFormat)
@@ -55,5 +204,201 @@ func ValidateExportConfigFlags(opts *ExportCfgOpts, acceptedFormatTypes []string
 
 	opts.Format = strings.ToLower(opts.Format)
 
+	_, populated := opts.Populated[flags.CollisionsFN]
+	isValid := control.IsValidExportCollisionPolicy(control.ExportCollisionPolicy(opts.Collisions))
+
+	if populated && !isValid {
+		return clues.New("invalid collision policy: " + opts.Collisions)
+	}
+
+	if _, populated := opts.Populated[flags.WriteParallelismFN]; populated && opts.WriteParallelism < 1 {
+		return clues.New("--" + flags.WriteParallelismFN + " must be greater than zero")
+	}
+
+	if err := validateArchiveFlags(opts); err != nil {
+		return err
+	}
+
+	return populateArchiveSecrets(opts)
+}
+
+func validateArchiveFlags(opts *ExportCfgOpts) error {
+	_, formatPopulated := opts.Populated[flags.ArchiveFormatFN]
+	_, splitPopulated := opts.Populated[flags.ArchiveSplitSizeFN]
+	toStdout := opts.Output == flags.StdoutOutput
+
+	if !opts.Archive {
+		switch {
+		case formatPopulated:
+			return clues.New("--" + flags.ArchiveFormatFN + " requires --" + flags.ArchiveFN)
+		case splitPopulated:
+			return clues.New("--" + flags.ArchiveSplitSizeFN + " requires --" + flags.ArchiveFN)
+		case toStdout:
+			return clues.New("exporting to stdout requires --" + flags.ArchiveFN)
+		}
+	}
+
+	af := control.ArchiveFormat(strings.ToLower(opts.ArchiveFormat))
+	if formatPopulated && !control.IsValidArchiveFormat(af) {
+		return clues.New("unrecognized archive format: " + opts.ArchiveFormat)
+	}
+
+	if !splitPopulated {
+		return nil
+	}
+
+	if toStdout {
+		return clues.New("--" + flags.ArchiveSplitSizeFN + " can't be used when exporting to stdout")
+	}
+
+	size, err := humanize.ParseBytes(opts.ArchiveSplitSize)
+	if err != nil {
+		return clues.Wrap(err, "parsing --"+flags.ArchiveSplitSizeFN)
+	}
+
+	if size == 0 {
+		return clues.New("--" + flags.ArchiveSplitSizeFN + " must be greater than zero")
+	}
+
+	return nil
+}
+
+// LoadManifestSigningKey reads the key used to sign the export manifest
+// from the file.  Returns a nil key if no file was provided.
+func LoadManifestSigningKey(fp string) (ed25519.PrivateKey, error) {
+	if len(fp) == 0 {
+		return nil, nil
+	}
+
+	bs, err := os.ReadFile(fp)
+	if err != nil {
+		return nil, clues.Wrap(err, "reading --"+flags.ManifestSigningKeyFN)
+	}
+
+	key, err := export.ParseSigningKey(bs)
+
+	return key, clues.Stack(err).OrNil()
+}
+
+// WriteExportManifest signs the manifest, if a key was provided, and
+// writes it to the manifest file, or else to the root of the export
+// destination, where it replaces the manifest of any earlier export to
+// the same location.
+func WriteExportManifest(
+	ctx context.Context,
+	manifest export.Manifest,
+	signingKey ed25519.PrivateKey,
+	dest export.Destination,
+	manifestFile string,
+) error {
+	if signingKey != nil {
+		if err := manifest.Sign(signingKey); err != nil {
+			return clues.Wrap(err, "signing manifest")
+		}
+	}
+
+	bs, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return clues.Wrap(err, "marshalling manifest")
+	}
+
+	if len(manifestFile) > 0 {
+		err := os.WriteFile(manifestFile, bs, 0o644)
+		return clues.Wrap(err, "writing manifest file").OrNil()
+	}
+
+	_, err = dest.WriteItem(
+		ctx,
+		"",
+		export.ManifestFileName,
+		bytes.NewReader(bs),
+		time.Now(),
+		control.ExportOverwrite,
+		count.New())
+
+	return clues.Wrap(err, "writing manifest").OrNil()
+}
+
+// ExportFormats lists the export formats accepted for items of the
+// service.
+func ExportFormats(service path.ServiceType) []string {
+	switch service {
+	case path.ExchangeService:
+		// csv is only produced for contacts.  Other data types fall back
+		// to their default format.
+		return []string{
+			string(control.DefaultFormat),
+			string(control.CSVFormat),
+		}
+	case path.GroupsService:
+		return []string{
+			string(control.DefaultFormat),
+			string(control.JSONFormat),
+			string(control.CSVFormat),
+		}
+	default:
+		return []string{string(control.DefaultFormat)}
+	}
+}
+
+// populateArchiveSecrets loads the archive password and recipients from
+// their files, falling back to the env for the password, and ensures
+// they're only used with an archive format that supports them.
+func populateArchiveSecrets(opts *ExportCfgOpts) error {
+	_, pwPopulated := opts.Populated[flags.ArchivePasswordFN]
+	_, pwFilePopulated := opts.Populated[flags.ArchivePasswordFileFN]
+	_, rcptFilePopulated := opts.Populated[flags.ArchiveRecipientsFileFN]
+	isZip := opts.Archive && (len(opts.ArchiveFormat) == 0 ||
+		control.ArchiveFormat(strings.ToLower(opts.ArchiveFormat)) == control.ZipArchive)
+
+	if pwPopulated && pwFilePopulated {
+		return clues.New("only one of --" + flags.ArchivePasswordFN + " and --" + flags.ArchivePasswordFileFN + " can be used")
+	}
+
+	switch {
+	case pwFilePopulated:
+		bs, err := os.ReadFile(opts.ArchivePasswordFile)
+		if err != nil {
+			return clues.Wrap(err, "reading --"+flags.ArchivePasswordFileFN)
+		}
+
+		opts.ArchivePassword = strings.TrimRight(string(bs), "\r\n")
+
+		if len(opts.ArchivePassword) == 0 {
+			return clues.New("--" + flags.ArchivePasswordFileFN + " is empty")
+		}
+
+	case !pwPopulated && isZip:
+		// the env is only consulted for zip archives, so that a globally
+		// set password doesn't interfere with other archive formats.
+		opts.ArchivePassword = os.Getenv(credentials.CorsoArchivePassword)
+	}
+
+	if rcptFilePopulated {
+		bs, err := os.ReadFile(opts.ArchiveRecipientsFile)
+		if err != nil {
+			return clues.Wrap(err, "reading --"+flags.ArchiveRecipientsFileFN)
+		}
+
+		opts.ArchiveRecipients = append(opts.ArchiveRecipients, string(bs))
+	}
+
+	hasPassword := len(opts.ArchivePassword) > 0
+	hasRecipients := len(opts.ArchiveRecipients) > 0
+
+	if !opts.Archive && (hasPassword || hasRecipients) {
+		return clues.New("archive encryption requires --" + flags.ArchiveFN)
+	}
+
+	if hasPassword && !isZip {
+		return clues.New("password encryption is only supported for " + string(control.ZipArchive) + " archives")
+	}
+
+	if hasRecipients {
+		if _, err := archive.ParseRecipients(opts.ArchiveRecipients); err != nil {
+			return clues.Stack(err)
+		}
+	}
+
 	return nil
 }
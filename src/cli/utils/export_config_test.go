@@ -1,15 +1,21 @@
 package utils
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"filippo.io/age"
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/storage"
 )
 
 type ExportCfgUnitSuite struct {
@@ -21,7 +27,15 @@ func TestExportCfgUnitSuite(t *testing.T) {
 }
 
 func (suite *ExportCfgUnitSuite) TestMakeExportConfig() {
-	rco := &ExportCfgOpts{Archive: true}
+	rco := &ExportCfgOpts{
+		Aggregate:        true,
+		Archive:          true,
+		ArchiveFormat:    "TAR.ZST",
+		ArchivePassword:  "hunter2",
+		ArchiveSplitSize: "4GB",
+		Collisions:       string(control.ExportRename),
+		WriteParallelism: 2,
+	}
 
 	table := []struct {
 		name      string
@@ -34,7 +48,39 @@ func (suite *ExportCfgUnitSuite) TestMakeExportConfig() {
 				flags.ArchiveFN: {},
 			},
 			expect: control.ExportConfig{
-				Archive: true,
+				Archive:          true,
+				ArchiveFormat:    control.ZipArchive,
+				OnCollision:      control.ExportOverwrite,
+				WriteParallelism: control.DefaultExportWriteParallelism,
+			},
+		},
+		{
+			name: "archive format and split size populated",
+			populated: flags.PopulatedFlags{
+				flags.ArchiveFN:          {},
+				flags.ArchiveFormatFN:    {},
+				flags.ArchiveSplitSizeFN: {},
+			},
+			expect: control.ExportConfig{
+				Archive:          true,
+				ArchiveFormat:    control.TarZstArchive,
+				ArchiveSplitSize: 4 * 1000 * 1000 * 1000,
+				OnCollision:      control.ExportOverwrite,
+				WriteParallelism: control.DefaultExportWriteParallelism,
+			},
+		},
+		{
+			name: "collisions and parallelism populated",
+			populated: flags.PopulatedFlags{
+				flags.ArchiveFN:          {},
+				flags.CollisionsFN:       {},
+				flags.WriteParallelismFN: {},
+			},
+			expect: control.ExportConfig{
+				Archive:          true,
+				ArchiveFormat:    control.ZipArchive,
+				OnCollision:      control.ExportRename,
+				WriteParallelism: 2,
 			},
 		},
 	}
@@ -49,7 +95,13 @@ func (suite *ExportCfgUnitSuite) TestMakeExportConfig() {
 			opts.Populated = test.populated
 
 			result := MakeExportConfig(ctx, opts)
+			assert.True(t, result.Aggregate)
 			assert.Equal(t, test.expect.Archive, result.Archive)
+			assert.Equal(t, test.expect.ArchiveFormat, result.ArchiveFormat)
+			assert.Equal(t, test.expect.ArchiveSplitSize, result.ArchiveSplitSize)
+			assert.Equal(t, rco.ArchivePassword, result.ArchivePassword)
+			assert.Equal(t, test.expect.OnCollision, result.OnCollision)
+			assert.Equal(t, test.expect.WriteParallelism, result.WriteParallelism)
 		})
 	}
 }
@@ -93,6 +145,128 @@ func (suite *ExportCfgUnitSuite) TestValidateExportConfigFlags() {
 			expectErr:    assert.Error,
 			expectFormat: control.DefaultFormat,
 		},
+		{
+			name: "bad collision policy",
+			input: ExportCfgOpts{
+				Collisions: "smurfs",
+				Populated:  flags.PopulatedFlags{flags.CollisionsFN: struct{}{}},
+			},
+			expectErr:    assert.Error,
+			expectFormat: control.DefaultFormat,
+		},
+		{
+			name: "bad write parallelism",
+			input: ExportCfgOpts{
+				Collisions:       string(control.ExportOverwrite),
+				WriteParallelism: 0,
+				Populated: flags.PopulatedFlags{
+					flags.CollisionsFN:       struct{}{},
+					flags.WriteParallelismFN: struct{}{},
+				},
+			},
+			expectErr:    assert.Error,
+			expectFormat: control.DefaultFormat,
+		},
+		{
+			name: "tar.zst archive",
+			input: ExportCfgOpts{
+				Archive:          true,
+				ArchiveFormat:    string(control.TarZstArchive),
+				ArchiveSplitSize: "4GB",
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:          struct{}{},
+					flags.ArchiveFormatFN:    struct{}{},
+					flags.ArchiveSplitSizeFN: struct{}{},
+				},
+			},
+			expectErr:    assert.NoError,
+			expectFormat: control.DefaultFormat,
+		},
+		{
+			name: "bad archive format",
+			input: ExportCfgOpts{
+				Archive:       true,
+				ArchiveFormat: "rar",
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:       struct{}{},
+					flags.ArchiveFormatFN: struct{}{},
+				},
+			},
+			expectErr:    assert.Error,
+			expectFormat: control.DefaultFormat,
+		},
+		{
+			name: "archive format without archive",
+			input: ExportCfgOpts{
+				ArchiveFormat: string(control.TarArchive),
+				Populated:     flags.PopulatedFlags{flags.ArchiveFormatFN: struct{}{}},
+			},
+			expectErr:    assert.Error,
+			expectFormat: control.DefaultFormat,
+		},
+		{
+			name: "bad split size",
+			input: ExportCfgOpts{
+				Archive:          true,
+				ArchiveSplitSize: "lots",
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:          struct{}{},
+					flags.ArchiveSplitSizeFN: struct{}{},
+				},
+			},
+			expectErr:    assert.Error,
+			expectFormat: control.DefaultFormat,
+		},
+		{
+			name: "zero split size",
+			input: ExportCfgOpts{
+				Archive:          true,
+				ArchiveSplitSize: "0",
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:          struct{}{},
+					flags.ArchiveSplitSizeFN: struct{}{},
+				},
+			},
+			expectErr:    assert.Error,
+			expectFormat: control.DefaultFormat,
+		},
+		{
+			name: "stdout",
+			input: ExportCfgOpts{
+				Archive: true,
+				Output:  flags.StdoutOutput,
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN: struct{}{},
+					flags.OutputFN:  struct{}{},
+				},
+			},
+			expectErr:    assert.NoError,
+			expectFormat: control.DefaultFormat,
+		},
+		{
+			name: "stdout without archive",
+			input: ExportCfgOpts{
+				Output:    flags.StdoutOutput,
+				Populated: flags.PopulatedFlags{flags.OutputFN: struct{}{}},
+			},
+			expectErr:    assert.Error,
+			expectFormat: control.DefaultFormat,
+		},
+		{
+			name: "stdout with split size",
+			input: ExportCfgOpts{
+				Archive:          true,
+				Output:           flags.StdoutOutput,
+				ArchiveSplitSize: "1GB",
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:          struct{}{},
+					flags.OutputFN:           struct{}{},
+					flags.ArchiveSplitSizeFN: struct{}{},
+				},
+			},
+			expectErr:    assert.Error,
+			expectFormat: control.DefaultFormat,
+		},
 		{
 			name: "bad format unpopulated",
 			input: ExportCfgOpts{
@@ -113,3 +287,287 @@ func (suite *ExportCfgUnitSuite) TestValidateExportConfigFlags() {
 		})
 	}
 }
+
+func (suite *ExportCfgUnitSuite) TestValidateExportConfigFlags_archiveSecrets() {
+	id, err := age.GenerateX25519Identity()
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	var (
+		dir       = suite.T().TempDir()
+		pwFile    = filepath.Join(dir, "password")
+		emptyFile = filepath.Join(dir, "empty")
+		rcptFile  = filepath.Join(dir, "recipients")
+		recipient = id.Recipient().String()
+	)
+
+	require.NoError(suite.T(), os.WriteFile(pwFile, []byte("from-file\n"), 0o600))
+	require.NoError(suite.T(), os.WriteFile(emptyFile, nil, 0o600))
+	require.NoError(suite.T(), os.WriteFile(rcptFile, []byte("# counsel\n"+recipient+"\n"), 0o600))
+
+	table := []struct {
+		name             string
+		input            ExportCfgOpts
+		env              string
+		expectErr        assert.ErrorAssertionFunc
+		expectPassword   string
+		expectRecipients int
+	}{
+		{
+			name: "password flag",
+			input: ExportCfgOpts{
+				Archive:         true,
+				ArchivePassword: "from-flag",
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:         struct{}{},
+					flags.ArchivePasswordFN: struct{}{},
+				},
+			},
+			env:            "from-env",
+			expectErr:      assert.NoError,
+			expectPassword: "from-flag",
+		},
+		{
+			name: "password file",
+			input: ExportCfgOpts{
+				Archive:             true,
+				ArchivePasswordFile: pwFile,
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:             struct{}{},
+					flags.ArchivePasswordFileFN: struct{}{},
+				},
+			},
+			env:            "from-env",
+			expectErr:      assert.NoError,
+			expectPassword: "from-file",
+		},
+		{
+			name: "password env",
+			input: ExportCfgOpts{
+				Archive:   true,
+				Populated: flags.PopulatedFlags{flags.ArchiveFN: struct{}{}},
+			},
+			env:            "from-env",
+			expectErr:      assert.NoError,
+			expectPassword: "from-env",
+		},
+		{
+			name: "password env ignored for tar",
+			input: ExportCfgOpts{
+				Archive:       true,
+				ArchiveFormat: string(control.TarArchive),
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:       struct{}{},
+					flags.ArchiveFormatFN: struct{}{},
+				},
+			},
+			env:       "from-env",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "password env ignored without archive",
+			input: ExportCfgOpts{
+				Populated: flags.PopulatedFlags{},
+			},
+			env:       "from-env",
+			expectErr: assert.NoError,
+		},
+		{
+			name: "password flag and file",
+			input: ExportCfgOpts{
+				Archive:             true,
+				ArchivePassword:     "from-flag",
+				ArchivePasswordFile: pwFile,
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:             struct{}{},
+					flags.ArchivePasswordFN:     struct{}{},
+					flags.ArchivePasswordFileFN: struct{}{},
+				},
+			},
+			expectErr:      assert.Error,
+			expectPassword: "from-flag",
+		},
+		{
+			name: "missing password file",
+			input: ExportCfgOpts{
+				Archive:             true,
+				ArchivePasswordFile: filepath.Join(dir, "smurfs"),
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:             struct{}{},
+					flags.ArchivePasswordFileFN: struct{}{},
+				},
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "empty password file",
+			input: ExportCfgOpts{
+				Archive:             true,
+				ArchivePasswordFile: emptyFile,
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:             struct{}{},
+					flags.ArchivePasswordFileFN: struct{}{},
+				},
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "password with tar",
+			input: ExportCfgOpts{
+				Archive:         true,
+				ArchiveFormat:   string(control.TarArchive),
+				ArchivePassword: "from-flag",
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:         struct{}{},
+					flags.ArchiveFormatFN:   struct{}{},
+					flags.ArchivePasswordFN: struct{}{},
+				},
+			},
+			expectErr:      assert.Error,
+			expectPassword: "from-flag",
+		},
+		{
+			name: "password without archive",
+			input: ExportCfgOpts{
+				ArchivePassword: "from-flag",
+				Populated:       flags.PopulatedFlags{flags.ArchivePasswordFN: struct{}{}},
+			},
+			expectErr:      assert.Error,
+			expectPassword: "from-flag",
+		},
+		{
+			name: "recipients flag and file",
+			input: ExportCfgOpts{
+				Archive:               true,
+				ArchiveFormat:         string(control.TarZstArchive),
+				ArchiveRecipients:     []string{recipient},
+				ArchiveRecipientsFile: rcptFile,
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:               struct{}{},
+					flags.ArchiveFormatFN:         struct{}{},
+					flags.ArchiveRecipientFN:      struct{}{},
+					flags.ArchiveRecipientsFileFN: struct{}{},
+				},
+			},
+			expectErr:        assert.NoError,
+			expectRecipients: 2,
+		},
+		{
+			name: "bad recipient",
+			input: ExportCfgOpts{
+				Archive:           true,
+				ArchiveRecipients: []string{"smurfs"},
+				Populated: flags.PopulatedFlags{
+					flags.ArchiveFN:          struct{}{},
+					flags.ArchiveRecipientFN: struct{}{},
+				},
+			},
+			expectErr:        assert.Error,
+			expectRecipients: 1,
+		},
+		{
+			name: "recipients without archive",
+			input: ExportCfgOpts{
+				ArchiveRecipients: []string{recipient},
+				Populated:         flags.PopulatedFlags{flags.ArchiveRecipientFN: struct{}{}},
+			},
+			expectErr:        assert.Error,
+			expectRecipients: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			t.Setenv(credentials.CorsoArchivePassword, test.env)
+
+			err := ValidateExportConfigFlags(&test.input, nil)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectPassword, test.input.ArchivePassword)
+			assert.Len(t, test.input.ArchiveRecipients, test.expectRecipients)
+		})
+	}
+}
+
+func (suite *ExportCfgUnitSuite) TestMakeS3ExportConfig() {
+	table := []struct {
+		name      string
+		location  string
+		opts      ExportCfgOpts
+		expect    storage.S3Config
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "bucket only",
+			location: "s3://bkt",
+			expect: storage.S3Config{
+				Bucket:   "bkt",
+				Endpoint: "s3.amazonaws.com",
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:     "bucket and prefix",
+			location: "s3://bkt/exports/case-1234/",
+			expect: storage.S3Config{
+				Bucket:   "bkt",
+				Prefix:   "exports/case-1234",
+				Endpoint: "s3.amazonaws.com",
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:     "endpoint and tls",
+			location: "s3://bkt/pfx",
+			opts: ExportCfgOpts{
+				Endpoint:       "localhost:9000",
+				DoNotUseTLS:    true,
+				DoNotVerifyTLS: true,
+			},
+			expect: storage.S3Config{
+				Bucket:         "bkt",
+				Prefix:         "pfx",
+				Endpoint:       "localhost:9000",
+				DoNotUseTLS:    true,
+				DoNotVerifyTLS: true,
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "missing bucket",
+			location:  "s3:///pfx",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			t.Setenv(credentials.AWSAccessKeyID, "access")
+			t.Setenv(credentials.AWSSecretAccessKey, "secret")
+			t.Setenv(credentials.AWSSessionToken, "")
+
+			result, err := MakeS3ExportConfig(test.location, test.opts)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expect.Bucket, result.Bucket)
+			assert.Equal(t, test.expect.Prefix, result.Prefix)
+			assert.Equal(t, test.expect.Endpoint, result.Endpoint)
+			assert.Equal(t, test.expect.DoNotUseTLS, result.DoNotUseTLS)
+			assert.Equal(t, test.expect.DoNotVerifyTLS, result.DoNotVerifyTLS)
+			assert.Equal(t, "access", result.AccessKey)
+			assert.Equal(t, "secret", result.SecretKey)
+		})
+	}
+}
+
+func (suite *ExportCfgUnitSuite) TestIsS3ExportLocation() {
+	t := suite.T()
+
+	assert.True(t, IsS3ExportLocation("s3://bkt/pfx"))
+	assert.False(t, IsS3ExportLocation("exports/s3://bkt"))
+	assert.False(t, IsS3ExportLocation("/tmp/exports"))
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/alcionai/clues"
+	"github.com/dustin/go-humanize"
 
 	"github.com/alcionai/corso/src/cli/flags"
 	"github.com/alcionai/corso/src/pkg/dttm"
@@ -26,6 +27,41 @@ func IsValidBool(in string) bool {
 	return err == nil
 }
 
+// IsValidSize returns true if the input is recognized as a count of
+// bytes, or a human readable size such as 10MB.
+func IsValidSize(in string) bool {
+	_, err := humanize.ParseBytes(in)
+	return err == nil
+}
+
+// MakeDriveExclusions builds the drive exclusion rules out of the
+// exclusion flag values.
+func MakeDriveExclusions(
+	extensions, names, paths []string,
+	maxSize string,
+) (selectors.DriveExclusions, error) {
+	de := selectors.DriveExclusions{
+		Extensions: extensions,
+		NameGlobs:  names,
+		PathGlobs:  paths,
+	}
+
+	if len(maxSize) > 0 {
+		size, err := humanize.ParseBytes(maxSize)
+		if err != nil {
+			return de, clues.New("invalid size format for " + flags.MaxFileSizeFN)
+		}
+
+		de.MaxSize = int64(size)
+	}
+
+	if err := de.Validate(); err != nil {
+		return de, clues.Wrap(err, "invalid exclusion rule")
+	}
+
+	return de, nil
+}
+
 // trimFolderSlash takes a set of folder paths and returns a set of folder paths
 // with any unescaped trailing `/` characters removed.
 func trimFolderSlash(folders []string) []string {
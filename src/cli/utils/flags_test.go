@@ -149,3 +149,62 @@ func (suite *FlagUnitSuite) TestFilesystemFlags() {
 	err := cmd.Execute()
 	require.NoError(t, err, clues.ToCore(err))
 }
+
+func (suite *FlagUnitSuite) TestMakeDriveExclusions() {
+	table := []struct {
+		name       string
+		extensions []string
+		names      []string
+		paths      []string
+		maxSize    string
+		expect     int64
+		expectErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "no rules",
+			expectErr: assert.NoError,
+		},
+		{
+			name:       "all rules",
+			extensions: []string{"iso"},
+			names:      []string{"*.tmp"},
+			paths:      []string{"Archive"},
+			maxSize:    "2KB",
+			expect:     2000,
+			expectErr:  assert.NoError,
+		},
+		{
+			name:      "bytes",
+			maxSize:   "1024",
+			expect:    1024,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "bad size",
+			maxSize:   "lots",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "bad glob",
+			names:     []string{"[a-"},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			de, err := MakeDriveExclusions(test.extensions, test.names, test.paths, test.maxSize)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.extensions, de.Extensions)
+			assert.Equal(t, test.names, de.NameGlobs)
+			assert.Equal(t, test.paths, de.PathGlobs)
+			assert.Equal(t, test.expect, de.MaxSize)
+		})
+	}
+}
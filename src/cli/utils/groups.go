@@ -16,12 +16,18 @@ type GroupsOpts struct {
 	Messages      []string
 	Conversations []string
 	Posts         []string
+	Plans         []string
+	Tasks         []string
 
 	MessageCreatedAfter    string
 	MessageCreatedBefore   string
 	MessageLastReplyAfter  string
 	MessageLastReplyBefore string
 
+	TaskAssignee  string
+	TaskDueAfter  string
+	TaskDueBefore string
+
 	SiteID             []string
 	WebURL             []string
 	Library            string
@@ -63,6 +69,7 @@ func GroupsAllowedCategories() map[string]struct{} {
 		flags.DataLibraries:     {},
 		flags.DataMessages:      {},
 		flags.DataConversations: {},
+		flags.DataPlanner:       {},
 	}
 }
 
@@ -79,6 +86,8 @@ func AddGroupsCategories(sel *selectors.GroupsBackup, cats []string) *selectors.
 			sel.Include(sel.ChannelMessages(selectors.Any(), selectors.Any()))
 		case flags.DataConversations:
 			sel.Include(sel.ConversationPosts(selectors.Any(), selectors.Any()))
+		case flags.DataPlanner:
+			sel.Include(sel.Plans(selectors.Any()))
 		}
 	}
 
@@ -92,6 +101,8 @@ func MakeGroupsOpts(cmd *cobra.Command) GroupsOpts {
 		Messages:      flags.MessageFV,
 		Conversations: flags.ConversationFV,
 		Posts:         flags.PostFV,
+		Plans:         flags.PlanFV,
+		Tasks:         flags.TaskFV,
 		WebURL:        flags.WebURLFV,
 		SiteID:        flags.SiteIDFV,
 
@@ -106,6 +117,9 @@ func MakeGroupsOpts(cmd *cobra.Command) GroupsOpts {
 		MessageCreatedBefore:   flags.MessageCreatedBeforeFV,
 		MessageLastReplyAfter:  flags.MessageLastReplyAfterFV,
 		MessageLastReplyBefore: flags.MessageLastReplyBeforeFV,
+		TaskAssignee:           flags.TaskAssigneeFV,
+		TaskDueAfter:           flags.TaskDueAfterFV,
+		TaskDueBefore:          flags.TaskDueBeforeFV,
 
 		Lists: flags.ListFV,
 
@@ -130,8 +144,9 @@ func ValidateGroupsRestoreFlags(backupID string, opts GroupsOpts, isRestore bool
 
 	// The user has to explicitly specify which resource to restore. In
 	// this case, since we can only restore sites, the user is supposed
-	// to specify which site to restore.
-	if isRestore {
+	// to specify which site to restore.  Planner data is restored into
+	// the group itself, so restores limited to planner data don't need one.
+	if isRestore && !isPlannerOnly(opts) {
 		if len(opts.WebURL)+len(opts.SiteID) == 0 {
 			return clues.New("web URL of the site to restore is required. Use --" + flags.SiteFN + " to provide one.")
 		} else if len(opts.WebURL)+len(opts.SiteID) > 1 {
@@ -155,9 +170,31 @@ func ValidateGroupsRestoreFlags(backupID string, opts GroupsOpts, isRestore bool
 		return clues.New("invalid time format for " + flags.MessageLastReplyBeforeFN)
 	}
 
+	if _, ok := opts.Populated[flags.TaskDueAfterFN]; ok && !IsValidTimeFormat(opts.TaskDueAfter) {
+		return clues.New("invalid time format for " + flags.TaskDueAfterFN)
+	}
+
+	if _, ok := opts.Populated[flags.TaskDueBeforeFN]; ok && !IsValidTimeFormat(opts.TaskDueBefore) {
+		return clues.New("invalid time format for " + flags.TaskDueBeforeFN)
+	}
+
 	return validateCommonTimeFlags(opts)
 }
 
+// isPlannerOnly is true if the opts only select planner data.
+func isPlannerOnly(opts GroupsOpts) bool {
+	planner := len(opts.Plans) + len(opts.Tasks) +
+		len(opts.TaskAssignee) + len(opts.TaskDueAfter) + len(opts.TaskDueBefore)
+
+	others := len(opts.FolderPath) + len(opts.FileName) +
+		len(opts.Lists) +
+		len(opts.PageFolder) + len(opts.Page) +
+		len(opts.Channels) + len(opts.Messages) +
+		len(opts.Conversations) + len(opts.Posts)
+
+	return planner > 0 && others == 0
+}
+
 // AddGroupsFilter adds the scope of the provided values to the selector's
 // filter set
 func AddGroupsFilter(
@@ -182,6 +219,7 @@ func IncludeGroupsRestoreDataSelectors(ctx context.Context, opts GroupsOpts) *se
 		pageFolders, pageItems = len(opts.PageFolder), len(opts.Page)
 		chans, chanMsgs        = len(opts.Channels), len(opts.Messages)
 		convs, convPosts       = len(opts.Conversations), len(opts.Posts)
+		plans, tasks           = len(opts.Plans), len(opts.Tasks)
 	)
 
 	if len(opts.Groups) == 0 {
@@ -190,11 +228,18 @@ func IncludeGroupsRestoreDataSelectors(ctx context.Context, opts GroupsOpts) *se
 
 	sel := selectors.NewGroupsRestore(groups)
 
+	// task filters without any other selection only select planner data.
+	if isPlannerOnly(opts) && plans+tasks == 0 {
+		sel.Include(sel.Plans(selectors.Any()))
+		return sel
+	}
+
 	if folderPaths+fileNames+
 		lists+
 		pageFolders+pageItems+
 		chans+chanMsgs+
-		convs+convPosts == 0 {
+		convs+convPosts+
+		plans+tasks == 0 {
 		sel.Include(sel.AllData())
 		return sel
 	}
@@ -280,6 +325,23 @@ func IncludeGroupsRestoreDataSelectors(ctx context.Context, opts GroupsOpts) *se
 		}
 	}
 
+	// planner selectors
+
+	if plans+tasks > 0 {
+		// if no plan is specified, include all plans
+		if plans == 0 {
+			opts.Plans = selectors.Any()
+		}
+
+		// if no task is specified, select whole plans;
+		// otherwise, look for plan/task pairs
+		if tasks == 0 {
+			sel.Include(sel.Plans(opts.Plans))
+		} else {
+			sel.Include(sel.PlanTasks(opts.Plans, opts.Tasks))
+		}
+	}
+
 	return sel
 }
 
@@ -314,4 +376,7 @@ func FilterGroupsRestoreInfoSelectors(
 	AddGroupsFilter(sel, opts.MessageCreatedBefore, sel.MessageCreatedBefore)
 	AddGroupsFilter(sel, opts.MessageLastReplyAfter, sel.MessageLastReplyAfter)
 	AddGroupsFilter(sel, opts.MessageLastReplyBefore, sel.MessageLastReplyBefore)
+	AddGroupsFilter(sel, opts.TaskAssignee, sel.TaskAssignee)
+	AddGroupsFilter(sel, opts.TaskDueAfter, sel.TaskDueAfter)
+	AddGroupsFilter(sel, opts.TaskDueBefore, sel.TaskDueBefore)
 }
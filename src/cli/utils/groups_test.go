@@ -43,28 +43,28 @@ func (suite *GroupsUtilsSuite) TestIncludeGroupsRestoreDataSelectors() {
 		{
 			name:             "no inputs",
 			opts:             utils.GroupsOpts{},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name: "empty",
 			opts: utils.GroupsOpts{
 				Groups: empty,
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name: "single inputs",
 			opts: utils.GroupsOpts{
 				Groups: single,
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		{
 			name: "multi inputs",
 			opts: utils.GroupsOpts{
 				Groups: multi,
 			},
-			expectIncludeLen: 3,
+			expectIncludeLen: 4,
 		},
 		// sharepoint
 		{
@@ -215,6 +215,40 @@ func (suite *GroupsUtilsSuite) TestIncludeGroupsRestoreDataSelectors() {
 			},
 			expectIncludeLen: 1,
 		},
+		// planner
+		{
+			name: "multiple plans",
+			opts: utils.GroupsOpts{
+				Groups: single,
+				Plans:  multi,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "single plan multiple tasks",
+			opts: utils.GroupsOpts{
+				Groups: single,
+				Plans:  single,
+				Tasks:  multi,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "tasks only",
+			opts: utils.GroupsOpts{
+				Groups: single,
+				Tasks:  single,
+			},
+			expectIncludeLen: 1,
+		},
+		{
+			name: "task filters only",
+			opts: utils.GroupsOpts{
+				Groups:       single,
+				TaskAssignee: "user",
+			},
+			expectIncludeLen: 1,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -293,6 +327,39 @@ func (suite *GroupsUtilsSuite) TestValidateGroupsRestoreFlags() {
 			},
 			expect: assert.NoError,
 		},
+		{
+			name:     "planner without site",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				Plans:         []string{"plan"},
+				TaskDueBefore: dttm.Now(),
+				Populated: flags.PopulatedFlags{
+					flags.TaskDueBeforeFN: struct{}{},
+				},
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:     "planner and library without site",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				Plans:    []string{"plan"},
+				FileName: []string{"file"},
+			},
+			expect: assert.Error,
+		},
+		{
+			name:     "invalid task due after",
+			backupID: "id",
+			opts: utils.GroupsOpts{
+				Plans:        []string{"plan"},
+				TaskDueAfter: "1235",
+				Populated: flags.PopulatedFlags{
+					flags.TaskDueAfterFN: struct{}{},
+				},
+			},
+			expect: assert.Error,
+		},
 		// sharepoint
 		{
 			name:     "invalid file created after",
@@ -401,7 +468,7 @@ func (suite *GroupsUtilsSuite) TestAddGroupsCategories() {
 		{
 			name:           "none",
 			cats:           []string{},
-			expectScopeLen: 3,
+			expectScopeLen: 4,
 		},
 		{
 			name:           "libraries",
@@ -418,14 +485,20 @@ func (suite *GroupsUtilsSuite) TestAddGroupsCategories() {
 			cats:           []string{flags.DataConversations},
 			expectScopeLen: 1,
 		},
+		{
+			name:           "planner",
+			cats:           []string{flags.DataPlanner},
+			expectScopeLen: 1,
+		},
 		{
 			name: "all allowed",
 			cats: []string{
 				flags.DataLibraries,
 				flags.DataMessages,
 				flags.DataConversations,
+				flags.DataPlanner,
 			},
-			expectScopeLen: 3,
+			expectScopeLen: 4,
 		},
 		{
 			name:           "bad inputs",
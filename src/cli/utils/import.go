@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"os"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cobra"
+
+	"github.com/alcionai/corso/src/cli/flags"
+)
+
+// ImportOpts holds the flag values for `corso import exchange`.
+type ImportOpts struct {
+	Mailbox    []string
+	Source     string
+	RestoreCfg RestoreCfgOpts
+
+	Populated flags.PopulatedFlags
+}
+
+// MakeImportOpts produces the import options from the command's flags.
+func MakeImportOpts(cmd *cobra.Command) ImportOpts {
+	return ImportOpts{
+		Mailbox:    flags.UserFV,
+		Source:     flags.SourceFV,
+		RestoreCfg: makeRestoreCfgOpts(cmd),
+
+		// populated contains the list of flags that appear in the
+		// command, according to pflags.  Use this to differentiate
+		// between an "empty" and a "missing" value.
+		Populated: flags.GetPopulatedFlags(cmd),
+	}
+}
+
+// ValidateImportFlags checks the import flags for correctness.
+func ValidateImportFlags(opts ImportOpts) error {
+	if len(opts.Mailbox) != 1 || opts.Mailbox[0] == flags.Wildcard {
+		return clues.New("import requires exactly one --" + flags.MailBoxFN)
+	}
+
+	fi, err := os.Stat(opts.Source)
+	if err != nil {
+		return clues.Wrap(err, "reading --"+flags.SourceFN)
+	}
+
+	if !fi.IsDir() {
+		return clues.New("--" + flags.SourceFN + " must be a directory")
+	}
+
+	return ValidateRestoreConfigFlags(opts.RestoreCfg)
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type ImportUnitSuite struct {
+	tester.Suite
+}
+
+func TestImportUnitSuite(t *testing.T) {
+	suite.Run(t, &ImportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ImportUnitSuite) TestValidateImportFlags() {
+	dir := suite.T().TempDir()
+	file := filepath.Join(dir, "file.eml")
+
+	err := os.WriteFile(file, []byte("Subject: hi\r\n\r\nhi"), 0o600)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name   string
+		opts   ImportOpts
+		expect assert.ErrorAssertionFunc
+	}{
+		{
+			name: "no error",
+			opts: ImportOpts{
+				Mailbox: []string{"mailbox"},
+				Source:  dir,
+			},
+			expect: assert.NoError,
+		},
+		{
+			name: "no mailbox",
+			opts: ImportOpts{
+				Source: dir,
+			},
+			expect: assert.Error,
+		},
+		{
+			name: "multiple mailboxes",
+			opts: ImportOpts{
+				Mailbox: []string{"mailbox1", "mailbox2"},
+				Source:  dir,
+			},
+			expect: assert.Error,
+		},
+		{
+			name: "wildcard mailbox",
+			opts: ImportOpts{
+				Mailbox: []string{flags.Wildcard},
+				Source:  dir,
+			},
+			expect: assert.Error,
+		},
+		{
+			name: "missing source",
+			opts: ImportOpts{
+				Mailbox: []string{"mailbox"},
+				Source:  filepath.Join(dir, "missing"),
+			},
+			expect: assert.Error,
+		},
+		{
+			name: "source is a file",
+			opts: ImportOpts{
+				Mailbox: []string{"mailbox"},
+				Source:  file,
+			},
+			expect: assert.Error,
+		},
+		{
+			name: "bad collision policy",
+			opts: ImportOpts{
+				Mailbox: []string{"mailbox"},
+				Source:  dir,
+				RestoreCfg: RestoreCfgOpts{
+					Collisions: "foo",
+					Populated: flags.PopulatedFlags{
+						flags.CollisionsFN: {},
+					},
+				},
+			},
+			expect: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := ValidateImportFlags(test.opts)
+			test.expect(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
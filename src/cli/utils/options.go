@@ -2,6 +2,7 @@ package utils
 
 import (
 	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/config"
 	"github.com/alcionai/corso/src/pkg/control"
 )
@@ -31,6 +32,11 @@ func Control() control.Options {
 	opt.ToggleFeatures.UseOldDeltaProcess = flags.UseOldDeltaProcessFV
 	opt.Parallelism.ItemFetch = flags.FetchParallelismFV
 
+	// malformed labels are rejected by the backup create commands.
+	if labels, err := backup.ParseLabels(flags.LabelFV); err == nil && len(labels) > 0 {
+		opt.Labels = labels
+	}
+
 	return opt
 }
 
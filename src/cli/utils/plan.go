@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/dustin/go-humanize"
+
+	. "github.com/alcionai/corso/src/cli/print"
+	"github.com/alcionai/corso/src/pkg/plan"
+)
+
+// PrintPlan prints each planned change, followed by the totals of
+// the plan.
+func PrintPlan(ctx context.Context, p *plan.Plan) {
+	items := p.Items()
+	ps := make([]Printable, 0, len(items))
+
+	for _, item := range items {
+		ps = append(ps, item)
+	}
+
+	if len(ps) > 0 {
+		All(ctx, ps...)
+	}
+
+	s := p.Summary()
+
+	Infof(
+		ctx,
+		"\nDry run: %d to create, %d to skip, %d to replace, %d to copy; %s to write",
+		s.Creates,
+		s.Skips,
+		s.Replaces,
+		s.Copies,
+		humanize.Bytes(uint64(s.Bytes)))
+
+	if s.Permissions > 0 {
+		Infof(ctx, "%d permissions would be granted", s.Permissions)
+	}
+
+	Info(ctx, "No data was written")
+}
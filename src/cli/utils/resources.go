@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"golang.org/x/exp/slices"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365"
+)
+
+// the cli names of the services that can be backed up.
+const (
+	ServiceExchange   = "exchange"
+	ServiceOneDrive   = "onedrive"
+	ServiceSharePoint = "sharepoint"
+	ServiceGroups     = "groups"
+)
+
+// the same values as the --data flag of `corso backup create exchange`.
+const (
+	dataContacts = "contacts"
+	dataEmail    = "email"
+	dataEvents   = "events"
+	dataSettings = "settings"
+)
+
+// BackupCategories are the data categories that can be selected when
+// backing up each service, keyed by the service's cli name.  Services
+// without categories always back up all of their data.
+var BackupCategories = map[string][]string{
+	ServiceExchange:   {dataEmail, dataContacts, dataEvents, dataSettings},
+	ServiceOneDrive:   {},
+	ServiceSharePoint: {flags.DataLibraries, flags.DataLists},
+	ServiceGroups: {
+		flags.DataLibraries,
+		flags.DataMessages,
+		flags.DataConversations,
+		flags.DataPlanner,
+	},
+}
+
+// ValidateBackupService checks that the service can be backed up, and
+// that it supports each of the categories.
+func ValidateBackupService(service string, cats []string) error {
+	supported, ok := BackupCategories[strings.ToLower(service)]
+	if !ok {
+		return clues.New("unsupported backup service: " + service)
+	}
+
+	for _, c := range cats {
+		if !slices.Contains(supported, c) {
+			return clues.New(c + " is not a supported category for " + service)
+		}
+	}
+
+	return nil
+}
+
+// BackupSelector produces the backup selector for the categories of a
+// single protected resource.  No categories selects all data.
+func BackupSelector(service, resourceID string, cats []string) selectors.Selector {
+	var (
+		sel    selectors.Selector
+		owners = []string{resourceID}
+	)
+
+	switch strings.ToLower(service) {
+	case ServiceExchange:
+		es := selectors.NewExchangeBackup(owners)
+
+		if len(cats) == 0 {
+			cats = BackupCategories[ServiceExchange]
+		}
+
+		for _, c := range cats {
+			switch c {
+			case dataContacts:
+				es.Include(es.ContactFolders(selectors.Any()))
+			case dataEmail:
+				es.Include(es.MailFolders(selectors.Any()))
+			case dataEvents:
+				es.Include(es.EventCalendars(selectors.Any()))
+			case dataSettings:
+				es.Include(es.MailboxSettings(selectors.Any()))
+			}
+		}
+
+		sel = es.Selector
+
+	case ServiceOneDrive:
+		ods := selectors.NewOneDriveBackup(owners)
+		ods.Include(ods.AllData())
+
+		sel = ods.Selector
+
+	case ServiceSharePoint:
+		sel = AddCategories(selectors.NewSharePointBackup(owners), cats).Selector
+
+	case ServiceGroups:
+		sel = AddGroupsCategories(selectors.NewGroupsBackup(owners), cats).Selector
+	}
+
+	sel.Configure(selectors.Config{OnlyMatchItemNames: true})
+
+	return sel
+}
+
+// ResourcesMap retrieves the ids and names of all of the service's
+// protected resources.
+func ResourcesMap(
+	ctx context.Context,
+	acct account.Account,
+	service string,
+	counter *count.Bus,
+) (idname.Cacher, error) {
+	errs := fault.New(false)
+
+	switch strings.ToLower(service) {
+	case ServiceExchange, ServiceOneDrive:
+		ins, err := UsersMap(ctx, acct, Control(), counter, errs)
+		return ins, clues.Wrap(err, "retrieving M365 users").OrNil()
+	}
+
+	svcCli, err := m365.NewM365Client(ctx, acct)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	switch strings.ToLower(service) {
+	case ServiceSharePoint:
+		ins, err := svcCli.SitesMap(ctx, errs)
+		return ins, clues.Wrap(err, "retrieving M365 sites").OrNil()
+	case ServiceGroups:
+		ins, err := svcCli.AC.Groups().GetAllIDsAndNames(ctx, errs)
+		return ins, clues.Wrap(err, "retrieving M365 groups").OrNil()
+	}
+
+	return nil, clues.New("unsupported backup service: " + service)
+}
+
+// ResolveResources produces the ids of the given resource ids or names,
+// or of all resources if the resources contain the wildcard.
+func ResolveResources(ins idname.Cacher, resources []string) ([]string, error) {
+	if slices.Contains(resources, flags.Wildcard) {
+		ids := ins.IDs()
+		slices.Sort(ids)
+
+		return ids, nil
+	}
+
+	ids := make([]string, 0, len(resources))
+
+	for _, r := range resources {
+		if id, ok := ins.IDOf(r); ok {
+			ids = append(ids, id)
+			continue
+		}
+
+		if _, ok := ins.NameOf(r); ok {
+			ids = append(ids, r)
+			continue
+		}
+
+		return nil, clues.New("unknown resource").With("resource", clues.Hide(r))
+	}
+
+	return ids, nil
+}
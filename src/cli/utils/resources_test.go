@@ -0,0 +1,112 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/utils"
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type ResourcesUnitSuite struct {
+	tester.Suite
+}
+
+func TestResourcesUnitSuite(t *testing.T) {
+	suite.Run(t, &ResourcesUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ResourcesUnitSuite) TestValidateBackupService() {
+	table := []struct {
+		name      string
+		service   string
+		cats      []string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{"exchange", "exchange", []string{"email", "events"}, assert.NoError},
+		{"mixed case", "SharePoint", []string{"lists"}, assert.NoError},
+		{"no categories", "onedrive", nil, assert.NoError},
+		{"unsupported service", "teams", nil, assert.Error},
+		{"unsupported category", "onedrive", []string{"email"}, assert.Error},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := utils.ValidateBackupService(test.service, test.cats)
+			test.expectErr(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *ResourcesUnitSuite) TestResolveResources() {
+	ins := idname.NewCache(map[string]string{
+		"id2": "two@example.com",
+		"id1": "one@example.com",
+	})
+
+	table := []struct {
+		name      string
+		resources []string
+		expect    []string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "wildcard",
+			resources: []string{"*"},
+			expect:    []string{"id1", "id2"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "names and ids",
+			resources: []string{"two@example.com", "id1"},
+			expect:    []string{"id2", "id1"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "unknown",
+			resources: []string{"three@example.com"},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, err := utils.ResolveResources(ins, test.resources)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *ResourcesUnitSuite) TestBackupSelector() {
+	table := []struct {
+		service  string
+		cats     []string
+		expect   path.ServiceType
+		scopeLen int
+	}{
+		{utils.ServiceExchange, nil, path.ExchangeService, 4},
+		{utils.ServiceExchange, []string{"email"}, path.ExchangeService, 1},
+		{utils.ServiceOneDrive, nil, path.OneDriveService, 1},
+		{utils.ServiceSharePoint, nil, path.SharePointService, 1},
+		{utils.ServiceGroups, nil, path.GroupsService, 4},
+	}
+	for _, test := range table {
+		suite.Run(test.service, func() {
+			t := suite.T()
+
+			sel := utils.BackupSelector(test.service, "id", test.cats)
+
+			assert.Equal(t, test.expect, sel.PathService())
+			assert.Equal(t, "id", sel.DiscreteOwner)
+			assert.Len(t, sel.Includes, test.scopeLen)
+			assert.Equal(t, selectors.Config{OnlyMatchItemNames: true}, sel.Cfg)
+		})
+	}
+}
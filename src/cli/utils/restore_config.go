@@ -3,6 +3,7 @@ package utils
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/alcionai/clues"
 	"github.com/spf13/cobra"
@@ -22,6 +23,16 @@ type RestoreCfgOpts struct {
 	DTTMFormat        dttm.TimeFormat
 	ProtectedResource string
 	SkipPermissions   bool
+	SkipTimestamps    bool
+	// MappingFile is the path to a csv that maps backed up
+	// users and groups onto those of the restore target.
+	MappingFile string
+	// PermissionMapFile is the path to a csv that maps the users and
+	// groups of restored permissions and link shares.
+	PermissionMapFile string
+	// TargetTenantID is the tenant that receives the restored data,
+	// when it differs from the backed up tenant.
+	TargetTenantID string
 
 	Populated flags.PopulatedFlags
 }
@@ -33,6 +44,10 @@ func makeRestoreCfgOpts(cmd *cobra.Command) RestoreCfgOpts {
 		DTTMFormat:        dttm.HumanReadable,
 		ProtectedResource: flags.ToResourceFV,
 		SkipPermissions:   flags.NoPermissionsFV,
+		SkipTimestamps:    flags.NoTimestampsFV,
+		MappingFile:       flags.MappingFileFV,
+		PermissionMapFile: flags.PermissionMapFV,
+		TargetTenantID:    flags.TargetTenantIDFV,
 
 		// populated contains the list of flags that appear in the
 		// command, according to pflags.  Use this to differentiate
@@ -50,7 +65,57 @@ func ValidateRestoreConfigFlags(opts RestoreCfgOpts) error {
 		return clues.New(fmt.Sprintf("invalid collision policy: %s", flags.CollisionsFN))
 	}
 
-	return nil
+	if len(opts.PermissionMapFile) > 0 && opts.SkipPermissions {
+		return clues.New(fmt.Sprintf(
+			"--%s cannot be used with --%s",
+			flags.PermissionMapFN,
+			flags.NoPermissionsFN))
+	}
+
+	return validateRestoreTarget(opts, len(opts.TargetTenantID) > 0)
+}
+
+// validateRestoreTarget ensures that restores into another tenant can find
+// their protected resource.  The IDs of the backed up tenant are meaningless
+// in the target tenant, so the resource has to be named explicitly or mapped.
+func validateRestoreTarget(opts RestoreCfgOpts, hasTargetTenant bool) error {
+	if !hasTargetTenant || len(opts.MappingFile) > 0 || len(opts.ProtectedResource) > 0 {
+		return nil
+	}
+
+	return clues.New(fmt.Sprintf(
+		"restoring into a target tenant requires --%s or --%s",
+		flags.MappingFileFN,
+		flags.ToResourceFN))
+}
+
+// ReadPrincipalMap parses the mapping file, if one was provided.
+func ReadPrincipalMap(opts RestoreCfgOpts) (control.PrincipalMap, error) {
+	return readPrincipalMapFile(opts.MappingFile)
+}
+
+// ReadPermissionMap parses the permission map file, if one was provided.
+func ReadPermissionMap(opts RestoreCfgOpts) (control.PrincipalMap, error) {
+	return readPrincipalMapFile(opts.PermissionMapFile)
+}
+
+func readPrincipalMapFile(fp string) (control.PrincipalMap, error) {
+	if len(fp) == 0 {
+		return nil, nil
+	}
+
+	f, err := os.Open(fp)
+	if err != nil {
+		return nil, clues.Wrap(err, "opening mapping file").With("mapping_file", fp)
+	}
+	defer f.Close()
+
+	pm, err := control.ParsePrincipalMap(f)
+	if err != nil {
+		return nil, clues.Stack(err).With("mapping_file", fp)
+	}
+
+	return pm, nil
 }
 
 func MakeRestoreConfig(
@@ -73,6 +138,7 @@ func MakeRestoreConfig(
 
 	restoreCfg.ProtectedResource = opts.ProtectedResource
 	restoreCfg.IncludePermissions = !opts.SkipPermissions
+	restoreCfg.IncludeTimestamps = !opts.SkipTimestamps
 
 	Infof(ctx, "Restoring to folder %s", restoreCfg.Location)
 
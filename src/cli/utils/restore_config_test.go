@@ -1,10 +1,13 @@
 package utils
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/cli/flags"
@@ -54,6 +57,37 @@ func (suite *RestoreCfgUnitSuite) TestValidateRestoreConfigFlags() {
 			},
 			expect: assert.Error,
 		},
+		{
+			name: "target tenant without a resource",
+			opts: RestoreCfgOpts{
+				TargetTenantID: "target-tenant",
+			},
+			expect: assert.Error,
+		},
+		{
+			name: "target tenant with a mapping file",
+			opts: RestoreCfgOpts{
+				TargetTenantID: "target-tenant",
+				MappingFile:    "mapping.csv",
+			},
+			expect: assert.NoError,
+		},
+		{
+			name: "target tenant with a protected resource",
+			opts: RestoreCfgOpts{
+				TargetTenantID:    "target-tenant",
+				ProtectedResource: "resource",
+			},
+			expect: assert.NoError,
+		},
+		{
+			name: "permission map without permissions",
+			opts: RestoreCfgOpts{
+				PermissionMapFile: "permission-map.csv",
+				SkipPermissions:   true,
+			},
+			expect: assert.Error,
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -78,8 +112,9 @@ func (suite *RestoreCfgUnitSuite) TestMakeRestoreConfig() {
 			},
 			populated: flags.PopulatedFlags{},
 			expect: control.RestoreConfig{
-				OnCollision: control.Skip,
-				Location:    "Corso_Restore_",
+				OnCollision:       control.Skip,
+				Location:          "Corso_Restore_",
+				IncludeTimestamps: true,
 			},
 		},
 		{
@@ -92,8 +127,9 @@ func (suite *RestoreCfgUnitSuite) TestMakeRestoreConfig() {
 				flags.CollisionsFN: {},
 			},
 			expect: control.RestoreConfig{
-				OnCollision: control.CollisionPolicy("collisions"),
-				Location:    "Corso_Restore_",
+				OnCollision:       control.CollisionPolicy("collisions"),
+				Location:          "Corso_Restore_",
+				IncludeTimestamps: true,
 			},
 		},
 		{
@@ -106,8 +142,9 @@ func (suite *RestoreCfgUnitSuite) TestMakeRestoreConfig() {
 				flags.DestinationFN: {},
 			},
 			expect: control.RestoreConfig{
-				OnCollision: control.Skip,
-				Location:    "destination",
+				OnCollision:       control.Skip,
+				Location:          "destination",
+				IncludeTimestamps: true,
 			},
 		},
 		{
@@ -121,8 +158,9 @@ func (suite *RestoreCfgUnitSuite) TestMakeRestoreConfig() {
 				flags.DestinationFN: {},
 			},
 			expect: control.RestoreConfig{
-				OnCollision: control.CollisionPolicy("collisions"),
-				Location:    "destination",
+				OnCollision:       control.CollisionPolicy("collisions"),
+				Location:          "destination",
+				IncludeTimestamps: true,
 			},
 		},
 		{
@@ -140,6 +178,24 @@ func (suite *RestoreCfgUnitSuite) TestMakeRestoreConfig() {
 				OnCollision:        control.CollisionPolicy("collisions"),
 				Location:           "destination",
 				IncludePermissions: false,
+				IncludeTimestamps:  true,
+			},
+		},
+		{
+			name: "without restore timestamps",
+			rco: &RestoreCfgOpts{
+				Collisions:     "collisions",
+				Destination:    "destination",
+				SkipTimestamps: true,
+			},
+			populated: flags.PopulatedFlags{
+				flags.CollisionsFN:  {},
+				flags.DestinationFN: {},
+			},
+			expect: control.RestoreConfig{
+				OnCollision:       control.CollisionPolicy("collisions"),
+				Location:          "destination",
+				IncludeTimestamps: false,
 			},
 		},
 	}
@@ -156,6 +212,52 @@ func (suite *RestoreCfgUnitSuite) TestMakeRestoreConfig() {
 			result := MakeRestoreConfig(ctx, opts)
 			assert.Equal(t, test.expect.OnCollision, result.OnCollision)
 			assert.Contains(t, result.Location, test.expect.Location)
+			assert.Equal(t, test.expect.IncludeTimestamps, result.IncludeTimestamps)
+		})
+	}
+}
+
+func (suite *RestoreCfgUnitSuite) TestReadPrincipalMap() {
+	table := []struct {
+		name      string
+		contents  string
+		noFile    bool
+		expect    control.PrincipalMap
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "no mapping file",
+			noFile:    true,
+			expectErr: assert.NoError,
+		},
+		{
+			name:     "mapping file",
+			contents: "source,target\nalice@source.com,alice@target.com\n",
+			expect: control.PrincipalMap{
+				"alice@source.com": "alice@target.com",
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "malformed mapping file",
+			contents:  "alice@source.com\n",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+			opts := RestoreCfgOpts{}
+
+			if !test.noFile {
+				opts.MappingFile = filepath.Join(t.TempDir(), "mapping.csv")
+				err := os.WriteFile(opts.MappingFile, []byte(test.contents), 0o600)
+				require.NoError(t, err, clues.ToCore(err))
+			}
+
+			result, err := ReadPrincipalMap(opts)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, result)
 		})
 	}
 }
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// serverShutdownTimeout bounds the time spent draining in-flight requests
+// once a server stops.
+const serverShutdownTimeout = 30 * time.Second
+
+// Serve runs the server using listen, eg: srv.ListenAndServe, until the
+// server fails or the ctx is cancelled.  The server is then shut down,
+// giving in-flight requests a chance to finish.  Returns nil if the server
+// was stopped by the ctx.
+func Serve(ctx context.Context, srv *http.Server, listen func() error) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- listen()
+	}()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return clues.Stack(err)
+		}
+	case <-ctx.Done():
+	}
+
+	sctx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(sctx); err != nil {
+		logger.CtxErr(ctx, err).Info("shutting down server")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type ServeUnitSuite struct {
+	tester.Suite
+}
+
+func TestServeUnitSuite(t *testing.T) {
+	suite.Run(t, &ServeUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ServeUnitSuite) TestServe_cancelled() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, clues.ToCore(err))
+
+	var (
+		served     = make(chan struct{})
+		cctx, cncl = context.WithCancel(ctx)
+		srv        = &http.Server{
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(served)
+			}),
+		}
+	)
+
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String())
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		<-served
+		cncl()
+	}()
+
+	err = Serve(cctx, srv, func() error { return srv.Serve(ln) })
+	assert.NoError(t, err, clues.ToCore(err))
+
+	// the server is shut down once serving stops.
+	_, err = net.Dial("tcp", ln.Addr().String())
+	assert.Error(t, err, "listener is closed")
+}
+
+func (suite *ServeUnitSuite) TestServe_listenFails() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	srv := &http.Server{}
+
+	err := Serve(ctx, srv, func() error { return assert.AnError })
+	assert.ErrorIs(t, err, assert.AnError, clues.ToCore(err))
+}
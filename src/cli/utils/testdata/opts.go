@@ -77,6 +77,39 @@ var (
 				}
 			},
 		},
+		{
+			Name: "BadEmailSizeGreaterThan",
+			Opts: func(t *testing.T, wantedVersion int) utils.ExchangeOpts {
+				return utils.ExchangeOpts{
+					EmailSizeGreaterThan: "foo",
+					Populated: flags.PopulatedFlags{
+						flags.EmailSizeGreaterThanFN: struct{}{},
+					},
+				}
+			},
+		},
+		{
+			Name: "BadEmailSizeLessThan",
+			Opts: func(t *testing.T, wantedVersion int) utils.ExchangeOpts {
+				return utils.ExchangeOpts{
+					EmailSizeLessThan: "foo",
+					Populated: flags.PopulatedFlags{
+						flags.EmailSizeLessThanFN: struct{}{},
+					},
+				}
+			},
+		},
+		{
+			Name: "BadEmailHasAttachments",
+			Opts: func(t *testing.T, wantedVersion int) utils.ExchangeOpts {
+				return utils.ExchangeOpts{
+					EmailHasAttachments: "foo",
+					Populated: flags.PopulatedFlags{
+						flags.EmailHasAttachmentsFN: struct{}{},
+					},
+				}
+			},
+		},
 		{
 			Name: "BadEventRecurs",
 			Opts: func(t *testing.T, wantedVersion int) utils.ExchangeOpts {
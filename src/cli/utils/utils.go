@@ -50,6 +50,35 @@ func GetAccountAndConnectWithOverrides(
 	pst path.ServiceType,
 	provider storage.ProviderType,
 	overrides map[string]string,
+) (repository.Repositoryer, RepoDetailsAndOpts, error) {
+	return getAccountAndConnect(ctx, pst, provider, overrides, nil)
+}
+
+// GetAccountAndConnectToRestoreTarget is GetAccountAndConnect for restores.
+// If a target tenant is provided, the data provider connects to that tenant,
+// while backups are still read using the configured account.
+func GetAccountAndConnectToRestoreTarget(
+	ctx context.Context,
+	cmd *cobra.Command,
+	pst path.ServiceType,
+	opts RestoreCfgOpts,
+) (repository.Repositoryer, RepoDetailsAndOpts, error) {
+	provider, overrides, err := GetStorageProviderAndOverrides(ctx, cmd)
+	if err != nil {
+		return nil, RepoDetailsAndOpts{}, clues.Stack(err)
+	}
+
+	return getAccountAndConnect(ctx, pst, provider, overrides, &opts)
+}
+
+// getAccountAndConnect connects to the repository.  When restore options
+// are provided, the data provider connects to the restore target account.
+func getAccountAndConnect(
+	ctx context.Context,
+	pst path.ServiceType,
+	provider storage.ProviderType,
+	overrides map[string]string,
+	restoreOpts *RestoreCfgOpts,
 ) (repository.Repositoryer, RepoDetailsAndOpts, error) {
 	cfg, err := config.ReadCorsoConfig(
 		ctx,
@@ -67,6 +96,20 @@ func GetAccountAndConnectWithOverrides(
 	}
 
 	opts := ControlWithConfig(cfg)
+	connCfg := repository.ConnConfig{Service: pst}
+
+	if restoreOpts != nil {
+		connCfg.TargetAccount, err = config.TargetAccount(cfg.Account)
+		if err != nil {
+			return nil, RepoDetailsAndOpts{}, clues.Wrap(err, "configuring the restore target account")
+		}
+
+		// the target tenant can also come from the env.
+		err = validateRestoreTarget(*restoreOpts, connCfg.TargetAccount != nil)
+		if err != nil {
+			return nil, RepoDetailsAndOpts{}, err
+		}
+	}
 
 	r, err := repository.New(
 		ctx,
@@ -78,7 +121,7 @@ func GetAccountAndConnectWithOverrides(
 		return nil, RepoDetailsAndOpts{}, clues.Wrap(err, "creating a repository controller")
 	}
 
-	if err := r.Connect(ctx, repository.ConnConfig{Service: pst}); err != nil {
+	if err := r.Connect(ctx, connCfg); err != nil {
 		return nil, RepoDetailsAndOpts{}, clues.Wrap(err, "connecting to the "+cfg.Storage.Provider.String()+" repository")
 	}
 
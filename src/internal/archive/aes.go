@@ -0,0 +1,235 @@
+package archive
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // sha1 is mandated by the winzip aes spec.
+	"encoding/binary"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/alcionai/clues"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// WinZip AES (AE-2) encryption, as described in
+// https://www.winzip.com/en/support/aes-encryption/.  Entries are
+// compressed with deflate, then encrypted with AES-256 in CTR mode and
+// authenticated with HMAC-SHA1.  AE-2 leaves the crc unset, so that it
+// can't leak any information about the plaintext.
+const (
+	aesMethod         uint16 = 99
+	aesExtraID        uint16 = 0x9901
+	aesVendorVersion  uint16 = 2
+	aesStrength256    byte   = 3
+	aesKeyLen                = 32
+	aesSaltLen               = 16
+	aesVerifierLen           = 2
+	aesMACLen                = 10
+	aesKDFIterations         = 1000
+	aesZipVersion     uint16 = 51
+	zipFlagEncrypted  uint16 = 0x1
+	zipFlagUTF8       uint16 = 0x800
+	zipDeflateMethod  uint16 = 8
+	aesExtraFieldSize uint16 = 7
+	extTimeExtraID    uint16 = 0x5455
+)
+
+// writeAESZipEntry compresses and encrypts the body with the password,
+// and adds it to the zip as the entry described by fh.  The encrypted
+// entry is spooled before writing, since zip writers need the size of
+// raw entries up front.  Only ciphertext is ever spooled to disk.
+func writeAESZipEntry(
+	wr *zip.Writer,
+	fh *zip.FileHeader,
+	body io.Reader,
+	password string,
+	buf []byte,
+) error {
+	pr, pw := io.Pipe()
+	// unblocks the encryption if spooling fails part way.
+	defer pr.Close()
+
+	var plainSize int64
+
+	go func() {
+		n, err := encryptAESEntry(pw, body, password, buf)
+		plainSize = n
+
+		pw.CloseWithError(err)
+	}()
+
+	enc, encSize, cleanup, err := spool(pr)
+	if err != nil {
+		return clues.Wrap(err, "encrypting zip entry")
+	}
+
+	defer cleanup()
+
+	modTime := fh.Modified
+	if modTime.IsZero() {
+		modTime = time.Now()
+	}
+
+	// the aes extra field, followed by the same extended timestamp
+	// that zip.Writer.CreateHeader adds for unencrypted entries.
+	var extra [4 + 7 + 4 + 5]byte
+
+	binary.LittleEndian.PutUint16(extra[0:], aesExtraID)
+	binary.LittleEndian.PutUint16(extra[2:], aesExtraFieldSize)
+	binary.LittleEndian.PutUint16(extra[4:], aesVendorVersion)
+	copy(extra[6:], "AE")
+	extra[8] = aesStrength256
+	binary.LittleEndian.PutUint16(extra[9:], zipDeflateMethod)
+	binary.LittleEndian.PutUint16(extra[11:], extTimeExtraID)
+	binary.LittleEndian.PutUint16(extra[13:], 5)
+	extra[15] = 1 // mod time only
+	binary.LittleEndian.PutUint32(extra[16:], uint32(modTime.Unix()))
+
+	fh.Method = aesMethod
+	fh.Flags |= zipFlagEncrypted | zipFlagUTF8
+	fh.CreatorVersion = aesZipVersion
+	fh.ReaderVersion = aesZipVersion
+	fh.Extra = append(fh.Extra, extra[:]...)
+	fh.ModifiedDate, fh.ModifiedTime = msDosTime(modTime)
+	fh.CompressedSize64 = uint64(encSize)
+	fh.UncompressedSize64 = uint64(plainSize)
+
+	f, err := wr.CreateRaw(fh)
+	if err != nil {
+		return clues.Wrap(err, "creating zip entry")
+	}
+
+	if _, err := io.CopyBuffer(f, enc, buf); err != nil {
+		return clues.Wrap(err, "writing zip entry")
+	}
+
+	return nil
+}
+
+// encryptAESEntry writes the salt, password verifier, encrypted and
+// deflated body, and authentication code to w.  Returns the size of the
+// unencrypted body.
+func encryptAESEntry(
+	w io.Writer,
+	body io.Reader,
+	password string,
+	buf []byte,
+) (int64, error) {
+	salt := make([]byte, aesSaltLen)
+
+	if _, err := rand.Read(salt); err != nil {
+		return 0, clues.Wrap(err, "generating salt")
+	}
+
+	encKey, macKey, verifier := deriveAESKeys(password, salt)
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return 0, clues.Wrap(err, "creating cipher")
+	}
+
+	if _, err := w.Write(append(salt, verifier...)); err != nil {
+		return 0, clues.Wrap(err, "writing encryption header")
+	}
+
+	mac := hmac.New(sha1.New, macKey)
+	ew := &aesCTRWriter{
+		w:      w,
+		block:  block,
+		mac:    mac,
+		stream: make([]byte, aes.BlockSize),
+		used:   aes.BlockSize,
+	}
+
+	fw, err := flate.NewWriter(ew, flate.DefaultCompression)
+	if err != nil {
+		return 0, clues.Wrap(err, "creating compressor")
+	}
+
+	n, err := io.CopyBuffer(fw, body, buf)
+	if err != nil {
+		return 0, clues.Wrap(err, "compressing entry")
+	}
+
+	if err := fw.Close(); err != nil {
+		return 0, clues.Wrap(err, "compressing entry")
+	}
+
+	if _, err := w.Write(mac.Sum(nil)[:aesMACLen]); err != nil {
+		return 0, clues.Wrap(err, "writing authentication code")
+	}
+
+	return n, nil
+}
+
+// deriveAESKeys produces the encryption key, authentication key, and
+// password verifier for the salt.
+func deriveAESKeys(password string, salt []byte) ([]byte, []byte, []byte) {
+	dk := pbkdf2.Key(
+		[]byte(password),
+		salt,
+		aesKDFIterations,
+		2*aesKeyLen+aesVerifierLen,
+		sha1.New)
+
+	return dk[:aesKeyLen], dk[aesKeyLen : 2*aesKeyLen], dk[2*aesKeyLen:]
+}
+
+// aesCTRWriter encrypts everything written to it, and adds the
+// ciphertext to the mac.  WinZip uses a little-endian block counter
+// starting at 1, which differs from the big-endian counter in
+// cipher.NewCTR.
+type aesCTRWriter struct {
+	w       io.Writer
+	block   cipher.Block
+	mac     hash.Hash
+	counter uint64
+	stream  []byte
+	used    int
+}
+
+func (cw *aesCTRWriter) Write(p []byte) (int, error) {
+	out := make([]byte, len(p))
+
+	for i := range p {
+		if cw.used == aes.BlockSize {
+			cw.nextStream()
+		}
+
+		out[i] = p[i] ^ cw.stream[cw.used]
+		cw.used++
+	}
+
+	cw.mac.Write(out)
+
+	return cw.w.Write(out)
+}
+
+func (cw *aesCTRWriter) nextStream() {
+	var ctr [aes.BlockSize]byte
+
+	cw.counter++
+	binary.LittleEndian.PutUint64(ctr[:], cw.counter)
+	cw.block.Encrypt(cw.stream, ctr[:])
+	cw.used = 0
+}
+
+// msDosTime converts the time into the ms-dos date and time fields used
+// in zip headers.
+func msDosTime(t time.Time) (uint16, uint16) {
+	// ms-dos time can't represent anything before 1980.
+	if t.Year() < 1980 {
+		t = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	date := uint16(t.Day() + int(t.Month())<<5 + (t.Year()-1980)<<9)
+	tm := uint16(t.Second()/2 + t.Minute()<<5 + t.Hour()<<11)
+
+	return date, tm
+}
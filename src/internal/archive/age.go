@@ -0,0 +1,98 @@
+package archive
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"filippo.io/age"
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/export"
+)
+
+// ParseRecipients parses age public keys (age1...), one per entry.
+// Entries may also hold the contents of an age recipients file, where
+// blank lines and lines starting with # are ignored.
+func ParseRecipients(recipients []string) ([]age.Recipient, error) {
+	rs, err := age.ParseRecipients(strings.NewReader(strings.Join(recipients, "\n")))
+	if err != nil {
+		return nil, clues.Wrap(err, "parsing age recipients")
+	}
+
+	return rs, nil
+}
+
+// EncryptExportCollection encrypts each item in the collection to the
+// age recipients, appending ".age" to the item names.  Only the holders
+// of the matching identities can decrypt the items.
+func EncryptExportCollection(
+	coll export.Collectioner,
+	recipients []age.Recipient,
+) export.Collectioner {
+	return encryptedCollection{
+		coll:       coll,
+		recipients: recipients,
+	}
+}
+
+type encryptedCollection struct {
+	coll       export.Collectioner
+	recipients []age.Recipient
+}
+
+func (ec encryptedCollection) BasePath() string {
+	return ec.coll.BasePath()
+}
+
+func (ec encryptedCollection) Items(ctx context.Context) <-chan export.Item {
+	rc := make(chan export.Item)
+
+	go func() {
+		defer close(rc)
+
+		for item := range ec.coll.Items(ctx) {
+			if item.Error == nil {
+				pr, pw := io.Pipe()
+
+				go encryptItem(item.Body, pw, ec.recipients)
+
+				item = export.Item{
+					ID:      item.ID,
+					Name:    item.Name + ".age",
+					Body:    pr,
+					ModTime: item.ModTime,
+				}
+			}
+
+			select {
+			case rc <- item:
+			case <-ctx.Done():
+				if item.Body != nil {
+					item.Body.Close()
+				}
+
+				return
+			}
+		}
+	}()
+
+	return rc
+}
+
+func encryptItem(body io.ReadCloser, pw *io.PipeWriter, recipients []age.Recipient) {
+	defer body.Close()
+
+	w, err := age.Encrypt(pw, recipients...)
+	if err != nil {
+		pw.CloseWithError(clues.Wrap(err, "initializing encryption"))
+		return
+	}
+
+	if _, err := io.Copy(w, body); err != nil {
+		pw.CloseWithError(clues.Wrap(err, "encrypting item"))
+		return
+	}
+
+	pw.CloseWithError(clues.Wrap(w.Close(), "encrypting item").OrNil())
+}
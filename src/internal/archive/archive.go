@@ -0,0 +1,115 @@
+package archive
+
+import (
+	"context"
+	"io"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/export"
+)
+
+// ExportCollection archives the export collections into a single
+// collection, using the archive format, encryption, and split size in
+// the config.
+func ExportCollection(
+	ctx context.Context,
+	cfg control.ExportConfig,
+	expCollections []export.Collectioner,
+) (export.Collectioner, error) {
+	var (
+		coll export.Collectioner
+		err  error
+	)
+
+	if len(cfg.ArchivePassword) > 0 && !isZip(cfg.ArchiveFormat) {
+		return nil, clues.New("password encryption is only supported for zip archives").
+			With("archive_format", cfg.ArchiveFormat)
+	}
+
+	switch cfg.ArchiveFormat {
+	case "", control.ZipArchive:
+		coll, err = ZipExportCollection(ctx, expCollections, cfg.ArchivePassword)
+	case control.TarArchive:
+		coll, err = TarExportCollection(ctx, expCollections, false)
+	case control.TarZstArchive:
+		coll, err = TarExportCollection(ctx, expCollections, true)
+	default:
+		return nil, clues.New("unsupported archive format").
+			With("archive_format", cfg.ArchiveFormat)
+	}
+
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	if len(cfg.ArchiveRecipients) > 0 {
+		rs, err := ParseRecipients(cfg.ArchiveRecipients)
+		if err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		coll = EncryptExportCollection(coll, rs)
+	}
+
+	// split last, so that the volumes hold the encrypted archive.
+	if cfg.ArchiveSplitSize > 0 {
+		coll = SplitExportCollection(coll, cfg.ArchiveSplitSize)
+	}
+
+	return coll, nil
+}
+
+// FileName produces the name of the archive that ExportCollection would
+// produce for the config, ahead of any volume numbering.
+func FileName(cfg control.ExportConfig) string {
+	ext := "zip"
+
+	switch cfg.ArchiveFormat {
+	case control.TarArchive:
+		ext = "tar"
+	case control.TarZstArchive:
+		ext = "tar.zst"
+	}
+
+	name := archiveName(ext)
+
+	if len(cfg.ArchiveRecipients) > 0 {
+		name += ".age"
+	}
+
+	return name
+}
+
+func archiveName(ext string) string {
+	return "Corso_Export_" + dttm.FormatNow(dttm.HumanReadable) + "." + ext
+}
+
+func isZip(af control.ArchiveFormat) bool {
+	return len(af) == 0 || af == control.ZipArchive
+}
+
+// archiveCollection holds the single archive file produced from a
+// set of export collections.
+type archiveCollection struct {
+	reader io.ReadCloser
+	ext    string
+}
+
+func (ac archiveCollection) BasePath() string {
+	return ""
+}
+
+func (ac archiveCollection) Items(ctx context.Context) <-chan export.Item {
+	rc := make(chan export.Item, 1)
+	defer close(rc)
+
+	rc <- export.Item{
+		Name: archiveName(ac.ext),
+		Body: ac.reader,
+	}
+
+	return rc
+}
@@ -0,0 +1,644 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/aes"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/alcionai/clues"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+)
+
+type ArchiveUnitSuite struct {
+	tester.Suite
+}
+
+func TestArchiveUnitSuite(t *testing.T) {
+	suite.Run(t, &ArchiveUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+type mockCollection struct {
+	path  string
+	items map[string]string
+}
+
+func (mc mockCollection) BasePath() string { return mc.path }
+func (mc mockCollection) Items(context.Context) <-chan export.Item {
+	ch := make(chan export.Item, len(mc.items))
+	defer close(ch)
+
+	for name, body := range mc.items {
+		ch <- export.Item{
+			ID:      name,
+			Name:    name,
+			Body:    io.NopCloser(bytes.NewBufferString(body)),
+			ModTime: time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+	}
+
+	return ch
+}
+
+func mockCollections() []export.Collectioner {
+	return []export.Collectioner{
+		mockCollection{
+			path:  "Documents",
+			items: map[string]string{"a.txt": "alpha", "b.txt": "bravo"},
+		},
+		mockCollection{
+			path:  "Documents/Reports",
+			items: map[string]string{"c.txt": strings.Repeat("charlie", 100)},
+		},
+	}
+}
+
+var expectEntries = map[string]string{
+	"Documents/a.txt":         "alpha",
+	"Documents/b.txt":         "bravo",
+	"Documents/Reports/c.txt": strings.Repeat("charlie", 100),
+}
+
+// readArchive collects the single item produced by the collection.
+func readArchive(
+	t *testing.T,
+	ctx context.Context, //revive:disable-line:context-as-argument
+	coll export.Collectioner,
+) (string, []byte) {
+	var (
+		name string
+		body []byte
+	)
+
+	for item := range coll.Items(ctx) {
+		require.NoError(t, item.Error, clues.ToCore(item.Error))
+		require.Empty(t, name, "only one archive item")
+
+		bs, err := io.ReadAll(item.Body)
+		require.NoError(t, err, clues.ToCore(err))
+
+		name = item.Name
+		body = bs
+	}
+
+	return name, body
+}
+
+func readTar(t *testing.T, r io.Reader) map[string]string {
+	var (
+		tr    = tar.NewReader(r)
+		found = map[string]string{}
+	)
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err, clues.ToCore(err))
+
+		bs, err := io.ReadAll(tr)
+		require.NoError(t, err, clues.ToCore(err))
+
+		assert.Equal(t, int64(len(bs)), hdr.Size, hdr.Name)
+		assert.Equal(t, 2022, hdr.ModTime.Year(), hdr.Name)
+
+		found[hdr.Name] = string(bs)
+	}
+
+	return found
+}
+
+func (suite *ArchiveUnitSuite) TestExportCollection() {
+	table := []struct {
+		name      string
+		format    control.ArchiveFormat
+		expectExt string
+		read      func(t *testing.T, bs []byte) map[string]string
+	}{
+		{
+			name:      "default is zip",
+			expectExt: ".zip",
+			read:      readZipEntries,
+		},
+		{
+			name:      "zip",
+			format:    control.ZipArchive,
+			expectExt: ".zip",
+			read:      readZipEntries,
+		},
+		{
+			name:      "tar",
+			format:    control.TarArchive,
+			expectExt: ".tar",
+			read: func(t *testing.T, bs []byte) map[string]string {
+				return readTar(t, bytes.NewReader(bs))
+			},
+		},
+		{
+			name:      "tar.zst",
+			format:    control.TarZstArchive,
+			expectExt: ".tar.zst",
+			read: func(t *testing.T, bs []byte) map[string]string {
+				zr, err := zstd.NewReader(bytes.NewReader(bs))
+				require.NoError(t, err, clues.ToCore(err))
+
+				defer zr.Close()
+
+				return readTar(t, zr)
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			cfg := control.ExportConfig{Archive: true, ArchiveFormat: test.format}
+
+			coll, err := ExportCollection(ctx, cfg, mockCollections())
+			require.NoError(t, err, clues.ToCore(err))
+
+			name, body := readArchive(t, ctx, coll)
+			assert.True(t, strings.HasSuffix(name, test.expectExt), name)
+			assert.Equal(t, expectEntries, test.read(t, body))
+
+			fn := FileName(cfg)
+			assert.True(t, strings.HasSuffix(fn, test.expectExt), "planned name: "+fn)
+		})
+	}
+}
+
+func readZipEntries(t *testing.T, bs []byte) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(bs), int64(len(bs)))
+	require.NoError(t, err, clues.ToCore(err))
+
+	found := map[string]string{}
+
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err, clues.ToCore(err))
+
+		body, err := io.ReadAll(rc)
+		require.NoError(t, err, clues.ToCore(err))
+
+		rc.Close()
+
+		found[f.Name] = string(body)
+	}
+
+	return found
+}
+
+func (suite *ArchiveUnitSuite) TestExportCollection_badFormat() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	cfg := control.ExportConfig{Archive: true, ArchiveFormat: "rar"}
+
+	_, err := ExportCollection(ctx, cfg, mockCollections())
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+// readAESZipEntries decrypts every entry in a WinZip AES encrypted zip.
+func readAESZipEntries(t *testing.T, bs []byte, password string) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(bs), int64(len(bs)))
+	require.NoError(t, err, clues.ToCore(err))
+
+	found := map[string]string{}
+
+	for _, f := range zr.File {
+		require.Equal(t, aesMethod, f.Method, f.Name)
+		require.NotZero(t, f.Flags&zipFlagEncrypted, f.Name)
+		assert.Equal(t, 2022, f.Modified.Year(), f.Name)
+
+		rc, err := f.OpenRaw()
+		require.NoError(t, err, clues.ToCore(err))
+
+		raw, err := io.ReadAll(rc)
+		require.NoError(t, err, clues.ToCore(err))
+
+		var (
+			salt     = raw[:aesSaltLen]
+			verifier = raw[aesSaltLen : aesSaltLen+aesVerifierLen]
+			ct       = raw[aesSaltLen+aesVerifierLen : len(raw)-aesMACLen]
+			code     = raw[len(raw)-aesMACLen:]
+		)
+
+		encKey, macKey, expectVerifier := deriveAESKeys(password, salt)
+		require.Equal(t, expectVerifier, verifier, "password verifier")
+
+		mac := hmac.New(sha1.New, macKey)
+		mac.Write(ct)
+		require.True(t, hmac.Equal(code, mac.Sum(nil)[:aesMACLen]), "authentication code")
+
+		// ctr mode is symmetric, so the encrypting writer also decrypts.
+		block, err := aes.NewCipher(encKey)
+		require.NoError(t, err, clues.ToCore(err))
+
+		compressed := &bytes.Buffer{}
+		dw := &aesCTRWriter{
+			w:      compressed,
+			block:  block,
+			mac:    hmac.New(sha1.New, macKey),
+			stream: make([]byte, aes.BlockSize),
+			used:   aes.BlockSize,
+		}
+
+		_, err = dw.Write(ct)
+		require.NoError(t, err, clues.ToCore(err))
+
+		body, err := io.ReadAll(flate.NewReader(compressed))
+		require.NoError(t, err, clues.ToCore(err))
+
+		assert.Equal(t, uint64(len(body)), f.UncompressedSize64, f.Name)
+
+		found[f.Name] = string(body)
+	}
+
+	return found
+}
+
+func (suite *ArchiveUnitSuite) TestExportCollection_password() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	cfg := control.ExportConfig{
+		Archive:         true,
+		ArchiveFormat:   control.ZipArchive,
+		ArchivePassword: "hunter2",
+	}
+
+	coll, err := ExportCollection(ctx, cfg, mockCollections())
+	require.NoError(t, err, clues.ToCore(err))
+
+	name, body := readArchive(t, ctx, coll)
+	assert.True(t, strings.HasSuffix(name, ".zip"), name)
+	assert.NotContains(t, string(body), "bravo", "plaintext in archive")
+	assert.Equal(t, expectEntries, readAESZipEntries(t, body, "hunter2"))
+}
+
+func (suite *ArchiveUnitSuite) TestExportCollection_passwordRequiresZip() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	cfg := control.ExportConfig{
+		Archive:         true,
+		ArchiveFormat:   control.TarArchive,
+		ArchivePassword: "hunter2",
+	}
+
+	_, err := ExportCollection(ctx, cfg, mockCollections())
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *ArchiveUnitSuite) TestExportCollection_recipients() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err, clues.ToCore(err))
+
+	cfg := control.ExportConfig{
+		Archive:           true,
+		ArchiveFormat:     control.TarArchive,
+		ArchiveRecipients: []string{id.Recipient().String()},
+	}
+
+	coll, err := ExportCollection(ctx, cfg, mockCollections())
+	require.NoError(t, err, clues.ToCore(err))
+
+	name, body := readArchive(t, ctx, coll)
+	assert.True(t, strings.HasSuffix(name, ".tar.age"), name)
+
+	r, err := age.Decrypt(bytes.NewReader(body), id)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, expectEntries, readTar(t, r))
+}
+
+func (suite *ArchiveUnitSuite) TestParseRecipients() {
+	id, err := age.GenerateX25519Identity()
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name       string
+		recipients []string
+		expectLen  int
+		expectErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "single key",
+			recipients: []string{id.Recipient().String()},
+			expectLen:  1,
+			expectErr:  assert.NoError,
+		},
+		{
+			name: "recipients file",
+			recipients: []string{
+				"# outside counsel\n" + id.Recipient().String() + "\n\n" + id.Recipient().String() + "\n",
+			},
+			expectLen: 2,
+			expectErr: assert.NoError,
+		},
+		{
+			name:       "not a key",
+			recipients: []string{"smurfs"},
+			expectErr:  assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			rs, err := ParseRecipients(test.recipients)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Len(t, rs, test.expectLen)
+		})
+	}
+}
+
+// closeSignaler closes its channel when the body is closed.
+type closeSignaler struct {
+	io.Reader
+	closed chan struct{}
+}
+
+func (cs closeSignaler) Close() error {
+	close(cs.closed)
+	return nil
+}
+
+type signalingCollection struct {
+	bodies []closeSignaler
+}
+
+func (sc signalingCollection) BasePath() string { return "" }
+func (sc signalingCollection) Items(context.Context) <-chan export.Item {
+	ch := make(chan export.Item, len(sc.bodies))
+	defer close(ch)
+
+	for i, body := range sc.bodies {
+		ch <- export.Item{
+			ID:   fmt.Sprint(i),
+			Name: fmt.Sprint(i),
+			Body: body,
+		}
+	}
+
+	return ch
+}
+
+func (suite *ArchiveUnitSuite) TestEncryptExportCollection_cancel() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err, clues.ToCore(err))
+
+	sc := signalingCollection{}
+
+	for i := 0; i < 2; i++ {
+		sc.bodies = append(sc.bodies, closeSignaler{
+			Reader: bytes.NewBufferString("body"),
+			closed: make(chan struct{}),
+		})
+	}
+
+	ictx, cancel := context.WithCancel(ctx)
+
+	items := EncryptExportCollection(sc, []age.Recipient{id.Recipient()}).Items(ictx)
+
+	// stop consuming after the first item.
+	first := <-items
+	first.Body.Close()
+	cancel()
+
+	// every source body gets released, including that of the item
+	// which was never received.
+	for i, body := range sc.bodies {
+		select {
+		case <-body.closed:
+		case <-time.After(5 * time.Second):
+			require.Failf(t, "item body not released after cancellation", "item %d", i)
+		}
+	}
+}
+
+func (suite *ArchiveUnitSuite) TestSplitExportCollection() {
+	table := []struct {
+		name          string
+		body          string
+		size          int64
+		expectVolumes []string
+	}{
+		{
+			name:          "smaller than a volume",
+			body:          "abc",
+			size:          10,
+			expectVolumes: []string{"abc"},
+		},
+		{
+			name:          "exact multiple of the volume size",
+			body:          "abcdef",
+			size:          3,
+			expectVolumes: []string{"abc", "def"},
+		},
+		{
+			name:          "partial last volume",
+			body:          "abcdefg",
+			size:          3,
+			expectVolumes: []string{"abc", "def", "g"},
+		},
+		{
+			name:          "empty",
+			body:          "",
+			size:          3,
+			expectVolumes: []string{""},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			coll := SplitExportCollection(
+				mockCollection{items: map[string]string{"export.zip": test.body}},
+				test.size)
+
+			var (
+				names   []string
+				volumes []string
+			)
+
+			for item := range coll.Items(ctx) {
+				require.NoError(t, item.Error, clues.ToCore(item.Error))
+
+				bs, err := io.ReadAll(item.Body)
+				require.NoError(t, err, clues.ToCore(err))
+
+				names = append(names, item.Name)
+				volumes = append(volumes, string(bs))
+			}
+
+			assert.Equal(t, test.expectVolumes, volumes)
+
+			for i, name := range names {
+				assert.Equal(t, fmt.Sprintf("export.zip.%03d", i+1), name)
+			}
+		})
+	}
+}
+
+func (suite *ArchiveUnitSuite) TestSplitExportCollection_earlyClose() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	coll := SplitExportCollection(
+		mockCollection{items: map[string]string{"export.zip": "abcdefg"}},
+		3)
+
+	var (
+		volumes int
+		errs    []error
+	)
+
+	for item := range coll.Items(ctx) {
+		if item.Error != nil {
+			errs = append(errs, item.Error)
+			continue
+		}
+
+		volumes++
+
+		// close the first volume without reading it.
+		item.Body.Close()
+	}
+
+	assert.Equal(t, 1, volumes, "no volumes produced after the early close")
+	assert.Len(t, errs, 1, "early close reported")
+}
+
+func (suite *ArchiveUnitSuite) TestSplitExportCollection_cancel() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	body := closeSignaler{
+		Reader: bytes.NewBufferString("abcdefg"),
+		closed: make(chan struct{}),
+	}
+
+	ictx, cancel := context.WithCancel(ctx)
+
+	items := SplitExportCollection(signalingCollection{bodies: []closeSignaler{body}}, 3).Items(ictx)
+
+	// stop consuming after receiving the first volume, without reading it.
+	first := <-items
+	require.NoError(t, first.Error, clues.ToCore(first.Error))
+	cancel()
+
+	select {
+	case <-body.closed:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "item body not released after cancellation")
+	}
+
+	// the split stops instead of waiting on the consumer.
+	select {
+	case _, ok := <-items:
+		for ok {
+			_, ok = <-items
+		}
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "volumes still produced after cancellation")
+	}
+
+	_, err := io.ReadAll(first.Body)
+	assert.ErrorIs(t, err, context.Canceled, "unread volume is closed")
+}
+
+func (suite *ArchiveUnitSuite) TestSpool() {
+	table := []struct {
+		name string
+		size int
+	}{
+		{
+			name: "in memory",
+			size: 1024,
+		},
+		{
+			name: "spooled to disk",
+			size: tarSpoolMemoryLimit + 1024,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			dir := t.TempDir()
+			t.Setenv("TMPDIR", dir)
+
+			in := bytes.Repeat([]byte("x"), test.size)
+
+			r, size, cleanup, err := spool(bytes.NewReader(in))
+			require.NoError(t, err, clues.ToCore(err))
+
+			defer cleanup()
+
+			assert.Equal(t, int64(test.size), size)
+
+			// spooled data never lands on disk in plaintext.
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err, clues.ToCore(err))
+
+			for _, ent := range entries {
+				bs, err := os.ReadFile(filepath.Join(dir, ent.Name()))
+				require.NoError(t, err, clues.ToCore(err))
+				assert.NotContains(t, string(bs), "xxxxxxxx", "plaintext in spool file")
+			}
+
+			out, err := io.ReadAll(r)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, len(in), len(out))
+		})
+	}
+}
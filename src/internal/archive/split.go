@@ -0,0 +1,146 @@
+package archive
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/export"
+)
+
+// SplitExportCollection splits each item in the collection into numbered
+// volumes of at most size bytes, eg: "export.zip.001", "export.zip.002".
+// Concatenating the volumes in order reproduces the original item.
+func SplitExportCollection(
+	coll export.Collectioner,
+	size int64,
+) export.Collectioner {
+	return splitCollection{
+		coll: coll,
+		size: size,
+	}
+}
+
+type splitCollection struct {
+	coll export.Collectioner
+	size int64
+}
+
+func (sc splitCollection) BasePath() string {
+	return sc.coll.BasePath()
+}
+
+func (sc splitCollection) Items(ctx context.Context) <-chan export.Item {
+	rc := make(chan export.Item)
+
+	go func() {
+		defer close(rc)
+
+		for item := range sc.coll.Items(ctx) {
+			if item.Error != nil {
+				if !send(ctx, rc, item) {
+					return
+				}
+
+				continue
+			}
+
+			if !splitItem(ctx, item, sc.size, rc) {
+				return
+			}
+		}
+	}()
+
+	return rc
+}
+
+// send hands the item to the consumer.  Returns false if the context
+// ended first, since the consumer may have stopped reading.
+func send(ctx context.Context, rc chan<- export.Item, item export.Item) bool {
+	select {
+	case rc <- item:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// splitItem produces the volumes of the item.  Each volume is fed from
+// the item body only after the previous volume has been fully read, so
+// consumers may receive the volumes concurrently, but must read all of
+// them for the split to progress.  Closing a volume early ends the split
+// with an error item.  Returns false if the context ended, which also
+// closes the volume being written.
+func splitItem(
+	ctx context.Context,
+	item export.Item,
+	size int64,
+	rc chan<- export.Item,
+) bool {
+	defer item.Body.Close()
+
+	br := bufio.NewReader(item.Body)
+
+	for vol := 1; ; vol++ {
+		// don't produce a trailing empty volume.  The first volume is
+		// always produced, so that empty items still get written.
+		if _, err := br.Peek(1); vol > 1 && err != nil {
+			if !errors.Is(err, io.EOF) {
+				return send(ctx, rc, export.Item{
+					ID:    item.ID,
+					Error: clues.Wrap(err, "reading item volume").With("volume", vol),
+				})
+			}
+
+			return true
+		}
+
+		pr, pw := io.Pipe()
+
+		sent := send(ctx, rc, export.Item{
+			ID:      fmt.Sprintf("%s.%03d", item.ID, vol),
+			Name:    fmt.Sprintf("%s.%03d", item.Name, vol),
+			Body:    pr,
+			ModTime: item.ModTime,
+		})
+		if !sent {
+			return false
+		}
+
+		// a consumer that stops early may never read or close the volume.
+		stop := context.AfterFunc(ctx, func() { pw.CloseWithError(ctx.Err()) })
+
+		_, err := io.CopyN(pw, br, size)
+
+		stop()
+
+		if ctx.Err() != nil {
+			pw.CloseWithError(ctx.Err())
+			return false
+		}
+
+		if errors.Is(err, io.ErrClosedPipe) {
+			// the consumer closed the volume before reading all of it, so
+			// the volumes can't reproduce the item anymore.
+			return send(ctx, rc, export.Item{
+				ID:    item.ID,
+				Error: clues.New("item volume closed before it was fully read").With("volume", vol),
+			})
+		}
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			pw.CloseWithError(clues.Wrap(err, "writing item volume").With("volume", vol))
+			return true
+		}
+
+		pw.Close()
+
+		if errors.Is(err, io.EOF) {
+			return true
+		}
+	}
+}
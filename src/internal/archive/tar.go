@@ -0,0 +1,226 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"filippo.io/age"
+	"github.com/alcionai/clues"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+const (
+	// tarSpoolMemoryLimit is the largest item held in memory while its
+	// size is measured for the tar header.  Larger items are spooled to
+	// an encrypted temporary file instead.
+	tarSpoolMemoryLimit = 32 * 1024 * 1024
+)
+
+// TarExportCollection takes a list of export collections and writes
+// them into a single tar archive, optionally compressed with zstandard.
+func TarExportCollection(
+	ctx context.Context,
+	expCollections []export.Collectioner,
+	compress bool,
+) (export.Collectioner, error) {
+	if len(expCollections) == 0 {
+		return nil, clues.New("no export collections provided")
+	}
+
+	reader, writer := io.Pipe()
+	ext := "tar"
+
+	var out io.WriteCloser = writer
+
+	if compress {
+		zw, err := zstd.NewWriter(writer)
+		if err != nil {
+			return nil, clues.Wrap(err, "creating zstd writer")
+		}
+
+		ext = "tar.zst"
+		out = zw
+	}
+
+	go func() {
+		tw := tar.NewWriter(out)
+
+		err := writeTarEntries(ctx, tw, expCollections)
+		if err == nil {
+			err = tw.Close()
+		}
+
+		// always close the compressor to release its resources, even
+		// if the archive is incomplete.
+		if compress {
+			if cerr := out.Close(); err == nil {
+				err = cerr
+			}
+		}
+
+		writer.CloseWithError(err)
+	}()
+
+	return archiveCollection{reader: reader, ext: ext}, nil
+}
+
+func writeTarEntries(
+	ctx context.Context,
+	tw *tar.Writer,
+	expCollections []export.Collectioner,
+) error {
+	buf := make([]byte, ZipCopyBufferSize)
+	counted := 0
+	log := logger.Ctx(ctx).
+		With("collection_count", len(expCollections))
+
+	for _, ec := range expCollections {
+		folder := ec.BasePath()
+		items := ec.Items(ctx)
+
+		for item := range items {
+			counted++
+
+			// Log every 1000 items that are processed
+			if counted%1000 == 0 {
+				log.Infow("progress archiving export items", "count_items", counted)
+			}
+
+			if item.Error != nil {
+				return clues.Wrap(item.Error, "getting export item").With("id", item.ID)
+			}
+
+			if err := writeTarEntry(tw, folder, item, buf); err != nil {
+				return clues.Stack(err).With("name", item.Name, "id", item.ID)
+			}
+		}
+	}
+
+	log.Infow("completed archiving export items", "count_items", counted)
+
+	return nil
+}
+
+func writeTarEntry(
+	tw *tar.Writer,
+	folder string,
+	item export.Item,
+	buf []byte,
+) error {
+	defer item.Body.Close()
+
+	// tar headers need the size of the entry up front, which
+	// isn't known for most export items.
+	body, size, cleanup, err := spool(item.Body)
+	if err != nil {
+		return clues.Wrap(err, "measuring tar entry")
+	}
+
+	defer cleanup()
+
+	modTime := item.ModTime
+	if modTime.IsZero() {
+		modTime = time.Now()
+	}
+
+	// As with zip entries, tar entries always use `/` as the separator.
+	//nolint:forbidigo
+	entryName := path.Join(folder, item.Name)
+
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     entryName,
+		Mode:     0o644,
+		Size:     size,
+		ModTime:  modTime,
+		Format:   tar.FormatPAX,
+	}
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return clues.Wrap(err, "creating tar entry")
+	}
+
+	if _, err := io.CopyBuffer(tw, body, buf); err != nil {
+		return clues.Wrap(err, "writing tar entry")
+	}
+
+	return nil
+}
+
+// spool buffers the reader so that its size is known.  Small bodies are
+// held in memory, and anything beyond tarSpoolMemoryLimit is written to
+// a temporary file.  Spool files are encrypted with a key that's only
+// held in memory, so that item data never lands on disk in plaintext.
+// The cleanup func must be called once the returned reader is no longer
+// needed.
+func spool(r io.Reader) (io.Reader, int64, func(), error) {
+	mem := &bytes.Buffer{}
+
+	n, err := io.CopyN(mem, r, tarSpoolMemoryLimit+1)
+	if errors.Is(err, io.EOF) {
+		return mem, n, func() {}, nil
+	}
+
+	if err != nil {
+		return nil, 0, nil, clues.Stack(err)
+	}
+
+	key, err := age.GenerateX25519Identity()
+	if err != nil {
+		return nil, 0, nil, clues.Wrap(err, "generating spool key")
+	}
+
+	f, err := os.CreateTemp("", "corso-export-*")
+	if err != nil {
+		return nil, 0, nil, clues.Wrap(err, "creating spool file")
+	}
+
+	cleanup := func() {
+		f.Close()
+		os.Remove(f.Name())
+	}
+
+	n, err = spoolTo(f, key.Recipient(), io.MultiReader(mem, r))
+	if err != nil {
+		cleanup()
+		return nil, 0, nil, clues.Wrap(err, "writing spool file")
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return nil, 0, nil, clues.Wrap(err, "rewinding spool file")
+	}
+
+	dr, err := age.Decrypt(f, key)
+	if err != nil {
+		cleanup()
+		return nil, 0, nil, clues.Wrap(err, "reading spool file")
+	}
+
+	return dr, n, cleanup, nil
+}
+
+// spoolTo encrypts the reader into the file, returning the count of
+// plaintext bytes written.
+func spoolTo(f *os.File, recipient age.Recipient, r io.Reader) (int64, error) {
+	w, err := age.Encrypt(f, recipient)
+	if err != nil {
+		return 0, clues.Stack(err)
+	}
+
+	n, err := io.Copy(w, r)
+	if err != nil {
+		return 0, clues.Stack(err)
+	}
+
+	return n, clues.Stack(w.Close()).OrNil()
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/alcionai/clues"
 
-	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/export"
 	"github.com/alcionai/corso/src/pkg/logger"
 )
@@ -19,31 +18,13 @@ const (
 	ZipCopyBufferSize = 5 * 1024 * 1024
 )
 
-type zipCollection struct {
-	reader io.ReadCloser
-}
-
-func (z zipCollection) BasePath() string {
-	return ""
-}
-
-func (z zipCollection) Items(ctx context.Context) <-chan export.Item {
-	rc := make(chan export.Item, 1)
-	defer close(rc)
-
-	rc <- export.Item{
-		Name: "Corso_Export_" + dttm.FormatNow(dttm.HumanReadable) + ".zip",
-		Body: z.reader,
-	}
-
-	return rc
-}
-
 // ZipExportCollection takes a list of export collections and zips
-// them into a single collection.
+// them into a single collection.  If a password is provided, every
+// entry is encrypted with WinZip AES-256.
 func ZipExportCollection(
 	ctx context.Context,
 	expCollections []export.Collectioner,
+	password string,
 ) (export.Collectioner, error) {
 	if len(expCollections) == 0 {
 		return nil, clues.New("no export collections provided")
@@ -88,7 +69,30 @@ func ZipExportCollection(
 				// separator and will have to have special handling.
 
 				//nolint:forbidigo
-				f, err := wr.Create(path.Join(folder, name))
+				entryName := path.Join(folder, name)
+
+				fh := &zip.FileHeader{
+					Name:   entryName,
+					Method: zip.Deflate,
+				}
+
+				if !item.ModTime.IsZero() {
+					fh.Modified = item.ModTime
+				}
+
+				if len(password) > 0 {
+					err = writeAESZipEntry(wr, fh, item.Body, password, buf)
+					if err != nil {
+						writer.CloseWithError(clues.Stack(err).With("name", name).With("id", item.ID))
+						return
+					}
+
+					item.Body.Close()
+
+					continue
+				}
+
+				f, err := wr.CreateHeader(fh)
 				if err != nil {
 					writer.CloseWithError(clues.Wrap(err, "creating zip entry").With("name", name).With("id", item.ID))
 					return
@@ -107,5 +111,5 @@ func ZipExportCollection(
 		log.Infow("completed zipping export items", "count_items", counted)
 	}()
 
-	return zipCollection{reader}, nil
+	return archiveCollection{reader: reader, ext: "zip"}, nil
 }
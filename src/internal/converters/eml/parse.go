@@ -0,0 +1,144 @@
+package eml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/jhillyerd/enmime"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+//-------------------------------------------------------------
+// EML -> Messageable
+//-------------------------------------------------------------
+
+// ToMessageable parses an .eml file (rfc5322) into a Messageable.
+// This is the inverse of FromMessageable, and is used to import mail
+// that was exported by corso, or by any other mail client.
+func ToMessageable(ctx context.Context, body []byte) (models.Messageable, error) {
+	ctx = clues.Add(ctx, "body_len", len(body))
+
+	env, err := enmime.ReadEnvelope(bytes.NewReader(body))
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "parsing eml")
+	}
+
+	msg := models.NewMessage()
+
+	msg.SetSubject(ptr.To(env.GetHeader("Subject")))
+	msg.SetIsRead(ptr.To(true))
+
+	if id := env.GetHeader("Message-ID"); len(id) > 0 {
+		msg.SetInternetMessageId(ptr.To(id))
+	}
+
+	if from := toRecipients(ctx, env, "From"); len(from) > 0 {
+		msg.SetFrom(from[0])
+		msg.SetSender(from[0])
+	}
+
+	msg.SetToRecipients(toRecipients(ctx, env, "To"))
+	msg.SetCcRecipients(toRecipients(ctx, env, "Cc"))
+	msg.SetBccRecipients(toRecipients(ctx, env, "Bcc"))
+	msg.SetReplyTo(toRecipients(ctx, env, "Reply-To"))
+
+	if date, err := env.Date(); err == nil {
+		msg.SetSentDateTime(ptr.To(date))
+		msg.SetReceivedDateTime(ptr.To(date))
+	} else if !errors.Is(err, mail.ErrHeaderNotPresent) {
+		logger.CtxErr(ctx, err).Info("parsing eml date")
+	}
+
+	content := models.NewItemBody()
+
+	if len(env.HTML) > 0 {
+		content.SetContentType(ptr.To(models.HTML_BODYTYPE))
+		content.SetContent(ptr.To(env.HTML))
+	} else {
+		content.SetContentType(ptr.To(models.TEXT_BODYTYPE))
+		content.SetContent(ptr.To(env.Text))
+	}
+
+	msg.SetBody(content)
+
+	attachments := make([]models.Attachmentable, 0, len(env.Attachments)+len(env.Inlines))
+
+	for _, part := range env.Attachments {
+		attachments = append(attachments, toFileAttachment(part, false))
+	}
+
+	for _, part := range env.Inlines {
+		attachments = append(attachments, toFileAttachment(part, true))
+	}
+
+	msg.SetAttachments(attachments)
+	msg.SetHasAttachments(ptr.To(len(attachments) > 0))
+
+	return msg, nil
+}
+
+// toRecipients produces the recipients in the address list header.
+// Unparseable addresses are skipped, since a single malformed address
+// shouldn't prevent the rest of the message from being imported.
+func toRecipients(
+	ctx context.Context,
+	env *enmime.Envelope,
+	header string,
+) []models.Recipientable {
+	addrs, err := env.AddressList(header)
+	if err != nil {
+		if !errors.Is(err, mail.ErrHeaderNotPresent) {
+			logger.CtxErr(ctx, err).
+				With("header", header).
+				Info("parsing eml address list")
+		}
+
+		return nil
+	}
+
+	rs := make([]models.Recipientable, 0, len(addrs))
+
+	for _, addr := range addrs {
+		ea := models.NewEmailAddress()
+		ea.SetAddress(ptr.To(addr.Address))
+
+		if len(addr.Name) > 0 {
+			ea.SetName(ptr.To(addr.Name))
+		}
+
+		r := models.NewRecipient()
+		r.SetEmailAddress(ea)
+
+		rs = append(rs, r)
+	}
+
+	return rs
+}
+
+func toFileAttachment(part *enmime.Part, inline bool) models.Attachmentable {
+	att := models.NewFileAttachment()
+
+	name := part.FileName
+	if len(name) == 0 {
+		name = "Unnamed"
+	}
+
+	att.SetName(ptr.To(name))
+	att.SetContentType(ptr.To(part.ContentType))
+	att.SetContentBytes(part.Content)
+	att.SetSize(ptr.To(int32(len(part.Content))))
+	att.SetIsInline(ptr.To(inline))
+
+	if cid := strings.Trim(part.ContentID, "<>"); len(cid) > 0 {
+		att.SetContentId(ptr.To(cid))
+	}
+
+	return att
+}
@@ -0,0 +1,114 @@
+package eml
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/converters/eml/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type ParseUnitSuite struct {
+	tester.Suite
+}
+
+func TestParseUnitSuite(t *testing.T) {
+	suite.Run(t, &ParseUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ParseUnitSuite) TestToMessageable_roundTrip() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	body := []byte(testdata.EmailWithAttachments)
+
+	orig, err := api.BytesToMessageable(body)
+	require.NoError(t, err, clues.ToCore(err))
+
+	out, err := FromJSON(ctx, body)
+	require.NoError(t, err, clues.ToCore(err))
+
+	msg, err := ToMessageable(ctx, []byte(out))
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, ptr.Val(orig.GetSubject()), ptr.Val(msg.GetSubject()))
+	assert.Equal(
+		t,
+		ptr.Val(orig.GetFrom().GetEmailAddress().GetAddress()),
+		ptr.Val(msg.GetFrom().GetEmailAddress().GetAddress()))
+	assert.Equal(t, orig.GetSentDateTime().Unix(), msg.GetSentDateTime().Unix())
+
+	expectTo := []string{}
+	for _, r := range orig.GetToRecipients() {
+		expectTo = append(expectTo, ptr.Val(r.GetEmailAddress().GetAddress()))
+	}
+
+	to := []string{}
+	for _, r := range msg.GetToRecipients() {
+		to = append(to, ptr.Val(r.GetEmailAddress().GetAddress()))
+	}
+
+	assert.ElementsMatch(t, expectTo, to)
+	assert.Equal(t, orig.GetBody().GetContentType(), msg.GetBody().GetContentType())
+
+	require.Len(t, msg.GetAttachments(), len(orig.GetAttachments()))
+	assert.True(t, ptr.Val(msg.GetHasAttachments()))
+
+	// exports name inline attachments after their content id, so only
+	// the names of regular attachments survive the round trip.
+	expectNames, names := []string{}, []string{}
+
+	for _, att := range orig.GetAttachments() {
+		if !ptr.Val(att.GetIsInline()) {
+			expectNames = append(expectNames, ptr.Val(att.GetName()))
+		}
+	}
+
+	for _, att := range msg.GetAttachments() {
+		if !ptr.Val(att.GetIsInline()) {
+			names = append(names, ptr.Val(att.GetName()))
+		}
+	}
+
+	assert.ElementsMatch(t, expectNames, names)
+}
+
+func (suite *ParseUnitSuite) TestToMessageable() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	eml := "From: \"Alice\" <alice@example.com>\r\n" +
+		"To: bob@example.com, \"Carol\" <carol@example.com>\r\n" +
+		"Cc: dan@example.com\r\n" +
+		"Subject: plain text\r\n" +
+		"Message-ID: <1234@example.com>\r\n" +
+		"Date: Mon, 02 Jan 2006 15:04:05 +0000\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"\r\n" +
+		"hello world\r\n"
+
+	msg, err := ToMessageable(ctx, []byte(eml))
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "plain text", ptr.Val(msg.GetSubject()))
+	assert.Equal(t, "<1234@example.com>", ptr.Val(msg.GetInternetMessageId()))
+	assert.Equal(t, "Alice", ptr.Val(msg.GetFrom().GetEmailAddress().GetName()))
+	assert.Equal(t, "alice@example.com", ptr.Val(msg.GetFrom().GetEmailAddress().GetAddress()))
+	assert.Len(t, msg.GetToRecipients(), 2)
+	assert.Len(t, msg.GetCcRecipients(), 1)
+	assert.Empty(t, msg.GetBccRecipients())
+	assert.Equal(t, int64(1136214245), msg.GetSentDateTime().Unix())
+	assert.Contains(t, ptr.Val(msg.GetBody().GetContent()), "hello world")
+	assert.Empty(t, msg.GetAttachments())
+	assert.False(t, ptr.Val(msg.GetHasAttachments()))
+}
@@ -0,0 +1,88 @@
+package ics
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	ics "github.com/arran4/golang-ical"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// AggregateCalendar combines many events into a single VCALENDAR, such
+// as all the events in a calendar folder.  Timezones shared by several
+// events are only included once.
+type AggregateCalendar struct {
+	timezones []ics.Component
+	tzids     map[string]struct{}
+	events    []ics.Component
+	count     int
+}
+
+func NewAggregateCalendar() *AggregateCalendar {
+	return &AggregateCalendar{tzids: map[string]struct{}{}}
+}
+
+// AddJSON adds the event serialized in the json body to the calendar.
+func (ac *AggregateCalendar) AddJSON(ctx context.Context, body []byte) error {
+	event, err := api.BytesToEventable(body)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "converting to eventable").
+			With("body_len", len(body))
+	}
+
+	return ac.AddEventable(ctx, event)
+}
+
+// AddEventable adds the event to the calendar.  If the event can't be
+// converted, the calendar is left unchanged.
+func (ac *AggregateCalendar) AddEventable(ctx context.Context, event models.Eventable) error {
+	// convert into a scratch calendar, so that a partially converted
+	// event never makes its way into the aggregate.
+	scratch := newCalendar()
+
+	if err := addEventable(ctx, scratch, event); err != nil {
+		return clues.Stack(err)
+	}
+
+	for _, comp := range scratch.Components {
+		tz, ok := comp.(*ics.VTimezone)
+		if !ok {
+			ac.events = append(ac.events, comp)
+			continue
+		}
+
+		var tzid string
+
+		if p := tz.GetProperty(ics.ComponentPropertyTzid); p != nil {
+			tzid = p.Value
+		}
+
+		if _, seen := ac.tzids[tzid]; seen {
+			continue
+		}
+
+		ac.tzids[tzid] = struct{}{}
+		ac.timezones = append(ac.timezones, comp)
+	}
+
+	ac.count++
+
+	return nil
+}
+
+// Len is the number of events added to the calendar.
+func (ac *AggregateCalendar) Len() int {
+	return ac.count
+}
+
+// Serialize produces the calendar, with all timezones ahead of the events.
+func (ac *AggregateCalendar) Serialize() string {
+	cal := newCalendar()
+
+	cal.Components = append(cal.Components, ac.timezones...)
+	cal.Components = append(cal.Components, ac.events...)
+
+	return cal.Serialize()
+}
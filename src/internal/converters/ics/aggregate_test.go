@@ -0,0 +1,78 @@
+package ics
+
+import (
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+func recurringEvent(id, tz string) *models.Event {
+	event := baseEvent()
+	event.SetId(ptr.To(id))
+
+	recur := models.NewPatternedRecurrence()
+	pattern := models.NewRecurrencePattern()
+	pattern.SetTypeEscaped(ptr.To(models.DAILY_RECURRENCEPATTERNTYPE))
+	pattern.SetInterval(ptr.To[int32](1))
+
+	rp := models.NewRecurrenceRange()
+	rp.SetTypeEscaped(ptr.To(models.NOEND_RECURRENCERANGETYPE))
+	rp.SetRecurrenceTimeZone(ptr.To(tz))
+
+	recur.SetPattern(pattern)
+	recur.SetRangeEscaped(rp)
+	event.SetRecurrence(recur)
+
+	return event
+}
+
+func (s *ICSUnitSuite) TestAggregateCalendar() {
+	t := s.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	agg := NewAggregateCalendar()
+
+	events := []*models.Event{
+		recurringEvent("kolkata-1", "Asia/Kolkata"),
+		recurringEvent("kolkata-2", "Asia/Kolkata"),
+		recurringEvent("new-york", "America/New_York"),
+		baseEvent(),
+	}
+
+	for _, event := range events {
+		bs, err := eventToJSON(event)
+		require.NoError(t, err, clues.ToCore(err))
+
+		err = agg.AddJSON(ctx, bs)
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	err := agg.AddJSON(ctx, []byte("not json"))
+	assert.Error(t, err, "invalid event")
+
+	assert.Equal(t, len(events), agg.Len())
+
+	out := agg.Serialize()
+
+	assert.Equal(t, 1, strings.Count(out, "BEGIN:VCALENDAR"), "calendars")
+	assert.Equal(t, 2, strings.Count(out, "BEGIN:VTIMEZONE"), "timezones")
+	assert.Equal(t, 1, strings.Count(out, "TZID:Asia/Kolkata"), "deduplicated timezone")
+	assert.Equal(t, len(events), strings.Count(out, "BEGIN:VEVENT"), "events")
+	assert.Less(
+		t,
+		strings.LastIndex(out, "END:VTIMEZONE"),
+		strings.Index(out, "BEGIN:VEVENT"),
+		"timezones precede events")
+
+	for _, id := range []string{"kolkata-1", "kolkata-2", "new-york", "mango"} {
+		assert.Contains(t, out, "UID:"+id)
+	}
+}
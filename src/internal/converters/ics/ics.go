@@ -235,12 +235,28 @@ func FromJSON(ctx context.Context, body []byte) (string, error) {
 }
 
 func FromEventable(ctx context.Context, event models.Eventable) (string, error) {
+	cal := newCalendar()
+
+	if err := addEventable(ctx, cal, event); err != nil {
+		return "", clues.Stack(err)
+	}
+
+	return cal.Serialize(), nil
+}
+
+func newCalendar() *ics.Calendar {
 	cal := ics.NewCalendar()
 	cal.SetProductId("-//Alcion//Corso") // Does this have to be customizable?
 
+	return cal
+}
+
+// addEventable adds the event, its exception occurrences, and the
+// timezone used by its recurrence to the calendar.
+func addEventable(ctx context.Context, cal *ics.Calendar, event models.Eventable) error {
 	err := addTimeZoneComponents(ctx, cal, event)
 	if err != nil {
-		return "", clues.Wrap(err, "adding timezone components")
+		return clues.Wrap(err, "adding timezone components")
 	}
 
 	id := ptr.Val(event.GetId())
@@ -248,30 +264,30 @@ func FromEventable(ctx context.Context, event models.Eventable) (string, error)
 
 	err = updateEventProperties(ctx, event, iCalEvent)
 	if err != nil {
-		return "", clues.Wrap(err, "updating event properties")
+		return clues.Wrap(err, "updating event properties")
 	}
 
 	exceptionOcurrances := event.GetAdditionalData()["exceptionOccurrences"]
 	if exceptionOcurrances == nil {
-		return cal.Serialize(), nil
+		return nil
 	}
 
 	for _, occ := range exceptionOcurrances.([]any) {
 		instance, ok := occ.(map[string]any)
 		if !ok {
-			return "", clues.NewWC(ctx, "converting exception instance to map[string]any").
+			return clues.NewWC(ctx, "converting exception instance to map[string]any").
 				With("interface_type", fmt.Sprintf("%T", instance))
 		}
 
 		exBody, err := json.Marshal(instance)
 		if err != nil {
-			return "", clues.WrapWC(ctx, err, "marshalling exception instance").
+			return clues.WrapWC(ctx, err, "marshalling exception instance").
 				With("instance_id", instance["id"])
 		}
 
 		exception, err := api.BytesToEventable(exBody)
 		if err != nil {
-			return "", clues.WrapWC(ctx, err, "converting to eventable")
+			return clues.WrapWC(ctx, err, "converting to eventable")
 		}
 
 		exICalEvent := cal.AddEvent(id)
@@ -281,11 +297,11 @@ func FromEventable(ctx context.Context, event models.Eventable) (string, error)
 
 		err = updateEventProperties(ctx, exception, exICalEvent)
 		if err != nil {
-			return "", clues.Wrap(err, "updating exception event properties")
+			return clues.Wrap(err, "updating exception event properties")
 		}
 	}
 
-	return cal.Serialize(), nil
+	return nil
 }
 
 func getTZDataKeyValues(ctx context.Context, timezone string) (map[string]string, error) {
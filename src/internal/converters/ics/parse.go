@@ -0,0 +1,809 @@
+package ics
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	ics "github.com/arran4/golang-ical"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// ---------------------------------------------------------------------------
+// ics -> Eventable
+// ---------------------------------------------------------------------------
+
+var (
+	// Map from iCal day of week representation to Graph API day of week representation
+	ICalToGraphDOW = reverseMap(GraphToICalDOW)
+
+	// Map from iCal recurrence index to Graph API recurrence index
+	ICalToGraphIndex = reverseMap(GraphToICalIndex)
+
+	// Map from TZ database time zone to Windows time zone.  Where more
+	// than one Windows time zone maps to the same TZ database time zone,
+	// the first one (sorted by name) wins.
+	TZToGraphTimeZone = func() map[string]string {
+		names := make([]string, 0, len(GraphTimeZoneToTZ))
+		for name := range GraphTimeZoneToTZ {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		m := map[string]string{}
+
+		for _, name := range names {
+			tz := GraphTimeZoneToTZ[name]
+			if _, ok := m[tz]; !ok {
+				m[tz] = name
+			}
+		}
+
+		return m
+	}()
+)
+
+func reverseMap[K, V comparable](m map[K]V) map[V]K {
+	r := make(map[V]K, len(m))
+
+	for k, v := range m {
+		r[v] = k
+	}
+
+	return r
+}
+
+// ToEventables parses every event in the .ics file.  This is the inverse
+// of FromEventable.  Events that override a single occurrence of a
+// recurring event (those with a RECURRENCE-ID) are attached to the series
+// as exception occurrences, and EXDATEs become cancelled occurrences, the
+// same way they're recorded in backups.
+func ToEventables(ctx context.Context, body []byte) ([]models.Eventable, error) {
+	cal, err := ics.ParseCalendar(bytes.NewReader(body))
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "parsing ics").With("body_len", len(body))
+	}
+
+	var (
+		masters   []*ics.VEvent
+		overrides = map[string][]*ics.VEvent{}
+	)
+
+	for _, ve := range cal.Events() {
+		if ve.GetProperty(ics.ComponentProperty(ics.PropertyRecurrenceId)) != nil {
+			overrides[ve.Id()] = append(overrides[ve.Id()], ve)
+			continue
+		}
+
+		masters = append(masters, ve)
+	}
+
+	events := make([]models.Eventable, 0, len(masters))
+
+	for _, ve := range masters {
+		ictx := clues.Add(ctx, "event_uid", ve.Id())
+
+		event, err := toEventable(ictx, ve)
+		if err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		if err := addExceptionOccurrences(ictx, event, overrides[ve.Id()]); err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		delete(overrides, ve.Id())
+
+		events = append(events, event)
+	}
+
+	// overrides without a series can still be imported as single events.
+	for uid, ves := range overrides {
+		for _, ve := range ves {
+			event, err := toEventable(clues.Add(ctx, "event_uid", uid), ve)
+			if err != nil {
+				return nil, clues.Stack(err)
+			}
+
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func addExceptionOccurrences(
+	ctx context.Context,
+	event models.Eventable,
+	overrides []*ics.VEvent,
+) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	exceptions := make([]any, 0, len(overrides))
+
+	for _, ve := range overrides {
+		exception, err := toEventable(ctx, ve)
+		if err != nil {
+			return clues.Wrap(err, "converting exception occurrence")
+		}
+
+		orig, _, _, err := parseTimeProp(ctx, ve.GetProperty(ics.ComponentProperty(ics.PropertyRecurrenceId)))
+		if err != nil {
+			return clues.Wrap(err, "parsing recurrence id")
+		}
+
+		exception.SetOriginalStart(ptr.To(orig.UTC()))
+
+		m, err := toMap(exception)
+		if err != nil {
+			return clues.WrapWC(ctx, err, "converting exception occurrence")
+		}
+
+		exceptions = append(exceptions, m)
+	}
+
+	event.GetAdditionalData()["exceptionOccurrences"] = exceptions
+
+	return nil
+}
+
+// toMap produces the event in the same form as the exception occurrences
+// of events deserialized from backups.
+func toMap(event models.Eventable) (map[string]any, error) {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", event); err != nil {
+		return nil, clues.Wrap(err, "serializing event")
+	}
+
+	bs, err := writer.GetSerializedContent()
+	if err != nil {
+		return nil, clues.Wrap(err, "serializing event")
+	}
+
+	var m map[string]any
+
+	err = json.Unmarshal(bs, &m)
+
+	return m, clues.Wrap(err, "unmarshalling event").OrNil()
+}
+
+func toEventable(ctx context.Context, ve *ics.VEvent) (models.Eventable, error) {
+	event := models.NewEvent()
+	event.SetId(ptr.To(ve.Id()))
+
+	// SUMMARY
+	if p := ve.GetProperty(ics.ComponentPropertySummary); p != nil {
+		event.SetSubject(ptr.To(ics.FromText(p.Value)))
+	}
+
+	// X-ALT-DESC holds the html body, if present.  Otherwise DESCRIPTION.
+	body := models.NewItemBody()
+	body.SetContentType(ptr.To(models.TEXT_BODYTYPE))
+	body.SetContent(ptr.To(""))
+
+	if p := ve.GetProperty("X-ALT-DESC"); p != nil && paramValue(p, string(ics.ParameterFmttype)) == "text/html" {
+		body.SetContentType(ptr.To(models.HTML_BODYTYPE))
+		body.SetContent(ptr.To(ics.FromText(p.Value)))
+	} else if p := ve.GetProperty(ics.ComponentPropertyDescription); p != nil {
+		body.SetContent(ptr.To(ics.FromText(p.Value)))
+	}
+
+	event.SetBody(body)
+
+	// DTSTART, DTEND
+	start, allDay, tz, err := parseTimeProp(ctx, ve.GetProperty(ics.ComponentPropertyDtStart))
+	if err != nil {
+		return nil, clues.Wrap(err, "parsing start time")
+	}
+
+	end := start
+
+	if p := ve.GetProperty(ics.ComponentPropertyDtEnd); p != nil {
+		end, _, _, err = parseTimeProp(ctx, p)
+		if err != nil {
+			return nil, clues.Wrap(err, "parsing end time")
+		}
+	} else if allDay {
+		end = start.AddDate(0, 0, 1)
+	}
+
+	event.SetIsAllDay(ptr.To(allDay))
+	event.SetStart(toDateTimeTimeZone(start, tz))
+	event.SetEnd(toDateTimeTimeZone(end, tz))
+
+	// RRULE
+	if p := ve.GetProperty(ics.ComponentPropertyRrule); p != nil {
+		recurrence, err := toPatternedRecurrence(ctx, p.Value, start, tz)
+		if err != nil {
+			return nil, clues.Wrap(err, "parsing RRULE")
+		}
+
+		event.SetRecurrence(recurrence)
+	}
+
+	// EXDATE
+	cancelled, err := cancelledOccurrences(ctx, ve, tz)
+	if err != nil {
+		return nil, clues.Wrap(err, "parsing EXDATE")
+	}
+
+	if len(cancelled) > 0 {
+		event.GetAdditionalData()["cancelledOccurrences"] = cancelled
+	}
+
+	// TRANSP, X-MICROSOFT-CDO-BUSYSTATUS
+	event.SetShowAs(toShowAs(ve))
+
+	// CATEGORIES
+	var categories []string
+
+	for _, p := range ve.Properties {
+		if p.IANAToken == string(ics.ComponentPropertyCategories) {
+			categories = append(categories, splitText(p.Value)...)
+		}
+	}
+
+	if len(categories) > 0 {
+		event.SetCategories(categories)
+	}
+
+	// CLASS
+	if p := ve.GetProperty(ics.ComponentPropertyClass); p != nil {
+		switch strings.ToUpper(p.Value) {
+		case "PRIVATE":
+			event.SetSensitivity(ptr.To(models.PRIVATE_SENSITIVITY))
+		case "CONFIDENTIAL":
+			event.SetSensitivity(ptr.To(models.CONFIDENTIAL_SENSITIVITY))
+		}
+	}
+
+	// PRIORITY
+	if p := ve.GetProperty(ics.ComponentPropertyPriority); p != nil {
+		priority, err := strconv.Atoi(p.Value)
+
+		switch {
+		case err != nil || priority == 0 || priority == 5:
+		case priority < 5:
+			event.SetImportance(ptr.To(models.HIGH_IMPORTANCE))
+		default:
+			event.SetImportance(ptr.To(models.LOW_IMPORTANCE))
+		}
+	}
+
+	// ORGANIZER
+	if p := ve.GetProperty(ics.ComponentPropertyOrganizer); p != nil {
+		organizer := models.NewRecipient()
+		organizer.SetEmailAddress(toEmailAddress(p.Value, paramValue(p, string(ics.ParameterCn))))
+		event.SetOrganizer(organizer)
+	}
+
+	// ATTENDEE
+	attendees := []models.Attendeeable{}
+
+	for _, a := range ve.Attendees() {
+		attendees = append(attendees, toAttendee(a))
+	}
+
+	event.SetAttendees(attendees)
+
+	// LOCATION
+	locationName := ""
+
+	if p := ve.GetProperty("X-MICROSOFT-LOCATIONDISPLAYNAME"); p != nil {
+		locationName = ics.FromText(p.Value)
+	} else if p := ve.GetProperty(ics.ComponentPropertyLocation); p != nil {
+		locationName = ics.FromText(p.Value)
+	}
+
+	if len(locationName) > 0 {
+		location := models.NewLocation()
+		location.SetDisplayName(ptr.To(locationName))
+		event.SetLocation(location)
+	}
+
+	// ATTACH
+	attachments, err := toAttachments(ctx, ve)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	event.SetAttachments(attachments)
+	event.SetHasAttachments(ptr.To(len(attachments) > 0))
+
+	return event, nil
+}
+
+func paramValue(p *ics.IANAProperty, param string) string {
+	if p == nil || len(p.ICalParameters[param]) == 0 {
+		return ""
+	}
+
+	return p.ICalParameters[param][0]
+}
+
+// splitText splits a comma separated list of text values, respecting
+// escaped commas.
+func splitText(s string) []string {
+	var (
+		vals []string
+		cur  strings.Builder
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			cur.WriteByte(s[i])
+			cur.WriteByte(s[i+1])
+			i++
+		case s[i] == ',':
+			vals = append(vals, ics.FromText(cur.String()))
+			cur.Reset()
+		default:
+			cur.WriteByte(s[i])
+		}
+	}
+
+	return append(vals, ics.FromText(cur.String()))
+}
+
+// loadICalTimezone loads the location for the TZID, which can either be
+// a TZ database name, or a Windows name as used by Outlook.  Returns
+// the Windows name of the location, which is the name Graph expects.
+// Unknown timezones produce UTC.
+func loadICalTimezone(ctx context.Context, tzid string) (*time.Location, string) {
+	if len(tzid) == 0 {
+		return time.UTC, "UTC"
+	}
+
+	tzName, isWindowsName := GraphTimeZoneToTZ[tzid]
+	if !isWindowsName {
+		tzName = tzid
+	}
+
+	loc, err := time.LoadLocation(tzName)
+	if err != nil {
+		logger.CtxErr(ctx, err).
+			With("tzid", tzid).
+			Info("unknown ics timezone, using UTC")
+
+		return time.UTC, "UTC"
+	}
+
+	if isWindowsName {
+		return loc, tzid
+	}
+
+	if name, ok := TZToGraphTimeZone[tzName]; ok {
+		return loc, name
+	}
+
+	// the tz database may have renamed the timezone, see CanonicalTimeZoneMap
+	for alias, canonical := range CanonicalTimeZoneMap {
+		if canonical != tzName {
+			continue
+		}
+
+		if name, ok := TZToGraphTimeZone[alias]; ok {
+			return loc, name
+		}
+	}
+
+	// graph also accepts a handful of tz database names.  Falling back
+	// to UTC keeps the correct instant, at the cost of recurrences not
+	// following daylight savings.
+	return time.UTC, "UTC"
+}
+
+// parseTimeProp parses a DATE or DATE-TIME property.  Returns the time in
+// the timezone Graph should use, whether the value was a DATE (and thus
+// an all day event), and the Graph name of the timezone.
+func parseTimeProp(ctx context.Context, p *ics.IANAProperty) (time.Time, bool, string, error) {
+	if p == nil {
+		return time.Time{}, false, "", clues.NewWC(ctx, "missing time property")
+	}
+
+	return parseTimeValue(ctx, p.Value, paramValue(p, "TZID"), paramValue(p, string(ics.ParameterValue)))
+}
+
+func parseTimeValue(
+	ctx context.Context,
+	value, tzid, valueType string,
+) (time.Time, bool, string, error) {
+	loc, tz := loadICalTimezone(ctx, tzid)
+
+	var (
+		t   time.Time
+		err error
+	)
+
+	switch {
+	case valueType == string(ics.ValueDataTypeDate) || len(value) == len(ICalDateFormat):
+		t, err = time.ParseInLocation(ICalDateFormat, value, loc)
+		if err != nil {
+			return time.Time{}, false, "", clues.WrapWC(ctx, err, "parsing date").With("value", value)
+		}
+
+		return t, true, tz, nil
+
+	case strings.HasSuffix(value, "Z"):
+		t, err = time.Parse(ICalDateTimeFormatUTC, value)
+
+	default:
+		t, err = time.ParseInLocation(ICalDateTimeFormat, value, loc)
+	}
+
+	if err != nil {
+		return time.Time{}, false, "", clues.WrapWC(ctx, err, "parsing date time").With("value", value)
+	}
+
+	return t.In(loc), false, tz, nil
+}
+
+func toDateTimeTimeZone(t time.Time, tz string) models.DateTimeTimeZoneable {
+	dt := models.NewDateTimeTimeZone()
+	dt.SetDateTime(ptr.To(t.Format(string(dttm.M365DateTimeTimeZone))))
+	dt.SetTimeZone(ptr.To(tz))
+
+	return dt
+}
+
+// toPatternedRecurrence is the inverse of getRecurrencePattern.
+func toPatternedRecurrence(
+	ctx context.Context,
+	rrule string,
+	start time.Time,
+	tz string,
+) (models.PatternedRecurrenceable, error) {
+	parts := map[string]string{}
+
+	for _, part := range strings.Split(rrule, ";") {
+		k, v, _ := strings.Cut(part, "=")
+		parts[strings.ToUpper(k)] = v
+	}
+
+	pattern := models.NewRecurrencePattern()
+	pattern.SetInterval(ptr.To[int32](1))
+
+	if interval, ok := parts["INTERVAL"]; ok {
+		i, err := strconv.Atoi(interval)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "parsing interval").With("interval", interval)
+		}
+
+		pattern.SetInterval(ptr.To(int32(i)))
+	}
+
+	var (
+		days  []models.DayOfWeek
+		index = 0
+	)
+
+	if byDay, ok := parts["BYDAY"]; ok {
+		for _, d := range strings.Split(byDay, ",") {
+			// eg: 2TU, -1FR, MO
+			prefix, code := d[:len(d)-2], d[len(d)-2:]
+
+			if len(prefix) > 0 {
+				i, err := strconv.Atoi(prefix)
+				if err != nil {
+					return nil, clues.WrapWC(ctx, err, "parsing day index").With("day", d)
+				}
+
+				index = i
+			}
+
+			day, err := toDayOfWeek(code)
+			if err != nil {
+				return nil, clues.StackWC(ctx, err)
+			}
+
+			days = append(days, day)
+		}
+	}
+
+	if pos, ok := parts["BYSETPOS"]; ok {
+		i, err := strconv.Atoi(pos)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "parsing set position").With("position", pos)
+		}
+
+		index = i
+	}
+
+	dayOfMonth := int32(start.Day())
+
+	if byMonthDay, ok := parts["BYMONTHDAY"]; ok {
+		d, err := strconv.Atoi(byMonthDay)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "parsing month day").With("month_day", byMonthDay)
+		}
+
+		dayOfMonth = int32(d)
+	}
+
+	month := int32(start.Month())
+
+	if byMonth, ok := parts["BYMONTH"]; ok {
+		m, err := strconv.Atoi(byMonth)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "parsing month").With("month", byMonth)
+		}
+
+		month = int32(m)
+	}
+
+	if len(days) == 0 {
+		day, err := toDayOfWeek(GraphToICalDOW[strings.ToLower(start.Weekday().String())])
+		if err != nil {
+			return nil, clues.StackWC(ctx, err)
+		}
+
+		days = []models.DayOfWeek{day}
+	}
+
+	var setIndex bool
+
+	switch strings.ToUpper(parts["FREQ"]) {
+	case "DAILY":
+		pattern.SetTypeEscaped(ptr.To(models.DAILY_RECURRENCEPATTERNTYPE))
+	case "WEEKLY":
+		pattern.SetTypeEscaped(ptr.To(models.WEEKLY_RECURRENCEPATTERNTYPE))
+		pattern.SetDaysOfWeek(days)
+	case "MONTHLY":
+		if index != 0 {
+			pattern.SetTypeEscaped(ptr.To(models.RELATIVEMONTHLY_RECURRENCEPATTERNTYPE))
+			pattern.SetDaysOfWeek(days)
+
+			setIndex = true
+		} else {
+			pattern.SetTypeEscaped(ptr.To(models.ABSOLUTEMONTHLY_RECURRENCEPATTERNTYPE))
+			pattern.SetDayOfMonth(ptr.To(dayOfMonth))
+		}
+	case "YEARLY":
+		pattern.SetMonth(ptr.To(month))
+
+		if index != 0 {
+			pattern.SetTypeEscaped(ptr.To(models.RELATIVEYEARLY_RECURRENCEPATTERNTYPE))
+			pattern.SetDaysOfWeek(days)
+
+			setIndex = true
+		} else {
+			pattern.SetTypeEscaped(ptr.To(models.ABSOLUTEYEARLY_RECURRENCEPATTERNTYPE))
+			pattern.SetDayOfMonth(ptr.To(dayOfMonth))
+		}
+	default:
+		return nil, clues.NewWC(ctx, "unsupported recurrence frequency").With("freq", parts["FREQ"])
+	}
+
+	if setIndex {
+		name, ok := ICalToGraphIndex[index]
+		if !ok {
+			return nil, clues.NewWC(ctx, "unsupported recurrence index").With("index", index)
+		}
+
+		wi, err := models.ParseWeekIndex(name)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "parsing recurrence index")
+		}
+
+		pattern.SetIndex(wi.(*models.WeekIndex))
+	}
+
+	if wkst, ok := parts["WKST"]; ok {
+		day, err := toDayOfWeek(wkst)
+		if err != nil {
+			return nil, clues.StackWC(ctx, err)
+		}
+
+		pattern.SetFirstDayOfWeek(ptr.To(day))
+	}
+
+	rrange := models.NewRecurrenceRange()
+	rrange.SetRecurrenceTimeZone(ptr.To(tz))
+	rrange.SetStartDate(serialization.NewDateOnly(start))
+	rrange.SetTypeEscaped(ptr.To(models.NOEND_RECURRENCERANGETYPE))
+
+	if count, ok := parts["COUNT"]; ok {
+		c, err := strconv.Atoi(count)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "parsing count").With("count", count)
+		}
+
+		rrange.SetTypeEscaped(ptr.To(models.NUMBERED_RECURRENCERANGETYPE))
+		rrange.SetNumberOfOccurrences(ptr.To(int32(c)))
+	}
+
+	if until, ok := parts["UNTIL"]; ok {
+		u, _, _, err := parseTimeValue(ctx, until, "", "")
+		if err != nil {
+			return nil, clues.Wrap(err, "parsing until")
+		}
+
+		rrange.SetTypeEscaped(ptr.To(models.ENDDATE_RECURRENCERANGETYPE))
+		rrange.SetEndDate(serialization.NewDateOnly(u.In(start.Location())))
+	}
+
+	recurrence := models.NewPatternedRecurrence()
+	recurrence.SetPattern(pattern)
+	recurrence.SetRangeEscaped(rrange)
+
+	return recurrence, nil
+}
+
+func toDayOfWeek(icalDay string) (models.DayOfWeek, error) {
+	name, ok := ICalToGraphDOW[strings.ToUpper(icalDay)]
+	if !ok {
+		return 0, clues.New("unknown day of week").With("day", icalDay)
+	}
+
+	day, err := models.ParseDayOfWeek(name)
+	if err != nil {
+		return 0, clues.Wrap(err, "parsing day of week")
+	}
+
+	return *day.(*models.DayOfWeek), nil
+}
+
+// cancelledOccurrences produces the EXDATEs in the same "<id>.<date>"
+// form that backups record cancelled occurrences in.
+func cancelledOccurrences(ctx context.Context, ve *ics.VEvent, tz string) ([]any, error) {
+	var cancelled []any
+
+	for _, p := range ve.Properties {
+		if p.IANAToken != string(ics.ComponentPropertyExdate) {
+			continue
+		}
+
+		for _, value := range strings.Split(p.Value, ",") {
+			t, _, _, err := parseTimeValue(
+				ctx,
+				value,
+				paramValue(&p, "TZID"),
+				paramValue(&p, string(ics.ParameterValue)))
+			if err != nil {
+				return nil, clues.Stack(err)
+			}
+
+			cancelled = append(cancelled, ve.Id()+"."+t.Format(string(dttm.DateOnly)))
+		}
+	}
+
+	return cancelled, nil
+}
+
+func toShowAs(ve *ics.VEvent) *models.FreeBusyStatus {
+	if p := ve.GetProperty("X-MICROSOFT-CDO-BUSYSTATUS"); p != nil {
+		switch strings.ToUpper(p.Value) {
+		case "FREE":
+			return ptr.To(models.FREE_FREEBUSYSTATUS)
+		case "TENTATIVE":
+			return ptr.To(models.TENTATIVE_FREEBUSYSTATUS)
+		case "OOF":
+			return ptr.To(models.OOF_FREEBUSYSTATUS)
+		case "WORKINGELSEWHERE":
+			return ptr.To(models.WORKINGELSEWHERE_FREEBUSYSTATUS)
+		}
+	}
+
+	if p := ve.GetProperty(ics.ComponentPropertyTransp); p != nil &&
+		strings.EqualFold(p.Value, string(ics.TransparencyTransparent)) {
+		return ptr.To(models.FREE_FREEBUSYSTATUS)
+	}
+
+	return ptr.To(models.BUSY_FREEBUSYSTATUS)
+}
+
+func toEmailAddress(calAddress, name string) models.EmailAddressable {
+	addr := calAddress
+	if strings.HasPrefix(strings.ToLower(addr), "mailto:") {
+		addr = addr[len("mailto:"):]
+	}
+
+	ea := models.NewEmailAddress()
+	ea.SetAddress(ptr.To(addr))
+
+	if len(name) > 0 {
+		ea.SetName(ptr.To(name))
+	}
+
+	return ea
+}
+
+func toAttendee(a *ics.Attendee) models.Attendeeable {
+	attendee := models.NewAttendee()
+	attendee.SetEmailAddress(toEmailAddress(a.Value, paramValue(&a.IANAProperty, string(ics.ParameterCn))))
+
+	switch ics.ParticipationRole(paramValue(&a.IANAProperty, string(ics.ParameterRole))) {
+	case ics.ParticipationRoleOptParticipant:
+		attendee.SetTypeEscaped(ptr.To(models.OPTIONAL_ATTENDEETYPE))
+	case ics.ParticipationRoleNonParticipant:
+		attendee.SetTypeEscaped(ptr.To(models.RESOURCE_ATTENDEETYPE))
+	default:
+		attendee.SetTypeEscaped(ptr.To(models.REQUIRED_ATTENDEETYPE))
+	}
+
+	var response models.ResponseType
+
+	switch a.ParticipationStatus() {
+	case ics.ParticipationStatusAccepted:
+		response = models.ACCEPTED_RESPONSETYPE
+	case ics.ParticipationStatusDeclined:
+		response = models.DECLINED_RESPONSETYPE
+	case ics.ParticipationStatusTentative:
+		response = models.TENTATIVELYACCEPTED_RESPONSETYPE
+	case ics.ParticipationStatusNeedsAction:
+		response = models.NOTRESPONDED_RESPONSETYPE
+	default:
+		response = models.NONE_RESPONSETYPE
+	}
+
+	status := models.NewResponseStatus()
+	status.SetResponse(ptr.To(response))
+	attendee.SetStatus(status)
+
+	return attendee
+}
+
+// toAttachments produces the inline (binary) attachments of the event.
+// Attachments referenced by uri can't be imported, and are skipped.
+func toAttachments(ctx context.Context, ve *ics.VEvent) ([]models.Attachmentable, error) {
+	attachments := []models.Attachmentable{}
+
+	for i, p := range ve.Properties {
+		if p.IANAToken != string(ics.ComponentPropertyAttach) {
+			continue
+		}
+
+		if !strings.EqualFold(paramValue(&p, string(ics.ParameterEncoding)), "base64") {
+			logger.Ctx(ctx).Info("skipping ics attachment that is not inline")
+			continue
+		}
+
+		content, err := base64.StdEncoding.DecodeString(p.Value)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "decoding attachment content")
+		}
+
+		name := paramValue(&p, "FILENAME")
+		if len(name) == 0 {
+			name = fmt.Sprintf("attachment-%d", i)
+		}
+
+		att := models.NewFileAttachment()
+		att.SetName(ptr.To(name))
+		att.SetContentBytes(content)
+		att.SetSize(ptr.To(int32(len(content))))
+		att.SetIsInline(ptr.To(false))
+
+		if ct := paramValue(&p, string(ics.ParameterFmttype)); len(ct) > 0 {
+			att.SetContentType(ptr.To(ct))
+		}
+
+		if cid := paramValue(&p, "CID"); len(cid) > 0 {
+			att.SetIsInline(ptr.To(true))
+			att.SetContentId(ptr.To(cid))
+		}
+
+		attachments = append(attachments, att)
+	}
+
+	return attachments, nil
+}
@@ -0,0 +1,291 @@
+package ics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+func wrapEvents(lines ...string) string {
+	return strings.Join(append(append(
+		[]string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//test//test"},
+		lines...),
+		"END:VCALENDAR"), "\r\n") + "\r\n"
+}
+
+func (s *ICSUnitSuite) TestToEventables() {
+	table := []struct {
+		name  string
+		ics   string
+		check func(t *testing.T, events []models.Eventable)
+	}{
+		{
+			name: "simple event",
+			ics: wrapEvents(
+				"BEGIN:VEVENT",
+				"UID:simple",
+				"SUMMARY:Lunch\\, maybe",
+				"DESCRIPTION:line one\\nline two",
+				"LOCATION:Cafe",
+				"DTSTART:20240102T120000Z",
+				"DTEND:20240102T130000Z",
+				"CATEGORIES:Red,Blue\\,Green",
+				"CLASS:PRIVATE",
+				"PRIORITY:1",
+				"TRANSP:TRANSPARENT",
+				"ORGANIZER;CN=Alice:mailto:alice@example.com",
+				"ATTENDEE;CN=Bob;ROLE=OPT-PARTICIPANT;PARTSTAT=ACCEPTED:mailto:bob@example.com",
+				"END:VEVENT"),
+			check: func(t *testing.T, events []models.Eventable) {
+				require.Len(t, events, 1)
+
+				event := events[0]
+				assert.Equal(t, "simple", ptr.Val(event.GetId()))
+				assert.Equal(t, "Lunch, maybe", ptr.Val(event.GetSubject()))
+				assert.Equal(t, models.TEXT_BODYTYPE, ptr.Val(event.GetBody().GetContentType()))
+				assert.Equal(t, "line one\nline two", ptr.Val(event.GetBody().GetContent()))
+				assert.Equal(t, "Cafe", ptr.Val(event.GetLocation().GetDisplayName()))
+				assert.Equal(t, "2024-01-02T12:00:00.0000000", ptr.Val(event.GetStart().GetDateTime()))
+				assert.Equal(t, "UTC", ptr.Val(event.GetStart().GetTimeZone()))
+				assert.Equal(t, "2024-01-02T13:00:00.0000000", ptr.Val(event.GetEnd().GetDateTime()))
+				assert.False(t, ptr.Val(event.GetIsAllDay()))
+				assert.Equal(t, []string{"Red", "Blue,Green"}, event.GetCategories())
+				assert.Equal(t, models.PRIVATE_SENSITIVITY, ptr.Val(event.GetSensitivity()))
+				assert.Equal(t, models.HIGH_IMPORTANCE, ptr.Val(event.GetImportance()))
+				assert.Equal(t, models.FREE_FREEBUSYSTATUS, ptr.Val(event.GetShowAs()))
+				assert.Equal(t, "alice@example.com", ptr.Val(event.GetOrganizer().GetEmailAddress().GetAddress()))
+				assert.Equal(t, "Alice", ptr.Val(event.GetOrganizer().GetEmailAddress().GetName()))
+
+				require.Len(t, event.GetAttendees(), 1)
+
+				attendee := event.GetAttendees()[0]
+				assert.Equal(t, "bob@example.com", ptr.Val(attendee.GetEmailAddress().GetAddress()))
+				assert.Equal(t, models.OPTIONAL_ATTENDEETYPE, ptr.Val(attendee.GetTypeEscaped()))
+				assert.Equal(t, models.ACCEPTED_RESPONSETYPE, ptr.Val(attendee.GetStatus().GetResponse()))
+				assert.Nil(t, event.GetRecurrence())
+				assert.False(t, ptr.Val(event.GetHasAttachments()))
+			},
+		},
+		{
+			name: "all day event without end",
+			ics: wrapEvents(
+				"BEGIN:VEVENT",
+				"UID:allday",
+				"DTSTART;VALUE=DATE:20240301",
+				"X-ALT-DESC;FMTTYPE=text/html:<b>hi</b>",
+				"END:VEVENT"),
+			check: func(t *testing.T, events []models.Eventable) {
+				require.Len(t, events, 1)
+
+				event := events[0]
+				assert.True(t, ptr.Val(event.GetIsAllDay()))
+				assert.Equal(t, "2024-03-01T00:00:00.0000000", ptr.Val(event.GetStart().GetDateTime()))
+				assert.Equal(t, "2024-03-02T00:00:00.0000000", ptr.Val(event.GetEnd().GetDateTime()))
+				assert.Equal(t, models.HTML_BODYTYPE, ptr.Val(event.GetBody().GetContentType()))
+				assert.Equal(t, "<b>hi</b>", ptr.Val(event.GetBody().GetContent()))
+			},
+		},
+		{
+			name: "timezones",
+			ics: wrapEvents(
+				"BEGIN:VEVENT",
+				"UID:windows",
+				"DTSTART;TZID=Pacific Standard Time:20240102T090000",
+				"DTEND;TZID=Pacific Standard Time:20240102T100000",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:iana",
+				"DTSTART;TZID=America/New_York:20240102T090000",
+				"DTEND;TZID=America/New_York:20240102T100000",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:unknown",
+				"DTSTART;TZID=Nowhere/Special:20240102T090000",
+				"END:VEVENT"),
+			check: func(t *testing.T, events []models.Eventable) {
+				require.Len(t, events, 3)
+
+				assert.Equal(t, "Pacific Standard Time", ptr.Val(events[0].GetStart().GetTimeZone()))
+				assert.Equal(t, "2024-01-02T09:00:00.0000000", ptr.Val(events[0].GetStart().GetDateTime()))
+
+				assert.Equal(t, "Eastern Standard Time", ptr.Val(events[1].GetStart().GetTimeZone()))
+				assert.Equal(t, "2024-01-02T09:00:00.0000000", ptr.Val(events[1].GetStart().GetDateTime()))
+
+				assert.Equal(t, "UTC", ptr.Val(events[2].GetStart().GetTimeZone()))
+			},
+		},
+		{
+			name: "recurrence with exceptions",
+			ics: wrapEvents(
+				"BEGIN:VEVENT",
+				"UID:series",
+				"SUMMARY:Standup",
+				"DTSTART;TZID=Pacific Standard Time:20240102T090000",
+				"DTEND;TZID=Pacific Standard Time:20240102T091500",
+				"RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;WKST=MO;COUNT=10",
+				"EXDATE;TZID=Pacific Standard Time:20240104T090000",
+				"END:VEVENT",
+				"BEGIN:VEVENT",
+				"UID:series",
+				"RECURRENCE-ID;TZID=Pacific Standard Time:20240116T090000",
+				"SUMMARY:Standup (moved)",
+				"DTSTART;TZID=Pacific Standard Time:20240116T100000",
+				"DTEND;TZID=Pacific Standard Time:20240116T101500",
+				"END:VEVENT"),
+			check: func(t *testing.T, events []models.Eventable) {
+				require.Len(t, events, 1)
+
+				event := events[0]
+				pattern := event.GetRecurrence().GetPattern()
+				assert.Equal(t, models.WEEKLY_RECURRENCEPATTERNTYPE, ptr.Val(pattern.GetTypeEscaped()))
+				assert.Equal(t, int32(2), ptr.Val(pattern.GetInterval()))
+				assert.Equal(
+					t,
+					[]models.DayOfWeek{models.TUESDAY_DAYOFWEEK, models.THURSDAY_DAYOFWEEK},
+					pattern.GetDaysOfWeek())
+				assert.Equal(t, models.MONDAY_DAYOFWEEK, ptr.Val(pattern.GetFirstDayOfWeek()))
+
+				rrange := event.GetRecurrence().GetRangeEscaped()
+				assert.Equal(t, models.NUMBERED_RECURRENCERANGETYPE, ptr.Val(rrange.GetTypeEscaped()))
+				assert.Equal(t, int32(10), ptr.Val(rrange.GetNumberOfOccurrences()))
+				assert.Equal(t, "2024-01-02", rrange.GetStartDate().String())
+				assert.Equal(t, "Pacific Standard Time", ptr.Val(rrange.GetRecurrenceTimeZone()))
+
+				assert.Equal(t, []any{"series.2024-01-04"}, event.GetAdditionalData()["cancelledOccurrences"])
+
+				exceptions, ok := event.GetAdditionalData()["exceptionOccurrences"].([]any)
+				require.True(t, ok, "exception occurrences")
+				require.Len(t, exceptions, 1)
+
+				exception := exceptions[0].(map[string]any)
+				assert.Equal(t, "Standup (moved)", exception["subject"])
+				assert.Equal(t, "2024-01-16T17:00:00Z", exception["originalStart"])
+			},
+		},
+		{
+			name: "relative monthly until",
+			ics: wrapEvents(
+				"BEGIN:VEVENT",
+				"UID:monthly",
+				"DTSTART:20240109T170000Z",
+				"RRULE:FREQ=MONTHLY;BYDAY=2TU;UNTIL=20241231T000000Z",
+				"END:VEVENT"),
+			check: func(t *testing.T, events []models.Eventable) {
+				require.Len(t, events, 1)
+
+				pattern := events[0].GetRecurrence().GetPattern()
+				assert.Equal(t, models.RELATIVEMONTHLY_RECURRENCEPATTERNTYPE, ptr.Val(pattern.GetTypeEscaped()))
+				assert.Equal(t, models.SECOND_WEEKINDEX, ptr.Val(pattern.GetIndex()))
+				assert.Equal(t, []models.DayOfWeek{models.TUESDAY_DAYOFWEEK}, pattern.GetDaysOfWeek())
+
+				rrange := events[0].GetRecurrence().GetRangeEscaped()
+				assert.Equal(t, models.ENDDATE_RECURRENCERANGETYPE, ptr.Val(rrange.GetTypeEscaped()))
+				assert.Equal(t, "2024-12-31", rrange.GetEndDate().String())
+			},
+		},
+		{
+			name: "absolute yearly",
+			ics: wrapEvents(
+				"BEGIN:VEVENT",
+				"UID:yearly",
+				"DTSTART;VALUE=DATE:20240315",
+				"RRULE:FREQ=YEARLY",
+				"END:VEVENT"),
+			check: func(t *testing.T, events []models.Eventable) {
+				require.Len(t, events, 1)
+
+				pattern := events[0].GetRecurrence().GetPattern()
+				assert.Equal(t, models.ABSOLUTEYEARLY_RECURRENCEPATTERNTYPE, ptr.Val(pattern.GetTypeEscaped()))
+				assert.Equal(t, int32(3), ptr.Val(pattern.GetMonth()))
+				assert.Equal(t, int32(15), ptr.Val(pattern.GetDayOfMonth()))
+				assert.Equal(
+					t,
+					models.NOEND_RECURRENCERANGETYPE,
+					ptr.Val(events[0].GetRecurrence().GetRangeEscaped().GetTypeEscaped()))
+			},
+		},
+		{
+			name: "attachments",
+			ics: wrapEvents(
+				"BEGIN:VEVENT",
+				"UID:attach",
+				"DTSTART:20240102T120000Z",
+				"ATTACH;ENCODING=BASE64;VALUE=BINARY;FMTTYPE=text/plain;FILENAME=a.txt:aGVsbG8=",
+				"ATTACH:https://example.com/remote.txt",
+				"END:VEVENT"),
+			check: func(t *testing.T, events []models.Eventable) {
+				require.Len(t, events, 1)
+				require.Len(t, events[0].GetAttachments(), 1)
+				assert.True(t, ptr.Val(events[0].GetHasAttachments()))
+
+				att := events[0].GetAttachments()[0].(models.FileAttachmentable)
+				assert.Equal(t, "a.txt", ptr.Val(att.GetName()))
+				assert.Equal(t, "text/plain", ptr.Val(att.GetContentType()))
+				assert.Equal(t, []byte("hello"), att.GetContentBytes())
+			},
+		},
+	}
+
+	for _, test := range table {
+		s.Run(test.name, func() {
+			t := s.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			events, err := ToEventables(ctx, []byte(test.ics))
+			require.NoError(t, err, clues.ToCore(err))
+
+			test.check(t, events)
+		})
+	}
+}
+
+func (s *ICSUnitSuite) TestToEventables_roundTrip() {
+	t := s.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	orig := recurringEvent("round-trip", "Pacific Standard Time")
+	orig.SetSubject(ptr.To("round trip"))
+
+	out, err := FromEventable(ctx, orig)
+	require.NoError(t, err, clues.ToCore(err))
+
+	events, err := ToEventables(ctx, []byte(out))
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, events, 1)
+
+	event := events[0]
+	assert.Equal(t, "round trip", ptr.Val(event.GetSubject()))
+	assert.Equal(
+		t,
+		ptr.Val(orig.GetRecurrence().GetPattern().GetTypeEscaped()),
+		ptr.Val(event.GetRecurrence().GetPattern().GetTypeEscaped()))
+	assert.Equal(t, "Pacific Standard Time", ptr.Val(event.GetStart().GetTimeZone()))
+
+	// re-exporting the parsed event produces the same calendar.
+	again, err := FromEventable(ctx, event)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, strings.Count(out, "RRULE"), strings.Count(again, "RRULE"))
+	assert.Equal(t, strings.Count(out, "BEGIN:VTIMEZONE"), strings.Count(again, "BEGIN:VTIMEZONE"))
+}
+
+func (s *ICSUnitSuite) TestToEventables_invalid() {
+	t := s.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	_, err := ToEventables(ctx, []byte("not a calendar"))
+	assert.Error(t, err, clues.ToCore(err))
+}
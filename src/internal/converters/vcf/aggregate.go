@@ -0,0 +1,224 @@
+package vcf
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// AggregateCards combines many contacts into a single multi-card vCard
+// file, such as all the contacts in a contacts folder.
+type AggregateCards struct {
+	cards []string
+}
+
+func NewAggregateCards() *AggregateCards {
+	return &AggregateCards{}
+}
+
+// AddJSON adds the contact serialized in the json body to the file.
+func (ac *AggregateCards) AddJSON(ctx context.Context, body []byte) error {
+	card, err := FromJSON(ctx, body)
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	ac.cards = append(ac.cards, card)
+
+	return nil
+}
+
+// Len is the number of contacts added to the file.
+func (ac *AggregateCards) Len() int {
+	return len(ac.cards)
+}
+
+// Serialize produces the cards, one after another.
+func (ac *AggregateCards) Serialize() string {
+	return strings.Join(ac.cards, "")
+}
+
+// outlookCSVHeader is the header of the contacts csv exported by
+// Outlook.  Columns Outlook doesn't require, and that contacts have no
+// data for, are left out.
+var outlookCSVHeader = []string{
+	"Title",
+	"First Name",
+	"Middle Name",
+	"Last Name",
+	"Suffix",
+	"Nickname",
+	"Company",
+	"Department",
+	"Job Title",
+	"Business Street",
+	"Business City",
+	"Business State",
+	"Business Postal Code",
+	"Business Country/Region",
+	"Home Street",
+	"Home City",
+	"Home State",
+	"Home Postal Code",
+	"Home Country/Region",
+	"Other Street",
+	"Other City",
+	"Other State",
+	"Other Postal Code",
+	"Other Country/Region",
+	"Assistant's Name",
+	"Business Phone",
+	"Business Phone 2",
+	"Home Phone",
+	"Home Phone 2",
+	"Mobile Phone",
+	"Birthday",
+	"Children",
+	"E-mail Address",
+	"E-mail 2 Address",
+	"E-mail 3 Address",
+	"Manager's Name",
+	"Notes",
+	"Profession",
+	"Spouse",
+}
+
+// AggregateCSV combines many contacts into a single csv file which can
+// be imported by Outlook.
+type AggregateCSV struct {
+	out   *strings.Builder
+	w     *csv.Writer
+	count int
+}
+
+func NewAggregateCSV() *AggregateCSV {
+	out := &strings.Builder{}
+	w := csv.NewWriter(out)
+
+	// outlook expects crlf line endings.
+	w.UseCRLF = true
+
+	return &AggregateCSV{out: out, w: w}
+}
+
+// AddJSON adds the contact serialized in the json body to the file.
+func (ac *AggregateCSV) AddJSON(ctx context.Context, body []byte) error {
+	data, err := api.BytesToContactable(body)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "converting to contactable").
+			With("body_length", len(body))
+	}
+
+	if ac.count == 0 {
+		if err := ac.w.Write(outlookCSVHeader); err != nil {
+			return clues.WrapWC(ctx, err, "writing csv header")
+		}
+	}
+
+	if err := ac.w.Write(toOutlookCSVRecord(data)); err != nil {
+		return clues.WrapWC(ctx, err, "writing csv record")
+	}
+
+	ac.count++
+
+	return nil
+}
+
+// Len is the number of contacts added to the file.
+func (ac *AggregateCSV) Len() int {
+	return ac.count
+}
+
+// Serialize produces the csv, including the header row.
+func (ac *AggregateCSV) Serialize() string {
+	ac.w.Flush()
+	return ac.out.String()
+}
+
+func toOutlookCSVRecord(data models.Contactable) []string {
+	var (
+		business = addressColumns(data.GetBusinessAddress())
+		home     = addressColumns(data.GetHomeAddress())
+		other    = addressColumns(data.GetOtherAddress())
+		bPhones  = nth(data.GetBusinessPhones(), 2)
+		hPhones  = nth(data.GetHomePhones(), 2)
+		emails   = make([]string, 0, 3)
+		birthday string
+	)
+
+	for _, email := range data.GetEmailAddresses() {
+		if addr := ptr.Val(email.GetAddress()); len(addr) > 0 {
+			emails = append(emails, addr)
+		}
+	}
+
+	emails = nth(emails, 3)
+
+	if bday := data.GetBirthday(); bday != nil {
+		birthday = bday.Format("1/2/2006")
+	}
+
+	record := []string{
+		ptr.Val(data.GetTitle()),
+		ptr.Val(data.GetGivenName()),
+		ptr.Val(data.GetMiddleName()),
+		ptr.Val(data.GetSurname()),
+		ptr.Val(data.GetGeneration()),
+		ptr.Val(data.GetNickName()),
+		ptr.Val(data.GetCompanyName()),
+		ptr.Val(data.GetDepartment()),
+		ptr.Val(data.GetJobTitle()),
+	}
+
+	record = append(record, business...)
+	record = append(record, home...)
+	record = append(record, other...)
+	record = append(
+		record,
+		ptr.Val(data.GetAssistantName()),
+		bPhones[0],
+		bPhones[1],
+		hPhones[0],
+		hPhones[1],
+		ptr.Val(data.GetMobilePhone()),
+		birthday,
+		strings.Join(data.GetChildren(), ";"),
+		emails[0],
+		emails[1],
+		emails[2],
+		ptr.Val(data.GetManager()),
+		ptr.Val(data.GetPersonalNotes()),
+		ptr.Val(data.GetProfession()),
+		ptr.Val(data.GetSpouseName()))
+
+	return record
+}
+
+func addressColumns(addr models.PhysicalAddressable) []string {
+	if addr == nil {
+		return make([]string, 5)
+	}
+
+	return []string{
+		ptr.Val(addr.GetStreet()),
+		ptr.Val(addr.GetCity()),
+		ptr.Val(addr.GetState()),
+		ptr.Val(addr.GetPostalCode()),
+		ptr.Val(addr.GetCountryOrRegion()),
+	}
+}
+
+// nth produces exactly n values, padding with empty strings or dropping
+// any values beyond the nth.
+func nth(vals []string, n int) []string {
+	out := make([]string, n)
+	copy(out, vals)
+
+	return out
+}
@@ -0,0 +1,100 @@
+package vcf
+
+import (
+	"encoding/csv"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alcionai/corso/src/internal/converters/vcf/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+func (suite *VCFUnitSuite) TestAggregateCards() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	agg := NewAggregateCards()
+
+	for i := 0; i < 2; i++ {
+		err := agg.AddJSON(ctx, []byte(testdata.ContactsInput))
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	err := agg.AddJSON(ctx, []byte("not json"))
+	assert.Error(t, err, "invalid contact")
+
+	assert.Equal(t, 2, agg.Len())
+
+	out := strings.ReplaceAll(agg.Serialize(), "\r", "")
+	single := strings.TrimSpace(testdata.ContactsOutput) + "\n"
+
+	assert.Equal(t, 2, strings.Count(out, "BEGIN:VCARD"))
+	assert.Equal(t, single+single, out)
+}
+
+func (suite *VCFUnitSuite) TestAggregateCSV() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	agg := NewAggregateCSV()
+
+	for i := 0; i < 2; i++ {
+		err := agg.AddJSON(ctx, []byte(testdata.ContactsInput))
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	err := agg.AddJSON(ctx, []byte("not json"))
+	assert.Error(t, err, "invalid contact")
+
+	assert.Equal(t, 2, agg.Len())
+
+	out := agg.Serialize()
+	assert.Contains(t, out, "\r\n", "crlf line endings")
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, records, 3, "header and records")
+	assert.Equal(t, outlookCSVHeader, records[0])
+
+	row := map[string]string{}
+	for i, col := range records[0] {
+		row[col] = records[1][i]
+	}
+
+	expect := map[string]string{
+		"Title":                   "Wo.",
+		"First Name":              "Random",
+		"Middle Name":             "Peterson",
+		"Last Name":               "Person",
+		"Suffix":                  "Jr.",
+		"Nickname":                "Mikey",
+		"Company":                 "Company Name",
+		"Department":              "Mangrove",
+		"Job Title":               "Principal Dough Beater",
+		"Business Street":         "Business Stree",
+		"Business Country/Region": "Business Country",
+		"Home City":               "City Name",
+		"Home Postal Code":        "090909",
+		"Other Street":            "Another street",
+		"Home Phone":              "123091230921",
+		"Home Phone 2":            "",
+		"Mobile Phone":            "00000111111",
+		"Birthday":                "9/11/2012",
+		"E-mail Address":          "mockemail@provider.com",
+		"E-mail 2 Address":        "anotheremail@place.com",
+		"E-mail 3 Address":        "",
+		"Notes":                   "Note on dough stuff",
+		"Spouse":                  "Nona Ur Business",
+	}
+
+	for col, val := range expect {
+		assert.Equal(t, val, row[col], col)
+	}
+}
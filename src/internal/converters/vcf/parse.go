@@ -0,0 +1,196 @@
+package vcf
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/emersion/go-vcard"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// ---------------------------------------------------------------------------
+// vCard -> Contactable
+// ---------------------------------------------------------------------------
+
+// ToContactables parses every card in the .vcf file.  This is the
+// inverse of FromJSON.
+func ToContactables(ctx context.Context, body []byte) ([]models.Contactable, error) {
+	var (
+		contacts = []models.Contactable{}
+		dec      = vcard.NewDecoder(bytes.NewReader(body))
+	)
+
+	for {
+		card, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "decoding vcard").
+				With("body_length", len(body), "card_index", len(contacts))
+		}
+
+		contacts = append(contacts, toContactable(ctx, card))
+	}
+
+	return contacts, nil
+}
+
+func toContactable(ctx context.Context, vc vcard.Card) models.Contactable {
+	contact := models.NewContact()
+
+	if name := vc.Name(); name != nil {
+		contact.SetGivenName(nonEmpty(name.GivenName))
+		contact.SetSurname(nonEmpty(name.FamilyName))
+		contact.SetMiddleName(nonEmpty(name.AdditionalName))
+		contact.SetTitle(nonEmpty(name.HonorificPrefix))
+		contact.SetGeneration(nonEmpty(name.HonorificSuffix))
+	}
+
+	if fn := vc.PreferredValue(vcard.FieldFormattedName); len(fn) > 0 {
+		contact.SetDisplayName(ptr.To(fn))
+	}
+
+	contact.SetNickName(nonEmpty(vc.PreferredValue(vcard.FieldNickname)))
+
+	if bday := vc.PreferredValue(vcard.FieldBirthday); len(bday) > 0 {
+		t, err := parseBirthday(bday)
+		if err != nil {
+			logger.CtxErr(ctx, err).Info("skipping unparseable vcard birthday")
+		} else {
+			contact.SetBirthday(ptr.To(t))
+		}
+	}
+
+	for _, addr := range vc.Addresses() {
+		paddr := models.NewPhysicalAddress()
+		paddr.SetStreet(nonEmpty(addr.StreetAddress))
+		paddr.SetCity(nonEmpty(addr.Locality))
+		paddr.SetState(nonEmpty(addr.Region))
+		paddr.SetPostalCode(nonEmpty(addr.PostalCode))
+		paddr.SetCountryOrRegion(nonEmpty(addr.Country))
+
+		switch {
+		case hasType(addr.Field, vcard.TypeHome):
+			contact.SetHomeAddress(paddr)
+		case hasType(addr.Field, vcard.TypeWork):
+			contact.SetBusinessAddress(paddr)
+		default:
+			contact.SetOtherAddress(paddr)
+		}
+	}
+
+	var businessPhones, homePhones []string
+
+	for _, tel := range vc[vcard.FieldTelephone] {
+		switch {
+		case hasType(tel, vcard.TypeCell) && contact.GetMobilePhone() == nil:
+			contact.SetMobilePhone(ptr.To(tel.Value))
+		case hasType(tel, vcard.TypeWork):
+			businessPhones = append(businessPhones, tel.Value)
+		default:
+			homePhones = append(homePhones, tel.Value)
+		}
+	}
+
+	contact.SetBusinessPhones(businessPhones)
+	contact.SetHomePhones(homePhones)
+
+	emails := []models.EmailAddressable{}
+
+	for _, email := range vc[vcard.FieldEmail] {
+		ea := models.NewEmailAddress()
+		ea.SetAddress(ptr.To(email.Value))
+
+		emails = append(emails, ea)
+	}
+
+	contact.SetEmailAddresses(emails)
+
+	ims := []string{}
+
+	for _, im := range vc[vcard.FieldIMPP] {
+		ims = append(ims, im.Value)
+	}
+
+	contact.SetImAddresses(ims)
+
+	// company;department;profession, the same as FromJSON writes it.
+	if org := vc.PreferredValue(vcard.FieldOrganization); len(org) > 0 {
+		parts := strings.SplitN(org, ";", 3)
+
+		contact.SetCompanyName(nonEmpty(parts[0]))
+
+		if len(parts) > 1 {
+			contact.SetDepartment(nonEmpty(parts[1]))
+		}
+
+		if len(parts) > 2 {
+			contact.SetProfession(nonEmpty(parts[2]))
+		}
+	}
+
+	contact.SetJobTitle(nonEmpty(vc.PreferredValue(vcard.FieldTitle)))
+
+	children := []string{}
+
+	for _, related := range vc[vcard.FieldRelated] {
+		switch {
+		case hasType(related, vcard.TypeChild):
+			children = append(children, related.Value)
+		case hasType(related, vcard.TypeSpouse):
+			contact.SetSpouseName(ptr.To(related.Value))
+		case hasType(related, "manager"):
+			contact.SetManager(ptr.To(related.Value))
+		case hasType(related, "assistant"):
+			contact.SetAssistantName(ptr.To(related.Value))
+		}
+	}
+
+	contact.SetChildren(children)
+
+	contact.SetPersonalNotes(nonEmpty(vc.PreferredValue(vcard.FieldNote)))
+
+	return contact
+}
+
+func nonEmpty(s string) *string {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return ptr.To(s)
+}
+
+func hasType(f *vcard.Field, t string) bool {
+	if f == nil {
+		return false
+	}
+
+	for _, typ := range f.Params.Types() {
+		if strings.EqualFold(typ, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseBirthday(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "20060102", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, clues.New("unknown birthday format").With("birthday", s)
+}
@@ -0,0 +1,103 @@
+package vcf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/converters/vcf/testdata"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type ParseUnitSuite struct {
+	tester.Suite
+}
+
+func TestParseUnitSuite(t *testing.T) {
+	suite.Run(t, &ParseUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ParseUnitSuite) TestToContactables_roundTrip() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	contacts, err := ToContactables(ctx, []byte(testdata.ContactsOutput))
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, contacts, 1)
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err = writer.WriteObjectValue("", contacts[0])
+	require.NoError(t, err, clues.ToCore(err))
+
+	body, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	out, err := FromJSON(ctx, body)
+	require.NoError(t, err, clues.ToCore(err))
+
+	out = strings.ReplaceAll(out, "\r", "")
+	assert.Equal(t, strings.TrimSpace(testdata.ContactsOutput), strings.TrimSpace(out))
+}
+
+func (suite *ParseUnitSuite) TestToContactables() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	cards := "BEGIN:VCARD\r\n" +
+		"VERSION:4.0\r\n" +
+		"FN:Alice Smith\r\n" +
+		"N:Smith;Alice;;;\r\n" +
+		"TEL;TYPE=work:555-0100\r\n" +
+		"TEL;TYPE=work:555-0101\r\n" +
+		"RELATED;TYPE=child:Eve\r\n" +
+		"RELATED;TYPE=manager:Bob\r\n" +
+		"END:VCARD\r\n" +
+		"BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"FN:Carol\r\n" +
+		"BDAY:19800102\r\n" +
+		"ORG:Acme;Sales\r\n" +
+		"END:VCARD\r\n"
+
+	contacts, err := ToContactables(ctx, []byte(cards))
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, contacts, 2)
+
+	alice := contacts[0]
+	assert.Equal(t, "Alice Smith", ptr.Val(alice.GetDisplayName()))
+	assert.Equal(t, "Alice", ptr.Val(alice.GetGivenName()))
+	assert.Equal(t, "Smith", ptr.Val(alice.GetSurname()))
+	assert.Nil(t, alice.GetMiddleName())
+	assert.Equal(t, []string{"555-0100", "555-0101"}, alice.GetBusinessPhones())
+	assert.Equal(t, []string{"Eve"}, alice.GetChildren())
+	assert.Equal(t, "Bob", ptr.Val(alice.GetManager()))
+
+	carol := contacts[1]
+	assert.Equal(t, "Carol", ptr.Val(carol.GetDisplayName()))
+	assert.Equal(t, "1980-01-02", carol.GetBirthday().Format("2006-01-02"))
+	assert.Equal(t, "Acme", ptr.Val(carol.GetCompanyName()))
+	assert.Equal(t, "Sales", ptr.Val(carol.GetDepartment()))
+	assert.Nil(t, carol.GetProfession())
+}
+
+func (suite *ParseUnitSuite) TestToContactables_invalid() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	_, err := ToContactables(ctx, []byte("BEGIN:VCARD\r\nnot a card"))
+	assert.Error(t, err, clues.ToCore(err))
+}
@@ -0,0 +1,405 @@
+package davfs
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	stdpath "path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alcionai/clues"
+	"golang.org/x/net/webdav"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+// driveRootElem is the first element of every drive item's LocationRef.
+const driveRootElem = "root:"
+
+// ItemOpener produces the exported content of a backed up item.
+type ItemOpener interface {
+	OpenItem(ctx context.Context, ent *details.Entry) (export.Item, error)
+}
+
+var _ webdav.FileSystem = &FS{}
+
+// FS is a read-only webdav.FileSystem over the items of a single backup.
+// Folders are laid out by their display names (the LocationRef of each
+// item), and item content is only read from the repository when a file
+// is read or measured.
+type FS struct {
+	root   *node
+	opener ItemOpener
+	cache  *contentCache
+}
+
+// New lays out the items in the details as a file tree.  Directories
+// without a known modification time use the backup's creation time.
+func New(
+	deets *details.Details,
+	opener ItemOpener,
+	created time.Time,
+) (*FS, error) {
+	root := newDir("", created)
+
+	for _, ent := range deets.Items() {
+		dirs, name, ok, err := layout(ent)
+		if err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		parent := root
+		for _, d := range dirs {
+			parent = parent.dir(sanitize(d, "_"), created)
+		}
+
+		n := &node{
+			name:    sanitize(name, itemID(ent)),
+			modTime: ent.Modified(),
+			size:    ent.Size(),
+			entry:   ent,
+		}
+
+		n.exportedSize.Store(-1)
+
+		if isDriveItem(ent) {
+			n.exportedSize.Store(n.size)
+		}
+
+		parent.add(n)
+	}
+
+	root.settleModTimes()
+
+	return &FS{
+		root:   root,
+		opener: opener,
+		cache:  newContentCache(cacheBudget),
+	}, nil
+}
+
+// layout produces the folders, by display name, that contain the item,
+// and the name of the item's file.  Items that can't be exported are
+// skipped.
+func layout(ent *details.Entry) ([]string, string, bool, error) {
+	var elems []string
+
+	if len(ent.LocationRef) > 0 {
+		pb, err := path.Builder{}.SplitUnescapeAppend(ent.LocationRef)
+		if err != nil {
+			return nil, "", false, clues.Wrap(err, "parsing location").
+				With("location_ref", ent.LocationRef)
+		}
+
+		elems = pb.Elements()
+	}
+
+	switch {
+	case ent.Exchange != nil:
+		return exchangeLayout(ent, elems)
+
+	case ent.OneDrive != nil:
+		return driveLayout(ent.OneDrive.DriveName, ent.OneDrive.DriveID, elems), ent.OneDrive.ItemName, true, nil
+
+	case ent.SharePoint != nil && ent.SharePoint.ItemType == details.SharePointLibrary:
+		return driveLayout(ent.SharePoint.DriveName, ent.SharePoint.DriveID, elems), ent.SharePoint.ItemName, true, nil
+
+	case ent.Groups != nil && ent.Groups.ItemType == details.SharePointLibrary:
+		return driveLayout(ent.Groups.DriveName, ent.Groups.DriveID, elems), ent.Groups.ItemName, true, nil
+	}
+
+	return elems, itemID(ent), true, nil
+}
+
+// isDriveItem reports whether the item is a file in a drive.  Drive
+// items get exported as-is, unlike other items, which get converted.
+func isDriveItem(ent *details.Entry) bool {
+	return ent.OneDrive != nil ||
+		(ent.SharePoint != nil && ent.SharePoint.ItemType == details.SharePointLibrary) ||
+		(ent.Groups != nil && ent.Groups.ItemType == details.SharePointLibrary)
+}
+
+// exchangeLayout groups items by category, and names each file after the
+// item's subject, or the contact's name, with the extension of the format
+// it gets exported to.
+func exchangeLayout(ent *details.Entry, elems []string) ([]string, string, bool, error) {
+	var (
+		info = ent.Exchange
+		cat  path.CategoryType
+		name string
+		ext  string
+	)
+
+	switch info.ItemType {
+	case details.ExchangeMail:
+		cat, name, ext = path.EmailCategory, info.Subject, ".eml"
+	case details.ExchangeEvent:
+		cat, name, ext = path.EventsCategory, info.Subject, ".ics"
+	case details.ExchangeContact:
+		cat, name, ext = path.ContactsCategory, info.ContactName, ".vcf"
+	default:
+		// mailbox settings have no export format.
+		return nil, "", false, nil
+	}
+
+	if len(strings.TrimSpace(name)) == 0 {
+		name = itemID(ent)
+	}
+
+	return append([]string{cat.HumanString()}, elems...), name + ext, true, nil
+}
+
+// driveLayout places drive items beneath a folder named for their drive.
+func driveLayout(driveName, driveID string, elems []string) []string {
+	if len(elems) > 0 && elems[0] == driveRootElem {
+		elems = elems[1:]
+	}
+
+	if len(driveName) == 0 {
+		driveName = driveID
+	}
+
+	return append([]string{driveName}, elems...)
+}
+
+func itemID(ent *details.Entry) string {
+	if len(ent.ItemRef) > 0 {
+		return ent.ItemRef
+	}
+
+	return stdpath.Base(ent.RepoRef)
+}
+
+// sanitize makes the display name usable as a single path element.
+func sanitize(name, fallback string) string {
+	name = strings.Map(
+		func(r rune) rune {
+			if r == '/' || r == '\\' || r < ' ' {
+				return '_'
+			}
+
+			return r
+		},
+		strings.TrimSpace(name))
+
+	if len(name) == 0 || name == "." || name == ".." {
+		return fallback
+	}
+
+	return name
+}
+
+// ---------------------------------------------------------------------------
+// tree
+// ---------------------------------------------------------------------------
+
+type node struct {
+	name    string
+	modTime time.Time
+	// size is the size recorded at backup time.
+	size int64
+
+	// measureMu serializes measuring the file's exported content.
+	measureMu sync.Mutex
+	// exportedSize is the size of the file's exported content, or -1
+	// until it's been measured.
+	exportedSize atomic.Int64
+
+	// entry is only set on files.
+	entry *details.Entry
+	// children is only set on directories.
+	children map[string]*node
+}
+
+func newDir(name string, modTime time.Time) *node {
+	return &node{
+		name:     name,
+		modTime:  modTime,
+		children: map[string]*node{},
+	}
+}
+
+func (n *node) isDir() bool {
+	return n.children != nil
+}
+
+// dir returns the child directory with the name, creating it if needed.
+func (n *node) dir(name string, modTime time.Time) *node {
+	if c, ok := n.children[name]; ok && c.isDir() {
+		return c
+	}
+
+	d := newDir(name, modTime)
+	n.add(d)
+
+	return d
+}
+
+// add inserts the child, renaming it if the name is already taken.
+func (n *node) add(c *node) {
+	name := c.name
+
+	for i := 2; ; i++ {
+		if _, ok := n.children[name]; !ok {
+			break
+		}
+
+		ext := stdpath.Ext(c.name)
+		name = fmt.Sprintf("%s (%d)%s", strings.TrimSuffix(c.name, ext), i, ext)
+	}
+
+	c.name = name
+	n.children[name] = c
+}
+
+// settleModTimes sets the modification time of each directory to that of
+// its most recently modified descendant.
+func (n *node) settleModTimes() time.Time {
+	if !n.isDir() {
+		return n.modTime
+	}
+
+	var latest time.Time
+
+	for _, c := range n.children {
+		if mt := c.settleModTimes(); mt.After(latest) {
+			latest = mt
+		}
+	}
+
+	if !latest.IsZero() {
+		n.modTime = latest
+	}
+
+	return n.modTime
+}
+
+func (n *node) sortedChildren() []*node {
+	cs := make([]*node, 0, len(n.children))
+
+	for _, c := range n.children {
+		cs = append(cs, c)
+	}
+
+	sort.Slice(cs, func(i, j int) bool { return cs[i].name < cs[j].name })
+
+	return cs
+}
+
+func (dav *FS) lookup(name string) (*node, error) {
+	n := dav.root
+
+	for _, elem := range strings.Split(strings.Trim(stdpath.Clean("/"+name), "/"), "/") {
+		if len(elem) == 0 {
+			continue
+		}
+
+		if !n.isDir() {
+			return nil, os.ErrNotExist
+		}
+
+		c, ok := n.children[elem]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+
+		n = c
+	}
+
+	return n, nil
+}
+
+// ---------------------------------------------------------------------------
+// webdav.FileSystem
+// ---------------------------------------------------------------------------
+
+const writeFlags = os.O_WRONLY | os.O_RDWR | os.O_CREATE | os.O_TRUNC | os.O_APPEND
+
+func (dav *FS) OpenFile(ctx context.Context, name string, flag int, _ os.FileMode) (webdav.File, error) {
+	if flag&writeFlags != 0 {
+		return nil, os.ErrPermission
+	}
+
+	n, err := dav.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.isDir() {
+		return &dirFile{n: n, fs: dav}, nil
+	}
+
+	return &itemFile{
+		ctx: ctx,
+		n:   n,
+		fs:  dav,
+	}, nil
+}
+
+func (dav *FS) Stat(_ context.Context, name string) (os.FileInfo, error) {
+	n, err := dav.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return dav.info(n), nil
+}
+
+func (dav *FS) Mkdir(context.Context, string, os.FileMode) error {
+	return os.ErrPermission
+}
+
+func (dav *FS) RemoveAll(context.Context, string) error {
+	return os.ErrPermission
+}
+
+func (dav *FS) Rename(context.Context, string, string) error {
+	return os.ErrPermission
+}
+
+// ---------------------------------------------------------------------------
+// file info
+// ---------------------------------------------------------------------------
+
+var _ webdav.ContentTyper = fileInfo{}
+
+type fileInfo struct {
+	n    *node
+	size int64
+}
+
+func (fi fileInfo) Name() string       { return fi.n.name }
+func (fi fileInfo) ModTime() time.Time { return fi.n.modTime }
+func (fi fileInfo) IsDir() bool        { return fi.n.isDir() }
+func (fi fileInfo) Sys() any           { return nil }
+
+func (fi fileInfo) Size() int64 { return fi.size }
+
+func (fi fileInfo) Mode() fs.FileMode {
+	if fi.n.isDir() {
+		return fs.ModeDir | 0o555
+	}
+
+	return 0o444
+}
+
+// ContentType keeps webdav from reading every file in a folder listing
+// in order to sniff its content type.
+func (fi fileInfo) ContentType(context.Context) (string, error) {
+	if fi.n.isDir() {
+		return "", webdav.ErrNotImplemented
+	}
+
+	return contentType(fi.n.name), nil
+}
@@ -0,0 +1,391 @@
+package davfs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/webdav"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/export"
+)
+
+type mockOpener struct {
+	content map[string]string
+	opened  []string
+}
+
+func (mo *mockOpener) OpenItem(_ context.Context, ent *details.Entry) (export.Item, error) {
+	mo.opened = append(mo.opened, ent.ItemRef)
+
+	c, ok := mo.content[ent.ItemRef]
+	if !ok {
+		return export.Item{}, assert.AnError
+	}
+
+	return export.Item{
+		ID:   ent.ItemRef,
+		Body: io.NopCloser(strings.NewReader(c)),
+	}, nil
+}
+
+type DavFSUnitSuite struct {
+	tester.Suite
+}
+
+func TestDavFSUnitSuite(t *testing.T) {
+	suite.Run(t, &DavFSUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+var (
+	created  = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	modified = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func testDetails() *details.Details {
+	mail := func(id, loc, subject string) details.Entry {
+		return details.Entry{
+			RepoRef:     "tenant/exchange/user/email/" + id,
+			ItemRef:     id,
+			LocationRef: loc,
+			ItemInfo: details.ItemInfo{
+				Exchange: &details.ExchangeInfo{
+					ItemType: details.ExchangeMail,
+					Subject:  subject,
+					Modified: modified,
+					Size:     10,
+				},
+			},
+		}
+	}
+
+	return &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{
+				mail("m1", "Inbox", "hello"),
+				mail("m2", "Inbox", "hello"),
+				mail("m3", "Inbox/Work\\/Play", "a/b"),
+				mail("m4", "Inbox", ""),
+				{
+					RepoRef:     "tenant/exchange/user/email/folder",
+					LocationRef: "Inbox",
+					ItemInfo: details.ItemInfo{
+						Folder: &details.FolderInfo{ItemType: details.FolderItem, DisplayName: "Inbox"},
+					},
+				},
+				{
+					RepoRef: "tenant/exchange/user/mailboxsettings/rules",
+					ItemRef: "rules",
+					ItemInfo: details.ItemInfo{
+						Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMailboxSettings},
+					},
+				},
+				{
+					RepoRef:     "tenant/onedrive/user/files/drives/d/root:/f/file1",
+					ItemRef:     "file1",
+					LocationRef: "root:/Documents",
+					ItemInfo: details.ItemInfo{
+						OneDrive: &details.OneDriveInfo{
+							ItemType:  details.OneDriveItem,
+							DriveName: "OneDrive",
+							ItemName:  "report.docx",
+							Modified:  modified,
+							Size:      15,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (suite *DavFSUnitSuite) TestLayout() {
+	t := suite.T()
+
+	dfs, err := New(testDetails(), &mockOpener{}, created)
+	require.NoError(t, err, clues.ToCore(err))
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	table := []struct {
+		name      string
+		expectDir bool
+		expectErr error
+	}{
+		{name: "/", expectDir: true},
+		{name: "/Emails/Inbox", expectDir: true},
+		{name: "/Emails/Inbox/hello.eml"},
+		{name: "/Emails/Inbox/hello (2).eml"},
+		{name: "/Emails/Inbox/m4.eml"},
+		{name: "/Emails/Inbox/Work_Play/a_b.eml"},
+		{name: "/OneDrive/Documents/report.docx"},
+		{name: "/OneDrive/root:", expectErr: os.ErrNotExist},
+		{name: "/Mailbox Settings", expectErr: os.ErrNotExist},
+		{name: "/Emails/Inbox/hello.eml/x", expectErr: os.ErrNotExist},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			fi, err := dfs.Stat(ctx, test.name)
+			if test.expectErr != nil {
+				assert.ErrorIs(t, err, test.expectErr, clues.ToCore(err))
+				return
+			}
+
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectDir, fi.IsDir())
+		})
+	}
+
+	fi, err := dfs.Stat(ctx, "/Emails")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, modified, fi.ModTime(), "directories take the latest item mod time")
+}
+
+func (suite *DavFSUnitSuite) TestReadOnly() {
+	t := suite.T()
+
+	dfs, err := New(testDetails(), &mockOpener{}, created)
+	require.NoError(t, err, clues.ToCore(err))
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	_, err = dfs.OpenFile(ctx, "/Emails/Inbox/hello.eml", os.O_RDWR, 0)
+	assert.ErrorIs(t, err, os.ErrPermission, clues.ToCore(err))
+
+	_, err = dfs.OpenFile(ctx, "/Emails/Inbox/new.eml", os.O_CREATE|os.O_WRONLY, 0)
+	assert.ErrorIs(t, err, os.ErrPermission, clues.ToCore(err))
+
+	err = dfs.Mkdir(ctx, "/Emails/new", 0o755)
+	assert.ErrorIs(t, err, os.ErrPermission, clues.ToCore(err))
+
+	err = dfs.RemoveAll(ctx, "/Emails")
+	assert.ErrorIs(t, err, os.ErrPermission, clues.ToCore(err))
+
+	err = dfs.Rename(ctx, "/Emails", "/Mail")
+	assert.ErrorIs(t, err, os.ErrPermission, clues.ToCore(err))
+}
+
+func (suite *DavFSUnitSuite) TestReaddir() {
+	t := suite.T()
+
+	dfs, err := New(testDetails(), &mockOpener{}, created)
+	require.NoError(t, err, clues.ToCore(err))
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	f, err := dfs.OpenFile(ctx, "/Emails/Inbox", os.O_RDONLY, 0)
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer f.Close()
+
+	fis, err := f.Readdir(2)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, fis, 2)
+	assert.Equal(t, "Work_Play", fis[0].Name())
+	assert.Equal(t, "hello (2).eml", fis[1].Name())
+
+	fis, err = f.Readdir(5)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, fis, 2)
+	assert.Equal(t, "hello.eml", fis[0].Name())
+	assert.Equal(t, "m4.eml", fis[1].Name())
+
+	_, err = f.Readdir(1)
+	assert.ErrorIs(t, err, io.EOF, clues.ToCore(err))
+}
+
+func (suite *DavFSUnitSuite) TestServe() {
+	t := suite.T()
+
+	opener := &mockOpener{
+		content: map[string]string{
+			"m1":    "Subject: hello",
+			"file1": "report contents",
+		},
+	}
+
+	dfs, err := New(testDetails(), opener, created)
+	require.NoError(t, err, clues.ToCore(err))
+
+	srv := httptest.NewServer(&webdav.Handler{
+		FileSystem: dfs,
+		LockSystem: webdav.NewMemLS(),
+	})
+	defer srv.Close()
+
+	do := func(method, name string, hdrs map[string]string) (*http.Response, string) {
+		req, err := http.NewRequest(method, srv.URL+name, nil)
+		require.NoError(t, err, clues.ToCore(err))
+
+		for k, v := range hdrs {
+			req.Header.Set(k, v)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err, clues.ToCore(err))
+
+		defer resp.Body.Close()
+
+		bs, err := io.ReadAll(resp.Body)
+		require.NoError(t, err, clues.ToCore(err))
+
+		return resp, string(bs)
+	}
+
+	resp, body := do("PROPFIND", "/OneDrive/Documents/", map[string]string{"Depth": "1"})
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+	assert.Contains(t, body, "report.docx")
+	assert.Contains(t, body, "<D:getcontentlength>15</D:getcontentlength>")
+	assert.Empty(t, opener.opened, "drive items are sized without reading them")
+
+	resp, body = do("PROPFIND", "/Emails/Inbox/", map[string]string{"Depth": "1"})
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+	assert.Contains(t, body, "hello.eml")
+	assert.Contains(t, body, "<D:getcontentlength>10</D:getcontentlength>", "listings report the recorded size")
+	assert.Empty(t, opener.opened, "listing a folder doesn't read any items")
+
+	resp, body = do(http.MethodGet, "/Emails/Inbox/hello.eml", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Subject: hello", body)
+	assert.Equal(t, "14", resp.Header.Get("Content-Length"))
+	assert.Equal(t, 1, countOf(opener.opened, "m1"), "measured items are served from the cache")
+
+	resp, body = do("PROPFIND", "/Emails/Inbox/hello.eml", map[string]string{"Depth": "0"})
+	assert.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+	assert.Contains(
+		t,
+		body,
+		"<D:getcontentlength>14</D:getcontentlength>",
+		"measured items report the size of their exported content")
+
+	resp, body = do(http.MethodGet, "/OneDrive/Documents/report.docx", map[string]string{"Range": "bytes=7-"})
+	assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, "contents", body)
+
+	resp, _ = do(http.MethodGet, "/Emails/Inbox/m4.eml", nil)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode, "item fails to open")
+
+	resp, _ = do(http.MethodDelete, "/Emails/Inbox/hello.eml", nil)
+	assert.NotEqual(t, http.StatusNoContent, resp.StatusCode)
+
+	resp, _ = do(http.MethodPut, "/Emails/Inbox/new.eml", nil)
+	assert.NotEqual(t, http.StatusCreated, resp.StatusCode)
+}
+
+func countOf(ss []string, s string) int {
+	var c int
+
+	for _, e := range ss {
+		if e == s {
+			c++
+		}
+	}
+
+	return c
+}
+
+func (suite *DavFSUnitSuite) TestItemFile_seek() {
+	t := suite.T()
+
+	opener := &mockOpener{
+		content: map[string]string{"file1": "report contents"},
+	}
+
+	dfs, err := New(testDetails(), opener, created)
+	require.NoError(t, err, clues.ToCore(err))
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	f, err := dfs.OpenFile(ctx, "/OneDrive/Documents/report.docx", os.O_RDONLY, 0)
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer f.Close()
+
+	read := func(n int) string {
+		p := make([]byte, n)
+
+		n, err := io.ReadFull(f, p)
+		require.NoError(t, err, clues.ToCore(err))
+
+		return string(p[:n])
+	}
+
+	end, err := f.Seek(0, io.SeekEnd)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, int64(15), end)
+	assert.Empty(t, opener.opened, "seeking doesn't read the item")
+
+	_, err = f.Seek(7, io.SeekStart)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "cont", read(4))
+
+	_, err = f.Seek(1, io.SeekCurrent)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "nts", read(3))
+	assert.Len(t, opener.opened, 1, "reading ahead skips forward in the stream")
+
+	_, err = f.Seek(0, io.SeekStart)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "report", read(6))
+	assert.Len(t, opener.opened, 2, "reading behind the stream reopens the item")
+
+	_, err = f.Seek(20, io.SeekStart)
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = f.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF, clues.ToCore(err))
+
+	_, err = f.Seek(-1, io.SeekStart)
+	assert.Error(t, err)
+}
+
+func (suite *DavFSUnitSuite) TestContentCache() {
+	t := suite.T()
+
+	var (
+		cc      = newContentCache(10)
+		a, b, c = &node{name: "a"}, &node{name: "b"}, &node{name: "c"}
+	)
+
+	cc.put(a, []byte("aaaa"))
+	cc.put(b, []byte("bbbb"))
+
+	// a becomes the most recently used.
+	_, ok := cc.get(a)
+	require.True(t, ok)
+
+	cc.put(c, []byte("cccc"))
+
+	_, ok = cc.get(b)
+	assert.False(t, ok, "least recently used item is evicted")
+
+	content, ok := cc.get(a)
+	assert.True(t, ok)
+	assert.Equal(t, "aaaa", string(content))
+
+	_, ok = cc.get(c)
+	assert.True(t, ok)
+
+	cc.put(b, []byte("too large to cache"))
+
+	_, ok = cc.get(b)
+	assert.False(t, ok, "items over the budget aren't cached")
+	assert.Equal(t, int64(8), cc.used)
+}
@@ -0,0 +1,365 @@
+package davfs
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	stdpath "path"
+	"sync"
+
+	"github.com/alcionai/clues"
+	"golang.org/x/net/webdav"
+
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+const (
+	// maxCachedItemSize is the largest item whose content is kept in
+	// the cache.
+	maxCachedItemSize = 4 * 1024 * 1024
+	// cacheBudget bounds the total content held in the cache.
+	cacheBudget = 64 * 1024 * 1024
+)
+
+var (
+	_ webdav.File = &dirFile{}
+	_ webdav.File = &itemFile{}
+)
+
+// contentType guesses the content type of a file from its extension.
+func contentType(name string) string {
+	if ct := mime.TypeByExtension(stdpath.Ext(name)); len(ct) > 0 {
+		return ct
+	}
+
+	return "application/octet-stream"
+}
+
+// ---------------------------------------------------------------------------
+// directories
+// ---------------------------------------------------------------------------
+
+type dirFile struct {
+	n  *node
+	fs *FS
+	// read counts the children already handed out by Readdir.
+	read int
+}
+
+func (df *dirFile) Close() error { return nil }
+
+func (df *dirFile) Read([]byte) (int, error) {
+	return 0, clues.New("is a directory")
+}
+
+func (df *dirFile) Seek(int64, int) (int64, error) {
+	return 0, clues.New("is a directory")
+}
+
+func (df *dirFile) Write([]byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+func (df *dirFile) Stat() (fs.FileInfo, error) {
+	return df.fs.info(df.n), nil
+}
+
+// Readdir follows the semantics of os.File.Readdir.
+func (df *dirFile) Readdir(count int) ([]fs.FileInfo, error) {
+	children := df.n.sortedChildren()[df.read:]
+
+	if count > 0 {
+		if len(children) == 0 {
+			return nil, io.EOF
+		}
+
+		if len(children) > count {
+			children = children[:count]
+		}
+	}
+
+	fis := make([]fs.FileInfo, 0, len(children))
+
+	for _, c := range children {
+		fis = append(fis, df.fs.info(c))
+	}
+
+	df.read += len(children)
+
+	return fis, nil
+}
+
+// ---------------------------------------------------------------------------
+// items
+// ---------------------------------------------------------------------------
+
+// itemFile streams the item's content out of the repository as it gets
+// read.  Seeking only moves the offset of the next read: reads ahead of
+// the stream skip forward in it, and reads behind it reopen the item.
+// Items held in the cache are served from there instead.
+type itemFile struct {
+	ctx context.Context
+	n   *node
+	fs  *FS
+
+	// off is the offset of the next read.
+	off int64
+	// body is the item's content, after pos bytes have been read.
+	body io.ReadCloser
+	pos  int64
+}
+
+func (f *itemFile) Read(p []byte) (int, error) {
+	if content, ok := f.fs.cache.get(f.n); ok {
+		if f.off >= int64(len(content)) {
+			return 0, io.EOF
+		}
+
+		n := copy(p, content[f.off:])
+		f.off += int64(n)
+
+		return n, nil
+	}
+
+	if f.body != nil && f.off < f.pos {
+		f.closeBody()
+	}
+
+	if f.body == nil {
+		body, err := f.fs.open(f.ctx, f.n)
+		if err != nil {
+			return 0, err
+		}
+
+		f.body = body
+		f.pos = 0
+	}
+
+	if f.off > f.pos {
+		skipped, err := io.CopyN(io.Discard, f.body, f.off-f.pos)
+		f.pos += skipped
+
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+
+		if err != nil {
+			return 0, clues.WrapWC(f.ctx, err, "reading item")
+		}
+	}
+
+	n, err := f.body.Read(p)
+	f.pos += int64(n)
+	f.off = f.pos
+
+	return n, err
+}
+
+func (f *itemFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.off
+	case io.SeekEnd:
+		size, err := f.fs.size(f.ctx, f.n)
+		if err != nil {
+			return 0, err
+		}
+
+		offset += size
+	default:
+		return 0, clues.New("invalid seek whence")
+	}
+
+	if offset < 0 {
+		return 0, clues.New("negative seek offset")
+	}
+
+	f.off = offset
+
+	return offset, nil
+}
+
+func (f *itemFile) Write([]byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+func (f *itemFile) Readdir(int) ([]fs.FileInfo, error) {
+	return nil, clues.New("not a directory")
+}
+
+func (f *itemFile) Stat() (fs.FileInfo, error) {
+	return f.fs.info(f.n), nil
+}
+
+func (f *itemFile) Close() error {
+	f.closeBody()
+	return nil
+}
+
+func (f *itemFile) closeBody() {
+	if f.body == nil {
+		return
+	}
+
+	if err := f.body.Close(); err != nil {
+		logger.CtxErr(f.ctx, err).Info("closing item")
+	}
+
+	f.body = nil
+}
+
+// open reads the item's exported content out of the repository.
+func (dav *FS) open(ctx context.Context, n *node) (io.ReadCloser, error) {
+	ctx = clues.Add(ctx, "item_name", clues.Hide(n.name))
+
+	item, err := dav.opener.OpenItem(ctx, n.entry)
+	if err != nil {
+		return nil, clues.Wrap(err, "opening item")
+	}
+
+	if item.Body == nil {
+		return nil, clues.NewWC(ctx, "item has no content")
+	}
+
+	return item.Body, nil
+}
+
+// size returns the size of the item's exported content.  Drive items get
+// exported as-is, so the size recorded at backup time is exact.  Other
+// items get converted (ex: mail to eml), so they're read once to measure
+// them, and cached if they're small enough.  That only happens when a
+// reader seeks to the end of the file, ex: to serve a GET.
+func (dav *FS) size(ctx context.Context, n *node) (int64, error) {
+	if size := n.exportedSize.Load(); size >= 0 {
+		return size, nil
+	}
+
+	n.measureMu.Lock()
+	defer n.measureMu.Unlock()
+
+	if size := n.exportedSize.Load(); size >= 0 {
+		return size, nil
+	}
+
+	body, err := dav.open(ctx, n)
+	if err != nil {
+		return 0, err
+	}
+
+	defer body.Close()
+
+	var buf bytes.Buffer
+
+	size, err := io.CopyN(&buf, body, maxCachedItemSize+1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, clues.WrapWC(ctx, err, "reading item")
+	}
+
+	if size <= maxCachedItemSize {
+		dav.cache.put(n, buf.Bytes())
+	} else {
+		rest, err := io.Copy(io.Discard, body)
+		if err != nil {
+			return 0, clues.WrapWC(ctx, err, "reading item")
+		}
+
+		size += rest
+	}
+
+	n.exportedSize.Store(size)
+
+	return size, nil
+}
+
+// info describes the node without reading it.  Files report the size of
+// their exported content once it's been measured, and the size recorded
+// at backup time until then.  Measuring every file would read each one
+// out of the repository just to list a folder.
+func (dav *FS) info(n *node) fileInfo {
+	if n.isDir() {
+		return fileInfo{n: n}
+	}
+
+	size := n.exportedSize.Load()
+	if size < 0 {
+		size = n.size
+	}
+
+	return fileInfo{n: n, size: size}
+}
+
+// ---------------------------------------------------------------------------
+// cache
+// ---------------------------------------------------------------------------
+
+// contentCache holds the content of the items that were read to measure
+// them, so that they don't get read out of the repository again when
+// they're opened.  The least recently used items are evicted to keep the
+// cache within its budget.
+type contentCache struct {
+	budget int64
+
+	mu     sync.Mutex
+	used   int64
+	lru    *list.List
+	byNode map[*node]*list.Element
+}
+
+type cacheEntry struct {
+	n       *node
+	content []byte
+}
+
+func newContentCache(budget int64) *contentCache {
+	return &contentCache{
+		budget: budget,
+		lru:    list.New(),
+		byNode: map[*node]*list.Element{},
+	}
+}
+
+func (cc *contentCache) get(n *node) ([]byte, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	e, ok := cc.byNode[n]
+	if !ok {
+		return nil, false
+	}
+
+	cc.lru.MoveToFront(e)
+
+	return e.Value.(*cacheEntry).content, true
+}
+
+func (cc *contentCache) put(n *node, content []byte) {
+	size := int64(len(content))
+	if size > cc.budget {
+		return
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if _, ok := cc.byNode[n]; ok {
+		return
+	}
+
+	for cc.used+size > cc.budget {
+		e := cc.lru.Back()
+		ce := e.Value.(*cacheEntry)
+
+		cc.lru.Remove(e)
+		delete(cc.byNode, ce.n)
+		cc.used -= int64(len(ce.content))
+	}
+
+	cc.byNode[n] = cc.lru.PushFront(&cacheEntry{n: n, content: content})
+	cc.used += size
+}
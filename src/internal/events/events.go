@@ -28,12 +28,14 @@ const (
 	tenantIDDeprecated = "m365_tenant_hash_deprecated"
 
 	// Event Keys
-	RepoInit       = "Repo Init"
-	RepoConnect    = "Repo Connect"
-	BackupEnd      = "Backup End"
-	RestoreEnd     = "Restore End"
-	ExportEnd      = "Export End"
-	MaintenanceEnd = "Maintenance End"
+	RepoInit        = "Repo Init"
+	RepoConnect     = "Repo Connect"
+	BackupEnd       = "Backup End"
+	RestoreEnd      = "Restore End"
+	ExportEnd       = "Export End"
+	ImportEnd       = "Import End"
+	MaintenanceEnd  = "Maintenance End"
+	ScheduledRunEnd = "Scheduled Run End"
 
 	// Event Data Keys
 	BackupCreateTime = "backup_creation_time"
@@ -47,6 +49,7 @@ const (
 	Resources        = "resources"
 	RestoreID        = "restore_id"
 	ExportID         = "export_id"
+	ScheduleJob      = "schedule_job"
 	Service          = "service"
 	StartTime        = "start_time"
 	Status           = "status"
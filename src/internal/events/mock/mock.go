@@ -2,11 +2,15 @@ package mock
 
 import (
 	"context"
+	"sync"
 
 	"github.com/alcionai/clues"
 )
 
+// Bus records the events it receives.  Events may be sent concurrently.
 type Bus struct {
+	mu sync.Mutex
+
 	TimesCalled map[string]int
 	CalledWith  map[string][]map[string]any
 	TimesClosed int
@@ -20,6 +24,9 @@ func NewBus() *Bus {
 }
 
 func (b *Bus) Event(ctx context.Context, key string, data map[string]any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
 	b.TimesCalled[key] = b.TimesCalled[key] + 1
 
 	cw := b.CalledWith[key]
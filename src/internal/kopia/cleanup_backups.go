@@ -62,6 +62,29 @@ func cleanupOrphanedData(
 		"current_time", nowFunc(),
 		"buffer_duration", gcBuffer)
 
+	toDelete, err := findOrphanedData(ctx, bs, mf, gcBuffer, nowFunc)
+	if err != nil {
+		return err
+	}
+
+	// Use single atomic batch delete operation to cleanup to keep from making a
+	// bunch of manifest content blobs.
+	if err := bs.DeleteWithModelStoreIDs(ctx, toDelete...); err != nil {
+		return clues.Wrap(err, "deleting orphaned data")
+	}
+
+	return nil
+}
+
+// findOrphanedData returns the IDs of the models and snapshots that
+// cleanupOrphanedData would delete, without deleting them.
+func findOrphanedData(
+	ctx context.Context,
+	bs store.Storer,
+	mf manifestFinder,
+	gcBuffer time.Duration,
+	nowFunc func() time.Time,
+) ([]manifest.ID, error) {
 	// Get all snapshot manifests.
 	snaps, err := mf.FindManifests(
 		ctx,
@@ -69,7 +92,7 @@ func cleanupOrphanedData(
 			manifest.TypeLabelKey: snapshot.ManifestType,
 		})
 	if err != nil {
-		return clues.Wrap(err, "getting snapshots")
+		return nil, clues.Wrap(err, "getting snapshots")
 	}
 
 	var (
@@ -113,7 +136,7 @@ func cleanupOrphanedData(
 	// enough.
 	deetsModels, err := bs.GetIDsForType(ctx, model.BackupDetailsSchema, nil)
 	if err != nil {
-		return clues.Wrap(err, "getting legacy backup details")
+		return nil, clues.Wrap(err, "getting legacy backup details")
 	}
 
 	for _, d := range deetsModels {
@@ -130,7 +153,7 @@ func cleanupOrphanedData(
 	// Get all backup models.
 	bups, err := bs.GetIDsForType(ctx, model.BackupSchema, nil)
 	if err != nil {
-		return clues.Wrap(err, "getting all backup models")
+		return nil, clues.Wrap(err, "getting all backup models")
 	}
 
 	var (
@@ -161,7 +184,7 @@ func cleanupOrphanedData(
 			bup.ModelStoreID,
 			&bm); err != nil {
 			if !errors.Is(err, data.ErrNotFound) {
-				return clues.Wrap(err, "getting backup model").
+				return nil, clues.Wrap(err, "getting backup model").
 					With("search_backup_id", bup.ID)
 			}
 
@@ -188,6 +211,16 @@ func cleanupOrphanedData(
 			ssid = bm.DetailsID
 		}
 
+		// Backups under legal hold are never garbage collected, even if
+		// they're incomplete or are old assist bases.
+		if bm.UnderLegalHold() {
+			delete(toDelete, bup.ModelStoreID)
+			delete(toDelete, manifest.ID(bm.SnapshotID))
+			delete(toDelete, manifest.ID(ssid))
+
+			continue
+		}
+
 		d, dataOK := dataSnaps[manifest.ID(bm.SnapshotID)]
 		_, deetsOK := deets[manifest.ID(ssid)]
 
@@ -253,7 +286,7 @@ func cleanupOrphanedData(
 	}
 
 	logger.Ctx(ctx).Infow(
-		"garbage collecting orphaned items",
+		"orphaned items eligible for garbage collection",
 		"num_items", len(toDelete),
 		"kopia_ids", maps.Keys(toDelete))
 
@@ -272,19 +305,11 @@ func cleanupOrphanedData(
 	assistItems := collectOldAssistBases(ctx, mostRecentMergeBase, assistBackups)
 
 	logger.Ctx(ctx).Debugw(
-		"garbage collecting old assist bases",
+		"old assist bases eligible for garbage collection",
 		"assist_num_items", len(assistItems),
 		"assist_kopia_ids", assistItems)
 
-	assistItems = append(assistItems, maps.Keys(toDelete)...)
-
-	// Use single atomic batch delete operation to cleanup to keep from making a
-	// bunch of manifest content blobs.
-	if err := bs.DeleteWithModelStoreIDs(ctx, assistItems...); err != nil {
-		return clues.Wrap(err, "deleting orphaned data")
-	}
-
-	return nil
+	return append(assistItems, maps.Keys(toDelete)...), nil
 }
 
 var skipKeys = []string{
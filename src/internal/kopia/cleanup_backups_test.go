@@ -479,6 +479,22 @@ func (suite *BackupCleanupUnitSuite) TestCleanupOrphanedData() {
 			time:      baseTime,
 			expectErr: assert.NoError,
 		},
+		{
+			name: "MissingDetails UnderLegalHold Noops",
+			snapshots: []*manifest.EntryMetadata{
+				snapCurrent(),
+			},
+			backups: []backupRes{
+				{bup: func() *backup.Backup {
+					b := bupCurrent()
+					b.PlaceLegalHold(backup.LegalHold{Name: "case-1234"})
+
+					return b
+				}()},
+			},
+			time:      baseTime,
+			expectErr: assert.NoError,
+		},
 		// Tests with various errors from Storer.
 		{
 			name:             "SnapshotsListError Fails",
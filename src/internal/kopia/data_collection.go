@@ -3,6 +3,7 @@ package kopia
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/fs"
@@ -140,16 +141,20 @@ func (kdc kopiaDataCollection) FetchItemByName(
 	}
 
 	return &kopiaDataStream{
-		id:     name,
-		reader: rr,
-		size:   size,
+		id:      name,
+		reader:  rr,
+		size:    size,
+		modTime: f.ModTime(),
 	}, nil
 }
 
+var _ data.ItemModTime = &kopiaDataStream{}
+
 type kopiaDataStream struct {
-	reader io.ReadCloser
-	id     string
-	size   int64
+	reader  io.ReadCloser
+	id      string
+	size    int64
+	modTime time.Time
 }
 
 func (kds kopiaDataStream) ToReader() io.ReadCloser {
@@ -167,3 +172,9 @@ func (kds kopiaDataStream) Deleted() bool {
 func (kds kopiaDataStream) Size() int64 {
 	return kds.size
 }
+
+// ModTime is the mod time recorded for the file in the snapshot, which
+// matches the modified time of the item when it was backed up.
+func (kds kopiaDataStream) ModTime() time.Time {
+	return kds.modTime
+}
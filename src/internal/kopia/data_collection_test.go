@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/fs"
@@ -296,8 +297,9 @@ func (suite *KopiaDataCollectionUnitSuite) TestFetchItemByName() {
 		errFileName   = "error"
 		errFileName2  = "error2"
 
-		noErrFileData = "foo bar baz"
-		errReader     = &dataMock.Item{
+		noErrFileData    = "foo bar baz"
+		noErrFileModTime = time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+		errReader        = &dataMock.Item{
 			ReadErr: assert.AnError,
 		}
 	)
@@ -322,8 +324,9 @@ func (suite *KopiaDataCollectionUnitSuite) TestFetchItemByName() {
 
 		return virtualfs.NewStaticDirectory(encodeAsPath(folder2), []fs.Entry{
 			&mockFile{
-				StreamingFile: virtualfs.StreamingFileFromReader(
+				StreamingFile: virtualfs.StreamingFileWithModTimeFromReader(
 					encodeAsPath(noErrFileName),
+					noErrFileModTime,
 					nil),
 				r: r1,
 			},
@@ -430,6 +433,7 @@ func (suite *KopiaDataCollectionUnitSuite) TestFetchItemByName() {
 			}
 
 			assert.Equal(t, test.expectedData, fileData)
+			assert.Equal(t, noErrFileModTime, s.(data.ItemModTime).ModTime())
 		})
 	}
 }
@@ -0,0 +1,148 @@
+package kopia
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo"
+	"github.com/kopia/kopia/repo/maintenance"
+
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// RepoStatus describes the configuration and maintenance state of the
+// repository.
+type RepoStatus struct {
+	FormatVersion int
+	Compressor    string
+
+	// RetentionMode is the object lock mode of the storage, or empty if
+	// object locking is disabled.
+	RetentionMode     string
+	RetentionPeriod   time.Duration
+	ExtendObjectLocks bool
+
+	MinEpochDuration time.Duration
+
+	// MaintenanceOwner is the user@host that's allowed to run maintenance.
+	MaintenanceOwner string
+	// CurrentOwner is the user@host of this client.
+	CurrentOwner string
+	// The start times of the most recent maintenance runs.  Zero if that
+	// kind of maintenance has never run.
+	LastQuickMaintenance time.Time
+	LastFullMaintenance  time.Time
+	// FailedMaintenanceTasks lists the maintenance tasks whose most recent
+	// run failed.
+	FailedMaintenanceTasks []string
+
+	// OrphanedItems is the number of models and snapshots from incomplete
+	// or orphaned backups that the next complete maintenance will delete.
+	OrphanedItems int
+}
+
+// RepoStatus gathers the configuration and maintenance state of the
+// repository.  Unexpected configuration values are added to errs as alerts.
+func (w Wrapper) RepoStatus(
+	ctx context.Context,
+	storer store.Storer,
+	errs *fault.Bus,
+) (*RepoStatus, error) {
+	if w.c == nil {
+		return nil, clues.StackWC(ctx, errNotConnected)
+	}
+
+	w.c.verifyDefaultConfigOptions(ctx, errs)
+
+	dr, ok := w.c.Repository.(repo.DirectRepository)
+	if !ok {
+		return nil, clues.NewWC(ctx, "unable to get valid handle to repo")
+	}
+
+	rs := &RepoStatus{
+		CurrentOwner: w.c.ClientOptions().UsernameAtHost(),
+	}
+
+	pol, err := w.c.getGlobalPolicyOrEmpty(ctx)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting global policy")
+	}
+
+	rs.Compressor = string(pol.CompressionPolicy.CompressorName)
+
+	mutableParams, blobCfg, err := w.c.getPersistentConfig(ctx)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	rs.FormatVersion = int(mutableParams.Version)
+	rs.MinEpochDuration = mutableParams.EpochParameters.MinEpochDuration
+	rs.RetentionMode = string(blobCfg.RetentionMode)
+	rs.RetentionPeriod = blobCfg.RetentionPeriod
+
+	params, err := maintenance.GetParams(ctx, dr)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "getting maintenance config")
+	}
+
+	rs.MaintenanceOwner = params.Owner
+	rs.ExtendObjectLocks = params.ExtendObjectLocks
+
+	sched, err := maintenance.GetSchedule(ctx, dr)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "getting maintenance schedule")
+	}
+
+	rs.LastQuickMaintenance, rs.LastFullMaintenance, rs.FailedMaintenanceTasks = maintenanceRuns(
+		sched,
+		params)
+
+	orphans, err := findOrphanedData(ctx, storer, w.c, defaultCleanupBuffer, time.Now)
+	if err != nil {
+		return nil, clues.Wrap(err, "finding incomplete backups")
+	}
+
+	rs.OrphanedItems = len(orphans)
+
+	return rs, nil
+}
+
+// maintenanceRuns produces the start times of the most recent quick and full
+// maintenance, along with the tasks whose most recent run failed.
+//
+// Kopia doesn't record a run for every quick maintenance, so the start times
+// are derived from the schedule instead.  Each run schedules the next one a
+// cycle interval after it started, and full maintenance also schedules the
+// next quick maintenance.
+func maintenanceRuns(
+	sched *maintenance.Schedule,
+	params *maintenance.Params,
+) (time.Time, time.Time, []string) {
+	var (
+		lastQuick time.Time
+		lastFull  time.Time
+		failed    = []string{}
+	)
+
+	if !sched.NextQuickMaintenanceTime.IsZero() {
+		lastQuick = sched.NextQuickMaintenanceTime.Add(-params.QuickCycle.Interval)
+	}
+
+	if !sched.NextFullMaintenanceTime.IsZero() {
+		lastFull = sched.NextFullMaintenanceTime.Add(-params.FullCycle.Interval)
+	}
+
+	// Runs are stored most recent first.
+	for task, runs := range sched.Runs {
+		if len(runs) > 0 && !runs[0].Success {
+			failed = append(failed, string(task))
+		}
+	}
+
+	sort.Strings(failed)
+
+	return lastQuick, lastFull, failed
+}
@@ -0,0 +1,146 @@
+package kopia
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/repo/maintenance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/fault"
+	storeTD "github.com/alcionai/corso/src/pkg/storage/testdata"
+)
+
+type StatusUnitSuite struct {
+	tester.Suite
+}
+
+func TestStatusUnitSuite(t *testing.T) {
+	suite.Run(t, &StatusUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *StatusUnitSuite) TestMaintenanceRuns() {
+	now := time.Now()
+
+	params := &maintenance.Params{
+		QuickCycle: maintenance.CycleParams{Interval: time.Hour},
+		FullCycle:  maintenance.CycleParams{Interval: 24 * time.Hour},
+	}
+
+	table := []struct {
+		name         string
+		sched        *maintenance.Schedule
+		expectQuick  time.Time
+		expectFull   time.Time
+		expectFailed []string
+	}{
+		{
+			name:         "never run",
+			sched:        &maintenance.Schedule{},
+			expectFailed: []string{},
+		},
+		{
+			name: "runs",
+			sched: &maintenance.Schedule{
+				NextQuickMaintenanceTime: now.Add(time.Hour),
+				NextFullMaintenanceTime:  now.Add(24 * time.Hour),
+				Runs: map[maintenance.TaskType][]maintenance.RunInfo{
+					maintenance.TaskCleanupLogs: {
+						{Success: false},
+						{Success: true},
+					},
+					maintenance.TaskIndexCompaction: {
+						{Success: true},
+						{Success: false},
+					},
+					maintenance.TaskDeleteOrphanedBlobsFull: {
+						{Success: false},
+					},
+				},
+			},
+			expectQuick: now,
+			expectFull:  now,
+			expectFailed: []string{
+				maintenance.TaskCleanupLogs,
+				maintenance.TaskDeleteOrphanedBlobsFull,
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			quick, full, failed := maintenanceRuns(test.sched, params)
+			assert.Equal(t, test.expectQuick, quick)
+			assert.Equal(t, test.expectFull, full)
+			assert.Equal(t, test.expectFailed, failed)
+		})
+	}
+}
+
+type StatusIntegrationSuite struct {
+	tester.Suite
+}
+
+func TestStatusIntegrationSuite(t *testing.T) {
+	suite.Run(t, &StatusIntegrationSuite{
+		Suite: tester.NewIntegrationSuite(
+			t,
+			[][]string{storeTD.AWSStorageCredEnvs}),
+	})
+}
+
+func (suite *StatusIntegrationSuite) TestRepoStatus() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	k, err := openLocalKopiaRepo(t, ctx)
+	require.NoError(t, err, clues.ToCore(err))
+
+	w := &Wrapper{k}
+
+	defer w.Close(ctx)
+
+	ms, err := NewModelStore(k)
+	require.NoError(t, err, clues.ToCore(err))
+
+	defer ms.Close(ctx)
+
+	errs := fault.New(false)
+
+	rs, err := w.RepoStatus(ctx, ms, errs)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Empty(t, errs.Alerts(), "default config shouldn't produce alerts")
+	assert.Equal(t, defaultCompressor, rs.Compressor)
+	assert.Positive(t, rs.FormatVersion)
+	assert.NotEmpty(t, rs.CurrentOwner)
+	assert.Empty(t, rs.RetentionMode)
+	assert.Zero(t, rs.OrphanedItems)
+	assert.True(t, rs.LastFullMaintenance.IsZero(), "full maintenance hasn't run")
+
+	err = w.RepoMaintenance(
+		ctx,
+		ms,
+		repository.Maintenance{
+			Type:   repository.MetadataMaintenance,
+			Safety: repository.FullMaintenanceSafety,
+			Force:  true,
+		},
+		fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+
+	rs, err = w.RepoStatus(ctx, ms, fault.New(false))
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, rs.CurrentOwner, rs.MaintenanceOwner)
+	assert.WithinDuration(t, time.Now(), rs.LastQuickMaintenance, time.Hour)
+	assert.True(t, rs.LastFullMaintenance.IsZero(), "full maintenance hasn't run")
+}
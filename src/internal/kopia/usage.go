@@ -0,0 +1,184 @@
+package kopia
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alcionai/clues"
+	"github.com/kopia/kopia/fs"
+	"github.com/kopia/kopia/repo"
+	"github.com/kopia/kopia/repo/content"
+	"github.com/kopia/kopia/repo/manifest"
+	"github.com/kopia/kopia/repo/object"
+	"github.com/kopia/kopia/snapshot"
+	"github.com/kopia/kopia/snapshot/snapshotfs"
+
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// SnapshotUsage describes the storage referenced by a single snapshot.
+type SnapshotUsage struct {
+	// LogicalBytes is the size of every file in the snapshot, before
+	// deduplication and compression.
+	LogicalBytes int64
+	// Contents holds the IDs of every content the snapshot references,
+	// including the contents that store its directories.
+	Contents []string
+}
+
+// StorageUsage describes the storage consumed by the repository, along
+// with the contents referenced by a set of snapshots.
+type StorageUsage struct {
+	// TotalBytes is the stored size of every content in the repository,
+	// after deduplication, compression, and encryption.
+	TotalBytes int64
+	// ContentCount is the number of contents in the repository.
+	ContentCount int
+	// ContentBytes holds the stored size of each content referenced by
+	// the snapshots.
+	ContentBytes map[string]int64
+	// Snapshots holds the usage of each snapshot, keyed by snapshot ID.
+	// Snapshots that no longer exist are left out.
+	Snapshots map[string]SnapshotUsage
+}
+
+// StorageUsage walks the given snapshots and the repository's content
+// index to produce the storage used by each snapshot.
+func (w Wrapper) StorageUsage(
+	ctx context.Context,
+	snapshotIDs ...string,
+) (*StorageUsage, error) {
+	if w.c == nil {
+		return nil, clues.StackWC(ctx, errNotConnected)
+	}
+
+	su := &StorageUsage{
+		ContentBytes: map[string]int64{},
+		Snapshots:    map[string]SnapshotUsage{},
+	}
+
+	if dr, ok := w.c.Repository.(repo.DirectRepository); ok {
+		err := dr.ContentReader().IterateContents(
+			ctx,
+			content.IterateOptions{},
+			func(ci content.Info) error {
+				su.TotalBytes += int64(ci.GetPackedLength())
+				su.ContentCount++
+
+				return nil
+			})
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "iterating repository contents")
+		}
+	}
+
+	for _, id := range snapshotIDs {
+		if len(id) == 0 {
+			continue
+		}
+
+		if _, ok := su.Snapshots[id]; ok {
+			continue
+		}
+
+		ictx := clues.Add(ctx, "snapshot_id", id)
+
+		snapUsage, err := w.snapshotUsage(ictx, id, su.ContentBytes)
+		if errors.Is(err, snapshot.ErrSnapshotNotFound) {
+			logger.Ctx(ictx).Info("skipping usage of missing snapshot")
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		su.Snapshots[id] = snapUsage
+	}
+
+	return su, nil
+}
+
+// snapshotUsage walks every entry in the snapshot, adding the stored size
+// of each newly seen content to contentBytes.
+func (w Wrapper) snapshotUsage(
+	ctx context.Context,
+	snapshotID string,
+	contentBytes map[string]int64,
+) (SnapshotUsage, error) {
+	var (
+		res  SnapshotUsage
+		seen = map[string]struct{}{}
+	)
+
+	man, err := snapshot.LoadSnapshot(ctx, w.c, manifest.ID(snapshotID))
+	if err != nil {
+		return res, clues.WrapWC(ctx, err, "getting snapshot handle")
+	}
+
+	root, err := snapshotfs.SnapshotRoot(w.c, man)
+	if err != nil {
+		return res, clues.WrapWC(ctx, err, "getting root directory")
+	}
+
+	var walk func(context.Context, fs.Entry) error
+
+	walk = func(ctx context.Context, e fs.Entry) error {
+		if oe, ok := e.(object.HasObjectID); ok {
+			cids, err := w.c.VerifyObject(ctx, oe.ObjectID())
+			if err != nil {
+				return clues.WrapWC(ctx, err, "listing object contents").
+					With("entry_name", e.Name())
+			}
+
+			for _, cid := range cids {
+				if err := w.addContent(ctx, cid, seen, contentBytes); err != nil {
+					return err
+				}
+			}
+		}
+
+		d, ok := e.(fs.Directory)
+		if !ok {
+			res.LogicalBytes += e.Size()
+			return nil
+		}
+
+		return fs.IterateEntries(ctx, d, walk)
+	}
+
+	if err := walk(ctx, root); err != nil {
+		return res, clues.Stack(err)
+	}
+
+	for cid := range seen {
+		res.Contents = append(res.Contents, cid)
+	}
+
+	return res, nil
+}
+
+// addContent records the content as referenced by the snapshot, looking up
+// its stored size the first time any snapshot references it.
+func (w Wrapper) addContent(
+	ctx context.Context,
+	cid content.ID,
+	seen map[string]struct{},
+	contentBytes map[string]int64,
+) error {
+	id := cid.String()
+	seen[id] = struct{}{}
+
+	if _, ok := contentBytes[id]; ok {
+		return nil
+	}
+
+	ci, err := w.c.ContentInfo(ctx, cid)
+	if err != nil {
+		return clues.WrapWC(ctx, err, "getting content info").With("content_id", id)
+	}
+
+	contentBytes[id] = int64(ci.GetPackedLength())
+
+	return nil
+}
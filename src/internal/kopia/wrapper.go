@@ -36,6 +36,10 @@ import (
 
 const defaultCorsoPin = "corso"
 
+// defaultCleanupBuffer is how old incomplete backups must be before
+// maintenance cleans them up.
+const defaultCleanupBuffer = time.Hour * 24 * 7
+
 // common manifest tags
 const (
 	TagBackupID       = "backup-id"
@@ -693,7 +697,7 @@ func (w Wrapper) RepoMaintenance(
 	// Check if we should do additional cleanup prior to running kopia's
 	// maintenance.
 	if opts.Type == repository.CompleteMaintenance {
-		buffer := time.Hour * 24 * 7
+		buffer := defaultCleanupBuffer
 		if opts.CleanupBuffer != nil {
 			buffer = *opts.CleanupBuffer
 		}
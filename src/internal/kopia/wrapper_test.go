@@ -2014,6 +2014,39 @@ func (suite *KopiaSimpleRepoIntegrationSuite) TestBackupExcludeItem() {
 	}
 }
 
+func (suite *KopiaSimpleRepoIntegrationSuite) TestStorageUsage() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var dataBytes int64
+
+	for _, f := range suite.filesByPath {
+		dataBytes += int64(len(f.data))
+	}
+
+	su, err := suite.w.StorageUsage(ctx, string(suite.snapshotID), "missing-snapshot-id")
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Positive(t, su.TotalBytes)
+	assert.Positive(t, su.ContentCount)
+	require.Len(t, su.Snapshots, 1, "missing snapshots are skipped")
+
+	snap := su.Snapshots[string(suite.snapshotID)]
+	assert.GreaterOrEqual(t, snap.LogicalBytes, dataBytes)
+	assert.NotEmpty(t, snap.Contents)
+
+	var referenced int64
+
+	for _, cid := range snap.Contents {
+		require.Contains(t, su.ContentBytes, cid)
+		referenced += su.ContentBytes[cid]
+	}
+
+	assert.LessOrEqual(t, referenced, su.TotalBytes)
+}
+
 func (suite *KopiaSimpleRepoIntegrationSuite) TestProduceRestoreCollections() {
 	doesntExist, err := path.Build(
 		testTenant,
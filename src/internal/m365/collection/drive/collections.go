@@ -23,6 +23,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/filters"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph/metadata"
@@ -52,6 +53,10 @@ type Collections struct {
 
 	ctrl control.Options
 
+	// exclusions are the selector's rules for files that should be
+	// left out of the backup.  May be nil.
+	exclusions *selectors.DriveExclusions
+
 	// collectionMap allows lookup of the data.BackupCollection
 	// for a OneDrive folder.
 	// driveID -> itemID -> collection
@@ -71,6 +76,7 @@ func NewCollections(
 	protectedResource idname.Provider,
 	statusUpdater support.StatusUpdater,
 	ctrlOpts control.Options,
+	exclusions *selectors.DriveExclusions,
 	counter *count.Bus,
 ) *Collections {
 	return &Collections{
@@ -80,6 +86,7 @@ func NewCollections(
 		CollectionMap:     map[string]map[string]*Collection{},
 		statusUpdater:     statusUpdater,
 		ctrl:              ctrlOpts,
+		exclusions:        exclusions,
 		counter:           counter,
 	}
 }
@@ -1114,6 +1121,34 @@ func (c *Collections) processItem(
 			return clues.NewWC(ctx, "file without parent ID").Label(count.MissingParent)
 		}
 
+		var folders []string
+
+		if pb, err := path.GetDriveFolderPath(collectionPath); err == nil {
+			folders = pb.Elements()
+		}
+
+		if skip := c.excludedFile(ctx, driveID, folders, item, counter); skip != nil {
+			skipper.AddSkip(ctx, skip)
+
+			// The file may have been added earlier in this enumeration
+			// (ex: it was moved into an excluded folder), or in a prior
+			// backup.  Either way, it shouldn't remain in this backup.
+			if prevParentID, ok := currPrevPaths[itemID]; ok {
+				if prevColl, found := c.CollectionMap[driveID][prevParentID]; found {
+					prevColl.Remove(itemID)
+				}
+
+				delete(currPrevPaths, itemID)
+			}
+
+			if !invalidPrevDelta {
+				excludedItemIDs[itemID+metadata.DataFileSuffix] = struct{}{}
+				excludedItemIDs[itemID+metadata.MetaFileSuffix] = struct{}{}
+			}
+
+			return nil
+		}
+
 		// Get the collection for this item.
 		parentID := ptr.Val(item.GetParentReference().GetId())
 		ctx = clues.Add(ctx, "parent_id", parentID)
@@ -1189,6 +1224,36 @@ func (c *Collections) processItem(
 	return nil
 }
 
+// excludedFile compares the file against the selector's exclusion rules.
+// If the file is excluded, returns the skip that records the exclusion.
+// folders holds the names of the folders between the drive root and the
+// file.
+func (c *Collections) excludedFile(
+	ctx context.Context,
+	driveID string,
+	folders []string,
+	file *custom.DriveItem,
+	counter *count.Bus,
+) *fault.Skipped {
+	var (
+		fileID   = ptr.Val(file.GetId())
+		fileName = ptr.Val(file.GetName())
+	)
+
+	rule, excluded := c.exclusions.Excludes(folders, fileName, ptr.Val(file.GetSize()))
+	if !excluded {
+		return nil
+	}
+
+	addtl := graph.ItemInfo(file)
+	addtl[fault.AddtlExclusionRule] = rule
+
+	logger.Ctx(ctx).Debugw("file excluded from backup", "exclusion_rule", rule)
+	counter.Inc(count.ExcludedItems)
+
+	return fault.FileSkip(fault.SkipExcludedByRule, driveID, fileID, fileName, addtl)
+}
+
 type dirScopeChecker interface {
 	IsAllPass() bool
 	IncludesDir(dir string) bool
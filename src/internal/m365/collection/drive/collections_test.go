@@ -885,6 +885,7 @@ func (suite *CollectionsUnitSuite) TestPopulateDriveCollections() {
 				idname.NewProvider(user, user),
 				nil,
 				control.Options{ToggleFeatures: control.Toggles{}},
+				nil,
 				count.New())
 
 			c.CollectionMap[drive.id] = map[string]*Collection{}
@@ -2637,6 +2638,7 @@ func (suite *CollectionsUnitSuite) TestGet() {
 				control.Options{ToggleFeatures: control.Toggles{
 					UseOldDeltaProcess: true,
 				}},
+				nil,
 				count.New())
 
 			prevDelta := "prev-delta"
@@ -2803,6 +2805,7 @@ func (suite *CollectionsUnitSuite) TestAddURLCacheToDriveCollections() {
 				idname.NewProvider(user, user),
 				func(*support.ControllerOperationStatus) {},
 				control.Options{ToggleFeatures: control.Toggles{}},
+				nil,
 				count.New())
 
 			errs := fault.New(true)
@@ -631,6 +631,21 @@ func (c *Collections) addFileToTree(
 	alreadySeen := tree.hasFile(fileID)
 	parentNode, parentNotNil := tree.folderIDToNode[parentID]
 
+	if c.exclusions != nil {
+		var folders []string
+
+		if parentNotNil {
+			folders = parentNode.folderNames()
+		}
+
+		if skip := c.excludedFile(ctx, driveID, folders, file, counter); skip != nil {
+			// treating the file as deleted drops any version that was
+			// added earlier in the enumeration, or in a prior backup.
+			tree.deleteFile(fileID)
+			return skip, nil
+		}
+	}
+
 	if parentNotNil && !alreadySeen {
 		countSize := tree.countLiveFilesAndSizes()
 
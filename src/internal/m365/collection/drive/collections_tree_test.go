@@ -19,6 +19,7 @@ import (
 	countTD "github.com/alcionai/corso/src/pkg/count/testdata"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
 	"github.com/alcionai/corso/src/pkg/services/m365/custom"
 )
@@ -2819,3 +2820,107 @@ func (suite *CollectionsTreeUnitSuite) TestCollections_AddFileToTree() {
 		})
 	}
 }
+
+func (suite *CollectionsTreeUnitSuite) TestCollections_AddFileToTree_exclusions() {
+	d := drive()
+
+	table := []struct {
+		name                          string
+		tree                          func(t *testing.T, d *deltaDrive) *folderyMcFolderFace
+		file                          models.DriveItemable
+		exclusions                    selectors.DriveExclusions
+		expectSkipped                 assert.ValueAssertionFunc
+		expectExcluded                int64
+		treeContainsFileIDsWithParent map[string]string
+		expectDeleted                 bool
+	}{
+		{
+			name:          "no matching rule",
+			tree:          treeWithRoot,
+			file:          d.fileAt(root),
+			exclusions:    selectors.DriveExclusions{Extensions: []string{"iso"}},
+			expectSkipped: assert.Nil,
+			treeContainsFileIDsWithParent: map[string]string{
+				fileID(): rootID,
+			},
+		},
+		{
+			name:                          "name glob",
+			tree:                          treeWithRoot,
+			file:                          d.fileAt(root),
+			exclusions:                    selectors.DriveExclusions{NameGlobs: []string{"N_*"}},
+			expectSkipped:                 assert.NotNil,
+			expectExcluded:                1,
+			treeContainsFileIDsWithParent: map[string]string{},
+			expectDeleted:                 true,
+		},
+		{
+			name:                          "max size",
+			tree:                          treeWithRoot,
+			file:                          d.fileAt(root),
+			exclusions:                    selectors.DriveExclusions{MaxSize: defaultFileSize - 1},
+			expectSkipped:                 assert.NotNil,
+			expectExcluded:                1,
+			treeContainsFileIDsWithParent: map[string]string{},
+			expectDeleted:                 true,
+		},
+		{
+			name:                          "path glob on parent folder",
+			tree:                          treeWithFolders,
+			file:                          d.fileAt(folder),
+			exclusions:                    selectors.DriveExclusions{PathGlobs: []string{name(folder, "parent")}},
+			expectSkipped:                 assert.NotNil,
+			expectExcluded:                1,
+			treeContainsFileIDsWithParent: map[string]string{},
+			expectDeleted:                 true,
+		},
+		{
+			name:                          "previously added file becomes excluded",
+			tree:                          treeWithFileInFolder,
+			file:                          d.fileAt(folder),
+			exclusions:                    selectors.DriveExclusions{PathGlobs: []string{"*/" + folderName()}},
+			expectSkipped:                 assert.NotNil,
+			expectExcluded:                1,
+			treeContainsFileIDsWithParent: map[string]string{},
+			expectDeleted:                 true,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				c       = collWithMBH(defaultOneDriveBH(user))
+				counter = count.New()
+				tree    = test.tree(t, d)
+			)
+
+			c.exclusions = &test.exclusions
+
+			skipped, err := c.addFileToTree(
+				ctx,
+				tree,
+				d.able,
+				custom.ToCustomDriveItem(test.file),
+				newPagerLimiter(control.DefaultOptions()),
+				counter)
+			require.NoError(t, err, clues.ToCore(err))
+
+			test.expectSkipped(t, skipped)
+
+			if skipped != nil {
+				assert.True(t, skipped.HasCause(fault.SkipExcludedByRule), "skip cause")
+				assert.NotEmpty(t, skipped.Item.Additional[fault.AddtlExclusionRule], "exclusion rule")
+			}
+
+			assert.Equal(t, test.treeContainsFileIDsWithParent, tree.fileIDToParentID)
+			assert.Equal(t, test.expectExcluded, counter.Get(count.ExcludedItems))
+
+			_, deleted := tree.deletedFileIDs[fileID()]
+			assert.Equal(t, test.expectDeleted, deleted, "file marked as deleted")
+		})
+	}
+}
@@ -113,6 +113,18 @@ func newNodeyMcNodeFace(
 // folder handling
 // ---------------------------------------------------------------------------
 
+// folderNames returns the names of each folder between the drive root
+// and this node, including the node itself.  The root is not included.
+func (nodey *nodeyMcNodeFace) folderNames() []string {
+	names := []string{}
+
+	for n := nodey; n != nil && n.folder != nil && n.folder.GetRoot() == nil; n = n.parent {
+		names = append([]string{ptr.Val(n.folder.GetName())}, names...)
+	}
+
+	return names
+}
+
 // containsFolder returns true if the given folder id is present as either
 // a live node or a tombstone.
 func (face *folderyMcFolderFace) containsFolder(id string) bool {
@@ -65,10 +65,11 @@ func streamItems(
 			body := metrics.ReaderWithStats(item.ToReader(), path.FilesCategory, stats)
 
 			ch <- export.Item{
-				ID:    itemUUID,
-				Name:  name,
-				Body:  body,
-				Error: err,
+				ID:      itemUUID,
+				Name:    name,
+				Body:    body,
+				ModTime: export.ModTimeOf(item),
+				Error:   err,
 			}
 		}
 
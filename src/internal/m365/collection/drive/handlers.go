@@ -108,6 +108,7 @@ type RestoreHandler interface {
 	NewItemContentUploader
 	PostDriver
 	PostItemInContainerer
+	PatchItemer
 	DeleteItemPermissioner
 	UpdateItemPermissioner
 	UpdateItemLinkSharer
@@ -181,6 +182,14 @@ type PostItemInContainerer interface {
 	) (models.DriveItemable, error)
 }
 
+type PatchItemer interface {
+	PatchItem(
+		ctx context.Context,
+		driveID, itemID string,
+		item models.DriveItemable,
+	) error
+}
+
 type GetFolderByNamer interface {
 	GetFolderByName(
 		ctx context.Context,
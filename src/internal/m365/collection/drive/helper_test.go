@@ -162,6 +162,7 @@ func collWithMBH(mbh BackupHandler) *Collections {
 		idname.NewProvider(user, user),
 		func(*support.ControllerOperationStatus) {},
 		control.Options{ToggleFeatures: control.Toggles{}},
+		nil,
 		count.New())
 }
 
@@ -175,6 +176,7 @@ func collWithMBHAndOpts(
 		idname.NewProvider(user, user),
 		func(*support.ControllerOperationStatus) {},
 		opts,
+		nil,
 		count.New())
 }
 
@@ -1257,6 +1259,10 @@ type mockRestoreHandler struct {
 	PostItemResp   models.DriveItemable
 	PostItemErr    error
 
+	CalledPatchItem bool
+	PatchItemReq    models.DriveItemable
+	PatchItemErr    error
+
 	DrivePagerV pagers.NonDeltaHandler[models.Driveable]
 
 	PostDriveResp models.Driveable
@@ -1343,6 +1349,17 @@ func (h *mockRestoreHandler) PostItemInContainer(
 	return h.PostItemResp, h.PostItemErr
 }
 
+func (h *mockRestoreHandler) PatchItem(
+	_ context.Context,
+	_, _ string,
+	item models.DriveItemable,
+) error {
+	h.CalledPatchItem = true
+	h.PatchItemReq = item
+
+	return h.PatchItemErr
+}
+
 func (h *mockRestoreHandler) GetFolderByName(
 	context.Context,
 	string, string, string,
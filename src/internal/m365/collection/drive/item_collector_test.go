@@ -291,6 +291,7 @@ func (suite *OneDriveIntgSuite) TestOneDriveNewCollections() {
 				control.Options{
 					ToggleFeatures: control.Toggles{},
 				},
+				nil,
 				count.New())
 
 			ssmb := prefixmatcher.NewStringSetBuilder()
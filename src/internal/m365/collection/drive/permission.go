@@ -2,16 +2,20 @@ package drive
 
 import (
 	"context"
+	"strings"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/drives"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
+	"github.com/alcionai/corso/src/internal/common/idname"
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/common/syncd"
 	"github.com/alcionai/corso/src/internal/data"
 	odmetadata "github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
@@ -456,11 +460,247 @@ func UpdateLinkShares(
 	return alreadyDeleted, nil
 }
 
+// principal identifies the user or group that receives a grant.
+type principal struct {
+	entityType odmetadata.GV2Type
+	id         string
+	email      string
+}
+
+// translatePrincipal maps an entity onto the principal that replaces it in
+// the restore target.  Entities without a mapping that aren't available in
+// the restore target are given to the fallback principal, if one exists.
+// Returns false if the entity isn't mapped, and true if the fallback was
+// used.
+func translatePrincipal(
+	principals control.PrincipalMap,
+	availableEntities ResourceIDNames,
+	p principal,
+) (principal, bool, bool) {
+	target, ok := principals.Lookup(p.id, p.email)
+	if ok {
+		return resolvePrincipal(availableEntities, p, target), true, false
+	}
+
+	if len(p.id) == 0 || entityAvailable(availableEntities, p.entityType, p.id) {
+		return p, false, false
+	}
+
+	target, ok = principals.Fallback()
+	if !ok {
+		return p, false, false
+	}
+
+	return resolvePrincipal(availableEntities, p, target), true, true
+}
+
+// resolvePrincipal looks up the target in the available entities.  Targets
+// are resolved as the same type of entity where possible, but users can be
+// mapped to groups and vice versa.  Targets that can't be resolved keep
+// their mapped value as the ID, so that the availability filters drop the
+// grant instead of handing access to some other principal.  External users,
+// who have no ID, remain external when mapped to an email.
+func resolvePrincipal(
+	availableEntities ResourceIDNames,
+	p principal,
+	target string,
+) principal {
+	resolved := principal{
+		entityType: p.entityType,
+		id:         target,
+	}
+
+	if strings.Contains(target, "@") {
+		resolved.email = target
+	}
+
+	entityTypes := []odmetadata.GV2Type{p.entityType}
+
+	switch p.entityType {
+	case odmetadata.GV2User:
+		entityTypes = append(entityTypes, odmetadata.GV2Group)
+	case odmetadata.GV2Group:
+		entityTypes = append(entityTypes, odmetadata.GV2User)
+	}
+
+	for _, et := range entityTypes {
+		entities := availableEntitiesOfType(availableEntities, et)
+		if entities == nil {
+			continue
+		}
+
+		if _, ok := entities.NameOf(target); ok {
+			resolved.entityType = et
+			return resolved
+		}
+
+		if id, ok := idOfName(entities, target); ok {
+			resolved.entityType, resolved.id = et, id
+			return resolved
+		}
+	}
+
+	// external users are only identified by email, and remain external.
+	if len(p.id) == 0 && len(resolved.email) > 0 {
+		resolved.id = ""
+	}
+
+	return resolved
+}
+
+func availableEntitiesOfType(
+	availableEntities ResourceIDNames,
+	entityType odmetadata.GV2Type,
+) idname.Cacher {
+	switch entityType {
+	case odmetadata.GV2User:
+		return availableEntities.Users
+	case odmetadata.GV2Group:
+		return availableEntities.Groups
+	}
+
+	return nil
+}
+
+// entityAvailable reports whether the entity exists in the restore target.
+// We only know about users and groups, so all other entities, and all
+// entities in unpopulated caches, are considered available.
+func entityAvailable(
+	availableEntities ResourceIDNames,
+	entityType odmetadata.GV2Type,
+	id string,
+) bool {
+	if availableEntities.Users == nil || availableEntities.Groups == nil {
+		return true
+	}
+
+	entities := availableEntitiesOfType(availableEntities, entityType)
+	if entities == nil {
+		return true
+	}
+
+	_, ok := entities.NameOf(id)
+
+	return ok
+}
+
+// idOfName looks up the ID of the named entity.  Group names keep their
+// casing in the cache, so a failed lookup falls back to a case insensitive
+// match.
+func idOfName(entities idname.Cacher, name string) (string, bool) {
+	if id, ok := entities.IDOf(name); ok {
+		return id, true
+	}
+
+	for _, id := range entities.IDs() {
+		if n, _ := entities.NameOf(id); strings.EqualFold(n, name) {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// countTranslation tallies and logs the grant that was translated.
+func countTranslation(
+	ctx context.Context,
+	from, to principal,
+	fallback bool,
+	ctr *count.Bus,
+) {
+	ctr.Inc(count.PermissionRemapped)
+
+	if fallback {
+		ctr.Inc(count.PermissionFallback)
+	}
+
+	logger.Ctx(ctx).
+		With(
+			"from_entity_type", from.entityType,
+			"from_entity_id", clues.Hide(from.id),
+			"from_entity_email", clues.Hide(from.email),
+			"to_entity_type", to.entityType,
+			"to_entity_id", clues.Hide(to.id),
+			"to_entity_email", clues.Hide(to.email),
+			"fallback", fallback).
+		Info("remapped permission grant")
+}
+
+// translateLinkSharePrincipals replaces the entities of the link shares
+// with their counterparts in the principal map.  The link shares are
+// copied, not modified.
+func translateLinkSharePrincipals(
+	ctx context.Context,
+	linkShares []odmetadata.LinkShare,
+	principals control.PrincipalMap,
+	availableEntities ResourceIDNames,
+	ctr *count.Bus,
+) []odmetadata.LinkShare {
+	if len(principals) == 0 {
+		return linkShares
+	}
+
+	translated := make([]odmetadata.LinkShare, 0, len(linkShares))
+
+	for _, ls := range linkShares {
+		entities := make([]odmetadata.Entity, 0, len(ls.Entities))
+
+		for _, e := range ls.Entities {
+			from := principal{e.EntityType, e.ID, e.Email}
+
+			to, ok, fallback := translatePrincipal(principals, availableEntities, from)
+			if ok {
+				e.EntityType, e.ID, e.Email = to.entityType, to.id, to.email
+				countTranslation(ctx, from, to, fallback, ctr)
+			}
+
+			entities = append(entities, e)
+		}
+
+		ls.Entities = entities
+		translated = append(translated, ls)
+	}
+
+	return translated
+}
+
+// translatePermissionPrincipals replaces the entity of each permission
+// with its counterpart in the principal map.  The permissions are copied,
+// not modified.
+func translatePermissionPrincipals(
+	ctx context.Context,
+	perms []odmetadata.Permission,
+	principals control.PrincipalMap,
+	availableEntities ResourceIDNames,
+	ctr *count.Bus,
+) []odmetadata.Permission {
+	if len(principals) == 0 {
+		return perms
+	}
+
+	translated := make([]odmetadata.Permission, 0, len(perms))
+
+	for _, p := range perms {
+		from := principal{p.EntityType, p.EntityID, p.Email}
+
+		to, ok, fallback := translatePrincipal(principals, availableEntities, from)
+		if ok {
+			p.EntityType, p.EntityID, p.Email = to.entityType, to.id, to.email
+			countTranslation(ctx, from, to, fallback, ctr)
+		}
+
+		translated = append(translated, p)
+	}
+
+	return translated
+}
+
 func filterUnavailableEntitiesInLinkShare(
 	ctx context.Context,
 	linkShares []odmetadata.LinkShare,
 	availableEntities ResourceIDNames,
 	oldLinkShareIDToNewID syncd.MapTo[string],
+	ctr *count.Bus,
 ) []odmetadata.LinkShare {
 	filtered := []odmetadata.LinkShare{}
 
@@ -493,7 +733,16 @@ func filterUnavailableEntitiesInLinkShare(
 
 			if available {
 				entities = append(entities, e)
+				continue
 			}
+
+			ctr.Inc(count.PermissionDropped)
+			logger.Ctx(ctx).
+				With(
+					"link_share_id", p.ID,
+					"entity_type", e.EntityType,
+					"entity_id", clues.Hide(e.ID)).
+				Info("dropped link share recipient unavailable in restore target")
 		}
 
 		if len(entities) > 0 {
@@ -517,6 +766,7 @@ func filterUnavailableEntitiesInPermissions(
 	perms []odmetadata.Permission,
 	availableEntities ResourceIDNames,
 	oldPermIDToNewID syncd.MapTo[string],
+	ctr *count.Bus,
 ) []odmetadata.Permission {
 	if availableEntities.Users == nil || availableEntities.Groups == nil {
 		// This should not be happening unless we missed to fill in the caches
@@ -547,6 +797,15 @@ func filterUnavailableEntitiesInPermissions(
 			continue
 		}
 
+		ctr.Inc(count.PermissionDropped)
+		logger.Ctx(ctx).
+			With(
+				"permission_id", p.ID,
+				"entity_type", p.EntityType,
+				"entity_id", clues.Hide(p.EntityID),
+				"entity_email", clues.Hide(p.Email)).
+			Info("dropped permission unavailable in restore target")
+
 		// If we have no entities, we can't restore the permission
 		// and so we have to mark it as not restored.
 		oldPermIDToNewID.Store(p.ID, "")
@@ -555,6 +814,42 @@ func filterUnavailableEntitiesInPermissions(
 	return filtered
 }
 
+// permissionsToRestore produces the permissions to add to, and remove
+// from, an item.  Added permissions are translated to the principals of
+// the restore target, and those whose principals aren't available in the
+// target get dropped.
+func permissionsToRestore(
+	ctx context.Context,
+	previous, current odmetadata.Metadata,
+	didReset bool,
+	caches *restoreCaches,
+	ctr *count.Bus,
+) ([]odmetadata.Permission, []odmetadata.Permission) {
+	// principals are translated after diffing, since the previous metadata
+	// still refers to the principals of the backup.
+	permAdded, permRemoved := odmetadata.DiffPermissions(previous.Permissions, current.Permissions)
+
+	if didReset {
+		// In case we did a reset of permissions when restoring link
+		// shares, we have to make sure to restore all the permissions
+		// that an item has as they too will be removed.
+		logger.Ctx(ctx).Debug("link share creation reset all inherited permissions")
+
+		permAdded = current.Permissions
+		permRemoved = []odmetadata.Permission{}
+	}
+
+	permAdded = translatePermissionPrincipals(ctx, permAdded, caches.Principals, caches.AvailableEntities, ctr)
+	permAdded = filterUnavailableEntitiesInPermissions(
+		ctx,
+		permAdded,
+		caches.AvailableEntities,
+		caches.OldPermIDToNewID,
+		ctr)
+
+	return permAdded, permRemoved
+}
+
 // RestorePermissions takes in the permissions of an item, computes
 // what permissions need to added and removed based on the parent
 // folder metas and uses that to add/remove the necessary permissions
@@ -567,6 +862,7 @@ func RestorePermissions(
 	itemPath path.Path,
 	current odmetadata.Metadata,
 	caches *restoreCaches,
+	ctr *count.Bus,
 	errs *fault.Bus,
 ) {
 	if current.SharingMode == odmetadata.SharingModeInherited {
@@ -584,7 +880,13 @@ func RestorePermissions(
 
 	if previousLinkShares != nil {
 		lsAdded, lsRemoved := odmetadata.DiffLinkShares(previousLinkShares, current.LinkShares)
-		lsAdded = filterUnavailableEntitiesInLinkShare(ctx, lsAdded, caches.AvailableEntities, caches.OldLinkShareIDToNewID)
+		lsAdded = translateLinkSharePrincipals(ctx, lsAdded, caches.Principals, caches.AvailableEntities, ctr)
+		lsAdded = filterUnavailableEntitiesInLinkShare(
+			ctx,
+			lsAdded,
+			caches.AvailableEntities,
+			caches.OldLinkShareIDToNewID,
+			ctr)
 
 		// Link shares have to be updated before permissions as we have to
 		// use the information about if we had to reset the inheritance to
@@ -609,18 +911,7 @@ func RestorePermissions(
 		return
 	}
 
-	permAdded, permRemoved := odmetadata.DiffPermissions(previous.Permissions, current.Permissions)
-	permAdded = filterUnavailableEntitiesInPermissions(ctx, permAdded, caches.AvailableEntities, caches.OldPermIDToNewID)
-
-	if didReset {
-		// In case we did a reset of permissions when restoring link
-		// shares, we have to make sure to restore all the permissions
-		// that an item has as they too will be removed.
-		logger.Ctx(ctx).Debug("link share creation reset all inherited permissions")
-
-		permRemoved = []odmetadata.Permission{}
-		permAdded = current.Permissions
-	}
+	permAdded, permRemoved := permissionsToRestore(ctx, previous, current, didReset, caches, ctr)
 
 	err = UpdatePermissions(
 		ctx,
@@ -18,6 +18,8 @@ import (
 	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
 	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/path"
 	graphTD "github.com/alcionai/corso/src/pkg/services/m365/api/graph/testdata"
@@ -458,10 +460,20 @@ func (suite *PermissionsUnitTestSuite) TestFilterUnavailableEntitiesInPermission
 			ctx, flush := tester.NewContext(t)
 			defer flush()
 
-			oldToNew := syncd.NewMapTo[string]()
-			filtered := filterUnavailableEntitiesInPermissions(ctx, test.permissions, test.availableEntities, oldToNew)
+			var (
+				oldToNew = syncd.NewMapTo[string]()
+				ctr      = count.New()
+			)
+
+			filtered := filterUnavailableEntitiesInPermissions(
+				ctx,
+				test.permissions,
+				test.availableEntities,
+				oldToNew,
+				ctr)
 
 			assert.Equal(t, test.expected, filtered, "filtered permissions")
+			assert.Equal(t, int64(len(test.skippedPermissions)), ctr.Get(count.PermissionDropped), "dropped count")
 
 			for _, id := range test.skippedPermissions {
 				_, ok := oldToNew.Load(id)
@@ -471,6 +483,68 @@ func (suite *PermissionsUnitTestSuite) TestFilterUnavailableEntitiesInPermission
 	}
 }
 
+func (suite *PermissionsUnitTestSuite) TestPermissionsToRestore() {
+	var (
+		kept     = metadata.Permission{ID: "p1", EntityID: "e1", EntityType: metadata.GV2User, Roles: []string{"read"}}
+		dropped  = metadata.Permission{ID: "p2", EntityID: "e2", EntityType: metadata.GV2User, Roles: []string{"read"}}
+		added    = metadata.Permission{ID: "p3", EntityID: "e1", EntityType: metadata.GV2User, Roles: []string{"write"}}
+		missing  = metadata.Permission{ID: "p4", EntityID: "e3", EntityType: metadata.GV2User, Roles: []string{"write"}}
+		previous = metadata.Metadata{Permissions: []metadata.Permission{kept, dropped}}
+		current  = metadata.Metadata{Permissions: []metadata.Permission{kept, dropped, added, missing}}
+	)
+
+	table := []struct {
+		name          string
+		didReset      bool
+		expectAdded   []metadata.Permission
+		expectDropped []string
+	}{
+		{
+			name:          "diffed against the parent",
+			expectAdded:   []metadata.Permission{added},
+			expectDropped: []string{"p4"},
+		},
+		{
+			// resetting inheritance drops every inherited permission, so
+			// all the current permissions are restored, and those that
+			// are unavailable get dropped the same as when diffing.
+			name:          "inheritance reset",
+			didReset:      true,
+			expectAdded:   []metadata.Permission{kept, added},
+			expectDropped: []string{"p2", "p4"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				ctr    = count.New()
+				caches = NewRestoreCaches(nil)
+			)
+
+			caches.AvailableEntities = ResourceIDNames{
+				Users:  idname.NewCache(map[string]string{"e1": "e1"}),
+				Groups: idname.NewCache(map[string]string{}),
+			}
+
+			permAdded, permRemoved := permissionsToRestore(ctx, previous, current, test.didReset, caches, ctr)
+
+			assert.Equal(t, test.expectAdded, permAdded, "added permissions")
+			assert.Empty(t, permRemoved, "removed permissions")
+			assert.Equal(t, int64(len(test.expectDropped)), ctr.Get(count.PermissionDropped), "dropped count")
+
+			for _, id := range test.expectDropped {
+				_, ok := caches.OldPermIDToNewID.Load(id)
+				assert.True(t, ok, fmt.Sprintf("dropped id %s", id))
+			}
+		})
+	}
+}
+
 type eidtype struct {
 	id    string
 	etype metadata.GV2Type
@@ -573,7 +647,12 @@ func (suite *PermissionsUnitTestSuite) TestFilterUnavailableEntitiesInLinkShare(
 			defer flush()
 
 			oldToNew := syncd.NewMapTo[string]()
-			filtered := filterUnavailableEntitiesInLinkShare(ctx, test.linkShares, test.availableEntities, oldToNew)
+			filtered := filterUnavailableEntitiesInLinkShare(
+				ctx,
+				test.linkShares,
+				test.availableEntities,
+				oldToNew,
+				count.New())
 
 			assert.Equal(t, test.expected, filtered, "filtered link shares")
 
@@ -584,3 +663,212 @@ func (suite *PermissionsUnitTestSuite) TestFilterUnavailableEntitiesInLinkShare(
 		})
 	}
 }
+
+func (suite *PermissionsUnitTestSuite) TestTranslatePermissionPrincipals() {
+	var (
+		available = ResourceIDNames{
+			Users:  idname.NewCache(map[string]string{"tu1": "alice@target.com", "tu2": "bob@target.com"}),
+			Groups: idname.NewCache(map[string]string{"tg1": "Finance"}),
+		}
+		principals = control.NewPrincipalMap(map[string]string{
+			"su1":             "alice@target.com",
+			"bob@source.com":  "tu2",
+			"sg1":             "finance",
+			"su3":             "carol@target.com",
+			"dave@source.com": "dave@target.com",
+			"su5":             "finance",
+		})
+		withFallback = control.NewPrincipalMap(map[string]string{
+			"su1": "alice@target.com",
+			"*":   "Finance",
+		})
+	)
+
+	table := []struct {
+		name           string
+		principals     control.PrincipalMap
+		permissions    []metadata.Permission
+		expected       []metadata.Permission
+		expectRemapped int64
+		expectFallback int64
+	}{
+		{
+			name: "no principal map",
+			permissions: []metadata.Permission{
+				{ID: "p1", EntityID: "su1", EntityType: metadata.GV2User},
+			},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "su1", EntityType: metadata.GV2User},
+			},
+		},
+		{
+			name:       "mapped by id to a target name",
+			principals: principals,
+			permissions: []metadata.Permission{
+				{ID: "p1", EntityID: "su1", EntityType: metadata.GV2User},
+			},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "tu1", Email: "alice@target.com", EntityType: metadata.GV2User},
+			},
+			expectRemapped: 1,
+		},
+		{
+			name:       "mapped by email to a target id",
+			principals: principals,
+			permissions: []metadata.Permission{
+				{ID: "p1", EntityID: "su2", Email: "bob@source.com", EntityType: metadata.GV2User},
+			},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "tu2", EntityType: metadata.GV2User},
+			},
+			expectRemapped: 1,
+		},
+		{
+			name:       "mapped group",
+			principals: principals,
+			permissions: []metadata.Permission{
+				{ID: "p1", EntityID: "sg1", EntityType: metadata.GV2Group},
+			},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "tg1", EntityType: metadata.GV2Group},
+			},
+			expectRemapped: 1,
+		},
+		{
+			name:       "user mapped to a group",
+			principals: principals,
+			permissions: []metadata.Permission{
+				{ID: "p1", EntityID: "su5", EntityType: metadata.GV2User},
+			},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "tg1", EntityType: metadata.GV2Group},
+			},
+			expectRemapped: 1,
+		},
+		{
+			name:       "unresolved target",
+			principals: principals,
+			permissions: []metadata.Permission{
+				{ID: "p1", EntityID: "su3", EntityType: metadata.GV2User},
+			},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "carol@target.com", Email: "carol@target.com", EntityType: metadata.GV2User},
+			},
+			expectRemapped: 1,
+		},
+		{
+			name:       "unmapped",
+			principals: principals,
+			permissions: []metadata.Permission{
+				{ID: "p1", EntityID: "su4", EntityType: metadata.GV2User},
+			},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "su4", EntityType: metadata.GV2User},
+			},
+		},
+		{
+			name:       "unavailable entities use the fallback",
+			principals: withFallback,
+			permissions: []metadata.Permission{
+				{ID: "p1", EntityID: "su1", EntityType: metadata.GV2User},
+				{ID: "p2", EntityID: "su4", EntityType: metadata.GV2User},
+				{ID: "p3", EntityID: "sg2", EntityType: metadata.GV2Group},
+				{ID: "p4", EntityID: "tu2", EntityType: metadata.GV2User},
+			},
+			expected: []metadata.Permission{
+				{ID: "p1", EntityID: "tu1", Email: "alice@target.com", EntityType: metadata.GV2User},
+				{ID: "p2", EntityID: "tg1", EntityType: metadata.GV2Group},
+				{ID: "p3", EntityID: "tg1", EntityType: metadata.GV2Group},
+				{ID: "p4", EntityID: "tu2", EntityType: metadata.GV2User},
+			},
+			expectRemapped: 3,
+			expectFallback: 2,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				orig = append([]metadata.Permission{}, test.permissions...)
+				ctr  = count.New()
+			)
+
+			result := translatePermissionPrincipals(ctx, test.permissions, test.principals, available, ctr)
+			assert.Equal(t, test.expected, result)
+			assert.Equal(t, orig, test.permissions, "input is not modified")
+			assert.Equal(t, test.expectRemapped, ctr.Get(count.PermissionRemapped), "remapped count")
+			assert.Equal(t, test.expectFallback, ctr.Get(count.PermissionFallback), "fallback count")
+
+			// unresolved targets get dropped by the availability filter
+			oldToNew := syncd.NewMapTo[string]()
+			filtered := filterUnavailableEntitiesInPermissions(ctx, result, available, oldToNew, ctr)
+
+			for _, p := range filtered {
+				_, ok := available.Users.NameOf(p.EntityID)
+				_, gok := available.Groups.NameOf(p.EntityID)
+				assert.True(t, ok || gok, "restored principal is available")
+			}
+		})
+	}
+}
+
+func (suite *PermissionsUnitTestSuite) TestTranslateLinkSharePrincipals() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		available = ResourceIDNames{
+			Users:  idname.NewCache(map[string]string{"tu1": "alice@target.com"}),
+			Groups: idname.NewCache(map[string]string{}),
+		}
+		principals = control.NewPrincipalMap(map[string]string{
+			"su1":               "alice@target.com",
+			"guest@partner.com": "guest@target.com",
+			"*":                 "alice@target.com",
+		})
+		linkShares = []metadata.LinkShare{
+			{
+				ID: "ls1",
+				Entities: []metadata.Entity{
+					{ID: "su1", EntityType: metadata.GV2User},
+					{ID: "su2", EntityType: metadata.GV2User},
+				},
+			},
+			{
+				ID: "ls2",
+				Entities: []metadata.Entity{
+					{Email: "guest@partner.com", EntityType: metadata.GV2User},
+				},
+			},
+		}
+		expected = []metadata.LinkShare{
+			{
+				ID: "ls1",
+				Entities: []metadata.Entity{
+					{ID: "tu1", Email: "alice@target.com", EntityType: metadata.GV2User},
+					{ID: "tu1", Email: "alice@target.com", EntityType: metadata.GV2User},
+				},
+			},
+			{
+				ID: "ls2",
+				Entities: []metadata.Entity{
+					{Email: "guest@target.com", EntityType: metadata.GV2User},
+				},
+			},
+		}
+	)
+
+	ctr := count.New()
+
+	result := translateLinkSharePrincipals(ctx, linkShares, principals, available, ctr)
+	assert.Equal(t, expected, result)
+	assert.Equal(t, int64(3), ctr.Get(count.PermissionRemapped), "remapped count")
+	assert.Equal(t, int64(1), ctr.Get(count.PermissionFallback), "fallback count")
+	assert.Equal(t, "su1", linkShares[0].Entities[0].ID, "input is not modified")
+}
@@ -0,0 +1,230 @@
+package drive
+
+import (
+	"context"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/pkg/errors"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph/metadata"
+)
+
+// PlanCollection records the action that restoring each item in the
+// collection would take.  The restore drive and folders are looked up,
+// but never created: items bound for a drive or folder that doesn't
+// exist yet get planned as new items.  Caches must be populated with
+// PopulateDrives beforehand.
+func PlanCollection(
+	ctx context.Context,
+	rh RestoreHandler,
+	rcc inject.RestoreConsumerConfig,
+	dc data.RestoreCollection,
+	caches *restoreCaches,
+	fallbackDriveName string,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	drivePath, err := path.ToDrivePath(dc.FullPath())
+	if err != nil {
+		return clues.WrapWC(ctx, err, "creating drive path")
+	}
+
+	restoreDir := &path.Builder{}
+
+	if len(rcc.RestoreConfig.Location) > 0 {
+		restoreDir = restoreDir.Append(rcc.RestoreConfig.Location)
+	}
+
+	restoreDir = restoreDir.Append(drivePath.Folders...)
+
+	var (
+		di, found            = planDrive(caches, drivePath, fallbackDriveName)
+		collisionKeyToItemID = map[string]api.DriveItemIDType{}
+		el                   = errs.Local()
+	)
+
+	ctx = clues.Add(
+		ctx,
+		"restore_destination", restoreDir,
+		"drive_id", di.id,
+		"drive_exists", found)
+
+	if found {
+		folderID, err := planRestoreFolder(ctx, rh, di, restoreDir, caches)
+		if err != nil {
+			return clues.Wrap(err, "looking up restore folder")
+		}
+
+		if len(folderID) > 0 {
+			collisionKeyToItemID, err = rh.GetItemsInContainerByCollisionKey(ctx, di.id, folderID)
+			if err != nil {
+				return clues.Wrap(err, "generating map of item collision keys")
+			}
+		}
+	}
+
+	dest := path.Builder{}.Append(di.name).Append(restoreDir.Elements()...)
+
+	for itemData := range dc.Items(ctx, errs) {
+		if el.Failure() != nil {
+			break
+		}
+
+		itemID := itemData.ID()
+		ictx := clues.Add(ctx, "restore_item_id", itemID)
+
+		if rcc.BackupVersion >= version.OneDrive1DataAndMetaFiles &&
+			!strings.HasSuffix(itemID, metadata.DataFileSuffix) {
+			// .meta and .dirmeta files get read alongside their items.
+			continue
+		}
+
+		name, perms, err := planItemNameAndPermissions(ictx, rcc, dc, itemID)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "planning item"))
+			continue
+		}
+
+		var (
+			size                int64
+			collisionKey        = api.DriveItemCollisionKey(api.NewDriveItem(name, false))
+			collision, collides = collisionKeyToItemID[collisionKey]
+		)
+
+		if ss, ok := itemData.(data.ItemSize); ok {
+			size = ss.Size()
+		}
+
+		action := plan.ForRestore(rcc.RestoreConfig.OnCollision, collides)
+
+		// files never replace folders.  The restore copies the file
+		// alongside the folder instead.
+		if action == plan.Replace && collision.IsFolder {
+			action = plan.Copy
+		}
+
+		p.Add(plan.Item{
+			Action:      action,
+			Destination: dest.Append(name).String(),
+			Size:        size,
+			Permissions: perms,
+		})
+	}
+
+	return el.Failure()
+}
+
+// planDrive finds the drive that the collection would be restored into,
+// following the same precedence as ensureDriveExists.  Returns false if
+// the restore would need to create the drive.
+func planDrive(
+	caches *restoreCaches,
+	drivePath *path.DrivePath,
+	fallbackDriveName string,
+) (driveInfo, bool) {
+	if di, ok := caches.DriveIDToDriveInfo.Load(drivePath.DriveID); ok {
+		return di, true
+	}
+
+	name := fallbackDriveName
+
+	if oldName, ok := caches.BackupDriveIDName.NameOf(drivePath.DriveID); ok {
+		if di, ok := caches.DriveNameToDriveInfo.Load(oldName); ok {
+			return di, true
+		}
+
+		name = oldName
+	}
+
+	return driveInfo{name: name}, false
+}
+
+// planRestoreFolder looks up the folder that the collection would be
+// restored into.  Returns an empty ID if any folder along the way doesn't
+// exist.
+func planRestoreFolder(
+	ctx context.Context,
+	gfbn GetFolderByNamer,
+	di driveInfo,
+	restoreDir *path.Builder,
+	caches *restoreCaches,
+) (string, error) {
+	var (
+		location       = path.Builder{}.Append(di.id)
+		parentFolderID = di.rootFolderID
+	)
+
+	for _, folderName := range restoreDir.Elements() {
+		location = location.Append(folderName)
+
+		if fl, ok := caches.Folders.get(location); ok {
+			parentFolderID = ptr.Val(fl.GetId())
+			continue
+		}
+
+		folder, err := gfbn.GetFolderByName(ctx, di.id, parentFolderID, folderName)
+		if errors.Is(err, api.ErrFolderNotFound) {
+			return "", nil
+		}
+
+		if err != nil {
+			return "", clues.Stack(err)
+		}
+
+		parentFolderID = ptr.Val(folder.GetId())
+		caches.Folders.set(location, folder)
+	}
+
+	return parentFolderID, nil
+}
+
+// planItemNameAndPermissions produces the name that the item would be
+// restored with, and the count of permissions that would be granted on
+// it, according to the backup version's layout.
+func planItemNameAndPermissions(
+	ctx context.Context,
+	rcc inject.RestoreConsumerConfig,
+	fibn data.FetchItemByNamer,
+	itemID string,
+) (string, int, error) {
+	if rcc.BackupVersion < version.OneDrive1DataAndMetaFiles {
+		return itemID, 0, nil
+	}
+
+	trimmedName := strings.TrimSuffix(itemID, metadata.DataFileSuffix)
+
+	if rcc.BackupVersion < version.OneDrive6NameInMeta &&
+		!rcc.RestoreConfig.IncludePermissions {
+		return trimmedName, 0, nil
+	}
+
+	meta, err := FetchAndReadMetadata(ctx, fibn, trimmedName+metadata.MetaFileSuffix)
+	if err != nil {
+		return "", 0, clues.Stack(err)
+	}
+
+	name := trimmedName
+
+	if rcc.BackupVersion >= version.OneDrive6NameInMeta {
+		if len(meta.FileName) == 0 {
+			return "", 0, clues.NewWC(ctx, "item with empty name")
+		}
+
+		name = meta.FileName
+	}
+
+	if !rcc.RestoreConfig.IncludePermissions {
+		return name, 0, nil
+	}
+
+	return name, len(meta.Permissions), nil
+}
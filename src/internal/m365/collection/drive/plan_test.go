@@ -0,0 +1,183 @@
+package drive
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	odConsts "github.com/alcionai/corso/src/internal/m365/service/onedrive/consts"
+	"github.com/alcionai/corso/src/internal/m365/service/onedrive/mock"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph/metadata"
+)
+
+type PlanUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlanUnitSuite(t *testing.T) {
+	suite.Run(t, &PlanUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlanUnitSuite) TestPlanCollection() {
+	const (
+		driveID   = "driveID1"
+		driveName = "Documents"
+		itemID    = "item-id"
+	)
+
+	table := []struct {
+		name          string
+		collisionKeys map[string]api.DriveItemIDType
+		driveExists   bool
+		onCollision   control.CollisionPolicy
+		expectAction  plan.Action
+		expectDest    string
+	}{
+		{
+			name:         "no collision",
+			driveExists:  true,
+			onCollision:  control.Skip,
+			expectAction: plan.Create,
+		},
+		{
+			name: "collision, skip",
+			collisionKeys: map[string]api.DriveItemIDType{
+				mock.DriveItemFileName: {ItemID: "existing"},
+			},
+			driveExists:  true,
+			onCollision:  control.Skip,
+			expectAction: plan.Skip,
+		},
+		{
+			name: "collision, copy",
+			collisionKeys: map[string]api.DriveItemIDType{
+				mock.DriveItemFileName: {ItemID: "existing"},
+			},
+			driveExists:  true,
+			onCollision:  control.Copy,
+			expectAction: plan.Copy,
+		},
+		{
+			name: "collision, replace",
+			collisionKeys: map[string]api.DriveItemIDType{
+				mock.DriveItemFileName: {ItemID: "existing"},
+			},
+			driveExists:  true,
+			onCollision:  control.Replace,
+			expectAction: plan.Replace,
+		},
+		{
+			name: "file-folder collision, replace",
+			collisionKeys: map[string]api.DriveItemIDType{
+				mock.DriveItemFileName: {
+					ItemID:   "existing",
+					IsFolder: true,
+				},
+			},
+			driveExists:  true,
+			onCollision:  control.Replace,
+			expectAction: plan.Copy,
+		},
+		{
+			name: "drive doesn't exist",
+			collisionKeys: map[string]api.DriveItemIDType{
+				mock.DriveItemFileName: {ItemID: "existing"},
+			},
+			onCollision:  control.Skip,
+			expectAction: plan.Create,
+			expectDest:   "fallback",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				p      = plan.New()
+				caches = NewRestoreCaches(nil)
+				rh     = &mockRestoreHandler{CollisionKeyMap: test.collisionKeys}
+				dpb    = odConsts.DriveFolderPrefixBuilder(driveID)
+				rcc    = inject.RestoreConsumerConfig{
+					BackupVersion: version.Backup,
+					Options:       control.DefaultOptions(),
+					RestoreConfig: control.RestoreConfig{OnCollision: test.onCollision},
+				}
+				payload = []byte(mock.DriveFilePayloadData)
+			)
+
+			if test.driveExists {
+				caches.DriveIDToDriveInfo.Store(driveID, driveInfo{
+					id:           driveID,
+					name:         driveName,
+					rootFolderID: "root-id",
+				})
+			}
+
+			fp, err := path.Build(
+				"t",
+				"u",
+				path.OneDriveService,
+				path.FilesCategory,
+				false,
+				dpb.Elements()...)
+			require.NoError(t, err, clues.ToCore(err))
+
+			dc := dataMock.Collection{
+				Path: fp,
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID:   itemID + metadata.DataFileSuffix,
+						ItemSize: int64(len(payload)),
+						Reader:   mock.FileRespReadCloser(mock.DriveFilePayloadData),
+					},
+					// metadata files are read alongside the item, and
+					// don't get planned on their own.
+					&dataMock.Item{
+						ItemID: itemID + metadata.MetaFileSuffix,
+						Reader: mock.FileRespReadCloser(mock.DriveFileMetaData),
+					},
+				},
+				AuxItems: map[string]data.Item{
+					itemID + metadata.MetaFileSuffix: &dataMock.Item{
+						ItemID: itemID + metadata.MetaFileSuffix,
+						Reader: mock.FileRespReadCloser(mock.DriveFileMetaData),
+					},
+				},
+			}
+
+			err = PlanCollection(ctx, rh, rcc, dc, caches, "fallback", p, fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			items := p.Items()
+			require.Len(t, items, 1)
+
+			expectDest := test.expectDest
+			if len(expectDest) == 0 {
+				expectDest = driveName
+			}
+
+			assert.Equal(t, test.expectAction, items[0].Action)
+			assert.Equal(
+				t,
+				path.Builder{}.Append(expectDest, mock.DriveItemFileName).String(),
+				items[0].Destination)
+			assert.Equal(t, int64(len(payload)), items[0].Size)
+		})
+	}
+}
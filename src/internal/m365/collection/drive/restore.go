@@ -9,6 +9,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
@@ -134,6 +135,7 @@ func RestoreCollection(
 		colMeta,
 		caches,
 		rcc.RestoreConfig.IncludePermissions,
+		ctr,
 		errs)
 	if err != nil {
 		return metrics, clues.Wrap(err, "creating folders for restore")
@@ -440,6 +442,18 @@ func restoreV1File(
 		return details.ItemInfo{}, err
 	}
 
+	itemInfo = restoreItemTimestamps(
+		ctx,
+		rh,
+		rcc,
+		caches,
+		drivePath.DriveID,
+		itemPath,
+		trimmedName,
+		itemID,
+		itemInfo,
+		ctr)
+
 	// Mark it as success without processing .meta
 	// file if we are not restoring permissions
 	if !rcc.RestoreConfig.IncludePermissions {
@@ -462,6 +476,7 @@ func restoreV1File(
 		itemPath,
 		meta,
 		caches,
+		ctr,
 		errs)
 
 	return itemInfo, nil
@@ -523,6 +538,18 @@ func restoreV6File(
 		return details.ItemInfo{}, err
 	}
 
+	itemInfo = restoreItemTimestamps(
+		ctx,
+		rh,
+		rcc,
+		caches,
+		drivePath.DriveID,
+		itemPath,
+		meta.FileName,
+		itemID,
+		itemInfo,
+		ctr)
+
 	// Mark it as success without processing .meta
 	// file if we are not restoring permissions
 	if !rcc.RestoreConfig.IncludePermissions {
@@ -537,11 +564,94 @@ func restoreV6File(
 		itemPath,
 		meta,
 		caches,
+		ctr,
 		errs)
 
 	return itemInfo, nil
 }
 
+// restoreItemTimestamps sets the created and modified times of the restored
+// file to those of the backed up file, and updates the item info to match.
+// The backed up file is found by the item's path in the backup, since the
+// file may be restored into a different drive.  The restored file is
+// otherwise complete, so failures are counted instead of failing the item.
+// Graph only allows setting the timestamps through the fileSystemInfo,
+// which SharePoint also uses for the Created and Modified columns; the
+// Author and Editor columns are read-only.
+func restoreItemTimestamps(
+	ctx context.Context,
+	pi PatchItemer,
+	rcc inject.RestoreConsumerConfig,
+	caches *restoreCaches,
+	driveID string,
+	itemPath path.Path,
+	name, itemID string,
+	itemInfo details.ItemInfo,
+	ctr *count.Bus,
+) details.ItemInfo {
+	if !rcc.RestoreConfig.IncludeTimestamps {
+		return itemInfo
+	}
+
+	backupPath, err := path.ToDrivePath(itemPath)
+	if err != nil {
+		logger.CtxErr(ctx, err).Error("getting backup drive path for item timestamps")
+		ctr.Inc(count.TimestampsNotRestored)
+
+		return itemInfo
+	}
+
+	parentPath := path.Builder{}.Append(backupPath.Folders...).String()
+
+	times, ok := caches.BackupItemTimes.Get(backupPath.DriveID, parentPath, name)
+	if !ok || (times.Created.IsZero() && times.Modified.IsZero()) {
+		return itemInfo
+	}
+
+	fsi := models.NewFileSystemInfo()
+
+	if !times.Created.IsZero() {
+		fsi.SetCreatedDateTime(ptr.To(times.Created))
+	}
+
+	if !times.Modified.IsZero() {
+		fsi.SetLastModifiedDateTime(ptr.To(times.Modified))
+	}
+
+	item := models.NewDriveItem()
+	item.SetFileSystemInfo(fsi)
+
+	if err := pi.PatchItem(ctx, driveID, itemID, item); err != nil {
+		logger.CtxErr(ctx, err).Error("unable to restore item timestamps")
+		ctr.Inc(count.TimestampsNotRestored)
+
+		return itemInfo
+	}
+
+	var created, modified *time.Time
+
+	switch {
+	case itemInfo.OneDrive != nil:
+		created, modified = &itemInfo.OneDrive.Created, &itemInfo.OneDrive.Modified
+	case itemInfo.SharePoint != nil:
+		created, modified = &itemInfo.SharePoint.Created, &itemInfo.SharePoint.Modified
+	case itemInfo.Groups != nil:
+		created, modified = &itemInfo.Groups.Created, &itemInfo.Groups.Modified
+	default:
+		return itemInfo
+	}
+
+	if !times.Created.IsZero() {
+		*created = times.Created
+	}
+
+	if !times.Modified.IsZero() {
+		*modified = times.Modified
+	}
+
+	return itemInfo
+}
+
 // CreateRestoreFolders creates the restore folder hierarchy in
 // the specified drive and returns the folder ID of the last folder entry in the
 // hierarchy. Permissions are only applied to the last folder in the hierarchy.
@@ -556,6 +666,7 @@ func CreateRestoreFolders(
 	folderMetadata odmetadata.Metadata,
 	caches *restoreCaches,
 	restorePerms bool,
+	ctr *count.Bus,
 	errs *fault.Bus,
 ) (string, error) {
 	id, err := createRestoreFolders(
@@ -585,6 +696,7 @@ func CreateRestoreFolders(
 		folderPath,
 		folderMetadata,
 		caches,
+		ctr,
 		errs)
 
 	return id, nil
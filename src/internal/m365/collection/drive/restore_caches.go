@@ -3,6 +3,7 @@ package drive
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
@@ -11,6 +12,8 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/common/syncd"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive/metadata"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
@@ -27,6 +30,72 @@ type ResourceIDNames struct {
 	Groups idname.Cacher
 }
 
+// ItemTimes holds the created and modified times of a backed up file.
+type ItemTimes struct {
+	Created  time.Time
+	Modified time.Time
+}
+
+type backupItemKey struct {
+	driveID    string
+	parentPath string
+	name       string
+}
+
+// BackupItemTimes tracks the timestamps of backed up files, keyed by the
+// drive, parent folder, and name of the file at the time of the backup.
+// It's populated from the backup details before the restore begins, and
+// only read afterwards.
+type BackupItemTimes map[backupItemKey]ItemTimes
+
+func NewBackupItemTimes() BackupItemTimes {
+	return BackupItemTimes{}
+}
+
+// CacheItemInfo adds the timestamps of drive files in the item info.
+// Folders and non-drive items are ignored.
+func (bit BackupItemTimes) CacheItemInfo(dii details.ItemInfo) {
+	switch {
+	case dii.OneDrive != nil && dii.OneDrive.ItemType == details.OneDriveItem:
+		bit.add(
+			dii.OneDrive.DriveID,
+			dii.OneDrive.ParentPath,
+			dii.OneDrive.ItemName,
+			ItemTimes{dii.OneDrive.Created, dii.OneDrive.Modified})
+
+	// SharePoint used to store library items with the OneDriveItem ItemType.
+	case dii.SharePoint != nil &&
+		(dii.SharePoint.ItemType == details.SharePointLibrary || dii.SharePoint.ItemType == details.OneDriveItem):
+		bit.add(
+			dii.SharePoint.DriveID,
+			dii.SharePoint.ParentPath,
+			dii.SharePoint.ItemName,
+			ItemTimes{dii.SharePoint.Created, dii.SharePoint.Modified})
+
+	case dii.Groups != nil && dii.Groups.ItemType == details.SharePointLibrary:
+		bit.add(
+			dii.Groups.DriveID,
+			dii.Groups.ParentPath,
+			dii.Groups.ItemName,
+			ItemTimes{dii.Groups.Created, dii.Groups.Modified})
+	}
+}
+
+func (bit BackupItemTimes) add(driveID, parentPath, name string, it ItemTimes) {
+	if len(driveID) == 0 || len(name) == 0 {
+		return
+	}
+
+	bit[backupItemKey{driveID, parentPath, name}] = it
+}
+
+// Get returns the timestamps of the file that was backed up in the
+// drive and parent folder under the given name.
+func (bit BackupItemTimes) Get(driveID, parentPath, name string) (ItemTimes, bool) {
+	it, ok := bit[backupItemKey{driveID, parentPath, name}]
+	return it, ok
+}
+
 type restoreCaches struct {
 	BackupDriveIDName     idname.Cacher
 	collisionKeyToItemID  map[string]api.DriveItemIDType
@@ -37,6 +106,8 @@ type restoreCaches struct {
 	OldPermIDToNewID      syncd.MapTo[string]
 	ParentDirToMeta       syncd.MapTo[metadata.Metadata]
 	AvailableEntities     ResourceIDNames
+	Principals            control.PrincipalMap
+	BackupItemTimes       BackupItemTimes
 
 	pool sync.Pool
 }
@@ -71,25 +142,18 @@ type GetAllIDsAndNameser interface {
 }
 
 // Populate looks up drive items available to the protectedResource
-// and adds their info to the caches.
+// and adds their info to the caches, along with the principal map used
+// to translate the principals of restored permissions.
 func (rc *restoreCaches) Populate(
 	ctx context.Context,
 	usersGetter, groupsGetter GetAllIDsAndNameser,
 	gdparf GetDrivePagerAndRootFolderer,
 	protectedResourceID string,
+	principals control.PrincipalMap,
 	errs *fault.Bus,
 ) error {
-	drives, err := api.GetAllDrives(
-		ctx,
-		gdparf.NewDrivePager(protectedResourceID, nil))
-	if err != nil {
-		return clues.Wrap(err, "getting drives")
-	}
-
-	for _, md := range drives {
-		if err := rc.AddDrive(ctx, md, gdparf); err != nil {
-			return clues.Wrap(err, "caching drive")
-		}
+	if err := rc.PopulateDrives(ctx, gdparf, protectedResourceID); err != nil {
+		return clues.Stack(err)
 	}
 
 	users, err := usersGetter.GetAllIDsAndNames(ctx, errs)
@@ -104,6 +168,30 @@ func (rc *restoreCaches) Populate(
 
 	rc.AvailableEntities.Users = users
 	rc.AvailableEntities.Groups = groups
+	rc.Principals = principals
+
+	return nil
+}
+
+// PopulateDrives looks up the drives available to the protectedResource
+// and adds their info to the caches.
+func (rc *restoreCaches) PopulateDrives(
+	ctx context.Context,
+	gdparf GetDrivePagerAndRootFolderer,
+	protectedResourceID string,
+) error {
+	drives, err := api.GetAllDrives(
+		ctx,
+		gdparf.NewDrivePager(protectedResourceID, nil))
+	if err != nil {
+		return clues.Wrap(err, "getting drives")
+	}
+
+	for _, md := range drives {
+		if err := rc.AddDrive(ctx, md, gdparf); err != nil {
+			return clues.Wrap(err, "caching drive")
+		}
+	}
 
 	return nil
 }
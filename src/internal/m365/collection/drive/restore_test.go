@@ -3,6 +3,7 @@ package drive
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/google/uuid"
@@ -20,6 +21,7 @@ import (
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/errs/core"
@@ -546,6 +548,7 @@ func (suite *RestoreUnitSuite) TestRestoreCaches_Populate() {
 				mockAllIDsAndNamesGetter{test.groups},
 				gdparf,
 				"shmoo",
+				nil,
 				errs)
 			test.expectErr(t, err, clues.ToCore(err))
 
@@ -832,3 +835,182 @@ func (suite *RestoreUnitSuite) TestEnsureDriveExists() {
 		})
 	}
 }
+
+func (suite *RestoreUnitSuite) TestBackupItemTimes() {
+	var (
+		t        = suite.T()
+		bit      = NewBackupItemTimes()
+		created  = time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+		modified = time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+		expect   = ItemTimes{Created: created, Modified: modified}
+	)
+
+	bit.CacheItemInfo(details.ItemInfo{
+		OneDrive: &details.OneDriveInfo{
+			ItemType:   details.OneDriveItem,
+			DriveID:    "od-drive",
+			ParentPath: "folder",
+			ItemName:   "file.txt",
+			Created:    created,
+			Modified:   modified,
+		},
+	})
+	bit.CacheItemInfo(details.ItemInfo{
+		SharePoint: &details.SharePointInfo{
+			ItemType:   details.SharePointLibrary,
+			DriveID:    "sp-drive",
+			ParentPath: "folder/sub",
+			ItemName:   "file.txt",
+			Created:    created,
+			Modified:   modified,
+		},
+	})
+	bit.CacheItemInfo(details.ItemInfo{
+		Groups: &details.GroupsInfo{
+			ItemType: details.SharePointLibrary,
+			DriveID:  "gr-drive",
+			ItemName: "file.txt",
+			Created:  created,
+			Modified: modified,
+		},
+	})
+	bit.CacheItemInfo(details.ItemInfo{
+		SharePoint: &details.SharePointInfo{
+			ItemType: details.SharePointList,
+			ItemName: "list",
+			Created:  created,
+			Modified: modified,
+		},
+	})
+
+	result, ok := bit.Get("od-drive", "folder", "file.txt")
+	assert.True(t, ok, "onedrive file")
+	assert.Equal(t, expect, result)
+
+	result, ok = bit.Get("sp-drive", "folder/sub", "file.txt")
+	assert.True(t, ok, "sharepoint file")
+	assert.Equal(t, expect, result)
+
+	result, ok = bit.Get("gr-drive", "", "file.txt")
+	assert.True(t, ok, "groups file")
+	assert.Equal(t, expect, result)
+
+	_, ok = bit.Get("sp-drive", "folder", "file.txt")
+	assert.False(t, ok, "different parent folder")
+	assert.Len(t, bit, 3, "lists are not cached")
+}
+
+func (suite *RestoreUnitSuite) TestRestoreItemTimestamps() {
+	var (
+		created  = time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+		modified = time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+		restored = time.Now().UTC().Truncate(time.Second)
+	)
+
+	itemPath, err := path.Build(
+		"tenant",
+		"user",
+		path.OneDriveService,
+		path.FilesCategory,
+		true,
+		odConsts.DrivesPathDir, "backup-drive-id", odConsts.RootPathDir, "folder", "item-id")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	bit := NewBackupItemTimes()
+	bit.add("backup-drive-id", "folder", "file.txt", ItemTimes{Created: created, Modified: modified})
+
+	table := []struct {
+		name              string
+		includeTimestamps bool
+		itemName          string
+		patchErr          error
+		expectPatch       assert.BoolAssertionFunc
+		expectCreated     time.Time
+		expectModified    time.Time
+		expectNotRestored int64
+	}{
+		{
+			// the restore drive differs from the backed up one, as when
+			// restoring to another resource or into a recreated drive.
+			name:              "restores timestamps",
+			includeTimestamps: true,
+			itemName:          "file.txt",
+			expectPatch:       assert.True,
+			expectCreated:     created,
+			expectModified:    modified,
+		},
+		{
+			name:              "timestamps not included",
+			includeTimestamps: false,
+			itemName:          "file.txt",
+			expectPatch:       assert.False,
+			expectCreated:     restored,
+			expectModified:    restored,
+		},
+		{
+			name:              "no backed up timestamps",
+			includeTimestamps: true,
+			itemName:          "other.txt",
+			expectPatch:       assert.False,
+			expectCreated:     restored,
+			expectModified:    restored,
+		},
+		{
+			name:              "patch fails",
+			includeTimestamps: true,
+			itemName:          "file.txt",
+			patchErr:          assert.AnError,
+			expectPatch:       assert.True,
+			expectCreated:     restored,
+			expectModified:    restored,
+			expectNotRestored: 1,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				rh  = &mock.RestoreHandler{PatchItemErr: test.patchErr}
+				rcc = inject.RestoreConsumerConfig{
+					RestoreConfig: control.RestoreConfig{IncludeTimestamps: test.includeTimestamps},
+				}
+				caches = NewRestoreCaches(nil)
+				ctr    = count.New()
+				dii    = details.ItemInfo{
+					OneDrive: &details.OneDriveInfo{Created: restored, Modified: restored},
+				}
+			)
+
+			caches.BackupItemTimes = bit
+
+			result := restoreItemTimestamps(
+				ctx,
+				rh,
+				rcc,
+				caches,
+				"restore-drive-id",
+				itemPath,
+				test.itemName,
+				"item-id",
+				dii,
+				ctr)
+			test.expectPatch(t, rh.CalledPatchItem, "patched item")
+			assert.Equal(t, test.expectNotRestored, ctr.Get(count.TimestampsNotRestored), "timestamps not restored")
+			assert.Equal(t, test.expectCreated, result.OneDrive.Created)
+			assert.Equal(t, test.expectModified, result.OneDrive.Modified)
+
+			if !rh.CalledPatchItem {
+				return
+			}
+
+			fsi := rh.PatchItemReq.GetFileSystemInfo()
+			require.NotNil(t, fsi)
+			assert.Equal(t, created, ptr.Val(fsi.GetCreatedDateTime()))
+			assert.Equal(t, modified, ptr.Val(fsi.GetLastModifiedDateTime()))
+		})
+	}
+}
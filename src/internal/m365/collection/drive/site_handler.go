@@ -278,6 +278,14 @@ func (h siteRestoreHandler) PostItemInContainer(
 	return h.ac.Drives().PostItemInContainer(ctx, driveID, parentFolderID, newItem, onCollision)
 }
 
+func (h siteRestoreHandler) PatchItem(
+	ctx context.Context,
+	driveID, itemID string,
+	item models.DriveItemable,
+) error {
+	return h.ac.Drives().PatchItem(ctx, driveID, itemID, item)
+}
+
 func (h siteRestoreHandler) GetFolderByName(
 	ctx context.Context,
 	driveID, parentFolderID, folderName string,
@@ -279,6 +279,14 @@ func (h userDriveRestoreHandler) PostItemInContainer(
 	return h.ac.PostItemInContainer(ctx, driveID, parentFolderID, newItem, onCollision)
 }
 
+func (h userDriveRestoreHandler) PatchItem(
+	ctx context.Context,
+	driveID, itemID string,
+	item models.DriveItemable,
+) error {
+	return h.ac.PatchItem(ctx, driveID, itemID, item)
+}
+
 func (h userDriveRestoreHandler) GetFolderByName(
 	ctx context.Context,
 	driveID, parentFolderID, folderName string,
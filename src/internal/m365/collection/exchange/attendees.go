@@ -2,10 +2,12 @@ package exchange
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/control"
 )
 
 type attendee struct {
@@ -56,6 +58,29 @@ func FormatAttendees(event models.Eventable, isHTML bool) string {
 	return req + opt + res
 }
 
+// translateAttendees replaces the email address of each attendee with its
+// counterpart in the principal map.  Only email targets can be used as an
+// attendee address; other attendees are left as they are.
+func translateAttendees(event models.Eventable, principals control.PrincipalMap) {
+	if len(principals) == 0 {
+		return
+	}
+
+	for _, entry := range event.GetAttendees() {
+		ea := entry.GetEmailAddress()
+		if ea == nil {
+			continue
+		}
+
+		target, ok := principals.Lookup(ptr.Val(ea.GetAddress()))
+		if !ok || !strings.Contains(target, "@") {
+			continue
+		}
+
+		ea.SetAddress(ptr.To(target))
+	}
+}
+
 func attendeeListToString(attendList []attendee, heading string, isHTML bool) string {
 	var (
 		message   string
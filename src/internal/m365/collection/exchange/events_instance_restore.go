@@ -11,6 +11,7 @@ import (
 
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
@@ -39,6 +40,7 @@ func updateRecurringEvents(
 	eiaa eventInstanceAndAttachmenter,
 	userID, containerID, itemID string,
 	event models.Eventable,
+	principals control.PrincipalMap,
 	errs *fault.Bus,
 ) error {
 	if event.GetRecurrence() == nil {
@@ -56,7 +58,7 @@ func updateRecurringEvents(
 		return clues.Wrap(err, "update cancelled occurrences")
 	}
 
-	err = updateExceptionOccurrences(ctx, eiaa, userID, containerID, itemID, exceptionOccurrences, errs)
+	err = updateExceptionOccurrences(ctx, eiaa, userID, containerID, itemID, exceptionOccurrences, principals, errs)
 	if err != nil {
 		return clues.Wrap(err, "update exception occurrences")
 	}
@@ -74,6 +76,7 @@ func updateExceptionOccurrences(
 	containerID string,
 	itemID string,
 	exceptionOccurrences any,
+	principals control.PrincipalMap,
 	errs *fault.Bus,
 ) error {
 	if exceptionOccurrences == nil {
@@ -119,6 +122,7 @@ func updateExceptionOccurrences(
 				With("instances_count", len(instances), "search_start", startStr, "search_end", endStr)
 		}
 
+		translateAttendees(evt, principals)
 		evt = toEventSimplified(evt)
 
 		_, err = eiaa.PatchItem(ictx, userID, ptr.Val(instances[0].GetId()), evt)
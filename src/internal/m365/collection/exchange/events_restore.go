@@ -26,14 +26,17 @@ var (
 )
 
 type eventRestoreHandler struct {
-	ac api.Events
+	ac         api.Events
+	principals control.PrincipalMap
 }
 
 func newEventRestoreHandler(
 	ac api.Client,
+	principals control.PrincipalMap,
 ) eventRestoreHandler {
 	return eventRestoreHandler{
-		ac: ac.Events(),
+		ac:         ac.Events(),
+		principals: principals,
 	}
 }
 
@@ -98,6 +101,7 @@ func (h eventRestoreHandler) restore(
 		userID, destinationID,
 		collisionKeyToItemID,
 		collisionPolicy,
+		h.principals,
 		errs,
 		ctr)
 }
@@ -114,6 +118,7 @@ func restoreEvent(
 	userID, destinationID string,
 	collisionKeyToItemID map[string]string,
 	collisionPolicy control.CollisionPolicy,
+	principals control.PrincipalMap,
 	errs *fault.Bus,
 	ctr *count.Bus,
 ) (*details.ExchangeInfo, error) {
@@ -145,6 +150,7 @@ func restoreEvent(
 		shouldDeleteOriginal = collisionPolicy == control.Replace
 	}
 
+	translateAttendees(event, principals)
 	event = toEventSimplified(event)
 
 	var attachments []models.Attachmentable
@@ -200,6 +206,7 @@ func restoreEvent(
 		destinationID,
 		ptr.Val(item.GetId()),
 		event,
+		principals,
 		errs)
 	if err != nil {
 		return nil, clues.Stack(err)
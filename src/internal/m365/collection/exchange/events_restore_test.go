@@ -121,7 +121,7 @@ func (suite *EventsRestoreIntgSuite) SetupSuite() {
 func (suite *EventsRestoreIntgSuite) TestCreateContainerDestination() {
 	runCreateDestinationTest(
 		suite.T(),
-		newEventRestoreHandler(suite.m365.AC),
+		newEventRestoreHandler(suite.m365.AC, nil),
 		path.EventsCategory,
 		suite.m365.TenantID,
 		suite.m365.User.ID,
@@ -269,6 +269,7 @@ func (suite *EventsRestoreIntgSuite) TestRestoreEvent() {
 				"destination",
 				test.collisionMap,
 				test.onCollision,
+				nil,
 				fault.New(true),
 				ctr)
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"time"
 
 	"github.com/alcionai/clues"
 
@@ -23,12 +24,14 @@ func NewExportCollection(
 	baseDir string,
 	backingCollection []data.RestoreCollection,
 	backupVersion int,
+	cec control.ExportConfig,
 	stats *metrics.ExportStats,
 ) export.Collectioner {
 	return export.BaseCollection{
 		BaseDir:           baseDir,
 		BackingCollection: backingCollection,
 		BackupVersion:     backupVersion,
+		Cfg:               cec,
 		Stream:            streamItems,
 		Stats:             stats,
 	}
@@ -61,6 +64,18 @@ func streamItems(
 			ext = ".ics"
 		}
 
+		if category == path.EventsCategory && config.Format == control.CSVFormat {
+			logger.Ctx(ictx).Warn("csv format only applies to contacts, exporting events as ics")
+		}
+
+		agg, aggExt := newAggregator(category, config)
+		if agg != nil {
+			streamAggregate(ictx, rc, agg, aggExt, ch, stats, errs)
+			sendRestoreErrors(ch, errs)
+
+			continue
+		}
+
 		for item := range rc.Items(ictx, errs) {
 			id := item.ID()
 			name := id + ext
@@ -138,26 +153,147 @@ func streamItems(
 			body := metrics.ReaderWithStats(emlReader, category, stats)
 
 			ch <- export.Item{
-				ID:   id,
-				Name: name,
-				Body: body,
+				ID:      id,
+				Name:    name,
+				Body:    body,
+				ModTime: export.ModTimeOf(item),
 			}
 		}
 
-		items, recovered := errs.ItemsAndRecovered()
+		sendRestoreErrors(ch, errs)
+	}
+}
 
-		// Return all the items that we failed to source from the persistence layer
-		for _, err := range items {
-			ch <- export.Item{
-				ID:    err.ID,
-				Error: &err,
-			}
+// sendRestoreErrors sends all the items that we failed to source from the
+// persistence layer.
+func sendRestoreErrors(ch chan<- export.Item, errs *fault.Bus) {
+	items, recovered := errs.ItemsAndRecovered()
+
+	for _, err := range items {
+		ch <- export.Item{
+			ID:    err.ID,
+			Error: &err,
+		}
+	}
+
+	for _, err := range recovered {
+		ch <- export.Item{
+			Error: err,
+		}
+	}
+}
+
+// aggregator combines all the items of a folder into a single file.
+type aggregator interface {
+	AddJSON(ctx context.Context, body []byte) error
+	Len() int
+	Serialize() string
+}
+
+// newAggregator produces the aggregator and file extension used for the
+// category, or nil if the items of the category are exported one file
+// per item.  Contacts exported as csv are always aggregated, since a
+// csv per contact isn't useful to anyone.
+func newAggregator(
+	category path.CategoryType,
+	config control.ExportConfig,
+) (aggregator, string) {
+	switch category {
+	case path.ContactsCategory:
+		if config.Format == control.CSVFormat {
+			return vcf.NewAggregateCSV(), ".csv"
+		}
+
+		if config.Aggregate {
+			return vcf.NewAggregateCards(), ".vcf"
+		}
+	case path.EventsCategory:
+		if config.Aggregate {
+			return ics.NewAggregateCalendar(), ".ics"
+		}
+	}
+
+	return nil, ""
+}
+
+// folderName produces the display name of the collection's folder.  Backup
+// collections are stored under graph folder ids, and carry the display
+// names in their location.  Restore collections are placed at the
+// LocationRef recorded in the backup details, which holds the display
+// names already.
+func folderName(rc data.RestoreCollection) string {
+	if lp, ok := rc.(data.LocationPather); ok && lp.LocationPath() != nil {
+		return lp.LocationPath().LastElem()
+	}
+
+	return rc.FullPath().Folder(false)
+}
+
+// streamAggregate adds every item in the collection to the aggregator,
+// then sends the aggregate as a single item named after the folder.
+// Items which fail to convert are sent as individual errors.
+func streamAggregate(
+	ctx context.Context,
+	rc data.RestoreCollection,
+	agg aggregator,
+	ext string,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+	errs *fault.Bus,
+) {
+	var (
+		category = rc.FullPath().Category()
+		modTime  time.Time
+	)
+
+	for item := range rc.Items(ctx, errs) {
+		id := item.ID()
+		itemCtx := clues.Add(ctx, "stream_item_id", id)
+
+		stats.UpdateResourceCount(category)
+
+		reader := item.ToReader()
+		content, err := io.ReadAll(reader)
+
+		reader.Close()
+
+		if err != nil {
+			err = clues.WrapWC(itemCtx, err, "reading export item")
+		} else if err = agg.AddJSON(itemCtx, content); err != nil {
+			err = clues.Wrap(err, "aggregating export item")
 		}
 
-		for _, err := range recovered {
+		if err != nil {
+			logger.CtxErr(ctx, err).Info("processing collection item")
+
 			ch <- export.Item{
+				ID:    id,
 				Error: err,
 			}
+
+			continue
 		}
+
+		if mt := export.ModTimeOf(item); mt.After(modTime) {
+			modTime = mt
+		}
+	}
+
+	if agg.Len() == 0 {
+		return
+	}
+
+	name := folderName(rc)
+	if len(name) == 0 {
+		name = category.HumanString()
+	}
+
+	body := io.NopCloser(bytes.NewReader([]byte(agg.Serialize())))
+
+	ch <- export.Item{
+		ID:      rc.FullPath().ShortRef(),
+		Name:    name + ext,
+		Body:    metrics.ReaderWithStats(body, category, stats),
+		ModTime: modTime,
 	}
 }
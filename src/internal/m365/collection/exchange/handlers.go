@@ -73,6 +73,7 @@ type canSkipItemFailurer interface {
 
 type restoreHandler interface {
 	itemRestorer
+	itemPlanner
 	containerAPI
 	getItemsByCollisionKeyser
 	NewContainerCache(userID string) graph.ContainerResolver
@@ -123,13 +124,15 @@ type containerByNamer interface {
 }
 
 // primary interface controller for all per-cateogry restoration behavior.
+// the principal map is used to translate event attendees.
 func RestoreHandlers(
 	ac api.Client,
+	principals control.PrincipalMap,
 ) map[path.CategoryType]restoreHandler {
 	return map[path.CategoryType]restoreHandler{
 		path.ContactsCategory: newContactRestoreHandler(ac),
 		path.EmailCategory:    newMailRestoreHandler(ac),
-		path.EventsCategory:   newEventRestoreHandler(ac),
+		path.EventsCategory:   newEventRestoreHandler(ac, principals),
 	}
 }
 
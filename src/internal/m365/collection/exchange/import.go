@@ -0,0 +1,291 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+
+	"github.com/alcionai/corso/src/internal/converters/eml"
+	"github.com/alcionai/corso/src/internal/converters/ics"
+	"github.com/alcionai/corso/src/internal/converters/vcf"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// importExtensions maps the file extensions that can be imported to the
+// category of data they contain.
+var importExtensions = map[string]path.CategoryType{
+	".eml": path.EmailCategory,
+	".ics": path.EventsCategory,
+	".vcf": path.ContactsCategory,
+}
+
+// importRootFolders are the folders that files in the root of the import
+// source are restored into.
+var importRootFolders = map[path.CategoryType]string{
+	path.EmailCategory:    api.MailInbox,
+	path.ContactsCategory: api.DefaultContacts,
+	path.EventsCategory:   api.DefaultCalendar,
+}
+
+// ProduceImportCollections walks the source directory and produces one
+// restore collection for each directory and category of .eml, .ics, and
+// .vcf files within it.  The directory structure is retained as the
+// folder structure of the collection.  Since exports place each category
+// in its own top level directory (eg: Emails/Inbox/...), that directory
+// is dropped, so that re-importing an export restores the original
+// folders.
+func ProduceImportCollections(
+	ctx context.Context,
+	source, tenantID, resourceID string,
+) ([]data.RestoreCollection, error) {
+	ctx = clues.Add(ctx, "import_source", clues.Hide(source))
+
+	type collKey struct {
+		dir      string
+		category path.CategoryType
+	}
+
+	var (
+		files = map[collKey][]string{}
+		keys  []collKey
+	)
+
+	err := filepath.WalkDir(source, func(fp string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return clues.WrapWC(ctx, err, "walking import source")
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		category, ok := importExtensions[strings.ToLower(filepath.Ext(fp))]
+		if !ok {
+			logger.Ctx(ctx).Debugw("skipping unsupported import file", "file", clues.Hide(fp))
+			return nil
+		}
+
+		key := collKey{filepath.Dir(fp), category}
+
+		if _, ok := files[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		files[key] = append(files[key], fp)
+
+		return nil
+	})
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	colls := make([]data.RestoreCollection, 0, len(keys))
+
+	for _, key := range keys {
+		folders, err := importFolders(source, key.dir, key.category)
+		if err != nil {
+			return nil, clues.StackWC(ctx, err)
+		}
+
+		fp, err := path.Build(
+			tenantID,
+			resourceID,
+			path.ExchangeService,
+			key.category,
+			false,
+			folders...)
+		if err != nil {
+			return nil, clues.WrapWC(ctx, err, "building import collection path")
+		}
+
+		colls = append(colls, data.NoFetchRestoreCollection{
+			Collection: &importCollection{
+				fullPath: fp,
+				files:    files[key],
+			},
+		})
+	}
+
+	return colls, nil
+}
+
+func importFolders(source, dir string, category path.CategoryType) ([]string, error) {
+	rel, err := filepath.Rel(source, dir)
+	if err != nil {
+		return nil, clues.Wrap(err, "relativizing import directory")
+	}
+
+	var folders []string
+
+	if rel != "." {
+		folders = strings.Split(filepath.ToSlash(rel), "/")
+	}
+
+	if len(folders) > 0 && folders[0] == category.HumanString() {
+		folders = folders[1:]
+	}
+
+	if len(folders) == 0 {
+		folders = []string{importRootFolders[category]}
+	}
+
+	return folders, nil
+}
+
+// importCollection is a restore collection of local files.  Items are
+// converted to the graph api json consumed by the restore handlers.
+type importCollection struct {
+	fullPath path.Path
+	files    []string
+}
+
+func (col importCollection) FullPath() path.Path {
+	return col.fullPath
+}
+
+func (col importCollection) Items(ctx context.Context, errs *fault.Bus) <-chan data.Item {
+	ch := make(chan data.Item)
+
+	go func() {
+		defer close(ch)
+
+		sort.Strings(col.files)
+
+		for _, fp := range col.files {
+			if errs.Failure() != nil {
+				return
+			}
+
+			name := filepath.Base(fp)
+			ictx := clues.Add(ctx, "import_file", clues.Hide(name))
+
+			bodies, err := toGraphJSON(ictx, fp, col.fullPath.Category())
+			if err != nil {
+				errs.AddRecoverable(ictx, clues.Wrap(err, "converting import file"))
+				continue
+			}
+
+			for i, body := range bodies {
+				id := name
+				if len(bodies) > 1 {
+					id = fmt.Sprintf("%s-%d", name, i)
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- &importItem{id: id, body: body}:
+				}
+			}
+		}
+	}()
+
+	return ch
+}
+
+// toGraphJSON converts each item in the file to graph api json.  ics
+// and vcf files may contain more than one item.
+func toGraphJSON(
+	ctx context.Context,
+	fp string,
+	category path.CategoryType,
+) ([][]byte, error) {
+	body, err := os.ReadFile(fp)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "reading import file")
+	}
+
+	var items []serialization.Parsable
+
+	switch category {
+	case path.EmailCategory:
+		msg, err := eml.ToMessageable(ctx, body)
+		if err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		items = append(items, msg)
+
+	case path.EventsCategory:
+		events, err := ics.ToEventables(ctx, body)
+		if err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		for _, event := range events {
+			items = append(items, event)
+		}
+
+	case path.ContactsCategory:
+		contacts, err := vcf.ToContactables(ctx, body)
+		if err != nil {
+			return nil, clues.Stack(err)
+		}
+
+		for _, contact := range contacts {
+			items = append(items, contact)
+		}
+
+	default:
+		return nil, clues.NewWC(ctx, "unsupported import category").With("category", category)
+	}
+
+	bodies := make([][]byte, 0, len(items))
+
+	for _, item := range items {
+		bs, err := serializeParsable(item)
+		if err != nil {
+			return nil, clues.StackWC(ctx, err)
+		}
+
+		bodies = append(bodies, bs)
+	}
+
+	return bodies, nil
+}
+
+func serializeParsable(item serialization.Parsable) ([]byte, error) {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", item); err != nil {
+		return nil, clues.Wrap(err, "serializing item")
+	}
+
+	bs, err := writer.GetSerializedContent()
+
+	return bs, clues.Wrap(err, "serializing item").OrNil()
+}
+
+var _ data.Item = &importItem{}
+
+type importItem struct {
+	id   string
+	body []byte
+}
+
+func (i importItem) ID() string {
+	return i.id
+}
+
+func (i *importItem) ToReader() io.ReadCloser {
+	return io.NopCloser(bytes.NewReader(i.body))
+}
+
+func (i importItem) Deleted() bool {
+	return false
+}
@@ -0,0 +1,151 @@
+package exchange
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type ImportUnitSuite struct {
+	tester.Suite
+}
+
+func TestImportUnitSuite(t *testing.T) {
+	suite.Run(t, &ImportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+const (
+	importEML = "From: alice@example.com\r\n" +
+		"To: bob@example.com\r\n" +
+		"Subject: imported\r\n" +
+		"\r\n" +
+		"hello\r\n"
+
+	importICS = "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//test//test\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:one\r\n" +
+		"SUMMARY:one\r\n" +
+		"DTSTART:20240102T120000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:two\r\n" +
+		"SUMMARY:two\r\n" +
+		"DTSTART:20240103T120000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	importVCF = "BEGIN:VCARD\r\n" +
+		"VERSION:4.0\r\n" +
+		"FN:Alice\r\n" +
+		"N:Smith;Alice;;;\r\n" +
+		"END:VCARD\r\n"
+)
+
+func (suite *ImportUnitSuite) TestProduceImportCollections() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	source := t.TempDir()
+
+	files := map[string]string{
+		"root.eml":                     importEML,
+		"Emails/Inbox/a.eml":           importEML,
+		"Emails/Inbox/b.EML":           importEML,
+		"Emails/Inbox/Sub/c.eml":       importEML,
+		"Emails/Inbox/notes.txt":       "ignored",
+		"Events/Calendar/calendar.ics": importICS,
+		"Contacts/Friends/friends.vcf": importVCF,
+		"Archive/Old/old.eml":          importEML,
+		"Archive/Old/broken.vcf":       "BEGIN:VCARD\r\nnot a card",
+	}
+
+	for name, content := range files {
+		fp := filepath.Join(source, filepath.FromSlash(name))
+
+		err := os.MkdirAll(filepath.Dir(fp), 0o700)
+		require.NoError(t, err, clues.ToCore(err))
+
+		err = os.WriteFile(fp, []byte(content), 0o600)
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	colls, err := ProduceImportCollections(ctx, source, "tenant", "user")
+	require.NoError(t, err, clues.ToCore(err))
+
+	type result struct {
+		category path.CategoryType
+		items    int
+	}
+
+	got := map[string]result{}
+	errs := fault.New(false)
+
+	for _, coll := range colls {
+		fp := coll.FullPath()
+
+		assert.Equal(t, "tenant", fp.Tenant())
+		assert.Equal(t, "user", fp.ProtectedResource())
+		assert.Equal(t, path.ExchangeService, fp.Service())
+
+		// the root directory and Emails/Inbox both import into Inbox.
+		key := fp.Category().String() + ":" + strings.Join(fp.Folders(), "/")
+		r := got[key]
+		r.category = fp.Category()
+
+		for item := range coll.Items(ctx, errs) {
+			r.items++
+
+			bs, err := io.ReadAll(item.ToReader())
+			require.NoError(t, err, clues.ToCore(err))
+
+			switch fp.Category() {
+			case path.EmailCategory:
+				msg, err := api.BytesToMessageable(bs)
+				require.NoError(t, err, clues.ToCore(err))
+				assert.Equal(t, "imported", ptr.Val(msg.GetSubject()))
+			case path.EventsCategory:
+				_, err := api.BytesToEventable(bs)
+				require.NoError(t, err, clues.ToCore(err))
+			case path.ContactsCategory:
+				contact, err := api.BytesToContactable(bs)
+				require.NoError(t, err, clues.ToCore(err))
+				assert.Equal(t, "Alice", ptr.Val(contact.GetGivenName()))
+			}
+		}
+
+		got[key] = r
+	}
+
+	assert.Equal(
+		t,
+		map[string]result{
+			"email:Inbox":          {path.EmailCategory, 3},
+			"email:Inbox/Sub":      {path.EmailCategory, 1},
+			"email:Archive/Old":    {path.EmailCategory, 1},
+			"events:Calendar":      {path.EventsCategory, 2},
+			"contacts:Friends":     {path.ContactsCategory, 1},
+			"contacts:Archive/Old": {path.ContactsCategory, 0},
+		},
+		got)
+
+	// the broken vcf file is reported without failing the import.
+	assert.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+	assert.Len(t, errs.Recovered(), 1)
+}
@@ -0,0 +1,589 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// Mailbox settings are stored as one item per kind of setting, at the
+// root of the mailboxSettings category.  The item ID names the setting.
+const (
+	MailboxSettingsItemID     = "mailboxSettings"
+	InboxRulesItemID          = "inboxRules"
+	MasterCategoriesItemID    = "masterCategories"
+	CalendarPermissionsItemID = "calendarPermissions"
+)
+
+// MailboxSettingsItemIDs lists every mailbox setting that gets backed up.
+var MailboxSettingsItemIDs = []string{
+	MailboxSettingsItemID,
+	InboxRulesItemID,
+	MasterCategoriesItemID,
+	CalendarPermissionsItemID,
+}
+
+var _ data.BackupCollection = &mailboxSettingsCollection{}
+
+type mailboxSettingsGetter interface {
+	GetSettings(ctx context.Context, userID string) (models.MailboxSettingsable, error)
+	GetInboxRules(ctx context.Context, userID string) ([]models.MessageRuleable, error)
+	GetMasterCategories(ctx context.Context, userID string) ([]models.OutlookCategoryable, error)
+	GetCalendarPermissions(ctx context.Context, userID string) ([]models.CalendarPermissionable, error)
+}
+
+type mailboxSettingsRestorer interface {
+	GetInboxRules(ctx context.Context, userID string) ([]models.MessageRuleable, error)
+	PostInboxRule(ctx context.Context, userID string, body models.MessageRuleable) (models.MessageRuleable, error)
+	DeleteInboxRule(ctx context.Context, userID, ruleID string) error
+	GetMasterCategories(ctx context.Context, userID string) ([]models.OutlookCategoryable, error)
+	PostMasterCategory(
+		ctx context.Context,
+		userID string,
+		body models.OutlookCategoryable,
+	) (models.OutlookCategoryable, error)
+	UpdateAutomaticReplies(ctx context.Context, userID string, replies models.AutomaticRepliesSettingable) error
+}
+
+// ---------------------------------------------------------------------------
+// backup
+// ---------------------------------------------------------------------------
+
+// CreateMailboxSettingsCollections produces the collection holding the
+// user's mailbox settings.  Settings are small and have no delta support,
+// so every backup captures the full set and replaces the previous copy.
+func CreateMailboxSettingsCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	msg mailboxSettingsGetter,
+	tenantID string,
+	scope selectors.ExchangeScope,
+	su support.StatusUpdater,
+	counter *count.Bus,
+) ([]data.BackupCollection, error) {
+	ctx = clues.Add(ctx, "category", path.MailboxSettingsCategory)
+
+	settings := []string{}
+
+	for _, id := range MailboxSettingsItemIDs {
+		if scope.IsAny(selectors.ExchangeMailboxSetting) ||
+			scope.Matches(selectors.ExchangeMailboxSetting, id) {
+			settings = append(settings, id)
+		}
+	}
+
+	if len(settings) == 0 {
+		return nil, nil
+	}
+
+	p, err := path.BuildPrefix(
+		tenantID,
+		bpc.ProtectedResource.ID(),
+		path.ExchangeService,
+		path.MailboxSettingsCategory)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "making mailbox settings path").Label(count.BadCollPath)
+	}
+
+	cl := counter.Local()
+
+	counter.Add(count.Collections, 1)
+
+	coll := &mailboxSettingsCollection{
+		// Settings are always fetched in full.  Merging items from a
+		// previous backup would resurrect settings that no longer exist.
+		BaseCollection: data.NewBaseCollection(
+			p,
+			p,
+			&path.Builder{},
+			bpc.Options,
+			true,
+			cl),
+		user:          bpc.ProtectedResource.ID(),
+		getter:        msg,
+		settings:      settings,
+		statusUpdater: su,
+	}
+
+	return []data.BackupCollection{coll}, nil
+}
+
+type mailboxSettingsCollection struct {
+	data.BaseCollection
+
+	user          string
+	getter        mailboxSettingsGetter
+	settings      []string
+	statusUpdater support.StatusUpdater
+}
+
+func (col *mailboxSettingsCollection) Items(ctx context.Context, errs *fault.Bus) <-chan data.Item {
+	stream := make(chan data.Item, len(col.settings))
+	go col.streamItems(ctx, stream, errs)
+
+	return stream
+}
+
+func (col *mailboxSettingsCollection) streamItems(
+	ctx context.Context,
+	stream chan<- data.Item,
+	errs *fault.Bus,
+) {
+	var (
+		success    int
+		totalBytes int64
+		el         = errs.Local()
+	)
+
+	defer func() {
+		close(stream)
+		logger.Ctx(ctx).Infow(
+			"finished stream backup collection items",
+			"stats", col.Counter.Values())
+		updateStatus(
+			ctx,
+			col.statusUpdater,
+			len(col.settings),
+			success,
+			totalBytes,
+			col.FullPath().Folder(false),
+			errs.Failure())
+	}()
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		col.Category().HumanString(),
+		col.LocationPath().Elements())
+	defer close(progressMessage)
+
+	for _, id := range col.settings {
+		if el.Failure() != nil {
+			return
+		}
+
+		ictx := clues.Add(ctx, "item_id", id)
+
+		body, entries, err := getMailboxSetting(ictx, col.getter, col.user, id)
+		if err != nil {
+			// Some mailboxes (ex: shared or resource mailboxes) refuse access to
+			// individual settings.  That shouldn't fail the rest of the backup.
+			if graph.IsErrAccessDenied(err) || errors.Is(err, core.ErrNotFound) {
+				logger.CtxErr(ictx, err).Info("mailbox setting not available")
+				col.Counter.Inc(count.SkippedItems)
+
+				continue
+			}
+
+			el.AddRecoverable(ictx, clues.Wrap(err, "getting mailbox setting").
+				Label(fault.LabelForceNoBackupCreation))
+
+			continue
+		}
+
+		now := time.Now().UTC()
+		info := details.ItemInfo{
+			Exchange: &details.ExchangeInfo{
+				ItemType:       details.ExchangeMailboxSettings,
+				Setting:        id,
+				SettingEntries: entries,
+				Created:        now,
+				Modified:       now,
+				Size:           int64(len(body)),
+			},
+		}
+
+		item, err := data.NewPrefetchedItemWithInfo(
+			io.NopCloser(bytes.NewReader(body)),
+			id,
+			info)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.StackWC(ictx, err).
+				Label(fault.LabelForceNoBackupCreation))
+
+			continue
+		}
+
+		stream <- item
+
+		success++
+		totalBytes += int64(len(body))
+
+		col.Counter.Inc(count.StreamItemsAdded)
+		col.Counter.Add(count.StreamBytesAdded, int64(len(body)))
+
+		progressMessage <- struct{}{}
+	}
+}
+
+// getMailboxSetting retrieves and serializes a single kind of mailbox
+// setting.  Returns the serialized bytes and the number of entries
+// (ex: count of rules) in the setting.
+func getMailboxSetting(
+	ctx context.Context,
+	msg mailboxSettingsGetter,
+	userID, settingID string,
+) ([]byte, int, error) {
+	var (
+		parsable serialization.Parsable
+		entries  int
+	)
+
+	switch settingID {
+	case MailboxSettingsItemID:
+		settings, err := msg.GetSettings(ctx, userID)
+		if err != nil {
+			return nil, 0, clues.Stack(err)
+		}
+
+		parsable, entries = settings, 1
+
+	case InboxRulesItemID:
+		rules, err := msg.GetInboxRules(ctx, userID)
+		if err != nil {
+			return nil, 0, clues.Stack(err)
+		}
+
+		resp := models.NewMessageRuleCollectionResponse()
+		resp.SetValue(rules)
+
+		parsable, entries = resp, len(rules)
+
+	case MasterCategoriesItemID:
+		cats, err := msg.GetMasterCategories(ctx, userID)
+		if err != nil {
+			return nil, 0, clues.Stack(err)
+		}
+
+		resp := models.NewOutlookCategoryCollectionResponse()
+		resp.SetValue(cats)
+
+		parsable, entries = resp, len(cats)
+
+	case CalendarPermissionsItemID:
+		perms, err := msg.GetCalendarPermissions(ctx, userID)
+		if err != nil {
+			return nil, 0, clues.Stack(err)
+		}
+
+		resp := models.NewCalendarPermissionCollectionResponse()
+		resp.SetValue(perms)
+
+		parsable, entries = resp, len(perms)
+
+	default:
+		return nil, 0, clues.NewWC(ctx, "unknown mailbox setting")
+	}
+
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", parsable); err != nil {
+		return nil, 0, clues.WrapWC(ctx, err, "serializing mailbox setting")
+	}
+
+	bs, err := writer.GetSerializedContent()
+	if err != nil {
+		return nil, 0, clues.WrapWC(ctx, err, "serializing mailbox setting")
+	}
+
+	return bs, entries, nil
+}
+
+// ---------------------------------------------------------------------------
+// restore
+// ---------------------------------------------------------------------------
+
+// RestoreMailboxSettings restores the settings held in the collection onto
+// the resource's mailbox.  Inbox rules are recreated (subject to the
+// collision policy, keyed by rule name), master categories are merged into
+// the existing list, and automatic replies are reapplied.  Calendar
+// permissions are retained in the backup for reference, but not restored,
+// since their grantees may no longer resolve.
+func RestoreMailboxSettings(
+	ctx context.Context,
+	msr mailboxSettingsRestorer,
+	dc data.RestoreCollection,
+	resourceID string,
+	collisionPolicy control.CollisionPolicy,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	var (
+		el       = errs.Local()
+		metrics  support.CollectionMetrics
+		items    = dc.Items(ctx, errs)
+		fullPath = dc.FullPath()
+	)
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		fullPath.Category().HumanString(),
+		fullPath.Folder(false))
+	defer close(progressMessage)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return metrics, clues.WrapWC(ctx, ctx.Err(), "context cancelled")
+
+		case itemData, ok := <-items:
+			if !ok || el.Failure() != nil {
+				return metrics, el.Failure()
+			}
+
+			ictx := clues.Add(ctx, "item_id", itemData.ID())
+			metrics.Objects++
+
+			buf := &bytes.Buffer{}
+
+			if _, err := buf.ReadFrom(itemData.ToReader()); err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading item bytes"))
+				continue
+			}
+
+			body := buf.Bytes()
+
+			restored, err := restoreMailboxSetting(
+				ictx,
+				msr,
+				itemData.ID(),
+				body,
+				resourceID,
+				collisionPolicy,
+				ctr)
+			if err != nil {
+				el.AddRecoverable(ictx, clues.Wrap(err, "restoring mailbox setting"))
+				continue
+			}
+
+			metrics.Bytes += int64(len(body))
+			metrics.Successes++
+
+			itemPath, err := fullPath.AppendItem(itemData.ID())
+			if err != nil {
+				el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "adding item to collection path"))
+				continue
+			}
+
+			err = deets.Add(
+				itemPath,
+				&path.Builder{},
+				details.ItemInfo{
+					Exchange: &details.ExchangeInfo{
+						ItemType:       details.ExchangeMailboxSettings,
+						Setting:        itemData.ID(),
+						SettingEntries: restored,
+						Size:           int64(len(body)),
+					},
+				})
+			if err != nil {
+				// These deets additions are for cli display purposes only.
+				// no need to fail out on error.
+				logger.Ctx(ictx).Infow("accounting for restored item", "error", err)
+			}
+
+			progressMessage <- struct{}{}
+		}
+	}
+}
+
+// restoreMailboxSetting applies a single setting to the mailbox, returning
+// the count of entries that were created or updated.
+func restoreMailboxSetting(
+	ctx context.Context,
+	msr mailboxSettingsRestorer,
+	settingID string,
+	body []byte,
+	resourceID string,
+	collisionPolicy control.CollisionPolicy,
+	ctr *count.Bus,
+) (int, error) {
+	switch settingID {
+	case MailboxSettingsItemID:
+		v, err := api.CreateFromBytes(body, models.CreateMailboxSettingsFromDiscriminatorValue)
+		if err != nil {
+			return 0, clues.WrapWC(ctx, err, "deserializing mailbox settings")
+		}
+
+		replies := v.(models.MailboxSettingsable).GetAutomaticRepliesSetting()
+		if replies == nil {
+			return 0, nil
+		}
+
+		if err := msr.UpdateAutomaticReplies(ctx, resourceID, replies); err != nil {
+			return 0, clues.Stack(err)
+		}
+
+		return 1, nil
+
+	case InboxRulesItemID:
+		v, err := api.CreateFromBytes(body, models.CreateMessageRuleCollectionResponseFromDiscriminatorValue)
+		if err != nil {
+			return 0, clues.WrapWC(ctx, err, "deserializing inbox rules")
+		}
+
+		return restoreInboxRules(
+			ctx,
+			msr,
+			v.(models.MessageRuleCollectionResponseable).GetValue(),
+			resourceID,
+			collisionPolicy,
+			ctr)
+
+	case MasterCategoriesItemID:
+		v, err := api.CreateFromBytes(body, models.CreateOutlookCategoryCollectionResponseFromDiscriminatorValue)
+		if err != nil {
+			return 0, clues.WrapWC(ctx, err, "deserializing master categories")
+		}
+
+		return mergeMasterCategories(
+			ctx,
+			msr,
+			v.(models.OutlookCategoryCollectionResponseable).GetValue(),
+			resourceID,
+			ctr)
+
+	case CalendarPermissionsItemID:
+		logger.Ctx(ctx).Info("calendar permissions are not restored")
+		ctr.Inc(count.SkippedItems)
+
+		return 0, nil
+	}
+
+	return 0, clues.NewWC(ctx, "unknown mailbox setting")
+}
+
+func restoreInboxRules(
+	ctx context.Context,
+	msr mailboxSettingsRestorer,
+	rules []models.MessageRuleable,
+	resourceID string,
+	collisionPolicy control.CollisionPolicy,
+	ctr *count.Bus,
+) (int, error) {
+	current, err := msr.GetInboxRules(ctx, resourceID)
+	if err != nil {
+		return 0, clues.Wrap(err, "getting existing inbox rules")
+	}
+
+	existing := map[string]string{}
+
+	for _, r := range current {
+		existing[strings.ToLower(ptr.Val(r.GetDisplayName()))] = ptr.Val(r.GetId())
+	}
+
+	var restored int
+
+	for _, r := range rules {
+		name := ptr.Val(r.GetDisplayName())
+		ictx := clues.Add(ctx, "rule_name", clues.Hide(name))
+
+		if id, ok := existing[strings.ToLower(name)]; ok {
+			switch collisionPolicy {
+			case control.Skip:
+				ctr.Inc(count.CollisionSkip)
+				continue
+
+			case control.Replace:
+				if err := msr.DeleteInboxRule(ictx, resourceID, id); err != nil {
+					return restored, clues.Wrap(err, "deleting colliding inbox rule")
+				}
+
+				ctr.Inc(count.CollisionReplace)
+			}
+		}
+
+		if _, err := msr.PostInboxRule(ictx, resourceID, toRestorableRule(r)); err != nil {
+			return restored, clues.Stack(err)
+		}
+
+		ctr.Inc(count.NewItemCreated)
+
+		restored++
+	}
+
+	return restored, nil
+}
+
+// toRestorableRule strips the server-assigned properties from a backed
+// up rule so that it can be posted as a new rule.
+func toRestorableRule(r models.MessageRuleable) models.MessageRuleable {
+	r.SetId(nil)
+	r.SetHasError(nil)
+	r.SetIsReadOnly(nil)
+
+	return r
+}
+
+func mergeMasterCategories(
+	ctx context.Context,
+	msr mailboxSettingsRestorer,
+	cats []models.OutlookCategoryable,
+	resourceID string,
+	ctr *count.Bus,
+) (int, error) {
+	current, err := msr.GetMasterCategories(ctx, resourceID)
+	if err != nil {
+		return 0, clues.Wrap(err, "getting existing master categories")
+	}
+
+	existing := map[string]struct{}{}
+
+	for _, c := range current {
+		existing[strings.ToLower(ptr.Val(c.GetDisplayName()))] = struct{}{}
+	}
+
+	var restored int
+
+	for _, c := range cats {
+		name := ptr.Val(c.GetDisplayName())
+
+		// category names are unique within a mailbox; the existing
+		// category always wins.
+		if _, ok := existing[strings.ToLower(name)]; ok {
+			ctr.Inc(count.CollisionSkip)
+			continue
+		}
+
+		body := models.NewOutlookCategory()
+		body.SetDisplayName(c.GetDisplayName())
+		body.SetColor(c.GetColor())
+
+		if _, err := msr.PostMasterCategory(ctx, resourceID, body); err != nil {
+			return restored, clues.Stack(err)
+		}
+
+		existing[strings.ToLower(name)] = struct{}{}
+
+		ctr.Inc(count.NewItemCreated)
+
+		restored++
+	}
+
+	return restored, nil
+}
@@ -0,0 +1,204 @@
+package exchange
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+)
+
+var _ mailboxSettingsRestorer = &mailboxSettingsRestoreMock{}
+
+type mailboxSettingsRestoreMock struct {
+	rules         []models.MessageRuleable
+	categories    []models.OutlookCategoryable
+	postedRules   []string
+	deletedRules  []string
+	postedCats    []string
+	updatedReply  bool
+	postItemErr   error
+	deleteItemErr error
+}
+
+func (m *mailboxSettingsRestoreMock) GetInboxRules(
+	_ context.Context,
+	_ string,
+) ([]models.MessageRuleable, error) {
+	return m.rules, nil
+}
+
+func (m *mailboxSettingsRestoreMock) PostInboxRule(
+	_ context.Context,
+	_ string,
+	body models.MessageRuleable,
+) (models.MessageRuleable, error) {
+	m.postedRules = append(m.postedRules, ptr.Val(body.GetDisplayName()))
+	return body, m.postItemErr
+}
+
+func (m *mailboxSettingsRestoreMock) DeleteInboxRule(
+	_ context.Context,
+	_, ruleID string,
+) error {
+	m.deletedRules = append(m.deletedRules, ruleID)
+	return m.deleteItemErr
+}
+
+func (m *mailboxSettingsRestoreMock) GetMasterCategories(
+	_ context.Context,
+	_ string,
+) ([]models.OutlookCategoryable, error) {
+	return m.categories, nil
+}
+
+func (m *mailboxSettingsRestoreMock) PostMasterCategory(
+	_ context.Context,
+	_ string,
+	body models.OutlookCategoryable,
+) (models.OutlookCategoryable, error) {
+	m.postedCats = append(m.postedCats, ptr.Val(body.GetDisplayName()))
+	return body, m.postItemErr
+}
+
+func (m *mailboxSettingsRestoreMock) UpdateAutomaticReplies(
+	_ context.Context,
+	_ string,
+	_ models.AutomaticRepliesSettingable,
+) error {
+	m.updatedReply = true
+	return nil
+}
+
+func stubRule(id, name string) models.MessageRuleable {
+	r := models.NewMessageRule()
+	r.SetId(ptr.To(id))
+	r.SetDisplayName(ptr.To(name))
+
+	return r
+}
+
+func stubCategory(name string) models.OutlookCategoryable {
+	c := models.NewOutlookCategory()
+	c.SetDisplayName(ptr.To(name))
+
+	return c
+}
+
+// ---------------------------------------------------------------------------
+// tests
+// ---------------------------------------------------------------------------
+
+type MailboxSettingsUnitSuite struct {
+	tester.Suite
+}
+
+func TestMailboxSettingsUnitSuite(t *testing.T) {
+	suite.Run(t, &MailboxSettingsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *MailboxSettingsUnitSuite) TestRestoreInboxRules() {
+	table := []struct {
+		name           string
+		policy         control.CollisionPolicy
+		expectPosted   []string
+		expectDeleted  []string
+		expectRestored int
+		expectSkips    int64
+	}{
+		{
+			name:           "skip",
+			policy:         control.Skip,
+			expectPosted:   []string{"new"},
+			expectRestored: 1,
+			expectSkips:    1,
+		},
+		{
+			name:           "replace",
+			policy:         control.Replace,
+			expectPosted:   []string{"Existing", "new"},
+			expectDeleted:  []string{"old-id"},
+			expectRestored: 2,
+		},
+		{
+			name:           "copy",
+			policy:         control.Copy,
+			expectPosted:   []string{"Existing", "new"},
+			expectRestored: 2,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				ctr = count.New()
+				msr = &mailboxSettingsRestoreMock{
+					rules: []models.MessageRuleable{stubRule("old-id", "existing")},
+				}
+				backup = []models.MessageRuleable{
+					stubRule("bid-1", "Existing"),
+					stubRule("bid-2", "new"),
+				}
+			)
+
+			n, err := restoreInboxRules(ctx, msr, backup, "uid", test.policy, ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectRestored, n)
+			assert.Equal(t, test.expectPosted, msr.postedRules)
+			assert.Equal(t, test.expectDeleted, msr.deletedRules)
+			assert.Equal(t, test.expectSkips, ctr.Get(count.CollisionSkip))
+		})
+	}
+}
+
+func (suite *MailboxSettingsUnitSuite) TestToRestorableRule() {
+	t := suite.T()
+
+	r := stubRule("id", "name")
+	r.SetHasError(ptr.To(true))
+	r.SetIsReadOnly(ptr.To(true))
+
+	result := toRestorableRule(r)
+	assert.Nil(t, result.GetId())
+	assert.Nil(t, result.GetHasError())
+	assert.Nil(t, result.GetIsReadOnly())
+	assert.Equal(t, "name", ptr.Val(result.GetDisplayName()))
+}
+
+func (suite *MailboxSettingsUnitSuite) TestMergeMasterCategories() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		ctr = count.New()
+		msr = &mailboxSettingsRestoreMock{
+			categories: []models.OutlookCategoryable{stubCategory("red")},
+		}
+		backup = []models.OutlookCategoryable{
+			stubCategory("Red"),
+			stubCategory("blue"),
+		}
+	)
+
+	n, err := mergeMasterCategories(ctx, msr, backup, "uid", ctr)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"blue"}, msr.postedCats)
+	assert.Equal(t, int64(1), ctr.Get(count.CollisionSkip))
+}
@@ -0,0 +1,279 @@
+package exchange
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// itemPlanner produces the collision key and display name of a
+// serialized item, so that a restore can be planned without posting it.
+type itemPlanner interface {
+	collisionKeyAndName(body []byte) (string, string, error)
+}
+
+func (h mailRestoreHandler) collisionKeyAndName(body []byte) (string, string, error) {
+	msg, err := api.BytesToMessageable(body)
+	if err != nil {
+		return "", "", clues.Wrap(err, "creating mail from bytes")
+	}
+
+	return api.MailCollisionKey(msg), ptr.Val(msg.GetSubject()), nil
+}
+
+func (h contactRestoreHandler) collisionKeyAndName(body []byte) (string, string, error) {
+	contact, err := api.BytesToContactable(body)
+	if err != nil {
+		return "", "", clues.Wrap(err, "creating contact from bytes")
+	}
+
+	return api.ContactCollisionKey(contact), ptr.Val(contact.GetDisplayName()), nil
+}
+
+func (h eventRestoreHandler) collisionKeyAndName(body []byte) (string, string, error) {
+	event, err := api.BytesToEventable(body)
+	if err != nil {
+		return "", "", clues.Wrap(err, "creating event from bytes")
+	}
+
+	return api.EventCollisionKey(event), ptr.Val(event.GetSubject()), nil
+}
+
+// PlanDestination resolves the container that the collection would be
+// restored into, without creating it.  Returns an empty container ID if
+// any folder along the restore path doesn't exist yet.
+func PlanDestination(
+	handler restoreHandler,
+	gcr graph.ContainerResolver,
+	restoreFolderPath *path.Builder,
+	collectionPath path.Path,
+) string {
+	if handler.ShouldSetContainerToDefaultRoot(restoreFolderPath.String(), collectionPath) {
+		return handler.DefaultRootContainer()
+	}
+
+	containerID, _ := gcr.LocationInCache(restoreFolderPath.String())
+
+	return containerID
+}
+
+// PlanCollection records the action that restoring each item in the
+// collection would take.  Items are compared against the collision keys
+// of the items already present in the restore destination.
+func PlanCollection(
+	ctx context.Context,
+	handler restoreHandler,
+	dc data.RestoreCollection,
+	restoreFolderPath *path.Builder,
+	collisionKeyToItemID map[string]string,
+	collisionPolicy control.CollisionPolicy,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	var (
+		el    = errs.Local()
+		items = dc.Items(ctx, errs)
+	)
+
+	for itemData := range items {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx, "item_id", itemData.ID())
+		buf := &bytes.Buffer{}
+
+		_, err := buf.ReadFrom(itemData.ToReader())
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading item bytes"))
+			continue
+		}
+
+		key, name, err := handler.collisionKeyAndName(buf.Bytes())
+		if err != nil {
+			el.AddRecoverable(ictx, clues.StackWC(ictx, err))
+			continue
+		}
+
+		if len(name) == 0 {
+			name = itemData.ID()
+		}
+
+		_, collides := collisionKeyToItemID[key]
+
+		p.Add(plan.Item{
+			Action:      plan.ForRestore(collisionPolicy, collides),
+			Destination: restoreFolderPath.Append(name).String(),
+			Size:        int64(buf.Len()),
+		})
+	}
+
+	return el.Failure()
+}
+
+// mailboxSettingsPlanner looks up the settings that a mailbox settings
+// restore compares against.
+type mailboxSettingsPlanner interface {
+	GetInboxRules(ctx context.Context, userID string) ([]models.MessageRuleable, error)
+	GetMasterCategories(ctx context.Context, userID string) ([]models.OutlookCategoryable, error)
+}
+
+// PlanMailboxSettings records the action that restoring each setting in
+// the collection would take.  Inbox rules are compared by name against the
+// mailbox's rules, and master categories against its categories.
+func PlanMailboxSettings(
+	ctx context.Context,
+	msp mailboxSettingsPlanner,
+	dc data.RestoreCollection,
+	resourceID string,
+	collisionPolicy control.CollisionPolicy,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	var (
+		el   = errs.Local()
+		root = path.Builder{}.Append(path.MailboxSettingsCategory.HumanString())
+	)
+
+	for itemData := range dc.Items(ctx, errs) {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx, "item_id", itemData.ID())
+		buf := &bytes.Buffer{}
+
+		_, err := buf.ReadFrom(itemData.ToReader())
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading item bytes"))
+			continue
+		}
+
+		err = planMailboxSetting(
+			ictx,
+			msp,
+			itemData.ID(),
+			buf.Bytes(),
+			resourceID,
+			collisionPolicy,
+			root.Append(itemData.ID()),
+			p)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "planning mailbox setting"))
+		}
+	}
+
+	return el.Failure()
+}
+
+func planMailboxSetting(
+	ctx context.Context,
+	msp mailboxSettingsPlanner,
+	settingID string,
+	body []byte,
+	resourceID string,
+	collisionPolicy control.CollisionPolicy,
+	dest *path.Builder,
+	p *plan.Plan,
+) error {
+	switch settingID {
+	case MailboxSettingsItemID:
+		v, err := api.CreateFromBytes(body, models.CreateMailboxSettingsFromDiscriminatorValue)
+		if err != nil {
+			return clues.WrapWC(ctx, err, "deserializing mailbox settings")
+		}
+
+		if v.(models.MailboxSettingsable).GetAutomaticRepliesSetting() != nil {
+			p.Add(plan.Item{
+				Action:      plan.Replace,
+				Destination: dest.Append("automaticReplies").String(),
+				Size:        int64(len(body)),
+			})
+		}
+
+	case InboxRulesItemID:
+		v, err := api.CreateFromBytes(body, models.CreateMessageRuleCollectionResponseFromDiscriminatorValue)
+		if err != nil {
+			return clues.WrapWC(ctx, err, "deserializing inbox rules")
+		}
+
+		current, err := msp.GetInboxRules(ctx, resourceID)
+		if err != nil {
+			return clues.Wrap(err, "getting existing inbox rules")
+		}
+
+		existing := map[string]struct{}{}
+
+		for _, r := range current {
+			existing[strings.ToLower(ptr.Val(r.GetDisplayName()))] = struct{}{}
+		}
+
+		for _, r := range v.(models.MessageRuleCollectionResponseable).GetValue() {
+			name := ptr.Val(r.GetDisplayName())
+			_, collides := existing[strings.ToLower(name)]
+
+			p.Add(plan.Item{
+				Action:      plan.ForRestore(collisionPolicy, collides),
+				Destination: dest.Append(name).String(),
+			})
+		}
+
+	case MasterCategoriesItemID:
+		v, err := api.CreateFromBytes(body, models.CreateOutlookCategoryCollectionResponseFromDiscriminatorValue)
+		if err != nil {
+			return clues.WrapWC(ctx, err, "deserializing master categories")
+		}
+
+		current, err := msp.GetMasterCategories(ctx, resourceID)
+		if err != nil {
+			return clues.Wrap(err, "getting existing master categories")
+		}
+
+		existing := map[string]struct{}{}
+
+		for _, c := range current {
+			existing[strings.ToLower(ptr.Val(c.GetDisplayName()))] = struct{}{}
+		}
+
+		// categories are merged, so the existing category always wins.
+		for _, c := range v.(models.OutlookCategoryCollectionResponseable).GetValue() {
+			name := ptr.Val(c.GetDisplayName())
+			action := plan.Create
+
+			if _, ok := existing[strings.ToLower(name)]; ok {
+				action = plan.Skip
+			}
+
+			existing[strings.ToLower(name)] = struct{}{}
+
+			p.Add(plan.Item{
+				Action:      action,
+				Destination: dest.Append(name).String(),
+			})
+		}
+
+	case CalendarPermissionsItemID:
+		p.Add(plan.Item{
+			Action:      plan.Skip,
+			Destination: dest.String(),
+			Size:        int64(len(body)),
+		})
+
+	default:
+		return clues.NewWC(ctx, "unknown mailbox setting")
+	}
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package exchange
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	exchMock "github.com/alcionai/corso/src/internal/m365/service/exchange/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type PlanUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlanUnitSuite(t *testing.T) {
+	suite.Run(t, &PlanUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func planItem(id string, body []byte) data.Item {
+	return &dataMock.Item{
+		ItemID: id,
+		Reader: io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func (suite *PlanUnitSuite) TestPlanCollection() {
+	var (
+		collides = exchMock.ContactBytes("collides")
+		fresh    = exchMock.ContactBytes("fresh")
+		restore  = path.Builder{}.Append("Corso_Restore", "contacts")
+	)
+
+	stub, err := api.BytesToContactable(collides)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	collisionMap := map[string]string{
+		api.ContactCollisionKey(stub): "existing-id",
+	}
+
+	table := []struct {
+		name         string
+		onCollision  control.CollisionPolicy
+		expectAction plan.Action
+	}{
+		{
+			name:         "skip",
+			onCollision:  control.Skip,
+			expectAction: plan.Skip,
+		},
+		{
+			name:         "copy",
+			onCollision:  control.Copy,
+			expectAction: plan.Copy,
+		},
+		{
+			name:         "replace",
+			onCollision:  control.Replace,
+			expectAction: plan.Replace,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				p  = plan.New()
+				dc = dataMock.Collection{
+					ItemData: []data.Item{
+						planItem("collides", collides),
+						planItem("fresh", fresh),
+					},
+				}
+			)
+
+			err := PlanCollection(
+				ctx,
+				newContactRestoreHandler(api.Client{}),
+				dc,
+				restore,
+				collisionMap,
+				test.onCollision,
+				p,
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			items := p.Items()
+			require.Len(t, items, 2)
+
+			actions := map[int64]plan.Action{}
+
+			for _, item := range items {
+				assert.Contains(t, item.Destination, restore.String())
+				actions[item.Size] = item.Action
+			}
+
+			assert.Equal(t, test.expectAction, actions[int64(len(collides))], "colliding item")
+			assert.Equal(t, plan.Create, actions[int64(len(fresh))], "new item")
+		})
+	}
+}
+
+func serializeSetting(t *testing.T, v serialization.Parsable) []byte {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	err := writer.WriteObjectValue("", v)
+	require.NoError(t, err, clues.ToCore(err))
+
+	bs, err := writer.GetSerializedContent()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return bs
+}
+
+func (suite *PlanUnitSuite) TestPlanMailboxSettings() {
+	t := suite.T()
+
+	rules := models.NewMessageRuleCollectionResponse()
+	rules.SetValue([]models.MessageRuleable{
+		stubRule("1", "Existing"),
+		stubRule("2", "Fresh"),
+	})
+
+	cats := models.NewOutlookCategoryCollectionResponse()
+	cats.SetValue([]models.OutlookCategoryable{
+		stubCategory("Red"),
+		stubCategory("Blue"),
+	})
+
+	var (
+		rulesBody = serializeSetting(t, rules)
+		catsBody  = serializeSetting(t, cats)
+		msp       = &mailboxSettingsRestoreMock{
+			rules:      []models.MessageRuleable{stubRule("a", "existing")},
+			categories: []models.OutlookCategoryable{stubCategory("red")},
+		}
+	)
+
+	table := []struct {
+		name        string
+		onCollision control.CollisionPolicy
+		expect      map[string]plan.Action
+	}{
+		{
+			name:        "skip",
+			onCollision: control.Skip,
+			expect: map[string]plan.Action{
+				"Existing": plan.Skip,
+				"Fresh":    plan.Create,
+				"Red":      plan.Skip,
+				"Blue":     plan.Create,
+			},
+		},
+		{
+			name:        "copy",
+			onCollision: control.Copy,
+			expect: map[string]plan.Action{
+				"Existing": plan.Copy,
+				"Fresh":    plan.Create,
+				"Red":      plan.Skip,
+				"Blue":     plan.Create,
+			},
+		},
+		{
+			name:        "replace",
+			onCollision: control.Replace,
+			expect: map[string]plan.Action{
+				"Existing": plan.Replace,
+				"Fresh":    plan.Create,
+				"Red":      plan.Skip,
+				"Blue":     plan.Create,
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				p  = plan.New()
+				dc = dataMock.Collection{
+					ItemData: []data.Item{
+						planItem(InboxRulesItemID, rulesBody),
+						planItem(MasterCategoriesItemID, catsBody),
+					},
+				}
+			)
+
+			err := PlanMailboxSettings(
+				ctx,
+				msp,
+				dc,
+				"user",
+				test.onCollision,
+				p,
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			items := p.Items()
+			require.Len(t, items, len(test.expect))
+
+			for _, item := range items {
+				pb, err := path.Builder{}.SplitUnescapeAppend(item.Destination)
+				require.NoError(t, err, clues.ToCore(err))
+
+				assert.Equal(t, test.expect[pb.LastElem()], item.Action, item.Destination)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	exchMock "github.com/alcionai/corso/src/internal/m365/service/exchange/mock"
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/services/m365/api"
 )
 
@@ -58,6 +59,30 @@ func (suite *TransformUnitTest) TestToEventSimplified_attendees() {
 	}
 }
 
+func (suite *TransformUnitTest) TestTranslateAttendees() {
+	t := suite.T()
+	bytes := exchMock.EventWithAttendeesBytes("M365 Event Support Test")
+	event, err := api.BytesToEventable(bytes)
+	require.NoError(t, err, clues.ToCore(err))
+
+	principals := control.NewPrincipalMap(map[string]string{
+		"george.martinez@8qzvrj.onmicrosoft.com": "george@target.com",
+		"leeg@8qzvrj.onmicrosoft.com":            "lee-gu-target-id",
+	})
+
+	translateAttendees(event, principals)
+
+	attendees := event.GetAttendees()
+	require.Len(t, attendees, 2)
+	assert.Equal(t, "george@target.com", ptr.Val(attendees[0].GetEmailAddress().GetAddress()))
+	assert.Equal(t, "George Martinez", ptr.Val(attendees[0].GetEmailAddress().GetName()))
+	// attendees can only be addressed by email
+	assert.Equal(t, "LeeG@8qzvrj.onmicrosoft.com", ptr.Val(attendees[1].GetEmailAddress().GetAddress()))
+
+	newEvent := toEventSimplified(event)
+	assert.Contains(t, ptr.Val(newEvent.GetBody().GetContent()), "george@target.com")
+}
+
 func (suite *TransformUnitTest) TestToEventSimplified_noAdditionalRemovedFields() {
 	t := suite.T()
 
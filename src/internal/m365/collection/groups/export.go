@@ -3,8 +3,11 @@ package groups
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/alcionai/corso/src/internal/data"
 	groupMeta "github.com/alcionai/corso/src/internal/m365/collection/groups/metadata"
 	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/dttm"
 	"github.com/alcionai/corso/src/pkg/export"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
@@ -40,6 +44,8 @@ func NewExportCollection(
 		streamItems = streamChannelMessages
 	case path.ConversationPostsCategory:
 		streamItems = streamConversationPosts
+	case path.PlannerCategory:
+		streamItems = streamPlanner
 	default:
 		return nil
 	}
@@ -87,9 +93,10 @@ func streamChannelMessages(
 				name := item.ID() + ".json"
 
 				ch <- export.Item{
-					ID:   item.ID(),
-					Name: name,
-					Body: body,
+					ID:      item.ID(),
+					Name:    name,
+					Body:    body,
+					ModTime: export.ModTimeOf(item),
 				}
 			}
 		}
@@ -294,9 +301,10 @@ func streamConversationPosts(
 			body := metrics.ReaderWithStats(emlReader, path.ConversationPostsCategory, stats)
 
 			ch <- export.Item{
-				ID:   item.ID(),
-				Name: exportName,
-				Body: body,
+				ID:      item.ID(),
+				Name:    exportName,
+				Body:    body,
+				ModTime: export.ModTimeOf(item),
 			}
 		}
 
@@ -361,3 +369,375 @@ func readMetadata(metaRC io.ReadCloser) (groupMeta.ConversationPostMetadata, err
 
 	return meta, nil
 }
+
+//-------------------------------------------------------------
+// Planner
+//-------------------------------------------------------------
+
+const (
+	planExportName     = "plan.json"
+	plannerCSVFileName = "tasks.csv"
+)
+
+var plannerCSVHeader = []string{
+	"Task ID",
+	"Title",
+	"Bucket",
+	"Assignees",
+	"Start",
+	"Due",
+	"Percent Complete",
+	"Priority",
+	"Created",
+	"Completed",
+	"Description",
+}
+
+type (
+	minimumPlan struct {
+		ID         string            `json:"id"`
+		Title      string            `json:"title"`
+		Created    time.Time         `json:"createdDateTime"`
+		Buckets    []string          `json:"buckets"`
+		Categories map[string]string `json:"categories,omitempty"`
+	}
+
+	minimumTask struct {
+		ID              string                 `json:"id"`
+		Title           string                 `json:"title"`
+		Bucket          string                 `json:"bucket,omitempty"`
+		Assignees       []string               `json:"assignees"`
+		Start           *time.Time             `json:"startDateTime,omitempty"`
+		Due             *time.Time             `json:"dueDateTime,omitempty"`
+		PercentComplete int32                  `json:"percentComplete"`
+		Priority        int32                  `json:"priority"`
+		Created         time.Time              `json:"createdDateTime"`
+		Completed       *time.Time             `json:"completedDateTime,omitempty"`
+		Description     string                 `json:"description,omitempty"`
+		Checklist       []minimumChecklistItem `json:"checklist,omitempty"`
+	}
+
+	minimumChecklistItem struct {
+		Title     string `json:"title"`
+		IsChecked bool   `json:"isChecked"`
+	}
+)
+
+// streamPlanner adds the plans and tasks into the export stream channel.
+// Each collection holds a single plan.  JSON exports produce the raw plan
+// and task items; CSV exports produce a single table of tasks per plan;
+// the default produces a minimized json file for the plan and each task.
+func streamPlanner(
+	ctx context.Context,
+	drc []data.RestoreCollection,
+	backupVersion int,
+	cec control.ExportConfig,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	defer close(ch)
+
+	errs := fault.New(false)
+
+	for _, rc := range drc {
+		var (
+			planID = rc.FullPath().Folder(false)
+			plan   models.PlannerPlanable
+			tasks  = []models.PlannerTaskable{}
+		)
+
+		for item := range rc.Items(ctx, errs) {
+			body, err := readItemBytes(item)
+			if err != nil {
+				ch <- export.Item{ID: item.ID(), Error: err}
+				continue
+			}
+
+			if cec.Format == control.JSONFormat {
+				name := item.ID() + ".json"
+				if item.ID() == planID {
+					name = planExportName
+				}
+
+				stats.UpdateResourceCount(path.PlannerCategory)
+
+				ch <- export.Item{
+					ID:   item.ID(),
+					Name: name,
+					Body: metrics.ReaderWithStats(
+						io.NopCloser(bytes.NewReader(body)),
+						path.PlannerCategory,
+						stats),
+				}
+
+				continue
+			}
+
+			if item.ID() == planID {
+				plan, err = DeserializePlan(body)
+			} else {
+				var task models.PlannerTaskable
+
+				task, err = DeserializeTask(body)
+				if err == nil {
+					tasks = append(tasks, task)
+				}
+			}
+
+			if err != nil {
+				ch <- export.Item{ID: item.ID(), Error: err}
+			}
+		}
+
+		if cec.Format != control.JSONFormat {
+			exportPlan(ctx, rc, plan, tasks, cec, ch, stats)
+		}
+
+		items, recovered := errs.ItemsAndRecovered()
+
+		// Return all the items that we failed to source from the persistence layer
+		for _, item := range items {
+			ch <- export.Item{
+				ID:    item.ID,
+				Error: &item,
+			}
+		}
+
+		for _, err := range recovered {
+			ch <- export.Item{
+				Error: err,
+			}
+		}
+	}
+}
+
+// exportPlan produces the formatted plan and tasks.  If the plan item
+// wasn't selected for export, it gets fetched from the collection in
+// order to name the buckets holding each task.
+func exportPlan(
+	ctx context.Context,
+	rc data.RestoreCollection,
+	plan models.PlannerPlanable,
+	tasks []models.PlannerTaskable,
+	cec control.ExportConfig,
+	ch chan<- export.Item,
+	stats *metrics.ExportStats,
+) {
+	planID := rc.FullPath().Folder(false)
+	sendPlan := plan != nil
+
+	if plan == nil {
+		item, err := rc.FetchItemByName(ctx, planID)
+		if err != nil {
+			ch <- export.Item{ID: planID, Error: clues.WrapWC(ctx, err, "fetching plan")}
+			return
+		}
+
+		body, err := readItemBytes(item)
+		if err == nil {
+			plan, err = DeserializePlan(body)
+		}
+
+		if err != nil {
+			ch <- export.Item{ID: planID, Error: err}
+			return
+		}
+	}
+
+	bucketNames := map[string]string{}
+
+	for _, b := range plan.GetBuckets() {
+		bucketNames[ptr.Val(b.GetId())] = ptr.Val(b.GetName())
+	}
+
+	send := func(id, name string, body []byte) {
+		stats.UpdateResourceCount(path.PlannerCategory)
+
+		ch <- export.Item{
+			ID:   id,
+			Name: name,
+			Body: metrics.ReaderWithStats(
+				io.NopCloser(bytes.NewReader(body)),
+				path.PlannerCategory,
+				stats),
+		}
+	}
+
+	if cec.Format == control.CSVFormat {
+		body, err := formatTasksCSV(tasks, bucketNames)
+		if err != nil {
+			ch <- export.Item{ID: planID, Error: err}
+			return
+		}
+
+		send(planID, plannerCSVFileName, body)
+
+		return
+	}
+
+	if sendPlan {
+		body, err := marshalJSONContainingHTML(makeMinimumPlan(plan))
+		if err != nil {
+			ch <- export.Item{ID: planID, Error: clues.Wrap(err, "serializing minimized plan")}
+		} else {
+			send(planID, planExportName, body)
+		}
+	}
+
+	for _, task := range tasks {
+		taskID := ptr.Val(task.GetId())
+
+		body, err := marshalJSONContainingHTML(makeMinimumTask(task, bucketNames))
+		if err != nil {
+			ch <- export.Item{ID: taskID, Error: clues.Wrap(err, "serializing minimized task")}
+			continue
+		}
+
+		send(taskID, taskID+".json", body)
+	}
+}
+
+func makeMinimumPlan(plan models.PlannerPlanable) minimumPlan {
+	mp := minimumPlan{
+		ID:      ptr.Val(plan.GetId()),
+		Title:   ptr.Val(plan.GetTitle()),
+		Created: ptr.Val(plan.GetCreatedDateTime()),
+		Buckets: make([]string, 0, len(plan.GetBuckets())),
+	}
+
+	for _, b := range plan.GetBuckets() {
+		mp.Buckets = append(mp.Buckets, ptr.Val(b.GetName()))
+	}
+
+	if plan.GetDetails() != nil && plan.GetDetails().GetCategoryDescriptions() != nil {
+		cd := plan.GetDetails().GetCategoryDescriptions()
+		mp.Categories = map[string]string{}
+
+		for k, v := range map[string]*string{
+			"category1": cd.GetCategory1(),
+			"category2": cd.GetCategory2(),
+			"category3": cd.GetCategory3(),
+			"category4": cd.GetCategory4(),
+			"category5": cd.GetCategory5(),
+			"category6": cd.GetCategory6(),
+		} {
+			if len(ptr.Val(v)) > 0 {
+				mp.Categories[k] = ptr.Val(v)
+			}
+		}
+	}
+
+	return mp
+}
+
+func makeMinimumTask(
+	task models.PlannerTaskable,
+	bucketNames map[string]string,
+) minimumTask {
+	mt := minimumTask{
+		ID:              ptr.Val(task.GetId()),
+		Title:           ptr.Val(task.GetTitle()),
+		Bucket:          bucketNames[ptr.Val(task.GetBucketId())],
+		Assignees:       taskAssignees(task),
+		Start:           task.GetStartDateTime(),
+		Due:             task.GetDueDateTime(),
+		PercentComplete: ptr.Val(task.GetPercentComplete()),
+		Priority:        ptr.Val(task.GetPriority()),
+		Created:         ptr.Val(task.GetCreatedDateTime()),
+		Completed:       task.GetCompletedDateTime(),
+	}
+
+	if mt.Assignees == nil {
+		mt.Assignees = []string{}
+	}
+
+	td := task.GetDetails()
+	if td == nil {
+		return mt
+	}
+
+	mt.Description = ptr.Val(td.GetDescription())
+
+	if td.GetChecklist() == nil {
+		return mt
+	}
+
+	type orderedItem struct {
+		minimumChecklistItem
+		order string
+	}
+
+	items := []orderedItem{}
+
+	for k, v := range td.GetChecklist().GetAdditionalData() {
+		props, ok := v.(map[string]any)
+		if !ok || strings.HasPrefix(k, "@") {
+			continue
+		}
+
+		oi := orderedItem{}
+		oi.Title, _ = props["title"].(string)
+		oi.IsChecked, _ = props["isChecked"].(bool)
+		oi.order, _ = props["orderHint"].(string)
+
+		items = append(items, oi)
+	}
+
+	// planner orders checklist items by comparing order hints.
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].order < items[j].order
+	})
+
+	for _, oi := range items {
+		mt.Checklist = append(mt.Checklist, oi.minimumChecklistItem)
+	}
+
+	return mt
+}
+
+func formatTasksCSV(
+	tasks []models.PlannerTaskable,
+	bucketNames map[string]string,
+) ([]byte, error) {
+	var (
+		buf = &bytes.Buffer{}
+		w   = csv.NewWriter(buf)
+	)
+
+	if err := w.Write(plannerCSVHeader); err != nil {
+		return nil, clues.Wrap(err, "writing csv header")
+	}
+
+	for _, task := range tasks {
+		mt := makeMinimumTask(task, bucketNames)
+
+		err := w.Write([]string{
+			mt.ID,
+			mt.Title,
+			mt.Bucket,
+			strings.Join(mt.Assignees, ";"),
+			csvTime(mt.Start),
+			csvTime(mt.Due),
+			strconv.Itoa(int(mt.PercentComplete)),
+			strconv.Itoa(int(mt.Priority)),
+			csvTime(&mt.Created),
+			csvTime(mt.Completed),
+			mt.Description,
+		})
+		if err != nil {
+			return nil, clues.Wrap(err, "writing csv row")
+		}
+	}
+
+	w.Flush()
+
+	return buf.Bytes(), clues.Wrap(w.Error(), "flushing csv").OrNil()
+}
+
+func csvTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+
+	return dttm.FormatToTabularDisplay(*t)
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -274,3 +275,101 @@ func (suite *ExportUnitSuite) TestStreamConversationPosts() {
 		})
 	}
 }
+
+func (suite *ExportUnitSuite) TestStreamPlanner() {
+	testPath, err := path.Build(
+		"t",
+		"g",
+		path.GroupsService,
+		path.PlannerCategory,
+		false,
+		"p1")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	plan := makePlan("p1", "Launch")
+	plan.SetBuckets([]models.PlannerBucketable{makeBucket("b1", "To do")})
+
+	table := []struct {
+		name         string
+		format       control.FormatType
+		includePlan  bool
+		expectNames  []string
+		expectInBody string
+	}{
+		{
+			name:         "default",
+			includePlan:  true,
+			expectNames:  []string{"plan.json", "t1.json"},
+			expectInBody: `"bucket":"To do"`,
+		},
+		{
+			name:         "default, tasks only",
+			expectNames:  []string{"t1.json"},
+			expectInBody: `"bucket":"To do"`,
+		},
+		{
+			name:         "json",
+			format:       control.JSONFormat,
+			includePlan:  true,
+			expectNames:  []string{"plan.json", "t1.json"},
+			expectInBody: `"bucketId":"b1"`,
+		},
+		{
+			name:         "csv",
+			format:       control.CSVFormat,
+			expectNames:  []string{"tasks.csv"},
+			expectInBody: "t1,Write spec,To do,u1",
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			coll := dataMock.Collection{
+				Path: testPath,
+				ItemData: []data.Item{
+					serializedItem(t, "t1", makeTask("t1", "Write spec", "b1", "u1")),
+				},
+				AuxItems: map[string]data.Item{
+					"p1": serializedItem(t, "p1", plan),
+				},
+			}
+
+			if test.includePlan {
+				coll.ItemData = append(coll.ItemData, serializedItem(t, "p1", plan))
+			}
+
+			ch := make(chan export.Item)
+
+			go streamPlanner(
+				ctx,
+				[]data.RestoreCollection{coll},
+				version.NoBackup,
+				control.ExportConfig{Format: test.format},
+				ch,
+				&metrics.ExportStats{})
+
+			var (
+				names  = []string{}
+				bodies = ""
+			)
+
+			for i := range ch {
+				require.NoError(t, i.Error, clues.ToCore(i.Error))
+
+				bs, err := io.ReadAll(i.Body)
+				require.NoError(t, err, clues.ToCore(err))
+
+				names = append(names, i.Name)
+				bodies += string(bs)
+			}
+
+			assert.ElementsMatch(t, test.expectNames, names)
+			assert.Contains(t, bodies, test.expectInBody)
+		})
+	}
+}
@@ -0,0 +1,908 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoft/kiota-abstractions-go/serialization"
+	kjson "github.com/microsoft/kiota-serialization-json-go"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+// Each plan is stored as its own collection within the planner category.
+// The collection holds one item for the plan itself (named by the plan
+// ID, and containing the plan details and buckets), and one item per task
+// (named by the task ID, and containing the task details).
+
+var _ data.BackupCollection = &plannerCollection{}
+
+type plannerGetter interface {
+	GetPlans(ctx context.Context, groupID string) ([]models.PlannerPlanable, error)
+	GetPlanDetails(ctx context.Context, planID string) (models.PlannerPlanDetailsable, error)
+	GetBuckets(ctx context.Context, planID string) ([]models.PlannerBucketable, error)
+	GetTasks(ctx context.Context, planID string) ([]models.PlannerTaskable, error)
+	GetTaskDetails(ctx context.Context, taskID string) (models.PlannerTaskDetailsable, error)
+}
+
+type plannerRestorer interface {
+	GetPlans(ctx context.Context, groupID string) ([]models.PlannerPlanable, error)
+	PostPlan(ctx context.Context, groupID, title string) (models.PlannerPlanable, error)
+	GetPlanDetails(ctx context.Context, planID string) (models.PlannerPlanDetailsable, error)
+	UpdatePlanDetails(ctx context.Context, planID, etag string, body models.PlannerPlanDetailsable) error
+	GetBuckets(ctx context.Context, planID string) ([]models.PlannerBucketable, error)
+	PostBucket(ctx context.Context, planID, name string) (models.PlannerBucketable, error)
+	GetTasks(ctx context.Context, planID string) ([]models.PlannerTaskable, error)
+	PostTask(ctx context.Context, body models.PlannerTaskable) (models.PlannerTaskable, error)
+	GetTaskDetails(ctx context.Context, taskID string) (models.PlannerTaskDetailsable, error)
+	UpdateTaskDetails(ctx context.Context, taskID, etag string, body models.PlannerTaskDetailsable) error
+	DeleteTask(ctx context.Context, taskID, etag string) error
+}
+
+// ---------------------------------------------------------------------------
+// backup
+// ---------------------------------------------------------------------------
+
+// CreatePlannerCollections produces one collection per plan owned by the
+// group.  Planner has no delta support, so every backup captures the full
+// set of plans; callers are expected to tombstone the planner prefix so
+// that deleted plans don't carry over from previous backups.
+func CreatePlannerCollections(
+	ctx context.Context,
+	bpc inject.BackupProducerConfig,
+	pg plannerGetter,
+	tenantID string,
+	scope selectors.GroupsScope,
+	su support.StatusUpdater,
+	counter *count.Bus,
+) ([]data.BackupCollection, error) {
+	groupID := bpc.ProtectedResource.ID()
+
+	plans, err := pg.GetPlans(ctx, groupID)
+	if err != nil {
+		// groups without a planner license refuse access to their plans.
+		// That shouldn't fail the rest of the backup.
+		if graph.IsErrAccessDenied(err) || errors.Is(err, core.ErrNotFound) {
+			logger.CtxErr(ctx, err).Info("planner not available for group")
+			return nil, nil
+		}
+
+		return nil, clues.Stack(err)
+	}
+
+	colls := make([]data.BackupCollection, 0, len(plans))
+
+	for _, plan := range plans {
+		var (
+			planID = ptr.Val(plan.GetId())
+			title  = ptr.Val(plan.GetTitle())
+			ictx   = clues.Add(ctx, "plan_id", planID, "plan_title", clues.Hide(title))
+		)
+
+		if !scope.IsAny(selectors.GroupsPlan) && !scope.Matches(selectors.GroupsPlan, title) {
+			continue
+		}
+
+		p, err := path.Build(
+			tenantID,
+			groupID,
+			path.GroupsService,
+			path.PlannerCategory,
+			false,
+			planID)
+		if err != nil {
+			return nil, clues.WrapWC(ictx, err, "making plan path").Label(count.BadCollPath)
+		}
+
+		counter.Inc(count.Containers)
+
+		colls = append(colls, &plannerCollection{
+			BaseCollection: data.NewBaseCollection(
+				p,
+				nil,
+				path.Builder{}.Append(title),
+				bpc.Options,
+				false,
+				counter),
+			plan:          plan,
+			getter:        pg,
+			statusUpdater: su,
+		})
+	}
+
+	return colls, nil
+}
+
+type plannerCollection struct {
+	data.BaseCollection
+
+	plan          models.PlannerPlanable
+	getter        plannerGetter
+	statusUpdater support.StatusUpdater
+}
+
+func (col *plannerCollection) Items(ctx context.Context, errs *fault.Bus) <-chan data.Item {
+	stream := make(chan data.Item, collectionChannelBufferSize)
+	go col.streamItems(ctx, stream, errs)
+
+	return stream
+}
+
+func (col *plannerCollection) streamItems(
+	ctx context.Context,
+	stream chan<- data.Item,
+	errs *fault.Bus,
+) {
+	var (
+		attempted  int
+		success    int64
+		totalBytes int64
+		el         = errs.Local()
+		planID     = ptr.Val(col.plan.GetId())
+		title      = ptr.Val(col.plan.GetTitle())
+	)
+
+	ctx = clues.Add(ctx, "plan_id", planID)
+
+	defer func() {
+		close(stream)
+		logger.Ctx(ctx).Infow(
+			"finished stream backup collection items",
+			"stats", col.Counter.Values())
+		updateStatus(
+			ctx,
+			col.statusUpdater,
+			attempted,
+			success,
+			totalBytes,
+			col.FullPath().Folder(false),
+			errs.Failure())
+	}()
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		col.Category().HumanString(),
+		col.LocationPath().Elements())
+	defer close(progressMessage)
+
+	send := func(ictx context.Context, itemID string, item serialization.Parsable, info details.ItemInfo) {
+		attempted++
+
+		body, err := serializePlannerItem(item)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "serializing planner item").
+				Label(fault.LabelForceNoBackupCreation))
+
+			return
+		}
+
+		info.Groups.Size = int64(len(body))
+
+		di, err := data.NewPrefetchedItemWithInfo(
+			io.NopCloser(bytes.NewReader(body)),
+			itemID,
+			info)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.StackWC(ictx, err).
+				Label(fault.LabelForceNoBackupCreation))
+
+			return
+		}
+
+		stream <- di
+
+		success++
+		totalBytes += int64(len(body))
+
+		col.Counter.Inc(count.StreamItemsAdded)
+		col.Counter.Add(count.StreamBytesAdded, int64(len(body)))
+
+		progressMessage <- struct{}{}
+	}
+
+	planDetails, err := col.getter.GetPlanDetails(ctx, planID)
+	if err != nil {
+		el.AddRecoverable(ctx, clues.Wrap(err, "getting plan details").
+			Label(fault.LabelForceNoBackupCreation))
+
+		return
+	}
+
+	buckets, err := col.getter.GetBuckets(ctx, planID)
+	if err != nil {
+		el.AddRecoverable(ctx, clues.Wrap(err, "getting plan buckets").
+			Label(fault.LabelForceNoBackupCreation))
+
+		return
+	}
+
+	tasks, err := col.getter.GetTasks(ctx, planID)
+	if err != nil {
+		el.AddRecoverable(ctx, clues.Wrap(err, "getting plan tasks").
+			Label(fault.LabelForceNoBackupCreation))
+
+		return
+	}
+
+	col.plan.SetDetails(planDetails)
+	col.plan.SetBuckets(buckets)
+
+	now := time.Now().UTC()
+
+	send(ctx, planID, col.plan, details.ItemInfo{
+		Groups: &details.GroupsInfo{
+			ItemType:   details.GroupsPlannerPlan,
+			Created:    ptr.Val(col.plan.GetCreatedDateTime()),
+			Modified:   now,
+			ParentPath: title,
+			Planner: details.PlannerInfo{
+				PlanTitle:   title,
+				BucketCount: len(buckets),
+				TaskCount:   len(tasks),
+			},
+		},
+	})
+
+	bucketNames := map[string]string{}
+
+	for _, b := range buckets {
+		bucketNames[ptr.Val(b.GetId())] = ptr.Val(b.GetName())
+	}
+
+	for _, task := range tasks {
+		if el.Failure() != nil {
+			return
+		}
+
+		taskID := ptr.Val(task.GetId())
+		ictx := clues.Add(ctx, "item_id", taskID)
+
+		taskDetails, err := col.getter.GetTaskDetails(ictx, taskID)
+		if err != nil {
+			// tasks deleted after enumeration get skipped.
+			if errors.Is(err, core.ErrNotFound) {
+				logger.CtxErr(ictx, err).Info("task deleted in flight. skipping")
+				col.Counter.Inc(count.SkippedItems)
+
+				continue
+			}
+
+			attempted++
+
+			el.AddRecoverable(ictx, clues.Wrap(err, "getting task details").
+				Label(fault.LabelForceNoBackupCreation))
+
+			continue
+		}
+
+		task.SetDetails(taskDetails)
+
+		info := plannerTaskInfo(task, title, bucketNames)
+		info.Modified = now
+
+		send(ictx, taskID, task, details.ItemInfo{Groups: info})
+	}
+}
+
+func serializePlannerItem(item serialization.Parsable) ([]byte, error) {
+	writer := kjson.NewJsonSerializationWriter()
+	defer writer.Close()
+
+	if err := writer.WriteObjectValue("", item); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	bs, err := writer.GetSerializedContent()
+
+	return bs, clues.Stack(err).OrNil()
+}
+
+// plannerTaskInfo produces the details info describing a task.
+func plannerTaskInfo(
+	task models.PlannerTaskable,
+	planTitle string,
+	bucketNames map[string]string,
+) *details.GroupsInfo {
+	return &details.GroupsInfo{
+		ItemType:   details.GroupsPlannerTask,
+		Created:    ptr.Val(task.GetCreatedDateTime()),
+		ParentPath: planTitle,
+		Planner: details.PlannerInfo{
+			PlanTitle:       planTitle,
+			BucketName:      bucketNames[ptr.Val(task.GetBucketId())],
+			TaskTitle:       ptr.Val(task.GetTitle()),
+			Assignees:       taskAssignees(task),
+			DueDate:         ptr.Val(task.GetDueDateTime()),
+			PercentComplete: int(ptr.Val(task.GetPercentComplete())),
+		},
+	}
+}
+
+// taskAssignees returns the sorted IDs of the users assigned to the task.
+func taskAssignees(task models.PlannerTaskable) []string {
+	if task.GetAssignments() == nil {
+		return nil
+	}
+
+	ids := []string{}
+
+	for id := range task.GetAssignments().GetAdditionalData() {
+		// the assignments are keyed by user ID; any odata annotations
+		// are not assignees.
+		if strings.HasPrefix(id, "@") {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// ---------------------------------------------------------------------------
+// restore
+// ---------------------------------------------------------------------------
+
+// PlannerRestoreCache tracks the plans in the target group across the
+// restore of multiple plan collections.
+type PlannerRestoreCache struct {
+	// plan title (lowercased) -> plan ID
+	plans map[string]string
+}
+
+// NewPlannerRestoreCache produces an empty cache.  The cache is
+// populated on the first restored plan.
+func NewPlannerRestoreCache() *PlannerRestoreCache {
+	return &PlannerRestoreCache{}
+}
+
+// RestorePlannerCollection recreates the plan held in the collection
+// within the target group.  Plans and buckets are reused when one with
+// the same name already exists.  Tasks are recreated subject to the
+// collision policy, keyed by task title.  Assignments are restored by
+// user ID, so they only carry over within the same tenant.
+func RestorePlannerCollection(
+	ctx context.Context,
+	pr plannerRestorer,
+	dc data.RestoreCollection,
+	groupID, restoreLocation string,
+	collisionPolicy control.CollisionPolicy,
+	cache *PlannerRestoreCache,
+	deets *details.Builder,
+	errs *fault.Bus,
+	ctr *count.Bus,
+) (support.CollectionMetrics, error) {
+	var (
+		el       = errs.Local()
+		metrics  support.CollectionMetrics
+		fullPath = dc.FullPath()
+		planID   = fullPath.Folder(false)
+	)
+
+	ctx = clues.Add(ctx, "plan_id", planID)
+
+	progressMessage := observe.CollectionProgress(
+		ctx,
+		fullPath.Category().HumanString(),
+		fullPath.Folder(false))
+	defer close(progressMessage)
+
+	plan, tasks, err := readPlanCollection(ctx, dc, errs)
+	if err != nil {
+		return metrics, clues.Stack(err)
+	}
+
+	title := ptr.Val(plan.GetTitle())
+	if len(restoreLocation) > 0 {
+		title = restoreLocation + " - " + title
+	}
+
+	target, created, err := ensurePlan(ctx, pr, groupID, title, cache)
+	if err != nil {
+		return metrics, clues.Wrap(err, "getting restore plan")
+	}
+
+	ctx = clues.Add(ctx, "restore_plan_id", target)
+
+	if created {
+		ctr.Inc(count.NewItemCreated)
+
+		if err := restorePlanCategories(ctx, pr, target, plan.GetDetails()); err != nil {
+			// labels are cosmetic; the tasks can be restored without them.
+			logger.CtxErr(ctx, err).Info("restoring plan categories")
+		}
+	}
+
+	br, err := newBucketRestorer(ctx, pr, target, plan.GetBuckets())
+	if err != nil {
+		return metrics, clues.Stack(err)
+	}
+
+	current, err := pr.GetTasks(ctx, target)
+	if err != nil {
+		return metrics, clues.Wrap(err, "getting existing tasks")
+	}
+
+	existing := map[string]models.PlannerTaskable{}
+
+	for _, t := range current {
+		existing[strings.ToLower(ptr.Val(t.GetTitle()))] = t
+	}
+
+	for _, rt := range tasks {
+		if el.Failure() != nil {
+			break
+		}
+
+		var (
+			task  = rt.task
+			ictx  = clues.Add(ctx, "item_id", ptr.Val(task.GetId()))
+			tname = ptr.Val(task.GetTitle())
+		)
+
+		metrics.Objects++
+
+		if prev, ok := existing[strings.ToLower(tname)]; ok {
+			switch collisionPolicy {
+			case control.Skip:
+				ctr.Inc(count.CollisionSkip)
+				continue
+
+			case control.Replace:
+				if err := pr.DeleteTask(ictx, ptr.Val(prev.GetId()), api.ETagOf(prev)); err != nil {
+					el.AddRecoverable(ictx, clues.Wrap(err, "deleting colliding task"))
+					continue
+				}
+
+				ctr.Inc(count.CollisionReplace)
+			}
+		}
+
+		bucketName, err := restoreTask(ictx, pr, target, task, br)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.Wrap(err, "restoring task"))
+			continue
+		}
+
+		ctr.Inc(count.NewItemCreated)
+
+		metrics.Successes++
+		metrics.Bytes += rt.size
+
+		itemPath, err := fullPath.AppendItem(ptr.Val(task.GetId()))
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "adding item to collection path"))
+			continue
+		}
+
+		info := plannerTaskInfo(task, ptr.Val(plan.GetTitle()), map[string]string{
+			ptr.Val(task.GetBucketId()): bucketName,
+		})
+		info.Size = rt.size
+
+		err = deets.Add(
+			itemPath,
+			path.Builder{}.Append(title),
+			details.ItemInfo{Groups: info})
+		if err != nil {
+			// These deets additions are for cli display purposes only.
+			// no need to fail out on error.
+			logger.Ctx(ictx).Infow("accounting for restored item", "error", err)
+		}
+
+		progressMessage <- struct{}{}
+	}
+
+	return metrics, el.Failure()
+}
+
+type restorableTask struct {
+	task models.PlannerTaskable
+	size int64
+}
+
+// readPlanCollection deserializes the plan and the tasks in the
+// collection.  If the plan item wasn't selected for restore, it gets
+// fetched from the collection by name.
+func readPlanCollection(
+	ctx context.Context,
+	dc data.RestoreCollection,
+	errs *fault.Bus,
+) (models.PlannerPlanable, []restorableTask, error) {
+	var (
+		planID = dc.FullPath().Folder(false)
+		plan   models.PlannerPlanable
+		tasks  = []restorableTask{}
+		el     = errs.Local()
+	)
+
+	for item := range dc.Items(ctx, errs) {
+		ictx := clues.Add(ctx, "item_id", item.ID())
+
+		body, err := readItemBytes(item)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading item bytes"))
+			continue
+		}
+
+		if item.ID() == planID {
+			plan, err = DeserializePlan(body)
+			if err != nil {
+				return nil, nil, clues.WrapWC(ictx, err, "deserializing plan")
+			}
+
+			continue
+		}
+
+		task, err := DeserializeTask(body)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "deserializing task"))
+			continue
+		}
+
+		tasks = append(tasks, restorableTask{task, int64(len(body))})
+	}
+
+	if plan != nil {
+		return plan, tasks, el.Failure()
+	}
+
+	item, err := dc.FetchItemByName(ctx, planID)
+	if err != nil {
+		return nil, nil, clues.WrapWC(ctx, err, "fetching plan")
+	}
+
+	body, err := readItemBytes(item)
+	if err != nil {
+		return nil, nil, clues.WrapWC(ctx, err, "reading plan bytes")
+	}
+
+	plan, err = DeserializePlan(body)
+	if err != nil {
+		return nil, nil, clues.WrapWC(ctx, err, "deserializing plan")
+	}
+
+	return plan, tasks, el.Failure()
+}
+
+func readItemBytes(item data.Item) ([]byte, error) {
+	rc := item.ToReader()
+	defer rc.Close()
+
+	bs, err := io.ReadAll(rc)
+
+	return bs, clues.Stack(err).OrNil()
+}
+
+// DeserializePlan produces the plan, including its details and buckets,
+// from the bytes of a backed up plan item.
+func DeserializePlan(body []byte) (models.PlannerPlanable, error) {
+	v, err := api.CreateFromBytes(body, models.CreatePlannerPlanFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	plan, ok := v.(models.PlannerPlanable)
+	if !ok {
+		return nil, clues.New(fmt.Sprintf("unexpected plan type %T", v))
+	}
+
+	return plan, nil
+}
+
+// DeserializeTask produces the task, including its details, from the
+// bytes of a backed up task item.
+func DeserializeTask(body []byte) (models.PlannerTaskable, error) {
+	v, err := api.CreateFromBytes(body, models.CreatePlannerTaskFromDiscriminatorValue)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	task, ok := v.(models.PlannerTaskable)
+	if !ok {
+		return nil, clues.New(fmt.Sprintf("unexpected task type %T", v))
+	}
+
+	return task, nil
+}
+
+// ensurePlan returns the ID of the plan with the given title in the
+// group, creating the plan if needed.  The bool is true if the plan was
+// created.
+func ensurePlan(
+	ctx context.Context,
+	pr plannerRestorer,
+	groupID, title string,
+	cache *PlannerRestoreCache,
+) (string, bool, error) {
+	if cache.plans == nil {
+		plans, err := pr.GetPlans(ctx, groupID)
+		if err != nil {
+			return "", false, clues.Wrap(err, "getting existing plans")
+		}
+
+		cache.plans = map[string]string{}
+
+		for _, p := range plans {
+			cache.plans[strings.ToLower(ptr.Val(p.GetTitle()))] = ptr.Val(p.GetId())
+		}
+	}
+
+	if id, ok := cache.plans[strings.ToLower(title)]; ok {
+		return id, false, nil
+	}
+
+	plan, err := pr.PostPlan(ctx, groupID, title)
+	if err != nil {
+		return "", false, clues.Stack(err)
+	}
+
+	id := ptr.Val(plan.GetId())
+	cache.plans[strings.ToLower(title)] = id
+
+	return id, true, nil
+}
+
+// restorePlanCategories carries the names of the plan's labels over to a
+// newly created plan.
+func restorePlanCategories(
+	ctx context.Context,
+	pr plannerRestorer,
+	planID string,
+	backup models.PlannerPlanDetailsable,
+) error {
+	if backup == nil || backup.GetCategoryDescriptions() == nil {
+		return nil
+	}
+
+	current, err := pr.GetPlanDetails(ctx, planID)
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	body := models.NewPlannerPlanDetails()
+	body.SetCategoryDescriptions(backup.GetCategoryDescriptions())
+
+	return clues.Stack(pr.UpdatePlanDetails(ctx, planID, api.ETagOf(current), body)).OrNil()
+}
+
+// bucketRestorer maps the buckets of the backed up plan onto buckets in
+// the target plan, creating buckets as tasks need them.
+type bucketRestorer struct {
+	pr     plannerRestorer
+	planID string
+	// backup bucket ID -> bucket name
+	names map[string]string
+	// bucket name (lowercased) -> target bucket ID
+	targets map[string]string
+}
+
+func newBucketRestorer(
+	ctx context.Context,
+	pr plannerRestorer,
+	planID string,
+	backup []models.PlannerBucketable,
+) (*bucketRestorer, error) {
+	current, err := pr.GetBuckets(ctx, planID)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting existing buckets")
+	}
+
+	br := &bucketRestorer{
+		pr:      pr,
+		planID:  planID,
+		names:   map[string]string{},
+		targets: map[string]string{},
+	}
+
+	for _, b := range backup {
+		br.names[ptr.Val(b.GetId())] = ptr.Val(b.GetName())
+	}
+
+	for _, b := range current {
+		br.targets[strings.ToLower(ptr.Val(b.GetName()))] = ptr.Val(b.GetId())
+	}
+
+	return br, nil
+}
+
+// target returns the name and target ID of the bucket matching the backed
+// up bucket ID.  Returns empty values if the task had no bucket.
+func (br *bucketRestorer) target(ctx context.Context, backupBucketID string) (string, string, error) {
+	name, ok := br.names[backupBucketID]
+	if !ok || len(name) == 0 {
+		return "", "", nil
+	}
+
+	if id, ok := br.targets[strings.ToLower(name)]; ok {
+		return name, id, nil
+	}
+
+	bucket, err := br.pr.PostBucket(ctx, br.planID, name)
+	if err != nil {
+		return "", "", clues.Stack(err)
+	}
+
+	id := ptr.Val(bucket.GetId())
+	br.targets[strings.ToLower(name)] = id
+
+	return name, id, nil
+}
+
+// restoreTask creates the task within the target plan, and then restores
+// its details.  Returns the name of the bucket holding the task.
+func restoreTask(
+	ctx context.Context,
+	pr plannerRestorer,
+	planID string,
+	task models.PlannerTaskable,
+	br *bucketRestorer,
+) (string, error) {
+	bucketName, bucketID, err := br.target(ctx, ptr.Val(task.GetBucketId()))
+	if err != nil {
+		return "", clues.Wrap(err, "getting restore bucket")
+	}
+
+	body := toRestorableTask(task, planID, bucketID)
+
+	created, err := pr.PostTask(ctx, body)
+	if err != nil {
+		return "", clues.Stack(err)
+	}
+
+	td := toRestorableTaskDetails(task.GetDetails())
+	if td == nil {
+		return bucketName, nil
+	}
+
+	newID := ptr.Val(created.GetId())
+
+	// details are created alongside the task; their etag is needed
+	// in order to update them.
+	current, err := pr.GetTaskDetails(ctx, newID)
+	if err != nil {
+		return "", clues.Stack(err)
+	}
+
+	err = pr.UpdateTaskDetails(ctx, newID, api.ETagOf(current), td)
+
+	return bucketName, clues.Stack(err).OrNil()
+}
+
+// toRestorableTask produces a new task containing only the writable
+// properties of the backed up task.
+func toRestorableTask(
+	task models.PlannerTaskable,
+	planID, bucketID string,
+) models.PlannerTaskable {
+	body := models.NewPlannerTask()
+	body.SetPlanId(ptr.To(planID))
+	body.SetTitle(task.GetTitle())
+	body.SetStartDateTime(task.GetStartDateTime())
+	body.SetDueDateTime(task.GetDueDateTime())
+	body.SetPercentComplete(task.GetPercentComplete())
+	body.SetPriority(task.GetPriority())
+	body.SetAppliedCategories(task.GetAppliedCategories())
+
+	if len(bucketID) > 0 {
+		body.SetBucketId(ptr.To(bucketID))
+	}
+
+	if assignees := taskAssignees(task); len(assignees) > 0 {
+		assignments := models.NewPlannerAssignments()
+		ad := map[string]any{}
+
+		for _, id := range assignees {
+			ad[id] = map[string]any{
+				"@odata.type": "#microsoft.graph.plannerAssignment",
+				"orderHint":   " !",
+			}
+		}
+
+		assignments.SetAdditionalData(ad)
+		body.SetAssignments(assignments)
+	}
+
+	return body
+}
+
+// toRestorableTaskDetails produces the writable properties of the backed
+// up task details.  Returns nil if the details hold nothing to restore.
+func toRestorableTaskDetails(td models.PlannerTaskDetailsable) models.PlannerTaskDetailsable {
+	if td == nil {
+		return nil
+	}
+
+	var (
+		body    = models.NewPlannerTaskDetails()
+		restore bool
+	)
+
+	if len(ptr.Val(td.GetDescription())) > 0 {
+		body.SetDescription(td.GetDescription())
+		body.SetPreviewType(td.GetPreviewType())
+
+		restore = true
+	}
+
+	if td.GetChecklist() != nil && len(td.GetChecklist().GetAdditionalData()) > 0 {
+		cl := models.NewPlannerChecklistItems()
+		cl.SetAdditionalData(writableOpenTypeEntries(
+			td.GetChecklist().GetAdditionalData(),
+			"#microsoft.graph.plannerChecklistItem",
+			"title", "isChecked", "orderHint"))
+		body.SetChecklist(cl)
+
+		restore = true
+	}
+
+	if td.GetReferences() != nil && len(td.GetReferences().GetAdditionalData()) > 0 {
+		refs := models.NewPlannerExternalReferences()
+		refs.SetAdditionalData(writableOpenTypeEntries(
+			td.GetReferences().GetAdditionalData(),
+			"#microsoft.graph.plannerExternalReference",
+			"alias", "type", "previewPriority"))
+		body.SetReferences(refs)
+
+		restore = true
+	}
+
+	if !restore {
+		return nil
+	}
+
+	return body
+}
+
+// writableOpenTypeEntries copies the writable properties of the entries
+// within a planner open type (ex: checklist items keyed by ID).
+func writableOpenTypeEntries(
+	entries map[string]any,
+	odataType string,
+	writable ...string,
+) map[string]any {
+	result := map[string]any{}
+
+	for k, v := range entries {
+		props, ok := v.(map[string]any)
+		if !ok || strings.HasPrefix(k, "@") {
+			continue
+		}
+
+		entry := map[string]any{"@odata.type": odataType}
+
+		for _, w := range writable {
+			if pv, ok := props[w]; ok && pv != nil {
+				entry[w] = pv
+			}
+		}
+
+		result[k] = entry
+	}
+
+	return result
+}
@@ -0,0 +1,91 @@
+package groups
+
+import (
+	"context"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+)
+
+// plannerPlanner looks up the plans and tasks that a planner restore
+// compares against.
+type plannerPlanner interface {
+	GetPlans(ctx context.Context, groupID string) ([]models.PlannerPlanable, error)
+	GetTasks(ctx context.Context, planID string) ([]models.PlannerTaskable, error)
+}
+
+// PlanPlannerCollection records the action that restoring each task in
+// the collection would take.  Tasks are compared by title against the
+// tasks of the plan they'd be restored into.  If that plan doesn't exist
+// yet, every task gets planned as a new task.
+func PlanPlannerCollection(
+	ctx context.Context,
+	pp plannerPlanner,
+	dc data.RestoreCollection,
+	groupID, restoreLocation string,
+	collisionPolicy control.CollisionPolicy,
+	cache *PlannerRestoreCache,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	ctx = clues.Add(ctx, "plan_id", dc.FullPath().Folder(false))
+
+	backupPlan, tasks, err := readPlanCollection(ctx, dc, errs)
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	title := ptr.Val(backupPlan.GetTitle())
+	if len(restoreLocation) > 0 {
+		title = restoreLocation + " - " + title
+	}
+
+	if cache.plans == nil {
+		plans, err := pp.GetPlans(ctx, groupID)
+		if err != nil {
+			return clues.Wrap(err, "getting existing plans")
+		}
+
+		cache.plans = map[string]string{}
+
+		for _, pl := range plans {
+			cache.plans[strings.ToLower(ptr.Val(pl.GetTitle()))] = ptr.Val(pl.GetId())
+		}
+	}
+
+	existing := map[string]struct{}{}
+
+	if target, ok := cache.plans[strings.ToLower(title)]; ok {
+		current, err := pp.GetTasks(ctx, target)
+		if err != nil {
+			return clues.Wrap(err, "getting existing tasks")
+		}
+
+		for _, t := range current {
+			existing[strings.ToLower(ptr.Val(t.GetTitle()))] = struct{}{}
+		}
+	}
+
+	dest := path.Builder{}.Append(path.PlannerCategory.HumanString(), title)
+
+	for _, rt := range tasks {
+		tname := ptr.Val(rt.task.GetTitle())
+		_, collides := existing[strings.ToLower(tname)]
+
+		p.Add(plan.Item{
+			Action:      plan.ForRestore(collisionPolicy, collides),
+			Destination: dest.Append(tname).String(),
+			Size:        rt.size,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package groups
+
+import (
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+)
+
+func (suite *PlannerUnitSuite) TestPlanPlannerCollection() {
+	fullPath, err := path.Build("tid", "gid", path.GroupsService, path.PlannerCategory, false, "p1")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	taskDest := func(planTitle, task string) string {
+		return path.Builder{}.Append(path.PlannerCategory.HumanString(), planTitle, task).String()
+	}
+
+	table := []struct {
+		name     string
+		location string
+		policy   control.CollisionPolicy
+		expect   map[string]plan.Action
+	}{
+		{
+			name:     "new plan",
+			location: "Corso_Restore",
+			policy:   control.Skip,
+			expect: map[string]plan.Action{
+				taskDest("Corso_Restore - Launch", "Write spec"): plan.Create,
+				taskDest("Corso_Restore - Launch", "Ship"):       plan.Create,
+			},
+		},
+		{
+			name:   "existing plan, skip",
+			policy: control.Skip,
+			expect: map[string]plan.Action{
+				taskDest("Launch", "Write spec"): plan.Skip,
+				taskDest("Launch", "Ship"):       plan.Create,
+			},
+		},
+		{
+			name:   "existing plan, copy",
+			policy: control.Copy,
+			expect: map[string]plan.Action{
+				taskDest("Launch", "Write spec"): plan.Copy,
+				taskDest("Launch", "Ship"):       plan.Create,
+			},
+		},
+		{
+			name:   "existing plan, replace",
+			policy: control.Replace,
+			expect: map[string]plan.Action{
+				taskDest("Launch", "Write spec"): plan.Replace,
+				taskDest("Launch", "Ship"):       plan.Create,
+			},
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				p  = plan.New()
+				mp = &mockPlanner{
+					plans: []models.PlannerPlanable{makePlan("ep1", "Launch")},
+					tasks: map[string][]models.PlannerTaskable{
+						"ep1": {makeTask("x1", "write SPEC", "eb1")},
+					},
+				}
+				dc = dataMock.Collection{
+					Path: fullPath,
+					ItemData: []data.Item{
+						serializedItem(t, "p1", makePlan("p1", "Launch")),
+						serializedItem(t, "t1", makeTask("t1", "Write spec", "b1")),
+						serializedItem(t, "t2", makeTask("t2", "Ship", "b2")),
+					},
+				}
+			)
+
+			err := PlanPlannerCollection(
+				ctx,
+				mp,
+				dc,
+				"gid",
+				test.location,
+				test.policy,
+				NewPlannerRestoreCache(),
+				p,
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			planned := map[string]plan.Action{}
+
+			for _, item := range p.Items() {
+				planned[item.Destination] = item.Action
+			}
+
+			assert.Equal(t, test.expect, planned)
+			assert.Empty(t, mp.postedPlans, "plans aren't created while planning")
+		})
+	}
+}
@@ -0,0 +1,497 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/common/readers"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/m365/support"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// ---------------------------------------------------------------------------
+// mocks
+// ---------------------------------------------------------------------------
+
+var (
+	_ plannerGetter   = &mockPlanner{}
+	_ plannerRestorer = &mockPlanner{}
+)
+
+type mockPlanner struct {
+	plans    []models.PlannerPlanable
+	plansErr error
+	buckets  map[string][]models.PlannerBucketable
+	tasks    map[string][]models.PlannerTaskable
+
+	postedPlans   []string
+	postedBuckets []string
+	postedTasks   []models.PlannerTaskable
+	deletedTasks  []string
+	updatedTasks  []models.PlannerTaskDetailsable
+}
+
+func (m *mockPlanner) GetPlans(_ context.Context, groupID string) ([]models.PlannerPlanable, error) {
+	return m.plans, m.plansErr
+}
+
+func (m *mockPlanner) GetPlanDetails(context.Context, string) (models.PlannerPlanDetailsable, error) {
+	pd := models.NewPlannerPlanDetails()
+	pd.SetAdditionalData(map[string]any{api.AddtlDataETag: "plan-etag"})
+
+	return pd, nil
+}
+
+func (m *mockPlanner) UpdatePlanDetails(context.Context, string, string, models.PlannerPlanDetailsable) error {
+	return nil
+}
+
+func (m *mockPlanner) PostPlan(_ context.Context, _, title string) (models.PlannerPlanable, error) {
+	m.postedPlans = append(m.postedPlans, title)
+	return makePlan("new-plan", title), nil
+}
+
+func (m *mockPlanner) GetBuckets(_ context.Context, planID string) ([]models.PlannerBucketable, error) {
+	return m.buckets[planID], nil
+}
+
+func (m *mockPlanner) PostBucket(_ context.Context, _, name string) (models.PlannerBucketable, error) {
+	m.postedBuckets = append(m.postedBuckets, name)
+	return makeBucket("new-"+name, name), nil
+}
+
+func (m *mockPlanner) GetTasks(_ context.Context, planID string) ([]models.PlannerTaskable, error) {
+	return m.tasks[planID], nil
+}
+
+func (m *mockPlanner) GetTaskDetails(_ context.Context, taskID string) (models.PlannerTaskDetailsable, error) {
+	td := models.NewPlannerTaskDetails()
+	td.SetDescription(ptr.To("description of " + taskID))
+	td.SetAdditionalData(map[string]any{api.AddtlDataETag: "task-etag"})
+
+	return td, nil
+}
+
+func (m *mockPlanner) PostTask(_ context.Context, body models.PlannerTaskable) (models.PlannerTaskable, error) {
+	m.postedTasks = append(m.postedTasks, body)
+
+	task := models.NewPlannerTask()
+	task.SetId(ptr.To("new-" + ptr.Val(body.GetTitle())))
+
+	return task, nil
+}
+
+func (m *mockPlanner) UpdateTaskDetails(
+	_ context.Context,
+	_, _ string,
+	body models.PlannerTaskDetailsable,
+) error {
+	m.updatedTasks = append(m.updatedTasks, body)
+	return nil
+}
+
+func (m *mockPlanner) DeleteTask(_ context.Context, taskID, _ string) error {
+	m.deletedTasks = append(m.deletedTasks, taskID)
+	return nil
+}
+
+func makePlan(id, title string) models.PlannerPlanable {
+	plan := models.NewPlannerPlan()
+	plan.SetId(ptr.To(id))
+	plan.SetTitle(ptr.To(title))
+
+	return plan
+}
+
+func makeBucket(id, name string) models.PlannerBucketable {
+	bucket := models.NewPlannerBucket()
+	bucket.SetId(ptr.To(id))
+	bucket.SetName(ptr.To(name))
+
+	return bucket
+}
+
+func makeTask(id, title, bucketID string, assignees ...string) models.PlannerTaskable {
+	task := models.NewPlannerTask()
+	task.SetId(ptr.To(id))
+	task.SetTitle(ptr.To(title))
+	task.SetBucketId(ptr.To(bucketID))
+
+	if len(assignees) > 0 {
+		ad := map[string]any{}
+
+		for _, a := range assignees {
+			ad[a] = map[string]any{"orderHint": "abc"}
+		}
+
+		assignments := models.NewPlannerAssignments()
+		assignments.SetAdditionalData(ad)
+		task.SetAssignments(assignments)
+	}
+
+	return task
+}
+
+func serializedItem(t *testing.T, id string, v interface{ GetId() *string }) data.Item {
+	var (
+		bs  []byte
+		err error
+	)
+
+	switch vt := v.(type) {
+	case models.PlannerPlanable:
+		bs, err = serializePlannerItem(vt)
+	case models.PlannerTaskable:
+		bs, err = serializePlannerItem(vt)
+	}
+
+	require.NoError(t, err, clues.ToCore(err))
+
+	return &dataMock.Item{
+		ItemID: id,
+		Reader: io.NopCloser(bytes.NewReader(bs)),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// tests
+// ---------------------------------------------------------------------------
+
+type PlannerUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlannerUnitSuite(t *testing.T) {
+	suite.Run(t, &PlannerUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlannerUnitSuite) TestCreatePlannerCollections() {
+	bpc := inject.BackupProducerConfig{
+		ProtectedResource: idname.NewProvider("gid", "group"),
+	}
+
+	sel := selectors.NewGroupsBackup([]string{"gid"})
+
+	table := []struct {
+		name        string
+		scope       selectors.GroupsScope
+		plansErr    error
+		expectPlans []string
+		expectErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "all plans",
+			scope:       sel.Plans(selectors.Any())[0],
+			expectPlans: []string{"p1", "p2"},
+			expectErr:   assert.NoError,
+		},
+		{
+			name:        "matching plan",
+			scope:       sel.Plans([]string{"Roadmap"})[0],
+			expectPlans: []string{"p2"},
+			expectErr:   assert.NoError,
+		},
+		{
+			name:      "planner not found",
+			scope:     sel.Plans(selectors.Any())[0],
+			plansErr:  clues.Stack(core.ErrNotFound),
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "planner error",
+			scope:     sel.Plans(selectors.Any())[0],
+			plansErr:  assert.AnError,
+			expectErr: assert.Error,
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			mp := &mockPlanner{
+				plans:    []models.PlannerPlanable{makePlan("p1", "Launch"), makePlan("p2", "Roadmap")},
+				plansErr: test.plansErr,
+			}
+
+			colls, err := CreatePlannerCollections(
+				ctx,
+				bpc,
+				mp,
+				"tid",
+				test.scope,
+				func(*support.ControllerOperationStatus) {},
+				count.New())
+			test.expectErr(t, err, clues.ToCore(err))
+
+			ids := []string{}
+
+			for _, c := range colls {
+				assert.Equal(t, path.PlannerCategory, c.FullPath().Category())
+				assert.Equal(t, data.NewState, c.State())
+
+				ids = append(ids, c.FullPath().Folder(false))
+			}
+
+			assert.ElementsMatch(t, test.expectPlans, ids)
+		})
+	}
+}
+
+func (suite *PlannerUnitSuite) TestPlannerCollection_Items() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		bpc = inject.BackupProducerConfig{
+			ProtectedResource: idname.NewProvider("gid", "group"),
+		}
+		due = time.Now().UTC().Truncate(time.Second)
+		t1  = makeTask("t1", "Write spec", "b1", "u2", "u1")
+		t2  = makeTask("t2", "Ship", "b2")
+		mp  = &mockPlanner{
+			plans:   []models.PlannerPlanable{makePlan("p1", "Launch")},
+			buckets: map[string][]models.PlannerBucketable{"p1": {makeBucket("b1", "To do")}},
+			tasks:   map[string][]models.PlannerTaskable{"p1": {t1, t2}},
+		}
+		sel = selectors.NewGroupsBackup([]string{"gid"})
+	)
+
+	t1.SetDueDateTime(ptr.To(due))
+	t1.SetPercentComplete(ptr.To[int32](50))
+
+	colls, err := CreatePlannerCollections(
+		ctx,
+		bpc,
+		mp,
+		"tid",
+		sel.Plans(selectors.Any())[0],
+		func(*support.ControllerOperationStatus) {},
+		count.New())
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, colls, 1)
+	assert.Equal(t, path.Elements{"Launch"}, colls[0].(data.LocationPather).LocationPath().Elements())
+
+	errs := fault.New(true)
+	infos := map[string]*details.GroupsInfo{}
+
+	for item := range colls[0].Items(ctx, errs) {
+		r, err := readers.NewVersionedRestoreReader(item.ToReader())
+		require.NoError(t, err, clues.ToCore(err))
+
+		_, err = io.ReadAll(r)
+		require.NoError(t, err, clues.ToCore(err))
+
+		info, err := item.(data.ItemInfo).Info()
+		require.NoError(t, err, clues.ToCore(err))
+
+		infos[item.ID()] = info.Groups
+	}
+
+	require.NoError(t, errs.Failure(), clues.ToCore(errs.Failure()))
+	require.Len(t, infos, 3)
+
+	plan := infos["p1"]
+	assert.Equal(t, details.GroupsPlannerPlan, plan.ItemType)
+	assert.Equal(t, 1, plan.Planner.BucketCount)
+	assert.Equal(t, 2, plan.Planner.TaskCount)
+
+	task := infos["t1"]
+	assert.Equal(t, details.GroupsPlannerTask, task.ItemType)
+	assert.Equal(t, "Launch", task.ParentPath)
+	assert.Equal(t, "Write spec", task.Planner.TaskTitle)
+	assert.Equal(t, "To do", task.Planner.BucketName)
+	assert.Equal(t, []string{"u1", "u2"}, task.Planner.Assignees)
+	assert.Equal(t, due, task.Planner.DueDate)
+	assert.Equal(t, 50, task.Planner.PercentComplete)
+
+	assert.Empty(t, infos["t2"].Planner.BucketName)
+	assert.Empty(t, infos["t2"].Planner.Assignees)
+}
+
+func (suite *PlannerUnitSuite) TestRestorePlannerCollection() {
+	fullPath, err := path.Build("tid", "gid", path.GroupsService, path.PlannerCategory, false, "p1")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	backupPlan := makePlan("p1", "Launch")
+	backupPlan.SetBuckets([]models.PlannerBucketable{
+		makeBucket("b1", "To do"),
+		makeBucket("b2", "Done"),
+	})
+
+	table := []struct {
+		name          string
+		location      string
+		policy        control.CollisionPolicy
+		existingTasks []models.PlannerTaskable
+		expectPlans   []string
+		expectBuckets []string
+		expectPosted  int
+		expectDeleted []string
+		expectCount   map[count.Key]int64
+	}{
+		{
+			name:          "new plan",
+			location:      "Corso_Restore",
+			policy:        control.Skip,
+			expectPlans:   []string{"Corso_Restore - Launch"},
+			expectBuckets: []string{"To do", "Done"},
+			expectPosted:  2,
+			expectCount: map[count.Key]int64{
+				count.NewItemCreated: 3,
+			},
+		},
+		{
+			name:          "existing plan, skip",
+			policy:        control.Skip,
+			existingTasks: []models.PlannerTaskable{makeTask("x1", "write SPEC", "eb1")},
+			expectBuckets: []string{"Done"},
+			expectPosted:  1,
+			expectCount: map[count.Key]int64{
+				count.NewItemCreated: 1,
+				count.CollisionSkip:  1,
+			},
+		},
+		{
+			name:          "existing plan, replace",
+			policy:        control.Replace,
+			existingTasks: []models.PlannerTaskable{makeTask("x1", "Write spec", "eb1")},
+			expectBuckets: []string{"Done"},
+			expectPosted:  2,
+			expectDeleted: []string{"x1"},
+			expectCount: map[count.Key]int64{
+				count.NewItemCreated:   2,
+				count.CollisionReplace: 1,
+			},
+		},
+		{
+			name:          "existing plan, copy",
+			policy:        control.Copy,
+			existingTasks: []models.PlannerTaskable{makeTask("x1", "Write spec", "eb1")},
+			expectBuckets: []string{"Done"},
+			expectPosted:  2,
+			expectCount: map[count.Key]int64{
+				count.NewItemCreated: 2,
+			},
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				mp = &mockPlanner{
+					plans:   []models.PlannerPlanable{makePlan("ep1", "Launch")},
+					buckets: map[string][]models.PlannerBucketable{"ep1": {makeBucket("eb1", "To do")}},
+					tasks:   map[string][]models.PlannerTaskable{"ep1": test.existingTasks},
+				}
+				// the plan item is only reachable by name, as happens when
+				// only tasks are selected for restore.
+				dc = dataMock.Collection{
+					Path: fullPath,
+					ItemData: []data.Item{
+						serializedItem(t, "t1", makeTask("t1", "Write spec", "b1", "u1")),
+						serializedItem(t, "t2", makeTask("t2", "Ship", "b2")),
+					},
+					AuxItems: map[string]data.Item{
+						"p1": serializedItem(t, "p1", backupPlan),
+					},
+				}
+				deets = &details.Builder{}
+				ctr   = count.New()
+			)
+
+			metrics, err := RestorePlannerCollection(
+				ctx,
+				mp,
+				dc,
+				"gid",
+				test.location,
+				test.policy,
+				NewPlannerRestoreCache(),
+				deets,
+				fault.New(true),
+				ctr)
+			require.NoError(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectPlans, mp.postedPlans, "posted plans")
+			assert.Equal(t, test.expectBuckets, mp.postedBuckets, "posted buckets")
+			assert.Len(t, mp.postedTasks, test.expectPosted, "posted tasks")
+			assert.Equal(t, test.expectDeleted, mp.deletedTasks, "deleted tasks")
+			assert.Equal(t, test.expectPosted, metrics.Successes, "successes")
+			assert.Len(t, deets.Details().Items(), test.expectPosted, "details entries")
+
+			for k, v := range test.expectCount {
+				assert.Equal(t, v, ctr.Get(k), k)
+			}
+
+			for _, posted := range mp.postedTasks {
+				assert.NotEmpty(t, ptr.Val(posted.GetPlanId()), "plan id")
+				assert.NotEmpty(t, ptr.Val(posted.GetBucketId()), "bucket id")
+
+				if ptr.Val(posted.GetTitle()) == "Write spec" {
+					assert.Equal(t, []string{"u1"}, taskAssignees(posted))
+				}
+			}
+		})
+	}
+}
+
+func (suite *PlannerUnitSuite) TestToRestorableTaskDetails() {
+	t := suite.T()
+
+	assert.Nil(t, toRestorableTaskDetails(nil))
+	assert.Nil(t, toRestorableTaskDetails(models.NewPlannerTaskDetails()))
+
+	td := models.NewPlannerTaskDetails()
+	cl := models.NewPlannerChecklistItems()
+	cl.SetAdditionalData(map[string]any{
+		"item1": map[string]any{
+			"@odata.type":          "#microsoft.graph.plannerChecklistItem",
+			"title":                "first",
+			"isChecked":            true,
+			"orderHint":            "8585",
+			"lastModifiedDateTime": "2024-01-01T00:00:00Z",
+		},
+	})
+	td.SetChecklist(cl)
+
+	result := toRestorableTaskDetails(td)
+	require.NotNil(t, result)
+
+	item := result.GetChecklist().GetAdditionalData()["item1"].(map[string]any)
+	assert.Equal(t, "first", item["title"])
+	assert.Equal(t, true, item["isChecked"])
+	assert.NotContains(t, item, "lastModifiedDateTime")
+}
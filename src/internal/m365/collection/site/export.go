@@ -46,9 +46,10 @@ func streamItems(
 			name := item.ID() + ".json"
 
 			ch <- export.Item{
-				ID:   item.ID(),
-				Name: name,
-				Body: body,
+				ID:      item.ID(),
+				Name:    name,
+				Body:    body,
+				ModTime: export.ModTimeOf(item),
 			}
 		}
 
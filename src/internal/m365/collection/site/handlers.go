@@ -40,6 +40,7 @@ type restoreHandler interface {
 	DeleteLister
 	GetLister
 	GetListsByCollisionKeyser
+	GetSiteUserLookupIDser
 }
 
 type PostLister interface {
@@ -83,3 +84,9 @@ type GetListsByCollisionKeyser interface {
 	// skipped, replaced, or copied.
 	GetListsByCollisionKey(ctx context.Context) (map[string]string, error)
 }
+
+type GetSiteUserLookupIDser interface {
+	// GetSiteUserLookupIDs maps the email and login name of each user
+	// known to the site onto the lookup ID used by person columns.
+	GetSiteUserLookupIDs(ctx context.Context) (map[string]float64, error)
+}
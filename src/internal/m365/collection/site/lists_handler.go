@@ -98,3 +98,7 @@ func (rh listsRestoreHandler) GetList(
 func (rh listsRestoreHandler) GetListsByCollisionKey(ctx context.Context) (map[string]string, error) {
 	return rh.ac.GetListsByCollisionKey(ctx, rh.protectedResource)
 }
+
+func (rh listsRestoreHandler) GetSiteUserLookupIDs(ctx context.Context) (map[string]float64, error) {
+	return rh.ac.GetSiteUserLookupIDs(ctx, rh.protectedResource)
+}
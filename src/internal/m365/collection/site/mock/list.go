@@ -144,6 +144,10 @@ func (lh *ListRestoreHandler) GetListsByCollisionKey(ctx context.Context) (map[s
 	return map[string]string{}, nil
 }
 
+func (lh *ListRestoreHandler) GetSiteUserLookupIDs(ctx context.Context) (map[string]float64, error) {
+	return map[string]float64{}, nil
+}
+
 func StubLists(ids ...string) []models.Listable {
 	lists := make([]models.Listable, 0, len(ids))
 
@@ -0,0 +1,120 @@
+package site
+
+import (
+	"context"
+	"io"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/data"
+	betaAPI "github.com/alcionai/corso/src/internal/m365/service/sharepoint/api"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+// PlanListCollection records the action that restoring each list in the
+// collection would take.  Lists are compared against the collision keys
+// of the lists already present in the site.
+func PlanListCollection(
+	ctx context.Context,
+	dc data.RestoreCollection,
+	restoreCfg control.RestoreConfig,
+	collisionKeyToItemID map[string]string,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	el := errs.Local()
+
+	for itemData := range dc.Items(ctx, errs) {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx, "list_item_id", itemData.ID())
+
+		bs, err := io.ReadAll(itemData.ToReader())
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading backup data"))
+			continue
+		}
+
+		storedList, err := api.BytesToListable(bs)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "generating list from stored bytes"))
+			continue
+		}
+
+		var (
+			_, collides = collisionKeyToItemID[api.ListCollisionKey(storedList)]
+			action      = plan.ForRestore(restoreCfg.OnCollision, collides)
+			name        = formatListsRestoreDestination(restoreCfg.Location, itemData.ID(), storedList)
+		)
+
+		// replaced lists take over the name of the list they replace.
+		if action == plan.Replace {
+			name = ptr.Val(storedList.GetDisplayName())
+		}
+
+		p.Add(plan.Item{
+			Action: action,
+			Destination: path.Builder{}.
+				Append(path.ListsCategory.HumanString(), name).
+				String(),
+			Size: int64(len(bs)),
+		})
+	}
+
+	return el.Failure()
+}
+
+// PlanPageCollection records the pages in the collection.  Pages are
+// always restored as new pages.
+func PlanPageCollection(
+	ctx context.Context,
+	dc data.RestoreCollection,
+	restoreContainerName string,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	el := errs.Local()
+
+	for itemData := range dc.Items(ctx, errs) {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx, "page_item_id", itemData.ID())
+
+		bs, err := io.ReadAll(itemData.ToReader())
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "reading backup data"))
+			continue
+		}
+
+		page, err := betaAPI.BytesToSitePageable(bs)
+		if err != nil {
+			el.AddRecoverable(ictx, clues.WrapWC(ictx, err, "creating page from stored bytes"))
+			continue
+		}
+
+		pageName := itemData.ID()
+
+		if name, ok := ptr.ValOK(page.GetName()); ok {
+			pageName = name
+		}
+
+		p.Add(plan.Item{
+			Action: plan.Create,
+			Destination: path.Builder{}.
+				Append(path.PagesCategory.HumanString(), restoreContainerName+"_"+pageName).
+				String(),
+			Size: int64(len(bs)),
+		})
+	}
+
+	return el.Failure()
+}
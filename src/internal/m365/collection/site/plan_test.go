@@ -0,0 +1,113 @@
+package site
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	spMock "github.com/alcionai/corso/src/internal/m365/service/sharepoint/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+)
+
+type PlanUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlanUnitSuite(t *testing.T) {
+	suite.Run(t, &PlanUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlanUnitSuite) TestPlanListCollection() {
+	const location = "Corso_Restore"
+
+	listsDest := func(name string) string {
+		return path.Builder{}.Append(path.ListsCategory.HumanString(), name).String()
+	}
+
+	table := []struct {
+		name        string
+		onCollision control.CollisionPolicy
+		expect      map[string]plan.Action
+	}{
+		{
+			name:        "skip",
+			onCollision: control.Skip,
+			expect: map[string]plan.Action{
+				listsDest(location + "_collides"): plan.Skip,
+				listsDest(location + "_fresh"):    plan.Create,
+			},
+		},
+		{
+			name:        "copy",
+			onCollision: control.Copy,
+			expect: map[string]plan.Action{
+				listsDest(location + "_collides"): plan.Copy,
+				listsDest(location + "_fresh"):    plan.Create,
+			},
+		},
+		{
+			name:        "replace",
+			onCollision: control.Replace,
+			expect: map[string]plan.Action{
+				// replaced lists keep the name of the list they replace.
+				listsDest("collides"):          plan.Replace,
+				listsDest(location + "_fresh"): plan.Create,
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				p     = plan.New()
+				items []data.Item
+			)
+
+			for _, title := range []string{"collides", "fresh"} {
+				bs, err := spMock.ListBytes(title)
+				require.NoError(t, err, clues.ToCore(err))
+
+				items = append(items, &dataMock.Item{
+					ItemID: title,
+					Reader: io.NopCloser(bytes.NewReader(bs)),
+				})
+			}
+
+			err := PlanListCollection(
+				ctx,
+				dataMock.Collection{ItemData: items},
+				control.RestoreConfig{
+					Location:    location,
+					OnCollision: test.onCollision,
+				},
+				map[string]string{"collides": "existing-id"},
+				p,
+				fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			planned := map[string]plan.Action{}
+
+			for _, item := range p.Items() {
+				assert.NotZero(t, item.Size, item.Destination)
+				planned[item.Destination] = item.Action
+			}
+
+			assert.Equal(t, test.expect, planned)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"runtime/trace"
+	"strings"
 
 	"github.com/alcionai/clues"
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
@@ -62,6 +63,12 @@ func restoreListItem(
 		return dii, clues.WrapWC(ctx, err, "generating list from stored bytes")
 	}
 
+	if len(restoreCfg.PrincipalMap) > 0 {
+		if err := translateListPeople(ctx, rh, storedList, restoreCfg.PrincipalMap); err != nil {
+			return dii, clues.Stack(err)
+		}
+	}
+
 	var (
 		collisionKey = api.ListCollisionKey(storedList)
 		collisionID  string
@@ -108,6 +115,46 @@ func restoreListItem(
 	return dii, nil
 }
 
+// translateListPeople points the list's person columns at the people who
+// replace the backed up people in the site being restored into.  Anyone not
+// in the principal map is looked up by their original email.
+func translateListPeople(
+	ctx context.Context,
+	rh GetSiteUserLookupIDser,
+	storedList models.Listable,
+	principals control.PrincipalMap,
+) error {
+	lookupIDs, err := rh.GetSiteUserLookupIDs(ctx)
+	if err != nil {
+		return clues.Wrap(err, "getting site user lookup ids")
+	}
+
+	var dropped int
+
+	api.TranslatePersonFields(storedList, func(email string) (float64, string, bool) {
+		target := email
+
+		if mapped, ok := principals.Lookup(email); ok {
+			target = mapped
+		}
+
+		id, ok := lookupIDs[strings.ToLower(target)]
+		if !ok {
+			dropped++
+		}
+
+		return id, target, ok
+	})
+
+	if dropped > 0 {
+		logger.Ctx(ctx).
+			With("dropped_people", dropped).
+			Info("list person values not found in the restore site")
+	}
+
+	return nil
+}
+
 func handleListReplace(
 	ctx context.Context,
 	listID string,
@@ -80,16 +80,35 @@ func (exchangeBackup) ProduceBackupCollections(
 			break
 		}
 
-		dcs, err := exchange.CreateCollections(
-			ctx,
-			bpc,
-			handlers,
-			tenantID,
-			scope,
-			cdps[scope.Category().PathType()],
-			su,
-			counter,
-			errs)
+		var (
+			dcs []data.BackupCollection
+			err error
+		)
+
+		// mailbox settings aren't stored in containers, and don't
+		// use the folder-based handlers.
+		if scope.Category().PathType() == path.MailboxSettingsCategory {
+			dcs, err = exchange.CreateMailboxSettingsCollections(
+				ctx,
+				bpc,
+				ac.MailboxSettings(),
+				tenantID,
+				scope,
+				su,
+				counter)
+		} else {
+			dcs, err = exchange.CreateCollections(
+				ctx,
+				bpc,
+				handlers,
+				tenantID,
+				scope,
+				cdps[scope.Category().PathType()],
+				su,
+				counter,
+				errs)
+		}
+
 		if err != nil {
 			el.AddRecoverable(ctx, err)
 			continue
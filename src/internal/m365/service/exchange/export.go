@@ -71,6 +71,7 @@ func (h *baseExchangeHandler) ProduceExportCollections(
 					pth.String(),
 					[]data.RestoreCollection{dc},
 					backupVersion,
+					exportCfg,
 					stats))
 		default:
 			return nil, clues.NewWC(ctx, "data category not supported").
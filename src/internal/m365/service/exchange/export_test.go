@@ -2,11 +2,14 @@ package exchange
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/alcionai/clues"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/converters/eml/testdata"
@@ -174,6 +177,7 @@ func (suite *ExportUnitSuite) TestGetItems() {
 				"",
 				[]data.RestoreCollection{test.backingCollection},
 				test.version,
+				control.DefaultExportConfig(),
 				stats)
 
 			items := ec.Items(ctx)
@@ -225,6 +229,171 @@ func (suite *ExportUnitSuite) TestGetItems() {
 	}
 }
 
+func (suite *ExportUnitSuite) TestGetItems_aggregate() {
+	t := suite.T()
+
+	contactsPath, err := path.Builder{}.
+		Append("Friends").
+		ToDataLayerPath("t", "r", path.ExchangeService, path.ContactsCategory, false)
+	assert.NoError(t, err, "build path")
+
+	eventsPath, err := path.Builder{}.
+		Append("Calendar").
+		ToDataLayerPath("t", "r", path.ExchangeService, path.EventsCategory, false)
+	assert.NoError(t, err, "build path")
+
+	// backup collections are stored under folder ids, with the display
+	// names held in their location.
+	contactsIDPath, err := path.Builder{}.
+		Append("AAMkAGZm-folder-id").
+		ToDataLayerPath("t", "r", path.ExchangeService, path.ContactsCategory, false)
+	assert.NoError(t, err, "build path")
+
+	newColl := func(p path.Path, bodies ...[]byte) data.RestoreCollection {
+		items := make([]data.Item, 0, len(bodies))
+
+		for i, body := range bodies {
+			items = append(items, &dataMock.Item{
+				ItemID: fmt.Sprintf("id%d", i+1),
+				Reader: io.NopCloser(bytes.NewReader(body)),
+			})
+		}
+
+		return data.NoFetchRestoreCollection{
+			Collection: dataMock.Collection{
+				Path:     p,
+				ItemData: items,
+			},
+		}
+	}
+
+	table := []struct {
+		name         string
+		coll         data.RestoreCollection
+		cfg          control.ExportConfig
+		expectNames  []string
+		expectErrIDs []string
+		expectCounts map[string]int
+	}{
+		{
+			name: "contacts, not aggregated",
+			coll: newColl(
+				contactsPath,
+				exchMock.ContactBytes("a"),
+				exchMock.ContactBytes("b")),
+			cfg:         control.ExportConfig{},
+			expectNames: []string{"id1.vcf", "id2.vcf"},
+		},
+		{
+			name: "contacts, aggregated",
+			coll: newColl(
+				contactsPath,
+				exchMock.ContactBytes("a"),
+				[]byte("not json"),
+				exchMock.ContactBytes("b")),
+			cfg:          control.ExportConfig{Aggregate: true},
+			expectNames:  []string{"Friends.vcf"},
+			expectErrIDs: []string{"id2"},
+			expectCounts: map[string]int{"BEGIN:VCARD": 2},
+		},
+		{
+			name: "contacts, aggregated, named by location",
+			coll: dataMock.Collection{
+				Path: contactsIDPath,
+				Loc:  path.Builder{}.Append("Friends"),
+				ItemData: []data.Item{
+					&dataMock.Item{
+						ItemID: "id1",
+						Reader: io.NopCloser(bytes.NewReader(exchMock.ContactBytes("a"))),
+					},
+				},
+			},
+			cfg:          control.ExportConfig{Aggregate: true},
+			expectNames:  []string{"Friends.vcf"},
+			expectCounts: map[string]int{"BEGIN:VCARD": 1},
+		},
+		{
+			name: "contacts, csv",
+			coll: newColl(
+				contactsPath,
+				exchMock.ContactBytes("a"),
+				exchMock.ContactBytes("b")),
+			cfg:          control.ExportConfig{Format: control.CSVFormat},
+			expectNames:  []string{"Friends.csv"},
+			expectCounts: map[string]int{"First Name": 1, "\r\n": 3},
+		},
+		{
+			name: "events, aggregated",
+			coll: newColl(
+				eventsPath,
+				exchMock.EventBytes("a"),
+				exchMock.EventBytes("b")),
+			cfg:          control.ExportConfig{Aggregate: true},
+			expectNames:  []string{"Calendar.ics"},
+			expectCounts: map[string]int{"BEGIN:VCALENDAR": 1, "BEGIN:VEVENT": 2},
+		},
+		{
+			name: "events, csv is not aggregated",
+			coll: newColl(
+				eventsPath,
+				exchMock.EventBytes("a")),
+			cfg:         control.ExportConfig{Format: control.CSVFormat},
+			expectNames: []string{"id1.ics"},
+		},
+		{
+			name: "aggregate with no valid items",
+			coll: newColl(
+				eventsPath,
+				[]byte("not json")),
+			cfg:          control.ExportConfig{Aggregate: true},
+			expectErrIDs: []string{"id1"},
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			ec := exchange.NewExportCollection(
+				"",
+				[]data.RestoreCollection{test.coll},
+				version.Backup,
+				test.cfg,
+				metrics.NewExportStats())
+
+			var (
+				names   []string
+				errIDs  []string
+				content string
+			)
+
+			for item := range ec.Items(ctx) {
+				if item.Error != nil {
+					errIDs = append(errIDs, item.ID)
+					continue
+				}
+
+				names = append(names, item.Name)
+
+				bs, err := io.ReadAll(item.Body)
+				require.NoError(t, err, clues.ToCore(err))
+
+				content += string(bs)
+			}
+
+			assert.ElementsMatch(t, test.expectNames, names, "names")
+			assert.ElementsMatch(t, test.expectErrIDs, errIDs, "errored items")
+
+			for sub, expect := range test.expectCounts {
+				assert.Equal(t, expect, strings.Count(content, sub), sub)
+			}
+		})
+	}
+}
+
 func (suite *ExportUnitSuite) TestExportRestoreCollections() {
 	t := suite.T()
 
@@ -0,0 +1,126 @@
+package exchange
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/exchange"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+var _ inject.RestorePlanner = &exchangeHandler{}
+
+// PlanRestoreCollections resolves the restore destination of each
+// collection and looks up the items it already holds, recording the
+// action that the restore would take on each item.  Folders that don't
+// exist yet aren't created; every item bound for them gets planned as a
+// new item.
+func (h *exchangeHandler) PlanRestoreCollections(
+	ctx context.Context,
+	rcc inject.RestoreConsumerConfig,
+	dcs []data.RestoreCollection,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	if len(dcs) == 0 {
+		return clues.WrapWC(ctx, data.ErrNoData, "planning restore")
+	}
+
+	ctx = graph.BindRateLimiterConfig(
+		ctx,
+		graph.LimiterCfg{Service: path.ExchangeService})
+
+	var (
+		resourceID     = rcc.ProtectedResource.ID()
+		directoryCache = make(map[path.CategoryType]graph.ContainerResolver)
+		handlers       = exchange.RestoreHandlers(h.apiClient, rcc.RestoreConfig.PrincipalMap)
+		el             = errs.Local()
+	)
+
+	for _, dc := range dcs {
+		if el.Failure() != nil {
+			break
+		}
+
+		var (
+			category = dc.FullPath().Category()
+			ictx     = clues.Add(
+				ctx,
+				"restore_category", category,
+				"restore_full_path", dc.FullPath())
+		)
+
+		if category == path.MailboxSettingsCategory {
+			err := exchange.PlanMailboxSettings(
+				ictx,
+				h.apiClient.MailboxSettings(),
+				dc,
+				resourceID,
+				rcc.RestoreConfig.OnCollision,
+				p,
+				errs)
+			if err != nil {
+				el.AddRecoverable(ictx, err)
+			}
+
+			continue
+		}
+
+		handler, ok := handlers[category]
+		if !ok {
+			el.AddRecoverable(ictx, clues.NewWC(ictx, "unsupported restore path category"))
+			continue
+		}
+
+		if directoryCache[category] == nil {
+			gcr := handler.NewContainerCache(resourceID)
+			if err := gcr.Populate(ictx, errs, handler.DefaultRootContainer()); err != nil {
+				return clues.Wrap(err, "populating container cache")
+			}
+
+			directoryCache[category] = gcr
+		}
+
+		var (
+			restoreFolderPath    = handler.FormatRestoreDestination(rcc.RestoreConfig.Location, dc.FullPath())
+			containerID          = exchange.PlanDestination(handler, directoryCache[category], restoreFolderPath, dc.FullPath())
+			collisionKeyToItemID = map[string]string{}
+		)
+
+		ictx = clues.Add(
+			ictx,
+			"restore_folder_path", restoreFolderPath,
+			"restore_destination_id", containerID)
+
+		if len(containerID) > 0 {
+			var err error
+
+			collisionKeyToItemID, err = handler.GetItemsInContainerByCollisionKey(ictx, resourceID, containerID)
+			if err != nil {
+				el.AddRecoverable(ictx, clues.Wrap(err, "building item collision cache"))
+				continue
+			}
+		}
+
+		err := exchange.PlanCollection(
+			ictx,
+			handler,
+			dc,
+			restoreFolderPath,
+			collisionKeyToItemID,
+			rcc.RestoreConfig.OnCollision,
+			p,
+			errs)
+		if err != nil {
+			el.AddRecoverable(ictx, err)
+		}
+	}
+
+	return el.Failure()
+}
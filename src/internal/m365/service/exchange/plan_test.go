@@ -0,0 +1,51 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type PlanUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlanUnitSuite(t *testing.T) {
+	suite.Run(t, &PlanUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlanUnitSuite) TestPlanRestoreCollections_noCollections() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		p   = plan.New()
+		rcc = inject.RestoreConsumerConfig{
+			BackupVersion:     version.Backup,
+			Options:           control.DefaultOptions(),
+			ProtectedResource: idname.NewProvider("uid", "user"),
+			RestoreConfig:     control.RestoreConfig{OnCollision: control.Skip},
+			Selector:          selectors.NewExchangeRestore([]string{"uid"}).Selector,
+		}
+	)
+
+	err := NewExchangeHandler(api.Client{}, nil).
+		PlanRestoreCollections(ctx, rcc, []data.RestoreCollection{}, p, fault.New(true))
+	assert.ErrorIs(t, err, data.ErrNoData, clues.ToCore(err))
+	assert.Empty(t, p.Items())
+}
@@ -45,7 +45,7 @@ func (h *exchangeHandler) ConsumeRestoreCollections(
 		deets          = &details.Builder{}
 		resourceID     = rcc.ProtectedResource.ID()
 		directoryCache = make(map[path.CategoryType]graph.ContainerResolver)
-		handlers       = exchange.RestoreHandlers(h.apiClient)
+		handlers       = exchange.RestoreHandlers(h.apiClient, rcc.RestoreConfig.PrincipalMap)
 		metrics        support.CollectionMetrics
 		el             = errs.Local()
 	)
@@ -63,6 +63,26 @@ func (h *exchangeHandler) ConsumeRestoreCollections(
 				"restore_full_path", dc.FullPath())
 		)
 
+		if category == path.MailboxSettingsCategory {
+			temp, err := exchange.RestoreMailboxSettings(
+				ictx,
+				h.apiClient.MailboxSettings(),
+				dc,
+				resourceID,
+				rcc.RestoreConfig.OnCollision,
+				deets,
+				errs,
+				ctr)
+
+			metrics = support.CombineMetrics(metrics, temp)
+
+			if err != nil {
+				el.AddRecoverable(ictx, err)
+			}
+
+			continue
+		}
+
 		handler, ok := handlers[category]
 		if !ok {
 			el.AddRecoverable(ictx, clues.NewWC(ictx, "unsupported restore path category"))
@@ -113,6 +113,12 @@ func (groupsBackup) ProduceBackupCollections(
 				scope,
 				cl,
 				el)
+		case path.PlannerCategory:
+			colls, err = backupPlanner(
+				ictx,
+				bc,
+				scope,
+				cl)
 		}
 
 		if err != nil {
@@ -380,6 +386,52 @@ func backupConversations(
 	return colls, nil
 }
 
+func backupPlanner(
+	ctx context.Context,
+	bc backupCommon,
+	scope selectors.GroupsScope,
+	counter *count.Bus,
+) ([]data.BackupCollection, error) {
+	var colls []data.BackupCollection
+
+	progressMessage := observe.MessageWithCompletion(
+		ctx,
+		observe.ProgressCfg{
+			Indent:            1,
+			CompletionMessage: func() string { return fmt.Sprintf("(found %d plans)", len(colls)) },
+		},
+		scope.Category().PathType().HumanString())
+	defer close(progressMessage)
+
+	colls, err := groups.CreatePlannerCollections(
+		ctx,
+		bc.producerConfig,
+		bc.apiCli.Planner(),
+		bc.creds.AzureTenantID,
+		scope,
+		bc.statusUpdater,
+		counter)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	// planner doesn't support delta queries, so each backup replaces
+	// the plans held by the previous backup.
+	tp, err := path.BuildPrefix(
+		bc.creds.AzureTenantID,
+		bc.producerConfig.ProtectedResource.ID(),
+		path.GroupsService,
+		path.PlannerCategory)
+	if err != nil {
+		err = clues.WrapWC(ctx, err, "getting planner path").Label(count.BadPathPrefix)
+		return nil, err
+	}
+
+	colls = append(colls, data.NewTombstoneCollection(tp, control.Options{}, counter))
+
+	return colls, nil
+}
+
 // ---------------------------------------------------------------------------
 // metadata
 // ---------------------------------------------------------------------------
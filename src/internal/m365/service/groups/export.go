@@ -32,6 +32,7 @@ func NewGroupsHandler(
 		baseGroupsHandler: baseGroupsHandler{
 			backupDriveIDNames: idname.NewCache(nil),
 			backupSiteIDWebURL: idname.NewCache(nil),
+			backupItemTimes:    drive.NewBackupItemTimes(),
 		},
 		apiClient:      apiClient,
 		resourceGetter: resourceGetter,
@@ -47,6 +48,7 @@ func NewGroupsHandler(
 type baseGroupsHandler struct {
 	backupDriveIDNames idname.CacheBuilder
 	backupSiteIDWebURL idname.CacheBuilder
+	backupItemTimes    drive.BackupItemTimes
 }
 
 func (h *baseGroupsHandler) CacheItemInfo(v details.ItemInfo) {
@@ -56,6 +58,7 @@ func (h *baseGroupsHandler) CacheItemInfo(v details.ItemInfo) {
 
 	h.backupDriveIDNames.Add(v.Groups.DriveID, v.Groups.DriveName)
 	h.backupSiteIDWebURL.Add(v.Groups.SiteID, v.Groups.WebURL)
+	h.backupItemTimes.CacheItemInfo(v)
 }
 
 // ProduceExportCollections will create the export collections for the
@@ -82,7 +85,7 @@ func (h *baseGroupsHandler) ProduceExportCollections(
 		)
 
 		switch cat {
-		case path.ChannelMessagesCategory, path.ConversationPostsCategory:
+		case path.ChannelMessagesCategory, path.ConversationPostsCategory, path.PlannerCategory:
 			folders = append(folders, fp.Folders()...)
 
 			coll = groups.NewExportCollection(
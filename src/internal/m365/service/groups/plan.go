@@ -0,0 +1,143 @@
+package groups
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/groups"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+var _ inject.RestorePlanner = &groupsHandler{}
+
+// PlanRestoreCollections looks up the site libraries and plans that each
+// collection would be restored into, and records the action that the
+// restore would take on each item.  Nothing gets created.  Channel
+// messages can't be restored, so they're left out of the plan.
+func (h *groupsHandler) PlanRestoreCollections(
+	ctx context.Context,
+	rcc inject.RestoreConsumerConfig,
+	dcs []data.RestoreCollection,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	if len(dcs) == 0 {
+		return clues.WrapWC(ctx, data.ErrNoData, "planning restore")
+	}
+
+	ctx = graph.BindRateLimiterConfig(
+		ctx,
+		graph.LimiterCfg{Service: path.GroupsService})
+
+	var (
+		caches = drive.NewRestoreCaches(h.backupDriveIDNames)
+		lrh    = drive.NewSiteRestoreHandler(
+			h.apiClient,
+			rcc.Selector.PathService())
+		el                = errs.Local()
+		webURLToSiteNames = map[string]string{}
+		planCache         = groups.NewPlannerRestoreCache()
+		cachedSites       = map[string]struct{}{}
+	)
+
+	for _, dc := range dcs {
+		if el.Failure() != nil {
+			break
+		}
+
+		var (
+			err      error
+			category = dc.FullPath().Category()
+			ictx     = clues.Add(ctx,
+				"category", category,
+				"restore_location", clues.Hide(rcc.RestoreConfig.Location),
+				"protected_resource", clues.Hide(dc.FullPath().ProtectedResource()),
+				"full_path", dc.FullPath())
+		)
+
+		switch category {
+		case path.LibrariesCategory:
+			siteID := dc.FullPath().Folders()[1]
+
+			webURL, ok := h.backupSiteIDWebURL.NameOf(siteID)
+			if !ok {
+				logger.Ctx(ictx).With("site_id", siteID).Info("site weburl not found, using site id")
+			}
+
+			siteName, err := getSiteName(ictx, siteID, webURL, h.apiClient.Sites(), webURLToSiteNames)
+			if err != nil {
+				el.AddRecoverable(ictx, clues.Wrap(err, "getting site").
+					With("web_url", webURL, "site_id", siteID))
+
+				continue
+			}
+
+			if len(siteName) == 0 {
+				// the site no longer exists, and the restore would skip it.
+				continue
+			}
+
+			srcc := inject.RestoreConsumerConfig{
+				BackupVersion:     rcc.BackupVersion,
+				Options:           rcc.Options,
+				ProtectedResource: idname.NewProvider(siteID, siteName),
+				RestoreConfig:     rcc.RestoreConfig,
+				Selector:          rcc.Selector,
+			}
+
+			if _, ok := cachedSites[siteID]; !ok {
+				if err := caches.PopulateDrives(ictx, lrh, siteID); err != nil {
+					return clues.Wrap(err, "initializing restore caches")
+				}
+
+				cachedSites[siteID] = struct{}{}
+			}
+
+			err = drive.PlanCollection(
+				ictx,
+				lrh,
+				srcc,
+				dc,
+				caches,
+				control.DefaultRestoreContainerName(dttm.HumanReadableDriveItem),
+				p,
+				errs)
+
+		case path.PlannerCategory:
+			err = groups.PlanPlannerCollection(
+				ictx,
+				h.apiClient.Planner(),
+				dc,
+				rcc.ProtectedResource.ID(),
+				rcc.RestoreConfig.Location,
+				rcc.RestoreConfig.OnCollision,
+				planCache,
+				p,
+				errs)
+
+		case path.ChannelMessagesCategory:
+			logger.Ctx(ictx).Debug("channel messages are not restored")
+
+		default:
+			return clues.NewWC(ictx, "data category not supported").
+				With("category", category)
+		}
+
+		if err != nil {
+			el.AddRecoverable(ictx, err)
+		}
+	}
+
+	return el.Failure()
+}
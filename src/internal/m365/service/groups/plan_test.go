@@ -0,0 +1,99 @@
+package groups
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type PlanUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlanUnitSuite(t *testing.T) {
+	suite.Run(t, &PlanUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlanUnitSuite) TestPlanRestoreCollections() {
+	collection := func(t *testing.T, cat path.CategoryType, folders ...string) data.RestoreCollection {
+		fp, err := path.Build("t", "gid", path.GroupsService, cat, false, folders...)
+		require.NoError(t, err, clues.ToCore(err))
+
+		return dataMock.Collection{
+			Path:     fp,
+			ItemData: []data.Item{&dataMock.Item{ItemID: "item"}},
+		}
+	}
+
+	table := []struct {
+		name      string
+		dcs       func(t *testing.T) []data.RestoreCollection
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "no collections",
+			dcs:       func(t *testing.T) []data.RestoreCollection { return nil },
+			expectErr: assert.Error,
+		},
+		{
+			// channel messages can't be restored, so they never show up
+			// in the plan.
+			name: "channel messages",
+			dcs: func(t *testing.T) []data.RestoreCollection {
+				return []data.RestoreCollection{
+					collection(t, path.ChannelMessagesCategory, "channel"),
+				}
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "conversation posts",
+			dcs: func(t *testing.T) []data.RestoreCollection {
+				return []data.RestoreCollection{
+					collection(t, path.ConversationPostsCategory, "conversation", "thread"),
+				}
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				p   = plan.New()
+				rcc = inject.RestoreConsumerConfig{
+					BackupVersion:     version.Backup,
+					Options:           control.DefaultOptions(),
+					ProtectedResource: idname.NewProvider("gid", "group"),
+					RestoreConfig:     control.RestoreConfig{OnCollision: control.Skip},
+					Selector:          selectors.NewGroupsRestore([]string{"gid"}).Selector,
+				}
+			)
+
+			err := NewGroupsHandler(api.Client{}, nil).
+				PlanRestoreCollections(ctx, rcc, test.dcs(t), p, fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Empty(t, p.Items())
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/alcionai/corso/src/internal/common/ptr"
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/groups"
 	"github.com/alcionai/corso/src/internal/m365/support"
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/pkg/backup/details"
@@ -56,8 +57,11 @@ func (h *groupsHandler) ConsumeRestoreCollections(
 			rcc.Selector.PathService())
 		el                = errs.Local()
 		webURLToSiteNames = map[string]string{}
+		planCache         = groups.NewPlannerRestoreCache()
 	)
 
+	caches.BackupItemTimes = h.backupItemTimes
+
 	// Reorder collections so that the parents directories are created
 	// before the child directories; a requirement for permissions.
 	data.SortRestoreCollections(dcs)
@@ -109,7 +113,14 @@ func (h *groupsHandler) ConsumeRestoreCollections(
 				Selector:          rcc.Selector,
 			}
 
-			err = caches.Populate(ictx, h.apiClient.Users(), h.apiClient.Groups(), lrh, srcc.ProtectedResource.ID(), errs)
+			err = caches.Populate(
+				ictx,
+				h.apiClient.Users(),
+				h.apiClient.Groups(),
+				lrh,
+				srcc.ProtectedResource.ID(),
+				srcc.RestoreConfig.PermissionPrincipals(),
+				errs)
 			if err != nil {
 				return nil, nil, clues.Wrap(err, "initializing restore caches")
 			}
@@ -124,6 +135,18 @@ func (h *groupsHandler) ConsumeRestoreCollections(
 				control.DefaultRestoreContainerName(dttm.HumanReadableDriveItem),
 				errs,
 				ctr)
+		case path.PlannerCategory:
+			metrics, err = groups.RestorePlannerCollection(
+				ictx,
+				h.apiClient.Planner(),
+				dc,
+				rcc.ProtectedResource.ID(),
+				rcc.RestoreConfig.Location,
+				rcc.RestoreConfig.OnCollision,
+				planCache,
+				deets,
+				errs,
+				ctr)
 		case path.ChannelMessagesCategory:
 			// Message cannot be restored as of now using Graph API.
 			logger.Ctx(ictx).Debug("Skipping restore for channel messages")
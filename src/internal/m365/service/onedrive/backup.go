@@ -68,6 +68,7 @@ func (oneDriveBackup) ProduceBackupCollections(
 			bpc.ProtectedResource,
 			su,
 			bpc.Options,
+			bpc.Selector.DriveExclusions,
 			counter)
 
 		progressMessage := observe.MessageWithCompletion(
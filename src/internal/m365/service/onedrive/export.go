@@ -28,6 +28,7 @@ func NewOneDriveHandler(
 	return &onedriveHandler{
 		baseOneDriveHandler: baseOneDriveHandler{
 			backupDriveIDNames: idname.NewCache(nil),
+			backupItemTimes:    drive.NewBackupItemTimes(),
 		},
 		apiClient:      apiClient,
 		resourceGetter: resourceGetter,
@@ -42,6 +43,7 @@ func NewOneDriveHandler(
 // (e.x. export) that don't require contact with external M356 services.
 type baseOneDriveHandler struct {
 	backupDriveIDNames idname.CacheBuilder
+	backupItemTimes    drive.BackupItemTimes
 }
 
 func (h *baseOneDriveHandler) CacheItemInfo(v details.ItemInfo) {
@@ -50,6 +52,7 @@ func (h *baseOneDriveHandler) CacheItemInfo(v details.ItemInfo) {
 	}
 
 	h.backupDriveIDNames.Add(v.OneDrive.DriveID, v.OneDrive.DriveName)
+	h.backupItemTimes.CacheItemInfo(v)
 }
 
 // ProduceExportCollections will create the export collections for the
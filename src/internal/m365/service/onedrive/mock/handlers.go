@@ -570,6 +570,10 @@ type RestoreHandler struct {
 	PostItemResp   models.DriveItemable
 	PostItemErr    error
 
+	CalledPatchItem bool
+	PatchItemReq    models.DriveItemable
+	PatchItemErr    error
+
 	DrivePagerV pagers.NonDeltaHandler[models.Driveable]
 
 	PostDriveResp models.Driveable
@@ -656,6 +660,17 @@ func (h *RestoreHandler) PostItemInContainer(
 	return h.PostItemResp, h.PostItemErr
 }
 
+func (h *RestoreHandler) PatchItem(
+	_ context.Context,
+	_, _ string,
+	item models.DriveItemable,
+) error {
+	h.CalledPatchItem = true
+	h.PatchItemReq = item
+
+	return h.PatchItemErr
+}
+
 func (h *RestoreHandler) GetFolderByName(
 	context.Context,
 	string, string, string,
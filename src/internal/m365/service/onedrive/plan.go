@@ -0,0 +1,74 @@
+package onedrive
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+var _ inject.RestorePlanner = &onedriveHandler{}
+
+// PlanRestoreCollections looks up the drive and folders that each
+// collection would be restored into, and records the action that the
+// restore would take on each item.  Nothing gets created.
+func (h *onedriveHandler) PlanRestoreCollections(
+	ctx context.Context,
+	rcc inject.RestoreConsumerConfig,
+	dcs []data.RestoreCollection,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	if len(dcs) == 0 {
+		return clues.WrapWC(ctx, data.ErrNoData, "planning restore")
+	}
+
+	ctx = graph.BindRateLimiterConfig(
+		ctx,
+		graph.LimiterCfg{Service: path.OneDriveService})
+
+	var (
+		el     = errs.Local()
+		caches = drive.NewRestoreCaches(h.backupDriveIDNames)
+		rh     = drive.NewUserDriveRestoreHandler(h.apiClient)
+	)
+
+	ctx = clues.Add(ctx, "backup_version", rcc.BackupVersion)
+
+	if err := caches.PopulateDrives(ctx, rh, rcc.ProtectedResource.ID()); err != nil {
+		return clues.Wrap(err, "initializing restore caches")
+	}
+
+	for _, dc := range dcs {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(
+			ctx,
+			"category", dc.FullPath().Category(),
+			"full_path", dc.FullPath())
+
+		err := drive.PlanCollection(
+			ictx,
+			rh,
+			rcc,
+			dc,
+			caches,
+			rcc.RestoreConfig.Location,
+			p,
+			errs)
+		if err != nil {
+			el.AddRecoverable(ictx, err)
+		}
+	}
+
+	return el.Failure()
+}
@@ -52,8 +52,16 @@ func (h *onedriveHandler) ConsumeRestoreCollections(
 	)
 
 	ctx = clues.Add(ctx, "backup_version", rcc.BackupVersion)
+	caches.BackupItemTimes = h.backupItemTimes
 
-	err := caches.Populate(ctx, h.apiClient.Users(), h.apiClient.Groups(), rh, rcc.ProtectedResource.ID(), errs)
+	err := caches.Populate(
+		ctx,
+		h.apiClient.Users(),
+		h.apiClient.Groups(),
+		rh,
+		rcc.ProtectedResource.ID(),
+		rcc.RestoreConfig.PermissionPrincipals(),
+		errs)
 	if err != nil {
 		return nil, nil, clues.Wrap(err, "initializing restore caches")
 	}
@@ -113,6 +113,7 @@ func (suite *LibrariesBackupUnitSuite) TestUpdateCollections() {
 				idname.NewProvider(siteID, siteID),
 				nil,
 				control.DefaultOptions(),
+				nil,
 				count.New())
 
 			c.CollectionMap = collMap
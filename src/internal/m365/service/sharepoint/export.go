@@ -30,6 +30,7 @@ func NewSharePointHandler(
 	return &sharepointHandler{
 		baseSharePointHandler: baseSharePointHandler{
 			backupDriveIDNames: idname.NewCache(nil),
+			backupItemTimes:    drive.NewBackupItemTimes(),
 		},
 		apiClient:      apiClient,
 		resourceGetter: resourceGetter,
@@ -44,6 +45,7 @@ func NewSharePointHandler(
 // (e.x. export) that don't require contact with external M356 services.
 type baseSharePointHandler struct {
 	backupDriveIDNames idname.CacheBuilder
+	backupItemTimes    drive.BackupItemTimes
 }
 
 func (h *baseSharePointHandler) CacheItemInfo(v details.ItemInfo) {
@@ -55,6 +57,8 @@ func (h *baseSharePointHandler) CacheItemInfo(v details.ItemInfo) {
 	case v.OneDrive != nil:
 		h.backupDriveIDNames.Add(v.OneDrive.DriveID, v.OneDrive.DriveName)
 	}
+
+	h.backupItemTimes.CacheItemInfo(v)
 }
 
 // ProduceExportCollections will create the export collections for the
@@ -0,0 +1,123 @@
+package sharepoint
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/data"
+	"github.com/alcionai/corso/src/internal/m365/collection/drive"
+	"github.com/alcionai/corso/src/internal/m365/collection/site"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+)
+
+var _ inject.RestorePlanner = &sharepointHandler{}
+
+// PlanRestoreCollections looks up the libraries, folders, and lists that
+// each collection would be restored into, and records the action that the
+// restore would take on each item.  Nothing gets created.
+func (h *sharepointHandler) PlanRestoreCollections(
+	ctx context.Context,
+	rcc inject.RestoreConsumerConfig,
+	dcs []data.RestoreCollection,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	if len(dcs) == 0 {
+		return clues.WrapWC(ctx, data.ErrNoData, "planning restore")
+	}
+
+	ctx = graph.BindRateLimiterConfig(
+		ctx,
+		graph.LimiterCfg{Service: path.SharePointService})
+
+	var (
+		lrh = drive.NewSiteRestoreHandler(
+			h.apiClient,
+			rcc.Selector.PathService())
+		listsRh = site.NewListsRestoreHandler(
+			rcc.ProtectedResource.ID(),
+			h.apiClient.Lists())
+		caches         = drive.NewRestoreCaches(h.backupDriveIDNames)
+		drivesCached   bool
+		listCollisions map[string]string
+		el             = errs.Local()
+	)
+
+	for _, dc := range dcs {
+		if el.Failure() != nil {
+			break
+		}
+
+		var (
+			err      error
+			category = dc.FullPath().Category()
+			ictx     = clues.Add(ctx,
+				"category", category,
+				"restore_location", clues.Hide(rcc.RestoreConfig.Location),
+				"resource_owner", clues.Hide(dc.FullPath().ProtectedResource()),
+				"full_path", dc.FullPath())
+		)
+
+		switch category {
+		case path.LibrariesCategory:
+			if !drivesCached {
+				if err := caches.PopulateDrives(ictx, lrh, rcc.ProtectedResource.ID()); err != nil {
+					return clues.Wrap(err, "initializing restore caches")
+				}
+
+				drivesCached = true
+			}
+
+			err = drive.PlanCollection(
+				ictx,
+				lrh,
+				rcc,
+				dc,
+				caches,
+				control.DefaultRestoreContainerName(dttm.HumanReadableDriveItem),
+				p,
+				errs)
+
+		case path.ListsCategory:
+			if listCollisions == nil {
+				listCollisions, err = listsRh.GetListsByCollisionKey(ictx)
+				if err != nil {
+					el.AddRecoverable(ictx, clues.Wrap(err, "building lists collision map"))
+					continue
+				}
+			}
+
+			err = site.PlanListCollection(
+				ictx,
+				dc,
+				rcc.RestoreConfig,
+				listCollisions,
+				p,
+				errs)
+
+		case path.PagesCategory:
+			err = site.PlanPageCollection(
+				ictx,
+				dc,
+				rcc.RestoreConfig.Location,
+				p,
+				errs)
+
+		default:
+			return clues.NewWC(ictx, "category not supported").With("category", category)
+		}
+
+		if err != nil {
+			el.AddRecoverable(ictx, err)
+		}
+	}
+
+	return el.Failure()
+}
@@ -0,0 +1,111 @@
+package sharepoint
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/idname"
+	"github.com/alcionai/corso/src/internal/data"
+	dataMock "github.com/alcionai/corso/src/internal/data/mock"
+	spMock "github.com/alcionai/corso/src/internal/m365/service/sharepoint/mock"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/services/m365/api"
+)
+
+type PlanUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlanUnitSuite(t *testing.T) {
+	suite.Run(t, &PlanUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlanUnitSuite) TestPlanRestoreCollections() {
+	pagesPath, err := path.Build("t", "sid", path.SharePointService, path.PagesCategory, false, "SitePages")
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name      string
+		dcs       func() []data.RestoreCollection
+		expectErr assert.ErrorAssertionFunc
+		expect    map[string]plan.Action
+	}{
+		{
+			name:      "no collections",
+			dcs:       func() []data.RestoreCollection { return nil },
+			expectErr: assert.Error,
+			expect:    map[string]plan.Action{},
+		},
+		{
+			// pages are always restored as new pages, whatever the
+			// collision policy.
+			name: "pages",
+			dcs: func() []data.RestoreCollection {
+				return []data.RestoreCollection{
+					dataMock.Collection{
+						Path: pagesPath,
+						ItemData: []data.Item{
+							&dataMock.Item{
+								ItemID: "page1",
+								Reader: io.NopCloser(bytes.NewReader(spMock.Page("home"))),
+							},
+						},
+					},
+				}
+			},
+			expectErr: assert.NoError,
+			expect: map[string]plan.Action{
+				path.Builder{}.
+					Append(path.PagesCategory.HumanString(), "Corso_Restore_home.aspx").
+					String(): plan.Create,
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				p   = plan.New()
+				rcc = inject.RestoreConsumerConfig{
+					BackupVersion:     version.Backup,
+					Options:           control.DefaultOptions(),
+					ProtectedResource: idname.NewProvider("sid", "site"),
+					RestoreConfig: control.RestoreConfig{
+						Location:    "Corso_Restore",
+						OnCollision: control.Replace,
+					},
+					Selector: selectors.NewSharePointRestore([]string{"sid"}).Selector,
+				}
+			)
+
+			err := NewSharePointHandler(api.Client{}, nil).
+				PlanRestoreCollections(ctx, rcc, test.dcs(), p, fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
+
+			planned := map[string]plan.Action{}
+
+			for _, item := range p.Items() {
+				planned[item.Destination] = item.Action
+			}
+
+			assert.Equal(t, test.expect, planned)
+		})
+	}
+}
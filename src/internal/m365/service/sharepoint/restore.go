@@ -59,6 +59,8 @@ func (h *sharepointHandler) ConsumeRestoreCollections(
 		cl = ctr.Local()
 	)
 
+	caches.BackupItemTimes = h.backupItemTimes
+
 	// Reorder collections so that the parents directories are created
 	// before the child directories; a requirement for permissions.
 	data.SortRestoreCollections(dcs)
@@ -83,7 +85,14 @@ func (h *sharepointHandler) ConsumeRestoreCollections(
 
 		switch dc.FullPath().Category() {
 		case path.LibrariesCategory:
-			err = caches.Populate(ctx, h.apiClient.Users(), h.apiClient.Groups(), lrh, rcc.ProtectedResource.ID(), errs)
+			err = caches.Populate(
+				ctx,
+				h.apiClient.Users(),
+				h.apiClient.Groups(),
+				lrh,
+				rcc.ProtectedResource.ID(),
+				rcc.RestoreConfig.PermissionPrincipals(),
+				errs)
 			if err != nil {
 				return nil, nil, clues.Wrap(err, "initializing restore caches")
 			}
@@ -67,6 +67,11 @@ const (
 	//
 	// See comment on BackupTypeTag for more information.
 	PreviewBackup = "preview-backup"
+	// LabelTagPrefix prefixes the keys of user-defined labels when they're
+	// stored as tags, so that they can't collide with the tags above.
+	LabelTagPrefix = "label-"
+	// LegalHoldTag marks backups that are under at least one legal hold.
+	LegalHoldTag = "legal-hold"
 )
 
 // Valid returns true if the ModelType value fits within the const range.
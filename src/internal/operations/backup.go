@@ -981,9 +981,8 @@ func (op *BackupOperation) createBackupModels(
 
 	ctx = clues.Add(ctx, "streamstore_snapshot_id", ssid)
 
-	tags := map[string]string{
-		model.ServiceTag: op.Selectors.PathService().String(),
-	}
+	tags := backup.LabelTags(op.Options.Labels)
+	tags[model.ServiceTag] = op.Selectors.PathService().String()
 
 	// Add tags to mark this backup as preview, assist, or merge. This is used to:
 	// 1. Filter assist backups by tag during base selection process
@@ -423,6 +423,7 @@ func (suite *BackupOpUnitSuite) TestNewBackupOperation_configuredOptionsMatchInp
 		SkipEventsOnInstance503ForResources: map[string]struct{}{
 			"resource": {},
 		},
+		Labels: map[string]string{"stage": "pre-migration"},
 	}
 
 	t := suite.T()
@@ -2007,6 +2008,7 @@ func (suite *AssistBackupIntegrationSuite) TestBackupTypesForFailureModes() {
 			opts := control.DefaultOptions()
 			opts.FailureHandling = test.failurePolicy
 			opts.PreviewLimits.Enabled = test.previewBackup
+			opts.Labels = map[string]string{"stage": "pre-migration"}
 
 			bo, err := NewBackupOperation(
 				ctx,
@@ -2038,6 +2040,7 @@ func (suite *AssistBackupIntegrationSuite) TestBackupTypesForFailureModes() {
 			require.NoError(t, err, clues.ToCore(err))
 
 			require.Equal(t, test.expectBackupTag, bup.Tags[model.BackupTypeTag])
+			assert.Equal(t, opts.Labels, bup.Labels())
 		})
 	}
 }
@@ -0,0 +1,228 @@
+package operations
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/crash"
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/internal/kopia"
+	kinject "github.com/alcionai/corso/src/internal/kopia/inject"
+	"github.com/alcionai/corso/src/internal/m365/service/onedrive"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/operations/pathtransformer"
+	"github.com/alcionai/corso/src/internal/streamstore"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// BrowseOperation gives on-demand, read-only access to the items of a
+// single backup.  Unlike an export, which produces every selected item,
+// running a browse only loads the backup details.  Item data is read out
+// of the repository, and converted to its export format, as each item
+// is opened.
+type BrowseOperation struct {
+	operation
+
+	BackupID  model.StableID
+	ExportCfg control.ExportConfig
+
+	acct account.Account
+	ec   inject.ExportConsumer
+
+	bup *backup.Backup
+}
+
+// NewBrowseOperation constructs and validates a browse operation.
+func NewBrowseOperation(
+	ctx context.Context,
+	opts control.Options,
+	kw *kopia.Wrapper,
+	sw store.BackupStorer,
+	ec inject.ExportConsumer,
+	acct account.Account,
+	backupID model.StableID,
+	exportCfg control.ExportConfig,
+	bus events.Eventer,
+) (BrowseOperation, error) {
+	op := BrowseOperation{
+		operation: newOperation(opts, bus, count.New(), kw, sw),
+		acct:      acct,
+		BackupID:  backupID,
+		ExportCfg: exportCfg,
+		ec:        ec,
+	}
+	if err := op.validate(); err != nil {
+		return BrowseOperation{}, err
+	}
+
+	return op, nil
+}
+
+func (op BrowseOperation) validate() error {
+	if op.ec == nil {
+		return clues.New("missing export consumer")
+	}
+
+	return op.operation.validate()
+}
+
+// Run loads the backup and its details.  The returned details describe
+// every item that can be opened with OpenItem.
+func (op *BrowseOperation) Run(ctx context.Context) (deets *details.Details, err error) {
+	defer func() {
+		if crErr := crash.Recovery(ctx, recover(), "browse"); crErr != nil {
+			err = crErr
+		}
+	}()
+
+	ctx = clues.Add(
+		ctx,
+		"tenant_id", clues.Hide(op.acct.ID()),
+		"backup_id", op.BackupID)
+
+	bup, err := op.store.GetBackup(ctx, op.BackupID)
+	if err != nil {
+		op.Status = Failed
+		return nil, clues.Wrap(err, "getting backup")
+	}
+
+	sstore := streamstore.NewStreamer(op.kopia, op.acct.ID(), bup.Selector.PathService())
+
+	deets, err = getDetailsFromBackup(ctx, bup, sstore, op.Errors)
+	if err != nil {
+		op.Status = Failed
+		return nil, clues.Wrap(err, "getting backup details")
+	}
+
+	// allow export handlers to name and place items the same way that
+	// an export of the whole backup would.
+	for _, ent := range deets.Entries {
+		op.ec.CacheItemInfo(ent.ItemInfo)
+	}
+
+	op.bup = bup
+	op.Status = Completed
+
+	logger.Ctx(ctx).Infow("loaded backup for browsing", "details_entries", len(deets.Entries))
+
+	return deets, nil
+}
+
+// OpenItem reads the item described by the details entry out of the
+// repository, and produces it in the same format as an export would.
+// The caller is responsible for closing the item's Body.  Run must be
+// called before any items are opened.
+func (op *BrowseOperation) OpenItem(
+	ctx context.Context,
+	ent *details.Entry,
+) (export.Item, error) {
+	if op.bup == nil {
+		return export.Item{}, clues.NewWC(ctx, "browse operation has not been run")
+	}
+
+	ctx = clues.Add(
+		ctx,
+		"backup_id", op.BackupID,
+		"item_repo_ref", clues.Hide(ent.RepoRef))
+
+	return openExportItem(
+		ctx,
+		op.kopia,
+		op.ec,
+		op.bup,
+		ent,
+		op.ExportCfg,
+		fault.New(true))
+}
+
+// openExportItem produces the export of a single backed up item.
+func openExportItem(
+	ctx context.Context,
+	rp kinject.RestoreProducer,
+	ec inject.ExportConsumer,
+	bup *backup.Backup,
+	ent *details.Entry,
+	exportCfg control.ExportConfig,
+	errs *fault.Bus,
+) (export.Item, error) {
+	paths, err := pathtransformer.GetPaths(ctx, bup.Version, []*details.Entry{ent}, errs)
+	if err != nil {
+		return export.Item{}, clues.Wrap(err, "getting item restore path")
+	}
+
+	switch bup.Selector.PathService() {
+	case path.OneDriveService, path.SharePointService, path.GroupsService:
+		paths, err = onedrive.AugmentRestorePaths(bup.Version, paths)
+		if err != nil {
+			return export.Item{}, clues.Wrap(err, "augmenting paths")
+		}
+	}
+
+	dcs, err := rp.ProduceRestoreCollections(ctx, bup.SnapshotID, paths, nil, errs)
+	if err != nil {
+		return export.Item{}, clues.Wrap(err, "producing item collections")
+	}
+
+	ecs, err := ec.ProduceExportCollections(
+		ctx,
+		bup.Version,
+		exportCfg,
+		dcs,
+		&metrics.ExportStats{},
+		errs)
+	if err != nil {
+		return export.Item{}, clues.Wrap(err, "exporting item")
+	}
+
+	var (
+		item     export.Item
+		found    bool
+		firstErr error
+	)
+
+	// every collection must be drained, or else its stream will block
+	// forever.  Only the first successfully exported item is kept.
+	for _, coll := range ecs {
+		for it := range coll.Items(ctx) {
+			switch {
+			case it.Error != nil:
+				if firstErr == nil {
+					firstErr = it.Error
+				}
+			case found:
+				if it.Body != nil {
+					it.Body.Close()
+				}
+			default:
+				item = it
+				found = true
+			}
+		}
+	}
+
+	if found {
+		return item, nil
+	}
+
+	if firstErr != nil {
+		return export.Item{}, clues.Wrap(firstErr, "exporting item")
+	}
+
+	if err := errs.Failure(); err != nil {
+		return export.Item{}, clues.Wrap(err, "exporting item")
+	}
+
+	return export.Item{}, clues.NewWC(ctx, "item not found in backup")
+}
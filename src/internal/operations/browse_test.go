@@ -0,0 +1,184 @@
+package operations
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	evmock "github.com/alcionai/corso/src/internal/events/mock"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/m365/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/metrics"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/selectors"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// mockExportConsumer hands back a fixed set of export collections.
+type mockExportConsumer struct {
+	mock.Controller
+	colls []export.Collectioner
+}
+
+func (mec mockExportConsumer) ProduceExportCollections(
+	_ context.Context,
+	_ int,
+	_ control.ExportConfig,
+	_ []data.RestoreCollection,
+	_ *metrics.ExportStats,
+	_ *fault.Bus,
+) ([]export.Collectioner, error) {
+	return mec.colls, mec.Err
+}
+
+type BrowseUnitSuite struct {
+	tester.Suite
+}
+
+func TestBrowseUnitSuite(t *testing.T) {
+	suite.Run(t, &BrowseUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *BrowseUnitSuite) TestBrowseOperation_OpenItemBeforeRun() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	op, err := NewBrowseOperation(
+		ctx,
+		control.DefaultOptions(),
+		&kopia.Wrapper{},
+		store.NewWrapper(&kopia.ModelStore{}),
+		&mock.Controller{},
+		account.Account{},
+		"foo",
+		control.DefaultExportConfig(),
+		evmock.NewBus())
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = op.OpenItem(ctx, nil)
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *BrowseUnitSuite) TestOpenExportItem() {
+	var (
+		itemPath = makePath(
+			suite.T(),
+			[]string{"tenant", path.ExchangeService.String(), "user", path.EmailCategory.String(), "inbox", "item1"},
+			true)
+		loc = path.Builder{}.Append("Inbox")
+		ent = makeDetailsEntry(suite.T(), itemPath, loc, 42, false)
+		bup = &backup.Backup{
+			SnapshotID: "snap",
+			Version:    version.Backup,
+			Selector:   selectors.NewExchangeBackup([]string{"user"}).Selector,
+		}
+		content = "From: someone"
+	)
+
+	table := []struct {
+		name        string
+		colls       []export.Collectioner
+		consumerErr error
+		expectErr   assert.ErrorAssertionFunc
+		expectName  string
+	}{
+		{
+			name: "single item",
+			colls: []export.Collectioner{
+				expCol{
+					base:  "Emails/Inbox",
+					items: []export.Item{{ID: "item1", Name: "item1.eml", Body: NewReadSeekCloser([]byte(content))}},
+				},
+			},
+			expectErr:  assert.NoError,
+			expectName: "item1.eml",
+		},
+		{
+			name: "errors before the item",
+			colls: []export.Collectioner{
+				expCol{
+					base: "Emails/Inbox",
+					items: []export.Item{
+						{ID: "other", Error: assert.AnError},
+						{ID: "item1", Name: "item1.eml", Body: NewReadSeekCloser([]byte(content))},
+						{ID: "extra", Name: "extra.eml", Body: NewReadSeekCloser([]byte(content))},
+					},
+				},
+			},
+			expectErr:  assert.NoError,
+			expectName: "item1.eml",
+		},
+		{
+			name: "only errors",
+			colls: []export.Collectioner{
+				expCol{
+					base:  "Emails/Inbox",
+					items: []export.Item{{ID: "item1", Error: assert.AnError}},
+				},
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "no items",
+			colls:     []export.Collectioner{expCol{base: "Emails/Inbox"}},
+			expectErr: assert.Error,
+		},
+		{
+			name:        "consumer error",
+			consumerErr: assert.AnError,
+			expectErr:   assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			rp := &mockRestoreProducer{}
+			ec := mockExportConsumer{
+				Controller: mock.Controller{Err: test.consumerErr},
+				colls:      test.colls,
+			}
+
+			item, err := openExportItem(
+				ctx,
+				rp,
+				ec,
+				bup,
+				ent,
+				control.DefaultExportConfig(),
+				fault.New(true))
+			test.expectErr(t, err, clues.ToCore(err))
+
+			require.Len(t, rp.gotPaths, 1, "restore paths")
+			assert.Equal(t, itemPath.String(), rp.gotPaths[0].String())
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expectName, item.Name)
+
+			bs, err := io.ReadAll(item.Body)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, content, string(bs))
+		})
+	}
+}
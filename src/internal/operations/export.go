@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/alcionai/clues"
@@ -20,7 +21,9 @@ import (
 	"github.com/alcionai/corso/src/internal/operations/inject"
 	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/internal/streamstore"
+	"github.com/alcionai/corso/src/internal/version"
 	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/dttm"
@@ -29,6 +32,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/metrics"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/store"
 )
@@ -49,6 +53,10 @@ type ExportOperation struct {
 	ExportCfg control.ExportConfig
 	Version   string
 	stats     metrics.ExportStats
+	manifest  *export.ManifestRecorder
+	// itemSizes holds the sizes recorded in the backup details, keyed
+	// the same way as the manifest's repoRefs.
+	itemSizes map[string]int64
 
 	acct account.Account
 	ec   inject.ExportConsumer
@@ -204,11 +212,105 @@ func (op *ExportOperation) Run(ctx context.Context) (
 	return expCollections, nil
 }
 
+// Plan records the changes that writing the export to dest would make,
+// without writing anything.  Items are sized from the backup details, so
+// their data never gets read.
+func (op *ExportOperation) Plan(
+	ctx context.Context,
+	dest export.Destination,
+) (p *plan.Plan, err error) {
+	defer func() {
+		if crErr := crash.Recovery(ctx, recover(), "export plan"); crErr != nil {
+			err = crErr
+		}
+	}()
+
+	var (
+		opStats = exportStats{bytesRead: &stats.ByteCounter{}}
+		sstore  = streamstore.NewStreamer(op.kopia, op.acct.ID(), op.Selectors.PathService())
+	)
+
+	// releases the producers of any items left unread if planning
+	// fails partway.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ctx = clues.AddTrace(ctx)
+	ctx = clues.Add(
+		ctx,
+		"tenant_id", clues.Hide(op.acct.ID()),
+		"backup_id", op.BackupID,
+		"service", op.Selectors.Service)
+
+	// archives are planned from the files they'd hold, since the
+	// archive itself can't be sized without writing it.
+	expCollections, err := op.exportCollections(ctx, &opStats, sstore, time.Now())
+	if err != nil {
+		return nil, clues.Wrap(err, "preparing export plan")
+	}
+
+	p = plan.New()
+
+	if op.ExportCfg.Archive {
+		err = export.PlanArchive(
+			ctx,
+			dest,
+			op.ExportCfg,
+			archive.FileName(op.ExportCfg),
+			expCollections,
+			op.itemSizes,
+			p,
+			op.Errors)
+	} else {
+		err = export.PlanExportCollections(
+			ctx,
+			dest,
+			op.ExportCfg,
+			expCollections,
+			op.itemSizes,
+			p,
+			op.Errors)
+	}
+
+	if err != nil {
+		return nil, clues.Wrap(err, "planning export")
+	}
+
+	finalizeErrorHandling(ctx, op.Options, op.Errors, "planning export")
+
+	return p, op.Errors.Failure()
+}
+
 func (op *ExportOperation) do(
 	ctx context.Context,
 	opStats *exportStats,
 	detailsStore streamstore.Reader,
 	start time.Time,
+) ([]export.Collectioner, error) {
+	expCollections, err := op.exportCollections(ctx, opStats, detailsStore, start)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	if op.ExportCfg.Archive {
+		ac, err := archive.ExportCollection(ctx, op.ExportCfg, expCollections)
+		if err != nil {
+			return nil, clues.Wrap(err, "archiving export collections")
+		}
+
+		return op.manifest.RecordArchives([]export.Collectioner{ac}), nil
+	}
+
+	return expCollections, nil
+}
+
+// exportCollections produces the export collections holding each of the
+// selected files, ahead of any archiving.
+func (op *ExportOperation) exportCollections(
+	ctx context.Context,
+	opStats *exportStats,
+	detailsStore streamstore.Reader,
+	start time.Time,
 ) ([]export.Collectioner, error) {
 	logger.Ctx(ctx).
 		With("control_options", op.Options, "selectors", op.Selectors).
@@ -263,6 +365,17 @@ func (op *ExportOperation) do(
 	opStats.resourceCount = 1
 	opStats.cs = dcs
 
+	op.itemSizes = itemSizesByID(deets)
+	op.manifest = export.NewManifestRecorder(
+		export.Manifest{
+			BackupID:        string(op.BackupID),
+			BackupCreatedAt: bup.CreationTime,
+			BackupVersion:   bup.Version,
+			CorsoVersion:    version.CurrentVersion(),
+			ExportedAt:      start,
+		},
+		repoRefsByItemID(deets))
+
 	expCollections, err := produceExportCollections(
 		ctx,
 		op.ec,
@@ -277,16 +390,75 @@ func (op *ExportOperation) do(
 		return nil, clues.Stack(err)
 	}
 
-	if op.ExportCfg.Archive {
-		zc, err := archive.ZipExportCollection(ctx, expCollections)
-		if err != nil {
-			return nil, clues.Wrap(err, "zipping export collections")
+	// snapshots hold the backup time for items whose mod time wasn't
+	// known, so the details are the better source.
+	expCollections = export.WithModTimes(expCollections, modTimesByID(deets))
+
+	return op.manifest.RecordFiles(expCollections), nil
+}
+
+// repoRefsByItemID maps the ids of the items in the details to their
+// RepoRef.  Items are keyed both by their storage name (the last element
+// of the RepoRef) and their ItemRef, since exports identify items by
+// either, depending on the service.
+func repoRefsByItemID(deets *details.Details) map[string]string {
+	refs := map[string]string{}
+
+	for _, ent := range deets.Items() {
+		if i := strings.LastIndex(ent.RepoRef, "/"); i >= 0 {
+			refs[ent.RepoRef[i+1:]] = ent.RepoRef
 		}
 
-		return []export.Collectioner{zc}, nil
+		if len(ent.ItemRef) > 0 {
+			refs[ent.ItemRef] = ent.RepoRef
+		}
 	}
 
-	return expCollections, nil
+	return refs
+}
+
+// itemSizesByID maps the ids of the items in the details to the size
+// recorded at backup time, keyed the same way as repoRefsByItemID.
+func itemSizesByID(deets *details.Details) map[string]int64 {
+	sizes := map[string]int64{}
+
+	for _, ent := range deets.Items() {
+		size := ent.ItemInfo.Size()
+
+		if i := strings.LastIndex(ent.RepoRef, "/"); i >= 0 {
+			sizes[ent.RepoRef[i+1:]] = size
+		}
+
+		if len(ent.ItemRef) > 0 {
+			sizes[ent.ItemRef] = size
+		}
+	}
+
+	return sizes
+}
+
+// modTimesByID maps the ids of the items in the details to their last
+// modified time, keyed the same way as repoRefsByItemID.  Items without a
+// modified time are left out.
+func modTimesByID(deets *details.Details) map[string]time.Time {
+	modTimes := map[string]time.Time{}
+
+	for _, ent := range deets.Items() {
+		mt := ent.ItemInfo.Modified()
+		if mt.IsZero() {
+			continue
+		}
+
+		if i := strings.LastIndex(ent.RepoRef, "/"); i >= 0 {
+			modTimes[ent.RepoRef[i+1:]] = mt
+		}
+
+		if len(ent.ItemRef) > 0 {
+			modTimes[ent.ItemRef] = mt
+		}
+	}
+
+	return modTimes
 }
 
 // persists details and statistics about the export operation.
@@ -317,6 +489,18 @@ func (op *ExportOperation) finalizeMetrics(
 	return op.Errors.Failure()
 }
 
+// Manifest returns the chain-of-custody manifest of the export.  As with
+// GetStats, this should only be called once the export collections have
+// been read and processed, since items are only recorded once they've
+// been read.
+func (op *ExportOperation) Manifest() export.Manifest {
+	if op.manifest == nil {
+		return export.Manifest{}
+	}
+
+	return op.manifest.Manifest()
+}
+
 // GetStats returns the stats of the export operation. You should only
 // be calling this once the export collections have been read and process
 // as the data that will be available here will be the data that was read
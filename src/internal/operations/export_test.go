@@ -23,6 +23,7 @@ import (
 	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/internal/tester"
 	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/export"
 	"github.com/alcionai/corso/src/pkg/selectors"
@@ -250,7 +251,7 @@ func (suite *ExportUnitSuite) TestZipExports() {
 			ctx, flush := tester.NewContext(t)
 			defer flush()
 
-			zc, err := archive.ZipExportCollection(ctx, test.inputColls)
+			zc, err := archive.ZipExportCollection(ctx, test.inputColls, "")
 			test.expectZipErr(t, err, clues.ToCore(err))
 
 			if err != nil {
@@ -326,3 +327,144 @@ func (suite *ExportUnitSuite) TestZipExports() {
 		})
 	}
 }
+
+func (suite *ExportUnitSuite) TestRepoRefsByItemID() {
+	t := suite.T()
+
+	deets := &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{
+				{
+					RepoRef: "tid/onedrive/uid/files/drive/root:/file-id.data",
+					ItemRef: "file-id",
+					ItemInfo: details.ItemInfo{
+						OneDrive: &details.OneDriveInfo{ItemType: details.OneDriveItem},
+					},
+				},
+				{
+					RepoRef: "tid/exchange/uid/email/inbox/mail-id",
+					ItemRef: "mail-id",
+					ItemInfo: details.ItemInfo{
+						Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMail},
+					},
+				},
+				{
+					RepoRef: "tid/exchange/uid/email/inbox",
+					ItemInfo: details.ItemInfo{
+						Folder: &details.FolderInfo{DisplayName: "inbox"},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(
+		t,
+		map[string]string{
+			"file-id.data": "tid/onedrive/uid/files/drive/root:/file-id.data",
+			"file-id":      "tid/onedrive/uid/files/drive/root:/file-id.data",
+			"mail-id":      "tid/exchange/uid/email/inbox/mail-id",
+		},
+		repoRefsByItemID(deets))
+}
+
+func (suite *ExportUnitSuite) TestItemSizesByID() {
+	t := suite.T()
+
+	deets := &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{
+				{
+					RepoRef: "tid/onedrive/uid/files/drive/root:/file-id.data",
+					ItemRef: "file-id",
+					ItemInfo: details.ItemInfo{
+						OneDrive: &details.OneDriveInfo{
+							ItemType: details.OneDriveItem,
+							Size:     42,
+						},
+					},
+				},
+				{
+					RepoRef: "tid/exchange/uid/email/inbox/mail-id",
+					ItemRef: "mail-id",
+					ItemInfo: details.ItemInfo{
+						Exchange: &details.ExchangeInfo{
+							ItemType: details.ExchangeMail,
+							Size:     7,
+						},
+					},
+				},
+				{
+					RepoRef: "tid/exchange/uid/email/inbox",
+					ItemInfo: details.ItemInfo{
+						Folder: &details.FolderInfo{DisplayName: "inbox", Size: 100},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(
+		t,
+		map[string]int64{
+			"file-id.data": 42,
+			"file-id":      42,
+			"mail-id":      7,
+		},
+		itemSizesByID(deets))
+}
+
+func (suite *ExportUnitSuite) TestModTimesByID() {
+	t := suite.T()
+
+	modified := time.Date(2020, 2, 3, 4, 5, 6, 0, time.UTC)
+
+	deets := &details.Details{
+		DetailsModel: details.DetailsModel{
+			Entries: []details.Entry{
+				{
+					RepoRef: "tid/onedrive/uid/files/drive/root:/file-id.data",
+					ItemRef: "file-id",
+					ItemInfo: details.ItemInfo{
+						OneDrive: &details.OneDriveInfo{
+							ItemType: details.OneDriveItem,
+							Modified: modified,
+						},
+					},
+				},
+				{
+					RepoRef: "tid/exchange/uid/email/inbox/mail-id",
+					ItemRef: "mail-id",
+					ItemInfo: details.ItemInfo{
+						Exchange: &details.ExchangeInfo{
+							ItemType: details.ExchangeMail,
+							Modified: modified,
+						},
+					},
+				},
+				{
+					RepoRef: "tid/exchange/uid/email/inbox/no-mod-time",
+					ItemRef: "no-mod-time",
+					ItemInfo: details.ItemInfo{
+						Exchange: &details.ExchangeInfo{ItemType: details.ExchangeMail},
+					},
+				},
+				{
+					RepoRef: "tid/exchange/uid/email/inbox",
+					ItemInfo: details.ItemInfo{
+						Folder: &details.FolderInfo{DisplayName: "inbox", Modified: modified},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(
+		t,
+		map[string]time.Time{
+			"file-id.data": modified,
+			"file-id":      modified,
+			"mail-id":      modified,
+		},
+		modTimesByID(deets))
+}
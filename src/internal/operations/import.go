@@ -0,0 +1,269 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/google/uuid"
+
+	"github.com/alcionai/corso/src/internal/common/crash"
+	"github.com/alcionai/corso/src/internal/diagnostics"
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/internal/m365/collection/exchange"
+	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/stats"
+	"github.com/alcionai/corso/src/internal/version"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+// ImportOperation wraps an operation with import-specific props.
+// Imports upload local files into a protected resource without
+// involving the repository.
+type ImportOperation struct {
+	operation
+
+	ProtectedResource string
+	Results           ImportResults
+	RestoreCfg        control.RestoreConfig
+	Source            string
+
+	acct account.Account
+	rc   inject.RestoreConsumer
+}
+
+// ImportResults aggregate the details of the results of the operation.
+type ImportResults struct {
+	stats.ReadWrites
+	stats.StartAndEndTime
+}
+
+// NewImportOperation constructs and validates an import operation.
+func NewImportOperation(
+	ctx context.Context,
+	opts control.Options,
+	rc inject.RestoreConsumer,
+	acct account.Account,
+	protectedResource, source string,
+	restoreCfg control.RestoreConfig,
+	bus events.Eventer,
+	ctr *count.Bus,
+) (ImportOperation, error) {
+	op := ImportOperation{
+		operation:         newOperation(opts, bus, ctr, nil, nil),
+		ProtectedResource: protectedResource,
+		RestoreCfg:        control.EnsureRestoreConfigDefaults(ctx, restoreCfg),
+		Source:            source,
+		acct:              acct,
+		rc:                rc,
+	}
+	if err := op.validate(); err != nil {
+		return ImportOperation{}, err
+	}
+
+	return op, nil
+}
+
+// validate does not check the operation's repository connections, since
+// imports neither read nor write to the repository.
+func (op ImportOperation) validate() error {
+	if op.rc == nil {
+		return clues.New("missing restore consumer")
+	}
+
+	if len(op.ProtectedResource) == 0 {
+		return clues.New("missing protected resource")
+	}
+
+	if len(op.Source) == 0 {
+		return clues.New("missing import source")
+	}
+
+	return nil
+}
+
+// Run begins a synchronous import operation.
+func (op *ImportOperation) Run(ctx context.Context) (importDetails *details.Details, err error) {
+	defer func() {
+		if crErr := crash.Recovery(ctx, recover(), "import"); crErr != nil {
+			err = crErr
+		}
+	}()
+
+	var (
+		opStats = restoreStats{
+			bytesRead: &stats.ByteCounter{},
+			restoreID: uuid.NewString(),
+		}
+		start = time.Now()
+	)
+
+	ctx = clues.AddLabelCounter(ctx, op.Counter.PlainAdder())
+
+	ctx, end := diagnostics.Span(ctx, "operations:import:run")
+	defer end()
+
+	ctx, flushMetrics := events.NewMetrics(ctx, logger.Writer{Ctx: ctx})
+	defer flushMetrics()
+
+	ctx = clues.AddTrace(ctx)
+
+	ctx = clues.Add(
+		ctx,
+		"tenant_id", clues.Hide(op.acct.ID()),
+		"import_source", clues.Hide(op.Source),
+		"destination_container", clues.Hide(op.RestoreCfg.Location))
+
+	defer func() {
+		op.bus.Event(
+			ctx,
+			events.ImportEnd,
+			map[string]any{
+				events.Duration:     op.Results.CompletedAt.Sub(op.Results.StartedAt),
+				events.EndTime:      dttm.Format(op.Results.CompletedAt),
+				events.ItemsRead:    op.Results.ItemsRead,
+				events.ItemsWritten: op.Results.ItemsWritten,
+				events.Resources:    op.Results.ResourceOwners,
+				events.RestoreID:    opStats.restoreID,
+				events.Service:      selectors.ServiceExchange.String(),
+				events.StartTime:    dttm.Format(op.Results.StartedAt),
+				events.Status:       op.Status.String(),
+			})
+	}()
+
+	deets, err := op.do(ctx, &opStats)
+	if err != nil {
+		// No return here!  We continue down to persistResults, even in case of failure.
+		logger.CtxErr(ctx, err).Error("running import")
+		op.Errors.Fail(clues.Wrap(err, "running import"))
+	}
+
+	finalizeErrorHandling(ctx, op.Options, op.Errors, "running import")
+	LogFaultErrors(ctx, op.Errors.Errors(), "running import")
+	logger.Ctx(ctx).With("total_counts", op.Counter.Values()).Info("import stats")
+
+	err = op.persistResults(start, &opStats)
+	if err != nil {
+		op.Errors.Fail(clues.Wrap(err, "persisting import results"))
+		return nil, op.Errors.Failure()
+	}
+
+	logger.Ctx(ctx).Infow("completed import", "results", op.Results)
+
+	return deets, nil
+}
+
+func (op *ImportOperation) do(
+	ctx context.Context,
+	opStats *restoreStats,
+) (*details.Details, error) {
+	resource, err := op.rc.PopulateProtectedResourceIDAndName(ctx, op.ProtectedResource, nil)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting destination protected resource")
+	}
+
+	ctx = clues.Add(
+		ctx,
+		"restore_protected_resource_id", resource.ID(),
+		"restore_protected_resource_name", clues.Hide(resource.Name()))
+
+	enabled, err := op.rc.IsServiceEnabled(ctx, resource.ID())
+	if err != nil {
+		return nil, clues.Wrap(err, "verifying service import is enabled")
+	}
+
+	if !enabled {
+		return nil, clues.StackWC(ctx, core.ErrServiceNotEnabled)
+	}
+
+	pcfg := observe.ProgressCfg{
+		NewSection:        true,
+		SectionIdentifier: clues.Hide(resource.Name()),
+	}
+	observe.Message(ctx, pcfg, "Importing")
+
+	dcs, err := exchange.ProduceImportCollections(ctx, op.Source, op.acct.ID(), resource.ID())
+	if err != nil {
+		return nil, clues.Wrap(err, "producing collections to import")
+	}
+
+	if len(dcs) == 0 {
+		return nil, clues.New("no importable files found in the source directory")
+	}
+
+	observe.Message(
+		ctx,
+		observe.ProgressCfg{},
+		fmt.Sprintf("Discovered %d folders to import", len(dcs)))
+
+	ctx = clues.Add(ctx, "coll_count", len(dcs))
+
+	opStats.resourceCount = 1
+	opStats.cs = dcs
+
+	sel := selectors.NewExchangeRestore([]string{resource.ID()})
+	sel.Include(sel.AllData())
+	sel.SetDiscreteOwnerIDName(resource.ID(), resource.Name())
+
+	deets, colStats, err := consumeRestoreCollections(
+		ctx,
+		op.rc,
+		version.Backup,
+		resource,
+		sel.Selector,
+		op.RestoreCfg,
+		op.Options,
+		dcs,
+		op.Errors,
+		op.Counter)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	opStats.ctrl = colStats
+
+	logger.Ctx(ctx).Debug(opStats.ctrl)
+
+	return deets, nil
+}
+
+// persists statistics about the import operation.
+func (op *ImportOperation) persistResults(
+	started time.Time,
+	opStats *restoreStats,
+) error {
+	op.Results.StartedAt = started
+	op.Results.CompletedAt = time.Now()
+
+	op.Status = Completed
+
+	if op.Errors.Failure() != nil {
+		op.Status = Failed
+	}
+
+	op.Results.ItemsRead = len(opStats.cs)
+	op.Results.ResourceOwners = opStats.resourceCount
+
+	if opStats.ctrl == nil {
+		op.Status = Failed
+		return clues.New("import never completed")
+	}
+
+	if op.Status != Failed && opStats.ctrl.IsZero() {
+		op.Status = NoData
+	}
+
+	op.Results.BytesRead = opStats.ctrl.Bytes
+	op.Results.ItemsWritten = opStats.ctrl.Successes
+
+	return op.Errors.Failure()
+}
@@ -0,0 +1,176 @@
+package operations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/data"
+	evmock "github.com/alcionai/corso/src/internal/events/mock"
+	"github.com/alcionai/corso/src/internal/m365/mock"
+	"github.com/alcionai/corso/src/internal/operations/inject"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/control/testdata"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/credentials"
+)
+
+type ImportOpUnitSuite struct {
+	tester.Suite
+}
+
+func TestImportOpUnitSuite(t *testing.T) {
+	suite.Run(t, &ImportOpUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ImportOpUnitSuite) TestNewImportOperation() {
+	table := []struct {
+		name      string
+		rc        inject.RestoreConsumer
+		resource  string
+		source    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "valid",
+			rc:        &mock.RestoreConsumer{},
+			resource:  "user",
+			source:    "/tmp",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "missing restore consumer",
+			resource:  "user",
+			source:    "/tmp",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing resource",
+			rc:        &mock.RestoreConsumer{},
+			source:    "/tmp",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing source",
+			rc:        &mock.RestoreConsumer{},
+			resource:  "user",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			_, err := NewImportOperation(
+				ctx,
+				control.DefaultOptions(),
+				test.rc,
+				account.Account{},
+				test.resource,
+				test.source,
+				testdata.DefaultRestoreConfig(""),
+				evmock.NewBus(),
+				count.New())
+			test.expectErr(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *ImportOpUnitSuite) TestImportOperation_Run() {
+	source := suite.T().TempDir()
+
+	acct, err := account.NewAccount(
+		account.ProviderM365,
+		account.M365Config{
+			M365: credentials.M365{
+				AzureClientID:     "id",
+				AzureClientSecret: "secret",
+			},
+			AzureTenantID: "tid",
+		})
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	err = os.WriteFile(
+		filepath.Join(source, "mail.eml"),
+		[]byte("Subject: hi\r\n\r\nhello\r\n"),
+		0o600)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name         string
+		source       string
+		rc           *mock.RestoreConsumer
+		expectErr    assert.ErrorAssertionFunc
+		expectStatus OpStatus
+	}{
+		{
+			name:   "imported",
+			source: source,
+			rc: &mock.RestoreConsumer{
+				Deets:                 &details.Details{},
+				Stats:                 data.CollectionStats{Objects: 1, Successes: 1, Bytes: 42},
+				ProtectedResourceID:   "id",
+				ProtectedResourceName: "name",
+			},
+			expectErr:    assert.NoError,
+			expectStatus: Completed,
+		},
+		{
+			name:   "no importable files",
+			source: suite.T().TempDir(),
+			rc: &mock.RestoreConsumer{
+				ProtectedResourceID:   "id",
+				ProtectedResourceName: "name",
+			},
+			expectErr:    assert.Error,
+			expectStatus: Failed,
+		},
+		{
+			name:   "unknown resource",
+			source: source,
+			rc: &mock.RestoreConsumer{
+				ProtectedResourceErr: assert.AnError,
+			},
+			expectErr:    assert.Error,
+			expectStatus: Failed,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			op, err := NewImportOperation(
+				ctx,
+				control.DefaultOptions(),
+				test.rc,
+				acct,
+				"user",
+				test.source,
+				testdata.DefaultRestoreConfig(""),
+				evmock.NewBus(),
+				count.New())
+			require.NoError(t, err, clues.ToCore(err))
+
+			_, err = op.Run(ctx)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			assert.Equal(t, test.expectStatus.String(), op.Status.String(), "status")
+			assert.Equal(t, test.rc.Stats.Successes, op.Results.ItemsWritten, "items written")
+			assert.Equal(t, test.rc.Stats.Bytes, op.Results.BytesRead, "bytes read")
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/metrics"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
 )
 
 type (
@@ -72,6 +73,22 @@ type (
 		PopulateProtectedResourceIDAndNamer
 	}
 
+	// RestorePlanner is implemented by restore consumers that can describe
+	// the changes a restore would make without making them.
+	RestorePlanner interface {
+		// PlanRestoreCollections performs the lookups of a restore, such as
+		// resolving the destination and checking for collisions, and records
+		// the action that would be taken on each item.  Nothing is written to
+		// the protected resource.
+		PlanRestoreCollections(
+			ctx context.Context,
+			rcc RestoreConsumerConfig,
+			dcs []data.RestoreCollection,
+			p *plan.Plan,
+			errs *fault.Bus,
+		) error
+	}
+
 	IsServiceEnableder interface {
 		// IsServiceEnabled checks if the service is enabled for backup/restore
 		// for the provided resource owner.
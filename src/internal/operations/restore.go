@@ -23,6 +23,7 @@ import (
 	"github.com/alcionai/corso/src/internal/stats"
 	"github.com/alcionai/corso/src/internal/streamstore"
 	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/backup"
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/control"
 	"github.com/alcionai/corso/src/pkg/count"
@@ -31,6 +32,7 @@ import (
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/plan"
 	"github.com/alcionai/corso/src/pkg/selectors"
 	"github.com/alcionai/corso/src/pkg/store"
 )
@@ -213,6 +215,49 @@ func (op *RestoreOperation) do(
 	detailsStore streamstore.Reader,
 	start time.Time,
 ) (*details.Details, error) {
+	ctx, bup, restoreToProtectedResource, dcs, err := op.produceRestoreCollections(
+		ctx,
+		opStats,
+		detailsStore)
+	if err != nil {
+		return nil, err
+	}
+
+	// should always be 1, since backups are 1:1 with resourceOwners.
+	opStats.resourceCount = 1
+	opStats.cs = dcs
+
+	deets, colStats, err := consumeRestoreCollections(
+		ctx,
+		op.rc,
+		bup.Version,
+		restoreToProtectedResource,
+		op.Selectors,
+		op.RestoreCfg,
+		op.Options,
+		dcs,
+		op.Errors,
+		op.Counter)
+	if err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	opStats.ctrl = colStats
+
+	logger.Ctx(ctx).Debug(opStats.ctrl)
+
+	return deets, nil
+}
+
+// produceRestoreCollections performs every step of a restore up to the
+// point of writing data: it looks up the backup, resolves the protected
+// resource to restore into, and produces the collections for the items
+// that match the selectors.
+func (op *RestoreOperation) produceRestoreCollections(
+	ctx context.Context,
+	opStats *restoreStats,
+	detailsStore streamstore.Reader,
+) (context.Context, *backup.Backup, idname.Provider, []data.RestoreCollection, error) {
 	logger.Ctx(ctx).
 		With("control_options", op.Options, "selectors", op.Selectors).
 		Info("restoring selection")
@@ -224,12 +269,12 @@ func (op *RestoreOperation) do(
 		detailsStore,
 		op.Errors)
 	if err != nil {
-		return nil, clues.Wrap(err, "getting backup and details")
+		return ctx, nil, nil, nil, clues.Wrap(err, "getting backup and details")
 	}
 
 	restoreToProtectedResource, err := chooseRestoreResource(ctx, op.rc, op.RestoreCfg, bup.Selector)
 	if err != nil {
-		return nil, clues.Wrap(err, "getting destination protected resource")
+		return ctx, nil, nil, nil, clues.Wrap(err, "getting destination protected resource")
 	}
 
 	ctx = clues.Add(
@@ -242,11 +287,11 @@ func (op *RestoreOperation) do(
 	// Check if the resource has the service enabled to be able to restore.
 	enabled, err := op.rc.IsServiceEnabled(ctx, restoreToProtectedResource.ID())
 	if err != nil {
-		return nil, clues.Wrap(err, "verifying service restore is enabled")
+		return ctx, nil, nil, nil, clues.Wrap(err, "verifying service restore is enabled")
 	}
 
 	if !enabled {
-		return nil, clues.StackWC(ctx, core.ErrServiceNotEnabled)
+		return ctx, nil, nil, nil, clues.StackWC(ctx, core.ErrServiceNotEnabled)
 	}
 
 	pcfg := observe.ProgressCfg{
@@ -263,7 +308,7 @@ func (op *RestoreOperation) do(
 		op.rc,
 		op.Errors)
 	if err != nil {
-		return nil, clues.Wrap(err, "formatting paths from details")
+		return ctx, nil, nil, nil, clues.Wrap(err, "formatting paths from details")
 	}
 
 	ctx = clues.Add(
@@ -274,7 +319,7 @@ func (op *RestoreOperation) do(
 		"backup_version", bup.Version)
 
 	if len(paths) == 0 {
-		return nil, clues.New("no items match the provided filters")
+		return ctx, nil, nil, nil, clues.New("no items match the provided filters")
 	}
 
 	observe.Message(
@@ -292,35 +337,65 @@ func (op *RestoreOperation) do(
 		opStats.bytesRead,
 		op.Errors)
 	if err != nil {
-		return nil, clues.Wrap(err, "producing collections to restore")
+		return ctx, nil, nil, nil, clues.Wrap(err, "producing collections to restore")
 	}
 
 	ctx = clues.Add(ctx, "coll_count", len(dcs))
 
-	// should always be 1, since backups are 1:1 with resourceOwners.
-	opStats.resourceCount = 1
-	opStats.cs = dcs
+	return ctx, bup, restoreToProtectedResource, dcs, nil
+}
 
-	deets, colStats, err := consumeRestoreCollections(
+// Plan performs the lookups of a restore against the protected resource
+// being restored into, and produces the changes that the restore would
+// make.  Nothing gets written to the protected resource.
+func (op *RestoreOperation) Plan(ctx context.Context) (p *plan.Plan, err error) {
+	defer func() {
+		if crErr := crash.Recovery(ctx, recover(), "restore plan"); crErr != nil {
+			err = crErr
+		}
+	}()
+
+	rp, ok := op.rc.(inject.RestorePlanner)
+	if !ok {
+		return nil, clues.New("dry runs are not supported when restoring this service").
+			With("service", op.Selectors.Service)
+	}
+
+	var (
+		opStats = restoreStats{bytesRead: &stats.ByteCounter{}}
+		sstore  = streamstore.NewStreamer(op.kopia, op.acct.ID(), op.Selectors.PathService())
+	)
+
+	ctx = clues.AddTrace(ctx)
+	ctx = clues.Add(
 		ctx,
-		op.rc,
-		bup.Version,
-		restoreToProtectedResource,
-		op.Selectors,
-		op.RestoreCfg,
-		op.Options,
-		dcs,
-		op.Errors,
-		op.Counter)
+		"tenant_id", clues.Hide(op.acct.ID()),
+		"backup_id", op.BackupID,
+		"service", op.Selectors.Service,
+		"destination_container", clues.Hide(op.RestoreCfg.Location))
+
+	ctx, bup, restoreToProtectedResource, dcs, err := op.produceRestoreCollections(ctx, &opStats, sstore)
 	if err != nil {
-		return nil, clues.Stack(err)
+		return nil, clues.Wrap(err, "preparing restore plan")
 	}
 
-	opStats.ctrl = colStats
+	rcc := inject.RestoreConsumerConfig{
+		BackupVersion:     bup.Version,
+		Options:           op.Options,
+		ProtectedResource: restoreToProtectedResource,
+		RestoreConfig:     op.RestoreCfg,
+		Selector:          op.Selectors,
+	}
 
-	logger.Ctx(ctx).Debug(opStats.ctrl)
+	p = plan.New()
 
-	return deets, nil
+	if err := rp.PlanRestoreCollections(ctx, rcc, dcs, p, op.Errors); err != nil {
+		return nil, clues.Wrap(err, "planning restore")
+	}
+
+	finalizeErrorHandling(ctx, op.Options, op.Errors, "planning restore")
+
+	return p, op.Errors.Failure()
 }
 
 // persists details and statistics about the restore operation.
@@ -362,13 +437,29 @@ func chooseRestoreResource(
 	restoreCfg control.RestoreConfig,
 	orig idname.Provider,
 ) (idname.Provider, error) {
-	if len(restoreCfg.ProtectedResource) == 0 {
+	target := restoreCfg.ProtectedResource
+
+	// without an explicit target, the original resource gets translated by
+	// the principal map, which is how resources get matched up when restoring
+	// into another tenant.  Falling back to the original resource would write
+	// into whichever resource happens to share its ID in the target.
+	if len(target) == 0 && len(restoreCfg.PrincipalMap) > 0 {
+		mapped, ok := restoreCfg.PrincipalMap.Lookup(orig.ID(), orig.Name())
+		if !ok {
+			return nil, clues.NewWC(ctx, "protected resource is not in the principal map").
+				With("protected_resource_id", orig.ID())
+		}
+
+		target = mapped
+	}
+
+	if len(target) == 0 {
 		return orig, nil
 	}
 
 	resource, err := pprian.PopulateProtectedResourceIDAndName(
 		ctx,
-		restoreCfg.ProtectedResource,
+		target,
 		nil)
 
 	return resource, clues.Stack(err).OrNil()
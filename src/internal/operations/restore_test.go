@@ -142,9 +142,11 @@ func (suite *RestoreOpUnitSuite) TestChooseRestoreResource() {
 		id        = "id"
 		name      = "name"
 		cfgWithPR = control.DefaultRestoreConfig(dttm.HumanReadable)
+		cfgWithPM = control.DefaultRestoreConfig(dttm.HumanReadable)
 	)
 
 	cfgWithPR.ProtectedResource = "cfgid"
+	cfgWithPM.PrincipalMap = control.NewPrincipalMap(map[string]string{"oname": "tname"})
 
 	table := []struct {
 		name           string
@@ -180,6 +182,28 @@ func (suite *RestoreOpUnitSuite) TestChooseRestoreResource() {
 			expectID:   id,
 			expectName: name,
 		},
+		{
+			name: "look up mapped resource",
+			cfg:  cfgWithPM,
+			ctrl: &mock.Controller{
+				ProtectedResourceID:   id,
+				ProtectedResourceName: name,
+			},
+			orig:       idname.NewProvider("oid", "oname"),
+			expectErr:  assert.NoError,
+			expectID:   id,
+			expectName: name,
+		},
+		{
+			name: "unmapped resource",
+			cfg:  cfgWithPM,
+			ctrl: &mock.Controller{
+				ProtectedResourceID:   id,
+				ProtectedResourceName: name,
+			},
+			orig:      idname.NewProvider("other-id", "other-name"),
+			expectErr: assert.Error,
+		},
 		{
 			name: "error looking up protected resource",
 			cfg:  cfgWithPR,
@@ -199,6 +223,11 @@ func (suite *RestoreOpUnitSuite) TestChooseRestoreResource() {
 
 			result, err := chooseRestoreResource(ctx, test.ctrl, test.cfg, test.orig)
 			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
 			require.NotNil(t, result)
 			assert.Equal(t, test.expectID, result.ID())
 			assert.Equal(t, test.expectName, result.Name())
@@ -0,0 +1,290 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/hashicorp/cronexpr"
+
+	"github.com/alcionai/corso/src/internal/events"
+	"github.com/alcionai/corso/src/pkg/dttm"
+	"github.com/alcionai/corso/src/pkg/logger"
+)
+
+// Job is a task that runs on a cron schedule.
+type Job struct {
+	// Name uniquely identifies the job.  Run statuses are persisted
+	// under the job name.
+	Name string
+	// Schedule is a cron expression, eg: "0 2 * * *".
+	Schedule string
+	// Service is included in emitted events.
+	Service string
+	// Resources produces the protected resources the job runs against.
+	// Each resource is run independently, and no resource gets run more
+	// than once at a time for the same service, even when multiple jobs
+	// select it.
+	Resources func(ctx context.Context) ([]string, error)
+	// Run performs the job for a single resource.  Returns the id of the
+	// produced operation (eg: the backup id), if any.
+	Run func(ctx context.Context, resource string) (string, error)
+	// Lock, if set, is held for each run.  Jobs that can't run at the
+	// same time share a Lock.  Runs only claim their resource once they
+	// hold the Lock, so waiting on it doesn't count as an overlap.
+	Lock sync.Locker
+
+	expr *cronexpr.Expression
+}
+
+// Scheduler triggers jobs according to their schedules.
+type Scheduler struct {
+	jobs  []*Job
+	state *State
+	bus   events.Eventer
+	now   func() time.Time
+
+	mu      sync.Mutex
+	running map[string]struct{}
+	wg      sync.WaitGroup
+}
+
+// New validates the jobs and produces a scheduler for them.
+func New(jobs []Job, state *State, bus events.Eventer) (*Scheduler, error) {
+	if state == nil {
+		return nil, clues.New("missing schedule state")
+	}
+
+	if bus == nil {
+		return nil, clues.New("missing event bus")
+	}
+
+	s := &Scheduler{
+		state:   state,
+		bus:     bus,
+		now:     time.Now,
+		running: map[string]struct{}{},
+	}
+
+	names := map[string]struct{}{}
+
+	for _, j := range jobs {
+		if len(j.Name) == 0 {
+			return nil, clues.New("schedule job is missing a name")
+		}
+
+		if _, ok := names[j.Name]; ok {
+			return nil, clues.New("duplicate schedule job name").With("job", j.Name)
+		}
+
+		names[j.Name] = struct{}{}
+
+		if j.Resources == nil || j.Run == nil {
+			return nil, clues.New("schedule job is missing its handlers").With("job", j.Name)
+		}
+
+		expr, err := cronexpr.Parse(j.Schedule)
+		if err != nil {
+			return nil, clues.Wrap(err, "parsing schedule").With("job", j.Name, "schedule", j.Schedule)
+		}
+
+		job := j
+		job.expr = expr
+
+		s.jobs = append(s.jobs, &job)
+	}
+
+	if len(s.jobs) == 0 {
+		return nil, clues.New("no jobs to schedule")
+	}
+
+	return s, nil
+}
+
+// Run triggers jobs as they come due until the context is cancelled.
+// On cancellation, Run waits for in-progress jobs to end before returning.
+func (s *Scheduler) Run(ctx context.Context) error {
+	defer s.wg.Wait()
+
+	next := make(map[*Job]time.Time, len(s.jobs))
+
+	for _, j := range s.jobs {
+		next[j] = j.expr.Next(s.now())
+
+		logger.Ctx(ctx).Infow(
+			"scheduled job",
+			"job", j.Name,
+			"next_run", dttm.Format(next[j]))
+	}
+
+	for {
+		var soonest time.Time
+
+		for _, t := range next {
+			if !t.IsZero() && (soonest.IsZero() || t.Before(soonest)) {
+				soonest = t
+			}
+		}
+
+		if soonest.IsZero() {
+			return clues.NewWC(ctx, "no jobs will run again")
+		}
+
+		timer := time.NewTimer(soonest.Sub(s.now()))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		now := s.now()
+
+		for _, j := range s.jobs {
+			if next[j].IsZero() || next[j].After(now) {
+				continue
+			}
+
+			s.trigger(ctx, j)
+
+			next[j] = j.expr.Next(now)
+		}
+	}
+}
+
+// trigger runs the job in the background.  Resources within a job are
+// run sequentially.
+func (s *Scheduler) trigger(ctx context.Context, j *Job) {
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		ctx := clues.Add(ctx, "schedule_job", j.Name)
+
+		resources, err := j.Resources(ctx)
+		if err != nil {
+			logger.CtxErr(ctx, err).Error("listing scheduled job resources")
+
+			now := s.now()
+			s.record(ctx, j, RunStatus{
+				Status:      StatusFailed,
+				StartedAt:   now,
+				CompletedAt: now,
+				Error:       err.Error(),
+			})
+
+			return
+		}
+
+		for _, resource := range resources {
+			if ctx.Err() != nil {
+				return
+			}
+
+			s.runResource(ctx, j, resource)
+		}
+	}()
+}
+
+func (s *Scheduler) runResource(ctx context.Context, j *Job, resource string) {
+	ctx = clues.Add(ctx, "schedule_resource", clues.Hide(resource))
+	start := s.now()
+
+	if j.Lock != nil {
+		j.Lock.Lock()
+		defer j.Lock.Unlock()
+	}
+
+	key := runKey(j, resource)
+
+	if !s.lock(key) {
+		logger.Ctx(ctx).Info("skipping scheduled run: resource is already running")
+
+		s.record(ctx, j, RunStatus{
+			Resource:    resource,
+			Status:      StatusSkipped,
+			StartedAt:   start,
+			CompletedAt: start,
+		})
+
+		return
+	}
+
+	defer s.unlock(key)
+
+	logger.Ctx(ctx).Info("starting scheduled run")
+
+	id, err := j.Run(ctx, resource)
+
+	rs := RunStatus{
+		Resource:    resource,
+		Status:      StatusCompleted,
+		StartedAt:   start,
+		CompletedAt: s.now(),
+		ID:          id,
+	}
+
+	if err != nil {
+		logger.CtxErr(ctx, err).Error("scheduled run failed")
+
+		rs.Status = StatusFailed
+		rs.Error = err.Error()
+	}
+
+	s.record(ctx, j, rs)
+}
+
+// runKey identifies the runs that overlap each other.  Runs of different
+// services against the same resource don't conflict.
+func runKey(j *Job, resource string) string {
+	return j.Service + "/" + resource
+}
+
+func (s *Scheduler) lock(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.running[key]; ok {
+		return false
+	}
+
+	s.running[key] = struct{}{}
+
+	return true
+}
+
+func (s *Scheduler) unlock(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.running, key)
+}
+
+// record persists the run status and emits the end-of-run event.  The
+// state guards itself, so neither happens under the scheduler's lock, and
+// slow writes or event delivery never hold up other runs.
+func (s *Scheduler) record(ctx context.Context, j *Job, rs RunStatus) {
+	rs.Job = j.Name
+
+	if err := s.state.Set(rs); err != nil {
+		logger.CtxErr(ctx, err).Error("persisting scheduled run status")
+	}
+
+	data := map[string]any{
+		events.ScheduleJob: j.Name,
+		events.Service:     j.Service,
+		events.Status:      rs.Status,
+		events.StartTime:   dttm.Format(rs.StartedAt),
+		events.EndTime:     dttm.Format(rs.CompletedAt),
+		events.Duration:    rs.CompletedAt.Sub(rs.StartedAt),
+	}
+
+	if len(rs.ID) > 0 {
+		data[events.BackupID] = rs.ID
+	}
+
+	s.bus.Event(ctx, events.ScheduledRunEnd, data)
+}
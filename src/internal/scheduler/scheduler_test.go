@@ -0,0 +1,457 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/events"
+	evmock "github.com/alcionai/corso/src/internal/events/mock"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type SchedulerUnitSuite struct {
+	tester.Suite
+}
+
+func TestSchedulerUnitSuite(t *testing.T) {
+	suite.Run(t, &SchedulerUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func resources(rs ...string) func(context.Context) ([]string, error) {
+	return func(context.Context) ([]string, error) {
+		return rs, nil
+	}
+}
+
+func runOK(context.Context, string) (string, error) {
+	return "id", nil
+}
+
+func (suite *SchedulerUnitSuite) TestNew() {
+	job := Job{
+		Name:      "job",
+		Schedule:  "0 2 * * *",
+		Resources: resources("r"),
+		Run:       runOK,
+	}
+
+	table := []struct {
+		name      string
+		jobs      func() []Job
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "valid",
+			jobs:      func() []Job { return []Job{job} },
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "no jobs",
+			jobs:      func() []Job { return nil },
+			expectErr: assert.Error,
+		},
+		{
+			name: "missing name",
+			jobs: func() []Job {
+				j := job
+				j.Name = ""
+
+				return []Job{j}
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "duplicate names",
+			jobs:      func() []Job { return []Job{job, job} },
+			expectErr: assert.Error,
+		},
+		{
+			name: "missing handler",
+			jobs: func() []Job {
+				j := job
+				j.Run = nil
+
+				return []Job{j}
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "bad schedule",
+			jobs: func() []Job {
+				j := job
+				j.Schedule = "not a cron"
+
+				return []Job{j}
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			_, err := New(test.jobs(), NewState(), evmock.NewBus())
+			test.expectErr(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *SchedulerUnitSuite) TestRunResource_skipsOverlap() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		state   = NewState()
+		bus     = evmock.NewBus()
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	blocking := Job{
+		Name:      "blocking",
+		Schedule:  "* * * * *",
+		Resources: resources("r"),
+		Run: func(context.Context, string) (string, error) {
+			close(started)
+			<-release
+
+			return "bid", nil
+		},
+	}
+
+	other := Job{
+		Name:      "other",
+		Schedule:  "* * * * *",
+		Resources: resources("r"),
+		Run: func(context.Context, string) (string, error) {
+			assert.Fail(t, "overlapping run on the same resource")
+			return "", nil
+		},
+	}
+
+	s, err := New([]Job{blocking, other}, state, bus)
+	require.NoError(t, err, clues.ToCore(err))
+
+	s.trigger(ctx, s.jobs[0])
+	<-started
+
+	s.runResource(ctx, s.jobs[1], "r")
+
+	close(release)
+	s.wg.Wait()
+
+	rs, ok := state.Get("blocking", "r")
+	require.True(t, ok)
+	assert.Equal(t, StatusCompleted, rs.Status)
+	assert.Equal(t, "bid", rs.ID)
+
+	rs, ok = state.Get("other", "r")
+	require.True(t, ok)
+	assert.Equal(t, StatusSkipped, rs.Status)
+
+	assert.Equal(t, 2, bus.TimesCalled[events.ScheduledRunEnd])
+}
+
+func (suite *SchedulerUnitSuite) TestRunResource_servicesDontOverlap() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		state   = NewState()
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	exchange := Job{
+		Name:      "exchange",
+		Schedule:  "* * * * *",
+		Service:   "exchange",
+		Resources: resources("r"),
+		Run: func(context.Context, string) (string, error) {
+			close(started)
+			<-release
+
+			return "bid", nil
+		},
+	}
+
+	onedrive := Job{
+		Name:      "onedrive",
+		Schedule:  "* * * * *",
+		Service:   "onedrive",
+		Resources: resources("r"),
+		Run:       runOK,
+	}
+
+	s, err := New([]Job{exchange, onedrive}, state, evmock.NewBus())
+	require.NoError(t, err, clues.ToCore(err))
+
+	s.trigger(ctx, s.jobs[0])
+	<-started
+
+	s.runResource(ctx, s.jobs[1], "r")
+
+	close(release)
+	s.wg.Wait()
+
+	for _, name := range []string{"exchange", "onedrive"} {
+		rs, ok := state.Get(name, "r")
+		require.True(t, ok, name)
+		assert.Equal(t, StatusCompleted, rs.Status, name)
+	}
+}
+
+func (suite *SchedulerUnitSuite) TestRunResource_waitingOnLockIsntAnOverlap() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		state   = NewState()
+		lock    = &sync.Mutex{}
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	first := Job{
+		Name:      "first",
+		Schedule:  "* * * * *",
+		Service:   "exchange",
+		Resources: resources("r"),
+		Lock:      lock,
+		Run: func(context.Context, string) (string, error) {
+			close(started)
+			<-release
+
+			return "bid", nil
+		},
+	}
+
+	second := Job{
+		Name:      "second",
+		Schedule:  "* * * * *",
+		Service:   "exchange",
+		Resources: resources("r"),
+		Lock:      lock,
+		Run:       runOK,
+	}
+
+	s, err := New([]Job{first, second}, state, evmock.NewBus())
+	require.NoError(t, err, clues.ToCore(err))
+
+	s.trigger(ctx, s.jobs[0])
+	<-started
+
+	// waits on the lock until the first run ends.
+	s.trigger(ctx, s.jobs[1])
+
+	close(release)
+	s.wg.Wait()
+
+	for _, name := range []string{"first", "second"} {
+		rs, ok := state.Get(name, "r")
+		require.True(t, ok, name)
+		assert.Equal(t, StatusCompleted, rs.Status, name)
+	}
+}
+
+// blockingBus holds up every event until it's released.
+type blockingBus struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (bb blockingBus) Event(context.Context, string, map[string]any) {
+	bb.entered <- struct{}{}
+	<-bb.release
+}
+
+func (bb blockingBus) Close() error { return nil }
+
+func (suite *SchedulerUnitSuite) TestRecord_doesntBlockScheduling() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	bus := blockingBus{
+		entered: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+
+	j := Job{
+		Name:      "j",
+		Schedule:  "* * * * *",
+		Resources: resources("r"),
+		Run:       runOK,
+	}
+
+	s, err := New([]Job{j}, NewState(), bus)
+	require.NoError(t, err, clues.ToCore(err))
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		s.record(ctx, s.jobs[0], RunStatus{Resource: "r", Status: StatusCompleted})
+	}()
+
+	<-bus.entered
+
+	// the event is still being delivered, but other runs can start.
+	assert.True(t, s.lock("other"))
+	s.unlock("other")
+
+	close(bus.release)
+	<-done
+
+	_, ok := s.state.Get("j", "r")
+	assert.True(t, ok)
+}
+
+func (suite *SchedulerUnitSuite) TestTrigger_failures() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		state = NewState()
+		bus   = evmock.NewBus()
+	)
+
+	jobs := []Job{
+		{
+			Name:     "resources",
+			Schedule: "* * * * *",
+			Resources: func(context.Context) ([]string, error) {
+				return nil, clues.New("listing")
+			},
+			Run: runOK,
+		},
+		{
+			Name:      "run",
+			Schedule:  "* * * * *",
+			Resources: resources("r"),
+			Run: func(context.Context, string) (string, error) {
+				return "", clues.New("running")
+			},
+		},
+	}
+
+	s, err := New(jobs, state, bus)
+	require.NoError(t, err, clues.ToCore(err))
+
+	for _, j := range s.jobs {
+		s.trigger(ctx, j)
+	}
+
+	s.wg.Wait()
+
+	rs, ok := state.Get("resources", "")
+	require.True(t, ok)
+	assert.Equal(t, StatusFailed, rs.Status)
+	assert.Contains(t, rs.Error, "listing")
+
+	rs, ok = state.Get("run", "r")
+	require.True(t, ok)
+	assert.Equal(t, StatusFailed, rs.Status)
+	assert.Contains(t, rs.Error, "running")
+
+	for _, data := range bus.CalledWith[events.ScheduledRunEnd] {
+		assert.Equal(t, StatusFailed, data[events.Status])
+		assert.NotContains(t, data, events.BackupID)
+	}
+}
+
+func (suite *SchedulerUnitSuite) TestRun_stopsOnCancel() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	job := Job{
+		Name:      "job",
+		Schedule:  "0 2 * * *",
+		Resources: resources("r"),
+		Run:       runOK,
+	}
+
+	s, err := New([]Job{job}, NewState(), evmock.NewBus())
+	require.NoError(t, err, clues.ToCore(err))
+
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+
+	err = s.Run(ctx)
+	assert.NoError(t, err, clues.ToCore(err))
+}
+
+func (suite *SchedulerUnitSuite) TestState_persists() {
+	t := suite.T()
+
+	fp := filepath.Join(t.TempDir(), "state.json")
+
+	state, err := LoadState(fp)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Empty(t, state.All())
+
+	now := time.Now().UTC().Truncate(time.Second)
+	runs := []RunStatus{
+		{Job: "a", Resource: "r1", Status: StatusCompleted, StartedAt: now, CompletedAt: now, ID: "id"},
+		{Job: "a", Resource: "r1", Status: StatusFailed, StartedAt: now, CompletedAt: now, Error: "err"},
+		{Job: "b", Resource: "r2", Status: StatusSkipped, StartedAt: now, CompletedAt: now},
+	}
+
+	for _, rs := range runs {
+		err := state.Set(rs)
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	loaded, err := LoadState(fp)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, runs[1:], loaded.All())
+}
+
+func (suite *SchedulerUnitSuite) TestState_concurrentSets() {
+	t := suite.T()
+
+	fp := filepath.Join(t.TempDir(), "state.json")
+
+	state, err := LoadState(fp)
+	require.NoError(t, err, clues.ToCore(err))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			err := state.Set(RunStatus{Job: "j", Resource: fmt.Sprintf("r%02d", i), Status: StatusCompleted})
+			assert.NoError(t, err, clues.ToCore(err))
+		}(i)
+	}
+
+	wg.Wait()
+
+	loaded, err := LoadState(fp)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Len(t, loaded.All(), 20, "the file holds the latest state")
+	assert.Equal(t, state.All(), loaded.All())
+}
@@ -0,0 +1,178 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+)
+
+const (
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusSkipped   = "skipped"
+)
+
+// RunStatus describes the most recent run of a job against a resource.
+type RunStatus struct {
+	Job         string    `json:"job"`
+	Resource    string    `json:"resource,omitempty"`
+	Status      string    `json:"status"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+	// ID is the id of the operation produced by the run, if any.
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+func (rs RunStatus) key() string {
+	return rs.Job + "/" + rs.Resource
+}
+
+// State holds the last run status of each job and resource.  If the
+// state has a file path, the state is written to that file on every
+// change, so that it survives restarts of the scheduler.
+type State struct {
+	path string
+
+	mu   sync.Mutex
+	runs map[string]RunStatus
+	// version counts the changes to runs.
+	version uint64
+
+	// writeMu serializes writes to the state file.  It's separate from
+	// mu so that reads and changes don't wait on the disk.
+	writeMu sync.Mutex
+	// written is the version of the runs in the state file.
+	written uint64
+}
+
+// NewState produces an empty state that only lives in memory.
+func NewState() *State {
+	return &State{runs: map[string]RunStatus{}}
+}
+
+// LoadState reads the state from the file at path.  A missing file
+// produces an empty state.  Changes to the state are persisted to the
+// same file.
+func LoadState(path string) (*State, error) {
+	s := NewState()
+	s.path = path
+
+	bs, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+
+	if err != nil {
+		return nil, clues.Wrap(err, "reading schedule state").With("state_file", path)
+	}
+
+	var runs []RunStatus
+
+	if err := json.Unmarshal(bs, &runs); err != nil {
+		return nil, clues.Wrap(err, "parsing schedule state").With("state_file", path)
+	}
+
+	for _, rs := range runs {
+		s.runs[rs.key()] = rs
+	}
+
+	return s, nil
+}
+
+// Set records the run status, replacing any prior status for the same
+// job and resource.
+func (s *State) Set(rs RunStatus) error {
+	s.mu.Lock()
+	s.runs[rs.key()] = rs
+	s.version++
+	version, runs := s.version, s.sorted()
+	s.mu.Unlock()
+
+	return s.persist(version, runs)
+}
+
+// Get returns the last run status for the job and resource.
+func (s *State) Get(job, resource string) (RunStatus, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rs, ok := s.runs[RunStatus{Job: job, Resource: resource}.key()]
+
+	return rs, ok
+}
+
+// All returns every run status, sorted by job and resource.
+func (s *State) All() []RunStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sorted()
+}
+
+func (s *State) sorted() []RunStatus {
+	runs := make([]RunStatus, 0, len(s.runs))
+
+	for _, rs := range s.runs {
+		runs = append(runs, rs)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].key() < runs[j].key()
+	})
+
+	return runs
+}
+
+// persist writes the runs to a temp file before moving it over the
+// state file, so that a crash never leaves a partially written state.
+// Runs older than those already in the file are dropped, since
+// concurrent changes can reach here out of order.
+func (s *State) persist(version uint64, runs []RunStatus) error {
+	if len(s.path) == 0 {
+		return nil
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if version <= s.written {
+		return nil
+	}
+
+	bs, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return clues.Wrap(err, "serializing schedule state")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return clues.Wrap(err, "creating schedule state file").With("state_file", s.path)
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(bs); err != nil {
+		tmp.Close()
+		return clues.Wrap(err, "writing schedule state").With("state_file", s.path)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return clues.Wrap(err, "writing schedule state").With("state_file", s.path)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return clues.Wrap(err, "replacing schedule state file").With("state_file", s.path)
+	}
+
+	s.written = version
+
+	return nil
+}
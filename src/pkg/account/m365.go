@@ -14,6 +14,8 @@ import (
 // config exported name consts
 const (
 	AzureTenantID = "AZURE_TENANT_ID"
+	// TargetAzureTenantID is the tenant that receives a cross-tenant restore.
+	TargetAzureTenantID = "TARGET_AZURE_TENANT_ID"
 )
 
 var excludedM365ConfigFieldsForHashing = []string{"AzureClientSecret"}
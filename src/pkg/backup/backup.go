@@ -68,6 +68,9 @@ type Backup struct {
 	// prefer protectedResource
 	ResourceOwnerID   string `json:"resourceOwnerID,omitempty"`
 	ResourceOwnerName string `json:"resourceOwnerName,omitempty"`
+
+	// LegalHolds block the deletion of the backup while any are present.
+	LegalHolds []LegalHold `json:"legalHolds,omitempty"`
 }
 
 // interface compliance checks
@@ -93,7 +96,7 @@ func New(
 		skipCount = len(fe.Skipped)
 		failMsg   string
 
-		malware, invalidONFile, otherSkips int
+		malware, invalidONFile, excluded, otherSkips int
 	)
 
 	if fe.Failure != nil {
@@ -107,6 +110,8 @@ func New(
 			malware++
 		case s.HasCause(fault.SkipOneNote):
 			invalidONFile++
+		case s.HasCause(fault.SkipExcludedByRule):
+			excluded++
 		default:
 			otherSkips++
 		}
@@ -140,6 +145,7 @@ func New(
 			TotalSkippedItems:         skipCount,
 			SkippedMalware:            malware,
 			SkippedInvalidOneNoteFile: invalidONFile,
+			SkippedExcluded:           excluded,
 		},
 	}
 }
@@ -197,13 +203,15 @@ func PrintAll(ctx context.Context, bs []*Backup) {
 }
 
 type Printable struct {
-	ID                    model.StableID `json:"id"`
-	Status                string         `json:"status"`
-	Version               string         `json:"version"`
-	ProtectedResourceID   string         `json:"protectedResourceID,omitempty"`
-	ProtectedResourceName string         `json:"protectedResourceName,omitempty"`
-	Owner                 string         `json:"owner,omitempty"`
-	Stats                 backupStats    `json:"stats"`
+	ID                    model.StableID    `json:"id"`
+	Status                string            `json:"status"`
+	Version               string            `json:"version"`
+	ProtectedResourceID   string            `json:"protectedResourceID,omitempty"`
+	ProtectedResourceName string            `json:"protectedResourceName,omitempty"`
+	Owner                 string            `json:"owner,omitempty"`
+	Labels                map[string]string `json:"labels,omitempty"`
+	LegalHolds            []LegalHold       `json:"legalHolds,omitempty"`
+	Stats                 backupStats       `json:"stats"`
 }
 
 // ToPrintable reduces the Backup to its minimally printable details.
@@ -215,6 +223,8 @@ func (b Backup) ToPrintable() Printable {
 		ProtectedResourceID:   b.Selector.DiscreteOwner,
 		ProtectedResourceName: b.Selector.DiscreteOwnerName,
 		Owner:                 b.Selector.DiscreteOwner,
+		Labels:                b.Labels(),
+		LegalHolds:            b.LegalHolds,
 		Stats:                 b.toStats(),
 	}
 }
@@ -266,7 +276,7 @@ func (b Backup) Values(skipID bool) []string {
 	if b.TotalSkippedItems > 0 {
 		status += fmt.Sprintf("%d skipped", b.TotalSkippedItems)
 
-		if b.SkippedMalware+b.SkippedInvalidOneNoteFile > 0 {
+		if b.SkippedMalware+b.SkippedInvalidOneNoteFile+b.SkippedExcluded > 0 {
 			status += ": "
 		}
 	}
@@ -281,12 +291,25 @@ func (b Backup) Values(skipID bool) []string {
 		skipped = append(skipped, fmt.Sprintf("%d invalid OneNote file", b.SkippedInvalidOneNoteFile))
 	}
 
+	if b.SkippedExcluded > 0 {
+		skipped = append(skipped, fmt.Sprintf("%d excluded", b.SkippedExcluded))
+	}
+
 	status += strings.Join(skipped, ", ")
 
 	if errCount+b.TotalSkippedItems > 0 {
 		status += (")")
 	}
 
+	if b.UnderLegalHold() {
+		holds := make([]string, 0, len(b.LegalHolds))
+		for _, lh := range b.LegalHolds {
+			holds = append(holds, lh.Name)
+		}
+
+		status += " [legal hold: " + strings.Join(holds, ", ") + "]"
+	}
+
 	name := str.First(
 		b.ProtectedResourceName,
 		b.ResourceOwnerName,
@@ -297,6 +297,17 @@ func (suite *BackupUnitSuite) TestBackup_Values_statusVariations() {
 			},
 			expect: "test (42 errors, 1 skipped: 1 invalid OneNote file)",
 		},
+		{
+			name: "excluded",
+			bup: backup.Backup{
+				Status: "test",
+				SkippedCounts: stats.SkippedCounts{
+					TotalSkippedItems: 3,
+					SkippedExcluded:   3,
+				},
+			},
+			expect: "test (3 skipped: 3 excluded)",
+		},
 		{
 			name: "errors, malware, notFound, invalid OneNote",
 			bup: backup.Backup{
@@ -310,6 +321,18 @@ func (suite *BackupUnitSuite) TestBackup_Values_statusVariations() {
 			},
 			expect: "test (42 errors, 1 skipped: 1 malware, 1 invalid OneNote file)",
 		},
+		{
+			name: "errors and legal holds",
+			bup: backup.Backup{
+				Status:     "test",
+				ErrorCount: 42,
+				LegalHolds: []backup.LegalHold{
+					{Name: "case-1234"},
+					{Name: "case-5678"},
+				},
+			},
+			expect: "test (42 errors) [legal hold: case-1234, case-5678]",
+		},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -114,7 +114,7 @@ func (b *Builder) addFolderEntries(
 			}
 		}
 
-		folder.Folder.Size += entry.size()
+		folder.Folder.Size += entry.Size()
 
 		itemModified := entry.Modified()
 		if folder.Folder.Modified.Before(itemModified) {
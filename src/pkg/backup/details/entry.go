@@ -87,7 +87,7 @@ func (de Entry) ToLocationIDer(backupVersion int) (LocationIDer, error) {
 		}
 
 		fallthrough
-	case ExchangeMail, ExchangeContact:
+	case ExchangeMail, ExchangeContact, ExchangeMailboxSettings:
 		baseLoc = path.Builder{}.Append(rr.Folders()...)
 
 	case OneDriveItem, SharePointLibrary:
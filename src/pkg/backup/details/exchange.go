@@ -45,6 +45,16 @@ type ExchangeInfo struct {
 	Created     time.Time `json:"created,omitempty"`
 	Modified    time.Time `json:"modified,omitempty"`
 	Size        int64     `json:"size,omitempty"`
+	// HasAttachments and AttachmentNames are only populated for mail.
+	// Backups made before these fields were added leave them empty.
+	HasAttachments  bool     `json:"hasAttachments,omitempty"`
+	AttachmentNames []string `json:"attachmentNames,omitempty"`
+	// Setting names the kind of mailbox setting (ex: inboxRules) held by
+	// an ExchangeMailboxSettings item.
+	Setting string `json:"setting,omitempty"`
+	// SettingEntries counts the entries (ex: number of rules) within the
+	// mailbox setting.
+	SettingEntries int `json:"settingEntries,omitempty"`
 }
 
 // Headers returns the human-readable names of properties in an ExchangeInfo
@@ -59,6 +69,9 @@ func (i ExchangeInfo) Headers() []string {
 
 	case ExchangeMail:
 		return []string{"Sender", "Folder", "Subject", "Received"}
+
+	case ExchangeMailboxSettings:
+		return []string{"Setting", "Entries", "Modified"}
 	}
 
 	return []string{}
@@ -85,6 +98,13 @@ func (i ExchangeInfo) Values() []string {
 			i.Sender, i.ParentPath, i.Subject,
 			dttm.FormatToTabularDisplay(i.Received),
 		}
+
+	case ExchangeMailboxSettings:
+		return []string{
+			i.Setting,
+			strconv.Itoa(i.SettingEntries),
+			dttm.FormatToTabularDisplay(i.Modified),
+		}
 	}
 
 	return []string{}
@@ -104,6 +124,8 @@ func (i *ExchangeInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error)
 		category = path.ContactsCategory
 	case ExchangeMail:
 		category = path.EmailCategory
+	case ExchangeMailboxSettings:
+		category = path.MailboxSettingsCategory
 	}
 
 	loc, err := NewExchangeLocationIDer(category, baseLoc.Elements()...)
@@ -113,7 +135,7 @@ func (i *ExchangeInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error)
 
 func (i *ExchangeInfo) updateFolder(f *FolderInfo) error {
 	switch i.ItemType {
-	case ExchangeContact, ExchangeEvent, ExchangeMail:
+	case ExchangeContact, ExchangeEvent, ExchangeMail, ExchangeMailboxSettings:
 	default:
 		return clues.New("unsupported non-Exchange ItemType").
 			With("item_type", i.ItemType)
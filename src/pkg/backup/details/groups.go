@@ -1,6 +1,7 @@
 package details
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -48,6 +49,9 @@ type GroupsInfo struct {
 	// Conversations Specific
 	Post ConversationPostInfo `json:"post,omitempty"`
 
+	// Planner Specific
+	Planner PlannerInfo `json:"planner,omitempty"`
+
 	// SharePoint specific
 	Created    time.Time `json:"created,omitempty"`
 	DriveName  string    `json:"driveName,omitempty"`
@@ -69,6 +73,20 @@ type ConversationPostInfo struct {
 	Topic      string    `json:"topic,omitempty"`
 }
 
+// PlannerInfo describes a plan, or a task within a plan.
+type PlannerInfo struct {
+	PlanTitle       string    `json:"planTitle,omitempty"`
+	BucketName      string    `json:"bucketName,omitempty"`
+	TaskTitle       string    `json:"taskTitle,omitempty"`
+	Assignees       []string  `json:"assignees,omitempty"`
+	DueDate         time.Time `json:"dueDate,omitempty"`
+	PercentComplete int       `json:"percentComplete,omitempty"`
+
+	// plan only
+	BucketCount int `json:"bucketCount,omitempty"`
+	TaskCount   int `json:"taskCount,omitempty"`
+}
+
 type ChannelMessageInfo struct {
 	AttachmentNames []string  `json:"attachmentNames,omitempty"`
 	CreatedAt       time.Time `json:"createdAt,omitempty"`
@@ -89,6 +107,10 @@ func (i GroupsInfo) Headers() []string {
 		return []string{"Message", "Channel", "Subject", "Replies", "Creator", "Created", "Last Reply"}
 	case GroupsConversationPost:
 		return []string{"Post", "Conversation", "Sender", "Created"}
+	case GroupsPlannerPlan:
+		return []string{"Plan", "Buckets", "Tasks", "Created"}
+	case GroupsPlannerTask:
+		return []string{"Task", "Plan", "Bucket", "Assignees", "Due", "Complete"}
 	}
 
 	return []string{}
@@ -132,6 +154,27 @@ func (i GroupsInfo) Values() []string {
 			i.Post.Creator,
 			dttm.FormatToTabularDisplay(i.Post.CreatedAt),
 		}
+	case GroupsPlannerPlan:
+		return []string{
+			i.Planner.PlanTitle,
+			strconv.Itoa(i.Planner.BucketCount),
+			strconv.Itoa(i.Planner.TaskCount),
+			dttm.FormatToTabularDisplay(i.Created),
+		}
+	case GroupsPlannerTask:
+		due := dttm.FormatToTabularDisplay(i.Planner.DueDate)
+		if i.Planner.DueDate.IsZero() {
+			due = ""
+		}
+
+		return []string{
+			i.Planner.TaskTitle,
+			i.Planner.PlanTitle,
+			i.Planner.BucketName,
+			strings.Join(i.Planner.Assignees, ", "),
+			due,
+			fmt.Sprintf("%d%%", i.Planner.PercentComplete),
+		}
 	}
 
 	return []string{}
@@ -158,6 +201,8 @@ func (i *GroupsInfo) uniqueLocation(baseLoc *path.Builder) (*uniqueLoc, error) {
 		loc, err = NewGroupsLocationIDer(path.ChannelMessagesCategory, "", baseLoc.Elements()...)
 	case GroupsConversationPost:
 		loc, err = NewGroupsLocationIDer(path.ConversationPostsCategory, "", baseLoc.Elements()...)
+	case GroupsPlannerPlan, GroupsPlannerTask:
+		loc, err = NewGroupsLocationIDer(path.PlannerCategory, "", baseLoc.Elements()...)
 	}
 
 	return &loc, err
@@ -169,7 +214,7 @@ func (i *GroupsInfo) updateFolder(f *FolderInfo) error {
 	switch i.ItemType {
 	case SharePointLibrary:
 		return updateFolderWithinDrive(SharePointLibrary, i.DriveName, i.DriveID, f)
-	case GroupsChannelMessage, GroupsConversationPost:
+	case GroupsChannelMessage, GroupsConversationPost, GroupsPlannerPlan, GroupsPlannerTask:
 		return nil
 	}
 
@@ -76,6 +76,48 @@ func (suite *GroupsUnitSuite) TestGroupsPrintable() {
 				dttm.FormatToTabularDisplay(now),
 			},
 		},
+		{
+			name: "planner plan",
+			info: details.GroupsInfo{
+				ItemType: details.GroupsPlannerPlan,
+				Created:  now,
+				Planner: details.PlannerInfo{
+					PlanTitle:   "plan",
+					BucketCount: 2,
+					TaskCount:   5,
+				},
+			},
+			expectHs: []string{"Plan", "Buckets", "Tasks", "Created"},
+			expectVs: []string{"plan", "2", "5", dttm.FormatToTabularDisplay(now)},
+		},
+		{
+			name: "planner task",
+			info: details.GroupsInfo{
+				ItemType: details.GroupsPlannerTask,
+				Planner: details.PlannerInfo{
+					PlanTitle:       "plan",
+					BucketName:      "bucket",
+					TaskTitle:       "task",
+					Assignees:       []string{"a", "b"},
+					DueDate:         then,
+					PercentComplete: 50,
+				},
+			},
+			expectHs: []string{"Task", "Plan", "Bucket", "Assignees", "Due", "Complete"},
+			expectVs: []string{"task", "plan", "bucket", "a, b", dttm.FormatToTabularDisplay(then), "50%"},
+		},
+		{
+			name: "planner task without due date",
+			info: details.GroupsInfo{
+				ItemType: details.GroupsPlannerTask,
+				Planner: details.PlannerInfo{
+					PlanTitle: "plan",
+					TaskTitle: "task",
+				},
+			},
+			expectHs: []string{"Task", "Plan", "Bucket", "Assignees", "Due", "Complete"},
+			expectVs: []string{"task", "plan", "", "", "", "0%"},
+		},
 		{
 			name: "sharepoint library",
 			info: details.GroupsInfo{
@@ -26,6 +26,9 @@ const (
 	ExchangeContact ItemType = 1
 	ExchangeEvent   ItemType = 2
 	ExchangeMail    ItemType = 3
+	// ExchangeMailboxSettings covers per-mailbox configuration such as
+	// inbox rules, master categories, and automatic replies.
+	ExchangeMailboxSettings ItemType = 4
 
 	// SharePoint (10x)
 	SharePointLibrary ItemType = 101 // also used for groups
@@ -41,6 +44,8 @@ const (
 	// Groups/Teams(40x)
 	GroupsChannelMessage   ItemType = 401
 	GroupsConversationPost ItemType = 402
+	GroupsPlannerPlan      ItemType = 403
+	GroupsPlannerTask      ItemType = 404
 
 	// Teams Chat
 	TeamsChat ItemType = 501
@@ -111,7 +116,8 @@ func (i ItemInfo) infoType() ItemType {
 	return UnknownType
 }
 
-func (i ItemInfo) size() int64 {
+// Size returns the size in bytes of the item, as recorded at backup time.
+func (i ItemInfo) Size() int64 {
 	switch {
 	case i.Exchange != nil:
 		return i.Exchange.Size
@@ -120,7 +120,7 @@ func (dm DetailsModel) SumNonMetaFileSizes() int64 {
 
 	// Items will provide only files and filter out folders
 	for _, ent := range dm.FilterMetaFiles().Items() {
-		size += ent.size()
+		size += ent.Size()
 	}
 
 	return size
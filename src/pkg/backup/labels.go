@@ -0,0 +1,103 @@
+package backup
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/model"
+)
+
+// LabelTag produces the tag that stores the value of the label key.
+func LabelTag(key string) string {
+	return model.LabelTagPrefix + key
+}
+
+// ValidateLabelKey returns an error if the key can't be used as a label.
+func ValidateLabelKey(key string) error {
+	if len(key) == 0 {
+		return clues.New("label key is empty")
+	}
+
+	if strings.ContainsFunc(key, func(r rune) bool { return r == '=' || unicode.IsSpace(r) }) {
+		return clues.New("label key contains '=' or whitespace").With("label_key", key)
+	}
+
+	return nil
+}
+
+// ParseLabels parses a set of `key=value` pairs into labels.  Values may
+// be empty, keys may not.
+func ParseLabels(pairs []string) (map[string]string, error) {
+	labels := make(map[string]string, len(pairs))
+
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, clues.New("label must be formatted as key=value").With("label", pair)
+		}
+
+		if err := ValidateLabelKey(key); err != nil {
+			return nil, err
+		}
+
+		labels[key] = value
+	}
+
+	return labels, nil
+}
+
+// LabelTags produces the tags that store the labels.
+func LabelTags(labels map[string]string) map[string]string {
+	tags := make(map[string]string, len(labels))
+
+	for k, v := range labels {
+		tags[LabelTag(k)] = v
+	}
+
+	return tags
+}
+
+// Labels returns the user-defined labels attached to the backup.
+func (b Backup) Labels() map[string]string {
+	labels := map[string]string{}
+
+	for k, v := range b.Tags {
+		if key, ok := strings.CutPrefix(k, model.LabelTagPrefix); ok {
+			labels[key] = v
+		}
+	}
+
+	return labels
+}
+
+// UpdateLabels adds or replaces the labels in set, then drops the labels
+// in remove.  Tags other than labels are left untouched.
+func (b *Backup) UpdateLabels(set map[string]string, remove []string) error {
+	for key := range set {
+		if err := ValidateLabelKey(key); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range remove {
+		if err := ValidateLabelKey(key); err != nil {
+			return err
+		}
+	}
+
+	if b.Tags == nil {
+		b.Tags = map[string]string{}
+	}
+
+	for k, v := range LabelTags(set) {
+		b.Tags[k] = v
+	}
+
+	for _, key := range remove {
+		delete(b.Tags, LabelTag(key))
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package backup_test
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+)
+
+type LabelsUnitSuite struct {
+	tester.Suite
+}
+
+func TestLabelsUnitSuite(t *testing.T) {
+	suite.Run(t, &LabelsUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *LabelsUnitSuite) TestParseLabels() {
+	table := []struct {
+		name      string
+		input     []string
+		expect    map[string]string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "none",
+			expect:    map[string]string{},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "pairs",
+			input:     []string{"stage=pre-migration", "dept=finance", "empty="},
+			expect:    map[string]string{"stage": "pre-migration", "dept": "finance", "empty": ""},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "value containing equals",
+			input:     []string{"query=a=b"},
+			expect:    map[string]string{"query": "a=b"},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "missing equals",
+			input:     []string{"stage"},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "empty key",
+			input:     []string{"=finance"},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "whitespace in key",
+			input:     []string{"my dept=finance"},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, err := backup.ParseLabels(test.input)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *LabelsUnitSuite) TestUpdateLabels() {
+	t := suite.T()
+
+	b := backup.Backup{
+		BaseModel: model.BaseModel{
+			Tags: map[string]string{
+				model.ServiceTag:        "exchange",
+				backup.LabelTag("old"):  "value",
+				backup.LabelTag("dept"): "hr",
+			},
+		},
+	}
+
+	err := b.UpdateLabels(
+		map[string]string{"dept": "finance", "stage": "pre-migration"},
+		[]string{"old"})
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(
+		t,
+		map[string]string{"dept": "finance", "stage": "pre-migration"},
+		b.Labels())
+	assert.Equal(t, "exchange", b.Tags[model.ServiceTag], "non-label tags are kept")
+
+	err = b.UpdateLabels(nil, []string{"bad key"})
+	assert.Error(t, err, clues.ToCore(err))
+}
@@ -0,0 +1,74 @@
+package backup
+
+import (
+	"slices"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/model"
+)
+
+// LegalHold records why, when, and by whom a backup was placed on hold.
+// Backups under any legal hold can't be deleted until every hold on them
+// is released.
+type LegalHold struct {
+	Name      string    `json:"name"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Validate returns an error if the hold can't be placed.
+func (lh LegalHold) Validate() error {
+	if len(lh.Name) == 0 {
+		return clues.New("legal hold name is empty")
+	}
+
+	return nil
+}
+
+// UnderLegalHold is true if the backup has at least one legal hold.
+func (b Backup) UnderLegalHold() bool {
+	return len(b.LegalHolds) > 0
+}
+
+// PlaceLegalHold adds the hold to the backup.  Returns false if the backup
+// already has a hold with the same name, in which case the existing hold
+// is kept as-is.
+func (b *Backup) PlaceLegalHold(lh LegalHold) bool {
+	if slices.ContainsFunc(b.LegalHolds, func(h LegalHold) bool { return h.Name == lh.Name }) {
+		return false
+	}
+
+	b.LegalHolds = append(b.LegalHolds, lh)
+	b.tagLegalHold()
+
+	return true
+}
+
+// ReleaseLegalHold drops the hold with the given name from the backup.
+// Returns false if the backup has no such hold.
+func (b *Backup) ReleaseLegalHold(name string) bool {
+	n := len(b.LegalHolds)
+
+	b.LegalHolds = slices.DeleteFunc(b.LegalHolds, func(h LegalHold) bool { return h.Name == name })
+	b.tagLegalHold()
+
+	return len(b.LegalHolds) < n
+}
+
+// tagLegalHold keeps the legal hold tag in sync with the holds, so that
+// held backups can be found without reading every backup model.
+func (b *Backup) tagLegalHold() {
+	if b.Tags == nil {
+		b.Tags = map[string]string{}
+	}
+
+	if b.UnderLegalHold() {
+		b.Tags[model.LegalHoldTag] = "1"
+		return
+	}
+
+	delete(b.Tags, model.LegalHoldTag)
+}
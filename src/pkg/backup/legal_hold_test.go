@@ -0,0 +1,62 @@
+package backup_test
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+)
+
+type LegalHoldUnitSuite struct {
+	tester.Suite
+}
+
+func TestLegalHoldUnitSuite(t *testing.T) {
+	suite.Run(t, &LegalHoldUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *LegalHoldUnitSuite) TestLegalHold_Validate() {
+	t := suite.T()
+
+	err := backup.LegalHold{Name: "case-1234"}.Validate()
+	assert.NoError(t, err, clues.ToCore(err))
+
+	err = backup.LegalHold{Reason: "audit"}.Validate()
+	assert.Error(t, err, clues.ToCore(err))
+}
+
+func (suite *LegalHoldUnitSuite) TestPlaceAndReleaseLegalHold() {
+	var (
+		t     = suite.T()
+		b     = &backup.Backup{}
+		first = backup.LegalHold{Name: "case-1234", Reason: "audit"}
+		other = backup.LegalHold{Name: "case-5678"}
+	)
+
+	assert.False(t, b.UnderLegalHold())
+
+	assert.True(t, b.PlaceLegalHold(first))
+	assert.True(t, b.UnderLegalHold())
+	assert.Contains(t, b.Tags, model.LegalHoldTag)
+
+	// placing a hold with the same name keeps the original.
+	assert.False(t, b.PlaceLegalHold(backup.LegalHold{Name: first.Name}))
+	assert.Equal(t, []backup.LegalHold{first}, b.LegalHolds)
+
+	assert.True(t, b.PlaceLegalHold(other))
+	assert.Len(t, b.LegalHolds, 2)
+
+	assert.True(t, b.ReleaseLegalHold(first.Name))
+	assert.False(t, b.ReleaseLegalHold(first.Name))
+	assert.Equal(t, []backup.LegalHold{other}, b.LegalHolds)
+	assert.Contains(t, b.Tags, model.LegalHoldTag)
+
+	assert.True(t, b.ReleaseLegalHold(other.Name))
+	assert.False(t, b.UnderLegalHold())
+	assert.NotContains(t, b.Tags, model.LegalHoldTag)
+}
@@ -66,8 +66,13 @@ func configureAccount(
 		}
 	}
 
+	secrets, err := credentials.FromProcess(vpr.GetString(credentials.CredentialProcess))
+	if err != nil {
+		return acct, clues.Stack(err)
+	}
+
 	// compose the m365 config and credentials
-	m365 = GetM365(m365Cfg)
+	m365 = GetM365(m365Cfg, secrets)
 	if err := m365.Validate(); err != nil {
 		return acct, clues.Wrap(err, "validating m365 credentials")
 	}
@@ -99,15 +104,66 @@ func configureAccount(
 	return acct, nil
 }
 
-// M365 is a helper for aggregating m365 secrets and credentials.
-func GetM365(m365Cfg account.M365Config) credentials.M365 {
-	AzureClientID := str.First(
+// TargetAccount builds the m365 account that receives a restore into another
+// tenant.  Values come from the --target-* flags, then the TARGET_AZURE_*
+// env vars.  The client ID and secret default to those of the source account,
+// which suits app registrations that are consented to in both tenants.
+// Returns nil if no target tenant is provided.
+func TargetAccount(source account.Account) (*account.Account, error) {
+	var (
+		tenantID = str.First(
+			flags.TargetTenantIDFV,
+			os.Getenv(account.TargetAzureTenantID))
+		clientID = str.First(
+			flags.TargetClientIDFV,
+			os.Getenv(credentials.TargetAzureClientID))
+		clientSecret = str.First(
+			flags.TargetClientSecretFV,
+			os.Getenv(credentials.TargetAzureClientSecret))
+	)
+
+	if len(tenantID) == 0 {
+		if len(clientID) > 0 || len(clientSecret) > 0 {
+			return nil, clues.New("target credentials require --" + flags.TargetTenantIDFN +
+				" or " + account.TargetAzureTenantID)
+		}
+
+		return nil, nil
+	}
+
+	srcCfg, err := source.M365Config()
+	if err != nil {
+		return nil, clues.Wrap(err, "reading source m365 config")
+	}
+
+	m365Cfg := account.M365Config{
+		M365: credentials.M365{
+			AzureClientID:     str.First(clientID, srcCfg.AzureClientID),
+			AzureClientSecret: str.First(clientSecret, srcCfg.AzureClientSecret),
+		},
+		AzureTenantID: tenantID,
+	}
+
+	acct, err := account.NewAccount(account.ProviderM365, m365Cfg)
+	if err != nil {
+		return nil, clues.Wrap(err, "building target m365 account")
+	}
+
+	return &acct, nil
+}
+
+// M365 is a helper for aggregating m365 secrets and credentials.  The
+// config values are those read from the config file.
+func GetM365(m365Cfg account.M365Config, processSecrets map[string]string) credentials.M365 {
+	AzureClientID := credentials.Resolve(
 		flags.AzureClientIDFV,
-		os.Getenv(credentials.AzureClientID),
+		credentials.AzureClientID,
+		processSecrets,
 		m365Cfg.AzureClientID)
-	AzureClientSecret := str.First(
+	AzureClientSecret := credentials.Resolve(
 		flags.AzureClientSecretFV,
-		os.Getenv(credentials.AzureClientSecret),
+		credentials.AzureClientSecret,
+		processSecrets,
 		m365Cfg.AzureClientSecret)
 
 	return credentials.M365{
@@ -72,12 +72,16 @@ func init() {
 	}
 }
 
-// adds the persistent flag --config-file to the provided command.
+// adds the persistent flags --config-file and --profile to the provided command.
 func AddConfigFlags(cmd *cobra.Command) {
 	pf := cmd.PersistentFlags()
 	pf.StringVar(
 		&flags.ConfigFileFV,
 		flags.ConfigFileFN, displayDefaultFP, "config file location")
+	pf.StringVar(
+		&flags.ProfileFV,
+		flags.ProfileFN, "",
+		"Named profile in the config file to use; overrides the "+CorsoProfile+" env var and the current profile")
 }
 
 // ---------------------------------------------------------------------------------------------------------
@@ -236,22 +240,43 @@ func writeRepoConfigWithViper(
 	repoOpts repository.Options,
 	repoID string,
 ) error {
+	profile, err := activeProfile(vpr)
+	if err != nil {
+		return err
+	}
+
+	// when a profile is selected, its settings are written into the
+	// profile's table, leaving the rest of the config file untouched.
+	target := vpr
+
+	if len(profile) > 0 {
+		target = viper.New()
+
+		if err := target.MergeConfigMap(vpr.GetStringMap(profileKey(profile))); err != nil {
+			return clues.Wrap(err, "reading profile "+profile)
+		}
+	}
+
 	// Write storage configuration to viper
-	wcs.WriteConfigToStore(vpr)
+	wcs.WriteConfigToStore(target)
 
-	vpr.Set(RepoID, repoID)
+	target.Set(RepoID, repoID)
 
 	// Need if-checks as Viper will write empty values otherwise.
 	if len(repoOpts.User) > 0 {
-		vpr.Set(CorsoUser, repoOpts.User)
+		target.Set(CorsoUser, repoOpts.User)
 	}
 
 	if len(repoOpts.Host) > 0 {
-		vpr.Set(CorsoHost, repoOpts.Host)
+		target.Set(CorsoHost, repoOpts.Host)
 	}
 
-	vpr.Set(account.AccountProviderTypeKey, account.ProviderM365.String())
-	vpr.Set(account.AzureTenantIDKey, m365Config.AzureTenantID)
+	target.Set(account.AccountProviderTypeKey, account.ProviderM365.String())
+	target.Set(account.AzureTenantIDKey, m365Config.AzureTenantID)
+
+	if len(profile) > 0 {
+		vpr.Set(profileKey(profile), target.AllSettings())
+	}
 
 	if err := vpr.SafeWriteConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileAlreadyExistsError); ok {
@@ -318,7 +343,33 @@ func getStorageAndAccountWithViper(
 
 			readConfigFromViper = false
 		}
+	}
 
+	profile, err := activeProfile(vpr)
+	if err != nil {
+		return config, err
+	}
+
+	// from here on, only the profile's settings are visible.  A profile
+	// that isn't in the config file is treated like a missing config file.
+	if len(profile) > 0 {
+		ctx = clues.Add(ctx, "config_profile", profile)
+
+		pv, found, err := profileViper(vpr, profile)
+		if err != nil {
+			return config, err
+		}
+
+		if !found {
+			logger.Ctx(ctx).Info("config profile not found")
+
+			readConfigFromViper = false
+		}
+
+		vpr = pv
+	}
+
+	if readFromFile {
 		// in case of existing config, fetch repoid from config file
 		config.RepoID = vpr.GetString(RepoID)
 	}
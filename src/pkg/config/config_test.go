@@ -394,6 +394,190 @@ func (suite *ConfigSuite) TestReadFromFlags() {
 	assert.Equal(t, flags.PassphraseFV, pass)
 }
 
+func (suite *ConfigSuite) TestReadSecretSources() {
+	const (
+		b          = "read-secret-sources-bucket"
+		tID        = "6f34ac30-8196-469b-bf8f-d83deadbbbba"
+		passphrase = "passphrase-test"
+	)
+
+	table := []struct {
+		name              string
+		passphraseFlag    string
+		passphraseFile    string
+		credentialProcess string
+		expectPassphrase  string
+		expectErr         assert.ErrorAssertionFunc
+	}{
+		{
+			name:             "passphrase file",
+			passphraseFile:   "passphrase-from-file\n",
+			expectPassphrase: "passphrase-from-file",
+			expectErr:        assert.NoError,
+		},
+		{
+			name:           "passphrase flag and file",
+			passphraseFlag: "passphrase-from-flag",
+			passphraseFile: "passphrase-from-file\n",
+			expectErr:      assert.Error,
+		},
+		{
+			name:           "empty passphrase file",
+			passphraseFile: "\n",
+			expectErr:      assert.Error,
+		},
+		{
+			name:              "failing credential process",
+			credentialProcess: "false",
+			expectErr:         assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			var (
+				t   = suite.T()
+				vpr = viper.New()
+				dir = t.TempDir()
+			)
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			t.Cleanup(func() {
+				flags.PassphraseFV = ""
+				flags.PassphraseFileFV = ""
+			})
+
+			flags.PassphraseFV = test.passphraseFlag
+
+			testConfigData := fmt.Sprintf(configFileTemplate, b, tID, "acc-key", "secret",
+				"token", passphrase, "azure-client-id", "azure-secret", "false", "false")
+
+			if len(test.credentialProcess) > 0 {
+				testConfigData += credentials.CredentialProcess + " = '" + test.credentialProcess + "'\n"
+			}
+
+			testConfigFilePath := filepath.Join(dir, "corso.toml")
+			err := os.WriteFile(testConfigFilePath, []byte(testConfigData), 0o700)
+			require.NoError(t, err, clues.ToCore(err))
+
+			if len(test.passphraseFile) > 0 {
+				flags.PassphraseFileFV = filepath.Join(dir, "passphrase")
+				err := os.WriteFile(flags.PassphraseFileFV, []byte(test.passphraseFile), 0o600)
+				require.NoError(t, err, clues.ToCore(err))
+			}
+
+			vpr.SetConfigFile(testConfigFilePath)
+
+			repoDetails, err := getStorageAndAccountWithViper(
+				ctx,
+				vpr,
+				storage.ProviderS3,
+				true,
+				false,
+				map[string]string{})
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			commonConfig, err := repoDetails.Storage.CommonConfig()
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectPassphrase, commonConfig.Corso.CorsoPassphrase)
+		})
+	}
+}
+
+func (suite *ConfigSuite) TestTargetAccount() {
+	source, err := account.NewAccount(
+		account.ProviderM365,
+		account.M365Config{
+			M365: credentials.M365{
+				AzureClientID:     "source-client-id",
+				AzureClientSecret: "source-client-secret",
+			},
+			AzureTenantID: "source-tenant-id",
+		})
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	table := []struct {
+		name         string
+		tenantFlag   string
+		tenantEnv    string
+		clientFlag   string
+		secretEnv    string
+		expectNil    bool
+		expectTenant string
+		expectClient string
+		expectSecret string
+		expectErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "no target",
+			expectNil: true,
+			expectErr: assert.NoError,
+		},
+		{
+			name:         "tenant flag uses source credentials",
+			tenantFlag:   "target-tenant-id",
+			expectTenant: "target-tenant-id",
+			expectClient: "source-client-id",
+			expectSecret: "source-client-secret",
+			expectErr:    assert.NoError,
+		},
+		{
+			name:         "flags and env",
+			tenantEnv:    "target-tenant-id",
+			clientFlag:   "target-client-id",
+			secretEnv:    "target-client-secret",
+			expectTenant: "target-tenant-id",
+			expectClient: "target-client-id",
+			expectSecret: "target-client-secret",
+			expectErr:    assert.NoError,
+		},
+		{
+			name:       "credentials without a tenant",
+			clientFlag: "target-client-id",
+			expectNil:  true,
+			expectErr:  assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			t.Cleanup(func() {
+				flags.TargetTenantIDFV = ""
+				flags.TargetClientIDFV = ""
+			})
+
+			flags.TargetTenantIDFV = test.tenantFlag
+			flags.TargetClientIDFV = test.clientFlag
+
+			t.Setenv(account.TargetAzureTenantID, test.tenantEnv)
+			t.Setenv(credentials.TargetAzureClientID, "")
+			t.Setenv(credentials.TargetAzureClientSecret, test.secretEnv)
+
+			result, err := TargetAccount(source)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if test.expectNil {
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NotNil(t, result)
+
+			m365Cfg, err := result.M365Config()
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectTenant, m365Cfg.AzureTenantID)
+			assert.Equal(t, test.expectClient, m365Cfg.AzureClientID)
+			assert.Equal(t, test.expectSecret, m365Cfg.AzureClientSecret)
+		})
+	}
+}
+
 // ------------------------------------------------------------
 // integration tests
 // ------------------------------------------------------------
@@ -0,0 +1,256 @@
+package config
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+const (
+	// ProfilesKey is the config file table that holds the named profiles.
+	// Eg: [profiles.prod-eu]
+	ProfilesKey = "profiles"
+	// CurrentProfileKey names the profile used when neither --profile nor
+	// CORSO_PROFILE is provided.
+	CurrentProfileKey = "current_profile"
+
+	// CorsoProfile is the env var used to select a profile.
+	CorsoProfile = "CORSO_PROFILE"
+
+	redactedValue = "********"
+)
+
+// profile names are used as toml keys, and viper lowercases every key.
+var profileNameRE = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// secretKeys are the config file keys whose values are never displayed.
+var secretKeys = map[string]struct{}{
+	CorsoPassphrase:         {},
+	storage.AccessKey:       {},
+	storage.SecretAccessKey: {},
+	storage.SessionToken:    {},
+	account.AzureSecret:     {},
+}
+
+// Profile is a named set of storage, account, and repo settings in the
+// config file.
+type Profile struct {
+	Name string `json:"name"`
+	// Active is true if this profile is selected by --profile, CORSO_PROFILE,
+	// or the config file's current profile.
+	Active bool `json:"active"`
+	// Settings holds the profile's config file values, with secrets redacted.
+	Settings map[string]string `json:"settings"`
+}
+
+// Provider returns the profile's storage provider.
+func (p Profile) Provider() string {
+	return p.Settings[storage.StorageProviderTypeKey]
+}
+
+// TenantID returns the profile's M365 tenant ID.
+func (p Profile) TenantID() string {
+	return p.Settings[account.AzureTenantIDKey]
+}
+
+// RepoID returns the ID of the repo the profile is connected to.
+func (p Profile) RepoID() string {
+	return p.Settings[RepoID]
+}
+
+// ActiveProfile returns the name of the selected profile, or an empty string
+// if the flat (profile-less) configuration is in use.
+func ActiveProfile(ctx context.Context) (string, error) {
+	return activeProfile(GetViper(ctx))
+}
+
+// activeProfile implements ActiveProfile, but takes in a viper struct for
+// testing.  --profile takes priority over CORSO_PROFILE, which takes priority
+// over the config file's current profile.
+func activeProfile(vpr *viper.Viper) (string, error) {
+	name := str.First(
+		flags.ProfileFV,
+		os.Getenv(CorsoProfile),
+		vpr.GetString(CurrentProfileKey))
+
+	return normalizeProfileName(name)
+}
+
+func normalizeProfileName(name string) (string, error) {
+	if len(name) == 0 {
+		return "", nil
+	}
+
+	name = strings.ToLower(name)
+
+	if !profileNameRE.MatchString(name) {
+		return "", clues.New("invalid profile name [" + name + "]: " +
+			"use only letters, numbers, dashes, and underscores")
+	}
+
+	return name, nil
+}
+
+func profileKey(name string) string {
+	return ProfilesKey + "." + name
+}
+
+// profileViper produces a viper instance that holds only the settings of the
+// named profile, along with whether the profile exists in the config file.
+// The instance reports the same config file as the original, so that
+// kopia's config is stored alongside it.
+func profileViper(vpr *viper.Viper, name string) (*viper.Viper, bool, error) {
+	pv := viper.New()
+	pv.SetConfigFile(vpr.ConfigFileUsed())
+
+	if !vpr.IsSet(profileKey(name)) {
+		return pv, false, nil
+	}
+
+	if err := pv.MergeConfigMap(vpr.GetStringMap(profileKey(name))); err != nil {
+		return nil, false, clues.Wrap(err, "reading profile "+name)
+	}
+
+	return pv, true, nil
+}
+
+// Profiles lists the profiles in the config file, ordered by name.
+func Profiles(ctx context.Context) ([]Profile, error) {
+	vpr := GetViper(ctx)
+
+	if err := vpr.ReadInConfig(); err != nil {
+		return nil, clues.Wrap(err, "reading config file")
+	}
+
+	return profilesWithViper(vpr)
+}
+
+// profilesWithViper implements Profiles, but takes in a viper struct for
+// testing.
+func profilesWithViper(vpr *viper.Viper) ([]Profile, error) {
+	active, err := activeProfile(vpr)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+
+	for name := range vpr.GetStringMap(ProfilesKey) {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	ps := make([]Profile, 0, len(names))
+
+	for _, name := range names {
+		ps = append(ps, Profile{
+			Name:     name,
+			Active:   name == active,
+			Settings: redactedSettings(vpr.GetStringMap(profileKey(name))),
+		})
+	}
+
+	return ps, nil
+}
+
+// GetProfile returns the named profile from the config file.  If the name
+// is empty, the active profile is returned.
+func GetProfile(ctx context.Context, name string) (Profile, error) {
+	vpr := GetViper(ctx)
+
+	if err := vpr.ReadInConfig(); err != nil {
+		return Profile{}, clues.Wrap(err, "reading config file")
+	}
+
+	return getProfileWithViper(vpr, name)
+}
+
+// getProfileWithViper implements GetProfile, but takes in a viper struct for
+// testing.
+func getProfileWithViper(vpr *viper.Viper, name string) (Profile, error) {
+	active, err := activeProfile(vpr)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	name, err = normalizeProfileName(str.First(name, active))
+	if err != nil {
+		return Profile{}, err
+	}
+
+	if len(name) == 0 {
+		return Profile{}, clues.New("no profile selected: provide a profile name, --" +
+			flags.ProfileFN + ", or " + CorsoProfile)
+	}
+
+	if !vpr.IsSet(profileKey(name)) {
+		return Profile{}, clues.New("profile " + name + " not found in " + vpr.ConfigFileUsed())
+	}
+
+	return Profile{
+		Name:     name,
+		Active:   name == active,
+		Settings: redactedSettings(vpr.GetStringMap(profileKey(name))),
+	}, nil
+}
+
+// UseProfile sets the named profile as the config file's current profile,
+// which is used whenever --profile and CORSO_PROFILE aren't provided.
+func UseProfile(ctx context.Context, name string) error {
+	vpr := GetViper(ctx)
+
+	if err := vpr.ReadInConfig(); err != nil {
+		return clues.Wrap(err, "reading config file")
+	}
+
+	return useProfileWithViper(vpr, name)
+}
+
+// useProfileWithViper implements UseProfile, but takes in a viper struct for
+// testing.
+func useProfileWithViper(vpr *viper.Viper, name string) error {
+	name, err := normalizeProfileName(name)
+	if err != nil {
+		return err
+	}
+
+	if len(name) == 0 {
+		return clues.New("a profile name is required")
+	}
+
+	if !vpr.IsSet(profileKey(name)) {
+		return clues.New("profile " + name + " not found in " + vpr.ConfigFileUsed())
+	}
+
+	vpr.Set(CurrentProfileKey, name)
+
+	return clues.Wrap(vpr.WriteConfig(), "writing config file").OrNil()
+}
+
+// redactedSettings stringifies the settings, hiding the value of any secret.
+func redactedSettings(settings map[string]any) map[string]string {
+	res := make(map[string]string, len(settings))
+
+	for k, v := range settings {
+		if _, ok := secretKeys[k]; ok {
+			res[k] = redactedValue
+			continue
+		}
+
+		res[k] = cast.ToString(v)
+	}
+
+	return res
+}
@@ -0,0 +1,329 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/cli/flags"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/account"
+	"github.com/alcionai/corso/src/pkg/control/repository"
+	"github.com/alcionai/corso/src/pkg/credentials"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+const profilesConfig = `
+current_profile = 'prod-eu'
+bucket = 'flat-bucket'
+
+[profiles.prod-eu]
+provider = 'S3'
+bucket = 'eu-bucket'
+azure_tenantid = 'eu-tenant'
+repo_id = 'eu-repo'
+aws_secret_access_key = 'eu-secret'
+passphrase = 'eu-passphrase'
+
+[profiles.dev]
+provider = 'filesystem'
+path = '/var/lib/corso/dev'
+`
+
+type ProfileUnitSuite struct {
+	tester.Suite
+}
+
+func TestProfileUnitSuite(t *testing.T) {
+	suite.Run(t, &ProfileUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// readProfilesConfig produces a viper instance that has read the config.
+func readProfilesConfig(t *testing.T, config string) *viper.Viper {
+	vpr := viper.New()
+	fp := filepath.Join(t.TempDir(), "corso.toml")
+
+	err := os.WriteFile(fp, []byte(config), 0o700)
+	require.NoError(t, err, clues.ToCore(err))
+
+	vpr.SetConfigFile(fp)
+
+	err = vpr.ReadInConfig()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return vpr
+}
+
+func (suite *ProfileUnitSuite) TestActiveProfile() {
+	table := []struct {
+		name      string
+		flag      string
+		env       string
+		current   string
+		expect    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "none",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "current profile",
+			current:   "prod-eu",
+			expect:    "prod-eu",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "env over current profile",
+			env:       "dev",
+			current:   "prod-eu",
+			expect:    "dev",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "flag over env",
+			flag:      "Staging",
+			env:       "dev",
+			current:   "prod-eu",
+			expect:    "staging",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "invalid name",
+			flag:      "prod.eu",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+			vpr := viper.New()
+
+			t.Setenv(CorsoProfile, test.env)
+
+			flags.ProfileFV = test.flag
+			t.Cleanup(func() { flags.ProfileFV = "" })
+
+			if len(test.current) > 0 {
+				vpr.Set(CurrentProfileKey, test.current)
+			}
+
+			result, err := activeProfile(vpr)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *ProfileUnitSuite) TestProfiles() {
+	t := suite.T()
+	t.Setenv(CorsoProfile, "")
+
+	vpr := readProfilesConfig(t, profilesConfig)
+
+	ps, err := profilesWithViper(vpr)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, ps, 2)
+
+	assert.Equal(t, "dev", ps[0].Name)
+	assert.False(t, ps[0].Active)
+	assert.Equal(t, "filesystem", ps[0].Provider())
+
+	assert.Equal(t, "prod-eu", ps[1].Name)
+	assert.True(t, ps[1].Active)
+	assert.Equal(t, "S3", ps[1].Provider())
+	assert.Equal(t, "eu-tenant", ps[1].TenantID())
+	assert.Equal(t, "eu-repo", ps[1].RepoID())
+	assert.Equal(t, redactedValue, ps[1].Settings[storage.SecretAccessKey])
+	assert.Equal(t, redactedValue, ps[1].Settings[CorsoPassphrase])
+}
+
+func (suite *ProfileUnitSuite) TestGetProfile() {
+	table := []struct {
+		name       string
+		config     string
+		profile    string
+		expectName string
+		expectErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "active profile",
+			config:     profilesConfig,
+			expectName: "prod-eu",
+			expectErr:  assert.NoError,
+		},
+		{
+			name:       "named profile",
+			config:     profilesConfig,
+			profile:    "DEV",
+			expectName: "dev",
+			expectErr:  assert.NoError,
+		},
+		{
+			name:      "missing profile",
+			config:    profilesConfig,
+			profile:   "staging",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "no profile selected",
+			config:    "bucket = 'flat-bucket'\n",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+			t.Setenv(CorsoProfile, "")
+
+			vpr := readProfilesConfig(t, test.config)
+
+			p, err := getProfileWithViper(vpr, test.profile)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectName, p.Name)
+
+			for k := range secretKeys {
+				if v, ok := p.Settings[k]; ok {
+					assert.Equal(t, redactedValue, v, "secret %s is redacted", k)
+				}
+			}
+		})
+	}
+}
+
+func (suite *ProfileUnitSuite) TestUseProfile() {
+	t := suite.T()
+	t.Setenv(CorsoProfile, "")
+
+	vpr := readProfilesConfig(t, profilesConfig)
+
+	err := useProfileWithViper(vpr, "staging")
+	assert.Error(t, err, "profile must exist", clues.ToCore(err))
+
+	err = useProfileWithViper(vpr, "")
+	assert.Error(t, err, "name is required", clues.ToCore(err))
+
+	err = useProfileWithViper(vpr, "Dev")
+	require.NoError(t, err, clues.ToCore(err))
+
+	reread := viper.New()
+	reread.SetConfigFile(vpr.ConfigFileUsed())
+
+	err = reread.ReadInConfig()
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "dev", reread.GetString(CurrentProfileKey))
+	assert.Equal(t, "eu-bucket", reread.GetString(profileKey("prod-eu")+"."+storage.BucketNameKey))
+	assert.Equal(t, "flat-bucket", reread.GetString(storage.BucketNameKey))
+}
+
+func (suite *ProfileUnitSuite) TestWriteReadProfile() {
+	var (
+		t   = suite.T()
+		vpr = viper.New()
+		fp  = filepath.Join(t.TempDir(), "corso.toml")
+	)
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	t.Setenv(CorsoProfile, "")
+
+	t.Cleanup(func() {
+		flags.ProfileFV = ""
+		flags.AzureClientIDFV = ""
+		flags.AzureClientSecretFV = ""
+		flags.PassphraseFV = ""
+	})
+
+	err := initWithViper(ctx, vpr, fp)
+	require.NoError(t, err, clues.ToCore(err))
+
+	// a flat config, written without a profile
+	err = writeRepoConfigWithViper(
+		vpr,
+		&storage.S3Config{Bucket: "flat-bucket"},
+		account.M365Config{AzureTenantID: "flat-tenant"},
+		repository.Options{},
+		"flat-repo")
+	require.NoError(t, err, clues.ToCore(err))
+
+	// the same file, now holding a profile
+	flags.ProfileFV = "prod-eu"
+
+	err = writeRepoConfigWithViper(
+		vpr,
+		&storage.S3Config{Bucket: "eu-bucket"},
+		account.M365Config{AzureTenantID: "eu-tenant"},
+		repository.Options{User: "eu-user"},
+		"eu-repo")
+	require.NoError(t, err, clues.ToCore(err))
+
+	reread := viper.New()
+	reread.SetConfigFile(fp)
+
+	err = reread.ReadInConfig()
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "flat-bucket", reread.GetString(storage.BucketNameKey))
+	assert.Equal(t, "flat-repo", reread.GetString(RepoID))
+	assert.Equal(t, "eu-bucket", reread.GetString(profileKey("prod-eu")+"."+storage.BucketNameKey))
+	assert.Equal(t, "eu-user", reread.GetString(profileKey("prod-eu")+"."+CorsoUser))
+
+	// reading with the profile selected only sees the profile's settings
+	flags.AzureClientIDFV = "azure-id-flag-value"
+	flags.AzureClientSecretFV = "azure-secret-flag-value"
+	flags.PassphraseFV = "passphrase-flag-value"
+
+	overrides := map[string]string{
+		credentials.AWSAccessKeyID:     "aws-access-key",
+		credentials.AWSSecretAccessKey: "aws-secret",
+		credentials.AWSSessionToken:    "aws-token",
+	}
+
+	repoDetails, err := getStorageAndAccountWithViper(
+		ctx,
+		reread,
+		storage.ProviderS3,
+		true,
+		true,
+		overrides)
+	require.NoError(t, err, clues.ToCore(err))
+
+	s3Cfg, err := repoDetails.Storage.ToS3Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "eu-bucket", s3Cfg.Bucket)
+	assert.Equal(t, "eu-repo", repoDetails.RepoID)
+	assert.Equal(t, "eu-user", repoDetails.RepoUser)
+
+	// a profile that isn't in the config file reads nothing from it
+	flags.ProfileFV = "staging"
+
+	repoDetails, err = getStorageAndAccountWithViper(
+		ctx,
+		reread,
+		storage.ProviderS3,
+		true,
+		false,
+		map[string]string{
+			storage.Bucket:                 "staging-bucket",
+			account.AzureTenantID:          "staging-tenant",
+			credentials.AWSAccessKeyID:     "aws-access-key",
+			credentials.AWSSecretAccessKey: "aws-secret",
+			credentials.AWSSessionToken:    "aws-token",
+		})
+	require.NoError(t, err, clues.ToCore(err))
+
+	s3Cfg, err = repoDetails.Storage.ToS3Config()
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(t, "staging-bucket", s3Cfg.Bucket)
+	assert.Empty(t, repoDetails.RepoID)
+}
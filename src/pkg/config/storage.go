@@ -2,7 +2,6 @@ package config
 
 import (
 	"context"
-	"os"
 	"path/filepath"
 
 	"github.com/alcionai/clues"
@@ -39,8 +38,17 @@ func configureStorage(
 		return store, clues.Stack(err)
 	}
 
+	secrets, err := credentials.FromProcess(vpr.GetString(credentials.CredentialProcess))
+	if err != nil {
+		return store, clues.Stack(err)
+	}
+
 	// compose the common config and credentials
-	corso := GetAndInsertCorso(vpr.GetString(CorsoPassphrase))
+	corso, err := GetAndInsertCorso(secrets, vpr.GetString(CorsoPassphrase))
+	if err != nil {
+		return store, clues.Stack(err)
+	}
+
 	if err := corso.Validate(); err != nil {
 		return store, clues.Wrap(err, "validating corso credentials")
 	}
@@ -74,15 +82,32 @@ func configureStorage(
 	return store, nil
 }
 
-// GetCorso is a helper for aggregating Corso secrets and credentials.
-func GetAndInsertCorso(passphase string) credentials.Corso {
-	// fetch data from flag, env var or func param giving priority to func param
-	// Func param generally will be value fetched from config file using viper.
-	corsoPassph := str.First(flags.PassphraseFV, os.Getenv(credentials.CorsoPassphrase), passphase)
+// GetCorso is a helper for aggregating Corso secrets and credentials.  The
+// passphrase is the one read from the config file.
+func GetAndInsertCorso(processSecrets map[string]string, passphase string) (credentials.Corso, error) {
+	if len(flags.PassphraseFV) > 0 && len(flags.PassphraseFileFV) > 0 {
+		return credentials.Corso{}, clues.New(
+			"only one of --" + flags.PassphraseFN + " and --" + flags.PassphraseFileFN + " can be used")
+	}
+
+	fromFile, err := credentials.FromFile(flags.PassphraseFileFV)
+	if err != nil {
+		return credentials.Corso{}, clues.Wrap(err, "reading --"+flags.PassphraseFileFN)
+	}
+
+	if len(flags.PassphraseFileFV) > 0 && len(fromFile) == 0 {
+		return credentials.Corso{}, clues.New("--" + flags.PassphraseFileFN + " is empty")
+	}
+
+	corsoPassph := credentials.Resolve(
+		str.First(flags.PassphraseFV, fromFile),
+		credentials.CorsoPassphrase,
+		processSecrets,
+		passphase)
 
 	return credentials.Corso{
 		CorsoPassphrase: corsoPassph,
-	}
+	}, nil
 }
 
 // GetStorageProviderFromConfigFile reads the storage provider from the config file.
@@ -96,6 +121,18 @@ func GetStorageProviderFromConfigFile(ctx context.Context) (storage.ProviderType
 		return storage.ProviderUnknown, clues.Wrap(err, "reading config file")
 	}
 
+	profile, err := activeProfile(vpr)
+	if err != nil {
+		return storage.ProviderUnknown, err
+	}
+
+	if len(profile) > 0 {
+		vpr, _, err = profileViper(vpr, profile)
+		if err != nil {
+			return storage.ProviderUnknown, err
+		}
+	}
+
 	provider := vpr.GetString(storage.StorageProviderTypeKey)
 
 	return storage.StringToProviderType[provider], nil
@@ -1,5 +1,10 @@
 package control
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // ExportConfig contains config for exports
 type ExportConfig struct {
 	// Archive decides if we should create an archive from the data
@@ -8,6 +13,29 @@ type ExportConfig struct {
 	// the archive.
 	Archive bool
 
+	// ArchiveFormat decides the type of archive produced when Archive
+	// is set.  Defaults to ZipArchive.
+	ArchiveFormat ArchiveFormat
+
+	// ArchiveSplitSize, when greater than zero, splits the archive into
+	// numbered volumes of at most this many bytes.  Concatenating the
+	// volumes in order reproduces the complete archive.
+	ArchiveSplitSize int64
+
+	// ArchivePassword, when set, encrypts every entry of a zip archive
+	// with WinZip AES-256.  Never logged or included in errors.
+	ArchivePassword string
+
+	// ArchiveRecipients, when set, encrypts the archive to each of the
+	// age public keys (age1...).  Only holders of a matching identity
+	// can decrypt the archive.
+	ArchiveRecipients []string
+
+	// Aggregate combines the items of each calendar or contacts folder
+	// into a single file, instead of producing one file per item.
+	// Only supported by exchange exports.
+	Aggregate bool
+
 	// DataFormat
 	// TODO: Enable once we support outlook exports
 	// DataFormat string
@@ -16,6 +44,16 @@ type ExportConfig struct {
 	// ex: html vs pst vs other.
 	// Default format is decided on a per-service or per-data basis.
 	Format FormatType
+
+	// OnCollision decides how files that already exist at the export
+	// location are handled when writing the export to disk.
+	// Defaults to ExportOverwrite.  Invalid policies fall back to the
+	// default.
+	OnCollision ExportCollisionPolicy
+
+	// WriteParallelism is the maximum number of items written to disk
+	// concurrently.  Values below 1 fall back to the default.
+	WriteParallelism int
 }
 
 type FormatType string
@@ -25,10 +63,88 @@ var (
 	DefaultFormat FormatType
 	// export the data as raw, unmodified json
 	JSONFormat FormatType = "json"
+	// export the data as comma separated values.  Only supported
+	// for tabular data, such as planner tasks.
+	CSVFormat FormatType = "csv"
 )
 
+// ArchiveFormat describes the type of archive produced by an export.
+type ArchiveFormat string
+
+const (
+	ZipArchive ArchiveFormat = "zip"
+	TarArchive ArchiveFormat = "tar"
+	// a tar archive, compressed with zstandard.
+	TarZstArchive ArchiveFormat = "tar.zst"
+)
+
+func IsValidArchiveFormat(af ArchiveFormat) bool {
+	switch af {
+	case ZipArchive, TarArchive, TarZstArchive:
+		return true
+	}
+
+	return false
+}
+
+// ExportCollisionPolicy describes how exports behave when an item would be
+// written over an existing file.  It mirrors the CollisionPolicy used by
+// restores, but in terms of files on disk.
+type ExportCollisionPolicy string
+
+const (
+	// leave the existing file in place, and don't write the item.
+	ExportSkip ExportCollisionPolicy = "skip"
+	// write the item alongside the existing file under a new name.
+	ExportRename ExportCollisionPolicy = "rename"
+	// replace the existing file with the item.
+	ExportOverwrite ExportCollisionPolicy = "overwrite"
+)
+
+func IsValidExportCollisionPolicy(cp ExportCollisionPolicy) bool {
+	switch cp {
+	case ExportSkip, ExportRename, ExportOverwrite:
+		return true
+	}
+
+	return false
+}
+
+// DefaultExportCollisionPolicy replaces existing files, which is how
+// exports behaved before collision policies were introduced.  Re-running
+// an export into the same location refreshes its files.
+const DefaultExportCollisionPolicy = ExportOverwrite
+
+const DefaultExportWriteParallelism = 8
+
 func DefaultExportConfig() ExportConfig {
 	return ExportConfig{
-		Archive: false,
+		Archive:          false,
+		ArchiveFormat:    ZipArchive,
+		OnCollision:      DefaultExportCollisionPolicy,
+		WriteParallelism: DefaultExportWriteParallelism,
 	}
 }
+
+// ---------------------------------------------------------------------------
+// secret handling
+// ---------------------------------------------------------------------------
+
+// ensures the config is printed, and logged by clues, via String().
+var _ fmt.Stringer = &ExportConfig{}
+
+// String produces a representation of the config with the archive
+// password redacted, suitable for logging, storing in errors, and
+// other output.
+func (ec ExportConfig) String() string {
+	if len(ec.ArchivePassword) > 0 {
+		ec.ArchivePassword = "***"
+	}
+
+	bs, err := json.Marshal(ec)
+	if err != nil {
+		return "err marshalling"
+	}
+
+	return string(bs)
+}
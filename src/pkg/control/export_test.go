@@ -0,0 +1,48 @@
+package control_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type ExportUnitSuite struct {
+	tester.Suite
+}
+
+func TestExportUnitSuite(t *testing.T) {
+	suite.Run(t, &ExportUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ExportUnitSuite) TestExportConfig_hidesPassword() {
+	t := suite.T()
+
+	ec := control.DefaultExportConfig()
+	ec.Archive = true
+	ec.ArchivePassword = "hunter2"
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	ctx = clues.Add(ctx, "export_config", ec)
+
+	assert.NotContains(t, ec.String(), "hunter2")
+	assert.NotContains(t, fmt.Sprintf("%v", ec), "hunter2")
+	assert.NotContains(t, fmt.Sprintf("%+v", clues.In(ctx).Map()), "hunter2")
+	assert.Equal(t, "hunter2", ec.ArchivePassword, "original config is unchanged")
+}
+
+func (suite *ExportUnitSuite) TestDefaultExportConfig_overwrites() {
+	t := suite.T()
+
+	// re-running an export into the same location refreshes its files,
+	// as it did before collision policies were introduced.
+	assert.Equal(t, control.ExportOverwrite, control.DefaultExportConfig().OnCollision)
+	assert.Equal(t, control.ExportOverwrite, control.DefaultExportCollisionPolicy)
+}
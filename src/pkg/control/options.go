@@ -32,6 +32,10 @@ type Options struct {
 	// a Skip instead of a recoverable error in case of a failure due to 503 when
 	// retrieving calendar event item data.
 	SkipEventsOnInstance503ForResources map[string]struct{}
+
+	// Labels are user-defined key/value pairs attached to the backups
+	// produced by the process.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // RateLimiter is the set of options applied to any external service facing rate
@@ -0,0 +1,117 @@
+package control
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/alcionai/clues"
+)
+
+// PrincipalMap translates the users and groups of the backed up tenant into
+// the users and groups that own the restored data.  Keys identify the source
+// principal by ID, email, or principal name; values identify the target
+// principal the same way.  Lookups are case insensitive.
+type PrincipalMap map[string]string
+
+// PrincipalMapFallback is the source of the mapping that receives
+// grants whose users and groups can't be found in the restore target.
+const PrincipalMapFallback = "*"
+
+// NewPrincipalMap produces a PrincipalMap from the source to target pairs.
+func NewPrincipalMap(sourceToTarget map[string]string) PrincipalMap {
+	pm := make(PrincipalMap, len(sourceToTarget))
+
+	for k, v := range sourceToTarget {
+		pm[strings.ToLower(strings.TrimSpace(k))] = strings.TrimSpace(v)
+	}
+
+	return pm
+}
+
+// ParsePrincipalMap reads a csv of `source,target` rows into a PrincipalMap.
+// Blank lines and lines starting with '#' are ignored, as is an optional
+// `source,target` header row.  A source can only be mapped to one target.
+// A `*` source sets the fallback target.
+func ParsePrincipalMap(r io.Reader) (PrincipalMap, error) {
+	var (
+		pm  = PrincipalMap{}
+		csr = csv.NewReader(r)
+	)
+
+	csr.Comment = '#'
+	csr.FieldsPerRecord = 2
+	csr.TrimLeadingSpace = true
+
+	for i := 0; ; i++ {
+		row, err := csr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, clues.Wrap(err, "reading principal map")
+		}
+
+		source := strings.ToLower(strings.TrimSpace(row[0]))
+		target := strings.TrimSpace(row[1])
+
+		if i == 0 && source == "source" && strings.EqualFold(target, "target") {
+			continue
+		}
+
+		line, _ := csr.FieldPos(0)
+
+		if len(source) == 0 || len(target) == 0 {
+			return nil, clues.New("principal map rows require both a source and a target").
+				With("line", line)
+		}
+
+		if prev, ok := pm[source]; ok && !strings.EqualFold(prev, target) {
+			return nil, clues.New("principal map source is mapped to more than one target").
+				With("line", line)
+		}
+
+		pm[source] = target
+	}
+
+	return pm, nil
+}
+
+// Lookup returns the target of the first identifier that has a mapping.
+// Empty identifiers are skipped.
+func (pm PrincipalMap) Lookup(identifiers ...string) (string, bool) {
+	for _, iden := range identifiers {
+		if len(iden) == 0 {
+			continue
+		}
+
+		if target, ok := pm[strings.ToLower(iden)]; ok {
+			return target, true
+		}
+	}
+
+	return "", false
+}
+
+// Fallback returns the target of the fallback mapping, if one exists.
+func (pm PrincipalMap) Fallback() (string, bool) {
+	target, ok := pm[PrincipalMapFallback]
+	return target, ok
+}
+
+// concealed hides both the source and target of every mapping.
+func (pm PrincipalMap) concealed() PrincipalMap {
+	if len(pm) == 0 {
+		return nil
+	}
+
+	res := make(PrincipalMap, len(pm))
+
+	for k, v := range pm {
+		res[clues.Conceal(k)] = clues.Conceal(v)
+	}
+
+	return res
+}
@@ -0,0 +1,156 @@
+package control_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type PrincipalMapUnitSuite struct {
+	tester.Suite
+}
+
+func TestPrincipalMapUnitSuite(t *testing.T) {
+	suite.Run(t, &PrincipalMapUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PrincipalMapUnitSuite) TestParsePrincipalMap() {
+	table := []struct {
+		name      string
+		input     string
+		expect    control.PrincipalMap
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "empty",
+			expect:    control.PrincipalMap{},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "rows",
+			input: "Alice@Source.com,alice@target.com\n" +
+				"bob-id, bob@target.com\n",
+			expect: control.PrincipalMap{
+				"alice@source.com": "alice@target.com",
+				"bob-id":           "bob@target.com",
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "header, comments, and blank lines",
+			input: "source,target\n" +
+				"# the finance team\n" +
+				"\n" +
+				"finance@source.com,finance@target.com\n",
+			expect: control.PrincipalMap{
+				"finance@source.com": "finance@target.com",
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "repeated row",
+			input: "alice@source.com,alice@target.com\n" +
+				"ALICE@source.com,alice@target.com\n",
+			expect: control.PrincipalMap{
+				"alice@source.com": "alice@target.com",
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name: "conflicting targets",
+			input: "alice@source.com,alice@target.com\n" +
+				"alice@source.com,bob@target.com\n",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing target",
+			input:     "alice@source.com,\n",
+			expectErr: assert.Error,
+		},
+		{
+			name:      "too many columns",
+			input:     "alice@source.com,alice@target.com,bob@target.com\n",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, err := control.ParsePrincipalMap(strings.NewReader(test.input))
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *PrincipalMapUnitSuite) TestPrincipalMap_Lookup() {
+	pm := control.NewPrincipalMap(map[string]string{
+		"Alice@Source.com": "alice@target.com",
+		"bob-id":           "bob-target-id",
+	})
+
+	table := []struct {
+		name        string
+		identifiers []string
+		expect      string
+		expectOK    assert.BoolAssertionFunc
+	}{
+		{
+			name:     "no identifiers",
+			expectOK: assert.False,
+		},
+		{
+			name:        "case insensitive",
+			identifiers: []string{"ALICE@source.com"},
+			expect:      "alice@target.com",
+			expectOK:    assert.True,
+		},
+		{
+			name:        "first match wins",
+			identifiers: []string{"", "carol-id", "bob-id", "alice@source.com"},
+			expect:      "bob-target-id",
+			expectOK:    assert.True,
+		},
+		{
+			name:        "no match",
+			identifiers: []string{"carol-id", "carol@source.com"},
+			expectOK:    assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			result, ok := pm.Lookup(test.identifiers...)
+			test.expectOK(t, ok)
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *PrincipalMapUnitSuite) TestPrincipalMap_Fallback() {
+	t := suite.T()
+
+	pm, err := control.ParsePrincipalMap(strings.NewReader(
+		"alice@source.com,alice@target.com\n" +
+			"*,everyone@target.com\n"))
+	require.NoError(t, err, clues.ToCore(err))
+
+	target, ok := pm.Fallback()
+	assert.True(t, ok)
+	assert.Equal(t, "everyone@target.com", target)
+
+	_, ok = pm.Lookup("bob@source.com")
+	assert.False(t, ok, "fallback does not match other sources")
+
+	_, ok = control.PrincipalMap{}.Fallback()
+	assert.False(t, ok)
+}
@@ -64,12 +64,37 @@ type RestoreConfig struct {
 	// IncludePermissions toggles whether the restore will include the original
 	// folder- and item-level permissions.
 	IncludePermissions bool `json:"includePermissions"`
+
+	// IncludeTimestamps toggles whether restored drive files keep the created
+	// and modified times of the backed up files, instead of the time of the
+	// restore.
+	// Defaults to true.
+	IncludeTimestamps bool `json:"includeTimestamps"`
+
+	// PrincipalMap translates the users and groups of the backup into the
+	// users and groups of the restore target.  Used to pick the protected
+	// resource when none is specified, and to translate the principals
+	// of permissions, event attendees, and list person fields.  When the
+	// map is populated and no ProtectedResource is specified, the backed up
+	// resource must be mapped, or the restore fails.  The CLI requires
+	// either this map or a ProtectedResource when restoring into a
+	// different tenant.
+	// Defaults to empty.
+	PrincipalMap PrincipalMap `json:"principalMap,omitempty"`
+
+	// PermissionMap translates the users and groups of the backup when
+	// restoring drive item permissions and link shares.  Its mappings take
+	// precedence over the PrincipalMap, and its fallback receives the grants
+	// of users and groups that aren't available in the restore target.
+	// Defaults to empty.
+	PermissionMap PrincipalMap `json:"permissionMap,omitempty"`
 }
 
 func DefaultRestoreConfig(timeFormat dttm.TimeFormat) RestoreConfig {
 	return RestoreConfig{
-		OnCollision: Skip,
-		Location:    DefaultRestoreLocation + dttm.FormatNow(timeFormat),
+		OnCollision:       Skip,
+		Location:          DefaultRestoreLocation + dttm.FormatNow(timeFormat),
+		IncludeTimestamps: true,
 	}
 }
 
@@ -124,7 +149,30 @@ func (rc RestoreConfig) concealed() RestoreConfig {
 		Location:           path.LoggableDir(rc.Location),
 		Drive:              clues.Conceal(rc.Drive),
 		IncludePermissions: rc.IncludePermissions,
+		IncludeTimestamps:  rc.IncludeTimestamps,
+		PrincipalMap:       rc.PrincipalMap.concealed(),
+		PermissionMap:      rc.PermissionMap.concealed(),
+	}
+}
+
+// PermissionPrincipals produces the principal map used to translate
+// permissions: the PermissionMap, backed by the PrincipalMap.
+func (rc RestoreConfig) PermissionPrincipals() PrincipalMap {
+	if len(rc.PermissionMap) == 0 {
+		return rc.PrincipalMap
 	}
+
+	pm := make(PrincipalMap, len(rc.PrincipalMap)+len(rc.PermissionMap))
+
+	for k, v := range rc.PrincipalMap {
+		pm[k] = v
+	}
+
+	for k, v := range rc.PermissionMap {
+		pm[k] = v
+	}
+
+	return pm
 }
 
 // Conceal produces a concealed representation of the config, suitable for
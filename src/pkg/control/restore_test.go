@@ -116,16 +116,19 @@ func (suite *RestoreUnitSuite) TestRestoreConfig_piiHandling() {
 		expectPlain string
 	}{
 		{
-			name:        "empty",
-			expectSafe:  `{"onCollision":"","protectedResource":"","location":"","drive":"","includePermissions":false}`,
-			expectPlain: `{"onCollision":"","protectedResource":"","location":"","drive":"","includePermissions":false}`,
+			name: "empty",
+			expectSafe: `{"onCollision":"","protectedResource":"","location":"","drive":"",` +
+				`"includePermissions":false,"includeTimestamps":false}`,
+			expectPlain: `{"onCollision":"","protectedResource":"","location":"","drive":"",` +
+				`"includePermissions":false,"includeTimestamps":false}`,
 		},
 		{
-			name:       "defaults",
-			rc:         cdrc,
-			expectSafe: `{"onCollision":"skip","protectedResource":"","location":"***","drive":"","includePermissions":false}`,
+			name: "defaults",
+			rc:   cdrc,
+			expectSafe: `{"onCollision":"skip","protectedResource":"","location":"***","drive":"",` +
+				`"includePermissions":false,"includeTimestamps":true}`,
 			expectPlain: `{"onCollision":"skip","protectedResource":"","location":"` +
-				cdrc.Location + `","drive":"","includePermissions":false}`,
+				cdrc.Location + `","drive":"","includePermissions":false,"includeTimestamps":true}`,
 		},
 		{
 			name: "populated",
@@ -137,9 +140,31 @@ func (suite *RestoreUnitSuite) TestRestoreConfig_piiHandling() {
 				IncludePermissions: true,
 			},
 			expectSafe: `{"onCollision":"copy","protectedResource":"***","location":"***/exchange/***/email/***/***/***",` +
-				`"drive":"***","includePermissions":true}`,
+				`"drive":"***","includePermissions":true,"includeTimestamps":false}`,
 			expectPlain: `{"onCollision":"copy","protectedResource":"snoob","location":"tid/exchange/ro/email/foo/bar/baz",` +
-				`"drive":"somedriveid","includePermissions":true}`,
+				`"drive":"somedriveid","includePermissions":true,"includeTimestamps":false}`,
+		},
+		{
+			name: "principal map",
+			rc: control.RestoreConfig{
+				OnCollision:  control.Copy,
+				PrincipalMap: control.PrincipalMap{"alice@source.com": "alice@target.com"},
+			},
+			expectSafe: `{"onCollision":"copy","protectedResource":"","location":"","drive":"",` +
+				`"includePermissions":false,"includeTimestamps":false,"principalMap":{"***":"***"}}`,
+			expectPlain: `{"onCollision":"copy","protectedResource":"","location":"","drive":"",` +
+				`"includePermissions":false,"includeTimestamps":false,"principalMap":{"alice@source.com":"alice@target.com"}}`,
+		},
+		{
+			name: "permission map",
+			rc: control.RestoreConfig{
+				OnCollision:   control.Copy,
+				PermissionMap: control.PrincipalMap{"*": "everyone@target.com"},
+			},
+			expectSafe: `{"onCollision":"copy","protectedResource":"","location":"","drive":"",` +
+				`"includePermissions":false,"includeTimestamps":false,"permissionMap":{"***":"***"}}`,
+			expectPlain: `{"onCollision":"copy","protectedResource":"","location":"","drive":"",` +
+				`"includePermissions":false,"includeTimestamps":false,"permissionMap":{"*":"everyone@target.com"}}`,
 		},
 	}
 	for _, test := range table {
@@ -158,3 +183,45 @@ func (suite *RestoreUnitSuite) TestRestoreConfig_piiHandling() {
 		})
 	}
 }
+
+func (suite *RestoreUnitSuite) TestRestoreConfig_PermissionPrincipals() {
+	table := []struct {
+		name   string
+		rc     control.RestoreConfig
+		expect control.PrincipalMap
+	}{
+		{
+			name: "no maps",
+		},
+		{
+			name: "principal map only",
+			rc: control.RestoreConfig{
+				PrincipalMap: control.PrincipalMap{"alice@source.com": "alice@target.com"},
+			},
+			expect: control.PrincipalMap{"alice@source.com": "alice@target.com"},
+		},
+		{
+			name: "permission map overrides the principal map",
+			rc: control.RestoreConfig{
+				PrincipalMap: control.PrincipalMap{
+					"alice@source.com": "alice@target.com",
+					"bob@source.com":   "bob@target.com",
+				},
+				PermissionMap: control.PrincipalMap{
+					"alice@source.com": "finance@target.com",
+					"*":                "everyone@target.com",
+				},
+			},
+			expect: control.PrincipalMap{
+				"alice@source.com": "finance@target.com",
+				"bob@source.com":   "bob@target.com",
+				"*":                "everyone@target.com",
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, test.rc.PermissionPrincipals())
+		})
+	}
+}
@@ -47,6 +47,7 @@ const (
 	DeleteItemMarker              Key = "delete-item-marker"
 	Drives                        Key = "drives"
 	DriveTombstones               Key = "drive-tombstones"
+	ExcludedItems                 Key = "excluded-items"
 	Files                         Key = "files"
 	Folders                       Key = "folders"
 	ItemsAdded                    Key = "items-added"
@@ -129,4 +130,23 @@ const (
 	// non-meta item creation counting.  IE: use it specifically
 	// for counting new items (no collision) or copied items.
 	NewItemCreated Key = "new-item-created"
+	// count of permission and link share grants whose user or group was
+	// replaced using the principal maps, including fallbacks.
+	PermissionRemapped Key = "permission-remapped"
+	// count of permission and link share grants given to the fallback
+	// principal because their user or group wasn't available.
+	PermissionFallback Key = "permission-fallback"
+	// count of permission and link share grants that were not restored
+	// because their user or group wasn't available.
+	PermissionDropped Key = "permission-dropped"
+	// count of restored files whose created and modified times couldn't
+	// be set to those of the backed up file.
+	TimestampsNotRestored Key = "timestamps-not-restored"
+)
+
+// Tracked during export
+const (
+	// count of times that exported items collided with existing files,
+	// and that collision was solved by writing the item under a new name.
+	CollisionRename Key = "collision-rename"
 )
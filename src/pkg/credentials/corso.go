@@ -7,6 +7,10 @@ import (
 // envvar consts
 const (
 	CorsoPassphrase = "CORSO_PASSPHRASE"
+	// optional, used to encrypt zip archives produced by exports.
+	CorsoArchivePassword = "CORSO_ARCHIVE_PASSWORD"
+	// the bearer token required by `corso server`.
+	CorsoServerToken = "CORSO_SERVER_TOKEN"
 )
 
 // Corso aggregates corso credentials from flag and env_var values.
@@ -10,6 +10,11 @@ import (
 const (
 	AzureClientID     = "AZURE_CLIENT_ID"
 	AzureClientSecret = "AZURE_CLIENT_SECRET"
+
+	// the app credentials used to write into the tenant that receives a
+	// cross-tenant restore.
+	TargetAzureClientID     = "TARGET_AZURE_CLIENT_ID"
+	TargetAzureClientSecret = "TARGET_AZURE_CLIENT_SECRET"
 )
 
 // M365 aggregates m365 credentials from flag and env_var values.
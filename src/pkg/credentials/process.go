@@ -0,0 +1,185 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/common/str"
+)
+
+// config file consts
+const (
+	// CredentialProcess is the config file key holding a command that
+	// prints secrets to stdout.
+	CredentialProcess = "credential_process"
+)
+
+// the longest a credential process may run before it's cancelled.
+const credentialProcessTimeout = time.Minute
+
+// secrets that expire are refreshed this long before their expiration,
+// so that they don't expire while in use.
+const credentialProcessRefreshWindow = 5 * time.Minute
+
+// processExpiration is the key of the optional expiration time in the
+// credential process output, following the AWS credential_process format.
+const processExpiration = "Expiration"
+
+// processSecrets are the secrets that a credential process may produce,
+// keyed by the env var that would otherwise provide them.
+var processSecrets = map[string]struct{}{
+	CorsoPassphrase:    {},
+	AWSAccessKeyID:     {},
+	AWSSecretAccessKey: {},
+	AWSSessionToken:    {},
+	AzureClientID:      {},
+	AzureClientSecret:  {},
+}
+
+type processResult struct {
+	secrets map[string]string
+	// zero if the secrets don't expire.
+	expires time.Time
+}
+
+// processCache holds the secrets produced by each command, so that every
+// credential process runs once per corso invocation, or once each time
+// its secrets expire.
+var processCache = struct {
+	sync.Mutex
+	results map[string]processResult
+}{results: map[string]processResult{}}
+
+// FromProcess runs the command and parses the JSON object it writes to
+// stdout into a set of secrets, keyed by the env var that would otherwise
+// provide them.  Eg: {"CORSO_PASSPHRASE": "...", "AWS_ACCESS_KEY_ID": "..."}.
+// Unrecognized keys are ignored.  An optional RFC 3339 "Expiration" marks
+// when the secrets expire.
+//
+// The command is split on whitespace; arguments can't contain spaces.
+// Anything the command writes to stderr is passed through to the user.
+// Results are cached, so repeated calls with the same command only run it
+// once, unless its secrets are about to expire.  An empty command produces
+// no secrets.
+func FromProcess(command string) (map[string]string, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return map[string]string{}, nil
+	}
+
+	processCache.Lock()
+	defer processCache.Unlock()
+
+	if result, ok := processCache.results[command]; ok {
+		if result.expires.IsZero() || time.Until(result.expires) > credentialProcessRefreshWindow {
+			return result.secrets, nil
+		}
+	}
+
+	// only the executable name is safe to report; the arguments could
+	// hold tokens.
+	name := filepath.Base(args[0])
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialProcessTimeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, clues.Wrap(err, "running credential process").With("credential_process", name)
+	}
+
+	var out map[string]any
+
+	// the output is never included in the error, since a partially
+	// malformed payload still contains secrets.
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, clues.New("credential process output is not a JSON object").
+			With("credential_process", name)
+	}
+
+	result := processResult{secrets: map[string]string{}}
+
+	for k, v := range out {
+		if strings.EqualFold(k, processExpiration) {
+			expires, err := parseExpiration(v)
+			if err != nil {
+				return nil, clues.Stack(err).With("credential_process", name)
+			}
+
+			result.expires = expires
+
+			continue
+		}
+
+		if _, ok := processSecrets[k]; !ok {
+			continue
+		}
+
+		s, ok := v.(string)
+		if !ok {
+			return nil, clues.New("credential process value for "+k+" is not a string").
+				With("credential_process", name)
+		}
+
+		result.secrets[k] = s
+	}
+
+	processCache.results[command] = result
+
+	return result.secrets, nil
+}
+
+func parseExpiration(v any) (time.Time, error) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, clues.New("credential process expiration is not a string")
+	}
+
+	expires, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, clues.Wrap(err, "parsing credential process expiration")
+	}
+
+	return expires, nil
+}
+
+// Resolve picks the value of the secret provided by the env var, taking
+// the first of, in order of priority: the explicit value (ie: from a flag
+// or secret file), the env var, the credential process secrets, and the
+// config file value.  Every secret is resolved in this same order.
+func Resolve(explicit, envVar string, processSecrets map[string]string, fromConfig string) string {
+	return str.First(
+		explicit,
+		os.Getenv(envVar),
+		processSecrets[envVar],
+		fromConfig)
+}
+
+// FromFile reads a secret from the file at the provided path, trimming
+// any trailing newline.  An empty path produces an empty secret.
+func FromFile(fp string) (string, error) {
+	if len(fp) == 0 {
+		return "", nil
+	}
+
+	bs, err := os.ReadFile(fp)
+	if err != nil {
+		return "", clues.Wrap(err, "reading secret file").With("secret_file", fp)
+	}
+
+	return strings.TrimRight(string(bs), "\r\n"), nil
+}
@@ -0,0 +1,284 @@
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type ProcessUnitSuite struct {
+	tester.Suite
+}
+
+func TestProcessUnitSuite(t *testing.T) {
+	suite.Run(t, &ProcessUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// writeScript produces an executable shell script that prints the output
+// and counts its runs in a file next to the script.
+func writeScript(t *testing.T, output string) (string, string) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "creds.sh")
+	counter := filepath.Join(dir, "runs")
+
+	script := "#!/bin/sh\necho run >> " + counter + "\ncat <<'EOF'\n" + output + "\nEOF\n"
+
+	err := os.WriteFile(fp, []byte(script), 0o700)
+	require.NoError(t, err, clues.ToCore(err))
+
+	return fp, counter
+}
+
+func (suite *ProcessUnitSuite) TestFromProcess() {
+	table := []struct {
+		name      string
+		output    string
+		expect    map[string]string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "secrets",
+			output: `{
+				"CORSO_PASSPHRASE": "pass",
+				"AWS_ACCESS_KEY_ID": "akid",
+				"AZURE_CLIENT_SECRET": "azsecret",
+				"Expiration": "` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"
+			}`,
+			expect: map[string]string{
+				CorsoPassphrase:   "pass",
+				AWSAccessKeyID:    "akid",
+				AzureClientSecret: "azsecret",
+			},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "empty object",
+			output:    `{}`,
+			expect:    map[string]string{},
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "not json",
+			output:    `CORSO_PASSPHRASE=pass`,
+			expectErr: assert.Error,
+		},
+		{
+			name:      "non-string value",
+			output:    `{"CORSO_PASSPHRASE": 42}`,
+			expectErr: assert.Error,
+		},
+		{
+			name:      "malformed expiration",
+			output:    `{"CORSO_PASSPHRASE": "pass", "Expiration": "tomorrow"}`,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			fp, counter := writeScript(t, test.output)
+
+			secrets, err := FromProcess(fp)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, secrets)
+
+			if err != nil {
+				assert.NotContains(t, err.Error(), "pass", "secrets are not reported")
+				return
+			}
+
+			// the second call should be served from the cache.
+			secrets, err = FromProcess(fp)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, secrets)
+
+			runs, err := os.ReadFile(counter)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, "run\n", string(runs), "process runs once")
+		})
+	}
+}
+
+func (suite *ProcessUnitSuite) TestFromProcess_expiration() {
+	table := []struct {
+		name       string
+		expiration time.Time
+	}{
+		{
+			name:       "expired",
+			expiration: time.Now().Add(-time.Hour),
+		},
+		{
+			name:       "expiring soon",
+			expiration: time.Now().Add(time.Minute),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			fp, counter := writeScript(
+				t,
+				`{"CORSO_PASSPHRASE": "pass", "Expiration": "`+test.expiration.UTC().Format(time.RFC3339)+`"}`)
+
+			for i := 0; i < 2; i++ {
+				secrets, err := FromProcess(fp)
+				require.NoError(t, err, clues.ToCore(err))
+				assert.Equal(t, map[string]string{CorsoPassphrase: "pass"}, secrets)
+			}
+
+			runs, err := os.ReadFile(counter)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, "run\nrun\n", string(runs), "expiring secrets are refreshed")
+		})
+	}
+}
+
+func (suite *ProcessUnitSuite) TestFromProcess_failures() {
+	table := []struct {
+		name    string
+		command string
+	}{
+		{
+			name:    "non-zero exit",
+			command: "false",
+		},
+		{
+			name:    "missing command",
+			command: filepath.Join(suite.T().TempDir(), "does-not-exist"),
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			_, err := FromProcess(test.command)
+			assert.Error(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *ProcessUnitSuite) TestFromProcess_noCommand() {
+	t := suite.T()
+
+	secrets, err := FromProcess("  ")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Empty(t, secrets)
+}
+
+func (suite *ProcessUnitSuite) TestResolve() {
+	process := map[string]string{CorsoPassphrase: "from-process"}
+
+	table := []struct {
+		name     string
+		explicit string
+		env      string
+		process  map[string]string
+		config   string
+		expect   string
+	}{
+		{
+			name:     "explicit",
+			explicit: "explicit",
+			env:      "from-env",
+			process:  process,
+			config:   "from-config",
+			expect:   "explicit",
+		},
+		{
+			name:    "env",
+			env:     "from-env",
+			process: process,
+			config:  "from-config",
+			expect:  "from-env",
+		},
+		{
+			name:    "process",
+			process: process,
+			config:  "from-config",
+			expect:  "from-process",
+		},
+		{
+			name:   "config",
+			config: "from-config",
+			expect: "from-config",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			t.Setenv(CorsoPassphrase, test.env)
+
+			result := Resolve(test.explicit, CorsoPassphrase, test.process, test.config)
+			assert.Equal(t, test.expect, result)
+		})
+	}
+}
+
+func (suite *ProcessUnitSuite) TestFromFile() {
+	dir := suite.T().TempDir()
+
+	table := []struct {
+		name      string
+		contents  *string
+		expect    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "trailing newline",
+			contents:  ptr.To("secret\n"),
+			expect:    "secret",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "windows newline",
+			contents:  ptr.To("secret\r\n"),
+			expect:    "secret",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "inner whitespace kept",
+			contents:  ptr.To(" two words "),
+			expect:    " two words ",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "missing file",
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+			fp := filepath.Join(dir, test.name)
+
+			if test.contents != nil {
+				err := os.WriteFile(fp, []byte(*test.contents), 0o600)
+				require.NoError(t, err, clues.ToCore(err))
+			}
+
+			secret, err := FromFile(fp)
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, secret)
+		})
+	}
+}
+
+func (suite *ProcessUnitSuite) TestFromFile_noPath() {
+	t := suite.T()
+
+	secret, err := FromFile("")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Empty(t, secret)
+}
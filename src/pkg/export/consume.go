@@ -2,43 +2,120 @@ package export
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/alcionai/clues"
 
+	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/internal/observe"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
 	"github.com/alcionai/corso/src/pkg/logger"
 )
 
+// maxRenameAttempts bounds the search for an unused file name when
+// renaming colliding items.
+const maxRenameAttempts = 1000
+
+// Destination is the location that exported items get written to.
+type Destination interface {
+	// WriteItem writes the body of the item with the given name beneath
+	// the folder, which is relative to the root of the destination.  Items
+	// that collide with existing data are handled according to the
+	// collision policy, and tallied in the counter.  Returns the path the
+	// item was written to, relative to the root of the destination and
+	// using `/` as the separator, or an empty path if the item was skipped.
+	WriteItem(
+		ctx context.Context,
+		folder, name string,
+		body io.Reader,
+		modTime time.Time,
+		onCollision control.ExportCollisionPolicy,
+		ctr *count.Bus,
+	) (string, error)
+	// String describes the destination for logging and user output.
+	String() string
+}
+
+// ConsumeExportCollections writes the items in the collections to disk,
+// beneath the export location.  Items are written concurrently, up to
+// the write parallelism in the config.  Items that collide with existing
+// files are handled according to the config's collision policy.
 func ConsumeExportCollections(
 	ctx context.Context,
 	exportLocation string,
+	cfg control.ExportConfig,
 	expColl []Collectioner,
+	ctr *count.Bus,
 	errs *fault.Bus,
 ) error {
-	el := errs.Local()
-	counted := 0
-	log := logger.Ctx(ctx).
-		With("export_location", exportLocation,
-			"collection_count", len(expColl))
+	return ConsumeExportCollectionsTo(
+		ctx,
+		NewLocalDestination(exportLocation),
+		cfg,
+		expColl,
+		ctr,
+		errs)
+}
+
+// ConsumeExportCollectionsTo writes the items in the collections to the
+// destination.  Items are written concurrently, up to the write parallelism
+// in the config.  Items that collide with existing data are handled
+// according to the config's collision policy.
+func ConsumeExportCollectionsTo(
+	ctx context.Context,
+	dest Destination,
+	cfg control.ExportConfig,
+	expColl []Collectioner,
+	ctr *count.Bus,
+	errs *fault.Bus,
+) error {
+	var (
+		el          = errs.Local()
+		counted     atomic.Int64
+		wg          sync.WaitGroup
+		parallelism = cfg.WriteParallelism
+		onCollision = cfg.OnCollision
+		log         = logger.Ctx(ctx).
+				With("export_destination", dest.String(),
+				"collection_count", len(expColl))
+	)
+
+	if parallelism < 1 {
+		parallelism = control.DefaultExportWriteParallelism
+	}
+
+	if !control.IsValidExportCollisionPolicy(onCollision) {
+		onCollision = control.DefaultExportCollisionPolicy
+	}
+
+	log = log.With("write_parallelism", parallelism, "on_collision", onCollision)
+
+	semaphoreCh := make(chan struct{}, parallelism)
+	defer close(semaphoreCh)
 
 	for _, col := range expColl {
 		if el.Failure() != nil {
 			break
 		}
 
-		folder := filepath.Join(exportLocation, col.BasePath())
+		folder := col.BasePath()
 		ictx := clues.Add(ctx, "dir_name", folder)
 
 		for item := range col.Items(ictx) {
-			counted++
-
 			// Log every 1000 items that are processed
-			if counted%1000 == 0 {
-				log.Infow("progress writing export items", "count_items", counted)
+			if c := counted.Add(1); c%1000 == 0 {
+				log.Infow("progress writing export items", "count_items", c)
 			}
 
 			if item.Error != nil {
@@ -46,49 +123,259 @@ func ConsumeExportCollections(
 				continue
 			}
 
-			if err := writeItem(ictx, item, folder); err != nil {
-				el.AddRecoverable(
-					ictx,
-					clues.Wrap(err, "writing item").With("file_name", item.Name))
+			if el.Failure() != nil {
+				item.Body.Close()
+				continue
 			}
+
+			semaphoreCh <- struct{}{}
+
+			wg.Add(1)
+
+			go func(item Item) {
+				defer wg.Done()
+				defer func() { <-semaphoreCh }()
+
+				err := writeItem(ictx, dest, item, folder, onCollision, ctr)
+				if err != nil {
+					el.AddRecoverable(
+						ictx,
+						clues.Wrap(err, "writing item").With("file_name", item.Name))
+				}
+			}(item)
 		}
 	}
 
-	log.Infow("completed writing export items", "count_items", counted)
+	wg.Wait()
+
+	log.Infow(
+		"completed writing export items",
+		"count_items", counted.Load(),
+		"stats", ctr.Values())
 
 	return el.Failure()
 }
 
-// writeItem writes an ExportItem to disk in the specified folder.
-func writeItem(ctx context.Context, item Item, folder string) error {
-	name := item.Name
-	fpath := filepath.Join(folder, name)
-
+// writeItem writes an ExportItem to the destination in the specified folder.
+func writeItem(
+	ctx context.Context,
+	dest Destination,
+	item Item,
+	folder string,
+	onCollision control.ExportCollisionPolicy,
+	ctr *count.Bus,
+) error {
 	progReader := observe.ItemSpinner(
 		ctx,
 		item.Body,
 		observe.ItemExportMsg,
-		clues.Hide(name))
+		clues.Hide(item.Name))
 
 	defer item.Body.Close()
 	defer progReader.Close()
 
-	err := os.MkdirAll(folder, os.ModePerm)
+	written, err := dest.WriteItem(ctx, folder, item.Name, progReader, item.ModTime, onCollision, ctr)
 	if err != nil {
-		return clues.WrapWC(ctx, err, "creating directory")
+		return clues.Stack(err)
+	}
+
+	// items renamed to avoid a collision get recorded under their new name.
+	if wp, ok := item.Body.(writtenPather); ok && len(written) > 0 {
+		wp.setWrittenPath(written)
 	}
 
-	// In case the user tries to restore to a non-clean
-	// directory, we might run into collisions an fail.
-	f, err := os.Create(fpath)
+	return nil
+}
+
+// writtenPather is implemented by item bodies that need to know where
+// the destination wrote the item.
+type writtenPather interface {
+	setWrittenPath(p string)
+}
+
+// renamedItem produces the name used for the i'th rename of a colliding
+// item, eg: "file (1).txt".
+func renamedItem(name string, i int) string {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	return fmt.Sprintf("%s (%d)%s", base, i, ext)
+}
+
+// ---------------------------------------------------------------------------
+// local filesystem destination
+// ---------------------------------------------------------------------------
+
+var _ Destination = &localDestination{}
+
+type localDestination struct {
+	root string
+}
+
+// NewLocalDestination produces a destination that writes items to the
+// local filesystem beneath the root directory.
+func NewLocalDestination(root string) Destination {
+	return &localDestination{root: root}
+}
+
+func (ld localDestination) String() string {
+	return ld.root
+}
+
+func (ld localDestination) WriteItem(
+	ctx context.Context,
+	folder, name string,
+	body io.Reader,
+	modTime time.Time,
+	onCollision control.ExportCollisionPolicy,
+	ctr *count.Bus,
+) (string, error) {
+	dir := filepath.Join(ld.root, folder)
+
+	err := os.MkdirAll(dir, os.ModePerm)
 	if err != nil {
-		return clues.WrapWC(ctx, err, "creating file")
+		return "", clues.WrapWC(ctx, err, "creating directory")
 	}
 
-	_, err = io.Copy(f, progReader)
+	f, fpath, err := createFile(ctx, dir, name, onCollision, ctr)
 	if err != nil {
-		return clues.WrapWC(ctx, err, "writing data")
+		return "", clues.Stack(err)
 	}
 
-	return nil
+	// the item collided with an existing file, and was skipped.
+	if f == nil {
+		return "", nil
+	}
+
+	_, err = io.Copy(f, body)
+	if err != nil {
+		f.Close()
+		return "", clues.WrapWC(ctx, err, "writing data")
+	}
+
+	if err := f.Close(); err != nil {
+		return "", clues.WrapWC(ctx, err, "closing file")
+	}
+
+	if !modTime.IsZero() {
+		if err := os.Chtimes(fpath, time.Time{}, modTime); err != nil {
+			return "", clues.WrapWC(ctx, err, "setting file mod time")
+		}
+	}
+
+	return objectKey(folder, filepath.Base(fpath)), nil
+}
+
+// createFile opens a new file for the item according to the collision
+// policy.  Returns a nil file if the item should be skipped.  Files are
+// created exclusively, so that concurrent writes of items sharing a name
+// can't clobber each other unless the policy asks for it.
+func createFile(
+	ctx context.Context,
+	folder, name string,
+	onCollision control.ExportCollisionPolicy,
+	ctr *count.Bus,
+) (*os.File, string, error) {
+	fpath := filepath.Join(folder, name)
+
+	if onCollision == control.ExportOverwrite {
+		_, statErr := os.Stat(fpath)
+
+		f, err := os.Create(fpath)
+		if err != nil {
+			return nil, "", clues.WrapWC(ctx, err, "creating file")
+		}
+
+		if statErr == nil {
+			ctr.Inc(count.CollisionReplace)
+		}
+
+		return f, fpath, nil
+	}
+
+	f, err := os.OpenFile(fpath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o666)
+	if err == nil {
+		return f, fpath, nil
+	}
+
+	if !errors.Is(err, fs.ErrExist) {
+		return nil, "", clues.WrapWC(ctx, err, "creating file")
+	}
+
+	if onCollision == control.ExportSkip {
+		logger.Ctx(ctx).Debugw("skipping export item; file exists", "file_name", clues.Hide(name))
+		ctr.Inc(count.CollisionSkip)
+
+		return nil, "", nil
+	}
+
+	for i := 1; i <= maxRenameAttempts; i++ {
+		fpath = filepath.Join(folder, renamedItem(name, i))
+
+		f, err = os.OpenFile(fpath, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o666)
+		if err == nil {
+			ctr.Inc(count.CollisionRename)
+			return f, fpath, nil
+		}
+
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, "", clues.WrapWC(ctx, err, "creating renamed file")
+		}
+	}
+
+	return nil, "", clues.NewWC(ctx, "no unused file name available").
+		With("file_name", clues.Hide(name))
+}
+
+// ModTimeOf returns the last modified time of the item, or the zero time
+// if the item doesn't provide one.  For items read out of a backup, this
+// is the time stored in the snapshot, which falls back to the time of the
+// backup when the item's own time wasn't known.  WithModTimes replaces it
+// with the item's time from the backup details.
+func ModTimeOf(item data.Item) time.Time {
+	if mt, ok := item.(data.ItemModTime); ok {
+		return mt.ModTime()
+	}
+
+	return time.Time{}
+}
+
+// WithModTimes wraps the collections so that each item takes its mod time
+// from modTimes, keyed by item id.  Items missing from modTimes keep the
+// mod time they were produced with.
+func WithModTimes(colls []Collectioner, modTimes map[string]time.Time) []Collectioner {
+	wrapped := make([]Collectioner, 0, len(colls))
+
+	for _, c := range colls {
+		wrapped = append(wrapped, modTimeCollection{coll: c, modTimes: modTimes})
+	}
+
+	return wrapped
+}
+
+type modTimeCollection struct {
+	coll     Collectioner
+	modTimes map[string]time.Time
+}
+
+func (mc modTimeCollection) BasePath() string {
+	return mc.coll.BasePath()
+}
+
+func (mc modTimeCollection) Items(ctx context.Context) <-chan Item {
+	ch := make(chan Item)
+
+	go func() {
+		defer close(ch)
+
+		for item := range mc.coll.Items(ctx) {
+			if mt, ok := mc.modTimes[item.ID]; ok {
+				item.ModTime = mt
+			}
+
+			ch <- item
+		}
+	}()
+
+	return ch
 }
@@ -3,16 +3,20 @@ package export
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
 	"github.com/alcionai/corso/src/pkg/fault"
 )
 
@@ -182,7 +186,13 @@ func (suite *ExportE2ESuite) TestConsumeExportCollection() {
 			require.NoError(t, err)
 			defer os.RemoveAll(dir)
 
-			err = ConsumeExportCollections(ctx, dir, ecs, fault.New(true))
+			err = ConsumeExportCollections(
+				ctx,
+				dir,
+				control.DefaultExportConfig(),
+				ecs,
+				count.New(),
+				fault.New(true))
 			if test.hasError {
 				require.Error(t, err)
 				return
@@ -206,3 +216,220 @@ func (suite *ExportE2ESuite) TestConsumeExportCollection() {
 		})
 	}
 }
+
+type ConsumeUnitSuite struct {
+	tester.Suite
+}
+
+func TestConsumeUnitSuite(t *testing.T) {
+	suite.Run(t, &ConsumeUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *ConsumeUnitSuite) TestConsumeExportCollections_collisions() {
+	table := []struct {
+		name        string
+		policy      control.ExportCollisionPolicy
+		expectFiles map[string]string
+		expectCount map[count.Key]int64
+	}{
+		{
+			name:   "skip",
+			policy: control.ExportSkip,
+			expectFiles: map[string]string{
+				"a.txt": "existing",
+				"b.txt": "new-b",
+			},
+			expectCount: map[count.Key]int64{count.CollisionSkip: 1},
+		},
+		{
+			name:   "unknown policy falls back to overwrite",
+			policy: control.ExportCollisionPolicy("smash"),
+			expectFiles: map[string]string{
+				"a.txt": "new-a",
+				"b.txt": "new-b",
+			},
+			expectCount: map[count.Key]int64{count.CollisionReplace: 1},
+		},
+		{
+			name:   "rename",
+			policy: control.ExportRename,
+			expectFiles: map[string]string{
+				"a.txt":     "existing",
+				"a (1).txt": "new-a",
+				"b.txt":     "new-b",
+			},
+			expectCount: map[count.Key]int64{count.CollisionRename: 1},
+		},
+		{
+			name:   "overwrite",
+			policy: control.ExportOverwrite,
+			expectFiles: map[string]string{
+				"a.txt": "new-a",
+				"b.txt": "new-b",
+			},
+			expectCount: map[count.Key]int64{count.CollisionReplace: 1},
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			dir := t.TempDir()
+
+			err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("existing"), 0o600)
+			require.NoError(t, err)
+
+			ecs := []Collectioner{
+				mockExportCollection{
+					items: []Item{
+						{Name: "a.txt", Body: io.NopCloser(bytes.NewBufferString("new-a"))},
+						{Name: "b.txt", Body: io.NopCloser(bytes.NewBufferString("new-b"))},
+					},
+				},
+			}
+
+			ctr := count.New()
+			cfg := control.ExportConfig{OnCollision: test.policy}
+
+			err = ConsumeExportCollections(ctx, dir, cfg, ecs, ctr, fault.New(true))
+			require.NoError(t, err)
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, entries, len(test.expectFiles))
+
+			for name, expect := range test.expectFiles {
+				bs, err := os.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err, name)
+				assert.Equal(t, expect, string(bs), name)
+			}
+
+			for k, v := range test.expectCount {
+				assert.Equal(t, v, ctr.Get(k), k)
+			}
+		})
+	}
+}
+
+func (suite *ConsumeUnitSuite) TestConsumeExportCollections_parallelModTimes() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		dir     = t.TempDir()
+		modTime = time.Date(2020, 2, 3, 4, 5, 6, 0, time.UTC)
+		items   = []Item{}
+	)
+
+	for i := 0; i < 50; i++ {
+		items = append(items, Item{
+			Name:    fmt.Sprintf("item-%d", i),
+			Body:    io.NopCloser(bytes.NewBufferString("body")),
+			ModTime: modTime,
+		})
+	}
+
+	// an item without a mod time keeps the time of the export
+	items = append(items, Item{
+		Name: "no-mod-time",
+		Body: io.NopCloser(bytes.NewBufferString("body")),
+	})
+
+	ecs := []Collectioner{
+		mockExportCollection{path: "one", items: items[:25]},
+		mockExportCollection{path: "two", items: items[25:]},
+	}
+
+	cfg := control.ExportConfig{WriteParallelism: 4}
+
+	err := ConsumeExportCollections(ctx, dir, cfg, ecs, count.New(), fault.New(true))
+	require.NoError(t, err)
+
+	for i, item := range items {
+		folder := "one"
+		if i >= 25 {
+			folder = "two"
+		}
+
+		fi, err := os.Stat(filepath.Join(dir, folder, item.Name))
+		require.NoError(t, err, item.Name)
+
+		if item.ModTime.IsZero() {
+			assert.True(t, fi.ModTime().After(modTime), "export time used for "+item.Name)
+			continue
+		}
+
+		assert.True(t, modTime.Equal(fi.ModTime()), "mod time of "+item.Name)
+	}
+}
+
+func (suite *ConsumeUnitSuite) TestWithModTimes() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		backupTime = time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+		modified   = time.Date(2020, 2, 3, 4, 5, 6, 0, time.UTC)
+	)
+
+	ecs := WithModTimes(
+		[]Collectioner{
+			mockExportCollection{
+				path: "folder",
+				items: []Item{
+					{ID: "in-details", Name: "a", ModTime: backupTime},
+					{ID: "not-in-details", Name: "b", ModTime: backupTime},
+				},
+			},
+		},
+		map[string]time.Time{"in-details": modified})
+
+	require.Len(t, ecs, 1)
+	assert.Equal(t, "folder", ecs[0].BasePath())
+
+	modTimes := map[string]time.Time{}
+
+	for item := range ecs[0].Items(ctx) {
+		modTimes[item.ID] = item.ModTime
+	}
+
+	assert.Equal(
+		t,
+		map[string]time.Time{
+			"in-details":     modified,
+			"not-in-details": backupTime,
+		},
+		modTimes)
+}
+
+func (suite *ConsumeUnitSuite) TestConsumeExportCollectionsTo_writer() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		buf  = &bytes.Buffer{}
+		dest = NewWriterDestination(buf, "buffer")
+		ecs  = []Collectioner{
+			mockExportCollection{
+				items: []Item{
+					{Name: "export.tar", Body: io.NopCloser(bytes.NewBufferString("archive-body"))},
+				},
+			},
+		}
+	)
+
+	err := ConsumeExportCollectionsTo(ctx, dest, control.ExportConfig{}, ecs, count.New(), fault.New(true))
+	require.NoError(t, err)
+	assert.Equal(t, "archive-body", buf.String())
+	assert.Equal(t, "buffer", dest.String())
+}
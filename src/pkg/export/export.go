@@ -3,6 +3,7 @@ package export
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/alcionai/corso/src/internal/data"
 	"github.com/alcionai/corso/src/pkg/control"
@@ -81,6 +82,11 @@ type Item struct {
 	// SDK consumer is responsible for closing it.
 	Body io.ReadCloser
 
+	// ModTime is the last modified time of the item, as recorded in the
+	// backup.  Zero if the item has no known modified time, in which case
+	// the item is written with the time of the export.
+	ModTime time.Time
+
 	// Error will contain any error that happened while trying to get
 	// the item/items like when trying to resolve the name of the item.
 	// In case we have the error bound to a particular item, we will
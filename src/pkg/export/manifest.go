@@ -0,0 +1,456 @@
+package export
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+)
+
+const (
+	// ManifestFileName is the name of the manifest written to the root
+	// of the export destination.
+	ManifestFileName = "corso-export-manifest.json"
+
+	// ManifestVersion is the current version of the manifest format.
+	ManifestVersion = 1
+
+	signatureAlgorithmEd25519 = "ed25519"
+)
+
+// Manifest is a chain-of-custody record of an export.  It lists every
+// exported file along with its hash, so that the files handed over can
+// later be proven to match the backup they were exported from.
+type Manifest struct {
+	Version         int       `json:"version"`
+	BackupID        string    `json:"backupID"`
+	BackupCreatedAt time.Time `json:"backupCreatedAt"`
+	BackupVersion   int       `json:"backupVersion"`
+	CorsoVersion    string    `json:"corsoVersion"`
+	ExportedAt      time.Time `json:"exportedAt"`
+
+	// Files lists each exported item.  Paths are relative to the root of
+	// the export, or to the root of the archive when exporting an archive.
+	Files []ManifestEntry `json:"files"`
+
+	// Archives lists the archive files, or archive volumes, written to
+	// the root of the export.  Only populated when exporting an archive.
+	Archives []ManifestEntry `json:"archives,omitempty"`
+
+	// Signature is produced by Sign.  It covers every other field of
+	// the manifest.
+	Signature *ManifestSignature `json:"signature,omitempty"`
+}
+
+// ManifestEntry describes a single exported file.
+type ManifestEntry struct {
+	// Path always uses `/` as the separator.
+	Path     string `json:"path"`
+	BackupID string `json:"backupID,omitempty"`
+	ItemID   string `json:"itemID,omitempty"`
+	RepoRef  string `json:"repoRef,omitempty"`
+	Size     int64  `json:"size"`
+	// ModTime is the item's modified time as recorded in the backup.
+	// Zero if the backup doesn't record one.
+	ModTime time.Time `json:"modTime"`
+	// SHA256 is the hex encoded hash of the file's contents.
+	SHA256 string `json:"sha256"`
+}
+
+// ManifestSignature is an ed25519 signature over the manifest.
+type ManifestSignature struct {
+	Algorithm string `json:"algorithm"`
+	// PublicKey is the base64 encoded ed25519 public key of the signer.
+	PublicKey string `json:"publicKey"`
+	// Value is the base64 encoded signature.
+	Value string `json:"value"`
+}
+
+// ---------------------------------------------------------------------------
+// recording
+// ---------------------------------------------------------------------------
+
+// ManifestRecorder hashes export items as they're read, building up the
+// manifest for the export.  Items are only recorded once their body has
+// been read in full, so items that are never written (eg: skipped due to
+// a collision) don't appear in the manifest.
+type ManifestRecorder struct {
+	mu       sync.Mutex
+	header   Manifest
+	repoRefs map[string]string
+	files    []*ManifestEntry
+	archives []*ManifestEntry
+}
+
+// NewManifestRecorder produces a recorder for the manifest described by
+// the header.  repoRefs maps item IDs to the RepoRef of the item in the
+// backup.
+func NewManifestRecorder(header Manifest, repoRefs map[string]string) *ManifestRecorder {
+	return &ManifestRecorder{
+		header:   header,
+		repoRefs: repoRefs,
+	}
+}
+
+// RecordFiles wraps the collections so that each of their items is
+// recorded in the manifest's files.
+func (mr *ManifestRecorder) RecordFiles(colls []Collectioner) []Collectioner {
+	return mr.wrap(colls, false)
+}
+
+// RecordArchives wraps the collections so that each of their items is
+// recorded in the manifest's archives.
+func (mr *ManifestRecorder) RecordArchives(colls []Collectioner) []Collectioner {
+	return mr.wrap(colls, true)
+}
+
+func (mr *ManifestRecorder) wrap(colls []Collectioner, isArchive bool) []Collectioner {
+	wrapped := make([]Collectioner, 0, len(colls))
+
+	for _, c := range colls {
+		wrapped = append(wrapped, recordingCollection{
+			coll:      c,
+			rec:       mr,
+			isArchive: isArchive,
+		})
+	}
+
+	return wrapped
+}
+
+// Manifest produces the manifest of every item recorded so far.  Entries
+// are sorted by path.
+func (mr *ManifestRecorder) Manifest() Manifest {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	m := mr.header
+	m.Version = ManifestVersion
+	m.Files = sortedEntries(mr.files)
+	m.Archives = sortedEntries(mr.archives)
+
+	return m
+}
+
+// add records the entry.  Returns the recorded entry, which can be
+// updated through setPath.
+func (mr *ManifestRecorder) add(entry ManifestEntry, isArchive bool) *ManifestEntry {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	if isArchive {
+		mr.archives = append(mr.archives, &entry)
+		return &entry
+	}
+
+	entry.BackupID = mr.header.BackupID
+	entry.RepoRef = mr.repoRefs[entry.ItemID]
+
+	mr.files = append(mr.files, &entry)
+
+	return &entry
+}
+
+// setPath replaces the path of a recorded entry.
+func (mr *ManifestRecorder) setPath(entry *ManifestEntry, p string) {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+
+	entry.Path = p
+}
+
+func sortedEntries(entries []*ManifestEntry) []ManifestEntry {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	sorted := make([]ManifestEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		sorted = append(sorted, *entry)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Path < sorted[j].Path
+	})
+
+	return sorted
+}
+
+type recordingCollection struct {
+	coll      Collectioner
+	rec       *ManifestRecorder
+	isArchive bool
+}
+
+func (rc recordingCollection) BasePath() string {
+	return rc.coll.BasePath()
+}
+
+func (rc recordingCollection) Items(ctx context.Context) <-chan Item {
+	ch := make(chan Item)
+
+	go func() {
+		defer close(ch)
+
+		for item := range rc.coll.Items(ctx) {
+			if item.Error == nil {
+				item.Body = &hashingBody{
+					ReadCloser: item.Body,
+					hash:       sha256.New(),
+					entry: ManifestEntry{
+						// manifest paths always use `/` as the separator,
+						// regardless of the platform.
+						//nolint:forbidigo
+						Path:    path.Join(rc.coll.BasePath(), item.Name),
+						ItemID:  item.ID,
+						ModTime: item.ModTime,
+					},
+					rec:       rc.rec,
+					isArchive: rc.isArchive,
+				}
+			}
+
+			ch <- item
+		}
+	}()
+
+	return ch
+}
+
+// hashingBody hashes the body as it's read, and records the entry once
+// the body is read to completion.
+type hashingBody struct {
+	io.ReadCloser
+	hash      hash.Hash
+	entry     ManifestEntry
+	rec       *ManifestRecorder
+	isArchive bool
+	recorded  *ManifestEntry
+}
+
+func (hb *hashingBody) Read(p []byte) (int, error) {
+	n, err := hb.ReadCloser.Read(p)
+
+	hb.hash.Write(p[:n])
+	hb.entry.Size += int64(n)
+
+	if errors.Is(err, io.EOF) && hb.recorded == nil {
+		hb.entry.SHA256 = hex.EncodeToString(hb.hash.Sum(nil))
+		hb.recorded = hb.rec.add(hb.entry, hb.isArchive)
+	}
+
+	return n, err
+}
+
+// setWrittenPath replaces the recorded path with the one the destination
+// wrote the item to, which differs from the item's own path when the
+// item was renamed to avoid a collision.
+func (hb *hashingBody) setWrittenPath(p string) {
+	if hb.recorded != nil {
+		hb.rec.setPath(hb.recorded, p)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// signing
+// ---------------------------------------------------------------------------
+
+// signedBytes produces the bytes covered by the manifest's signature.
+func (m Manifest) signedBytes() ([]byte, error) {
+	m.Signature = nil
+
+	bs, err := json.Marshal(m)
+
+	return bs, clues.Wrap(err, "marshalling manifest").OrNil()
+}
+
+// Sign signs the manifest with the ed25519 key, replacing any existing
+// signature.
+func (m *Manifest) Sign(key ed25519.PrivateKey) error {
+	bs, err := m.signedBytes()
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	pub, ok := key.Public().(ed25519.PublicKey)
+	if !ok {
+		return clues.New("unexpected public key type")
+	}
+
+	m.Signature = &ManifestSignature{
+		Algorithm: signatureAlgorithmEd25519,
+		PublicKey: base64.StdEncoding.EncodeToString(pub),
+		Value:     base64.StdEncoding.EncodeToString(ed25519.Sign(key, bs)),
+	}
+
+	return nil
+}
+
+// VerifySignature checks that the manifest was signed by the key.  If
+// no key is provided, the public key recorded in the signature is used
+// instead, which proves the manifest hasn't changed since signing, but
+// not who signed it.
+func (m Manifest) VerifySignature(key ed25519.PublicKey) error {
+	if m.Signature == nil {
+		return clues.New("manifest is not signed")
+	}
+
+	if m.Signature.Algorithm != signatureAlgorithmEd25519 {
+		return clues.New("unsupported signature algorithm").
+			With("algorithm", m.Signature.Algorithm)
+	}
+
+	signer, err := base64.StdEncoding.DecodeString(m.Signature.PublicKey)
+	if err != nil || len(signer) != ed25519.PublicKeySize {
+		return clues.New("malformed signature public key")
+	}
+
+	if key != nil && !key.Equal(ed25519.PublicKey(signer)) {
+		return clues.New("manifest was signed by a different key")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(m.Signature.Value)
+	if err != nil {
+		return clues.Wrap(err, "decoding signature")
+	}
+
+	bs, err := m.signedBytes()
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	if !ed25519.Verify(signer, bs, sig) {
+		return clues.New("manifest signature does not match")
+	}
+
+	return nil
+}
+
+// ParseSigningKey parses a PEM encoded PKCS #8 ed25519 private key, such
+// as those produced by `openssl genpkey -algorithm ed25519`.
+func ParseSigningKey(pemBytes []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, clues.New("no PEM data found in signing key")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, clues.Wrap(err, "parsing signing key")
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, clues.New("signing key is not an ed25519 key")
+	}
+
+	return edKey, nil
+}
+
+// ParseVerifyingKey parses a PEM encoded PKIX ed25519 public key, such
+// as those produced by `openssl pkey -pubout`.
+func ParseVerifyingKey(pemBytes []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, clues.New("no PEM data found in public key")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, clues.Wrap(err, "parsing public key")
+	}
+
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, clues.New("public key is not an ed25519 key")
+	}
+
+	return edKey, nil
+}
+
+// ---------------------------------------------------------------------------
+// verification
+// ---------------------------------------------------------------------------
+
+// ManifestMismatch describes a file that doesn't match its manifest entry.
+type ManifestMismatch struct {
+	Path   string
+	Reason string
+}
+
+// VerifyFiles re-hashes the files beneath the root directory and compares
+// them to the manifest.  Archive exports are verified by their archive
+// files; all other exports by their individual files.  Returns every
+// file that is missing or doesn't match.
+func (m Manifest) VerifyFiles(root string) ([]ManifestMismatch, error) {
+	entries := m.Files
+	if len(m.Archives) > 0 {
+		entries = m.Archives
+	}
+
+	var mismatches []ManifestMismatch
+
+	for _, entry := range entries {
+		reason, err := verifyEntry(root, entry)
+		if err != nil {
+			return nil, clues.Stack(err).With("path", entry.Path)
+		}
+
+		if len(reason) > 0 {
+			mismatches = append(mismatches, ManifestMismatch{
+				Path:   entry.Path,
+				Reason: reason,
+			})
+		}
+	}
+
+	return mismatches, nil
+}
+
+// verifyEntry produces the reason the file doesn't match the entry, or
+// an empty string if it matches.
+func verifyEntry(root string, entry ManifestEntry) (string, error) {
+	f, err := os.Open(filepath.Join(root, filepath.FromSlash(entry.Path)))
+	if errors.Is(err, os.ErrNotExist) {
+		return "missing", nil
+	}
+
+	if err != nil {
+		return "", clues.Wrap(err, "opening file")
+	}
+
+	defer f.Close()
+
+	h := sha256.New()
+
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", clues.Wrap(err, "hashing file")
+	}
+
+	if size != entry.Size {
+		return "size differs", nil
+	}
+
+	if hex.EncodeToString(h.Sum(nil)) != entry.SHA256 {
+		return "hash differs", nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,373 @@
+package export
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+)
+
+type ManifestUnitSuite struct {
+	tester.Suite
+}
+
+func TestManifestUnitSuite(t *testing.T) {
+	suite.Run(t, &ManifestUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func (suite *ManifestUnitSuite) TestManifestRecorder() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		modTime = time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		rec     = NewManifestRecorder(
+			Manifest{BackupID: "bid", CorsoVersion: "v1.2.3"},
+			map[string]string{"id-a": "tid/onedrive/uid/files/drive/root/id-a"})
+		colls = rec.RecordFiles([]Collectioner{
+			mockExportCollection{
+				path: "Documents",
+				items: []Item{
+					{ID: "id-b", Name: "b.txt", Body: io.NopCloser(bytes.NewBufferString("bravo"))},
+					{ID: "id-a", Name: "a.txt", Body: io.NopCloser(bytes.NewBufferString("alpha")), ModTime: modTime},
+					{ID: "id-c", Name: "unread.txt", Body: io.NopCloser(bytes.NewBufferString("charlie"))},
+					{ID: "id-err", Error: assert.AnError},
+				},
+			},
+		})
+	)
+
+	for item := range colls[0].Items(ctx) {
+		if item.Error != nil || item.Name == "unread.txt" {
+			continue
+		}
+
+		_, err := io.ReadAll(item.Body)
+		require.NoError(t, err, clues.ToCore(err))
+	}
+
+	m := rec.Manifest()
+
+	assert.Equal(t, ManifestVersion, m.Version)
+	assert.Equal(t, "bid", m.BackupID)
+	assert.Equal(t, "v1.2.3", m.CorsoVersion)
+	assert.Empty(t, m.Archives)
+	assert.Equal(
+		t,
+		[]ManifestEntry{
+			{
+				Path:     "Documents/a.txt",
+				BackupID: "bid",
+				ItemID:   "id-a",
+				RepoRef:  "tid/onedrive/uid/files/drive/root/id-a",
+				Size:     5,
+				ModTime:  modTime,
+				SHA256:   sha256Hex("alpha"),
+			},
+			{
+				Path:     "Documents/b.txt",
+				BackupID: "bid",
+				ItemID:   "id-b",
+				Size:     5,
+				SHA256:   sha256Hex("bravo"),
+			},
+		},
+		m.Files)
+}
+
+func (suite *ManifestUnitSuite) TestManifestRecorder_archives() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		dir   = t.TempDir()
+		rec   = NewManifestRecorder(Manifest{BackupID: "bid"}, nil)
+		colls = rec.RecordArchives([]Collectioner{
+			mockExportCollection{
+				items: []Item{
+					{Name: "export.zip", Body: io.NopCloser(bytes.NewBufferString("zipped"))},
+				},
+			},
+		})
+	)
+
+	err := ConsumeExportCollections(ctx, dir, control.DefaultExportConfig(), colls, count.New(), fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+
+	m := rec.Manifest()
+
+	assert.Empty(t, m.Files)
+	require.Len(t, m.Archives, 1)
+	assert.Equal(t, "export.zip", m.Archives[0].Path)
+	assert.Equal(t, sha256Hex("zipped"), m.Archives[0].SHA256)
+	assert.Empty(t, m.Archives[0].BackupID)
+
+	mismatches, err := m.VerifyFiles(dir)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Empty(t, mismatches)
+}
+
+func (suite *ManifestUnitSuite) TestManifestRecorder_collisions() {
+	table := []struct {
+		name        string
+		onCollision control.ExportCollisionPolicy
+		expect      []ManifestEntry
+	}{
+		{
+			name:        "rename",
+			onCollision: control.ExportRename,
+			expect: []ManifestEntry{
+				{
+					Path:     "Documents/a (1).txt",
+					BackupID: "bid",
+					ItemID:   "id-a",
+					Size:     5,
+					SHA256:   sha256Hex("alpha"),
+				},
+			},
+		},
+		{
+			name:        "overwrite",
+			onCollision: control.ExportOverwrite,
+			expect: []ManifestEntry{
+				{
+					Path:     "Documents/a.txt",
+					BackupID: "bid",
+					ItemID:   "id-a",
+					Size:     5,
+					SHA256:   sha256Hex("alpha"),
+				},
+			},
+		},
+		{
+			name:        "skip",
+			onCollision: control.ExportSkip,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			dir := t.TempDir()
+
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "Documents"), 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "Documents", "a.txt"), []byte("existing"), 0o644))
+
+			var (
+				cfg   = control.DefaultExportConfig()
+				rec   = NewManifestRecorder(Manifest{BackupID: "bid"}, nil)
+				colls = rec.RecordFiles([]Collectioner{
+					mockExportCollection{
+						path: "Documents",
+						items: []Item{
+							{ID: "id-a", Name: "a.txt", Body: io.NopCloser(bytes.NewBufferString("alpha"))},
+						},
+					},
+				})
+			)
+
+			cfg.OnCollision = test.onCollision
+
+			err := ConsumeExportCollections(ctx, dir, cfg, colls, count.New(), fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			m := rec.Manifest()
+			assert.Equal(t, test.expect, m.Files)
+
+			mismatches, err := m.VerifyFiles(dir)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Empty(t, mismatches)
+		})
+	}
+}
+
+func (suite *ManifestUnitSuite) TestManifest_VerifyFiles() {
+	t := suite.T()
+
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "Documents"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Documents", "match.txt"), []byte("alpha"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Documents", "size.txt"), []byte("alphas"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Documents", "hash.txt"), []byte("alphz"), 0o644))
+
+	m := Manifest{
+		Files: []ManifestEntry{
+			{Path: "Documents/match.txt", Size: 5, SHA256: sha256Hex("alpha")},
+			{Path: "Documents/size.txt", Size: 5, SHA256: sha256Hex("alpha")},
+			{Path: "Documents/hash.txt", Size: 5, SHA256: sha256Hex("alpha")},
+			{Path: "Documents/missing.txt", Size: 5, SHA256: sha256Hex("alpha")},
+		},
+	}
+
+	mismatches, err := m.VerifyFiles(dir)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(
+		t,
+		[]ManifestMismatch{
+			{Path: "Documents/size.txt", Reason: "size differs"},
+			{Path: "Documents/hash.txt", Reason: "hash differs"},
+			{Path: "Documents/missing.txt", Reason: "missing"},
+		},
+		mismatches)
+}
+
+func (suite *ManifestUnitSuite) TestManifest_signatures() {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(suite.T(), err, clues.ToCore(err))
+
+	signed := func(t *testing.T) Manifest {
+		m := Manifest{
+			BackupID:        "bid",
+			BackupCreatedAt: time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC),
+			Files:           []ManifestEntry{{Path: "a.txt", Size: 5, SHA256: sha256Hex("alpha")}},
+		}
+
+		require.NoError(t, m.Sign(priv))
+
+		return m
+	}
+
+	table := []struct {
+		name      string
+		manifest  func(t *testing.T) Manifest
+		key       ed25519.PublicKey
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "embedded key",
+			manifest:  signed,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "expected key",
+			manifest:  signed,
+			key:       pub,
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "other key",
+			manifest:  signed,
+			key:       otherPub,
+			expectErr: assert.Error,
+		},
+		{
+			name: "tampered file hash",
+			manifest: func(t *testing.T) Manifest {
+				m := signed(t)
+				m.Files[0].SHA256 = sha256Hex("alphz")
+
+				return m
+			},
+			expectErr: assert.Error,
+		},
+		{
+			name: "unsigned",
+			manifest: func(t *testing.T) Manifest {
+				return Manifest{BackupID: "bid"}
+			},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			err := test.manifest(t).VerifySignature(test.key)
+			test.expectErr(t, err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *ManifestUnitSuite) TestManifest_signatureSurvivesJSON() {
+	t := suite.T()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, clues.ToCore(err))
+
+	m := Manifest{
+		BackupID:   "bid",
+		ExportedAt: time.Date(2022, 1, 2, 3, 4, 5, 6, time.FixedZone("test", 3600)),
+		Files: []ManifestEntry{{
+			Path:    "a.txt",
+			ModTime: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+			SHA256:  sha256Hex("alpha"),
+		}},
+	}
+
+	require.NoError(t, m.Sign(priv))
+
+	bs, err := json.MarshalIndent(m, "", "  ")
+	require.NoError(t, err, clues.ToCore(err))
+
+	var parsed Manifest
+
+	require.NoError(t, json.Unmarshal(bs, &parsed))
+
+	err = parsed.VerifySignature(nil)
+	assert.NoError(t, err, clues.ToCore(err))
+}
+
+func (suite *ManifestUnitSuite) TestParseKeys() {
+	t := suite.T()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, clues.ToCore(err))
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err, clues.ToCore(err))
+
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err, clues.ToCore(err))
+
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+
+	parsedPriv, err := ParseSigningKey(privPEM)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.True(t, priv.Equal(parsedPriv))
+
+	parsedPub, err := ParseVerifyingKey(pubPEM)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.True(t, pub.Equal(parsedPub))
+
+	_, err = ParseSigningKey(pubPEM)
+	assert.Error(t, err, "public key as signing key")
+
+	_, err = ParseVerifyingKey([]byte("smurfs"))
+	assert.Error(t, err, "not pem")
+}
@@ -0,0 +1,234 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/plan"
+)
+
+// itemExistser is implemented by destinations that can report whether
+// an item is already present.
+type itemExistser interface {
+	itemExists(ctx context.Context, folder, name string) (bool, error)
+}
+
+func (ld localDestination) itemExists(_ context.Context, folder, name string) (bool, error) {
+	_, err := os.Stat(filepath.Join(ld.root, folder, name))
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+
+	return false, clues.Wrap(err, "checking for existing file")
+}
+
+func (sd *s3Destination) itemExists(ctx context.Context, folder, name string) (bool, error) {
+	key := objectKey(sd.prefix, folder, name)
+
+	if sd.isClaimed(key) {
+		return true, nil
+	}
+
+	return sd.stat(ctx, key)
+}
+
+// PlanExportCollections records the action that writing each item in the
+// collections to dest would take, without writing anything.  Item bodies
+// are closed without being read.  Instead, items are sized from sizes,
+// which holds the sizes recorded in the backup details, keyed by item ID.
+// Items missing from sizes, such as archives, are planned with an unknown
+// size.  Collisions are only detected for destinations that can look up
+// existing items, such as the local filesystem and s3.
+func PlanExportCollections(
+	ctx context.Context,
+	dest Destination,
+	cfg control.ExportConfig,
+	expColl []Collectioner,
+	sizes map[string]int64,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	var (
+		el          = errs.Local()
+		onCollision = cfg.OnCollision
+		pl          = &planner{
+			dest:    dest,
+			p:       p,
+			claimed: map[string]struct{}{},
+		}
+	)
+
+	if !control.IsValidExportCollisionPolicy(onCollision) {
+		onCollision = control.DefaultExportCollisionPolicy
+	}
+
+	for _, col := range expColl {
+		if el.Failure() != nil {
+			break
+		}
+
+		folder := col.BasePath()
+		ictx := clues.Add(ctx, "dir_name", folder)
+
+		for item := range col.Items(ictx) {
+			if item.Error != nil {
+				el.AddRecoverable(ictx, clues.Wrap(item.Error, "getting item"))
+				continue
+			}
+
+			item.Body.Close()
+
+			if el.Failure() != nil {
+				continue
+			}
+
+			err := pl.add(ictx, folder, item.Name, sizes[item.ID], onCollision)
+			if err != nil {
+				el.AddRecoverable(
+					ictx,
+					clues.Wrap(err, "planning item").With("file_name", item.Name))
+			}
+		}
+	}
+
+	return el.Failure()
+}
+
+// PlanArchive records the action that writing the collections to dest as
+// a single archive would take, without writing anything.  As with
+// PlanExportCollections, item bodies are closed without being read.  The
+// archive is sized by the total of the items it holds, before any
+// compression, and is planned as a single file even if it would be split
+// into volumes.
+func PlanArchive(
+	ctx context.Context,
+	dest Destination,
+	cfg control.ExportConfig,
+	name string,
+	expColl []Collectioner,
+	sizes map[string]int64,
+	p *plan.Plan,
+	errs *fault.Bus,
+) error {
+	var (
+		el          = errs.Local()
+		onCollision = cfg.OnCollision
+		size        int64
+		pl          = &planner{
+			dest:    dest,
+			p:       p,
+			claimed: map[string]struct{}{},
+		}
+	)
+
+	if !control.IsValidExportCollisionPolicy(onCollision) {
+		onCollision = control.DefaultExportCollisionPolicy
+	}
+
+	for _, col := range expColl {
+		ictx := clues.Add(ctx, "dir_name", col.BasePath())
+
+		for item := range col.Items(ictx) {
+			if item.Error != nil {
+				el.AddRecoverable(ictx, clues.Wrap(item.Error, "getting item"))
+				continue
+			}
+
+			item.Body.Close()
+
+			size += sizes[item.ID]
+		}
+	}
+
+	if err := pl.add(ctx, "", name, size, onCollision); err != nil {
+		el.AddRecoverable(ctx, clues.Wrap(err, "planning archive"))
+	}
+
+	return el.Failure()
+}
+
+type planner struct {
+	dest Destination
+	p    *plan.Plan
+	// claimed tracks the items planned so far, so that items which
+	// collide with each other get planned the same way they'd get written.
+	claimed map[string]struct{}
+}
+
+// add records the action that writing the item would take.
+func (pl *planner) add(
+	ctx context.Context,
+	folder, name string,
+	size int64,
+	onCollision control.ExportCollisionPolicy,
+) error {
+	collides, err := pl.exists(ctx, folder, name)
+	if err != nil {
+		return clues.Stack(err)
+	}
+
+	action := plan.ForExport(onCollision, collides)
+
+	if action == plan.Copy {
+		name, err = pl.renamed(ctx, folder, name)
+		if err != nil {
+			return clues.Stack(err)
+		}
+	}
+
+	fpath := filepath.Join(folder, name)
+	pl.claimed[fpath] = struct{}{}
+
+	pl.p.Add(plan.Item{
+		Action:      action,
+		Destination: fpath,
+		Size:        size,
+	})
+
+	return nil
+}
+
+// exists reports whether the item was already planned, or is present in
+// the destination.
+func (pl *planner) exists(ctx context.Context, folder, name string) (bool, error) {
+	if _, ok := pl.claimed[filepath.Join(folder, name)]; ok {
+		return true, nil
+	}
+
+	ie, ok := pl.dest.(itemExistser)
+	if !ok {
+		return false, nil
+	}
+
+	return ie.itemExists(ctx, folder, name)
+}
+
+// renamed finds the name that a colliding item would be renamed to.
+func (pl *planner) renamed(ctx context.Context, folder, name string) (string, error) {
+	for i := 1; i <= maxRenameAttempts; i++ {
+		rn := renamedItem(name, i)
+
+		exists, err := pl.exists(ctx, folder, rn)
+		if err != nil {
+			return "", clues.Stack(err)
+		}
+
+		if !exists {
+			return rn, nil
+		}
+	}
+
+	return "", clues.NewWC(ctx, "no unused file name available").
+		With("file_name", clues.Hide(name))
+}
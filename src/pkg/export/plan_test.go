@@ -0,0 +1,211 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/plan"
+)
+
+type PlanUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlanUnitSuite(t *testing.T) {
+	suite.Run(t, &PlanUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+// unreadBody fails the test if the planner reads the item body.
+type unreadBody struct {
+	t      *testing.T
+	closed bool
+}
+
+func (ub *unreadBody) Read([]byte) (int, error) {
+	assert.Fail(ub.t, "item body read while planning")
+	return 0, io.EOF
+}
+
+func (ub *unreadBody) Close() error {
+	ub.closed = true
+	return nil
+}
+
+func (suite *PlanUnitSuite) TestPlanExportCollections_collisions() {
+	table := []struct {
+		name   string
+		policy control.ExportCollisionPolicy
+		expect []plan.Item
+	}{
+		{
+			name:   "skip",
+			policy: control.ExportSkip,
+			expect: []plan.Item{
+				{Action: plan.Skip, Destination: "a.txt", Size: 5},
+				{Action: plan.Create, Destination: "b.txt", Size: 7},
+			},
+		},
+		{
+			name:   "rename",
+			policy: control.ExportRename,
+			expect: []plan.Item{
+				{Action: plan.Copy, Destination: "a (2).txt", Size: 5},
+				{Action: plan.Create, Destination: "b.txt", Size: 7},
+			},
+		},
+		{
+			name:   "overwrite",
+			policy: control.ExportOverwrite,
+			expect: []plan.Item{
+				{Action: plan.Replace, Destination: "a.txt", Size: 5},
+				{Action: plan.Create, Destination: "b.txt", Size: 7},
+			},
+		},
+	}
+
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			dir := t.TempDir()
+
+			err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("existing"), 0o600)
+			require.NoError(t, err)
+
+			// the first rename candidate is taken, too.
+			err = os.WriteFile(filepath.Join(dir, "a (1).txt"), []byte("existing"), 0o600)
+			require.NoError(t, err)
+
+			var (
+				bodyA = &unreadBody{t: t}
+				bodyB = &unreadBody{t: t}
+				ecs   = []Collectioner{
+					mockExportCollection{
+						items: []Item{
+							{ID: "id-a", Name: "a.txt", Body: bodyA},
+							{ID: "id-b", Name: "b.txt", Body: bodyB},
+						},
+					},
+				}
+				sizes = map[string]int64{"id-a": 5, "id-b": 7}
+				p     = plan.New()
+				cfg   = control.ExportConfig{OnCollision: test.policy}
+			)
+
+			err = PlanExportCollections(ctx, NewLocalDestination(dir), cfg, ecs, sizes, p, fault.New(true))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expect, p.Items())
+			assert.True(t, bodyA.closed, "item body closed")
+			assert.True(t, bodyB.closed, "item body closed")
+
+			// nothing gets written during planning.
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, entries, 2)
+
+			bs, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, "existing", string(bs))
+		})
+	}
+}
+
+func (suite *PlanUnitSuite) TestPlanExportCollections_plannedCollisions() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		buf bytes.Buffer
+		p   = plan.New()
+		ecs = []Collectioner{
+			mockExportCollection{
+				path: "folder",
+				items: []Item{
+					{ID: "1", Name: "a.txt", Body: &unreadBody{t: t}},
+					{ID: "2", Name: "a.txt", Body: &unreadBody{t: t}},
+				},
+			},
+		}
+		// sizes that aren't in the details are unknown.
+		sizes = map[string]int64{"1": 1}
+		cfg   = control.ExportConfig{OnCollision: control.ExportRename}
+	)
+
+	err := PlanExportCollections(
+		ctx,
+		NewWriterDestination(&buf, "stdout"),
+		cfg,
+		ecs,
+		sizes,
+		p,
+		fault.New(true))
+	require.NoError(t, err)
+
+	expect := []plan.Item{
+		{Action: plan.Copy, Destination: filepath.Join("folder", "a (1).txt")},
+		{Action: plan.Create, Destination: filepath.Join("folder", "a.txt"), Size: 1},
+	}
+
+	assert.Equal(t, expect, p.Items())
+	assert.Empty(t, buf.Bytes())
+}
+
+func (suite *PlanUnitSuite) TestPlanArchive() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		p   = plan.New()
+		ecs = []Collectioner{
+			mockExportCollection{
+				path: "a",
+				items: []Item{
+					{ID: "1", Name: "a.txt", Body: &unreadBody{t: t}},
+				},
+			},
+			mockExportCollection{
+				path: "b",
+				items: []Item{
+					{ID: "2", Name: "b.txt", Body: &unreadBody{t: t}},
+					{ID: "3", Name: "c.txt", Body: &unreadBody{t: t}},
+				},
+			},
+		}
+		sizes = map[string]int64{"1": 1, "2": 2, "3": 3}
+	)
+
+	err := PlanArchive(
+		ctx,
+		NewLocalDestination(t.TempDir()),
+		control.ExportConfig{Archive: true},
+		"export.zip",
+		ecs,
+		sizes,
+		p,
+		fault.New(true))
+	require.NoError(t, err)
+
+	expect := []plan.Item{
+		{Action: plan.Create, Destination: "export.zip", Size: 6},
+	}
+
+	assert.Equal(t, expect, p.Items())
+}
@@ -0,0 +1,264 @@
+package export
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/storage"
+)
+
+const (
+	defaultS3Endpoint = "s3.amazonaws.com"
+
+	// s3PartSize is the size of each part in a multipart upload.  Items are
+	// streamed to the bucket one part at a time, so this bounds the memory
+	// held by each concurrent write.
+	s3PartSize = 16 * 1024 * 1024
+
+	// s3MTimeMetadataKey holds the item's last modified time, as unix
+	// seconds.  The key matches the one used by rclone and s3cmd, so that
+	// common tooling can restore mod times when syncing the export.
+	s3MTimeMetadataKey = "mtime"
+
+	s3NoSuchKeyCode = "NoSuchKey"
+)
+
+var _ Destination = &s3Destination{}
+
+type s3Destination struct {
+	client *minio.Client
+	bucket string
+	prefix string
+
+	// claimed tracks the keys written during this export.  S3 has no
+	// exclusive create, so concurrent writes of items sharing a name
+	// coordinate here instead of clobbering each other.
+	mu      sync.Mutex
+	claimed map[string]struct{}
+}
+
+// NewS3Destination produces a destination that streams items into the
+// bucket, beneath the prefix, using multipart uploads.  Nothing is staged
+// on local disk.
+func NewS3Destination(cfg storage.S3Config) (Destination, error) {
+	if len(cfg.Bucket) == 0 {
+		return nil, clues.New("missing export bucket")
+	}
+
+	creds := credentials.NewChainCredentials(
+		[]credentials.Provider{
+			&credentials.Static{
+				Value: credentials.Value{
+					AccessKeyID:     cfg.AccessKey,
+					SecretAccessKey: cfg.SecretKey,
+					SessionToken:    cfg.SessionToken,
+					SignerType:      credentials.SignatureV4,
+				},
+			},
+			&credentials.EnvAWS{},
+			&credentials.IAM{
+				Client: &http.Client{
+					Transport: http.DefaultTransport,
+				},
+			},
+		})
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.DoNotVerifyTLS {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	endpoint := cfg.Endpoint
+	if len(endpoint) == 0 {
+		endpoint = defaultS3Endpoint
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:     creds,
+		Secure:    !cfg.DoNotUseTLS,
+		Transport: transport,
+	})
+	if err != nil {
+		return nil, clues.Wrap(err, "creating s3 client").With("endpoint", endpoint)
+	}
+
+	return &s3Destination{
+		client:  client,
+		bucket:  cfg.Bucket,
+		prefix:  cfg.Prefix,
+		claimed: map[string]struct{}{},
+	}, nil
+}
+
+func (sd *s3Destination) String() string {
+	return "s3://" + objectKey(sd.bucket, sd.prefix)
+}
+
+func (sd *s3Destination) WriteItem(
+	ctx context.Context,
+	folder, name string,
+	body io.Reader,
+	modTime time.Time,
+	onCollision control.ExportCollisionPolicy,
+	ctr *count.Bus,
+) (string, error) {
+	key, err := sd.claimKey(ctx, folder, name, onCollision, ctr)
+	if err != nil {
+		return "", clues.Stack(err)
+	}
+
+	// the item collided with an existing object, and was skipped.
+	if len(key) == 0 {
+		return "", nil
+	}
+
+	opts := minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+		PartSize:    s3PartSize,
+	}
+
+	if !modTime.IsZero() {
+		opts.UserMetadata = map[string]string{
+			s3MTimeMetadataKey: strconv.FormatInt(modTime.Unix(), 10),
+		}
+	}
+
+	// a size of -1 streams the body in PartSize chunks using a multipart
+	// upload, since the item size isn't known ahead of time.
+	_, err = sd.client.PutObject(ctx, sd.bucket, key, body, -1, opts)
+	if err != nil {
+		return "", clues.WrapWC(ctx, err, "uploading object").
+			With("object_key", clues.Hide(key))
+	}
+
+	return objectKey(folder, path.Base(key)), nil
+}
+
+// claimKey picks the object key for the item according to the collision
+// policy.  Returns an empty key if the item should be skipped.  Items in
+// this export never replace each other, even when overwriting: an item
+// whose key was already claimed by another item gets a renamed key.
+func (sd *s3Destination) claimKey(
+	ctx context.Context,
+	folder, name string,
+	onCollision control.ExportCollisionPolicy,
+	ctr *count.Bus,
+) (string, error) {
+	key := objectKey(sd.prefix, folder, name)
+
+	claimed, exists, err := sd.reserve(ctx, key)
+	if err != nil {
+		return "", clues.Stack(err)
+	}
+
+	switch {
+	case !claimed && !exists:
+		return key, nil
+
+	case onCollision == control.ExportOverwrite && !claimed:
+		ctr.Inc(count.CollisionReplace)
+		return key, nil
+
+	case onCollision == control.ExportOverwrite, onCollision == control.ExportRename:
+		for i := 1; i <= maxRenameAttempts; i++ {
+			key = objectKey(sd.prefix, folder, renamedItem(name, i))
+
+			claimed, exists, err := sd.reserve(ctx, key)
+			if err != nil {
+				return "", clues.Stack(err)
+			}
+
+			// objects from before this export can still be overwritten.
+			if !claimed && (!exists || onCollision == control.ExportOverwrite) {
+				ctr.Inc(count.CollisionRename)
+				return key, nil
+			}
+		}
+
+		return "", clues.NewWC(ctx, "no unused object key available").
+			With("file_name", clues.Hide(name))
+	}
+
+	logger.Ctx(ctx).Debugw("skipping export item; object exists", "file_name", clues.Hide(name))
+	ctr.Inc(count.CollisionSkip)
+
+	return "", nil
+}
+
+// reserve claims the key.  Reports whether the key was already claimed by
+// another write during this export, and otherwise, whether it's present
+// in the bucket.  The lock is only held while claiming, so that
+// concurrent writes don't wait on each other's bucket lookups.  Keys found
+// in the bucket stay claimed, since they're known to be taken.
+func (sd *s3Destination) reserve(ctx context.Context, key string) (bool, bool, error) {
+	sd.mu.Lock()
+	_, claimed := sd.claimed[key]
+	sd.claimed[key] = struct{}{}
+	sd.mu.Unlock()
+
+	if claimed {
+		return true, false, nil
+	}
+
+	exists, err := sd.stat(ctx, key)
+	if err != nil {
+		sd.mu.Lock()
+		delete(sd.claimed, key)
+		sd.mu.Unlock()
+
+		return false, false, clues.Stack(err)
+	}
+
+	return false, exists, nil
+}
+
+// isClaimed reports whether the key was claimed during this export.
+func (sd *s3Destination) isClaimed(key string) bool {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	_, ok := sd.claimed[key]
+
+	return ok
+}
+
+// stat reports whether the key is present in the bucket.
+func (sd *s3Destination) stat(ctx context.Context, key string) (bool, error) {
+	_, err := sd.client.StatObject(ctx, sd.bucket, key, minio.StatObjectOptions{})
+	if err == nil {
+		return true, nil
+	}
+
+	if minio.ToErrorResponse(err).Code == s3NoSuchKeyCode {
+		return false, nil
+	}
+
+	return false, clues.WrapWC(ctx, err, "checking for existing object").
+		With("object_key", clues.Hide(key))
+}
+
+// objectKey joins the elements into a slash-separated object key, with
+// no leading slash.  Elements may contain OS-specific separators.
+func objectKey(elems ...string) string {
+	for i, e := range elems {
+		elems[i] = filepath.ToSlash(e)
+	}
+
+	//nolint:forbidigo
+	return strings.TrimPrefix(path.Join(elems...), "/")
+}
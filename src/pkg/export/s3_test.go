@@ -0,0 +1,470 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alcionai/clues"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/internal/tester/tconfig"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/storage"
+	storeTD "github.com/alcionai/corso/src/pkg/storage/testdata"
+)
+
+// ---------------------------------------------------------------------------
+// unit
+// ---------------------------------------------------------------------------
+
+type S3DestinationUnitSuite struct {
+	tester.Suite
+}
+
+func TestS3DestinationUnitSuite(t *testing.T) {
+	suite.Run(t, &S3DestinationUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *S3DestinationUnitSuite) TestObjectKey() {
+	table := []struct {
+		name   string
+		elems  []string
+		expect string
+	}{
+		{
+			name:   "prefix, folder, and name",
+			elems:  []string{"exports", "Documents/Finance", "plan.xlsx"},
+			expect: "exports/Documents/Finance/plan.xlsx",
+		},
+		{
+			name:   "no prefix",
+			elems:  []string{"", "Documents", "plan.xlsx"},
+			expect: "Documents/plan.xlsx",
+		},
+		{
+			name:   "no folder",
+			elems:  []string{"exports/", "", "plan.xlsx"},
+			expect: "exports/plan.xlsx",
+		},
+		{
+			name:   "leading slash",
+			elems:  []string{"/exports", "Documents", "plan.xlsx"},
+			expect: "exports/Documents/plan.xlsx",
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, objectKey(test.elems...))
+		})
+	}
+}
+
+func (suite *S3DestinationUnitSuite) TestRenamedItem() {
+	t := suite.T()
+
+	assert.Equal(t, "plan (1).xlsx", renamedItem("plan.xlsx", 1))
+	assert.Equal(t, "notes (12)", renamedItem("notes", 12))
+	assert.Equal(t, "archive.tar (2).gz", renamedItem("archive.tar.gz", 2))
+}
+
+func (suite *S3DestinationUnitSuite) TestNewS3Destination_missingBucket() {
+	_, err := NewS3Destination(storage.S3Config{})
+	assert.Error(suite.T(), err, clues.ToCore(err))
+}
+
+type mockDestination struct {
+	written map[string]string
+}
+
+func (md *mockDestination) String() string { return "mock" }
+func (md *mockDestination) WriteItem(
+	_ context.Context,
+	folder, name string,
+	body io.Reader,
+	_ time.Time,
+	_ control.ExportCollisionPolicy,
+	_ *count.Bus,
+) (string, error) {
+	bs, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+
+	key := objectKey(folder, name)
+	md.written[key] = string(bs)
+
+	return key, nil
+}
+
+func (suite *S3DestinationUnitSuite) TestConsumeExportCollectionsTo() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	dest := &mockDestination{written: map[string]string{}}
+	ecs := []Collectioner{
+		mockExportCollection{
+			path: "Documents",
+			items: []Item{
+				{Name: "a.txt", Body: io.NopCloser(bytes.NewBufferString("a"))},
+				{Name: "b.txt", Body: io.NopCloser(bytes.NewBufferString("b"))},
+			},
+		},
+	}
+
+	// the mock isn't safe for concurrent writes.
+	cfg := control.ExportConfig{WriteParallelism: 1}
+
+	err := ConsumeExportCollectionsTo(ctx, dest, cfg, ecs, count.New(), fault.New(true))
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Equal(
+		t,
+		map[string]string{
+			"Documents/a.txt": "a",
+			"Documents/b.txt": "b",
+		},
+		dest.written)
+}
+
+// newStubS3Destination produces a destination backed by a stub server,
+// which answers every object lookup with handleHead.
+func newStubS3Destination(
+	t *testing.T,
+	handleHead func(w http.ResponseWriter, r *http.Request),
+) *s3Destination {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			handleHead(w, r)
+			return
+		}
+
+		w.WriteHeader(http.StatusNotImplemented)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := minio.New(strings.TrimPrefix(srv.URL, "http://"), &minio.Options{
+		Creds:  credentials.NewStaticV4("access", "secret", ""),
+		Region: "us-east-1",
+	})
+	require.NoError(t, err, clues.ToCore(err))
+
+	return &s3Destination{
+		client:  client,
+		bucket:  "bucket",
+		claimed: map[string]struct{}{},
+	}
+}
+
+func (suite *S3DestinationUnitSuite) TestClaimKey_concurrentLookups() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	const writers = 2
+
+	var (
+		arrived  = make(chan struct{}, writers)
+		inFlight atomic.Int32
+		maxSeen  atomic.Int32
+	)
+
+	// each lookup waits for the others, so that the lookups only all
+	// complete quickly if they're made concurrently.
+	sd := newStubS3Destination(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		if n > maxSeen.Load() {
+			maxSeen.Store(n)
+		}
+
+		arrived <- struct{}{}
+
+		for len(arrived) < writers {
+			select {
+			case <-time.After(10 * time.Millisecond):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			key, err := sd.claimKey(ctx, "", strconv.Itoa(i), control.ExportSkip, count.New())
+			assert.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, strconv.Itoa(i), key)
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(writers), maxSeen.Load(), "concurrent object lookups")
+}
+
+func (suite *S3DestinationUnitSuite) TestClaimKey_collisions() {
+	table := []struct {
+		name   string
+		policy control.ExportCollisionPolicy
+		expect []string
+		counts map[count.Key]int64
+	}{
+		{
+			name:   "skip",
+			policy: control.ExportSkip,
+			expect: []string{"a.txt", ""},
+			counts: map[count.Key]int64{count.CollisionSkip: 1},
+		},
+		{
+			name:   "rename",
+			policy: control.ExportRename,
+			expect: []string{"a.txt", "a (2).txt"},
+			counts: map[count.Key]int64{count.CollisionRename: 1},
+		},
+		{
+			// items in the export never replace each other, but objects
+			// from before the export get overwritten.
+			name:   "overwrite",
+			policy: control.ExportOverwrite,
+			expect: []string{"a.txt", "a (1).txt"},
+			counts: map[count.Key]int64{count.CollisionRename: 1, count.CollisionReplace: 0},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			// only the first rename candidate is present in the bucket.
+			sd := newStubS3Destination(t, func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/a (1).txt") {
+					w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+					w.Header().Set("ETag", `"etag"`)
+					w.WriteHeader(http.StatusOK)
+
+					return
+				}
+
+				w.WriteHeader(http.StatusNotFound)
+			})
+
+			var (
+				ctr  = count.New()
+				keys []string
+			)
+
+			// the second write collides with the key claimed by the first.
+			for i := 0; i < 2; i++ {
+				key, err := sd.claimKey(ctx, "", "a.txt", test.policy, ctr)
+				require.NoError(t, err, clues.ToCore(err))
+
+				keys = append(keys, key)
+			}
+
+			assert.Equal(t, test.expect, keys)
+
+			for k, v := range test.counts {
+				assert.Equal(t, v, ctr.Get(k), k)
+			}
+		})
+	}
+}
+
+func (suite *S3DestinationUnitSuite) TestClaimKey_overwriteExisting() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	sd := newStubS3Destination(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"etag"`)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ctr := count.New()
+
+	key, err := sd.claimKey(ctx, "", "a.txt", control.ExportOverwrite, ctr)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "a.txt", key)
+	assert.Equal(t, int64(1), ctr.Get(count.CollisionReplace))
+}
+
+// ---------------------------------------------------------------------------
+// integration
+// ---------------------------------------------------------------------------
+
+// S3DestinationIntgSuite runs against the bucket and endpoint in the test
+// config, which can point to a local MinIO server.
+type S3DestinationIntgSuite struct {
+	tester.Suite
+	cfg storage.S3Config
+}
+
+func TestS3DestinationIntgSuite(t *testing.T) {
+	suite.Run(t, &S3DestinationIntgSuite{
+		Suite: tester.NewIntegrationSuite(
+			t,
+			[][]string{storeTD.AWSStorageCredEnvs}),
+	})
+}
+
+func (suite *S3DestinationIntgSuite) SetupSuite() {
+	t := suite.T()
+
+	cfg, err := tconfig.ReadTestConfig()
+	require.NoError(t, err, clues.ToCore(err))
+
+	suite.cfg = storage.S3Config{
+		Bucket:   cfg[tconfig.TestCfgBucket],
+		Endpoint: cfg[tconfig.TestCfgEndpoint],
+		Prefix:   "corso_export_test/" + tester.LogTimeOfTest(t),
+	}
+}
+
+func (suite *S3DestinationIntgSuite) TestConsumeExportCollectionsTo() {
+	table := []struct {
+		name        string
+		policy      control.ExportCollisionPolicy
+		expect      map[string]string
+		expectCount map[count.Key]int64
+	}{
+		{
+			name:   "skip",
+			policy: control.ExportSkip,
+			expect: map[string]string{
+				"folder/a.txt": "first",
+				"folder/b.txt": "b",
+			},
+			expectCount: map[count.Key]int64{count.CollisionSkip: 1},
+		},
+		{
+			name:   "rename",
+			policy: control.ExportRename,
+			expect: map[string]string{
+				"folder/a.txt":     "first",
+				"folder/a (1).txt": "second",
+				"folder/b.txt":     "b",
+			},
+			expectCount: map[count.Key]int64{count.CollisionRename: 1},
+		},
+		{
+			name:   "overwrite",
+			policy: control.ExportOverwrite,
+			expect: map[string]string{
+				"folder/a.txt": "second",
+				"folder/b.txt": "b",
+			},
+			expectCount: map[count.Key]int64{count.CollisionReplace: 1},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			var (
+				modTime = time.Date(2021, 4, 5, 6, 7, 8, 0, time.UTC)
+				cfg     = suite.cfg
+				ctr     = count.New()
+			)
+
+			cfg.Prefix = objectKey(cfg.Prefix, test.name)
+
+			dest, err := NewS3Destination(cfg)
+			require.NoError(t, err, clues.ToCore(err))
+
+			ecs := []Collectioner{
+				mockExportCollection{
+					path: "folder",
+					items: []Item{
+						{
+							Name:    "a.txt",
+							Body:    io.NopCloser(bytes.NewBufferString("first")),
+							ModTime: modTime,
+						},
+					},
+				},
+				mockExportCollection{
+					path: "folder",
+					items: []Item{
+						{Name: "a.txt", Body: io.NopCloser(bytes.NewBufferString("second"))},
+						{Name: "b.txt", Body: io.NopCloser(bytes.NewBufferString("b"))},
+					},
+				},
+			}
+
+			// serialize the writes so the first a.txt always lands first.
+			ecfg := control.ExportConfig{
+				OnCollision:      test.policy,
+				WriteParallelism: 1,
+			}
+
+			err = ConsumeExportCollectionsTo(ctx, dest, ecfg, ecs, ctr, fault.New(true))
+			require.NoError(t, err, clues.ToCore(err))
+
+			sd := dest.(*s3Destination)
+
+			for key, expect := range test.expect {
+				obj, err := sd.client.GetObject(
+					ctx,
+					cfg.Bucket,
+					objectKey(cfg.Prefix, key),
+					minio.GetObjectOptions{})
+				require.NoError(t, err, clues.ToCore(err))
+
+				bs, err := io.ReadAll(obj)
+				require.NoError(t, err, key, clues.ToCore(err))
+				assert.Equal(t, expect, string(bs), key)
+			}
+
+			if test.policy != control.ExportOverwrite {
+				info, err := sd.client.StatObject(
+					ctx,
+					cfg.Bucket,
+					objectKey(cfg.Prefix, "folder/a.txt"),
+					minio.StatObjectOptions{})
+				require.NoError(t, err, clues.ToCore(err))
+				assert.Equal(
+					t,
+					strconv.FormatInt(modTime.Unix(), 10),
+					info.UserMetadata["Mtime"])
+			}
+
+			for k, v := range test.expectCount {
+				assert.Equal(t, v, ctr.Get(k), k)
+			}
+		})
+	}
+}
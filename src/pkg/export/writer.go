@@ -0,0 +1,52 @@
+package export
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+)
+
+var _ Destination = &writerDestination{}
+
+type writerDestination struct {
+	mu   sync.Mutex
+	w    io.Writer
+	desc string
+}
+
+// NewWriterDestination produces a destination that streams the body of
+// every item, one after another, into the writer.  Folders, names, and
+// mod times are dropped, so it's only useful for exports that produce a
+// single item, such as an archive piped to stdout.
+func NewWriterDestination(w io.Writer, desc string) Destination {
+	return &writerDestination{w: w, desc: desc}
+}
+
+func (wd *writerDestination) String() string {
+	return wd.desc
+}
+
+func (wd *writerDestination) WriteItem(
+	ctx context.Context,
+	folder, name string,
+	body io.Reader,
+	_ time.Time,
+	_ control.ExportCollisionPolicy,
+	_ *count.Bus,
+) (string, error) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+
+	_, err := io.Copy(wd.w, body)
+	if err != nil {
+		return "", clues.WrapWC(ctx, err, "writing data")
+	}
+
+	return objectKey(folder, name), nil
+}
@@ -9,6 +9,7 @@ const (
 	AddtlContainerName = "container_name"
 	AddtlContainerPath = "container_path"
 	AddtlMalwareDesc   = "malware_description"
+	AddtlExclusionRule = "exclusion_rule"
 )
 
 type ItemType string
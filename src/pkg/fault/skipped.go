@@ -45,6 +45,12 @@ const (
 	// of event IDs where the events are known to fail with a 503 due to there being
 	// too many instances to retrieve from graph api.
 	SkipKnownEventInstance503s SkipCause = "known_event_instance_503"
+
+	// SkipExcludedByRule identifies that a file was skipped because it
+	// matched one of the exclusion rules (extension, name, path, or size)
+	// defined on the backup selector.  The matching rule is recorded in the
+	// item's additional data under AddtlExclusionRule.
+	SkipExcludedByRule SkipCause = "excluded_by_rule"
 )
 
 var _ print.Printable = &Skipped{}
@@ -96,7 +102,10 @@ func (s Skipped) Headers(bool) []string {
 
 // Values populates the printable values matching the Headers list.
 func (s Skipped) Values(bool) []string {
-	var cn string
+	var (
+		cn    string
+		cause = s.Item.Cause
+	)
 
 	acn, ok := s.Item.Additional[AddtlContainerName]
 	if ok {
@@ -106,7 +115,13 @@ func (s Skipped) Values(bool) []string {
 		}
 	}
 
-	return []string{"Skip", s.Item.Type.Printable(), s.Item.Name, cn, s.Item.Cause}
+	// exclusions are user-defined, so the rule itself is the
+	// most useful description of the cause.
+	if rule, ok := s.Item.Additional[AddtlExclusionRule].(string); ok && len(rule) > 0 {
+		cause += ": " + rule
+	}
+
+	return []string{"Skip", s.Item.Type.Printable(), s.Item.Name, cn, cause}
 }
 
 // ContainerSkip produces a Container-kind Item for tracking skipped items.
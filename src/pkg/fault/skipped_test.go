@@ -110,6 +110,23 @@ func (suite *SkippedUnitSuite) TestSkipped_HeadersValues() {
 			skip:   fault.ContainerSkip(fault.SkipMalware, "ns", "id", "name", addtl),
 			expect: []string{"Skip", fault.ContainerType.Printable(), "name", "cname", string(fault.SkipMalware)},
 		},
+		{
+			name: "excluded file",
+			skip: fault.FileSkip(
+				fault.SkipExcludedByRule,
+				"ns", "id", "name",
+				map[string]any{
+					fault.AddtlContainerName: "cname",
+					fault.AddtlExclusionRule: "extension .iso",
+				}),
+			expect: []string{
+				"Skip",
+				fault.FileType.Printable(),
+				"name",
+				"cname",
+				string(fault.SkipExcludedByRule) + ": extension .iso",
+			},
+		},
 		{
 			name:   "owner",
 			skip:   fault.OwnerSkip(fault.SkipMalware, "ns", "id", "name", nil),
@@ -29,6 +29,8 @@ const (
 	ChannelMessagesCategory   CategoryType = 9  // channelMessages
 	ConversationPostsCategory CategoryType = 10 // conversationPosts
 	ChatsCategory             CategoryType = 11 // chats
+	MailboxSettingsCategory   CategoryType = 12 // mailboxSettings
+	PlannerCategory           CategoryType = 13 // planner
 )
 
 var strToCat = map[string]CategoryType{
@@ -43,6 +45,8 @@ var strToCat = map[string]CategoryType{
 	strings.ToLower(ChannelMessagesCategory.String()):   ChannelMessagesCategory,
 	strings.ToLower(ConversationPostsCategory.String()): ConversationPostsCategory,
 	strings.ToLower(ChatsCategory.String()):             ChatsCategory,
+	strings.ToLower(MailboxSettingsCategory.String()):   MailboxSettingsCategory,
+	strings.ToLower(PlannerCategory.String()):           PlannerCategory,
 }
 
 func ToCategoryType(s string) CategoryType {
@@ -66,6 +70,8 @@ var catToHuman = map[CategoryType]string{
 	ChannelMessagesCategory:   "Messages",
 	ConversationPostsCategory: "Posts",
 	ChatsCategory:             "Chats",
+	MailboxSettingsCategory:   "Mailbox Settings",
+	PlannerCategory:           "Planner",
 }
 
 // HumanString produces a more human-readable string version of the category.
@@ -86,9 +92,10 @@ func (cat CategoryType) HumanString() string {
 // non-metadata paths.
 var serviceCategories = map[ServiceType]map[CategoryType]struct{}{
 	ExchangeService: {
-		EmailCategory:    {},
-		ContactsCategory: {},
-		EventsCategory:   {},
+		EmailCategory:           {},
+		ContactsCategory:        {},
+		EventsCategory:          {},
+		MailboxSettingsCategory: {},
 	},
 	OneDriveService: {
 		FilesCategory: {},
@@ -102,6 +109,7 @@ var serviceCategories = map[ServiceType]map[CategoryType]struct{}{
 		ChannelMessagesCategory:   {},
 		ConversationPostsCategory: {},
 		LibrariesCategory:         {},
+		PlannerCategory:           {},
 	},
 	TeamsChatsService: {
 		ChatsCategory: {},
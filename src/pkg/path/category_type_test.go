@@ -36,6 +36,7 @@ func (suite *CategoryTypeUnitSuite) TestToCategoryType() {
 		{input: "channelmessages", expect: 9},
 		{input: "conversationposts", expect: 10},
 		{input: "chats", expect: 11},
+		{input: "mailboxsettings", expect: 12},
 	}
 	for _, test := range table {
 		suite.Run(test.input, func() {
@@ -64,6 +65,7 @@ func (suite *CategoryTypeUnitSuite) TestHumanString() {
 		{input: 9, expect: "Messages"},
 		{input: 10, expect: "Posts"},
 		{input: 11, expect: "Chats"},
+		{input: 12, expect: "Mailbox Settings"},
 	}
 	for _, test := range table {
 		suite.Run(test.input.String(), func() {
@@ -20,11 +20,13 @@ func _() {
 	_ = x[ChannelMessagesCategory-9]
 	_ = x[ConversationPostsCategory-10]
 	_ = x[ChatsCategory-11]
+	_ = x[MailboxSettingsCategory-12]
+	_ = x[PlannerCategory-13]
 }
 
-const _CategoryType_name = "UnknownCategoryemailcontactseventsfileslistslibrariespagesdetailschannelMessagesconversationPostschats"
+const _CategoryType_name = "UnknownCategoryemailcontactseventsfileslistslibrariespagesdetailschannelMessagesconversationPostschatsmailboxSettingsplanner"
 
-var _CategoryType_index = [...]uint8{0, 15, 20, 28, 34, 39, 44, 53, 58, 65, 80, 97, 102}
+var _CategoryType_index = [...]uint8{0, 15, 20, 28, 34, 39, 44, 53, 58, 65, 80, 97, 102, 117, 124}
 
 func (i CategoryType) String() string {
 	if i < 0 || i >= CategoryType(len(_CategoryType_index)-1) {
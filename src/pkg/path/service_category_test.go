@@ -102,6 +102,14 @@ func (suite *ServiceCategoryUnitSuite) TestValidateServiceAndCategory() {
 			expectedCategory: EventsCategory,
 			check:            assert.NoError,
 		},
+		{
+			name:             "ExchangeMailboxSettings",
+			service:          ExchangeService.String(),
+			category:         MailboxSettingsCategory.String(),
+			expectedService:  ExchangeService,
+			expectedCategory: MailboxSettingsCategory,
+			check:            assert.NoError,
+		},
 		{
 			name:             "OneDriveFiles",
 			service:          OneDriveService.String(),
@@ -118,6 +126,14 @@ func (suite *ServiceCategoryUnitSuite) TestValidateServiceAndCategory() {
 			expectedCategory: LibrariesCategory,
 			check:            assert.NoError,
 		},
+		{
+			name:             "GroupsPlanner",
+			service:          GroupsService.String(),
+			category:         PlannerCategory.String(),
+			expectedService:  GroupsService,
+			expectedCategory: PlannerCategory,
+			check:            assert.NoError,
+		},
 		{
 			name:             "ChatsChats",
 			service:          TeamsChatsService.String(),
@@ -0,0 +1,170 @@
+// Package plan describes the changes that a restore or export would make,
+// without making them.
+package plan
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+// Action is the change that would be made for a single item.
+type Action string
+
+const (
+	// Create writes a new item to the destination.
+	Create Action = "create"
+	// Skip leaves an existing item untouched.
+	Skip Action = "skip"
+	// Replace overwrites an existing item.
+	Replace Action = "replace"
+	// Copy writes the item alongside an existing item of the same name.
+	Copy Action = "copy"
+)
+
+// ForRestore produces the action that the restore collision policy takes
+// on an item.
+func ForRestore(policy control.CollisionPolicy, collides bool) Action {
+	if !collides {
+		return Create
+	}
+
+	switch policy {
+	case control.Replace:
+		return Replace
+	case control.Copy:
+		return Copy
+	}
+
+	return Skip
+}
+
+// ForExport produces the action that the export collision policy takes
+// on an item.  Renamed items are reported as copies.
+func ForExport(policy control.ExportCollisionPolicy, collides bool) Action {
+	if !collides {
+		return Create
+	}
+
+	switch policy {
+	case control.ExportOverwrite:
+		return Replace
+	case control.ExportRename:
+		return Copy
+	}
+
+	return Skip
+}
+
+// Item is the planned change for a single item.
+type Item struct {
+	Action Action `json:"action"`
+	// Destination is the human-readable location that the item would be
+	// written to, including its name.
+	Destination string `json:"destination"`
+	// Size is the number of bytes that would be written.  Zero when the
+	// size isn't known ahead of time.
+	Size int64 `json:"size"`
+	// Permissions is the number of permission grants that would be
+	// applied to the item.
+	Permissions int `json:"permissions,omitempty"`
+}
+
+// MinimumPrintable reduces the item to its printable fields.
+func (i Item) MinimumPrintable() any {
+	return i
+}
+
+// Headers returns the column names used when printing the item.
+func (i Item) Headers(bool) []string {
+	return []string{"Action", "Destination", "Size", "Permissions"}
+}
+
+// Values returns the column values used when printing the item.
+func (i Item) Values(bool) []string {
+	return []string{
+		string(i.Action),
+		i.Destination,
+		humanize.Bytes(uint64(i.Size)),
+		strconv.Itoa(i.Permissions),
+	}
+}
+
+// Plan collects the planned changes of a restore or export.  It's safe
+// for concurrent use.
+type Plan struct {
+	mu    sync.Mutex
+	items []Item
+}
+
+// New produces an empty plan.
+func New() *Plan {
+	return &Plan{}
+}
+
+// Add records the planned change for an item.
+func (p *Plan) Add(item Item) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.items = append(p.items, item)
+}
+
+// Items returns the planned changes, ordered by destination.
+func (p *Plan) Items() []Item {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	items := make([]Item, len(p.items))
+	copy(items, p.items)
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Destination < items[j].Destination
+	})
+
+	return items
+}
+
+// Summary totals the planned changes.
+type Summary struct {
+	Creates  int `json:"creates"`
+	Skips    int `json:"skips"`
+	Replaces int `json:"replaces"`
+	Copies   int `json:"copies"`
+	// Bytes counts the bytes written by every item that isn't skipped.
+	Bytes int64 `json:"bytes"`
+	// Permissions counts the permission grants applied to items that
+	// aren't skipped.
+	Permissions int `json:"permissions"`
+}
+
+// Summary totals the planned changes.
+func (p *Plan) Summary() Summary {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var s Summary
+
+	for _, item := range p.items {
+		switch item.Action {
+		case Create:
+			s.Creates++
+		case Replace:
+			s.Replaces++
+		case Copy:
+			s.Copies++
+		case Skip:
+			s.Skips++
+			continue
+		}
+
+		s.Bytes += item.Size
+		s.Permissions += item.Permissions
+	}
+
+	return s
+}
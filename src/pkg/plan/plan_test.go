@@ -0,0 +1,90 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/control"
+)
+
+type PlanUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlanUnitSuite(t *testing.T) {
+	suite.Run(t, &PlanUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlanUnitSuite) TestForRestore() {
+	table := []struct {
+		policy   control.CollisionPolicy
+		collides bool
+		expect   Action
+	}{
+		{control.Skip, false, Create},
+		{control.Replace, false, Create},
+		{control.Copy, false, Create},
+		{control.Skip, true, Skip},
+		{control.Replace, true, Replace},
+		{control.Copy, true, Copy},
+		{control.CollisionPolicy("smash"), true, Skip},
+	}
+	for _, test := range table {
+		suite.Run(string(test.policy), func() {
+			assert.Equal(suite.T(), test.expect, ForRestore(test.policy, test.collides))
+		})
+	}
+}
+
+func (suite *PlanUnitSuite) TestForExport() {
+	table := []struct {
+		policy   control.ExportCollisionPolicy
+		collides bool
+		expect   Action
+	}{
+		{control.ExportSkip, false, Create},
+		{control.ExportOverwrite, false, Create},
+		{control.ExportRename, false, Create},
+		{control.ExportSkip, true, Skip},
+		{control.ExportOverwrite, true, Replace},
+		{control.ExportRename, true, Copy},
+		{control.ExportCollisionPolicy("smash"), true, Skip},
+	}
+	for _, test := range table {
+		suite.Run(string(test.policy), func() {
+			assert.Equal(suite.T(), test.expect, ForExport(test.policy, test.collides))
+		})
+	}
+}
+
+func (suite *PlanUnitSuite) TestPlan() {
+	t := suite.T()
+	p := New()
+
+	p.Add(Item{Action: Skip, Destination: "c", Size: 100, Permissions: 1})
+	p.Add(Item{Action: Create, Destination: "a", Size: 1, Permissions: 2})
+	p.Add(Item{Action: Replace, Destination: "b", Size: 10})
+	p.Add(Item{Action: Copy, Destination: "d", Size: 1000, Permissions: 3})
+
+	items := p.Items()
+
+	dests := make([]string, 0, len(items))
+	for _, item := range items {
+		dests = append(dests, item.Destination)
+	}
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, dests)
+
+	expect := Summary{
+		Creates:     1,
+		Skips:       1,
+		Replaces:    1,
+		Copies:      1,
+		Bytes:       1011,
+		Permissions: 5,
+	}
+	assert.Equal(t, expect, p.Summary())
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/repo/manifest"
@@ -53,6 +54,22 @@ type Backuper interface {
 		failOnMissing bool,
 		ids ...string,
 	) error
+	UpdateBackupLabels(
+		ctx context.Context,
+		id string,
+		set map[string]string,
+		remove []string,
+	) (*backup.Backup, error)
+	PlaceLegalHold(
+		ctx context.Context,
+		hold backup.LegalHold,
+		ids ...string,
+	) (int, error)
+	ReleaseLegalHold(
+		ctx context.Context,
+		name string,
+		ids ...string,
+	) (int, error)
 }
 
 // NewBackup generates a BackupOperation runner.
@@ -71,6 +88,10 @@ func (r repository) NewBackupWithLookup(
 	sel selectors.Selector,
 	ins idname.Cacher,
 ) (operations.BackupOperation, error) {
+	if r.targetAccount != nil {
+		return operations.BackupOperation{}, clues.New("cannot back up from a restore target account")
+	}
+
 	err := r.ConnectDataProvider(ctx, sel.PathService())
 	if err != nil {
 		return operations.BackupOperation{}, clues.Wrap(err, "connecting to m365")
@@ -173,6 +194,114 @@ func backupsByTag(
 	return res, nil
 }
 
+// UpdateBackupLabels adds or replaces the labels in set on the backup,
+// then removes the labels in remove.  Returns the updated backup.
+func (r repository) UpdateBackupLabels(
+	ctx context.Context,
+	id string,
+	set map[string]string,
+	remove []string,
+) (*backup.Backup, error) {
+	return updateBackupLabels(ctx, store.NewWrapper(r.modelStore), id, set, remove)
+}
+
+// updateBackupLabels handles the processing for UpdateBackupLabels.
+func updateBackupLabels(
+	ctx context.Context,
+	sw store.BackupGetterUpdater,
+	id string,
+	set map[string]string,
+	remove []string,
+) (*backup.Backup, error) {
+	ctx = clues.Add(ctx, "backup_id", id)
+
+	b, err := sw.GetBackup(ctx, model.StableID(id))
+	if err != nil {
+		return nil, errWrapper(err)
+	}
+
+	if err := b.UpdateLabels(set, remove); err != nil {
+		return nil, clues.StackWC(ctx, err)
+	}
+
+	if err := sw.UpdateBackup(ctx, b); err != nil {
+		return nil, clues.Stack(err)
+	}
+
+	return b, nil
+}
+
+// PlaceLegalHold places the hold on each of the backups, blocking their
+// deletion until the hold is released.  Backups that already have a hold
+// with the same name are left unchanged.  Returns the number of backups
+// that were placed on hold.
+func (r repository) PlaceLegalHold(
+	ctx context.Context,
+	hold backup.LegalHold,
+	ids ...string,
+) (int, error) {
+	if err := hold.Validate(); err != nil {
+		return 0, clues.StackWC(ctx, err)
+	}
+
+	if hold.CreatedAt.IsZero() {
+		hold.CreatedAt = time.Now()
+	}
+
+	return updateLegalHolds(
+		ctx,
+		store.NewWrapper(r.modelStore),
+		func(b *backup.Backup) bool { return b.PlaceLegalHold(hold) },
+		ids...)
+}
+
+// ReleaseLegalHold releases the named hold from each of the backups.
+// Backups without the hold are left unchanged.  Returns the number of
+// backups the hold was released from.
+func (r repository) ReleaseLegalHold(
+	ctx context.Context,
+	name string,
+	ids ...string,
+) (int, error) {
+	return updateLegalHolds(
+		ctx,
+		store.NewWrapper(r.modelStore),
+		func(b *backup.Backup) bool { return b.ReleaseLegalHold(name) },
+		ids...)
+}
+
+// updateLegalHolds applies the change to each backup, and stores the
+// backups that changed.  Returns the number of backups that changed.
+func updateLegalHolds(
+	ctx context.Context,
+	sw store.BackupGetterUpdater,
+	change func(*backup.Backup) bool,
+	ids ...string,
+) (int, error) {
+	var updated int
+
+	for _, id := range ids {
+		ictx := clues.Add(ctx, "backup_id", id)
+
+		b, err := sw.GetBackup(ictx, model.StableID(id))
+		if err != nil {
+			return updated, clues.StackWC(ictx, errWrapper(err))
+		}
+
+		if !change(b) {
+			continue
+		}
+
+		if err := sw.UpdateBackup(ictx, b); err != nil {
+			return updated, clues.Stack(err)
+		}
+
+		updated++
+	}
+
+	return updated, nil
+}
+
 // BackupDetails returns the specified backup.Details
 func (r repository) GetBackupDetails(
 	ctx context.Context,
@@ -302,6 +431,8 @@ func getBackupErrors(
 // If failOnMissing is true then returns an error if a backup model can't be
 // found. Otherwise ignores missing backup models.
 //
+// Returns an error if any of the backups are under legal hold.
+//
 // Missing models or snapshots during the actual deletion do not cause errors.
 //
 // All backups are delete as an atomic unit so any failures will result in no
@@ -338,6 +469,10 @@ func deleteBackups(
 			return clues.StackWC(ctx, errWrapper(err)).With("delete_backup_id", id)
 		}
 
+		if b.UnderLegalHold() {
+			return clues.StackWC(ctx, ErrorBackupLegalHold).With("delete_backup_id", id)
+		}
+
 		toDelete = append(toDelete, b.ModelStoreID)
 
 		if len(b.SnapshotID) > 0 {
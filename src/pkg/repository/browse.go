@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/operations"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+type Browser interface {
+	NewBrowse(
+		ctx context.Context,
+		backupID string,
+		exportCfg control.ExportConfig,
+	) (operations.BrowseOperation, error)
+}
+
+// NewBrowse generates a browseOperation runner, which opens the items of
+// a single backup one at a time.
+func (r repository) NewBrowse(
+	ctx context.Context,
+	backupID string,
+	exportCfg control.ExportConfig,
+) (operations.BrowseOperation, error) {
+	bup, err := r.Backup(ctx, backupID)
+	if err != nil {
+		return operations.BrowseOperation{}, clues.Stack(err)
+	}
+
+	handler, err := r.Provider.NewServiceHandler(bup.Selector.PathService())
+	if err != nil {
+		return operations.BrowseOperation{}, clues.Stack(err)
+	}
+
+	return operations.NewBrowseOperation(
+		ctx,
+		r.Opts,
+		r.dataLayer,
+		store.NewWrapper(r.modelStore),
+		handler,
+		r.Account,
+		model.StableID(backupID),
+		exportCfg,
+		r.Bus)
+}
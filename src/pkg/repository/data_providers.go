@@ -93,9 +93,14 @@ func connectToM365(
 	progressMessage := observe.MessageWithCompletion(ctx, observe.DefaultCfg(), "Connecting to M365")
 	defer close(progressMessage)
 
+	acct := r.Account
+	if r.targetAccount != nil {
+		acct = *r.targetAccount
+	}
+
 	ctrl, err := m365.NewController(
 		ctx,
-		r.Account,
+		acct,
 		pst,
 		r.Opts,
 		r.counter)
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/operations"
+	"github.com/alcionai/corso/src/pkg/control"
+	"github.com/alcionai/corso/src/pkg/count"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type Importer interface {
+	NewImport(
+		ctx context.Context,
+		protectedResource, source string,
+		restoreCfg control.RestoreConfig,
+	) (operations.ImportOperation, error)
+}
+
+// NewImport generates an importOperation runner.  Imports upload local
+// eml, ics, and vcf files into an exchange mailbox.
+func (r repository) NewImport(
+	ctx context.Context,
+	protectedResource, source string,
+	restoreCfg control.RestoreConfig,
+) (operations.ImportOperation, error) {
+	handler, err := r.Provider.NewServiceHandler(path.ExchangeService)
+	if err != nil {
+		return operations.ImportOperation{}, clues.Stack(err)
+	}
+
+	return operations.NewImportOperation(
+		ctx,
+		r.Opts,
+		handler,
+		r.Account,
+		protectedResource,
+		source,
+		restoreCfg,
+		r.Bus,
+		count.New())
+}
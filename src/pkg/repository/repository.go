@@ -31,6 +31,7 @@ const NewRepoID = ""
 var (
 	ErrorRepoAlreadyExists = clues.New("a repository was already initialized with that configuration")
 	ErrorBackupNotFound    = clues.New("no backup exists with that id")
+	ErrorBackupLegalHold   = clues.New("backup is under legal hold")
 )
 
 type Repositoryer interface {
@@ -38,7 +39,11 @@ type Repositoryer interface {
 	BackupGetter
 	Restorer
 	Exporter
+	Importer
+	Browser
 	Debugger
+	UsageReporter
+	StatusReporter
 	DataProviderConnector
 
 	Initialize(
@@ -79,6 +84,10 @@ type repository struct {
 	Opts     control.Options
 	Provider DataProvider // the client controller used for external user data CRUD
 
+	// when set, the data provider connects to this account instead of the
+	// one that created the backups.  Used to restore into another tenant.
+	targetAccount *account.Account
+
 	counter    *count.Bus
 	Bus        events.Eventer
 	dataLayer  *kopia.Wrapper
@@ -173,6 +182,11 @@ type ConnConfig struct {
 	// use for its connection pattern.  Leave empty
 	// to skip the provider connection.
 	Service path.ServiceType
+	// TargetAccount, when provided, is the m365 account the data
+	// provider connects to, in place of the repository's account.
+	// Backups are still read using the repository's account, which
+	// allows restoring data into another tenant.  Optional.
+	TargetAccount *account.Account
 }
 
 // Connect will:
@@ -187,6 +201,8 @@ func (r *repository) Connect(ctx context.Context, cfg ConnConfig) (err error) {
 		}
 	}()
 
+	r.targetAccount = cfg.TargetAccount
+
 	if err := r.ConnectDataProvider(ctx, cfg.Service); err != nil {
 		return clues.Stack(err)
 	}
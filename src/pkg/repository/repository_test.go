@@ -145,6 +145,31 @@ func (suite *RepositoryUnitSuite) TestConnect() {
 	}
 }
 
+func (suite *RepositoryUnitSuite) TestNewBackup_targetAccount() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	acct := tconfig.NewFakeM365Account(t)
+
+	st, err := storage.NewStorage(storage.ProviderUnknown)
+	require.NoError(t, err, clues.ToCore(err))
+
+	r, err := New(
+		ctx,
+		acct,
+		st,
+		control.DefaultOptions(),
+		NewRepoID)
+	require.NoError(t, err, clues.ToCore(err))
+
+	r.targetAccount = &acct
+
+	_, err = r.NewBackup(ctx, selectors.NewExchangeBackup([]string{"user"}).Selector)
+	assert.Error(t, err, "backups never use the restore target account", clues.ToCore(err))
+}
+
 // ---------------
 // integration tests
 // ---------------
@@ -137,6 +137,145 @@ func (suite *RepositoryBackupsUnitSuite) TestGetBackup() {
 	}
 }
 
+func (suite *RepositoryBackupsUnitSuite) TestUpdateBackupLabels() {
+	table := []struct {
+		name         string
+		getErr       error
+		set          map[string]string
+		remove       []string
+		expectErr    assert.ErrorAssertionFunc
+		expectLabels map[string]string
+	}{
+		{
+			name:         "set and remove",
+			set:          map[string]string{"stage": "pre-migration"},
+			remove:       []string{"dept"},
+			expectErr:    assert.NoError,
+			expectLabels: map[string]string{"stage": "pre-migration"},
+		},
+		{
+			name:      "invalid key",
+			remove:    []string{"bad key"},
+			expectErr: assert.Error,
+		},
+		{
+			name:      "missing backup",
+			getErr:    data.ErrNotFound,
+			set:       map[string]string{"stage": "pre-migration"},
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			bup := &backup.Backup{
+				BaseModel: model.BaseModel{
+					ID:           model.StableID(uuid.NewString()),
+					ModelStoreID: manifest.ID(uuid.NewString()),
+					Tags:         map[string]string{backup.LabelTag("dept"): "hr"},
+				},
+			}
+
+			sw := store.NewWrapper(mock.NewModelStoreMock(bup, test.getErr))
+
+			result, err := updateBackupLabels(ctx, sw, string(bup.ID), test.set, test.remove)
+			test.expectErr(t, err, clues.ToCore(err))
+
+			if err != nil {
+				return
+			}
+
+			assert.Equal(t, test.expectLabels, result.Labels())
+
+			stored, err := sw.GetBackup(ctx, bup.ID)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectLabels, stored.Labels())
+		})
+	}
+}
+
+func (suite *RepositoryBackupsUnitSuite) TestUpdateLegalHolds() {
+	hold := backup.LegalHold{Name: "case-1234", Reason: "audit"}
+
+	table := []struct {
+		name          string
+		holds         []backup.LegalHold
+		getErr        error
+		change        func(*backup.Backup) bool
+		expectErr     assert.ErrorAssertionFunc
+		expectUpdated int
+		expectHeld    bool
+	}{
+		{
+			name:          "place",
+			change:        func(b *backup.Backup) bool { return b.PlaceLegalHold(hold) },
+			expectErr:     assert.NoError,
+			expectUpdated: 1,
+			expectHeld:    true,
+		},
+		{
+			name:       "place existing",
+			holds:      []backup.LegalHold{hold},
+			change:     func(b *backup.Backup) bool { return b.PlaceLegalHold(hold) },
+			expectErr:  assert.NoError,
+			expectHeld: true,
+		},
+		{
+			name:          "release",
+			holds:         []backup.LegalHold{hold},
+			change:        func(b *backup.Backup) bool { return b.ReleaseLegalHold(hold.Name) },
+			expectErr:     assert.NoError,
+			expectUpdated: 1,
+		},
+		{
+			name:      "release missing",
+			change:    func(b *backup.Backup) bool { return b.ReleaseLegalHold(hold.Name) },
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "missing backup",
+			getErr:    data.ErrNotFound,
+			change:    func(b *backup.Backup) bool { return b.PlaceLegalHold(hold) },
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			bup := &backup.Backup{
+				BaseModel: model.BaseModel{
+					ID:           model.StableID(uuid.NewString()),
+					ModelStoreID: manifest.ID(uuid.NewString()),
+				},
+				LegalHolds: test.holds,
+			}
+
+			sw := store.NewWrapper(mock.NewModelStoreMock(bup, test.getErr))
+
+			updated, err := updateLegalHolds(ctx, sw, test.change, string(bup.ID))
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectUpdated, updated)
+
+			if err != nil {
+				assert.ErrorIs(t, err, ErrorBackupNotFound, clues.ToCore(err))
+				return
+			}
+
+			stored, err := sw.GetBackup(ctx, bup.ID)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expectHeld, stored.UnderLegalHold())
+		})
+	}
+}
+
 func (suite *RepositoryBackupsUnitSuite) TestBackupsByTag() {
 	unlabeled1 := &backup.Backup{
 		BaseModel: model.BaseModel{
@@ -406,6 +545,16 @@ func (suite *RepositoryBackupsUnitSuite) TestDeleteBackups() {
 		SnapshotID: "nssid-bup-dsid",
 	}
 
+	bupHeld := &backup.Backup{
+		BaseModel: model.BaseModel{
+			ID:           model.StableID("held-bup-id"),
+			ModelStoreID: manifest.ID("held-bup-msid"),
+		},
+		SnapshotID:    "held-bup-dsid",
+		StreamStoreID: "held-bup-ssid",
+		LegalHolds:    []backup.LegalHold{{Name: "case-1234"}},
+	}
+
 	table := []struct {
 		name          string
 		inputIDs      []model.StableID
@@ -613,6 +762,24 @@ func (suite *RepositoryBackupsUnitSuite) TestDeleteBackups() {
 				assert.ErrorIs(t, result, ErrorBackupNotFound, clues.ToCore(result))
 			},
 		},
+		{
+			name: "MultipleBackups LegalHold",
+			inputIDs: []model.StableID{
+				bup.ID,
+				bupHeld.ID,
+			},
+			gets: []getRes{
+				{bup: bup},
+				{bup: bupHeld},
+			},
+			expectGets: []model.StableID{
+				bup.ID,
+				bupHeld.ID,
+			},
+			expectErr: func(t *testing.T, result error) {
+				assert.ErrorIs(t, result, ErrorBackupLegalHold, clues.ToCore(result))
+			},
+		},
 		{
 			name: "MultipleBackups GetError NoFailOnMissing",
 			inputIDs: []model.StableID{
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/store"
+)
+
+// maintenance is considered overdue once it hasn't run for this long.
+const (
+	completeMaintenanceOverdue = 7 * 24 * time.Hour
+	metadataMaintenanceOverdue = 24 * time.Hour
+)
+
+type StatusReporter interface {
+	Status(ctx context.Context) (Status, error)
+}
+
+// MaintenanceStatus describes when maintenance last ran, and who's
+// allowed to run it.
+type MaintenanceStatus struct {
+	// Owner is the user@host that runs maintenance.
+	Owner string `json:"owner"`
+	// LastMetadata and LastComplete are the start times of the most recent
+	// metadata and complete maintenance, or nil if they've never run.
+	LastMetadata *time.Time `json:"lastMetadata,omitempty"`
+	LastComplete *time.Time `json:"lastComplete,omitempty"`
+	// FailedTasks lists the maintenance tasks whose most recent run failed.
+	FailedTasks []string `json:"failedTasks,omitempty"`
+}
+
+// RetentionStatus describes the object lock configuration of the storage.
+type RetentionStatus struct {
+	// Mode is the object lock mode, or "none" if object locking is disabled.
+	Mode              string        `json:"mode"`
+	Period            time.Duration `json:"period,omitempty"`
+	ExtendObjectLocks bool          `json:"extendObjectLocks"`
+}
+
+// Status describes the state of the repository.
+type Status struct {
+	ID              string `json:"id"`
+	Version         string `json:"version"`
+	FormatVersion   int    `json:"formatVersion"`
+	StorageProvider string `json:"storageProvider"`
+	StorageLocation string `json:"storageLocation"`
+	Compressor      string `json:"compressor"`
+
+	Retention        RetentionStatus   `json:"retention"`
+	MinEpochDuration time.Duration     `json:"minEpochDuration"`
+	Maintenance      MaintenanceStatus `json:"maintenance"`
+
+	// BackupsByService counts the backups of each service.
+	BackupsByService map[string]int `json:"backupsByService"`
+	// OrphanedItems is the number of models and snapshots from incomplete
+	// or orphaned backups that the next complete maintenance will delete.
+	OrphanedItems int `json:"orphanedItems"`
+
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// MinimumPrintable reduces the status to its printable fields.
+func (s Status) MinimumPrintable() any {
+	return s
+}
+
+// Headers returns the column names used when printing the status summary.
+func (s Status) Headers(skipID bool) []string {
+	hs := []string{"ID", "Provider", "Location", "Backups", "Warnings"}
+
+	if skipID {
+		hs = hs[1:]
+	}
+
+	return hs
+}
+
+// Values returns the column values used when printing the status summary.
+func (s Status) Values(skipID bool) []string {
+	var backups int
+
+	for _, n := range s.BackupsByService {
+		backups += n
+	}
+
+	vs := []string{
+		s.ID,
+		s.StorageProvider,
+		s.StorageLocation,
+		strconv.Itoa(backups),
+		strconv.Itoa(len(s.Warnings)),
+	}
+
+	if skipID {
+		vs = vs[1:]
+	}
+
+	return vs
+}
+
+// Status reports the configuration and maintenance state of the repository,
+// along with warnings about anything that needs attention.
+func (r repository) Status(ctx context.Context) (Status, error) {
+	s := Status{
+		ID:               r.ID,
+		Version:          r.Version,
+		StorageProvider:  r.Storage.Provider.String(),
+		BackupsByService: map[string]int{},
+	}
+
+	loc, err := r.Storage.Location()
+	if err != nil {
+		return s, clues.Wrap(err, "describing storage location")
+	}
+
+	s.StorageLocation = loc
+
+	errs := fault.New(false)
+
+	rs, err := r.dataLayer.RepoStatus(ctx, r.modelStore, errs)
+	if err != nil {
+		return s, clues.Wrap(err, "getting repository status")
+	}
+
+	bups, err := store.NewWrapper(r.modelStore).GetBackups(ctx)
+	if err != nil {
+		return s, clues.Wrap(err, "listing backups")
+	}
+
+	for _, b := range bups {
+		s.BackupsByService[b.Selector.PathService().HumanString()]++
+	}
+
+	populateStatus(&s, rs, errs.Alerts(), time.Now())
+
+	return s, nil
+}
+
+// populateStatus fills in the status from the kopia repository status, and
+// adds warnings for alerts, overdue maintenance, and data awaiting cleanup.
+func populateStatus(
+	s *Status,
+	rs *kopia.RepoStatus,
+	alerts []fault.Alert,
+	now time.Time,
+) {
+	s.FormatVersion = rs.FormatVersion
+	s.Compressor = rs.Compressor
+	s.MinEpochDuration = rs.MinEpochDuration
+	s.OrphanedItems = rs.OrphanedItems
+
+	s.Retention = RetentionStatus{
+		Mode:              "none",
+		Period:            rs.RetentionPeriod,
+		ExtendObjectLocks: rs.ExtendObjectLocks,
+	}
+
+	if len(rs.RetentionMode) > 0 {
+		s.Retention.Mode = rs.RetentionMode
+	}
+
+	s.Maintenance = MaintenanceStatus{
+		Owner:       rs.MaintenanceOwner,
+		FailedTasks: rs.FailedMaintenanceTasks,
+	}
+
+	if !rs.LastQuickMaintenance.IsZero() {
+		s.Maintenance.LastMetadata = &rs.LastQuickMaintenance
+	}
+
+	if !rs.LastFullMaintenance.IsZero() {
+		s.Maintenance.LastComplete = &rs.LastFullMaintenance
+	}
+
+	for _, a := range alerts {
+		s.Warnings = append(s.Warnings, a.Item.Name+": "+a.Message)
+	}
+
+	s.Warnings = append(
+		s.Warnings,
+		overdueWarning("complete", s.Maintenance.LastComplete, completeMaintenanceOverdue, now)...)
+	s.Warnings = append(
+		s.Warnings,
+		overdueWarning("metadata", s.Maintenance.LastMetadata, metadataMaintenanceOverdue, now)...)
+
+	for _, task := range s.Maintenance.FailedTasks {
+		s.Warnings = append(s.Warnings, "the last run of maintenance task "+task+" failed")
+	}
+
+	if len(rs.MaintenanceOwner) > 0 && rs.MaintenanceOwner != rs.CurrentOwner {
+		s.Warnings = append(
+			s.Warnings,
+			fmt.Sprintf(
+				"maintenance is owned by %s; run it from that machine or use --force",
+				rs.MaintenanceOwner))
+	}
+
+	if s.OrphanedItems > 0 {
+		s.Warnings = append(
+			s.Warnings,
+			fmt.Sprintf(
+				"%d items from incomplete or orphaned backups are awaiting cleanup by complete maintenance",
+				s.OrphanedItems))
+	}
+}
+
+// overdueWarning produces a warning if maintenance of the given type has
+// never run, or hasn't run within the overdue duration.
+func overdueWarning(
+	mode string,
+	last *time.Time,
+	overdue time.Duration,
+	now time.Time,
+) []string {
+	if last == nil {
+		return []string{mode + " maintenance has never run"}
+	}
+
+	if now.Sub(*last) > overdue {
+		return []string{fmt.Sprintf(
+			"%s maintenance is overdue; it last ran %s ago",
+			mode,
+			now.Sub(*last).Round(time.Hour))}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/fault"
+)
+
+type RepositoryStatusUnitSuite struct {
+	tester.Suite
+}
+
+func TestRepositoryStatusUnitSuite(t *testing.T) {
+	suite.Run(t, &RepositoryStatusUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *RepositoryStatusUnitSuite) TestPopulateStatus() {
+	now := time.Now()
+
+	table := []struct {
+		name            string
+		rs              kopia.RepoStatus
+		alerts          []fault.Alert
+		expectRetention string
+		expectWarnings  []string
+	}{
+		{
+			name: "healthy",
+			rs: kopia.RepoStatus{
+				MaintenanceOwner:     "corso@host",
+				CurrentOwner:         "corso@host",
+				LastQuickMaintenance: now.Add(-time.Hour),
+				LastFullMaintenance:  now.Add(-24 * time.Hour),
+			},
+			expectRetention: "none",
+		},
+		{
+			name: "never maintained",
+			rs: kopia.RepoStatus{
+				RetentionMode:   "GOVERNANCE",
+				RetentionPeriod: 48 * time.Hour,
+				CurrentOwner:    "corso@host",
+			},
+			expectRetention: "GOVERNANCE",
+			expectWarnings: []string{
+				"complete maintenance has never run",
+				"metadata maintenance has never run",
+			},
+		},
+		{
+			name: "needs attention",
+			rs: kopia.RepoStatus{
+				MaintenanceOwner:       "other@host",
+				CurrentOwner:           "corso@host",
+				LastQuickMaintenance:   now.Add(-48 * time.Hour),
+				LastFullMaintenance:    now.Add(-10 * 24 * time.Hour),
+				FailedMaintenanceTasks: []string{"cleanup-logs"},
+				OrphanedItems:          3,
+			},
+			alerts: []fault.Alert{
+				*fault.NewAlert("unexpected compressor", "ns", "compressor", "kopia-global-policy", nil),
+			},
+			expectRetention: "none",
+			expectWarnings: []string{
+				"kopia-global-policy: unexpected compressor",
+				"complete maintenance is overdue; it last ran 240h0m0s ago",
+				"metadata maintenance is overdue; it last ran 48h0m0s ago",
+				"the last run of maintenance task cleanup-logs failed",
+				"maintenance is owned by other@host; run it from that machine or use --force",
+				"3 items from incomplete or orphaned backups are awaiting cleanup by complete maintenance",
+			},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+			s := Status{}
+
+			populateStatus(&s, &test.rs, test.alerts, now)
+
+			assert.Equal(t, test.expectRetention, s.Retention.Mode)
+			assert.Equal(t, test.rs.RetentionPeriod, s.Retention.Period)
+			assert.Equal(t, test.rs.OrphanedItems, s.OrphanedItems)
+			assert.Equal(t, test.expectWarnings, s.Warnings)
+
+			if test.rs.LastFullMaintenance.IsZero() {
+				assert.Nil(t, s.Maintenance.LastComplete)
+			} else {
+				assert.Equal(t, test.rs.LastFullMaintenance, *s.Maintenance.LastComplete)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/store"
+	"github.com/alcionai/corso/src/pkg/usage"
+)
+
+type UsageReporter interface {
+	StorageUsage(ctx context.Context) (usage.Report, error)
+}
+
+// StorageUsage reports the storage consumed by the repository, along with
+// the logical, deduplicated, and unique size of every backup, protected
+// resource, and service.  Producing the report walks every backup, so it
+// can take a while on large repositories.
+func (r repository) StorageUsage(ctx context.Context) (usage.Report, error) {
+	bups, err := store.NewWrapper(r.modelStore).GetBackups(ctx)
+	if err != nil {
+		return usage.Report{}, clues.Wrap(err, "listing backups")
+	}
+
+	ids := []string{}
+
+	for _, b := range bups {
+		ids = append(ids, usage.SnapshotIDs(b)...)
+	}
+
+	su, err := r.dataLayer.StorageUsage(ctx, ids...)
+	if err != nil {
+		return usage.Report{}, clues.Wrap(err, "gathering storage usage")
+	}
+
+	return usage.NewReport(bups, su), nil
+}
@@ -0,0 +1,352 @@
+package search
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/logger"
+	"github.com/alcionai/corso/src/pkg/path"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph/metadata"
+)
+
+// IndexVersion is incremented any time the format of the index, or the
+// way text gets tokenized, changes.  Indexes built with a different
+// version are discarded and rebuilt.
+const IndexVersion = 1
+
+const (
+	minTermLen = 2
+	maxTermLen = 64
+)
+
+// Item identifies a single indexed item within a backup.
+type Item struct {
+	ItemRef     string `json:"itemRef"`
+	ShortRef    string `json:"shortRef,omitempty"`
+	LocationRef string `json:"locationRef,omitempty"`
+	Name        string `json:"name,omitempty"`
+}
+
+// Index is an inverted index over the text contents of the items in a
+// single backup.
+type Index struct {
+	Version  int    `json:"version"`
+	BackupID string `json:"backupID"`
+	Service  string `json:"service"`
+	Items    []Item `json:"items"`
+	// Terms maps each term to the (ascending) positions of the items
+	// in Items that contain the term.
+	Terms map[string][]int `json:"terms"`
+}
+
+// Search returns all items in the index that contain every term in the
+// query.  A term ending in '*' matches any term with that prefix.
+func (idx *Index) Search(query string) []Item {
+	terms := ParseQuery(query)
+	if idx == nil || len(terms) == 0 {
+		return nil
+	}
+
+	var matched []int
+
+	for i, term := range terms {
+		postings := idx.postings(term)
+
+		if i == 0 {
+			matched = postings
+		} else {
+			matched = intersect(matched, postings)
+		}
+
+		if len(matched) == 0 {
+			return nil
+		}
+	}
+
+	items := make([]Item, 0, len(matched))
+	for _, pos := range matched {
+		items = append(items, idx.Items[pos])
+	}
+
+	return items
+}
+
+func (idx *Index) postings(term string) []int {
+	prefix, isPrefix := strings.CutSuffix(term, "*")
+	if !isPrefix {
+		return idx.Terms[term]
+	}
+
+	set := map[int]struct{}{}
+
+	for t, ps := range idx.Terms {
+		if !strings.HasPrefix(t, prefix) {
+			continue
+		}
+
+		for _, p := range ps {
+			set[p] = struct{}{}
+		}
+	}
+
+	union := make([]int, 0, len(set))
+	for p := range set {
+		union = append(union, p)
+	}
+
+	sort.Ints(union)
+
+	return union
+}
+
+// intersect returns the values found in both of the sorted slices.
+func intersect(a, b []int) []int {
+	var (
+		result = []int{}
+		i, j   int
+	)
+
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+
+	return result
+}
+
+// ParseQuery splits the query into the set of terms which must all be
+// present in an item for it to match.  Quotes are ignored, so a quoted
+// phrase matches items containing each word in the phrase.
+func ParseQuery(query string) []string {
+	var (
+		terms = []string{}
+		seen  = map[string]struct{}{}
+	)
+
+	for _, f := range strings.Fields(strings.ToLower(query)) {
+		prefix := strings.HasSuffix(f, "*")
+
+		toks := tokenize(f)
+		for i, t := range toks {
+			if prefix && i == len(toks)-1 {
+				t += "*"
+			}
+
+			if _, ok := seen[t]; ok {
+				continue
+			}
+
+			seen[t] = struct{}{}
+			terms = append(terms, t)
+		}
+	}
+
+	return terms
+}
+
+// tokenize splits the text into lower-cased terms of letters and digits.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		if len(f) < minTermLen || len(f) > maxTermLen {
+			continue
+		}
+
+		terms = append(terms, f)
+	}
+
+	return terms
+}
+
+// ---------------------------------------------------------------------------
+// building
+// ---------------------------------------------------------------------------
+
+// Builder collects the terms from each item in a backup into an Index.
+type Builder struct {
+	idx     *Index
+	entries map[string]details.Entry
+}
+
+// NewBuilder produces a builder for the backup.  The backup details are
+// used to decorate the indexed items with their location and name.
+func NewBuilder(
+	backupID string,
+	service path.ServiceType,
+	deets *details.Details,
+) *Builder {
+	b := &Builder{
+		idx: &Index{
+			Version:  IndexVersion,
+			BackupID: backupID,
+			Service:  service.String(),
+			Items:    []Item{},
+			Terms:    map[string][]int{},
+		},
+		entries: map[string]details.Entry{},
+	}
+
+	if deets == nil {
+		return b
+	}
+
+	for _, ent := range deets.Items() {
+		if _, ok := b.entries[ent.ItemRef]; !ok && len(ent.ItemRef) > 0 {
+			b.entries[ent.ItemRef] = *ent
+		}
+	}
+
+	return b
+}
+
+// Add reads the item body and indexes its text.  The caller retains
+// ownership of the reader.
+func (b *Builder) Add(
+	itemID, name, location string,
+	body io.Reader,
+) error {
+	content, err := io.ReadAll(io.LimitReader(body, maxIndexedBytes))
+	if err != nil {
+		return clues.Wrap(err, "reading item")
+	}
+
+	item := Item{
+		ItemRef:     strings.TrimSuffix(itemID, metadata.DataFileSuffix),
+		LocationRef: location,
+		Name:        name,
+	}
+
+	if ent, ok := b.entries[item.ItemRef]; ok {
+		item.ShortRef = ent.ShortRef
+		item.LocationRef = ent.LocationRef
+
+		if n := entryName(ent); len(n) > 0 {
+			item.Name = n
+		}
+	}
+
+	pos := len(b.idx.Items)
+	b.idx.Items = append(b.idx.Items, item)
+
+	// item names are searchable along with the item contents.
+	text := item.Name + " " + extractText(name, content)
+
+	for _, term := range tokenize(text) {
+		ps := b.idx.Terms[term]
+
+		if len(ps) > 0 && ps[len(ps)-1] == pos {
+			continue
+		}
+
+		b.idx.Terms[term] = append(ps, pos)
+	}
+
+	return nil
+}
+
+// Index returns the index built so far.
+func (b *Builder) Index() *Index {
+	return b.idx
+}
+
+// BuildIndex indexes the text of every item in the export collections.
+// Failures to read individual items are recorded in errs and the item
+// is left out of the index.
+func BuildIndex(
+	ctx context.Context,
+	backupID string,
+	service path.ServiceType,
+	deets *details.Details,
+	colls []export.Collectioner,
+	errs *fault.Bus,
+) (*Index, error) {
+	var (
+		el      = errs.Local()
+		b       = NewBuilder(backupID, service, deets)
+		counted int
+	)
+
+	ctx = clues.Add(ctx, "backup_id", backupID)
+
+	for _, col := range colls {
+		if el.Failure() != nil {
+			break
+		}
+
+		ictx := clues.Add(ctx, "collection_path", col.BasePath())
+
+		for item := range col.Items(ictx) {
+			if item.Error != nil {
+				el.AddRecoverable(ictx, clues.Wrap(item.Error, "getting item"))
+				continue
+			}
+
+			err := b.Add(item.ID, item.Name, col.BasePath(), item.Body)
+			item.Body.Close()
+
+			if err != nil {
+				el.AddRecoverable(ictx, clues.Stack(err).With("item_id", item.ID))
+				continue
+			}
+
+			counted++
+		}
+	}
+
+	logger.Ctx(ctx).Infow(
+		"completed building search index",
+		"count_items", counted,
+		"count_terms", len(b.idx.Terms))
+
+	return b.Index(), el.Failure()
+}
+
+// entryName produces the human readable name of the item described by
+// the details entry.
+func entryName(ent details.Entry) string {
+	switch {
+	case ent.Exchange != nil:
+		if len(ent.Exchange.Subject) > 0 {
+			return ent.Exchange.Subject
+		}
+
+		return ent.Exchange.ContactName
+	case ent.OneDrive != nil:
+		return ent.OneDrive.ItemName
+	case ent.SharePoint != nil:
+		return ent.SharePoint.ItemName
+	case ent.Groups != nil:
+		for _, n := range []string{
+			ent.Groups.ItemName,
+			ent.Groups.Message.Subject,
+			ent.Groups.Post.Topic,
+		} {
+			if len(n) > 0 {
+				return n
+			}
+		}
+	}
+
+	return ""
+}
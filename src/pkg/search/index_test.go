@@ -0,0 +1,220 @@
+package search
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup/details"
+	"github.com/alcionai/corso/src/pkg/export"
+	"github.com/alcionai/corso/src/pkg/fault"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type IndexUnitSuite struct {
+	tester.Suite
+}
+
+func TestIndexUnitSuite(t *testing.T) {
+	suite.Run(t, &IndexUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *IndexUnitSuite) TestParseQuery() {
+	table := []struct {
+		name   string
+		query  string
+		expect []string
+	}{
+		{
+			name:   "empty",
+			query:  "  ",
+			expect: []string{},
+		},
+		{
+			name:   "words",
+			query:  "Project X-Ray budget",
+			expect: []string{"project", "ray", "budget"},
+		},
+		{
+			name:   "quoted phrase",
+			query:  `"project falcon" q3`,
+			expect: []string{"project", "falcon", "q3"},
+		},
+		{
+			name:   "duplicates",
+			query:  "budget BUDGET",
+			expect: []string{"budget"},
+		},
+		{
+			name:   "prefix",
+			query:  "falc* budget",
+			expect: []string{"falc*", "budget"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			assert.Equal(suite.T(), test.expect, ParseQuery(test.query))
+		})
+	}
+}
+
+func (suite *IndexUnitSuite) TestSearch() {
+	t := suite.T()
+
+	deets := &details.Details{}
+	deets.Entries = []details.Entry{
+		{
+			ShortRef:    "sr1",
+			LocationRef: "Inbox",
+			ItemRef:     "mail1",
+			ItemInfo: details.ItemInfo{
+				Exchange: &details.ExchangeInfo{
+					ItemType: details.ExchangeMail,
+					Subject:  "quarterly numbers",
+				},
+			},
+		},
+		{
+			ShortRef:    "sr2",
+			LocationRef: "Documents/Plans",
+			ItemRef:     "file1",
+			ItemInfo: details.ItemInfo{
+				OneDrive: &details.OneDriveInfo{
+					ItemType: details.OneDriveItem,
+					ItemName: "plan.txt",
+				},
+			},
+		},
+	}
+
+	b := NewBuilder("bid", path.ExchangeService, deets)
+
+	err := b.Add("mail1", "mail1.eml", "Inbox", strings.NewReader("Subject: hi\n\nProject Falcon launches soon"))
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = b.Add("file1.data", "plan.txt", "Documents/Plans", strings.NewReader("falconry club budget"))
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = b.Add("orphan", "notes.txt", "Other", strings.NewReader("project budget"))
+	require.NoError(t, err, clues.ToCore(err))
+
+	idx := b.Index()
+	assert.Equal(t, "bid", idx.BackupID)
+	assert.Equal(t, IndexVersion, idx.Version)
+	require.Len(t, idx.Items, 3)
+
+	// details decorate the indexed items
+	assert.Equal(t, Item{
+		ItemRef:     "mail1",
+		ShortRef:    "sr1",
+		LocationRef: "Inbox",
+		Name:        "quarterly numbers",
+	}, idx.Items[0])
+	assert.Equal(t, "file1", idx.Items[1].ItemRef)
+	assert.Equal(t, "plan.txt", idx.Items[1].Name)
+	assert.Equal(t, Item{
+		ItemRef:     "orphan",
+		LocationRef: "Other",
+		Name:        "notes.txt",
+	}, idx.Items[2])
+
+	refs := func(items []Item) []string {
+		rs := []string{}
+		for _, i := range items {
+			rs = append(rs, i.ItemRef)
+		}
+
+		return rs
+	}
+
+	table := []struct {
+		query  string
+		expect []string
+	}{
+		{"falcon", []string{"mail1"}},
+		{"FALCON project", []string{"mail1"}},
+		{"falcon*", []string{"mail1", "file1"}},
+		{"budget", []string{"file1", "orphan"}},
+		{"project budget", []string{"orphan"}},
+		{"quarterly", []string{"mail1"}},
+		{"plan", []string{"file1"}},
+		{"falcon missing", []string{}},
+		{"", []string{}},
+	}
+	for _, test := range table {
+		suite.Run(test.query, func() {
+			assert.ElementsMatch(suite.T(), test.expect, refs(idx.Search(test.query)))
+		})
+	}
+}
+
+type mockCollection struct {
+	base  string
+	items []export.Item
+}
+
+func (mc mockCollection) BasePath() string {
+	return mc.base
+}
+
+func (mc mockCollection) Items(context.Context) <-chan export.Item {
+	ch := make(chan export.Item, len(mc.items))
+	defer close(ch)
+
+	for _, i := range mc.items {
+		ch <- i
+	}
+
+	return ch
+}
+
+func (suite *IndexUnitSuite) TestBuildIndex() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	colls := []export.Collectioner{
+		mockCollection{
+			base: "Inbox",
+			items: []export.Item{
+				{
+					ID:   "id1",
+					Name: "id1.eml",
+					Body: io.NopCloser(strings.NewReader("Subject: falcon\n\nbody")),
+				},
+				{
+					ID:    "id2",
+					Error: assert.AnError,
+				},
+			},
+		},
+		mockCollection{
+			base: "Sent",
+			items: []export.Item{
+				{
+					ID:   "id3",
+					Name: "id3.eml",
+					Body: io.NopCloser(strings.NewReader("Subject: other\n\nfalcon")),
+				},
+			},
+		},
+	}
+
+	errs := fault.New(false)
+
+	idx, err := BuildIndex(ctx, "bid", path.ExchangeService, nil, colls, errs)
+	require.NoError(t, err, clues.ToCore(err))
+
+	assert.Len(t, errs.Recovered(), 1)
+	assert.Len(t, idx.Items, 2)
+	assert.Len(t, idx.Search("falcon"), 2)
+	assert.Equal(t, "Sent", idx.Search("other")[0].LocationRef)
+}
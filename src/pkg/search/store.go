@@ -0,0 +1,127 @@
+package search
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/alcionai/clues"
+
+	"github.com/alcionai/corso/src/pkg/errs/core"
+)
+
+const indexFileSuffix = ".json.gz"
+
+// Store persists the search index of each backup.
+type Store interface {
+	// Load returns the index for the backup.  Returns core.ErrNotFound
+	// if no usable index exists.
+	Load(ctx context.Context, backupID string) (*Index, error)
+	Save(ctx context.Context, idx *Index) error
+}
+
+var _ Store = &LocalStore{}
+
+// LocalStore keeps one compressed index file per backup within a local
+// directory.
+type LocalStore struct {
+	dir string
+}
+
+// DefaultDir returns the default location for indexes of backups in the
+// repository: a folder within the user's cache directory.
+func DefaultDir(repoID string) (string, error) {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", clues.Wrap(err, "finding user cache directory")
+	}
+
+	return filepath.Join(cache, "corso", "search", repoID), nil
+}
+
+// NewLocalStore produces a store that keeps indexes in dir, creating
+// the directory if needed.
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, clues.Wrap(err, "creating index directory").With("index_dir", dir)
+	}
+
+	return &LocalStore{dir: dir}, nil
+}
+
+func (ls LocalStore) filePath(backupID string) string {
+	return filepath.Join(ls.dir, backupID+indexFileSuffix)
+}
+
+func (ls LocalStore) Load(ctx context.Context, backupID string) (*Index, error) {
+	f, err := os.Open(ls.filePath(backupID))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, clues.StackWC(ctx, core.ErrNotFound)
+		}
+
+		return nil, clues.WrapWC(ctx, err, "opening index file")
+	}
+
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, clues.WrapWC(ctx, err, "reading index file")
+	}
+
+	defer gr.Close()
+
+	idx := &Index{}
+
+	if err := json.NewDecoder(gr).Decode(idx); err != nil {
+		return nil, clues.WrapWC(ctx, err, "decoding index")
+	}
+
+	// indexes from other versions get rebuilt.
+	if idx.Version != IndexVersion || idx.BackupID != backupID {
+		return nil, clues.StackWC(ctx, core.ErrNotFound).
+			With("index_version", idx.Version)
+	}
+
+	return idx, nil
+}
+
+func (ls LocalStore) Save(ctx context.Context, idx *Index) error {
+	if idx == nil {
+		return clues.NewWC(ctx, "nil index")
+	}
+
+	// write to a temp file first so that an interrupted save doesn't
+	// leave a partial index behind.
+	tmp, err := os.CreateTemp(ls.dir, idx.BackupID+"-*.tmp")
+	if err != nil {
+		return clues.WrapWC(ctx, err, "creating index file")
+	}
+
+	defer os.Remove(tmp.Name())
+
+	gw := gzip.NewWriter(tmp)
+
+	if err := json.NewEncoder(gw).Encode(idx); err != nil {
+		tmp.Close()
+		return clues.WrapWC(ctx, err, "encoding index")
+	}
+
+	if err := gw.Close(); err != nil {
+		tmp.Close()
+		return clues.WrapWC(ctx, err, "compressing index")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return clues.WrapWC(ctx, err, "closing index file")
+	}
+
+	err = os.Rename(tmp.Name(), ls.filePath(idx.BackupID))
+
+	return clues.WrapWC(ctx, err, "saving index file").OrNil()
+}
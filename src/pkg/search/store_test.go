@@ -0,0 +1,69 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/errs/core"
+	"github.com/alcionai/corso/src/pkg/path"
+)
+
+type StoreUnitSuite struct {
+	tester.Suite
+}
+
+func TestStoreUnitSuite(t *testing.T) {
+	suite.Run(t, &StoreUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *StoreUnitSuite) TestLocalStore() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	dir := filepath.Join(t.TempDir(), "nested", "index")
+
+	ls, err := NewLocalStore(dir)
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = ls.Load(ctx, "bid")
+	assert.ErrorIs(t, err, core.ErrNotFound, clues.ToCore(err))
+
+	b := NewBuilder("bid", path.OneDriveService, nil)
+
+	err = b.Add("id.data", "file.txt", "Documents", strings.NewReader("falcon"))
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = ls.Save(ctx, b.Index())
+	require.NoError(t, err, clues.ToCore(err))
+
+	idx, err := ls.Load(ctx, "bid")
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, b.Index(), idx)
+	assert.Len(t, idx.Search("falcon"), 1)
+
+	// only the index file remains in the directory
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err, clues.ToCore(err))
+	require.Len(t, files, 1)
+	assert.Equal(t, "bid"+indexFileSuffix, files[0].Name())
+
+	// indexes from a different version are ignored
+	old := b.Index()
+	old.Version = IndexVersion + 1
+
+	err = ls.Save(ctx, old)
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = ls.Load(ctx, "bid")
+	assert.ErrorIs(t, err, core.ErrNotFound, clues.ToCore(err))
+}
@@ -0,0 +1,293 @@
+package search
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	stdpath "path"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxIndexedBytes caps how much of any single item gets read for
+// indexing.  Anything beyond the cap is left out of the index.
+const maxIndexedBytes = 64 << 20
+
+// sniffLen is the number of leading bytes checked when deciding if
+// unrecognized content is plain text.
+const sniffLen = 8 << 10
+
+var headerDecoder = &mime.WordDecoder{}
+
+// extractText produces the searchable text out of an item's contents.
+// The name's extension determines how the content is interpreted.
+// Content that can't be interpreted as text produces an empty string.
+func extractText(name string, content []byte) string {
+	switch strings.ToLower(stdpath.Ext(name)) {
+	case ".eml":
+		return emlText(content)
+	case ".docx", ".docm", ".pptx", ".xlsx", ".odt", ".odp", ".ods":
+		return officeText(content)
+	case ".html", ".htm":
+		return stripTags(string(content))
+	}
+
+	if !isText(content) {
+		return ""
+	}
+
+	return string(content)
+}
+
+// isText guesses whether the content is human readable text.
+func isText(content []byte) bool {
+	sniff := content
+	if len(sniff) > sniffLen {
+		sniff = sniff[:sniffLen]
+	}
+
+	if bytes.IndexByte(sniff, 0) >= 0 {
+		return false
+	}
+
+	// a multi-byte rune may be split at the end of the sniffed range.
+	for i := 0; i < utf8.UTFMax && len(sniff) > 0; i++ {
+		if utf8.Valid(sniff) {
+			return true
+		}
+
+		sniff = sniff[:len(sniff)-1]
+	}
+
+	return false
+}
+
+// ---------------------------------------------------------------------------
+// email
+// ---------------------------------------------------------------------------
+
+// emlText produces the headers, bodies, and text attachments of an
+// RFC 822 message.
+func emlText(content []byte) string {
+	msg, err := mail.ReadMessage(bytes.NewReader(content))
+	if err != nil {
+		return ""
+	}
+
+	sb := &strings.Builder{}
+
+	for _, h := range []string{"Subject", "From", "To", "Cc"} {
+		v := msg.Header.Get(h)
+		if len(v) == 0 {
+			continue
+		}
+
+		if dec, err := headerDecoder.DecodeHeader(v); err == nil {
+			v = dec
+		}
+
+		sb.WriteString(v)
+		sb.WriteString("\n")
+	}
+
+	writePart(
+		sb,
+		msg.Header.Get("Content-Type"),
+		msg.Header.Get("Content-Transfer-Encoding"),
+		"",
+		msg.Body)
+
+	return sb.String()
+}
+
+// writePart writes the text of a single mime part, recursing into any
+// nested multipart content.
+func writePart(
+	sb *strings.Builder,
+	contentType, encoding, disposition string,
+	body io.Reader,
+) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	_, dispParams, _ := mime.ParseMediaType(disposition)
+
+	filename := dispParams["filename"]
+	if len(filename) == 0 {
+		filename = params["name"]
+	}
+
+	if len(filename) > 0 {
+		sb.WriteString(filename)
+		sb.WriteString("\n")
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+
+		for {
+			p, err := mr.NextRawPart()
+			if err != nil {
+				return
+			}
+
+			writePart(
+				sb,
+				p.Header.Get("Content-Type"),
+				p.Header.Get("Content-Transfer-Encoding"),
+				p.Header.Get("Content-Disposition"),
+				p)
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, &newlineSkipper{r: body})
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(body, maxIndexedBytes))
+	if err != nil {
+		return
+	}
+
+	switch {
+	case mediaType == "message/rfc822":
+		sb.WriteString(emlText(content))
+	case mediaType == "text/html":
+		sb.WriteString(stripTags(string(content)))
+	case strings.HasPrefix(mediaType, "text/"):
+		sb.Write(content)
+	case len(filename) > 0:
+		sb.WriteString(extractText(filename, content))
+	}
+
+	sb.WriteString("\n")
+}
+
+// newlineSkipper drops line breaks so that wrapped base64 content can
+// be decoded.
+type newlineSkipper struct {
+	r io.Reader
+}
+
+func (ns *newlineSkipper) Read(p []byte) (int, error) {
+	n, err := ns.r.Read(p)
+
+	j := 0
+
+	for i := 0; i < n; i++ {
+		if p[i] == '\r' || p[i] == '\n' {
+			continue
+		}
+
+		p[j] = p[i]
+		j++
+	}
+
+	return j, err
+}
+
+// ---------------------------------------------------------------------------
+// documents
+// ---------------------------------------------------------------------------
+
+// officeText produces the text found within OOXML (docx, pptx, xlsx)
+// and OpenDocument files.
+func officeText(content []byte) string {
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return ""
+	}
+
+	sb := &strings.Builder{}
+
+	for _, f := range zr.File {
+		if !isOfficeTextPart(f.Name) {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			continue
+		}
+
+		writeXMLText(sb, io.LimitReader(rc, maxIndexedBytes))
+		rc.Close()
+	}
+
+	return sb.String()
+}
+
+func isOfficeTextPart(name string) bool {
+	if !strings.HasSuffix(name, ".xml") {
+		return false
+	}
+
+	switch {
+	case name == "content.xml",
+		name == "xl/sharedStrings.xml",
+		strings.HasPrefix(name, "word/document"),
+		strings.HasPrefix(name, "word/header"),
+		strings.HasPrefix(name, "word/footer"),
+		strings.HasPrefix(name, "word/footnotes"),
+		strings.HasPrefix(name, "ppt/slides/slide"),
+		strings.HasPrefix(name, "ppt/notesSlides/"):
+		return true
+	}
+
+	return false
+}
+
+// writeXMLText writes the character data of each xml element.
+func writeXMLText(sb *strings.Builder, r io.Reader) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return
+		}
+
+		if cd, ok := tok.(xml.CharData); ok {
+			sb.Write(cd)
+			sb.WriteString(" ")
+		}
+	}
+}
+
+// stripTags removes markup from html content, leaving only the text.
+func stripTags(s string) string {
+	var (
+		sb    = &strings.Builder{}
+		inTag bool
+	)
+
+	sb.Grow(len(s))
+
+	for _, r := range s {
+		switch {
+		case r == '<':
+			inTag = true
+		case r == '>' && inTag:
+			inTag = false
+
+			sb.WriteRune(' ')
+		case !inTag:
+			sb.WriteRune(r)
+		}
+	}
+
+	return html.UnescapeString(sb.String())
+}
@@ -0,0 +1,181 @@
+package search
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type TextUnitSuite struct {
+	tester.Suite
+}
+
+func TestTextUnitSuite(t *testing.T) {
+	suite.Run(t, &TextUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func makeDocx(t *testing.T, body string) []byte {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+
+	w, err := zw.Create("word/document.xml")
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = w.Write([]byte(`<?xml version="1.0"?><w:document><w:body><w:p><w:r><w:t>` +
+		body +
+		`</w:t></w:r></w:p></w:body></w:document>`))
+	require.NoError(t, err, clues.ToCore(err))
+
+	w, err = zw.Create("word/styles.xml")
+	require.NoError(t, err, clues.ToCore(err))
+
+	_, err = w.Write([]byte(`<styles><name>stylename</name></styles>`))
+	require.NoError(t, err, clues.ToCore(err))
+
+	err = zw.Close()
+	require.NoError(t, err, clues.ToCore(err))
+
+	return buf.Bytes()
+}
+
+func (suite *TextUnitSuite) TestExtractText() {
+	t := suite.T()
+
+	attachment := base64.StdEncoding.EncodeToString([]byte("attached falcon notes"))
+	docx := base64.StdEncoding.EncodeToString(makeDocx(t, "docx falcon roadmap"))
+
+	eml := strings.Join([]string{
+		"From: Alice <alice@example.com>",
+		"To: bob@example.com",
+		"Subject: =?UTF-8?Q?Caf=C3=A9_plans?=",
+		"MIME-Version: 1.0",
+		`Content-Type: multipart/mixed; boundary="outer"`,
+		"",
+		"--outer",
+		`Content-Type: multipart/alternative; boundary="inner"`,
+		"",
+		"--inner",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"plain body about the launch=3D",
+		"--inner",
+		"Content-Type: text/html; charset=utf-8",
+		"",
+		"<html><body><p>html&nbsp;body &amp; launch</p></body></html>",
+		"--inner--",
+		"--outer",
+		`Content-Type: text/plain; name="notes.txt"`,
+		`Content-Disposition: attachment; filename="notes.txt"`,
+		"Content-Transfer-Encoding: base64",
+		"",
+		attachment,
+		"--outer",
+		`Content-Type: application/octet-stream; name="roadmap.docx"`,
+		`Content-Disposition: attachment; filename="roadmap.docx"`,
+		"Content-Transfer-Encoding: base64",
+		"",
+		docx,
+		"--outer",
+		`Content-Type: image/png; name="pic.png"`,
+		`Content-Disposition: attachment; filename="pic.png"`,
+		"Content-Transfer-Encoding: base64",
+		"",
+		base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G', 0, 0, 'x', 'y', 'z', 'z', 'y'}),
+		"--outer--",
+	}, "\r\n")
+
+	table := []struct {
+		name         string
+		fileName     string
+		content      []byte
+		expectTerms  []string
+		missingTerms []string
+	}{
+		{
+			name:     "eml",
+			fileName: "id.eml",
+			content:  []byte(eml),
+			expectTerms: []string{
+				"café", "plans", "alice", "bob", "plain", "launch", "html", "body",
+				"notes", "txt", "attached", "falcon", "roadmap", "docx", "pic", "png",
+			},
+			missingTerms: []string{"nbsp", "amp", "xyzzy", "3d"},
+		},
+		{
+			name:         "docx",
+			fileName:     "Roadmap.DOCX",
+			content:      makeDocx(t, "docx falcon roadmap"),
+			expectTerms:  []string{"docx", "falcon", "roadmap"},
+			missingTerms: []string{"stylename", "document", "body"},
+		},
+		{
+			name:        "plain text",
+			fileName:    "readme",
+			content:     []byte("just some text"),
+			expectTerms: []string{"just", "some", "text"},
+		},
+		{
+			name:         "html",
+			fileName:     "page.html",
+			content:      []byte("<div class=\"hidden\">visible &lt;words&gt;</div>"),
+			expectTerms:  []string{"visible", "words"},
+			missingTerms: []string{"div", "class", "hidden"},
+		},
+		{
+			name:         "binary",
+			fileName:     "image.png",
+			content:      []byte{0x89, 'P', 'N', 'G', 0, 'a', 'b', 'c'},
+			missingTerms: []string{"png", "abc"},
+		},
+		{
+			name:         "corrupt docx",
+			fileName:     "bad.docx",
+			content:      []byte("not a zip"),
+			missingTerms: []string{"not", "zip"},
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			terms := tokenize(extractText(test.fileName, test.content))
+
+			for _, e := range test.expectTerms {
+				assert.Contains(t, terms, e)
+			}
+
+			for _, m := range test.missingTerms {
+				assert.NotContains(t, terms, m)
+			}
+		})
+	}
+}
+
+func (suite *TextUnitSuite) TestIsText() {
+	table := []struct {
+		name    string
+		content []byte
+		expect  assert.BoolAssertionFunc
+	}{
+		{"ascii", []byte("hello"), assert.True},
+		{"utf8", []byte("héllo wörld"), assert.True},
+		{"split rune", append(bytes.Repeat([]byte("a"), sniffLen-1), []byte("é")...), assert.True},
+		{"null byte", []byte("hel\x00lo"), assert.False},
+		{"invalid utf8", []byte{0xff, 0xfe, 0xfd, 0xfc, 0xfb, 'a'}, assert.False},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			test.expect(suite.T(), isText(test.content))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package selectors
+
+import (
+	"fmt"
+	stdpath "path"
+	"strings"
+
+	"github.com/alcionai/clues"
+	"github.com/dustin/go-humanize"
+)
+
+// DriveExclusions describes drive files (OneDrive, SharePoint and Groups
+// libraries) that get left out of a backup while the drive is enumerated.
+// Unlike scopes, which are matched against backup details, exclusions are
+// matched against the items as they are discovered, and any excluded
+// file is reported as a skipped item.
+type DriveExclusions struct {
+	// Extensions matches the file extension, case insensitive, with or
+	// without the leading dot.  Ex: "iso", ".pst".
+	Extensions []string `json:"extensions,omitempty"`
+	// NameGlobs matches the file name using shell glob patterns, case
+	// insensitive.  Ex: "*.tmp", "~$*".
+	NameGlobs []string `json:"nameGlobs,omitempty"`
+	// PathGlobs matches the file's path within the drive, case insensitive.
+	// Each glob is compared against every leading portion of the path, so
+	// "Archive" excludes everything within the top-level Archive folder, and
+	// "*/node_modules" excludes node_modules folders one level down.
+	PathGlobs []string `json:"pathGlobs,omitempty"`
+	// MaxSize excludes any file larger than this many bytes.  Zero means
+	// no limit.
+	MaxSize int64 `json:"maxSize,omitempty"`
+}
+
+// SetDriveExclusions attaches the exclusions to the selector.  Only drive
+// backups make use of exclusions; other services ignore them.
+func (s *Selector) SetDriveExclusions(de DriveExclusions) {
+	if de.IsZero() {
+		s.DriveExclusions = nil
+		return
+	}
+
+	s.DriveExclusions = &de
+}
+
+// IsZero returns true if no exclusion rules are defined.
+func (de DriveExclusions) IsZero() bool {
+	return len(de.Extensions)+len(de.NameGlobs)+len(de.PathGlobs) == 0 &&
+		de.MaxSize <= 0
+}
+
+// Validate checks that each of the glob patterns is well formed.
+func (de DriveExclusions) Validate() error {
+	for _, g := range append(append([]string{}, de.NameGlobs...), de.PathGlobs...) {
+		if _, err := stdpath.Match(strings.ToLower(g), ""); err != nil {
+			return clues.Wrap(err, "invalid glob pattern").With("glob", g)
+		}
+	}
+
+	if de.MaxSize < 0 {
+		return clues.New("max size cannot be negative")
+	}
+
+	return nil
+}
+
+// Excludes checks the file against each of the exclusion rules.  folders
+// is the set of folder names between the drive root and the file.  If the
+// file is excluded, the returned string describes the rule it matched.
+func (de *DriveExclusions) Excludes(
+	folders []string,
+	name string,
+	size int64,
+) (string, bool) {
+	if de == nil {
+		return "", false
+	}
+
+	lname := strings.ToLower(name)
+
+	if de.MaxSize > 0 && size > de.MaxSize {
+		return fmt.Sprintf(
+			"size %s exceeds %s",
+			humanize.Bytes(uint64(size)),
+			humanize.Bytes(uint64(de.MaxSize))), true
+	}
+
+	ext := strings.ToLower(stdpath.Ext(lname))
+
+	for _, e := range de.Extensions {
+		e = "." + strings.TrimPrefix(strings.ToLower(e), ".")
+		if len(ext) > 0 && ext == e {
+			return "extension " + e, true
+		}
+	}
+
+	for _, g := range de.NameGlobs {
+		if ok, _ := stdpath.Match(strings.ToLower(g), lname); ok {
+			return "name matches " + g, true
+		}
+	}
+
+	if len(de.PathGlobs) == 0 {
+		return "", false
+	}
+
+	elems := make([]string, 0, len(folders)+1)
+
+	for _, f := range folders {
+		elems = append(elems, strings.ToLower(f))
+	}
+
+	elems = append(elems, lname)
+
+	for _, g := range de.PathGlobs {
+		lg := strings.ToLower(strings.Trim(g, "/"))
+
+		for i := 1; i <= len(elems); i++ {
+			if ok, _ := stdpath.Match(lg, strings.Join(elems[:i], "/")); ok {
+				return "path matches " + g, true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,173 @@
+package selectors
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type DriveExclusionsSuite struct {
+	tester.Suite
+}
+
+func TestDriveExclusionsSuite(t *testing.T) {
+	suite.Run(t, &DriveExclusionsSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *DriveExclusionsSuite) TestExcludes() {
+	var (
+		folders = []string{"Documents", "Archive", "2019"}
+		name    = "Backup.ISO"
+		size    = int64(2048)
+	)
+
+	table := []struct {
+		name       string
+		exclusions *DriveExclusions
+		expect     assert.BoolAssertionFunc
+	}{
+		{
+			name:       "nil exclusions",
+			exclusions: nil,
+			expect:     assert.False,
+		},
+		{
+			name:       "empty exclusions",
+			exclusions: &DriveExclusions{},
+			expect:     assert.False,
+		},
+		{
+			name:       "extension",
+			exclusions: &DriveExclusions{Extensions: []string{"pst", "iso"}},
+			expect:     assert.True,
+		},
+		{
+			name:       "extension with dot",
+			exclusions: &DriveExclusions{Extensions: []string{".iso"}},
+			expect:     assert.True,
+		},
+		{
+			name:       "different extension",
+			exclusions: &DriveExclusions{Extensions: []string{"is"}},
+			expect:     assert.False,
+		},
+		{
+			name:       "name glob",
+			exclusions: &DriveExclusions{NameGlobs: []string{"backup.*"}},
+			expect:     assert.True,
+		},
+		{
+			name:       "name glob mismatch",
+			exclusions: &DriveExclusions{NameGlobs: []string{"~$*"}},
+			expect:     assert.False,
+		},
+		{
+			name:       "over max size",
+			exclusions: &DriveExclusions{MaxSize: 1024},
+			expect:     assert.True,
+		},
+		{
+			name:       "at max size",
+			exclusions: &DriveExclusions{MaxSize: size},
+			expect:     assert.False,
+		},
+		{
+			name:       "path glob top level folder",
+			exclusions: &DriveExclusions{PathGlobs: []string{"documents"}},
+			expect:     assert.True,
+		},
+		{
+			name:       "path glob nested folder",
+			exclusions: &DriveExclusions{PathGlobs: []string{"*/archive"}},
+			expect:     assert.True,
+		},
+		{
+			name:       "path glob full path",
+			exclusions: &DriveExclusions{PathGlobs: []string{"/Documents/Archive/*/*.iso"}},
+			expect:     assert.True,
+		},
+		{
+			name:       "path glob wrong depth",
+			exclusions: &DriveExclusions{PathGlobs: []string{"archive"}},
+			expect:     assert.False,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			rule, excluded := test.exclusions.Excludes(folders, name, size)
+			test.expect(t, excluded)
+
+			if excluded {
+				assert.NotEmpty(t, rule)
+			} else {
+				assert.Empty(t, rule)
+			}
+		})
+	}
+}
+
+func (suite *DriveExclusionsSuite) TestValidate() {
+	table := []struct {
+		name       string
+		exclusions DriveExclusions
+		expect     assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "empty",
+			exclusions: DriveExclusions{},
+			expect:     assert.NoError,
+		},
+		{
+			name: "valid globs",
+			exclusions: DriveExclusions{
+				NameGlobs: []string{"*.tmp"},
+				PathGlobs: []string{"Archive/*"},
+			},
+			expect: assert.NoError,
+		},
+		{
+			name:       "bad name glob",
+			exclusions: DriveExclusions{NameGlobs: []string{"[a-"}},
+			expect:     assert.Error,
+		},
+		{
+			name:       "bad path glob",
+			exclusions: DriveExclusions{PathGlobs: []string{"[a-"}},
+			expect:     assert.Error,
+		},
+		{
+			name:       "negative size",
+			exclusions: DriveExclusions{MaxSize: -1},
+			expect:     assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			err := test.exclusions.Validate()
+			test.expect(suite.T(), err, clues.ToCore(err))
+		})
+	}
+}
+
+func (suite *DriveExclusionsSuite) TestSetDriveExclusions() {
+	t := suite.T()
+
+	sel := NewOneDriveBackup(Any())
+
+	sel.SetDriveExclusions(DriveExclusions{})
+	assert.Nil(t, sel.DriveExclusions)
+
+	sel.SetDriveExclusions(DriveExclusions{Extensions: []string{"iso"}})
+	assert.Equal(t, []string{"iso"}, sel.DriveExclusions.Extensions)
+
+	// exclusions persist through resource owner splitting.
+	for _, s := range sel.SplitByResourceOwner([]string{"a", "b"}) {
+		assert.Equal(t, []string{"iso"}, s.DriveExclusions.Extensions)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/alcionai/clues"
+	"github.com/dustin/go-humanize"
 
 	"github.com/alcionai/corso/src/pkg/backup/details"
 	"github.com/alcionai/corso/src/pkg/backup/identity"
@@ -318,6 +319,21 @@ func (s *exchange) MailFolders(folders []string, opts ...option) []ExchangeScope
 	return scopes
 }
 
+// MailboxSettings produces one or more exchange mailbox settings scopes.
+// Settings are identified by kind (ex: inboxRules, masterCategories).
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *exchange) MailboxSettings(settings []string, opts ...option) []ExchangeScope {
+	scopes := []ExchangeScope{}
+
+	scopes = append(
+		scopes,
+		makeScope[ExchangeScope](ExchangeMailboxSetting, settings, opts...))
+
+	return scopes
+}
+
 // Retrieves all exchange data.
 // Each user id generates three scopes, one for each data type: contact, event, and mail.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
@@ -453,6 +469,81 @@ func (sr *ExchangeRestore) MailReceivedBefore(timeStrings string) []ExchangeScop
 	}
 }
 
+// MailAttachmentName produces one or more exchange mail attachment name info scopes.
+// Matches any mail with an attachment whose name contains the provided string.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (sr *ExchangeRestore) MailAttachmentName(name string) []ExchangeScope {
+	return []ExchangeScope{
+		makeInfoScope[ExchangeScope](
+			ExchangeMail,
+			ExchangeInfoMailAttachmentName,
+			[]string{name},
+			filters.In),
+	}
+}
+
+// MailHasAttachments produces one or more exchange mail attachment info scopes.
+// Matches any mail if the comparator flag matches the mail's has-attachments flag.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (sr *ExchangeRestore) MailHasAttachments(hasAttachments string) []ExchangeScope {
+	return []ExchangeScope{
+		makeInfoScope[ExchangeScope](
+			ExchangeMail,
+			ExchangeInfoMailHasAttachments,
+			[]string{hasAttachments},
+			filters.Equal),
+	}
+}
+
+// MailRecipient produces one or more exchange mail recipient info scopes.
+// Matches any mail where one of the recipients contains the provided string.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (sr *ExchangeRestore) MailRecipient(recipient string) []ExchangeScope {
+	return []ExchangeScope{
+		makeInfoScope[ExchangeScope](
+			ExchangeMail,
+			ExchangeInfoMailRecipient,
+			[]string{recipient},
+			filters.In),
+	}
+}
+
+// MailSizeGreater produces an exchange mail size info scope.
+// Matches any mail whose size is greater than the provided size.  The size
+// accepts either a count of bytes or a human readable value (ex: 10MB).
+// If the input equals selectors.Any, the scope will match all sizes.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (sr *ExchangeRestore) MailSizeGreater(size string) []ExchangeScope {
+	return []ExchangeScope{
+		makeInfoScope[ExchangeScope](
+			ExchangeMail,
+			ExchangeInfoMailSizeGreater,
+			[]string{normalizeSize(size)},
+			filters.Less),
+	}
+}
+
+// MailSizeLess produces an exchange mail size info scope.
+// Matches any mail whose size is less than the provided size.  The size
+// accepts either a count of bytes or a human readable value (ex: 10MB).
+// If the input equals selectors.Any, the scope will match all sizes.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (sr *ExchangeRestore) MailSizeLess(size string) []ExchangeScope {
+	return []ExchangeScope{
+		makeInfoScope[ExchangeScope](
+			ExchangeMail,
+			ExchangeInfoMailSizeLess,
+			[]string{normalizeSize(size)},
+			filters.Greater),
+	}
+}
+
 // MailSender produces one or more exchange mail sender info scopes.
 // Matches any mail whose sender contains one of the provided strings.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
@@ -506,11 +597,19 @@ const (
 	ExchangeMailFolder    exchangeCategory = "ExchangeMailFolder"
 	ExchangeUser          exchangeCategory = "ExchangeUser"
 
+	// mailbox settings live at the root of their category, with no folders.
+	ExchangeMailboxSetting exchangeCategory = "ExchangeMailboxSetting"
+
 	// data contained within details.ItemInfo
 	ExchangeInfoMailSender         exchangeCategory = "ExchangeInfoMailSender"
 	ExchangeInfoMailSubject        exchangeCategory = "ExchangeInfoMailSubject"
 	ExchangeInfoMailReceivedAfter  exchangeCategory = "ExchangeInfoMailReceivedAfter"
 	ExchangeInfoMailReceivedBefore exchangeCategory = "ExchangeInfoMailReceivedBefore"
+	ExchangeInfoMailRecipient      exchangeCategory = "ExchangeInfoMailRecipient"
+	ExchangeInfoMailSizeGreater    exchangeCategory = "ExchangeInfoMailSizeGreater"
+	ExchangeInfoMailSizeLess       exchangeCategory = "ExchangeInfoMailSizeLess"
+	ExchangeInfoMailHasAttachments exchangeCategory = "ExchangeInfoMailHasAttachments"
+	ExchangeInfoMailAttachmentName exchangeCategory = "ExchangeInfoMailAttachmentName"
 	ExchangeInfoContactName        exchangeCategory = "ExchangeInfoContactName"
 	ExchangeInfoEventOrganizer     exchangeCategory = "ExchangeInfoEventOrganizer"
 	ExchangeInfoEventRecurs        exchangeCategory = "ExchangeInfoEventRecurs"
@@ -533,6 +632,10 @@ var exchangeLeafProperties = map[categorizer]leafProperty{
 		pathKeys: []categorizer{ExchangeMailFolder, ExchangeMail},
 		pathType: path.EmailCategory,
 	},
+	ExchangeMailboxSetting: {
+		pathKeys: []categorizer{ExchangeMailboxSetting},
+		pathType: path.MailboxSettingsCategory,
+	},
 	ExchangeUser: { // the root category must be represented, even though it isn't a leaf
 		pathKeys: []categorizer{ExchangeUser},
 		pathType: path.UnknownCategory,
@@ -561,7 +664,9 @@ func (ec exchangeCategory) leafCat() categorizer {
 		return ExchangeEvent
 
 	case ExchangeMail, ExchangeMailFolder, ExchangeInfoMailReceivedAfter,
-		ExchangeInfoMailReceivedBefore, ExchangeInfoMailSender, ExchangeInfoMailSubject:
+		ExchangeInfoMailReceivedBefore, ExchangeInfoMailSender, ExchangeInfoMailSubject,
+		ExchangeInfoMailRecipient, ExchangeInfoMailSizeGreater, ExchangeInfoMailSizeLess,
+		ExchangeInfoMailHasAttachments, ExchangeInfoMailAttachmentName:
 		return ExchangeMail
 	}
 
@@ -610,6 +715,12 @@ func (ec exchangeCategory) pathValues(
 	case ExchangeMail:
 		folderCat, itemCat = ExchangeMailFolder, ExchangeMail
 
+	case ExchangeMailboxSetting:
+		// settings have no containing folder; the item ID names the setting.
+		return map[categorizer][]string{
+			ExchangeMailboxSetting: {ent.ShortRef, repo.Item()},
+		}, nil
+
 	default:
 		return nil, clues.New("bad exchanageCategory").With("category", ec)
 	}
@@ -736,6 +847,7 @@ func (s ExchangeScope) setDefaults() {
 		s[ExchangeEvent.String()] = passAny
 		s[ExchangeMailFolder.String()] = passAny
 		s[ExchangeMail.String()] = passAny
+		s[ExchangeMailboxSetting.String()] = passAny
 	}
 }
 
@@ -755,9 +867,10 @@ func (s exchange) Reduce(
 		deets,
 		s.Selector,
 		map[path.CategoryType]exchangeCategory{
-			path.ContactsCategory: ExchangeContact,
-			path.EventsCategory:   ExchangeEvent,
-			path.EmailCategory:    ExchangeMail,
+			path.ContactsCategory:        ExchangeContact,
+			path.EventsCategory:          ExchangeEvent,
+			path.EmailCategory:           ExchangeMail,
+			path.MailboxSettingsCategory: ExchangeMailboxSetting,
 		},
 		errs)
 }
@@ -796,11 +909,41 @@ func (s ExchangeScope) matchesInfo(dii details.ItemInfo) bool {
 		i = info.Subject
 	case ExchangeInfoMailReceivedAfter, ExchangeInfoMailReceivedBefore:
 		i = dttm.Format(info.Received)
+	case ExchangeInfoMailRecipient:
+		return matchesAny(s, infoCat, info.Recipient)
+	case ExchangeInfoMailSizeGreater, ExchangeInfoMailSizeLess:
+		i = formatSize(info.Size)
+	case ExchangeInfoMailHasAttachments:
+		i = strconv.FormatBool(info.HasAttachments)
+	case ExchangeInfoMailAttachmentName:
+		return matchesAny(s, infoCat, info.AttachmentNames)
 	}
 
 	return s.Matches(infoCat, i)
 }
 
+// formatSize zero-pads the size so that the lexical comparison used by
+// the greater and less filters agrees with numerical ordering.
+func formatSize(size int64) string {
+	return fmt.Sprintf("%020d", size)
+}
+
+// normalizeSize parses a byte count or human readable size (ex: 10MB)
+// into the padded format produced by formatSize.  The Any and None
+// targets, and any unparseable values, are returned unchanged.
+func normalizeSize(size string) string {
+	if size == AnyTgt || size == NoneTgt {
+		return size
+	}
+
+	n, err := humanize.ParseBytes(size)
+	if err != nil {
+		return size
+	}
+
+	return formatSize(int64(n))
+}
+
 // exchangeCategoryFromItemType interprets the category represented by the ExchangeInfo
 // struct.  Since every ExchangeInfo can hold all exchange data info, the exact
 // type that the struct represents must be compared using its ItemType prop.
@@ -812,6 +955,8 @@ func exchangeCategoryFromItemType(pct details.ItemType) exchangeCategory {
 		return ExchangeMail
 	case details.ExchangeEvent:
 		return ExchangeEvent
+	case details.ExchangeMailboxSettings:
+		return ExchangeMailboxSetting
 	}
 
 	return ExchangeCategoryUnknown
@@ -302,6 +302,26 @@ func (suite *ExchangeSelectorSuite) TestExchangeSelector_Include_Mails() {
 	assert.Equal(t, sel.Scopes()[0].Category(), ExchangeMail)
 }
 
+func (suite *ExchangeSelectorSuite) TestExchangeSelector_Include_MailboxSettings() {
+	t := suite.T()
+
+	const user = "user"
+
+	sel := NewExchangeBackup([]string{user})
+	sel.Include(sel.MailboxSettings([]string{"inboxRules"}))
+	scopes := sel.Includes
+	require.Len(t, scopes, 1)
+
+	scopeMustHave(
+		t,
+		ExchangeScope(scopes[0]),
+		map[categorizer][]string{
+			ExchangeMailboxSetting: {"inboxRules"},
+		})
+
+	assert.Equal(t, sel.Scopes()[0].Category(), ExchangeMailboxSetting)
+}
+
 func (suite *ExchangeSelectorSuite) TestExchangeSelector_Exclude_MailFolders() {
 	t := suite.T()
 
@@ -586,6 +606,8 @@ func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesInfo() {
 		organizer = "cooks@2many.smarf"
 		sender    = "smarf@2many.cooks"
 		subject   = "I have seen the fnords!"
+		recipient = "fnords@2many.smarf"
+		attName   = "fnords.pdf"
 	)
 
 	var (
@@ -605,6 +627,11 @@ func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesInfo() {
 				Sender:      sender,
 				Subject:     subject,
 				Received:    now,
+				Recipient:   []string{"other@2many.smarf", recipient},
+				Size:        2048,
+
+				HasAttachments:  true,
+				AttachmentNames: []string{attName},
 			},
 		}
 	}
@@ -645,6 +672,23 @@ func (suite *ExchangeSelectorSuite) TestExchangeScope_MatchesInfo() {
 			es.MailReceivedBefore(dttm.Format(future)),
 			assert.True,
 		},
+		{"mail with any recipient", details.ExchangeMail, es.MailRecipient(AnyTgt), assert.True},
+		{"mail with none recipient", details.ExchangeMail, es.MailRecipient(NoneTgt), assert.False},
+		{"mail to a different recipient", details.ExchangeMail, es.MailRecipient("magoo@ma.goo"), assert.False},
+		{"mail to the matching recipient", details.ExchangeMail, es.MailRecipient(recipient), assert.True},
+		{"mail with a substring recipient match", details.ExchangeMail, es.MailRecipient("fnords@"), assert.True},
+		{"mail larger than a smaller size", details.ExchangeMail, es.MailSizeGreater("1000"), assert.True},
+		{"mail larger than a larger size", details.ExchangeMail, es.MailSizeGreater("1MB"), assert.False},
+		{"mail larger than the same size", details.ExchangeMail, es.MailSizeGreater("2048"), assert.False},
+		{"mail smaller than a smaller size", details.ExchangeMail, es.MailSizeLess("1000"), assert.False},
+		{"mail smaller than a larger size", details.ExchangeMail, es.MailSizeLess("1MB"), assert.True},
+		{"mail smaller than a size with more digits", details.ExchangeMail, es.MailSizeLess("10000"), assert.True},
+		{"mail with attachments", details.ExchangeMail, es.MailHasAttachments("true"), assert.True},
+		{"mail without attachments", details.ExchangeMail, es.MailHasAttachments("false"), assert.False},
+		{"mail with any attachment name", details.ExchangeMail, es.MailAttachmentName(AnyTgt), assert.True},
+		{"mail with the matching attachment", details.ExchangeMail, es.MailAttachmentName(attName), assert.True},
+		{"mail with a substring attachment", details.ExchangeMail, es.MailAttachmentName(".pdf"), assert.True},
+		{"mail with a different attachment", details.ExchangeMail, es.MailAttachmentName("smarf.doc"), assert.False},
 		{"event with any organizer", details.ExchangeEvent, es.EventOrganizer(AnyTgt), assert.True},
 		{"event with none organizer", details.ExchangeEvent, es.EventOrganizer(NoneTgt), assert.False},
 		{"event with a different organizer", details.ExchangeEvent, es.EventOrganizer("fancy"), assert.False},
@@ -1463,6 +1507,7 @@ func (suite *ExchangeSelectorSuite) TestExchangeCategory_leafCat() {
 		{ExchangeMail, ExchangeMail},
 		{ExchangeContactFolder, ExchangeContact},
 		{ExchangeEvent, ExchangeEvent},
+		{ExchangeMailboxSetting, ExchangeMailboxSetting},
 	}
 	for _, test := range table {
 		suite.Run(test.cat.String(), func() {
@@ -1551,6 +1596,7 @@ func (suite *ExchangeSelectorSuite) TestExchangeCategory_PathKeys() {
 	event := []categorizer{ExchangeEventCalendar, ExchangeEvent}
 	mail := []categorizer{ExchangeMailFolder, ExchangeMail}
 	user := []categorizer{ExchangeUser}
+	setting := []categorizer{ExchangeMailboxSetting}
 
 	var empty []categorizer
 
@@ -1563,6 +1609,7 @@ func (suite *ExchangeSelectorSuite) TestExchangeCategory_PathKeys() {
 		{ExchangeEvent, event},
 		{ExchangeMail, mail},
 		{ExchangeUser, user},
+		{ExchangeMailboxSetting, setting},
 	}
 	for _, test := range table {
 		suite.Run(string(test.cat), func() {
@@ -1592,6 +1639,11 @@ func (suite *ExchangeSelectorSuite) TestCategoryFromItemType() {
 			input:  details.ExchangeMail,
 			expect: ExchangeMail,
 		},
+		{
+			name:   "mailbox settings",
+			input:  details.ExchangeMailboxSettings,
+			expect: ExchangeMailboxSetting,
+		},
 		{
 			name:   "unknown",
 			input:  details.UnknownType,
@@ -1621,8 +1673,14 @@ func (suite *ExchangeSelectorSuite) TestCategory_PathType() {
 		{ExchangeMail, path.EmailCategory},
 		{ExchangeMailFolder, path.EmailCategory},
 		{ExchangeUser, path.UnknownCategory},
+		{ExchangeMailboxSetting, path.MailboxSettingsCategory},
 		{ExchangeInfoMailSender, path.EmailCategory},
 		{ExchangeInfoMailSubject, path.EmailCategory},
+		{ExchangeInfoMailRecipient, path.EmailCategory},
+		{ExchangeInfoMailSizeGreater, path.EmailCategory},
+		{ExchangeInfoMailSizeLess, path.EmailCategory},
+		{ExchangeInfoMailHasAttachments, path.EmailCategory},
+		{ExchangeInfoMailAttachmentName, path.EmailCategory},
 		{ExchangeInfoMailReceivedAfter, path.EmailCategory},
 		{ExchangeInfoMailReceivedBefore, path.EmailCategory},
 		{ExchangeInfoContactName, path.ContactsCategory},
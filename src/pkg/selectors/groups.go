@@ -218,7 +218,8 @@ func (s *groups) AllData() []GroupsScope {
 		scopes,
 		makeScope[GroupsScope](GroupsLibraryFolder, Any()),
 		makeScope[GroupsScope](GroupsChannel, Any()),
-		makeScope[GroupsScope](GroupsConversation, Any()))
+		makeScope[GroupsScope](GroupsConversation, Any()),
+		makeScope[GroupsScope](GroupsPlan, Any()))
 
 	return scopes
 }
@@ -295,6 +296,41 @@ func (s *groups) ConversationPosts(conversations, posts []string, opts ...option
 	return scopes
 }
 
+// Plans produces one or more Groups planner plan scopes, where the plan
+// matches upon a given plan by title.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *groups) Plans(plans []string, opts ...option) []GroupsScope {
+	var (
+		scopes = []GroupsScope{}
+		os     = append([]option{pathComparator()}, opts...)
+	)
+
+	scopes = append(
+		scopes,
+		makeScope[GroupsScope](GroupsPlan, plans, os...))
+
+	return scopes
+}
+
+// PlanTasks produces one or more Groups planner task scopes, where the
+// task matches upon a given task by ID or title.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+// options are only applied to the plan scopes.
+func (s *groups) PlanTasks(plans, tasks []string, opts ...option) []GroupsScope {
+	scopes := []GroupsScope{}
+
+	scopes = append(
+		scopes,
+		makeScope[GroupsScope](GroupsPlanTask, tasks).
+			set(GroupsPlan, plans, opts...))
+
+	return scopes
+}
+
 // Sites produces one or more Groups site scopes, where the site
 // matches upon a given site by ID or URL.
 // If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
@@ -536,6 +572,51 @@ func (s *GroupsRestore) MessageLastReplyBefore(timeStrings string) []GroupsScope
 	}
 }
 
+// TaskAssignee produces one or more groups planner task info scopes.
+// Matches any task assigned to the specified user ID.
+// If any slice contains selectors.Any, that slice is reduced to [selectors.Any]
+// If any slice contains selectors.None, that slice is reduced to [selectors.None]
+// If any slice is empty, it defaults to [selectors.None]
+func (s *GroupsRestore) TaskAssignee(assignee string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsPlanTask,
+			GroupsInfoPlanTaskAssignee,
+			[]string{assignee},
+			filters.Equal),
+	}
+}
+
+// TaskDueAfter produces a planner task due-after info scope.
+// Matches any task where the due date is after the timestring.
+// Tasks without a due date never match.
+// If the input equals selectors.Any, the scope will match all times.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *GroupsRestore) TaskDueAfter(timeStrings string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsPlanTask,
+			GroupsInfoPlanTaskDueAfter,
+			[]string{timeStrings},
+			filters.Less),
+	}
+}
+
+// TaskDueBefore produces a planner task due-before info scope.
+// Matches any task where the due date is before the timestring.
+// Tasks without a due date never match.
+// If the input equals selectors.Any, the scope will match all times.
+// If the input is empty or selectors.None, the scope will always fail comparisons.
+func (s *GroupsRestore) TaskDueBefore(timeStrings string) []GroupsScope {
+	return []GroupsScope{
+		makeInfoScope[GroupsScope](
+			GroupsPlanTask,
+			GroupsInfoPlanTaskDueBefore,
+			[]string{timeStrings},
+			filters.Greater),
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Categories
 // ---------------------------------------------------------------------------
@@ -562,6 +643,8 @@ const (
 	GroupsListItem         groupsCategory = "GroupsListItem"
 	GroupsPageFolder       groupsCategory = "GroupsPageFolder"
 	GroupsPage             groupsCategory = "GroupsPage"
+	GroupsPlan             groupsCategory = "GroupsPlan"
+	GroupsPlanTask         groupsCategory = "GroupsPlanTask"
 
 	// details.itemInfo comparables
 	GroupsInfoLibraryItemCreatedAfter   groupsCategory = "GroupsInfoLibraryItemCreatedAfter"
@@ -579,6 +662,9 @@ const (
 	GroupsInfoChannelMessageCreator         groupsCategory = "GroupsInfoChannelMessageCreator"
 	GroupsInfoChannelMessageLastReplyAfter  groupsCategory = "GroupsInfoChannelMessageLastReplyAfter"
 	GroupsInfoChannelMessageLastReplyBefore groupsCategory = "GroupsInfoChannelMessageLastReplyBefore"
+	GroupsInfoPlanTaskAssignee              groupsCategory = "GroupsInfoPlanTaskAssignee"
+	GroupsInfoPlanTaskDueAfter              groupsCategory = "GroupsInfoPlanTaskDueAfter"
+	GroupsInfoPlanTaskDueBefore             groupsCategory = "GroupsInfoPlanTaskDueBefore"
 )
 
 // groupsLeafProperties describes common metadata of the leaf categories
@@ -595,6 +681,10 @@ var groupsLeafProperties = map[categorizer]leafProperty{
 		pathKeys: []categorizer{GroupsLibraryFolder, GroupsLibraryItem},
 		pathType: path.LibrariesCategory,
 	},
+	GroupsPlanTask: {
+		pathKeys: []categorizer{GroupsPlan, GroupsPlanTask},
+		pathType: path.PlannerCategory,
+	},
 	GroupsGroup: { // the root category must be represented, even though it isn't a leaf
 		pathKeys: []categorizer{GroupsGroup},
 		pathType: path.UnknownCategory,
@@ -618,6 +708,9 @@ func (c groupsCategory) leafCat() categorizer {
 		return GroupsChannelMessage
 	case GroupsConversation, GroupsConversationPost:
 		return GroupsConversationPost
+	case GroupsPlan, GroupsPlanTask,
+		GroupsInfoPlanTaskAssignee, GroupsInfoPlanTaskDueAfter, GroupsInfoPlanTaskDueBefore:
+		return GroupsPlanTask
 	case GroupsLibraryFolder, GroupsLibraryItem, GroupsInfoSite, GroupsInfoSiteLibraryDrive,
 		GroupsInfoLibraryItemCreatedAfter, GroupsInfoLibraryItemCreatedBefore,
 		GroupsInfoLibraryItemModifiedAfter, GroupsInfoLibraryItemModifiedBefore:
@@ -678,6 +771,11 @@ func (c groupsCategory) pathValues(
 	case GroupsLibraryFolder, GroupsLibraryItem:
 		folderCat, itemCat = GroupsLibraryFolder, GroupsLibraryItem
 		rFld = ent.Groups.ParentPath
+	case GroupsPlan, GroupsPlanTask:
+		folderCat, itemCat = GroupsPlan, GroupsPlanTask
+		rFld = ent.Groups.ParentPath
+		// tasks can be selected by title as well as by ID
+		itemID = ent.Groups.Planner.TaskTitle
 	default:
 		return nil, clues.New("unrecognized groupsCategory").With("category", c)
 	}
@@ -773,7 +871,7 @@ func (s GroupsScope) set(cat groupsCategory, v []string, opts ...option) GroupsS
 	os := []option{}
 
 	switch cat {
-	case GroupsChannel, GroupsConversation, GroupsLibraryFolder:
+	case GroupsChannel, GroupsConversation, GroupsLibraryFolder, GroupsPlan:
 		os = append(os, pathComparator())
 	}
 
@@ -790,12 +888,16 @@ func (s GroupsScope) setDefaults() {
 		s[GroupsConversationPost.String()] = passAny
 		s[GroupsLibraryFolder.String()] = passAny
 		s[GroupsLibraryItem.String()] = passAny
+		s[GroupsPlan.String()] = passAny
+		s[GroupsPlanTask.String()] = passAny
 	case GroupsChannel:
 		s[GroupsChannelMessage.String()] = passAny
 	case GroupsLibraryFolder:
 		s[GroupsLibraryItem.String()] = passAny
 	case GroupsConversation:
 		s[GroupsConversationPost.String()] = passAny
+	case GroupsPlan:
+		s[GroupsPlanTask.String()] = passAny
 	}
 }
 
@@ -818,6 +920,7 @@ func (s groups) Reduce(
 			path.ChannelMessagesCategory:   GroupsChannelMessage,
 			path.ConversationPostsCategory: GroupsConversationPost,
 			path.LibrariesCategory:         GroupsLibraryItem,
+			path.PlannerCategory:           GroupsPlanTask,
 		},
 		errs)
 }
@@ -844,6 +947,8 @@ func (s GroupsScope) matchesInfo(dii details.ItemInfo) bool {
 		acceptableItemType = int(details.GroupsChannelMessage)
 	case GroupsConversationPost:
 		acceptableItemType = int(details.GroupsConversationPost)
+	case GroupsPlanTask:
+		acceptableItemType = int(details.GroupsPlannerTask)
 	}
 
 	switch infoCat {
@@ -885,6 +990,15 @@ func (s GroupsScope) matchesInfo(dii details.ItemInfo) bool {
 		}
 
 		i = dttm.Format(info.LastReply.CreatedAt)
+	case GroupsInfoPlanTaskAssignee:
+		return matchesAny(s, GroupsInfoPlanTaskAssignee, info.Planner.Assignees) &&
+			int(info.ItemType) == acceptableItemType
+	case GroupsInfoPlanTaskDueAfter, GroupsInfoPlanTaskDueBefore:
+		if info.Planner.DueDate.IsZero() {
+			return false
+		}
+
+		i = dttm.Format(info.Planner.DueDate)
 	}
 
 	return s.Matches(infoCat, i) && int(info.ItemType) == acceptableItemType
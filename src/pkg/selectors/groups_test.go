@@ -115,6 +115,8 @@ func (suite *GroupsSelectorSuite) TestGroupsRestore_Reduce() {
 		convItem  = toRR(path.ConversationPostsCategory, "gid", slices.Clone(itemElems1), "convitem")
 		convItem2 = toRR(path.ConversationPostsCategory, "gid", slices.Clone(itemElems2), "convitem2")
 		convItem3 = toRR(path.ConversationPostsCategory, "gid", slices.Clone(itemElems3), "convitem3")
+		planItem  = toRR(path.PlannerCategory, "gid", []string{"planid"}, "planid")
+		taskItem  = toRR(path.PlannerCategory, "gid", []string{"planid"}, "taskitem")
 	)
 
 	deets := &details.Details{
@@ -222,6 +224,35 @@ func (suite *GroupsSelectorSuite) TestGroupsRestore_Reduce() {
 						},
 					},
 				},
+				{
+					RepoRef:     planItem,
+					ItemRef:     "planid",
+					LocationRef: "Launch",
+					ItemInfo: details.ItemInfo{
+						Groups: &details.GroupsInfo{
+							ItemType:   details.GroupsPlannerPlan,
+							ParentPath: "Launch",
+							Planner: details.PlannerInfo{
+								PlanTitle: "Launch",
+							},
+						},
+					},
+				},
+				{
+					RepoRef:     taskItem,
+					ItemRef:     "taskitem",
+					LocationRef: "Launch",
+					ItemInfo: details.ItemInfo{
+						Groups: &details.GroupsInfo{
+							ItemType:   details.GroupsPlannerTask,
+							ParentPath: "Launch",
+							Planner: details.PlannerInfo{
+								PlanTitle: "Launch",
+								TaskTitle: "Write spec",
+							},
+						},
+					},
+				},
 			},
 		},
 	}
@@ -246,7 +277,8 @@ func (suite *GroupsSelectorSuite) TestGroupsRestore_Reduce() {
 			expect: arr(
 				libItem, libItem2, libItem3,
 				chanItem, chanItem2, chanItem3,
-				convItem, convItem2, convItem3),
+				convItem, convItem2, convItem3,
+				planItem, taskItem),
 		},
 		{
 			name: "only match library item",
@@ -323,6 +355,42 @@ func (suite *GroupsSelectorSuite) TestGroupsRestore_Reduce() {
 			},
 			expect: arr(convItem2),
 		},
+		{
+			name: "only match plan",
+			makeSelector: func() *GroupsRestore {
+				sel := NewGroupsRestore(Any())
+				sel.Include(sel.Plans([]string{"Launch"}))
+				return sel
+			},
+			expect: arr(planItem, taskItem),
+		},
+		{
+			name: "only match task by title",
+			makeSelector: func() *GroupsRestore {
+				sel := NewGroupsRestore(Any())
+				sel.Include(sel.PlanTasks(Any(), []string{"Write spec"}))
+				return sel
+			},
+			expect: arr(taskItem),
+		},
+		{
+			name: "only match task by id",
+			makeSelector: func() *GroupsRestore {
+				sel := NewGroupsRestore(Any())
+				sel.Include(sel.PlanTasks([]string{"Launch"}, []string{"taskitem"}))
+				return sel
+			},
+			expect: arr(taskItem),
+		},
+		{
+			name: "plan doesn't match",
+			makeSelector: func() *GroupsRestore {
+				sel := NewGroupsRestore(Any())
+				sel.Include(sel.PlanTasks([]string{"Other"}, Any()))
+				return sel
+			},
+			expect: []string{},
+		},
 		{
 			name: "conversation id doesn't match name",
 			makeSelector: func() *GroupsRestore {
@@ -364,6 +432,7 @@ func (suite *GroupsSelectorSuite) TestGroupsCategory_PathValues() {
 		pathElems  []string
 		locRef     string
 		parentPath string
+		taskTitle  string
 		expected   map[categorizer][]string
 		cfg        Config
 	}{
@@ -389,6 +458,19 @@ func (suite *GroupsSelectorSuite) TestGroupsCategory_PathValues() {
 			},
 			cfg: Config{},
 		},
+		{
+			name:       "Groups Planner Tasks",
+			sc:         GroupsPlanTask,
+			pathElems:  []string{"plan-id", itemID},
+			locRef:     "plan",
+			parentPath: "plan",
+			taskTitle:  "task",
+			expected: map[categorizer][]string{
+				GroupsPlan:     {"plan"},
+				GroupsPlanTask: {itemID, shortRef, "task"},
+			},
+			cfg: Config{},
+		},
 	}
 
 	for _, test := range table {
@@ -413,6 +495,9 @@ func (suite *GroupsSelectorSuite) TestGroupsCategory_PathValues() {
 					Groups: &details.GroupsInfo{
 						ItemName:   itemName,
 						ParentPath: test.parentPath,
+						Planner: details.PlannerInfo{
+							TaskTitle: test.taskTitle,
+						},
 					},
 				},
 			}
@@ -437,6 +522,7 @@ func (suite *GroupsSelectorSuite) TestGroupsScope_MatchesInfo() {
 		future = now.Add(45 * time.Minute)
 		dgcm   = details.GroupsChannelMessage
 		dspl   = details.SharePointLibrary
+		dgpt   = details.GroupsPlannerTask
 	)
 
 	type expectation func(t assert.TestingT, value bool, msg string, args ...any) bool
@@ -500,6 +586,15 @@ func (suite *GroupsSelectorSuite) TestGroupsScope_MatchesInfo() {
 		{"chan msg last reply before future", dgcm, user, sel.MessageLastReplyBefore(dttm.Format(future)), assert.Truef},
 		{"chan msg last reply before now", dgcm, user, sel.MessageLastReplyBefore(dttm.Format(now)), assert.Falsef},
 		{"chan msg last reply before epoch", dgcm, user, sel.MessageLastReplyBefore(dttm.Format(now)), assert.Falsef},
+
+		{"task assigned to", dgpt, user, sel.TaskAssignee(user), assert.Truef},
+		{"task not assigned to", dgpt, user, sel.TaskAssignee(host), assert.Falsef},
+		{"task assigned to wrong type", dgcm, user, sel.TaskAssignee(user), assert.Falsef},
+		{"task due after now", dgpt, user, sel.TaskDueAfter(dttm.Format(now)), assert.Truef},
+		{"task due after later", dgpt, user, sel.TaskDueAfter(dttm.Format(future)), assert.Falsef},
+		{"task due after wrong type", dspl, user, sel.TaskDueAfter(dttm.Format(now)), assert.Falsef},
+		{"task due before future", dgpt, user, sel.TaskDueBefore(dttm.Format(future)), assert.Truef},
+		{"task due before now", dgpt, user, sel.TaskDueBefore(dttm.Format(now)), assert.Falsef},
 	}
 	for _, test := range table {
 		suite.Run(test.name, func() {
@@ -521,6 +616,10 @@ func (suite *GroupsSelectorSuite) TestGroupsScope_MatchesInfo() {
 					LastReply: details.ChannelMessageInfo{
 						CreatedAt: mod,
 					},
+					Planner: details.PlannerInfo{
+						Assignees: []string{test.creator},
+						DueDate:   mod,
+					},
 				},
 			}
 
@@ -556,6 +655,11 @@ func (suite *GroupsSelectorSuite) TestCategory_PathType() {
 		{GroupsLibraryItem, path.LibrariesCategory},
 		{GroupsInfoSiteLibraryDrive, path.LibrariesCategory},
 		{GroupsInfoSite, path.LibrariesCategory},
+		{GroupsPlan, path.PlannerCategory},
+		{GroupsPlanTask, path.PlannerCategory},
+		{GroupsInfoPlanTaskAssignee, path.PlannerCategory},
+		{GroupsInfoPlanTaskDueAfter, path.PlannerCategory},
+		{GroupsInfoPlanTaskDueBefore, path.PlannerCategory},
 	}
 	for _, test := range table {
 		suite.Run(test.cat.String(), func() {
@@ -140,6 +140,10 @@ type Selector struct {
 	// or all filters, to be included.
 	Includes []scope `json:"includes,omitempty"`
 
+	// Backup-time exclusion rules for drive items.  Only used by the
+	// OneDrive, SharePoint, and Groups services.
+	DriveExclusions *DriveExclusions `json:"driveExclusions,omitempty"`
+
 	Cfg Config `json:"cfg,omitempty"`
 }
 
@@ -40,6 +40,7 @@ const (
 // header keys
 const (
 	headerKeyConsistencyLevel = "ConsistencyLevel"
+	headerKeyIfMatch          = "If-Match"
 	headerKeyPrefer           = "Prefer"
 )
 
@@ -80,6 +81,16 @@ func newPreferHeaders(values ...string) *abstractions.RequestHeaders {
 	return headers
 }
 
+// newIfMatchHeaders produces the headers needed to modify resources
+// (ex: planner tasks) that require the caller to provide the etag of
+// the version being replaced.
+func newIfMatchHeaders(etag string) *abstractions.RequestHeaders {
+	headers := abstractions.NewRequestHeaders()
+	headers.Add(headerKeyIfMatch, etag)
+
+	return headers
+}
+
 func newEventualConsistencyHeaders() *abstractions.RequestHeaders {
 	headers := abstractions.NewRequestHeaders()
 	headers.Add(headerKeyConsistencyLevel, eventual)
@@ -62,6 +62,12 @@ const (
 
 	PersonEmailKey = "Email"
 
+	// the hidden list in which sharepoint tracks the users known to a site.
+	// Person columns reference users by their item ID in this list.
+	UserInformationListName = "User Information List"
+	UserInfoEmailFieldName  = "EMail"
+	UserInfoLoginFieldName  = "UserName"
+
 	MetadataLabelKey    = "Label"
 	MetadataTermGUIDKey = "TermGuid"
 	MetadataWssIDKey    = "WssId"
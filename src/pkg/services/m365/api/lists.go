@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/alcionai/clues"
@@ -216,6 +217,109 @@ func (c Lists) PostList(
 	return restoredList, nil
 }
 
+// GetSiteUserLookupIDs maps the email and login name of every user known to
+// the site onto the lookup ID that person columns use to reference them.
+// Sharepoint tracks those users in the site's hidden user information list.
+func (c Lists) GetSiteUserLookupIDs(
+	ctx context.Context,
+	siteID string,
+) (map[string]float64, error) {
+	cc := CallConfig{
+		Expand: []string{"fields($select=" + UserInfoEmailFieldName + "," + UserInfoLoginFieldName + ")"},
+	}
+
+	items, err := c.GetListItems(ctx, siteID, UserInformationListName, cc)
+	if err != nil {
+		return nil, clues.Wrap(err, "getting site users")
+	}
+
+	lookupIDs := map[string]float64{}
+
+	for _, item := range items {
+		id, err := strconv.ParseFloat(ptr.Val(item.GetId()), 64)
+		if err != nil || item.GetFields() == nil {
+			continue
+		}
+
+		fields := item.GetFields().GetAdditionalData()
+
+		for _, fn := range []string{UserInfoEmailFieldName, UserInfoLoginFieldName} {
+			if v, ok := fields[fn].(*string); ok && len(ptr.Val(v)) > 0 {
+				lookupIDs[strings.ToLower(ptr.Val(v))] = id
+			}
+		}
+	}
+
+	return lookupIDs, nil
+}
+
+// TranslatePersonFields rewrites the person columns of the list's items so
+// that they reference people in the site being restored into.  Resolve
+// maps the email of a backed up person onto the lookup ID of the person that
+// replaces them.  People who can't be resolved are removed.  Single value
+// person columns only hold a lookup ID into the backed up site, which can't
+// be resolved, so those values are removed as well.
+func TranslatePersonFields(
+	lst models.Listable,
+	resolve func(email string) (float64, string, bool),
+) {
+	personColumns := []string{}
+
+	for _, cd := range lst.GetColumns() {
+		if cd.GetPersonOrGroup() != nil {
+			personColumns = append(personColumns, ptr.Val(cd.GetName()))
+		}
+	}
+
+	if len(personColumns) == 0 {
+		return
+	}
+
+	for _, item := range lst.GetItems() {
+		if item.GetFields() == nil {
+			continue
+		}
+
+		fields := item.GetFields().GetAdditionalData()
+
+		for _, colName := range personColumns {
+			delete(fields, colName+LookupIDFieldNamePart)
+
+			people, ok := fields[colName].([]any)
+			if !ok {
+				continue
+			}
+
+			translated := make([]any, 0, len(people))
+
+			for _, person := range people {
+				md, ok := person.(map[string]any)
+				if !ok {
+					continue
+				}
+
+				email, _ := md[PersonEmailKey].(*string)
+
+				id, target, ok := resolve(ptr.Val(email))
+				if !ok {
+					continue
+				}
+
+				md[LookupIDKey] = ptr.To(id)
+				md[PersonEmailKey] = ptr.To(target)
+				translated = append(translated, md)
+			}
+
+			if len(translated) == 0 {
+				delete(fields, colName)
+				continue
+			}
+
+			fields[colName] = translated
+		}
+	}
+}
+
 func (c Lists) PostListItems(
 	ctx context.Context,
 	siteID, listID string,
@@ -150,6 +150,10 @@ func (c Lists) NewListItemsPager(
 		options.QueryParameters.Select = cc.Select
 	}
 
+	if len(cc.Expand) > 0 {
+		options.QueryParameters.Expand = cc.Expand
+	}
+
 	return &listItemsPageCtrl{
 		siteID:  siteID,
 		listID:  listID,
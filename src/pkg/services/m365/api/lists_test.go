@@ -1124,6 +1124,73 @@ func (suite *ListsUnitSuite) TestCheckFields() {
 	}
 }
 
+func (suite *ListsUnitSuite) TestTranslatePersonFields() {
+	t := suite.T()
+
+	person := func(id float64, email string) map[string]any {
+		return map[string]any{
+			LookupIDKey:    ptr.To(id),
+			LookupValueKey: ptr.To(email),
+			PersonEmailKey: ptr.To(email),
+		}
+	}
+
+	personCol := models.NewColumnDefinition()
+	personCol.SetName(ptr.To("owners"))
+	personCol.SetPersonOrGroup(models.NewPersonOrGroupColumn())
+
+	singleCol := models.NewColumnDefinition()
+	singleCol.SetName(ptr.To("reviewer"))
+	singleCol.SetPersonOrGroup(models.NewPersonOrGroupColumn())
+
+	textCol := models.NewColumnDefinition()
+	textCol.SetName(ptr.To("notes"))
+	textCol.SetText(models.NewTextColumn())
+
+	fields := models.NewFieldValueSet()
+	fields.SetAdditionalData(map[string]any{
+		"owners": []any{
+			person(1, "alice@source.com"),
+			person(2, "bob@source.com"),
+		},
+		"reviewerLookupId": ptr.To("3"),
+		"notes":            ptr.To("keep me"),
+	})
+
+	noneFields := models.NewFieldValueSet()
+	noneFields.SetAdditionalData(map[string]any{
+		"owners": []any{person(2, "bob@source.com")},
+	})
+
+	item := models.NewListItem()
+	item.SetFields(fields)
+
+	noneItem := models.NewListItem()
+	noneItem.SetFields(noneFields)
+
+	lst := models.NewList()
+	lst.SetColumns([]models.ColumnDefinitionable{personCol, singleCol, textCol})
+	lst.SetItems([]models.ListItemable{item, noneItem})
+
+	TranslatePersonFields(lst, func(email string) (float64, string, bool) {
+		if email == "alice@source.com" {
+			return 11, "alice@target.com", true
+		}
+
+		return 0, "", false
+	})
+
+	result := item.GetFields().GetAdditionalData()
+	expect := person(11, "alice@target.com")
+	expect[LookupValueKey] = ptr.To("alice@source.com")
+
+	assert.Equal(t, []any{expect}, result["owners"], "resolved people are translated")
+	assert.NotContains(t, result, "reviewerLookupId", "single person values are removed")
+	assert.Equal(t, ptr.To("keep me"), result["notes"], "other columns are untouched")
+
+	assert.NotContains(t, noneItem.GetFields().GetAdditionalData(), "owners", "columns without resolved people are removed")
+}
+
 type ListsAPIIntgSuite struct {
 	tester.Suite
 	its intgTesterSetup
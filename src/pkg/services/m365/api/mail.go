@@ -643,6 +643,7 @@ func MailInfo(msg models.Messageable, size int64) *details.ExchangeInfo {
 		received   = ptr.Val(msg.GetReceivedDateTime())
 		created    = ptr.Val(msg.GetCreatedDateTime())
 		recipients = make([]string, 0)
+		attNames   []string
 	)
 
 	if msg.GetToRecipients() != nil {
@@ -655,15 +656,26 @@ func MailInfo(msg models.Messageable, size int64) *details.ExchangeInfo {
 		}
 	}
 
+	// attachments are only present if they were fetched along with the
+	// message body (see GetItem).
+	for _, att := range msg.GetAttachments() {
+		name := ptr.Val(att.GetName())
+		if len(name) > 0 {
+			attNames = append(attNames, name)
+		}
+	}
+
 	return &details.ExchangeInfo{
-		ItemType:  details.ExchangeMail,
-		Sender:    sender,
-		Recipient: recipients,
-		Subject:   subject,
-		Received:  received,
-		Size:      size,
-		Created:   created,
-		Modified:  ptr.OrNow(msg.GetLastModifiedDateTime()),
+		ItemType:        details.ExchangeMail,
+		Sender:          sender,
+		Recipient:       recipients,
+		Subject:         subject,
+		Received:        received,
+		Size:            size,
+		HasAttachments:  ptr.Val(msg.GetHasAttachments()) || len(attNames) > 0,
+		AttachmentNames: attNames,
+		Created:         created,
+		Modified:        ptr.OrNow(msg.GetLastModifiedDateTime()),
 	}
 }
 
@@ -115,6 +115,29 @@ func (suite *MailAPIUnitSuite) TestMailInfo() {
 				return msg, i
 			},
 		},
+		{
+			name: "With attachments",
+			msgAndRP: func() (models.Messageable, *details.ExchangeInfo) {
+				att := models.NewFileAttachment()
+				att.SetName(ptr.To("report.pdf"))
+
+				msg := models.NewMessage()
+				msg.SetCreatedDateTime(&initial)
+				msg.SetLastModifiedDateTime(&initial)
+				msg.SetHasAttachments(ptr.To(true))
+				msg.SetAttachments([]models.Attachmentable{att})
+
+				i := &details.ExchangeInfo{
+					ItemType:        details.ExchangeMail,
+					Recipient:       []string{},
+					HasAttachments:  true,
+					AttachmentNames: []string{"report.pdf"},
+					Created:         initial,
+					Modified:        initial,
+				}
+				return msg, i
+			},
+		},
 		{
 			name: "All fields",
 			msgAndRP: func() (models.Messageable, *details.ExchangeInfo) {
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/users"
+
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) MailboxSettings() MailboxSettings {
+	return MailboxSettings{c}
+}
+
+// MailboxSettings is an interface-compliant provider of the client.
+// It covers the per-mailbox configuration that lives outside of the
+// mail, contact, and calendar items: the mailboxSettings resource,
+// inbox rules, outlook master categories, and the default calendar's
+// sharing permissions.
+type MailboxSettings struct {
+	Client
+}
+
+// ---------------------------------------------------------------------------
+// mailbox settings
+// ---------------------------------------------------------------------------
+
+// GetSettings retrieves the user's mailboxSettings resource, which includes
+// the automatic replies configuration, time zone, and working hours.
+func (c MailboxSettings) GetSettings(
+	ctx context.Context,
+	userID string,
+) (models.MailboxSettingsable, error) {
+	resp, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		MailboxSettings().
+		Get(ctx, nil)
+
+	return resp, clues.Wrap(err, "getting mailbox settings").OrNil()
+}
+
+// UpdateAutomaticReplies replaces the user's automatic replies configuration.
+// No other mailbox settings are modified.
+func (c MailboxSettings) UpdateAutomaticReplies(
+	ctx context.Context,
+	userID string,
+	replies models.AutomaticRepliesSettingable,
+) error {
+	body := models.NewMailboxSettings()
+	body.SetAutomaticRepliesSetting(replies)
+
+	_, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		MailboxSettings().
+		Patch(ctx, body, nil)
+
+	return clues.Wrap(err, "updating automatic replies").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// inbox rules
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.MessageRuleable] = &inboxRulesPageCtrl{}
+
+type inboxRulesPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemMailFoldersItemMessageRulesRequestBuilder
+}
+
+func (p *inboxRulesPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemMailFoldersItemMessageRulesRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *inboxRulesPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.MessageRuleable], error) {
+	resp, err := p.builder.Get(ctx, nil)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *inboxRulesPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c MailboxSettings) NewInboxRulesPager(userID string) *inboxRulesPageCtrl {
+	builder := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		MailFolders().
+		ByMailFolderId(MailInbox).
+		MessageRules()
+
+	return &inboxRulesPageCtrl{
+		gs:      c.Stable,
+		builder: builder,
+	}
+}
+
+// GetInboxRules retrieves all message rules defined on the user's inbox.
+func (c MailboxSettings) GetInboxRules(
+	ctx context.Context,
+	userID string,
+) ([]models.MessageRuleable, error) {
+	pager := c.NewInboxRulesPager(userID)
+	items, err := pagers.BatchEnumerateItems[models.MessageRuleable](ctx, pager)
+
+	return items, clues.Wrap(err, "getting inbox rules").OrNil()
+}
+
+// PostInboxRule creates a new message rule on the user's inbox.
+func (c MailboxSettings) PostInboxRule(
+	ctx context.Context,
+	userID string,
+	body models.MessageRuleable,
+) (models.MessageRuleable, error) {
+	resp, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		MailFolders().
+		ByMailFolderId(MailInbox).
+		MessageRules().
+		Post(ctx, body, nil)
+
+	return resp, clues.Wrap(err, "creating inbox rule").OrNil()
+}
+
+// DeleteInboxRule removes a message rule from the user's inbox.
+func (c MailboxSettings) DeleteInboxRule(
+	ctx context.Context,
+	userID, ruleID string,
+) error {
+	// deletes require unique http clients
+	// https://github.com/alcionai/corso/issues/2707
+	srv, err := NewService(c.Credentials, c.counter)
+	if err != nil {
+		return clues.StackWC(ctx, err)
+	}
+
+	err = srv.
+		Client().
+		Users().
+		ByUserId(userID).
+		MailFolders().
+		ByMailFolderId(MailInbox).
+		MessageRules().
+		ByMessageRuleId(ruleID).
+		Delete(ctx, nil)
+
+	return clues.Wrap(err, "deleting inbox rule").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// master categories
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.OutlookCategoryable] = &masterCategoriesPageCtrl{}
+
+type masterCategoriesPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemOutlookMasterCategoriesRequestBuilder
+}
+
+func (p *masterCategoriesPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemOutlookMasterCategoriesRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *masterCategoriesPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.OutlookCategoryable], error) {
+	resp, err := p.builder.Get(ctx, nil)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *masterCategoriesPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c MailboxSettings) NewMasterCategoriesPager(userID string) *masterCategoriesPageCtrl {
+	builder := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		Outlook().
+		MasterCategories()
+
+	return &masterCategoriesPageCtrl{
+		gs:      c.Stable,
+		builder: builder,
+	}
+}
+
+// GetMasterCategories retrieves the user's outlook master category list.
+func (c MailboxSettings) GetMasterCategories(
+	ctx context.Context,
+	userID string,
+) ([]models.OutlookCategoryable, error) {
+	pager := c.NewMasterCategoriesPager(userID)
+	items, err := pagers.BatchEnumerateItems[models.OutlookCategoryable](ctx, pager)
+
+	return items, clues.Wrap(err, "getting master categories").OrNil()
+}
+
+// PostMasterCategory adds a category to the user's master category list.
+func (c MailboxSettings) PostMasterCategory(
+	ctx context.Context,
+	userID string,
+	body models.OutlookCategoryable,
+) (models.OutlookCategoryable, error) {
+	resp, err := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		Outlook().
+		MasterCategories().
+		Post(ctx, body, nil)
+
+	return resp, clues.Wrap(err, "creating master category").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// calendar permissions
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.CalendarPermissionable] = &calendarPermissionsPageCtrl{}
+
+type calendarPermissionsPageCtrl struct {
+	gs      graph.Servicer
+	builder *users.ItemCalendarCalendarPermissionsRequestBuilder
+}
+
+func (p *calendarPermissionsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = users.NewItemCalendarCalendarPermissionsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *calendarPermissionsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.CalendarPermissionable], error) {
+	resp, err := p.builder.Get(ctx, nil)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *calendarPermissionsPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c MailboxSettings) NewCalendarPermissionsPager(userID string) *calendarPermissionsPageCtrl {
+	builder := c.Stable.
+		Client().
+		Users().
+		ByUserId(userID).
+		Calendar().
+		CalendarPermissions()
+
+	return &calendarPermissionsPageCtrl{
+		gs:      c.Stable,
+		builder: builder,
+	}
+}
+
+// GetCalendarPermissions retrieves the sharing and delegate permissions
+// granted on the user's default calendar.
+func (c MailboxSettings) GetCalendarPermissions(
+	ctx context.Context,
+	userID string,
+) ([]models.CalendarPermissionable, error) {
+	pager := c.NewCalendarPermissionsPager(userID)
+	items, err := pagers.BatchEnumerateItems[models.CalendarPermissionable](ctx, pager)
+
+	return items, clues.Wrap(err, "getting calendar permissions").OrNil()
+}
@@ -0,0 +1,364 @@
+package api
+
+import (
+	"context"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/groups"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/microsoftgraph/msgraph-sdk-go/planner"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/graph"
+	"github.com/alcionai/corso/src/pkg/services/m365/api/pagers"
+)
+
+// AddtlDataETag is the additionalData key holding the etag of planner
+// resources.  Planner requires the etag for all updates and deletes.
+const AddtlDataETag = "@odata.etag"
+
+// ---------------------------------------------------------------------------
+// controller
+// ---------------------------------------------------------------------------
+
+func (c Client) Planner() Planner {
+	return Planner{c}
+}
+
+// Planner is an interface-compliant provider of the client.
+// It covers the plans owned by a group, along with the buckets
+// and tasks within those plans.
+type Planner struct {
+	Client
+}
+
+// ETagOf returns the etag of a planner resource, or an empty string
+// if the resource wasn't retrieved with one.
+func ETagOf(v interface{ GetAdditionalData() map[string]any }) string {
+	if v == nil {
+		return ""
+	}
+
+	switch etag := v.GetAdditionalData()[AddtlDataETag].(type) {
+	case *string:
+		return ptr.Val(etag)
+	case string:
+		return etag
+	}
+
+	return ""
+}
+
+// ---------------------------------------------------------------------------
+// plans
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.PlannerPlanable] = &plansPageCtrl{}
+
+type plansPageCtrl struct {
+	gs      graph.Servicer
+	builder *groups.ItemPlannerPlansRequestBuilder
+}
+
+func (p *plansPageCtrl) SetNextLink(nextLink string) {
+	p.builder = groups.NewItemPlannerPlansRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *plansPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.PlannerPlanable], error) {
+	resp, err := p.builder.Get(ctx, nil)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *plansPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Planner) NewPlansPager(groupID string) *plansPageCtrl {
+	builder := c.Stable.
+		Client().
+		Groups().
+		ByGroupId(groupID).
+		Planner().
+		Plans()
+
+	return &plansPageCtrl{
+		gs:      c.Stable,
+		builder: builder,
+	}
+}
+
+// GetPlans retrieves all plans owned by the group.
+func (c Planner) GetPlans(
+	ctx context.Context,
+	groupID string,
+) ([]models.PlannerPlanable, error) {
+	pager := c.NewPlansPager(groupID)
+	items, err := pagers.BatchEnumerateItems[models.PlannerPlanable](ctx, pager)
+
+	return items, clues.Wrap(err, "getting plans").OrNil()
+}
+
+// GetPlanDetails retrieves the details of the plan, which include the
+// names given to the plan's categories (labels).
+func (c Planner) GetPlanDetails(
+	ctx context.Context,
+	planID string,
+) (models.PlannerPlanDetailsable, error) {
+	resp, err := c.Stable.
+		Client().
+		Planner().
+		Plans().
+		ByPlannerPlanId(planID).
+		Details().
+		Get(ctx, nil)
+
+	return resp, clues.Wrap(err, "getting plan details").OrNil()
+}
+
+// PostPlan creates a new plan within the group.
+func (c Planner) PostPlan(
+	ctx context.Context,
+	groupID, title string,
+) (models.PlannerPlanable, error) {
+	container := models.NewPlannerPlanContainer()
+	container.SetUrl(ptr.To("https://graph.microsoft.com/v1.0/groups/" + groupID))
+
+	body := models.NewPlannerPlan()
+	body.SetTitle(ptr.To(title))
+	body.SetContainer(container)
+
+	resp, err := c.Stable.
+		Client().
+		Planner().
+		Plans().
+		Post(ctx, body, nil)
+
+	return resp, clues.Wrap(err, "creating plan").OrNil()
+}
+
+// UpdatePlanDetails patches the details of the plan.  The etag must
+// match the current version of the details.
+func (c Planner) UpdatePlanDetails(
+	ctx context.Context,
+	planID, etag string,
+	body models.PlannerPlanDetailsable,
+) error {
+	config := &planner.PlansItemDetailsRequestBuilderPatchRequestConfiguration{
+		Headers: newIfMatchHeaders(etag),
+	}
+
+	_, err := c.Stable.
+		Client().
+		Planner().
+		Plans().
+		ByPlannerPlanId(planID).
+		Details().
+		Patch(ctx, body, config)
+
+	return clues.Wrap(err, "updating plan details").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// buckets
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.PlannerBucketable] = &bucketsPageCtrl{}
+
+type bucketsPageCtrl struct {
+	gs      graph.Servicer
+	builder *planner.PlansItemBucketsRequestBuilder
+}
+
+func (p *bucketsPageCtrl) SetNextLink(nextLink string) {
+	p.builder = planner.NewPlansItemBucketsRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *bucketsPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.PlannerBucketable], error) {
+	resp, err := p.builder.Get(ctx, nil)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *bucketsPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Planner) NewBucketsPager(planID string) *bucketsPageCtrl {
+	builder := c.Stable.
+		Client().
+		Planner().
+		Plans().
+		ByPlannerPlanId(planID).
+		Buckets()
+
+	return &bucketsPageCtrl{
+		gs:      c.Stable,
+		builder: builder,
+	}
+}
+
+// GetBuckets retrieves all buckets in the plan.
+func (c Planner) GetBuckets(
+	ctx context.Context,
+	planID string,
+) ([]models.PlannerBucketable, error) {
+	pager := c.NewBucketsPager(planID)
+	items, err := pagers.BatchEnumerateItems[models.PlannerBucketable](ctx, pager)
+
+	return items, clues.Wrap(err, "getting buckets").OrNil()
+}
+
+// PostBucket creates a new bucket within the plan.
+func (c Planner) PostBucket(
+	ctx context.Context,
+	planID, name string,
+) (models.PlannerBucketable, error) {
+	body := models.NewPlannerBucket()
+	body.SetPlanId(ptr.To(planID))
+	body.SetName(ptr.To(name))
+	// places the bucket after all existing buckets
+	body.SetOrderHint(ptr.To(" !"))
+
+	resp, err := c.Stable.
+		Client().
+		Planner().
+		Buckets().
+		Post(ctx, body, nil)
+
+	return resp, clues.Wrap(err, "creating bucket").OrNil()
+}
+
+// ---------------------------------------------------------------------------
+// tasks
+// ---------------------------------------------------------------------------
+
+var _ pagers.NonDeltaHandler[models.PlannerTaskable] = &tasksPageCtrl{}
+
+type tasksPageCtrl struct {
+	gs      graph.Servicer
+	builder *planner.PlansItemTasksRequestBuilder
+}
+
+func (p *tasksPageCtrl) SetNextLink(nextLink string) {
+	p.builder = planner.NewPlansItemTasksRequestBuilder(nextLink, p.gs.Adapter())
+}
+
+func (p *tasksPageCtrl) GetPage(
+	ctx context.Context,
+) (pagers.NextLinkValuer[models.PlannerTaskable], error) {
+	resp, err := p.builder.Get(ctx, nil)
+	return resp, clues.Stack(err).OrNil()
+}
+
+func (p *tasksPageCtrl) ValidModTimes() bool {
+	return false
+}
+
+func (c Planner) NewTasksPager(planID string) *tasksPageCtrl {
+	builder := c.Stable.
+		Client().
+		Planner().
+		Plans().
+		ByPlannerPlanId(planID).
+		Tasks()
+
+	return &tasksPageCtrl{
+		gs:      c.Stable,
+		builder: builder,
+	}
+}
+
+// GetTasks retrieves all tasks in the plan.
+func (c Planner) GetTasks(
+	ctx context.Context,
+	planID string,
+) ([]models.PlannerTaskable, error) {
+	pager := c.NewTasksPager(planID)
+	items, err := pagers.BatchEnumerateItems[models.PlannerTaskable](ctx, pager)
+
+	return items, clues.Wrap(err, "getting tasks").OrNil()
+}
+
+// GetTaskDetails retrieves the details of the task: its description,
+// checklist, and references.
+func (c Planner) GetTaskDetails(
+	ctx context.Context,
+	taskID string,
+) (models.PlannerTaskDetailsable, error) {
+	resp, err := c.Stable.
+		Client().
+		Planner().
+		Tasks().
+		ByPlannerTaskId(taskID).
+		Details().
+		Get(ctx, nil)
+
+	return resp, clues.Wrap(err, "getting task details").OrNil()
+}
+
+// PostTask creates a new task.  The body must identify the plan, and
+// may identify a bucket, that contain the task.
+func (c Planner) PostTask(
+	ctx context.Context,
+	body models.PlannerTaskable,
+) (models.PlannerTaskable, error) {
+	resp, err := c.Stable.
+		Client().
+		Planner().
+		Tasks().
+		Post(ctx, body, nil)
+
+	return resp, clues.Wrap(err, "creating task").OrNil()
+}
+
+// UpdateTaskDetails patches the details of the task.  The etag must
+// match the current version of the details.
+func (c Planner) UpdateTaskDetails(
+	ctx context.Context,
+	taskID, etag string,
+	body models.PlannerTaskDetailsable,
+) error {
+	config := &planner.TasksItemDetailsRequestBuilderPatchRequestConfiguration{
+		Headers: newIfMatchHeaders(etag),
+	}
+
+	_, err := c.Stable.
+		Client().
+		Planner().
+		Tasks().
+		ByPlannerTaskId(taskID).
+		Details().
+		Patch(ctx, body, config)
+
+	return clues.Wrap(err, "updating task details").OrNil()
+}
+
+// DeleteTask removes the task.  The etag must match the current
+// version of the task.
+func (c Planner) DeleteTask(
+	ctx context.Context,
+	taskID, etag string,
+) error {
+	// deletes require unique http clients
+	// https://github.com/alcionai/corso/issues/2707
+	srv, err := NewService(c.Credentials, c.counter)
+	if err != nil {
+		return clues.StackWC(ctx, err)
+	}
+
+	config := &planner.TasksPlannerTaskItemRequestBuilderDeleteRequestConfiguration{
+		Headers: newIfMatchHeaders(etag),
+	}
+
+	err = srv.
+		Client().
+		Planner().
+		Tasks().
+		ByPlannerTaskId(taskID).
+		Delete(ctx, config)
+
+	return clues.Wrap(err, "deleting task").OrNil()
+}
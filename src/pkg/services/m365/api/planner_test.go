@@ -0,0 +1,41 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/alcionai/clues"
+	"github.com/microsoftgraph/msgraph-sdk-go/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/common/ptr"
+	"github.com/alcionai/corso/src/internal/tester"
+)
+
+type PlannerUnitSuite struct {
+	tester.Suite
+}
+
+func TestPlannerUnitSuite(t *testing.T) {
+	suite.Run(t, &PlannerUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func (suite *PlannerUnitSuite) TestETagOf() {
+	t := suite.T()
+
+	v, err := CreateFromBytes(
+		[]byte(`{"@odata.etag":"W/\"JzEtVGFzayAgQEBAQEBAQEBAQEBAQEBARCc=\"","id":"tid","title":"task"}`),
+		models.CreatePlannerTaskFromDiscriminatorValue)
+	require.NoError(t, err, clues.ToCore(err))
+
+	task := v.(models.PlannerTaskable)
+	assert.Equal(t, "tid", ptr.Val(task.GetId()))
+	assert.Equal(t, `W/"JzEtVGFzayAgQEBAQEBAQEBAQEBAQEBARCc="`, ETagOf(task))
+
+	manual := models.NewPlannerTaskDetails()
+	manual.SetAdditionalData(map[string]any{AddtlDataETag: "etag"})
+	assert.Equal(t, "etag", ETagOf(manual))
+
+	assert.Empty(t, ETagOf(models.NewPlannerBucket()))
+}
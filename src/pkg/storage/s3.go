@@ -2,7 +2,6 @@ package storage
 
 import (
 	"encoding/json"
-	"os"
 	"reflect"
 	"slices"
 	"strconv"
@@ -223,18 +222,26 @@ func (c *S3Config) ApplyConfigOverrides(
 
 	c.s3CredsFromStore(kvg)
 
+	secrets, err := credentials.FromProcess(cast.ToString(kvg.Get(credentials.CredentialProcess)))
+	if err != nil {
+		return clues.Stack(err)
+	}
+
 	aws := credentials.AWS{
-		AccessKey: str.First(
+		AccessKey: credentials.Resolve(
 			overrides[credentials.AWSAccessKeyID],
-			os.Getenv(credentials.AWSAccessKeyID),
+			credentials.AWSAccessKeyID,
+			secrets,
 			c.AccessKey),
-		SecretKey: str.First(
+		SecretKey: credentials.Resolve(
 			overrides[credentials.AWSSecretAccessKey],
-			os.Getenv(credentials.AWSSecretAccessKey),
+			credentials.AWSSecretAccessKey,
+			secrets,
 			c.SecretKey),
-		SessionToken: str.First(
+		SessionToken: credentials.Resolve(
 			overrides[credentials.AWSSessionToken],
-			os.Getenv(credentials.AWSSessionToken),
+			credentials.AWSSessionToken,
+			secrets,
 			c.SessionToken),
 	}
 
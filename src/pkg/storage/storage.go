@@ -3,6 +3,7 @@ package storage
 import (
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/alcionai/clues"
 	"github.com/spf13/cast"
@@ -117,6 +118,39 @@ func (s Storage) StorageConfig() (Configurer, error) {
 	return nil, errInvalidProvider.With("provider", s.Provider)
 }
 
+// Location describes where the storage keeps the repository, for display
+// to users.  It never includes credentials.
+func (s Storage) Location() (string, error) {
+	switch s.Provider {
+	case ProviderS3:
+		cfg, err := s.ToS3Config()
+		if err != nil {
+			return "", clues.Stack(err)
+		}
+
+		loc := "s3://" + cfg.Bucket
+		if len(cfg.Prefix) > 0 {
+			loc += "/" + strings.TrimPrefix(cfg.Prefix, "/")
+		}
+
+		if len(cfg.Endpoint) > 0 {
+			loc += " (" + cfg.Endpoint + ")"
+		}
+
+		return loc, nil
+
+	case ProviderFilesystem:
+		cfg, err := s.ToFilesystemConfig()
+		if err != nil {
+			return "", clues.Stack(err)
+		}
+
+		return cfg.Path, nil
+	}
+
+	return "", errInvalidProvider.With("provider", s.Provider)
+}
+
 func (s Storage) GetStorageConfigHash() (string, error) {
 	switch s.Provider {
 	case ProviderS3:
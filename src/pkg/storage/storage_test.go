@@ -269,3 +269,56 @@ func (suite *StorageUnitSuite) TestMustMatchConfig() {
 		})
 	}
 }
+
+func (suite *StorageUnitSuite) TestLocation() {
+	table := []struct {
+		name      string
+		provider  ProviderType
+		cfg       map[string]string
+		expect    string
+		expectErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "s3",
+			provider: ProviderS3,
+			cfg: map[string]string{
+				keyS3Bucket:    "bkt",
+				keyS3Prefix:    "corso/",
+				keyS3Endpoint:  "minio.example.com:9000",
+				keyS3SecretKey: "secret",
+			},
+			expect:    "s3://bkt/corso/ (minio.example.com:9000)",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "s3 bucket only",
+			provider:  ProviderS3,
+			cfg:       map[string]string{keyS3Bucket: "bkt"},
+			expect:    "s3://bkt",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "filesystem",
+			provider:  ProviderFilesystem,
+			cfg:       map[string]string{FilesystemPath: "/var/corso"},
+			expect:    "/var/corso",
+			expectErr: assert.NoError,
+		},
+		{
+			name:      "unknown",
+			provider:  ProviderUnknown,
+			expectErr: assert.Error,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			st := Storage{Provider: test.provider, Config: test.cfg}
+
+			loc, err := st.Location()
+			test.expectErr(t, err, clues.ToCore(err))
+			assert.Equal(t, test.expect, loc)
+		})
+	}
+}
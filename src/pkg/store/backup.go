@@ -2,6 +2,8 @@ package store
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/alcionai/clues"
 	"github.com/kopia/kopia/repo/manifest"
@@ -13,6 +15,9 @@ import (
 
 type queryFilters struct {
 	tags map[string]string
+	// matchers filter out backups using properties that aren't stored
+	// as tags.
+	matchers []func(*backup.Backup) bool
 }
 
 type FilterOption func(*queryFilters)
@@ -29,6 +34,17 @@ func (q *queryFilters) populate(qf ...FilterOption) {
 	}
 }
 
+// matches is true if the backup passes every matcher.
+func (q *queryFilters) matches(b *backup.Backup) bool {
+	for _, m := range q.matchers {
+		if !m(b) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Service ensures the retrieved backups only match
 // the specified service.
 func Service(pst path.ServiceType) FilterOption {
@@ -37,6 +53,68 @@ func Service(pst path.ServiceType) FilterOption {
 	}
 }
 
+// Label ensures the retrieved backups only match
+// the specified label key and value.
+func Label(key, value string) FilterOption {
+	return func(qf *queryFilters) {
+		qf.tags[backup.LabelTag(key)] = value
+	}
+}
+
+// ProtectedResource ensures the retrieved backups only match the
+// protected resource with the specified ID or name.  Names are
+// compared case-insensitively.
+func ProtectedResource(idOrName string) FilterOption {
+	return func(qf *queryFilters) {
+		qf.matchers = append(qf.matchers, func(b *backup.Backup) bool {
+			for _, v := range []string{
+				b.ProtectedResourceID,
+				b.ProtectedResourceName,
+				b.ResourceOwnerID,
+				b.ResourceOwnerName,
+				b.Selector.DiscreteOwner,
+				b.Selector.DiscreteOwnerName,
+			} {
+				if len(v) > 0 && strings.EqualFold(v, idOrName) {
+					return true
+				}
+			}
+
+			return false
+		})
+	}
+}
+
+// Since ensures the retrieved backups were created
+// at or after the specified time.
+func Since(t time.Time) FilterOption {
+	return func(qf *queryFilters) {
+		qf.matchers = append(qf.matchers, func(b *backup.Backup) bool {
+			return !b.CreationTime.Before(t)
+		})
+	}
+}
+
+// Until ensures the retrieved backups were created
+// at or before the specified time.
+func Until(t time.Time) FilterOption {
+	return func(qf *queryFilters) {
+		qf.matchers = append(qf.matchers, func(b *backup.Backup) bool {
+			return !b.CreationTime.After(t)
+		})
+	}
+}
+
+// Status ensures the retrieved backups only match the specified
+// status, compared case-insensitively.
+func Status(status string) FilterOption {
+	return func(qf *queryFilters) {
+		qf.matchers = append(qf.matchers, func(b *backup.Backup) bool {
+			return strings.EqualFold(b.Status, status)
+		})
+	}
+}
+
 type (
 	BackupWrapper interface {
 		BackupGetterDeleter
@@ -55,6 +133,15 @@ type (
 		GetBackup(ctx context.Context, backupID model.StableID) (*backup.Backup, error)
 	}
 
+	BackupUpdater interface {
+		UpdateBackup(ctx context.Context, b *backup.Backup) error
+	}
+
+	BackupGetterUpdater interface {
+		BackupGetter
+		BackupUpdater
+	}
+
 	BackupDeleter interface {
 		DeleteBackup(ctx context.Context, backupID model.StableID) error
 	}
@@ -117,9 +204,9 @@ func (w wrapper) GetBackups(
 		return nil, err
 	}
 
-	bs := make([]*backup.Backup, len(bms))
+	bs := make([]*backup.Backup, 0, len(bms))
 
-	for i, bm := range bms {
+	for _, bm := range bms {
 		b := &backup.Backup{}
 
 		err := w.GetWithModelStoreID(ctx, model.BackupSchema, bm.ModelStoreID, b)
@@ -127,12 +214,23 @@ func (w wrapper) GetBackups(
 			return nil, err
 		}
 
-		bs[i] = b
+		if q.matches(b) {
+			bs = append(bs, b)
+		}
 	}
 
 	return bs, nil
 }
 
+// UpdateBackup replaces the stored backup model with b.
+func (w wrapper) UpdateBackup(ctx context.Context, b *backup.Backup) error {
+	if err := w.Update(ctx, model.BackupSchema, b); err != nil {
+		return clues.Wrap(err, "updating backup")
+	}
+
+	return nil
+}
+
 // DeleteBackup deletes the backup and its details entry from the model store.
 func (w wrapper) DeleteBackup(ctx context.Context, backupID model.StableID) error {
 	return w.Delete(ctx, model.BackupSchema, backupID)
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/kopia/kopia/repo/manifest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/alcionai/corso/src/internal/model"
@@ -119,6 +120,109 @@ func (suite *StoreBackupUnitSuite) TestGetBackups() {
 	}
 }
 
+func (suite *StoreBackupUnitSuite) TestGetBackups_filters() {
+	created := time.Now()
+
+	fbu := bu
+	fbu.CreationTime = created
+	fbu.Status = "Completed"
+	fbu.ProtectedResourceID = "uid"
+	fbu.ProtectedResourceName = "Finance@example.com"
+
+	table := []struct {
+		name    string
+		filters []store.FilterOption
+		expect  int
+	}{
+		{
+			name:   "no filters",
+			expect: 1,
+		},
+		{
+			name:    "resource id",
+			filters: []store.FilterOption{store.ProtectedResource("uid")},
+			expect:  1,
+		},
+		{
+			name:    "resource name ignores case",
+			filters: []store.FilterOption{store.ProtectedResource("finance@example.com")},
+			expect:  1,
+		},
+		{
+			name:    "other resource",
+			filters: []store.FilterOption{store.ProtectedResource("other")},
+			expect:  0,
+		},
+		{
+			name: "within time range",
+			filters: []store.FilterOption{
+				store.Since(created.Add(-time.Hour)),
+				store.Until(created),
+			},
+			expect: 1,
+		},
+		{
+			name:    "before since",
+			filters: []store.FilterOption{store.Since(created.Add(time.Hour))},
+			expect:  0,
+		},
+		{
+			name:    "after until",
+			filters: []store.FilterOption{store.Until(created.Add(-time.Hour))},
+			expect:  0,
+		},
+		{
+			name:    "status ignores case",
+			filters: []store.FilterOption{store.Status("completed")},
+			expect:  1,
+		},
+		{
+			name: "all filters must match",
+			filters: []store.FilterOption{
+				store.Status("completed"),
+				store.ProtectedResource("other"),
+			},
+			expect: 0,
+		},
+	}
+	for _, test := range table {
+		suite.Run(test.name, func() {
+			t := suite.T()
+
+			ctx, flush := tester.NewContext(t)
+			defer flush()
+
+			sm := store.NewWrapper(mock.NewModelStoreMock(&fbu, nil))
+
+			result, err := sm.GetBackups(ctx, test.filters...)
+			require.NoError(t, err, clues.ToCore(err))
+			assert.Len(t, result, test.expect)
+		})
+	}
+}
+
+func (suite *StoreBackupUnitSuite) TestUpdateBackup() {
+	t := suite.T()
+
+	ctx, flush := tester.NewContext(t)
+	defer flush()
+
+	var (
+		ub = bu
+		ms = mock.NewModelStoreMock(&bu, nil)
+		sm = store.NewWrapper(ms)
+	)
+
+	ub.Status = "updated"
+
+	err := sm.UpdateBackup(ctx, &ub)
+	require.NoError(t, err, clues.ToCore(err))
+
+	result, err := sm.GetBackup(ctx, ub.ID)
+	require.NoError(t, err, clues.ToCore(err))
+	assert.Equal(t, "updated", result.Status)
+}
+
 func (suite *StoreBackupUnitSuite) TestDeleteBackup() {
 	table := []struct {
 		name   string
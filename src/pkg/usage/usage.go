@@ -0,0 +1,295 @@
+// Package usage reports the storage consumed by the backups in a repository.
+package usage
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/alcionai/corso/src/internal/common/str"
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/pkg/backup"
+)
+
+// Stats totals the storage used by a set of backups.
+type Stats struct {
+	Backups int `json:"backups"`
+	// LogicalBytes is the size of the backed up data before deduplication
+	// and compression.
+	LogicalBytes int64 `json:"logicalBytes"`
+	// DeduplicatedBytes is the stored size of the data after deduplication,
+	// compression, and encryption.
+	DeduplicatedBytes int64 `json:"deduplicatedBytes"`
+	// UniqueBytes is the stored size of the data that no other backup,
+	// resource, or service references.  It's the space freed by deleting
+	// the backups, once maintenance runs.
+	UniqueBytes int64 `json:"uniqueBytes"`
+}
+
+func (s Stats) values() []string {
+	return []string{
+		strconv.Itoa(s.Backups),
+		humanize.Bytes(uint64(s.LogicalBytes)),
+		humanize.Bytes(uint64(s.DeduplicatedBytes)),
+		humanize.Bytes(uint64(s.UniqueBytes)),
+	}
+}
+
+var statsHeaders = []string{"Backups", "Logical size", "Stored size", "Unique size"}
+
+// BackupUsage is the storage used by a single backup.
+type BackupUsage struct {
+	ID                string `json:"id"`
+	ProtectedResource string `json:"protectedResource"`
+	Service           string `json:"service"`
+	Stats
+}
+
+// MinimumPrintable reduces the usage to its printable fields.
+func (bu BackupUsage) MinimumPrintable() any {
+	return bu
+}
+
+// Headers returns the column names used when printing the usage.
+func (bu BackupUsage) Headers(skipID bool) []string {
+	hs := []string{"ID", "Protected resource", "Service", "Logical size", "Stored size", "Reclaimable"}
+
+	if skipID {
+		hs = hs[1:]
+	}
+
+	return hs
+}
+
+// Values returns the column values used when printing the usage.
+func (bu BackupUsage) Values(skipID bool) []string {
+	vs := []string{
+		bu.ID,
+		bu.ProtectedResource,
+		bu.Service,
+		humanize.Bytes(uint64(bu.LogicalBytes)),
+		humanize.Bytes(uint64(bu.DeduplicatedBytes)),
+		humanize.Bytes(uint64(bu.UniqueBytes)),
+	}
+
+	if skipID {
+		vs = vs[1:]
+	}
+
+	return vs
+}
+
+// GroupUsage is the storage used by every backup of a protected resource
+// or service.
+type GroupUsage struct {
+	Name string `json:"name"`
+	Stats
+}
+
+// MinimumPrintable reduces the usage to its printable fields.
+func (gu GroupUsage) MinimumPrintable() any {
+	return gu
+}
+
+// Headers returns the column names used when printing the usage.
+func (gu GroupUsage) Headers(bool) []string {
+	return append([]string{"Name"}, statsHeaders...)
+}
+
+// Values returns the column values used when printing the usage.
+func (gu GroupUsage) Values(bool) []string {
+	return append([]string{gu.Name}, gu.Stats.values()...)
+}
+
+// Report describes the storage used by the repository and its backups.
+type Report struct {
+	// TotalBytes is the stored size of everything in the repository,
+	// including data that's no longer referenced by any backup.
+	TotalBytes   int64 `json:"totalBytes"`
+	ContentCount int   `json:"contentCount"`
+	// Backed up data across every backup.
+	Stats
+	Resources []GroupUsage  `json:"resources"`
+	Services  []GroupUsage  `json:"services"`
+	Backups   []BackupUsage `json:"backups"`
+}
+
+// MinimumPrintable reduces the report to its printable fields.
+func (r Report) MinimumPrintable() any {
+	return r
+}
+
+// Headers returns the column names used when printing the report totals.
+func (r Report) Headers(bool) []string {
+	return append([]string{"Repository size"}, statsHeaders[:3]...)
+}
+
+// Values returns the column values used when printing the report totals.
+func (r Report) Values(bool) []string {
+	return append([]string{humanize.Bytes(uint64(r.TotalBytes))}, r.Stats.values()[:3]...)
+}
+
+// entry is a backup along with the contents of its snapshots.
+type entry struct {
+	usage    BackupUsage
+	contents map[string]struct{}
+}
+
+// NewReport builds the report for the backups, using the storage usage
+// of the snapshots that hold their data and details.
+func NewReport(bups []*backup.Backup, su *kopia.StorageUsage) Report {
+	r := Report{
+		TotalBytes:   su.TotalBytes,
+		ContentCount: su.ContentCount,
+	}
+
+	entries := make([]entry, 0, len(bups))
+
+	for _, b := range bups {
+		e := entry{
+			usage: BackupUsage{
+				ID: string(b.ID),
+				ProtectedResource: str.First(
+					b.ProtectedResourceName,
+					b.ResourceOwnerName,
+					b.ProtectedResourceID,
+					b.ResourceOwnerID,
+					b.Selector.Name()),
+				Service: b.Selector.PathService().HumanString(),
+			},
+			contents: map[string]struct{}{},
+		}
+
+		for _, sid := range SnapshotIDs(b) {
+			snap, ok := su.Snapshots[sid]
+			if !ok {
+				continue
+			}
+
+			e.usage.LogicalBytes += snap.LogicalBytes
+
+			for _, cid := range snap.Contents {
+				e.contents[cid] = struct{}{}
+			}
+		}
+
+		entries = append(entries, e)
+	}
+
+	backupStats := tally(entries, func(e entry) string { return e.usage.ID }, su.ContentBytes)
+	for _, e := range entries {
+		e.usage.Stats = backupStats[e.usage.ID]
+		r.Backups = append(r.Backups, e.usage)
+	}
+
+	r.Resources = groups(tally(
+		entries,
+		func(e entry) string { return e.usage.ProtectedResource },
+		su.ContentBytes))
+	r.Services = groups(tally(
+		entries,
+		func(e entry) string { return e.usage.Service },
+		su.ContentBytes))
+	r.Stats = tally(entries, func(entry) string { return "" }, su.ContentBytes)[""]
+
+	sort.Slice(r.Backups, func(i, j int) bool {
+		if r.Backups[i].UniqueBytes != r.Backups[j].UniqueBytes {
+			return r.Backups[i].UniqueBytes > r.Backups[j].UniqueBytes
+		}
+
+		return r.Backups[i].ID < r.Backups[j].ID
+	})
+
+	return r
+}
+
+// SnapshotIDs returns the IDs of the snapshots that hold the backup's
+// data and details.
+func SnapshotIDs(b *backup.Backup) []string {
+	ids := []string{}
+
+	if len(b.SnapshotID) > 0 {
+		ids = append(ids, b.SnapshotID)
+	}
+
+	if ssid := str.First(b.StreamStoreID, b.DetailsID); len(ssid) > 0 {
+		ids = append(ids, ssid)
+	}
+
+	return ids
+}
+
+// tally totals the entries that share the same key.  Contents referenced
+// by entries with more than one key are shared, and don't count towards
+// the unique size of any key.
+func tally(
+	entries []entry,
+	keyOf func(entry) string,
+	contentBytes map[string]int64,
+) map[string]Stats {
+	var (
+		res      = map[string]Stats{}
+		contents = map[string]map[string]struct{}{}
+		owners   = map[string]string{}
+		shared   = map[string]struct{}{}
+	)
+
+	for _, e := range entries {
+		key := keyOf(e)
+
+		s := res[key]
+		s.Backups++
+		s.LogicalBytes += e.usage.LogicalBytes
+		res[key] = s
+
+		if contents[key] == nil {
+			contents[key] = map[string]struct{}{}
+		}
+
+		for cid := range e.contents {
+			contents[key][cid] = struct{}{}
+
+			if owner, ok := owners[cid]; ok && owner != key {
+				shared[cid] = struct{}{}
+			}
+
+			owners[cid] = key
+		}
+	}
+
+	for key, cids := range contents {
+		s := res[key]
+
+		for cid := range cids {
+			s.DeduplicatedBytes += contentBytes[cid]
+
+			if _, ok := shared[cid]; !ok {
+				s.UniqueBytes += contentBytes[cid]
+			}
+		}
+
+		res[key] = s
+	}
+
+	return res
+}
+
+// groups flattens the tallies into usage ordered by stored size.
+func groups(tallies map[string]Stats) []GroupUsage {
+	res := make([]GroupUsage, 0, len(tallies))
+
+	for name, s := range tallies {
+		res = append(res, GroupUsage{Name: name, Stats: s})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].DeduplicatedBytes != res[j].DeduplicatedBytes {
+			return res[i].DeduplicatedBytes > res[j].DeduplicatedBytes
+		}
+
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
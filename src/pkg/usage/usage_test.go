@@ -0,0 +1,136 @@
+package usage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/alcionai/corso/src/internal/kopia"
+	"github.com/alcionai/corso/src/internal/model"
+	"github.com/alcionai/corso/src/internal/tester"
+	"github.com/alcionai/corso/src/pkg/backup"
+	"github.com/alcionai/corso/src/pkg/selectors"
+)
+
+type UsageUnitSuite struct {
+	tester.Suite
+}
+
+func TestUsageUnitSuite(t *testing.T) {
+	suite.Run(t, &UsageUnitSuite{Suite: tester.NewUnitSuite(t)})
+}
+
+func stubBackup(id, resource, snapshotID, streamStoreID string, sel selectors.Selector) *backup.Backup {
+	return &backup.Backup{
+		BaseModel:             model.BaseModel{ID: model.StableID(id)},
+		SnapshotID:            snapshotID,
+		StreamStoreID:         streamStoreID,
+		ProtectedResourceName: resource,
+		Selector:              sel,
+	}
+}
+
+func (suite *UsageUnitSuite) TestNewReport() {
+	var (
+		t        = suite.T()
+		exchange = selectors.NewExchangeBackup([]string{"alice"}).Selector
+		onedrive = selectors.NewOneDriveBackup([]string{"bob"}).Selector
+		bups     = []*backup.Backup{
+			stubBackup("b1", "alice", "s1", "d1", exchange),
+			// the details snapshot of b2 no longer exists.
+			stubBackup("b2", "alice", "s2", "d2", exchange),
+			stubBackup("b3", "bob", "s3", "", onedrive),
+		}
+		su = &kopia.StorageUsage{
+			TotalBytes:   1000,
+			ContentCount: 9,
+			ContentBytes: map[string]int64{
+				"a": 100,
+				"b": 50,
+				"c": 30,
+				"d": 10,
+				"e": 5,
+			},
+			Snapshots: map[string]kopia.SnapshotUsage{
+				"s1": {LogicalBytes: 1000, Contents: []string{"a", "b"}},
+				"d1": {LogicalBytes: 20, Contents: []string{"d"}},
+				"s2": {LogicalBytes: 900, Contents: []string{"a", "c"}},
+				"s3": {LogicalBytes: 300, Contents: []string{"c", "e"}},
+			},
+		}
+	)
+
+	r := NewReport(bups, su)
+
+	assert.Equal(t, int64(1000), r.TotalBytes)
+	assert.Equal(t, 9, r.ContentCount)
+	assert.Equal(
+		t,
+		Stats{Backups: 3, LogicalBytes: 2220, DeduplicatedBytes: 195, UniqueBytes: 195},
+		r.Stats)
+
+	assert.Equal(
+		t,
+		[]BackupUsage{
+			{
+				ID:                "b1",
+				ProtectedResource: "alice",
+				Service:           "Exchange",
+				Stats:             Stats{Backups: 1, LogicalBytes: 1020, DeduplicatedBytes: 160, UniqueBytes: 60},
+			},
+			{
+				ID:                "b3",
+				ProtectedResource: "bob",
+				Service:           "OneDrive",
+				Stats:             Stats{Backups: 1, LogicalBytes: 300, DeduplicatedBytes: 35, UniqueBytes: 5},
+			},
+			{
+				ID:                "b2",
+				ProtectedResource: "alice",
+				Service:           "Exchange",
+				Stats:             Stats{Backups: 1, LogicalBytes: 900, DeduplicatedBytes: 130},
+			},
+		},
+		r.Backups)
+
+	expectGroups := func(a, b string) []GroupUsage {
+		return []GroupUsage{
+			{
+				Name:  a,
+				Stats: Stats{Backups: 2, LogicalBytes: 1920, DeduplicatedBytes: 190, UniqueBytes: 160},
+			},
+			{
+				Name:  b,
+				Stats: Stats{Backups: 1, LogicalBytes: 300, DeduplicatedBytes: 35, UniqueBytes: 5},
+			},
+		}
+	}
+
+	assert.Equal(t, expectGroups("alice", "bob"), r.Resources)
+	assert.Equal(t, expectGroups("Exchange", "OneDrive"), r.Services)
+}
+
+func (suite *UsageUnitSuite) TestNewReport_noBackups() {
+	t := suite.T()
+
+	r := NewReport(nil, &kopia.StorageUsage{TotalBytes: 42})
+
+	assert.Equal(t, int64(42), r.TotalBytes)
+	assert.Equal(t, Stats{}, r.Stats)
+	assert.Empty(t, r.Backups)
+	assert.Empty(t, r.Resources)
+	assert.Empty(t, r.Services)
+}
+
+func (suite *UsageUnitSuite) TestSnapshotIDs() {
+	t := suite.T()
+
+	current := &backup.Backup{SnapshotID: "s", StreamStoreID: "ss"}
+	legacy := &backup.Backup{SnapshotID: "s", DetailsID: "d"}
+	noSnapshot := &backup.Backup{StreamStoreID: "ss"}
+
+	assert.Equal(t, []string{"s", "ss"}, SnapshotIDs(current))
+	assert.Equal(t, []string{"s", "d"}, SnapshotIDs(legacy))
+	assert.Equal(t, []string{"ss"}, SnapshotIDs(noSnapshot))
+}